@@ -41,6 +41,7 @@ import (
 	"github.com/coder/coder/v2/agent/agentcontainers"
 	"github.com/coder/coder/v2/agent/agentexec"
 	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/agent/agentrecord"
 	"github.com/coder/coder/v2/agent/agentscripts"
 	"github.com/coder/coder/v2/agent/agentsocket"
 	"github.com/coder/coder/v2/agent/agentssh"
@@ -48,6 +49,7 @@ import (
 	"github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/agent/proto/resourcesmonitor"
 	"github.com/coder/coder/v2/agent/reconnectingpty"
+	"github.com/coder/coder/v2/agent/unit"
 	"github.com/coder/coder/v2/buildinfo"
 	"github.com/coder/coder/v2/cli/gitauth"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
@@ -104,12 +106,13 @@ type Options struct {
 	Clock                        quartz.Clock
 	SocketServerEnabled          bool
 	SocketPath                   string // Path for the agent socket server socket
+	SocketJournalEnabled         bool   // Persist socket unit state in ScriptDataDir across agent restarts
 	BoundaryLogProxySocketPath   string
 }
 
 type Client interface {
-	ConnectRPC28(ctx context.Context) (
-		proto.DRPCAgentClient28, tailnetproto.DRPCTailnetClient28, error,
+	ConnectRPC210(ctx context.Context) (
+		proto.DRPCAgentClient210, tailnetproto.DRPCTailnetClient210, error,
 	)
 	tailnet.DERPMapRewriter
 	agentsdk.RefreshableSessionTokenProvider
@@ -212,6 +215,7 @@ func New(options Options) Agent {
 		containerAPIOptions:        options.DevcontainerAPIOptions,
 		socketPath:                 options.SocketPath,
 		socketServerEnabled:        options.SocketServerEnabled,
+		socketJournalEnabled:       options.SocketJournalEnabled,
 		boundaryLogProxySocketPath: options.BoundaryLogProxySocketPath,
 	}
 	// Initially, we have a closed channel, reflecting the fact that we are not initially connected.
@@ -279,6 +283,8 @@ type agent struct {
 	reportConnectionsMu     sync.Mutex
 	reportConnections       []*proto.ReportConnectionRequest
 
+	sessionRecorder *agentrecord.Manager
+
 	logSender *agentsdk.LogSender
 
 	// boundaryLogProxy is a socket server that forwards boundary audit logs to coderd.
@@ -298,9 +304,17 @@ type agent struct {
 
 	filesAPI *agentfiles.API
 
-	socketServerEnabled bool
-	socketPath          string
-	socketServer        *agentsocket.Server
+	socketServerEnabled  bool
+	socketJournalEnabled bool
+	socketPath           string
+	socketServer         *agentsocket.Server
+	unitJournal          *unit.Journal
+
+	// unitManager tracks dependencies between startup units declared via
+	// the agent socket.
+	unitManager   *unit.Manager
+	failedUnitsMu sync.Mutex
+	failedUnits   []unit.ID
 }
 
 func (a *agent) TailnetConn() *tailnet.Conn {
@@ -310,6 +324,21 @@ func (a *agent) TailnetConn() *tailnet.Conn {
 }
 
 func (a *agent) init() {
+	a.sessionRecorder = agentrecord.NewManager(agentrecord.Options{
+		Logger: a.logger.Named("session-recorder"),
+		Config: func() agentrecord.Config {
+			manifest := a.manifest.Load()
+			if manifest == nil {
+				return agentrecord.Config{}
+			}
+			return agentrecord.Config{
+				Enabled:      manifest.SessionRecordingEnabled,
+				IncludeInput: manifest.SessionRecordingIncludeInput,
+			}
+		},
+		Dir: a.tempDir,
+	})
+
 	// pass the "hard" context because we explicitly close the SSH server as part of graceful shutdown.
 	sshSrv, err := agentssh.NewServer(a.hardCtx, a.logger.Named("ssh-server"), a.prometheusRegistry, a.filesystem, a.execer, &agentssh.Config{
 		MaxTimeout:          a.sshMaxTimeout,
@@ -319,22 +348,10 @@ func (a *agent) init() {
 		WorkingDirectory:    func() string { return a.manifest.Load().Directory },
 		BlockFileTransfer:   a.blockFileTransfer,
 		ReportConnection: func(id uuid.UUID, magicType agentssh.MagicSessionType, ip string) func(code int, reason string) {
-			var connectionType proto.Connection_Type
-			switch magicType {
-			case agentssh.MagicSessionTypeSSH:
-				connectionType = proto.Connection_SSH
-			case agentssh.MagicSessionTypeVSCode:
-				connectionType = proto.Connection_VSCODE
-			case agentssh.MagicSessionTypeJetBrains:
-				connectionType = proto.Connection_JETBRAINS
-			case agentssh.MagicSessionTypeUnknown:
-				connectionType = proto.Connection_TYPE_UNSPECIFIED
-			default:
-				a.logger.Error(a.hardCtx, "unhandled magic session type when reporting connection", slog.F("magic_type", magicType))
-				connectionType = proto.Connection_TYPE_UNSPECIFIED
-			}
-
-			return a.reportConnection(id, connectionType, ip)
+			return a.reportConnection(id, a.connectionTypeFromMagicType(magicType), ip)
+		},
+		StartRecording: func(id uuid.UUID, magicType agentssh.MagicSessionType, width, height int, command string) *agentrecord.Recorder {
+			return a.sessionRecorder.Start(id, a.connectionTypeFromMagicType(magicType), width, height, command)
 		},
 
 		ExperimentalContainers: a.devcontainers,
@@ -343,6 +360,11 @@ func (a *agent) init() {
 		panic(err)
 	}
 	a.sshServer = sshSrv
+
+	// The socket server owns the unit manager, which the script runner
+	// shares so that scripts and `coder exp sync` see the same units.
+	a.initSocketServer()
+
 	a.scriptRunner = agentscripts.New(agentscripts.Options{
 		LogDir:      a.logDir,
 		DataDirBase: a.scriptDataDir,
@@ -352,6 +374,7 @@ func (a *agent) init() {
 		GetScriptLogger: func(logSourceID uuid.UUID) agentscripts.ScriptLogger {
 			return a.logSender.GetScriptLogger(logSourceID)
 		},
+		UnitManager: a.unitManager,
 	})
 	// Register runner metrics. If the prom registry is nil, the metrics
 	// will not report anywhere.
@@ -368,7 +391,7 @@ func (a *agent) init() {
 
 	a.containerAPI = agentcontainers.NewAPI(a.logger.Named("containers"), containerAPIOpts...)
 
-	a.filesAPI = agentfiles.NewAPI(a.logger.Named("files"), a.filesystem)
+	a.filesAPI = agentfiles.NewAPI(a.logger.Named("files"), a.filesystem, agentfiles.WithClock(a.clock))
 
 	a.reconnectingPTYServer = reconnectingpty.NewServer(
 		a.logger.Named("reconnecting-pty"),
@@ -380,10 +403,12 @@ func (a *agent) init() {
 		a.reconnectingPTYTimeout,
 		func(s *reconnectingpty.Server) {
 			s.ExperimentalContainers = a.devcontainers
+			s.StartRecording = func(id uuid.UUID, width, height int, command string) *agentrecord.Recorder {
+				return a.sessionRecorder.Start(id, proto.Connection_RECONNECTING_PTY, width, height, command)
+			}
 		},
 	)
 
-	a.initSocketServer()
 	a.startBoundaryLogProxyServer()
 
 	go a.runLoop()
@@ -396,9 +421,28 @@ func (a *agent) initSocketServer() {
 		return
 	}
 
+	unitOpts := []unit.ManagerOption{
+		unit.WithClock(a.clock),
+		unit.WithFailureCallback(a.reportUnitFailure),
+	}
+	if a.socketJournalEnabled {
+		journal, err := unit.OpenJournal(a.scriptDataDir)
+		if err != nil {
+			// Startup coordination still works without persistence, so
+			// continue with in-memory state only.
+			a.logger.Warn(a.hardCtx, "failed to open unit journal", slog.Error(err), slog.F("dir", a.scriptDataDir))
+		} else {
+			a.unitJournal = journal
+			unitOpts = append(unitOpts, unit.WithJournal(journal))
+			a.logger.Debug(a.hardCtx, "unit journal opened", slog.F("path", journal.Path()))
+		}
+	}
+	a.unitManager = unit.NewManager(unitOpts...)
+
 	server, err := agentsocket.NewServer(
 		a.logger.Named("socket"),
 		agentsocket.WithPath(a.socketPath),
+		agentsocket.WithUnitManager(a.unitManager),
 	)
 	if err != nil {
 		a.logger.Warn(a.hardCtx, "failed to create socket server", slog.Error(err), slog.F("path", a.socketPath))
@@ -409,6 +453,49 @@ func (a *agent) initSocketServer() {
 	a.logger.Debug(a.hardCtx, "socket server started", slog.F("path", a.socketPath))
 }
 
+// reportUnitFailure records a failed unit so that the startup lifecycle
+// reflects it, and surfaces the failure in the workspace startup logs.
+func (a *agent) reportUnitFailure(id unit.ID, reason string) {
+	a.logger.Warn(a.hardCtx, "unit failed", slog.F("unit", id), slog.F("reason", reason))
+
+	a.failedUnitsMu.Lock()
+	if !slices.Contains(a.failedUnits, id) {
+		a.failedUnits = append(a.failedUnits, id)
+	}
+	a.failedUnitsMu.Unlock()
+
+	output := fmt.Sprintf("Unit %q failed", id)
+	if reason != "" {
+		output += ": " + reason
+	}
+	a.logSender.Enqueue(agentsdk.ExternalLogSourceID, agentsdk.Log{
+		CreatedAt: a.clock.Now(),
+		Output:    output,
+		Level:     codersdk.LogLevelError,
+	})
+	a.logSender.Flush(agentsdk.ExternalLogSourceID)
+}
+
+// unitFailureError returns an error naming the units that are failed, or nil
+// if none are. Units that failed but have since recovered, e.g. by being
+// started again and completing, are not reported.
+func (a *agent) unitFailureError() error {
+	a.failedUnitsMu.Lock()
+	defer a.failedUnitsMu.Unlock()
+
+	var failed []unit.ID
+	for _, id := range a.failedUnits {
+		u, err := a.unitManager.Unit(id)
+		if err == nil && u.Status() == unit.StatusFailed {
+			failed = append(failed, id)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return xerrors.Errorf("units failed: %v", failed)
+}
+
 // startBoundaryLogProxyServer starts the boundary log proxy socket server.
 func (a *agent) startBoundaryLogProxyServer() {
 	proxy := boundarylogproxy.NewServer(a.logger, a.boundaryLogProxySocketPath)
@@ -533,7 +620,7 @@ func (t *trySingleflight) Do(key string, fn func()) {
 	fn()
 }
 
-func (a *agent) reportMetadata(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+func (a *agent) reportMetadata(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 	tickerDone := make(chan struct{})
 	collectDone := make(chan struct{})
 	ctx, cancel := context.WithCancel(ctx)
@@ -748,7 +835,7 @@ func (a *agent) reportMetadata(ctx context.Context, aAPI proto.DRPCAgentClient28
 
 // reportLifecycle reports the current lifecycle state once. All state
 // changes are reported in order.
-func (a *agent) reportLifecycle(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+func (a *agent) reportLifecycle(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 	for {
 		select {
 		case <-a.lifecycleUpdate:
@@ -827,8 +914,26 @@ func (a *agent) setLifecycle(state codersdk.WorkspaceAgentLifecycle) {
 	}
 }
 
+// connectionTypeFromMagicType maps the type of an SSH session to the type its
+// connection is reported and recorded as.
+func (a *agent) connectionTypeFromMagicType(magicType agentssh.MagicSessionType) proto.Connection_Type {
+	switch magicType {
+	case agentssh.MagicSessionTypeSSH:
+		return proto.Connection_SSH
+	case agentssh.MagicSessionTypeVSCode:
+		return proto.Connection_VSCODE
+	case agentssh.MagicSessionTypeJetBrains:
+		return proto.Connection_JETBRAINS
+	case agentssh.MagicSessionTypeUnknown:
+		return proto.Connection_TYPE_UNSPECIFIED
+	default:
+		a.logger.Error(a.hardCtx, "unhandled magic session type when reporting connection", slog.F("magic_type", magicType))
+		return proto.Connection_TYPE_UNSPECIFIED
+	}
+}
+
 // reportConnectionsLoop reports connections to the agent for auditing.
-func (a *agent) reportConnectionsLoop(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+func (a *agent) reportConnectionsLoop(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 	for {
 		select {
 		case <-a.reportConnectionsUpdate:
@@ -963,7 +1068,7 @@ func (a *agent) reportConnection(id uuid.UUID, connectionType proto.Connection_T
 // fetchServiceBannerLoop fetches the service banner on an interval.  It will
 // not be fetched immediately; the expectation is that it is primed elsewhere
 // (and must be done before the session actually starts).
-func (a *agent) fetchServiceBannerLoop(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+func (a *agent) fetchServiceBannerLoop(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 	ticker := time.NewTicker(a.announcementBannersRefreshInterval)
 	defer ticker.Stop()
 	for {
@@ -998,7 +1103,7 @@ func (a *agent) run() (retErr error) {
 	}
 
 	// ConnectRPC returns the dRPC connection we use for the Agent and Tailnet v2+ APIs
-	aAPI, tAPI, err := a.client.ConnectRPC28(a.hardCtx)
+	aAPI, tAPI, err := a.client.ConnectRPC210(a.hardCtx)
 	if err != nil {
 		return err
 	}
@@ -1015,7 +1120,7 @@ func (a *agent) run() (retErr error) {
 	connMan := newAPIConnRoutineManager(a.gracefulCtx, a.hardCtx, a.logger, aAPI, tAPI)
 
 	connMan.startAgentAPI("init notification banners", gracefulShutdownBehaviorStop,
-		func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+		func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 			bannersProto, err := aAPI.GetAnnouncementBanners(ctx, &proto.GetAnnouncementBannersRequest{})
 			if err != nil {
 				return xerrors.Errorf("fetch service banner: %w", err)
@@ -1032,7 +1137,7 @@ func (a *agent) run() (retErr error) {
 	// sending logs gets gracefulShutdownBehaviorRemain because we want to send logs generated by
 	// shutdown scripts.
 	connMan.startAgentAPI("send logs", gracefulShutdownBehaviorRemain,
-		func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+		func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 			err := a.logSender.SendLoop(ctx, aAPI)
 			if xerrors.Is(err, agentsdk.ErrLogLimitExceeded) {
 				// we don't want this error to tear down the API connection and propagate to the
@@ -1046,7 +1151,7 @@ func (a *agent) run() (retErr error) {
 	// Forward boundary audit logs to coderd if boundary log forwarding is enabled.
 	// These are audit logs so they should continue during graceful shutdown.
 	if a.boundaryLogProxy != nil {
-		proxyFunc := func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+		proxyFunc := func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 			return a.boundaryLogProxy.RunForwarder(ctx, aAPI)
 		}
 		connMan.startAgentAPI("boundary log proxy", gracefulShutdownBehaviorRemain, proxyFunc)
@@ -1060,7 +1165,7 @@ func (a *agent) run() (retErr error) {
 	connMan.startAgentAPI("report metadata", gracefulShutdownBehaviorStop, a.reportMetadata)
 
 	// resources monitor can cease as soon as we start gracefully shutting down.
-	connMan.startAgentAPI("resources monitor", gracefulShutdownBehaviorStop, func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+	connMan.startAgentAPI("resources monitor", gracefulShutdownBehaviorStop, func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 		logger := a.logger.Named("resources_monitor")
 		clk := quartz.NewReal()
 		config, err := aAPI.GetResourcesMonitoringConfiguration(ctx, &proto.GetResourcesMonitoringConfigurationRequest{})
@@ -1085,6 +1190,12 @@ func (a *agent) run() (retErr error) {
 	// gracefulShutdownBehaviorRemain.
 	connMan.startAgentAPI("report connections", gracefulShutdownBehaviorRemain, a.reportConnectionsLoop)
 
+	// Session recordings are part of auditing too, and are only uploaded once
+	// the session ends, which may be during graceful shutdown.
+	connMan.startAgentAPI("upload session recordings", gracefulShutdownBehaviorRemain, func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
+		return a.sessionRecorder.Run(ctx, aAPI)
+	})
+
 	// channels to sync goroutines below
 	//  handle manifest
 	//       |
@@ -1107,7 +1218,7 @@ func (a *agent) run() (retErr error) {
 	connMan.startAgentAPI("handle manifest", gracefulShutdownBehaviorStop, a.handleManifest(manifestOK))
 
 	connMan.startAgentAPI("app health reporter", gracefulShutdownBehaviorStop,
-		func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+		func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 			if err := manifestOK.wait(ctx); err != nil {
 				return xerrors.Errorf("no manifest: %w", err)
 			}
@@ -1140,7 +1251,7 @@ func (a *agent) run() (retErr error) {
 
 	connMan.startAgentAPI("fetch service banner loop", gracefulShutdownBehaviorStop, a.fetchServiceBannerLoop)
 
-	connMan.startAgentAPI("stats report loop", gracefulShutdownBehaviorStop, func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+	connMan.startAgentAPI("stats report loop", gracefulShutdownBehaviorStop, func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 		if err := networkOK.wait(ctx); err != nil {
 			return xerrors.Errorf("no network: %w", err)
 		}
@@ -1155,8 +1266,8 @@ func (a *agent) run() (retErr error) {
 }
 
 // handleManifest returns a function that fetches and processes the manifest
-func (a *agent) handleManifest(manifestOK *checkpoint) func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
-	return func(ctx context.Context, aAPI proto.DRPCAgentClient28) error {
+func (a *agent) handleManifest(manifestOK *checkpoint) func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
+	return func(ctx context.Context, aAPI proto.DRPCAgentClient210) error {
 		var (
 			sentResult = false
 			err        error
@@ -1290,6 +1401,13 @@ func (a *agent) handleManifest(manifestOK *checkpoint) func(ctx context.Context,
 					}
 				}
 
+				// Units that failed while the startup scripts were running
+				// indicate a broken startup even if the scripts themselves
+				// exited successfully.
+				if err == nil {
+					err = a.unitFailureError()
+				}
+
 				dur := time.Since(start).Seconds()
 				if err != nil {
 					a.logger.Warn(ctx, "startup script(s) failed", slog.Error(err))
@@ -1319,7 +1437,7 @@ func (a *agent) handleManifest(manifestOK *checkpoint) func(ctx context.Context,
 
 func (a *agent) createDevcontainer(
 	ctx context.Context,
-	aAPI proto.DRPCAgentClient28,
+	aAPI proto.DRPCAgentClient210,
 	dc codersdk.WorkspaceAgentDevcontainer,
 	script codersdk.WorkspaceAgentScript,
 ) (err error) {
@@ -1351,8 +1469,8 @@ func (a *agent) createDevcontainer(
 
 // createOrUpdateNetwork waits for the manifest to be set using manifestOK, then creates or updates
 // the tailnet using the information in the manifest
-func (a *agent) createOrUpdateNetwork(manifestOK, networkOK *checkpoint) func(context.Context, proto.DRPCAgentClient28) error {
-	return func(ctx context.Context, aAPI proto.DRPCAgentClient28) (retErr error) {
+func (a *agent) createOrUpdateNetwork(manifestOK, networkOK *checkpoint) func(context.Context, proto.DRPCAgentClient210) error {
+	return func(ctx context.Context, aAPI proto.DRPCAgentClient210) (retErr error) {
 		if err := manifestOK.wait(ctx); err != nil {
 			return xerrors.Errorf("no manifest: %w", err)
 		}
@@ -2018,6 +2136,12 @@ func (a *agent) Close() error {
 		}
 	}
 
+	if a.unitJournal != nil {
+		if err := a.unitJournal.Close(); err != nil {
+			a.logger.Error(a.hardCtx, "unit journal close", slog.Error(err))
+		}
+	}
+
 	if err := a.containerAPI.Close(); err != nil {
 		a.logger.Error(a.hardCtx, "container API close", slog.Error(err))
 	}
@@ -2146,8 +2270,8 @@ const (
 
 type apiConnRoutineManager struct {
 	logger    slog.Logger
-	aAPI      proto.DRPCAgentClient28
-	tAPI      tailnetproto.DRPCTailnetClient28
+	aAPI      proto.DRPCAgentClient210
+	tAPI      tailnetproto.DRPCTailnetClient210
 	eg        *errgroup.Group
 	stopCtx   context.Context
 	remainCtx context.Context
@@ -2155,7 +2279,7 @@ type apiConnRoutineManager struct {
 
 func newAPIConnRoutineManager(
 	gracefulCtx, hardCtx context.Context, logger slog.Logger,
-	aAPI proto.DRPCAgentClient28, tAPI tailnetproto.DRPCTailnetClient28,
+	aAPI proto.DRPCAgentClient210, tAPI tailnetproto.DRPCTailnetClient210,
 ) *apiConnRoutineManager {
 	// routines that remain in operation during graceful shutdown use the remainCtx.  They'll still
 	// exit if the errgroup hits an error, which usually means a problem with the conn.
@@ -2188,7 +2312,7 @@ func newAPIConnRoutineManager(
 // but for Tailnet.
 func (a *apiConnRoutineManager) startAgentAPI(
 	name string, behavior gracefulShutdownBehavior,
-	f func(context.Context, proto.DRPCAgentClient28) error,
+	f func(context.Context, proto.DRPCAgentClient210) error,
 ) {
 	logger := a.logger.With(slog.F("name", name))
 	var ctx context.Context
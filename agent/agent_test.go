@@ -46,10 +46,12 @@ import (
 	"cdr.dev/slog/v3/sloggers/slogtest"
 	"github.com/coder/coder/v2/agent"
 	"github.com/coder/coder/v2/agent/agentcontainers"
+	"github.com/coder/coder/v2/agent/agentsocket"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/agenttest"
 	"github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/agent/usershell"
+	"github.com/coder/coder/v2/asciicast"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
 	"github.com/coder/coder/v2/codersdk/workspacesdk"
@@ -1566,6 +1568,73 @@ func TestAgent_Lifecycle(t *testing.T) {
 		require.Equal(t, want, got[:len(want)])
 	})
 
+	t.Run("UnitFailure", func(t *testing.T) {
+		t.Parallel()
+
+		if runtime.GOOS == "windows" {
+			t.Skip("agentsocket is not supported on Windows")
+		}
+
+		for _, tc := range []struct {
+			name    string
+			recover bool
+			want    codersdk.WorkspaceAgentLifecycle
+		}{
+			{name: "Failed", want: codersdk.WorkspaceAgentLifecycleStartError},
+			{name: "Recovered", recover: true, want: codersdk.WorkspaceAgentLifecycleReady},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctx := testutil.Context(t, testutil.WaitLong)
+				socketPath := filepath.Join(testutil.TempDirUnixSocket(t), "agent.sock")
+				done := filepath.Join(t.TempDir(), "done")
+
+				// The startup script keeps running until the unit has
+				// been handled.
+				_, client, _, _, _ := setupAgent(t, agentsdk.Manifest{
+					Scripts: []codersdk.WorkspaceAgentScript{{
+						Script:     fmt.Sprintf("while [ ! -f %q ]; do sleep 0.1; done", done),
+						Timeout:    30 * time.Second,
+						RunOnStart: true,
+					}},
+				}, 0, func(_ *agenttest.Client, o *agent.Options) {
+					o.SocketServerEnabled = true
+					o.SocketPath = socketPath
+				})
+
+				var sock *agentsocket.Client
+				require.Eventually(t, func() bool {
+					c, err := agentsocket.NewClient(ctx, agentsocket.WithPath(socketPath))
+					if err != nil {
+						return false
+					}
+					if err := c.Ping(ctx); err != nil {
+						_ = c.Close()
+						return false
+					}
+					sock = c
+					return true
+				}, testutil.WaitShort, testutil.IntervalFast)
+				defer sock.Close()
+
+				require.NoError(t, sock.SyncFail(ctx, "db", "connection refused"))
+				if tc.recover {
+					require.NoError(t, sock.SyncStart(ctx, "db"))
+					require.NoError(t, sock.SyncComplete(ctx, "db"))
+				}
+				require.NoError(t, os.WriteFile(done, nil, 0o600))
+
+				var got []codersdk.WorkspaceAgentLifecycle
+				require.Eventually(t, func() bool {
+					got = client.GetLifecycleStates()
+					return len(got) > 0 && got[len(got)-1] == tc.want
+				}, testutil.WaitShort, testutil.IntervalMedium)
+				require.Equal(t, []codersdk.WorkspaceAgentLifecycle{codersdk.WorkspaceAgentLifecycleStarting, tc.want}, got)
+			})
+		}
+	})
+
 	t.Run("Ready", func(t *testing.T) {
 		t.Parallel()
 
@@ -1992,6 +2061,114 @@ func TestAgent_ReconnectingPTY(t *testing.T) {
 	}
 }
 
+func TestAgent_ReconnectingPTYSessions(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("ConPTY appears to be inconsistent on Windows.")
+	}
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	//nolint:dogsled
+	conn, _, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+	id := uuid.New()
+	buffered := func(init *workspacesdk.AgentReconnectingPTYInit) {
+		init.BackendType = "buffered"
+	}
+
+	// Read-only connections cannot start new sessions.
+	netConn0, err := conn.ReconnectingPTY(ctx, id, 80, 80, "bash --norc", buffered, workspacesdk.AgentReconnectingPTYInitWithReadOnly())
+	require.NoError(t, err)
+	defer netConn0.Close()
+	require.ErrorIs(t, testutil.NewTerminalReader(t, netConn0).ReadUntil(ctx, nil), io.EOF)
+
+	netConn1, err := conn.ReconnectingPTY(ctx, id, 80, 80, "bash --norc", buffered)
+	require.NoError(t, err)
+	defer netConn1.Close()
+	tr1 := testutil.NewTerminalReader(t, netConn1)
+
+	matchPrompt := func(line string) bool {
+		return strings.Contains(line, "$ ") || strings.Contains(line, "# ")
+	}
+	require.NoError(t, tr1.ReadUntil(ctx, matchPrompt), "find prompt")
+
+	// The read-only connection asks for a different size, which must not be
+	// applied to the pty.
+	netConn2, err := conn.ReconnectingPTY(ctx, id, 40, 200, "bash --norc", buffered, workspacesdk.AgentReconnectingPTYInitWithReadOnly())
+	require.NoError(t, err)
+	defer netConn2.Close()
+	tr2 := testutil.NewTerminalReader(t, netConn2)
+
+	var session workspacesdk.ReconnectingPTYSession
+	require.Eventually(t, func() bool {
+		res, err := conn.ReconnectingPTYSessions(ctx)
+		if !assert.NoError(t, err) || len(res.Sessions) != 1 {
+			return false
+		}
+		session = res.Sessions[0]
+		return len(session.Connections) == 2
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.Equal(t, id, session.ID)
+	require.Equal(t, "bash --norc", session.Command)
+	require.False(t, session.StartedAt.IsZero())
+	require.False(t, session.LastActivityAt.Before(session.StartedAt))
+	readOnly := 0
+	for _, c := range session.Connections {
+		if c.ReadOnly {
+			readOnly++
+		}
+	}
+	require.Equal(t, 1, readOnly)
+
+	// Input from the read-only connection is dropped while input from the
+	// other connection is visible to both.
+	for _, input := range []struct {
+		conn net.Conn
+		data string
+	}{
+		{netConn2, "echo readonly-input\r"},
+		{netConn1, "echo writer-input\r"},
+	} {
+		data, err := json.Marshal(workspacesdk.ReconnectingPTYRequest{Data: input.data})
+		require.NoError(t, err)
+		_, err = input.conn.Write(data)
+		require.NoError(t, err)
+	}
+	sawReadOnlyInput := false
+	matchWriterOutput := func(line string) bool {
+		if strings.Contains(line, "readonly-input") {
+			sawReadOnlyInput = true
+		}
+		return strings.Contains(line, "writer-input") && !strings.Contains(line, "echo")
+	}
+	require.NoError(t, tr1.ReadUntil(ctx, matchWriterOutput), "find writer output")
+	require.NoError(t, tr2.ReadUntil(ctx, matchWriterOutput), "find writer output")
+	require.False(t, sawReadOnlyInput, "read-only input was written to the pty")
+
+	// The pty keeps the size of the writer.
+	data, err := json.Marshal(workspacesdk.ReconnectingPTYRequest{Data: "stty size\r"})
+	require.NoError(t, err)
+	_, err = netConn1.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, tr1.ReadUntil(ctx, func(line string) bool {
+		return strings.TrimSpace(line) == "80 80"
+	}), "find pty size")
+
+	err = conn.KillReconnectingPTY(ctx, id)
+	require.NoError(t, err)
+	require.ErrorIs(t, tr1.ReadUntil(ctx, nil), io.EOF)
+	require.ErrorIs(t, tr2.ReadUntil(ctx, nil), io.EOF)
+
+	res, err := conn.ReconnectingPTYSessions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, res.Sessions)
+
+	err = conn.KillReconnectingPTY(ctx, id)
+	var sdkErr *codersdk.Error
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+}
+
 // This tests end-to-end functionality of connecting to a running container
 // and executing a command. It creates a real Docker container and runs a
 // command. As such, it does not run by default in CI.
@@ -3684,6 +3861,135 @@ func TestAgent_Metrics_SSH(t *testing.T) {
 	require.NoError(t, err, "waiting for session to exit")
 }
 
+func TestAgent_SessionRecording(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("ConPTY appears to be inconsistent on Windows.")
+	}
+
+	manifest := agentsdk.Manifest{
+		SessionRecordingEnabled:      true,
+		SessionRecordingIncludeInput: true,
+	}
+
+	// requireRecording waits for the recording of the reported connection and
+	// returns its events.
+	requireRecording := func(t *testing.T, agentClient *agenttest.Client, connectionType proto.Connection_Type) (*proto.UploadSessionRecordingRequest, []asciicast.Event) {
+		t.Helper()
+		var recordings []*proto.UploadSessionRecordingRequest
+		require.Eventually(t, func() bool {
+			recordings = agentClient.GetSessionRecordings()
+			return len(recordings) > 0
+		}, testutil.WaitMedium, testutil.IntervalFast)
+		require.Len(t, recordings, 1)
+		rec := recordings[0]
+		require.Equal(t, connectionType, rec.GetType())
+		require.True(t, rec.GetIncludeInput())
+
+		reports := agentClient.GetConnectionReports()
+		require.NotEmpty(t, reports)
+		require.Equal(t, reports[0].GetConnection().GetId(), rec.GetConnectionId(), "recording is tied to the reported connection")
+
+		r, _, err := asciicast.NewReader(bytes.NewReader(rec.GetData()))
+		require.NoError(t, err)
+		var events []asciicast.Event
+		for {
+			ev, err := r.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			events = append(events, ev)
+		}
+		return rec, events
+	}
+	containsEvent := func(events []asciicast.Event, typ asciicast.EventType, substr string) bool {
+		var data strings.Builder
+		for _, ev := range events {
+			if ev.Type == typ {
+				_, _ = data.WriteString(ev.Data)
+			}
+		}
+		return strings.Contains(data.String(), substr)
+	}
+
+	t.Run("SSH", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		//nolint:dogsled
+		conn, agentClient, _, _, _ := setupAgent(t, manifest, 0)
+		sshClient, err := conn.SSHClient(ctx)
+		require.NoError(t, err)
+		defer sshClient.Close()
+		session, err := sshClient.NewSession()
+		require.NoError(t, err)
+		defer session.Close()
+
+		err = session.RequestPty("xterm", 24, 80, ssh.TerminalModes{})
+		require.NoError(t, err)
+		ptty := ptytest.New(t)
+		session.Stdout = ptty.Output()
+		session.Stderr = ptty.Output()
+		session.Stdin = ptty.Input()
+		err = session.Start("sh")
+		require.NoError(t, err)
+		_ = ptty.Peek(ctx, 1) // wait for the prompt
+		ptty.WriteLine("echo recorded")
+		ptty.ExpectMatch("recorded")
+		ptty.WriteLine("exit")
+		err = session.Wait()
+		require.NoError(t, err)
+
+		_, events := requireRecording(t, agentClient, proto.Connection_SSH)
+		require.True(t, containsEvent(events, asciicast.EventTypeOutput, "recorded"))
+		require.True(t, containsEvent(events, asciicast.EventTypeInput, "echo recorded"))
+	})
+
+	t.Run("ReconnectingPTY", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		//nolint:dogsled
+		conn, agentClient, _, _, _ := setupAgent(t, manifest, 0)
+		netConn, err := conn.ReconnectingPTY(ctx, uuid.New(), 24, 80, "bash --norc")
+		require.NoError(t, err)
+		defer netConn.Close()
+		tr := testutil.NewTerminalReader(t, netConn)
+
+		data, err := json.Marshal(workspacesdk.ReconnectingPTYRequest{
+			Data:   "echo recorded\r",
+			Height: 30,
+			Width:  100,
+		})
+		require.NoError(t, err)
+		_, err = netConn.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, tr.ReadUntil(ctx, func(line string) bool {
+			return strings.Contains(line, "recorded") && !strings.Contains(line, "echo")
+		}), "find echo output")
+		_ = netConn.Close()
+
+		_, events := requireRecording(t, agentClient, proto.Connection_RECONNECTING_PTY)
+		require.True(t, containsEvent(events, asciicast.EventTypeOutput, "recorded"))
+		require.True(t, containsEvent(events, asciicast.EventTypeInput, "echo recorded"))
+		require.True(t, containsEvent(events, asciicast.EventTypeResize, "100x30"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		//nolint:dogsled
+		conn, agentClient, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+		netConn, err := conn.ReconnectingPTY(ctx, uuid.New(), 24, 80, "bash --norc")
+		require.NoError(t, err)
+		_ = netConn.Close()
+		assertConnectionReport(t, agentClient, proto.Connection_RECONNECTING_PTY, 0, "")
+		require.Empty(t, agentClient.GetSessionRecordings())
+	})
+}
+
 // echoOnce accepts a single connection, reads 4 bytes and echos them back
 func echoOnce(t *testing.T, ll net.Listener) {
 	t.Helper()
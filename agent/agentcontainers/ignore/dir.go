@@ -93,6 +93,30 @@ func ReadPatterns(ctx context.Context, logger slog.Logger, fileSystem afero.Fs,
 	return ps, nil
 }
 
+// ReadDirPatterns returns the patterns from the .gitignore and
+// .git/info/exclude files directly in path. Unlike ReadPatterns it does not
+// walk subdirectories, allowing callers to load patterns lazily as they
+// descend.
+func ReadDirPatterns(fileSystem afero.Fs, path string) ([]gitignore.Pattern, error) {
+	ps, err := readIgnoreFile(fileSystem, path, gitInfoExcludeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	subPs, err := readIgnoreFile(fileSystem, path, gitignoreFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ps, subPs...), nil
+}
+
+// ReadIgnoreFile returns the patterns from the file called name directly in
+// path, e.g. ".gitignore". A missing file has no patterns.
+func ReadIgnoreFile(fileSystem afero.Fs, path, name string) ([]gitignore.Pattern, error) {
+	return readIgnoreFile(fileSystem, path, name)
+}
+
 func loadPatterns(fileSystem afero.Fs, path string) ([]gitignore.Pattern, error) {
 	data, err := afero.ReadFile(fileSystem, path)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -7,18 +7,35 @@ import (
 	"github.com/spf13/afero"
 
 	"cdr.dev/slog/v3"
+	"github.com/coder/quartz"
 )
 
 // API exposes file-related operations performed through the agent.
 type API struct {
 	logger     slog.Logger
 	filesystem afero.Fs
+	clock      quartz.Clock
 }
 
-func NewAPI(logger slog.Logger, filesystem afero.Fs) *API {
+// Option is a functional option for API.
+type Option func(*API)
+
+// WithClock sets the quartz.Clock implementation to use.
+// This is primarily used for testing to control time.
+func WithClock(clock quartz.Clock) Option {
+	return func(api *API) {
+		api.clock = clock
+	}
+}
+
+func NewAPI(logger slog.Logger, filesystem afero.Fs, opts ...Option) *API {
 	api := &API{
 		logger:     logger,
 		filesystem: filesystem,
+		clock:      quartz.NewReal(),
+	}
+	for _, opt := range opts {
+		opt(api)
 	}
 	return api
 }
@@ -31,6 +48,16 @@ func (api *API) Routes() http.Handler {
 	r.Get("/read-file", api.HandleReadFile)
 	r.Post("/write-file", api.HandleWriteFile)
 	r.Post("/edit-files", api.HandleEditFiles)
+	r.Get("/stat", api.HandleStat)
+	r.Post("/mkdir", api.HandleMkdir)
+	r.Post("/rename", api.HandleRename)
+	r.Post("/delete", api.HandleDelete)
+	r.Post("/chmod", api.HandleChmod)
+	r.Post("/walk", api.HandleWalk)
+	r.Post("/search", api.HandleSearch)
+	r.Get("/watch", api.HandleWatch)
+	r.Get("/download-archive", api.HandleDownloadArchive)
+	r.Post("/upload-archive", api.HandleUploadArchive)
 
 	return r
 }
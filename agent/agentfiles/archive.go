@@ -0,0 +1,166 @@
+package agentfiles
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/archive"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+func parseArchiveQuery(rw http.ResponseWriter, r *http.Request) (string, workspacesdk.ArchiveFormat, archive.Filter, bool) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	parser := httpapi.NewQueryParamParser().RequiredNotEmpty("path")
+	path := parser.String(query, "", "path")
+	format := httpapi.ParseCustom(parser, query, workspacesdk.ArchiveFormatTar, "format", httpapi.ParseEnum[workspacesdk.ArchiveFormat])
+	filter := archive.Filter{
+		Include: parser.Strings(query, nil, "include"),
+		Exclude: parser.Strings(query, nil, "exclude"),
+	}
+	parser.ErrorExcessParams(query)
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Query parameters have invalid values.",
+			Validations: parser.Errors,
+		})
+		return "", "", archive.Filter{}, false
+	}
+
+	if !filepath.IsAbs(path) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("directory path must be absolute: %q", path),
+		})
+		return "", "", archive.Filter{}, false
+	}
+	if err := filter.Validate(); err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: err.Error(),
+		})
+		return "", "", archive.Filter{}, false
+	}
+
+	return path, format, filter, true
+}
+
+// HandleDownloadArchive streams a directory as a tar or zip archive.
+func (api *API) HandleDownloadArchive(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	path, format, filter, ok := parseArchiveQuery(rw, r)
+	if !ok {
+		return
+	}
+
+	stat, err := api.filesystem.Stat(path)
+	if err != nil {
+		httpapi.Write(ctx, rw, fileErrorStatus(err), codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+	if !stat.IsDir() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("path %q is not a directory", path),
+		})
+		return
+	}
+
+	// The archive is streamed, so errors after this point can only be
+	// surfaced to the client as a truncated archive.
+	rw.Header().Set("Content-Type", format.ContentType())
+	rw.WriteHeader(http.StatusOK)
+
+	switch format {
+	case workspacesdk.ArchiveFormatZip:
+		err = archive.ZipDir(rw, api.filesystem, path, filter)
+	default:
+		err = archive.TarDir(rw, api.filesystem, path, filter)
+	}
+	if err != nil && ctx.Err() == nil {
+		api.logger.Error(ctx, "workspace agent download archive", slog.F("path", path), slog.Error(err))
+	}
+}
+
+// HandleUploadArchive extracts a tar or zip archive into a directory.
+func (api *API) HandleUploadArchive(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	path, format, filter, ok := parseArchiveQuery(rw, r)
+	if !ok {
+		return
+	}
+
+	status, err := api.uploadArchive(ctx, r.Body, path, format, filter)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: fmt.Sprintf("Successfully extracted archive to %q", path),
+	})
+}
+
+func (api *API) uploadArchive(ctx context.Context, body io.Reader, path string, format workspacesdk.ArchiveFormat, filter archive.Filter) (HTTPResponseCode, error) {
+	var err error
+	switch format {
+	case workspacesdk.ArchiveFormatZip:
+		err = api.unzipBody(ctx, body, path, filter)
+	default:
+		err = archive.UntarDir(api.filesystem, path, body, filter)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, archive.ErrUnsafePath),
+			errors.Is(err, tar.ErrHeader),
+			errors.Is(err, zip.ErrFormat),
+			errors.Is(err, io.ErrUnexpectedEOF):
+			return http.StatusBadRequest, err
+		default:
+			return fileErrorStatus(err), err
+		}
+	}
+	return 0, nil
+}
+
+// unzipBody spools the body to a temporary file since zip archives can only
+// be read with random access.
+func (api *API) unzipBody(ctx context.Context, body io.Reader, path string, filter archive.Filter) error {
+	tmpfile, err := afero.TempFile(api.filesystem, "", "coder-archive-*.zip")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmpfile.Close()
+		if err := api.filesystem.Remove(tmpfile.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			api.logger.Warn(ctx, "unable to clean up temp file", slog.Error(err))
+		}
+	}()
+
+	size, err := io.Copy(tmpfile, body)
+	if err != nil {
+		return xerrors.Errorf("read archive: %w", err)
+	}
+	zr, err := zip.NewReader(tmpfile, size)
+	if err != nil {
+		return err
+	}
+	return archive.UnzipDir(api.filesystem, path, zr, filter)
+}
@@ -0,0 +1,283 @@
+package agentfiles_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestDownloadArchive(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	root := filepath.Join(tmpdir, "download")
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	for path, content := range map[string]string{
+		"a.go":                "package a",
+		"sub/b.go":            "package b",
+		"node_modules/dep.js": "module.exports = {}",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		err := fs.MkdirAll(filepath.Dir(path), 0o755)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, path, []byte(content), 0o644)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name        string
+		query       url.Values
+		contentType string
+		entries     []string
+		errCode     int
+		error       string
+	}{
+		{
+			name:    "NoPath",
+			query:   url.Values{},
+			errCode: http.StatusBadRequest,
+			error:   "Query parameters have invalid values",
+		},
+		{
+			name:    "RelativePath",
+			query:   url.Values{"path": {"relative"}},
+			errCode: http.StatusBadRequest,
+			error:   "directory path must be absolute",
+		},
+		{
+			name:    "InvalidFormat",
+			query:   url.Values{"path": {root}, "format": {"rar"}},
+			errCode: http.StatusBadRequest,
+			error:   "Query parameters have invalid values",
+		},
+		{
+			name:    "InvalidPattern",
+			query:   url.Values{"path": {root}, "exclude": {"["}},
+			errCode: http.StatusBadRequest,
+			error:   "invalid pattern",
+		},
+		{
+			name:    "NonExistent",
+			query:   url.Values{"path": {filepath.Join(tmpdir, "does-not-exist")}},
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name:    "NotADirectory",
+			query:   url.Values{"path": {filepath.Join(root, "a.go")}},
+			errCode: http.StatusBadRequest,
+			error:   "is not a directory",
+		},
+		{
+			name:        "Tar",
+			query:       url.Values{"path": {root}},
+			contentType: "application/x-tar",
+			entries:     []string{"a.go", "node_modules/", "node_modules/dep.js", "sub/", "sub/b.go"},
+		},
+		{
+			name:        "TarFiltered",
+			query:       url.Values{"path": {root}, "include": {"*.go,*.js"}, "exclude": {"node_modules"}},
+			contentType: "application/x-tar",
+			entries:     []string{"a.go", "sub/b.go"},
+		},
+		{
+			name:        "Zip",
+			query:       url.Values{"path": {root}, "format": {"zip"}, "exclude": {"node_modules"}},
+			contentType: "application/zip",
+			entries:     []string{"a.go", "sub/", "sub/b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/download-archive?"+tt.query.Encode(), nil)
+			api.Routes().ServeHTTP(w, r)
+
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+
+			var entries []string
+			if tt.contentType == "application/zip" {
+				zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+				require.NoError(t, err)
+				for _, f := range zr.File {
+					entries = append(entries, f.Name)
+				}
+			} else {
+				tr := tar.NewReader(w.Body)
+				for {
+					header, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					require.NoError(t, err)
+					entries = append(entries, header.Name)
+				}
+			}
+			require.Equal(t, tt.entries, entries)
+		})
+	}
+}
+
+func TestUploadArchive(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	files := map[string]string{
+		"a.go":     "package a",
+		"sub/b.go": "package b",
+		"c.txt":    "c",
+	}
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+
+	var unsafeBuf bytes.Buffer
+	tw = tar.NewWriter(&unsafeBuf)
+	err := tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0o644, Size: 1, Typeflag: tar.TypeReg})
+	require.NoError(t, err)
+	_, err = tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		body     []byte
+		expected map[string]string
+		errCode  int
+		error    string
+	}{
+		{
+			name:    "RelativePath",
+			query:   url.Values{"path": {"relative"}},
+			body:    tarBuf.Bytes(),
+			errCode: http.StatusBadRequest,
+			error:   "directory path must be absolute",
+		},
+		{
+			name:    "UnsafePath",
+			query:   url.Values{"path": {filepath.Join(tmpdir, "upload-unsafe")}},
+			body:    unsafeBuf.Bytes(),
+			errCode: http.StatusBadRequest,
+			error:   "archive entry path is not local",
+		},
+		{
+			name:    "InvalidZip",
+			query:   url.Values{"path": {filepath.Join(tmpdir, "upload-invalid")}, "format": {"zip"}},
+			body:    []byte("not a zip"),
+			errCode: http.StatusBadRequest,
+			error:   "not a valid zip file",
+		},
+		{
+			name:     "Tar",
+			query:    url.Values{"path": {filepath.Join(tmpdir, "upload-tar")}},
+			body:     tarBuf.Bytes(),
+			expected: files,
+		},
+		{
+			name:  "TarFiltered",
+			query: url.Values{"path": {filepath.Join(tmpdir, "upload-tar-filtered")}, "exclude": {"*.txt"}},
+			body:  tarBuf.Bytes(),
+			expected: map[string]string{
+				"a.go":     "package a",
+				"sub/b.go": "package b",
+			},
+		},
+		{
+			name:     "Zip",
+			query:    url.Values{"path": {filepath.Join(tmpdir, "upload-zip")}, "format": {"zip"}},
+			body:     zipBuf.Bytes(),
+			expected: files,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/upload-archive?"+tt.query.Encode(), bytes.NewReader(tt.body))
+			api.Routes().ServeHTTP(w, r)
+
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			dir := tt.query.Get("path")
+			var got []string
+			err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+				require.NoError(t, err)
+				if !info.IsDir() {
+					got = append(got, path)
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.expected))
+			for name, content := range tt.expected {
+				b, err := afero.ReadFile(fs, filepath.Join(dir, filepath.FromSlash(name)))
+				require.NoError(t, err)
+				require.Equal(t, content, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package agentfiles
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+const defaultDirMode os.FileMode = 0o755
+
+func (api *API) HandleStat(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	parser := httpapi.NewQueryParamParser().RequiredNotEmpty("path")
+	path := parser.String(query, "", "path")
+	parser.ErrorExcessParams(query)
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Query parameters have invalid values.",
+			Validations: parser.Errors,
+		})
+		return
+	}
+
+	if !filepath.IsAbs(path) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("file path must be absolute: %q", path),
+		})
+		return
+	}
+
+	stat, err := api.filesystem.Stat(path)
+	if err != nil {
+		httpapi.Write(ctx, rw, fileErrorStatus(err), codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, toFileInfo(path, stat))
+}
+
+func (api *API) HandleMkdir(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req workspacesdk.MkdirRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	status, err := api.mkdir(req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: fmt.Sprintf("Successfully created %q", req.Path),
+	})
+}
+
+func (api *API) mkdir(req workspacesdk.MkdirRequest) (HTTPResponseCode, error) {
+	if err := requireAbsolutePath("path", req.Path); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	mode, err := parseFileMode(req.Mode, defaultDirMode)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if req.Parents {
+		err = api.filesystem.MkdirAll(req.Path, mode)
+	} else {
+		err = api.filesystem.Mkdir(req.Path, mode)
+	}
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	return 0, nil
+}
+
+func (api *API) HandleRename(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req workspacesdk.RenameRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	status, err := api.rename(req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: fmt.Sprintf("Successfully renamed %q to %q", req.Source, req.Destination),
+	})
+}
+
+func (api *API) rename(req workspacesdk.RenameRequest) (HTTPResponseCode, error) {
+	if err := requireAbsolutePath("source", req.Source); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := requireAbsolutePath("destination", req.Destination); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if _, err := api.filesystem.Stat(req.Source); err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	_, err := api.filesystem.Stat(req.Destination)
+	switch {
+	case err == nil && !req.Overwrite:
+		return http.StatusConflict, xerrors.Errorf("destination %q already exists", req.Destination)
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return fileErrorStatus(err), err
+	}
+
+	err = api.filesystem.MkdirAll(filepath.Dir(req.Destination), defaultDirMode)
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	err = api.filesystem.Rename(req.Source, req.Destination)
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	return 0, nil
+}
+
+func (api *API) HandleDelete(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req workspacesdk.DeleteRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	status, err := api.delete(req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: fmt.Sprintf("Successfully deleted %q", req.Path),
+	})
+}
+
+func (api *API) delete(req workspacesdk.DeleteRequest) (HTTPResponseCode, error) {
+	if err := requireAbsolutePath("path", req.Path); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	path := filepath.Clean(req.Path)
+	if filepath.Dir(path) == path {
+		return http.StatusBadRequest, xerrors.Errorf("refusing to delete root directory %q", path)
+	}
+
+	stat, err := api.filesystem.Stat(path)
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	if !stat.IsDir() {
+		err = api.filesystem.Remove(path)
+		if err != nil {
+			return fileErrorStatus(err), err
+		}
+		return 0, nil
+	}
+
+	if req.Recursive {
+		err = api.filesystem.RemoveAll(path)
+		if err != nil {
+			return fileErrorStatus(err), err
+		}
+		return 0, nil
+	}
+
+	// Not every afero.Fs refuses to remove a non-empty directory, so check
+	// explicitly rather than relying on ENOTEMPTY.
+	f, err := api.filesystem.Open(path)
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+	names, err := f.Readdirnames(1)
+	_ = f.Close()
+	if err != nil && len(names) == 0 && !errors.Is(err, io.EOF) {
+		return fileErrorStatus(err), err
+	}
+	if len(names) > 0 {
+		return http.StatusBadRequest, xerrors.Errorf("directory %q is not empty, set recursive to delete it", path)
+	}
+
+	err = api.filesystem.Remove(path)
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	return 0, nil
+}
+
+func (api *API) HandleChmod(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req workspacesdk.ChmodRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	status, err := api.chmod(req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: fmt.Sprintf("Successfully changed mode of %q to %s", req.Path, req.Mode),
+	})
+}
+
+func (api *API) chmod(req workspacesdk.ChmodRequest) (HTTPResponseCode, error) {
+	if err := requireAbsolutePath("path", req.Path); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if req.Mode == "" {
+		return http.StatusBadRequest, xerrors.New("\"mode\" is required")
+	}
+
+	mode, err := parseFileMode(req.Mode, 0)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = api.filesystem.Chmod(req.Path, mode)
+	if err != nil {
+		return fileErrorStatus(err), err
+	}
+
+	return 0, nil
+}
+
+func requireAbsolutePath(field, path string) error {
+	if path == "" {
+		return xerrors.Errorf("%q is required", field)
+	}
+	if !filepath.IsAbs(path) {
+		return xerrors.Errorf("file path must be absolute: %q", path)
+	}
+	return nil
+}
+
+// parseFileMode parses octal permission bits such as "0755" or "644". An empty
+// string returns def.
+func parseFileMode(s string, def os.FileMode) (os.FileMode, error) {
+	if s == "" {
+		return def, nil
+	}
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, xerrors.Errorf("invalid file mode %q, must be octal permission bits such as \"0755\"", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+func fileErrorStatus(err error) HTTPResponseCode {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return http.StatusNotFound
+	case errors.Is(err, os.ErrPermission):
+		return http.StatusForbidden
+	case errors.Is(err, os.ErrExist):
+		return http.StatusConflict
+	case errors.Is(err, syscall.ENOTDIR), errors.Is(err, syscall.EISDIR), errors.Is(err, syscall.ENOTEMPTY):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toFileInfo(path string, info os.FileInfo) workspacesdk.FileInfo {
+	return workspacesdk.FileInfo{
+		Name:               info.Name(),
+		AbsolutePathString: path,
+		IsDir:              info.IsDir(),
+		Size:               info.Size(),
+		Mode:               fmt.Sprintf("%04o", info.Mode().Perm()),
+		ModTime:            info.ModTime(),
+	}
+}
@@ -0,0 +1,510 @@
+package agentfiles_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestStat(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	noPermsFilePath := filepath.Join(tmpdir, "no-perms")
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		if file == noPermsFilePath {
+			return os.ErrPermission
+		}
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	dirPath := filepath.Join(tmpdir, "a-directory")
+	err := fs.MkdirAll(dirPath, 0o755)
+	require.NoError(t, err)
+
+	filePath := filepath.Join(tmpdir, "file")
+	err = afero.WriteFile(fs, filePath, []byte("content"), 0o640)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		isDir   bool
+		size    int64
+		mode    string
+		errCode int
+		error   string
+	}{
+		{
+			name:    "NoPath",
+			path:    "",
+			errCode: http.StatusBadRequest,
+			error:   "\"path\" is required",
+		},
+		{
+			name:    "RelativePath",
+			path:    "relative",
+			errCode: http.StatusBadRequest,
+			error:   "file path must be absolute",
+		},
+		{
+			name:    "NonExistent",
+			path:    filepath.Join(tmpdir, "does-not-exist"),
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name:    "NoPermissions",
+			path:    noPermsFilePath,
+			errCode: http.StatusForbidden,
+			error:   "permission denied",
+		},
+		{
+			name: "File",
+			path: filePath,
+			size: 7,
+			mode: "0640",
+		},
+		{
+			name:  "Dir",
+			path:  dirPath,
+			isDir: true,
+			mode:  "0755",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/stat?path=%s", tt.path), nil)
+			api.Routes().ServeHTTP(w, r)
+
+			if tt.errCode != 0 {
+				got := &codersdk.Error{}
+				err := json.NewDecoder(w.Body).Decode(got)
+				require.NoError(t, err)
+				require.ErrorContains(t, got, tt.error)
+				require.Equal(t, tt.errCode, w.Code)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var got workspacesdk.FileInfo
+			err := json.NewDecoder(w.Body).Decode(&got)
+			require.NoError(t, err)
+			require.Equal(t, filepath.Base(tt.path), got.Name)
+			require.Equal(t, tt.path, got.AbsolutePathString)
+			require.Equal(t, tt.isDir, got.IsDir)
+			require.Equal(t, tt.mode, got.Mode)
+			if !tt.isDir {
+				require.Equal(t, tt.size, got.Size)
+			}
+		})
+	}
+}
+
+func TestMkdir(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	existingPath := filepath.Join(tmpdir, "existing")
+	err := fs.MkdirAll(existingPath, 0o755)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     workspacesdk.MkdirRequest
+		mode    os.FileMode
+		errCode int
+		error   string
+	}{
+		{
+			name:    "NoPath",
+			errCode: http.StatusBadRequest,
+			error:   "\"path\" is required",
+		},
+		{
+			name:    "RelativePath",
+			req:     workspacesdk.MkdirRequest{Path: "relative"},
+			errCode: http.StatusBadRequest,
+			error:   "file path must be absolute",
+		},
+		{
+			name:    "InvalidMode",
+			req:     workspacesdk.MkdirRequest{Path: filepath.Join(tmpdir, "bad-mode"), Mode: "999"},
+			errCode: http.StatusBadRequest,
+			error:   "invalid file mode",
+		},
+		{
+			name:    "Exists",
+			req:     workspacesdk.MkdirRequest{Path: existingPath},
+			errCode: http.StatusConflict,
+			error:   "file already exists",
+		},
+		{
+			name: "ExistsParents",
+			req:  workspacesdk.MkdirRequest{Path: existingPath, Parents: true},
+			mode: 0o755,
+		},
+		{
+			name: "Mode",
+			req:  workspacesdk.MkdirRequest{Path: filepath.Join(tmpdir, "with-mode"), Mode: "0700"},
+			mode: 0o700,
+		},
+		{
+			name: "Parents",
+			req:  workspacesdk.MkdirRequest{Path: filepath.Join(tmpdir, "a", "b", "c"), Parents: true},
+			mode: 0o755,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := doJSONRequest(ctx, t, api, "/mkdir", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			stat, err := fs.Stat(tt.req.Path)
+			require.NoError(t, err)
+			require.True(t, stat.IsDir())
+			require.Equal(t, tt.mode, stat.Mode().Perm())
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	noPermsFilePath := filepath.Join(tmpdir, "no-perms")
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		if file == noPermsFilePath {
+			return os.ErrPermission
+		}
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	tests := []struct {
+		name     string
+		contents map[string]string
+		req      workspacesdk.RenameRequest
+		expected map[string]string
+		errCode  int
+		error    string
+	}{
+		{
+			name:    "NoSource",
+			req:     workspacesdk.RenameRequest{Destination: filepath.Join(tmpdir, "dst")},
+			errCode: http.StatusBadRequest,
+			error:   "\"source\" is required",
+		},
+		{
+			name:    "RelativeDestination",
+			req:     workspacesdk.RenameRequest{Source: filepath.Join(tmpdir, "src"), Destination: "relative"},
+			errCode: http.StatusBadRequest,
+			error:   "file path must be absolute",
+		},
+		{
+			name:    "NonExistent",
+			req:     workspacesdk.RenameRequest{Source: filepath.Join(tmpdir, "does-not-exist"), Destination: filepath.Join(tmpdir, "dst")},
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name:     "NoPermissions",
+			contents: map[string]string{filepath.Join(tmpdir, "perm-src"): "content"},
+			req:      workspacesdk.RenameRequest{Source: filepath.Join(tmpdir, "perm-src"), Destination: noPermsFilePath},
+			errCode:  http.StatusForbidden,
+			error:    "permission denied",
+		},
+		{
+			name: "DestinationExists",
+			contents: map[string]string{
+				filepath.Join(tmpdir, "exists-src"): "src",
+				filepath.Join(tmpdir, "exists-dst"): "dst",
+			},
+			req:     workspacesdk.RenameRequest{Source: filepath.Join(tmpdir, "exists-src"), Destination: filepath.Join(tmpdir, "exists-dst")},
+			errCode: http.StatusConflict,
+			error:   "already exists",
+		},
+		{
+			name: "Overwrite",
+			contents: map[string]string{
+				filepath.Join(tmpdir, "overwrite-src"): "src",
+				filepath.Join(tmpdir, "overwrite-dst"): "dst",
+			},
+			req: workspacesdk.RenameRequest{Source: filepath.Join(tmpdir, "overwrite-src"), Destination: filepath.Join(tmpdir, "overwrite-dst"), Overwrite: true},
+			expected: map[string]string{
+				filepath.Join(tmpdir, "overwrite-dst"): "src",
+			},
+		},
+		{
+			name:     "CreatesParents",
+			contents: map[string]string{filepath.Join(tmpdir, "nested-src"): "content"},
+			req:      workspacesdk.RenameRequest{Source: filepath.Join(tmpdir, "nested-src"), Destination: filepath.Join(tmpdir, "nested", "dir", "dst")},
+			expected: map[string]string{
+				filepath.Join(tmpdir, "nested", "dir", "dst"): "content",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			for path, content := range tt.contents {
+				err := afero.WriteFile(fs, path, []byte(content), 0o644)
+				require.NoError(t, err)
+			}
+
+			w := doJSONRequest(ctx, t, api, "/rename", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			_, err := fs.Stat(tt.req.Source)
+			require.ErrorIs(t, err, os.ErrNotExist)
+			for path, content := range tt.expected {
+				b, err := afero.ReadFile(fs, path)
+				require.NoError(t, err)
+				require.Equal(t, content, string(b))
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	tests := []struct {
+		name     string
+		contents map[string]string
+		dirs     []string
+		req      workspacesdk.DeleteRequest
+		errCode  int
+		error    string
+	}{
+		{
+			name:    "NoPath",
+			errCode: http.StatusBadRequest,
+			error:   "\"path\" is required",
+		},
+		{
+			name:    "RelativePath",
+			req:     workspacesdk.DeleteRequest{Path: "relative"},
+			errCode: http.StatusBadRequest,
+			error:   "file path must be absolute",
+		},
+		{
+			name:    "Root",
+			req:     workspacesdk.DeleteRequest{Path: filepath.VolumeName(tmpdir) + string(filepath.Separator), Recursive: true},
+			errCode: http.StatusBadRequest,
+			error:   "refusing to delete root directory",
+		},
+		{
+			name:    "NonExistent",
+			req:     workspacesdk.DeleteRequest{Path: filepath.Join(tmpdir, "does-not-exist")},
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name:     "File",
+			contents: map[string]string{filepath.Join(tmpdir, "delete-file"): "content"},
+			req:      workspacesdk.DeleteRequest{Path: filepath.Join(tmpdir, "delete-file")},
+		},
+		{
+			name: "EmptyDir",
+			dirs: []string{filepath.Join(tmpdir, "empty-dir")},
+			req:  workspacesdk.DeleteRequest{Path: filepath.Join(tmpdir, "empty-dir")},
+		},
+		{
+			name:     "NonEmptyDir",
+			contents: map[string]string{filepath.Join(tmpdir, "non-empty", "file"): "content"},
+			req:      workspacesdk.DeleteRequest{Path: filepath.Join(tmpdir, "non-empty")},
+			errCode:  http.StatusBadRequest,
+			error:    "is not empty",
+		},
+		{
+			name:     "Recursive",
+			contents: map[string]string{filepath.Join(tmpdir, "recursive", "nested", "file"): "content"},
+			req:      workspacesdk.DeleteRequest{Path: filepath.Join(tmpdir, "recursive"), Recursive: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			for _, dir := range tt.dirs {
+				err := fs.MkdirAll(dir, 0o755)
+				require.NoError(t, err)
+			}
+			for path, content := range tt.contents {
+				err := fs.MkdirAll(filepath.Dir(path), 0o755)
+				require.NoError(t, err)
+				err = afero.WriteFile(fs, path, []byte(content), 0o644)
+				require.NoError(t, err)
+			}
+
+			w := doJSONRequest(ctx, t, api, "/delete", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			_, err := fs.Stat(tt.req.Path)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestChmod(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	filePath := filepath.Join(tmpdir, "chmod")
+	err := afero.WriteFile(fs, filePath, []byte("content"), 0o644)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     workspacesdk.ChmodRequest
+		mode    os.FileMode
+		errCode int
+		error   string
+	}{
+		{
+			name:    "NoPath",
+			req:     workspacesdk.ChmodRequest{Mode: "0755"},
+			errCode: http.StatusBadRequest,
+			error:   "\"path\" is required",
+		},
+		{
+			name:    "NoMode",
+			req:     workspacesdk.ChmodRequest{Path: filePath},
+			errCode: http.StatusBadRequest,
+			error:   "\"mode\" is required",
+		},
+		{
+			name:    "InvalidMode",
+			req:     workspacesdk.ChmodRequest{Path: filePath, Mode: "rwx"},
+			errCode: http.StatusBadRequest,
+			error:   "invalid file mode",
+		},
+		{
+			name:    "NonExistent",
+			req:     workspacesdk.ChmodRequest{Path: filepath.Join(tmpdir, "does-not-exist"), Mode: "0755"},
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name: "OK",
+			req:  workspacesdk.ChmodRequest{Path: filePath, Mode: "755"},
+			mode: 0o755,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := doJSONRequest(ctx, t, api, "/chmod", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			stat, err := fs.Stat(tt.req.Path)
+			require.NoError(t, err)
+			require.Equal(t, tt.mode, stat.Mode().Perm())
+		})
+	}
+}
+
+func doJSONRequest(ctx context.Context, t *testing.T, api *agentfiles.API, path string, req any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(req)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequestWithContext(ctx, http.MethodPost, path, buf)
+	api.Routes().ServeHTTP(w, r)
+	return w
+}
+
+func requireErrorResponse(t *testing.T, w *httptest.ResponseRecorder, code int, message string) {
+	t.Helper()
+
+	got := &codersdk.Error{}
+	err := json.NewDecoder(w.Body).Decode(got)
+	require.NoError(t, err)
+	require.ErrorContains(t, got, message)
+	require.Equal(t, code, w.Code)
+}
@@ -1,7 +1,10 @@
 package agentfiles
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +16,7 @@ import (
 	"syscall"
 
 	"github.com/icholy/replace"
+	"github.com/pkg/diff"
 	"github.com/spf13/afero"
 	"golang.org/x/text/transform"
 	"golang.org/x/xerrors"
@@ -185,42 +189,93 @@ func (api *API) HandleEditFiles(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var combinedErr error
-	status := http.StatusOK
+	resp, status, err := api.editFiles(ctx, req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+// stagedEdit is an edited file that has not been moved into place yet.
+type stagedEdit struct {
+	result workspacesdk.FileEditResult
+	// tmpfile holds the edited contents. Empty for dry runs.
+	tmpfile string
+}
+
+func (api *API) editFiles(ctx context.Context, req workspacesdk.FileEditRequest) (workspacesdk.FileEditResponse, HTTPResponseCode, error) {
+	var (
+		combinedErr error
+		status      = http.StatusOK
+		staged      []stagedEdit
+	)
+	// Without atomic or dry run, each file is moved into place as soon as it
+	// is edited, so earlier files stay edited when a later one fails.
+	immediate := !req.Atomic && !req.DryRun
 	for _, edit := range req.Files {
-		s, err := api.editFile(r.Context(), edit.Path, edit.Edits)
-		// Keep the highest response status, so 500 will be preferred over 400, etc.
-		if s > status {
-			status = s
+		file, s, err := api.stageEdit(ctx, edit, req.DryRun)
+		if err == nil && immediate {
+			s, err = api.commitEdit(ctx, file)
 		}
 		if err != nil {
+			// Keep the highest response status, so 500 will be preferred over 400, etc.
+			status = max(status, s)
 			combinedErr = errors.Join(combinedErr, err)
+			continue
 		}
+		staged = append(staged, file)
 	}
 
-	if combinedErr != nil {
-		httpapi.Write(ctx, rw, status, codersdk.Response{
-			Message: combinedErr.Error(),
-		})
-		return
+	if combinedErr != nil && !immediate {
+		api.discardEdits(ctx, staged)
+		return workspacesdk.FileEditResponse{}, status, combinedErr
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+	if req.Atomic && !req.DryRun {
+		for i, file := range staged {
+			if _, err := api.commitEdit(ctx, file); err != nil {
+				// Renames within a directory rarely fail, but if one does the
+				// files before it have already been replaced.
+				api.discardEdits(ctx, staged[i+1:])
+				return workspacesdk.FileEditResponse{}, http.StatusInternalServerError, xerrors.Errorf("%d of %d file(s) were edited before the failure: %w", i, len(staged), err)
+			}
+		}
+	}
+
+	resp := workspacesdk.FileEditResponse{
 		Message: "Successfully edited file(s)",
-	})
+		Files:   make([]workspacesdk.FileEditResult, 0, len(staged)),
+	}
+	if req.DryRun {
+		resp.Message = "No files were changed"
+	}
+	for _, file := range staged {
+		resp.Files = append(resp.Files, file.result)
+	}
+	if combinedErr != nil {
+		return workspacesdk.FileEditResponse{}, status, combinedErr
+	}
+	return resp, 0, nil
 }
 
-func (api *API) editFile(ctx context.Context, path string, edits []workspacesdk.FileEdit) (int, error) {
+// stageEdit applies edits to a file, writing the result to an adjacent
+// temporary file, or for dry runs only computing the diff.
+func (api *API) stageEdit(ctx context.Context, edit workspacesdk.FileEdits, dryRun bool) (stagedEdit, HTTPResponseCode, error) {
+	path := edit.Path
 	if path == "" {
-		return http.StatusBadRequest, xerrors.New("\"path\" is required")
+		return stagedEdit{}, http.StatusBadRequest, xerrors.New("\"path\" is required")
 	}
 
 	if !filepath.IsAbs(path) {
-		return http.StatusBadRequest, xerrors.Errorf("file path must be absolute: %q", path)
+		return stagedEdit{}, http.StatusBadRequest, xerrors.Errorf("file path must be absolute: %q", path)
 	}
 
-	if len(edits) == 0 {
-		return http.StatusBadRequest, xerrors.New("must specify at least one edit")
+	if len(edit.Edits) == 0 {
+		return stagedEdit{}, http.StatusBadRequest, xerrors.New("must specify at least one edit")
 	}
 
 	f, err := api.filesystem.Open(path)
@@ -232,44 +287,115 @@ func (api *API) editFile(ctx context.Context, path string, edits []workspacesdk.
 		case errors.Is(err, os.ErrPermission):
 			status = http.StatusForbidden
 		}
-		return status, err
+		return stagedEdit{}, status, err
 	}
 	defer f.Close()
 
 	stat, err := f.Stat()
 	if err != nil {
-		return http.StatusInternalServerError, err
+		return stagedEdit{}, http.StatusInternalServerError, err
 	}
 
 	if stat.IsDir() {
-		return http.StatusBadRequest, xerrors.Errorf("open %s: not a file", path)
+		return stagedEdit{}, http.StatusBadRequest, xerrors.Errorf("open %s: not a file", path)
 	}
 
-	transforms := make([]transform.Transformer, len(edits))
-	for i, edit := range edits {
+	transforms := make([]transform.Transformer, len(edit.Edits))
+	for i, edit := range edit.Edits {
 		transforms[i] = replace.String(edit.Search, edit.Replace)
 	}
 
-	// Create an adjacent file to ensure it will be on the same device and can be
-	// moved atomically.
-	tmpfile, err := afero.TempFile(api.filesystem, filepath.Dir(path), filepath.Base(path))
-	if err != nil {
-		return http.StatusInternalServerError, err
+	var (
+		oldHash = sha256.New()
+		newHash = sha256.New()
+		src     io.Reader
+		dst     io.Writer
+		before  bytes.Buffer
+		after   bytes.Buffer
+		tmpfile afero.File
+	)
+	if dryRun {
+		src = io.TeeReader(f, io.MultiWriter(oldHash, &before))
+		dst = io.MultiWriter(newHash, &after)
+	} else {
+		// Create an adjacent file to ensure it will be on the same device and can be
+		// moved atomically.
+		tmpfile, err = afero.TempFile(api.filesystem, filepath.Dir(path), filepath.Base(path))
+		if err != nil {
+			return stagedEdit{}, http.StatusInternalServerError, err
+		}
+		defer tmpfile.Close()
+		src = io.TeeReader(f, oldHash)
+		dst = io.MultiWriter(newHash, tmpfile)
 	}
-	defer tmpfile.Close()
-
-	_, err = io.Copy(tmpfile, replace.Chain(f, transforms...))
-	if err != nil {
+	removeTmp := func() {
+		if tmpfile == nil {
+			return
+		}
 		if rerr := api.filesystem.Remove(tmpfile.Name()); rerr != nil {
 			api.logger.Warn(ctx, "unable to clean up temp file", slog.Error(rerr))
 		}
-		return http.StatusInternalServerError, xerrors.Errorf("edit %s: %w", path, err)
 	}
 
-	err = api.filesystem.Rename(tmpfile.Name(), path)
+	_, err = io.Copy(dst, replace.Chain(src, transforms...))
 	if err != nil {
-		return http.StatusInternalServerError, err
+		removeTmp()
+		return stagedEdit{}, http.StatusInternalServerError, xerrors.Errorf("edit %s: %w", path, err)
+	}
+
+	if edit.ExpectedHash != "" {
+		if actual := hex.EncodeToString(oldHash.Sum(nil)); actual != edit.ExpectedHash {
+			removeTmp()
+			return stagedEdit{}, http.StatusConflict, xerrors.Errorf("%s was modified: expected hash %s, got %s", path, edit.ExpectedHash, actual)
+		}
+	}
+
+	file := stagedEdit{
+		result: workspacesdk.FileEditResult{
+			Path: path,
+			Hash: hex.EncodeToString(newHash.Sum(nil)),
+		},
+	}
+	if dryRun {
+		var buf bytes.Buffer
+		err = diff.Text(path, path, before.Bytes(), after.Bytes(), &buf)
+		if err != nil {
+			return stagedEdit{}, http.StatusInternalServerError, xerrors.Errorf("diff %s: %w", path, err)
+		}
+		// An unchanged file only has the two header lines.
+		if bytes.Count(buf.Bytes(), []byte{'\n'}) > 2 {
+			file.result.Diff = buf.String()
+		}
+		return file, 0, nil
 	}
 
+	// Preserve the permissions of the original file, temporary files are
+	// only readable by the owner.
+	if err := api.filesystem.Chmod(tmpfile.Name(), stat.Mode().Perm()); err != nil {
+		removeTmp()
+		return stagedEdit{}, http.StatusInternalServerError, err
+	}
+	file.tmpfile = tmpfile.Name()
+	return file, 0, nil
+}
+
+// commitEdit moves the edited contents into place.
+func (api *API) commitEdit(ctx context.Context, file stagedEdit) (HTTPResponseCode, error) {
+	err := api.filesystem.Rename(file.tmpfile, file.result.Path)
+	if err != nil {
+		api.discardEdits(ctx, []stagedEdit{file})
+		return http.StatusInternalServerError, err
+	}
 	return 0, nil
 }
+
+func (api *API) discardEdits(ctx context.Context, staged []stagedEdit) {
+	for _, file := range staged {
+		if file.tmpfile == "" {
+			continue
+		}
+		if err := api.filesystem.Remove(file.tmpfile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			api.logger.Warn(ctx, "unable to clean up temp file", slog.Error(err))
+		}
+	}
+}
@@ -46,6 +46,13 @@ func (fs *testFs) Open(name string) (afero.File, error) {
 	return fs.Fs.Open(name)
 }
 
+func (fs *testFs) Stat(name string) (os.FileInfo, error) {
+	if err := fs.intercept("stat", name); err != nil {
+		return nil, err
+	}
+	return fs.Fs.Stat(name)
+}
+
 func (fs *testFs) Create(name string) (afero.File, error) {
 	if err := fs.intercept("create", name); err != nil {
 		return nil, err
@@ -424,7 +431,10 @@ func TestEditFiles(t *testing.T) {
 		name     string
 		contents map[string]string
 		edits    []workspacesdk.FileEdits
+		atomic   bool
+		dryRun   bool
 		expected map[string]string
+		diffs    map[string]string
 		errCode  int
 		errors   []string
 	}{
@@ -694,6 +704,145 @@ func TestEditFiles(t *testing.T) {
 				"file9: file does not exist",
 			},
 		},
+		{
+			name:     "ExpectedHash",
+			contents: map[string]string{filepath.Join(tmpdir, "expected-hash"): "foo bar"},
+			edits: []workspacesdk.FileEdits{
+				{
+					Path:         filepath.Join(tmpdir, "expected-hash"),
+					ExpectedHash: workspacesdk.FileHash([]byte("foo bar")),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "foo",
+							Replace: "bar",
+						},
+					},
+				},
+			},
+			expected: map[string]string{filepath.Join(tmpdir, "expected-hash"): "bar bar"},
+		},
+		{
+			name:     "ExpectedHashConflict",
+			contents: map[string]string{filepath.Join(tmpdir, "hash-conflict"): "foo bar"},
+			edits: []workspacesdk.FileEdits{
+				{
+					Path:         filepath.Join(tmpdir, "hash-conflict"),
+					ExpectedHash: workspacesdk.FileHash([]byte("foo")),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "foo",
+							Replace: "bar",
+						},
+					},
+				},
+			},
+			expected: map[string]string{filepath.Join(tmpdir, "hash-conflict"): "foo bar"},
+			errCode:  http.StatusConflict,
+			errors:   []string{"hash-conflict was modified"},
+		},
+		{
+			name: "Atomic",
+			contents: map[string]string{
+				filepath.Join(tmpdir, "atomic1"): "atomic 1",
+				filepath.Join(tmpdir, "atomic2"): "atomic 2",
+			},
+			atomic: true,
+			edits: []workspacesdk.FileEdits{
+				{
+					Path: filepath.Join(tmpdir, "atomic1"),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "atomic",
+							Replace: "edited1",
+						},
+					},
+				},
+				{
+					Path: filepath.Join(tmpdir, "atomic2"),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "atomic",
+							Replace: "edited2",
+						},
+					},
+				},
+			},
+			expected: map[string]string{
+				filepath.Join(tmpdir, "atomic1"): "edited1 1",
+				filepath.Join(tmpdir, "atomic2"): "edited2 2",
+			},
+		},
+		{
+			name: "AtomicError",
+			contents: map[string]string{
+				filepath.Join(tmpdir, "atomic-error1"): "atomic 1",
+				filepath.Join(tmpdir, "atomic-error2"): "atomic 2",
+			},
+			atomic: true,
+			edits: []workspacesdk.FileEdits{
+				{
+					Path: filepath.Join(tmpdir, "atomic-error1"),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "atomic",
+							Replace: "edited1",
+						},
+					},
+				},
+				{
+					Path:         filepath.Join(tmpdir, "atomic-error2"),
+					ExpectedHash: workspacesdk.FileHash([]byte("stale")),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "atomic",
+							Replace: "edited2",
+						},
+					},
+				},
+			},
+			// Neither file is edited since the second one conflicts.
+			expected: map[string]string{
+				filepath.Join(tmpdir, "atomic-error1"): "atomic 1",
+				filepath.Join(tmpdir, "atomic-error2"): "atomic 2",
+			},
+			errCode: http.StatusConflict,
+			errors:  []string{"atomic-error2 was modified"},
+		},
+		{
+			name: "DryRun",
+			contents: map[string]string{
+				filepath.Join(tmpdir, "dry-run1"): "foo\nbar\nbaz\n",
+				filepath.Join(tmpdir, "dry-run2"): "unchanged\n",
+			},
+			dryRun: true,
+			edits: []workspacesdk.FileEdits{
+				{
+					Path: filepath.Join(tmpdir, "dry-run1"),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "bar",
+							Replace: "qux",
+						},
+					},
+				},
+				{
+					Path: filepath.Join(tmpdir, "dry-run2"),
+					Edits: []workspacesdk.FileEdit{
+						{
+							Search:  "missing",
+							Replace: "qux",
+						},
+					},
+				},
+			},
+			expected: map[string]string{
+				filepath.Join(tmpdir, "dry-run1"): "foo\nbar\nbaz\n",
+				filepath.Join(tmpdir, "dry-run2"): "unchanged\n",
+			},
+			diffs: map[string]string{
+				filepath.Join(tmpdir, "dry-run1"): fmt.Sprintf("--- %[1]s\n+++ %[1]s\n@@ -1,3 +1,3 @@\n foo\n-bar\n+qux\n baz\n", filepath.Join(tmpdir, "dry-run1")),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -711,7 +860,7 @@ func TestEditFiles(t *testing.T) {
 			buf := bytes.NewBuffer(nil)
 			enc := json.NewEncoder(buf)
 			enc.SetEscapeHTML(false)
-			err := enc.Encode(workspacesdk.FileEditRequest{Files: tt.edits})
+			err := enc.Encode(workspacesdk.FileEditRequest{Files: tt.edits, Atomic: tt.atomic, DryRun: tt.dryRun})
 			require.NoError(t, err)
 
 			w := httptest.NewRecorder()
@@ -728,11 +877,27 @@ func TestEditFiles(t *testing.T) {
 				require.Equal(t, tt.errCode, w.Code)
 			} else {
 				require.Equal(t, http.StatusOK, w.Code)
+				var resp workspacesdk.FileEditResponse
+				err := json.NewDecoder(w.Body).Decode(&resp)
+				require.NoError(t, err)
+				require.Len(t, resp.Files, len(tt.edits))
+				for _, file := range resp.Files {
+					require.Equal(t, tt.diffs[file.Path], file.Diff)
+					if !tt.dryRun {
+						b, err := afero.ReadFile(fs, file.Path)
+						require.NoError(t, err)
+						require.Equal(t, workspacesdk.FileHash(b), file.Hash)
+					}
+				}
 			}
 			for path, expect := range tt.expected {
 				b, err := afero.ReadFile(fs, path)
 				require.NoError(t, err)
 				require.Equal(t, expect, string(b))
+				// Edits keep the permissions of the original file.
+				stat, err := fs.Stat(path)
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0o644), stat.Mode().Perm())
 			}
 		})
 	}
@@ -0,0 +1,264 @@
+package agentfiles
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/agent/agentcontainers/ignore"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+// binarySniffLen is how many leading bytes are checked for a NUL byte to
+// detect binary files, matching git.
+const binarySniffLen = 8000
+
+var errSearchLimitReached = xerrors.New("search limit reached")
+
+func (api *API) HandleSearch(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req workspacesdk.SearchRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	resp, status, err := api.searchFiles(ctx, req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+func (api *API) searchFiles(ctx context.Context, req workspacesdk.SearchRequest) (workspacesdk.SearchResponse, HTTPResponseCode, error) {
+	if err := requireAbsolutePath("path", req.Path); err != nil {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, err
+	}
+	if req.Query == "" {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.New("query is required")
+	}
+	expr := req.Query
+	if req.CaseInsensitive {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.Errorf("invalid query: %w", err)
+	}
+	if req.ContextLines < 0 || req.ContextLines > workspacesdk.SearchMaxContextLines {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.Errorf("context_lines must be between 0 and %d, got %d", workspacesdk.SearchMaxContextLines, req.ContextLines)
+	}
+	maxResults := req.MaxResults
+	if maxResults == 0 {
+		maxResults = workspacesdk.SearchDefaultMaxResults
+	}
+	if maxResults < 0 || maxResults > workspacesdk.SearchMaxResults {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.Errorf("max_results must be between 1 and %d, got %d", workspacesdk.SearchMaxResults, maxResults)
+	}
+	maxFileSize := req.MaxFileSize
+	if maxFileSize == 0 {
+		maxFileSize = workspacesdk.SearchDefaultMaxFileSize
+	}
+	if maxFileSize < 0 {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.Errorf("max_file_size must not be negative, got %d", maxFileSize)
+	}
+	if _, err := filepath.Match(req.Include, ""); err != nil {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.Errorf("invalid include pattern %q: %w", req.Include, err)
+	}
+	include := filepath.ToSlash(req.Include)
+	includeRelative := strings.Contains(include, "/")
+
+	root := filepath.Clean(req.Path)
+	// codeql[go/path-injection] - The intent is to allow the user to search any directory in their workspace.
+	stat, err := api.filesystem.Stat(root)
+	if err != nil {
+		return workspacesdk.SearchResponse{}, fileErrorStatus(err), err
+	}
+	if !stat.IsDir() {
+		return workspacesdk.SearchResponse{}, http.StatusBadRequest, xerrors.Errorf("path %q is not a directory", root)
+	}
+
+	// Ignore patterns are loaded as directories are entered. Patterns are
+	// scoped to the directory they were read from, so patterns from sibling
+	// directories never match each other's entries. The matcher is only
+	// rebuilt when a directory adds patterns. When searching a subdirectory
+	// of a repository, the patterns of the directories above it are loaded
+	// up front so the same files are ignored as when searching from the
+	// repository root.
+	var (
+		patterns []gitignore.Pattern
+		matcher  gitignore.Matcher
+	)
+	if !req.NoIgnore {
+		patterns, err = ignore.LoadGlobalPatterns(api.filesystem)
+		if err != nil {
+			api.logger.Debug(ctx, "unable to load global git ignore patterns", slog.Error(err))
+		}
+		for _, dir := range api.repositoryAncestors(root) {
+			dirPatterns, err := ignore.ReadDirPatterns(api.filesystem, dir)
+			if err != nil {
+				api.logger.Debug(ctx, "unable to read git ignore patterns", slog.F("path", dir), slog.Error(err))
+				continue
+			}
+			patterns = append(patterns, dirPatterns...)
+		}
+		matcher = gitignore.NewMatcher(patterns)
+	}
+
+	resp := workspacesdk.SearchResponse{
+		Matches: []workspacesdk.SearchMatch{},
+	}
+	err = afero.Walk(api.filesystem, root, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Skip entries we cannot read rather than failing the whole search.
+			return nil
+		}
+
+		if info.IsDir() {
+			if path != root && info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if req.NoIgnore {
+				return nil
+			}
+			if path != root && matcher.Match(ignore.FilePathToParts(path), true) {
+				return filepath.SkipDir
+			}
+			dirPatterns, err := ignore.ReadDirPatterns(api.filesystem, path)
+			if err != nil {
+				api.logger.Debug(ctx, "unable to read git ignore patterns", slog.F("path", path), slog.Error(err))
+				return nil
+			}
+			if len(dirPatterns) > 0 {
+				patterns = append(patterns, dirPatterns...)
+				matcher = gitignore.NewMatcher(patterns)
+			}
+			return nil
+		}
+
+		if !info.Mode().IsRegular() || info.Size() > maxFileSize {
+			return nil
+		}
+		if !req.NoIgnore && matcher.Match(ignore.FilePathToParts(path), false) {
+			return nil
+		}
+		if include != "" {
+			name := info.Name()
+			if includeRelative {
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				name = filepath.ToSlash(rel)
+			}
+			if ok, _ := filepath.Match(include, name); !ok {
+				return nil
+			}
+		}
+
+		data, err := afero.ReadFile(api.filesystem, path)
+		if err != nil {
+			return nil
+		}
+		if bytes.IndexByte(data[:min(len(data), binarySniffLen)], 0) != -1 {
+			return nil
+		}
+		resp.FilesSearched++
+
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		for i, line := range lines {
+			line = strings.TrimSuffix(line, "\r")
+			loc := re.FindStringIndex(line)
+			if loc == nil {
+				continue
+			}
+			if len(resp.Matches) >= maxResults {
+				resp.Truncated = true
+				return errSearchLimitReached
+			}
+			resp.Matches = append(resp.Matches, workspacesdk.SearchMatch{
+				Path:   path,
+				Line:   i + 1,
+				Column: loc[0] + 1,
+				Text:   truncateLine(line),
+				Before: contextLines(lines, max(0, i-req.ContextLines), i),
+				After:  contextLines(lines, i+1, min(len(lines), i+1+req.ContextLines)),
+			})
+		}
+		return nil
+	})
+	if err != nil && !xerrors.Is(err, errSearchLimitReached) {
+		if ctx.Err() != nil {
+			return workspacesdk.SearchResponse{}, http.StatusRequestTimeout, xerrors.Errorf("search %q: %w", root, err)
+		}
+		return workspacesdk.SearchResponse{}, fileErrorStatus(err), xerrors.Errorf("search %q: %w", root, err)
+	}
+
+	return resp, 0, nil
+}
+
+// repositoryAncestors returns the directories above path up to the root of
+// the git repository it is in, i.e. the first directory containing .git,
+// ordered from the repository root down. It returns nothing when path is the
+// repository root or is not in a repository.
+func (api *API) repositoryAncestors(path string) []string {
+	var dirs []string
+	for dir := path; ; {
+		if _, err := api.filesystem.Stat(filepath.Join(dir, ".git")); err == nil {
+			slices.Reverse(dirs)
+			return dirs
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+		dirs = append(dirs, dir)
+	}
+}
+
+func contextLines(lines []string, start, end int) []string {
+	if start >= end {
+		return nil
+	}
+	out := make([]string, 0, end-start)
+	for _, line := range lines[start:end] {
+		out = append(out, truncateLine(strings.TrimSuffix(line, "\r")))
+	}
+	return out
+}
+
+func truncateLine(line string) string {
+	if len(line) <= workspacesdk.SearchMaxLineLength {
+		return line
+	}
+	// Avoid splitting a multi-byte character.
+	end := workspacesdk.SearchMaxLineLength
+	for end > 0 && !utf8.RuneStart(line[end]) {
+		end--
+	}
+	return line[:end]
+}
@@ -0,0 +1,247 @@
+package agentfiles_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	root := filepath.Join(tmpdir, "search")
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	for path, content := range map[string]string{
+		".gitignore":          "node_modules\n*.log\n",
+		".git/config":         "TODO in git config",
+		"main.go":             "package main\n\n// TODO: one\nfunc main() {}\n",
+		"sub/util.go":         "package sub\n// todo: lowercase\n",
+		"sub/.gitignore":      "generated.go\n",
+		"sub/generated.go":    "// TODO: generated\n",
+		"sub/trace.log":       "// TODO: trace\n",
+		"notes.txt":           "line 1\nline 2\nTODO: notes\nline 4\nline 5\n",
+		"debug.log":           "TODO: log\n",
+		"node_modules/dep.js": "// TODO: dep\n",
+		"binary.bin":          "TODO\x00binary",
+		"crlf.txt":            "first\r\nTODO: crlf\r\nlast\r\n",
+		"long.txt":            "TODO " + strings.Repeat("x", workspacesdk.SearchMaxLineLength),
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		err := fs.MkdirAll(filepath.Dir(path), 0o755)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, path, []byte(content), 0o644)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name      string
+		req       workspacesdk.SearchRequest
+		expected  []string
+		truncated bool
+		errCode   int
+		error     string
+	}{
+		{
+			name:    "NoPath",
+			req:     workspacesdk.SearchRequest{Query: "TODO"},
+			errCode: http.StatusBadRequest,
+			error:   "\"path\" is required",
+		},
+		{
+			name:    "RelativePath",
+			req:     workspacesdk.SearchRequest{Path: "relative", Query: "TODO"},
+			errCode: http.StatusBadRequest,
+			error:   "file path must be absolute",
+		},
+		{
+			name:    "NoQuery",
+			req:     workspacesdk.SearchRequest{Path: root},
+			errCode: http.StatusBadRequest,
+			error:   "query is required",
+		},
+		{
+			name:    "BadQuery",
+			req:     workspacesdk.SearchRequest{Path: root, Query: "("},
+			errCode: http.StatusBadRequest,
+			error:   "invalid query",
+		},
+		{
+			name:    "BadInclude",
+			req:     workspacesdk.SearchRequest{Path: root, Query: "TODO", Include: "["},
+			errCode: http.StatusBadRequest,
+			error:   "invalid include pattern",
+		},
+		{
+			name:    "TooManyContextLines",
+			req:     workspacesdk.SearchRequest{Path: root, Query: "TODO", ContextLines: workspacesdk.SearchMaxContextLines + 1},
+			errCode: http.StatusBadRequest,
+			error:   "context_lines must be between",
+		},
+		{
+			name:    "TooManyResults",
+			req:     workspacesdk.SearchRequest{Path: root, Query: "TODO", MaxResults: workspacesdk.SearchMaxResults + 1},
+			errCode: http.StatusBadRequest,
+			error:   "max_results must be between",
+		},
+		{
+			name:    "NonExistent",
+			req:     workspacesdk.SearchRequest{Path: filepath.Join(tmpdir, "does-not-exist"), Query: "TODO"},
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name:    "NotADirectory",
+			req:     workspacesdk.SearchRequest{Path: filepath.Join(root, "main.go"), Query: "TODO"},
+			errCode: http.StatusBadRequest,
+			error:   "is not a directory",
+		},
+		{
+			name:     "RespectsGitignore",
+			req:      workspacesdk.SearchRequest{Path: root, Query: "TODO"},
+			expected: []string{"crlf.txt:2:1", "long.txt:1:1", "main.go:3:4", "notes.txt:3:1"},
+		},
+		{
+			name: "NoIgnore",
+			req:  workspacesdk.SearchRequest{Path: root, Query: "TODO", NoIgnore: true},
+			expected: []string{
+				"crlf.txt:2:1", "debug.log:1:1", "long.txt:1:1", "main.go:3:4",
+				"node_modules/dep.js:1:4", "notes.txt:3:1", "sub/generated.go:1:4", "sub/trace.log:1:4",
+			},
+		},
+		{
+			name:     "SubdirectoryRespectsRepositoryGitignore",
+			req:      workspacesdk.SearchRequest{Path: filepath.Join(root, "sub"), Query: "(?i)todo"},
+			expected: []string{"sub/util.go:2:4"},
+		},
+		{
+			name:     "SubdirectoryNoIgnore",
+			req:      workspacesdk.SearchRequest{Path: filepath.Join(root, "sub"), Query: "(?i)todo", NoIgnore: true},
+			expected: []string{"sub/generated.go:1:4", "sub/trace.log:1:4", "sub/util.go:2:4"},
+		},
+		{
+			name:     "CaseInsensitive",
+			req:      workspacesdk.SearchRequest{Path: root, Query: "todo:", CaseInsensitive: true},
+			expected: []string{"crlf.txt:2:1", "main.go:3:4", "notes.txt:3:1", "sub/util.go:2:4"},
+		},
+		{
+			name:     "IncludeBaseName",
+			req:      workspacesdk.SearchRequest{Path: root, Query: "(?i)todo", Include: "*.go"},
+			expected: []string{"main.go:3:4", "sub/util.go:2:4"},
+		},
+		{
+			name:     "IncludeRelativePath",
+			req:      workspacesdk.SearchRequest{Path: root, Query: "(?i)todo", Include: "sub/*.go"},
+			expected: []string{"sub/util.go:2:4"},
+		},
+		{
+			name:     "MaxFileSize",
+			req:      workspacesdk.SearchRequest{Path: root, Query: "TODO", MaxFileSize: 50},
+			expected: []string{"crlf.txt:2:1", "main.go:3:4", "notes.txt:3:1"},
+		},
+		{
+			name:      "MaxResults",
+			req:       workspacesdk.SearchRequest{Path: root, Query: "TODO", MaxResults: 2},
+			expected:  []string{"crlf.txt:2:1", "long.txt:1:1"},
+			truncated: true,
+		},
+		{
+			name:     "MaxResultsExact",
+			req:      workspacesdk.SearchRequest{Path: root, Query: "TODO", MaxResults: 4},
+			expected: []string{"crlf.txt:2:1", "long.txt:1:1", "main.go:3:4", "notes.txt:3:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := doJSONRequest(ctx, t, api, "/search", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var resp workspacesdk.SearchResponse
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(resp.Matches))
+			for _, match := range resp.Matches {
+				rel, err := filepath.Rel(root, match.Path)
+				require.NoError(t, err)
+				got = append(got, fmt.Sprintf("%s:%d:%d", filepath.ToSlash(rel), match.Line, match.Column))
+			}
+			require.Equal(t, tt.expected, got)
+			require.Equal(t, tt.truncated, resp.Truncated)
+		})
+	}
+
+	t.Run("Context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		w := doJSONRequest(ctx, t, api, "/search", workspacesdk.SearchRequest{
+			Path:         root,
+			Query:        "TODO",
+			Include:      "*.txt",
+			ContextLines: 2,
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp workspacesdk.SearchResponse
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		require.NoError(t, err)
+		require.Len(t, resp.Matches, 3)
+
+		crlf := resp.Matches[0]
+		require.Equal(t, "TODO: crlf", crlf.Text)
+		require.Equal(t, []string{"first"}, crlf.Before)
+		require.Equal(t, []string{"last"}, crlf.After)
+
+		long := resp.Matches[1]
+		require.Len(t, long.Text, workspacesdk.SearchMaxLineLength)
+		require.Empty(t, long.Before)
+		require.Empty(t, long.After)
+
+		notes := resp.Matches[2]
+		require.Equal(t, "TODO: notes", notes.Text)
+		require.Equal(t, []string{"line 1", "line 2"}, notes.Before)
+		require.Equal(t, []string{"line 4", "line 5"}, notes.After)
+		require.Equal(t, 3, resp.FilesSearched)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		w := doJSONRequest(ctx, t, api, "/search", workspacesdk.SearchRequest{Path: root, Query: "TODO"})
+		requireErrorResponse(t, w, http.StatusRequestTimeout, "context canceled")
+	})
+}
@@ -0,0 +1,188 @@
+package agentfiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/agent/agentcontainers/ignore"
+	"github.com/coder/coder/v2/archive"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+var errWalkLimitReached = xerrors.New("walk limit reached")
+
+func (api *API) HandleWalk(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req workspacesdk.WalkRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	resp, status, err := walkFiles(api.filesystem, req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+// Walk lists a directory on fs the same way the walk endpoint does, so that
+// clients can list a local directory with the same filtering as a workspace
+// one.
+func Walk(fs afero.Fs, req workspacesdk.WalkRequest) (workspacesdk.WalkResponse, error) {
+	resp, _, err := walkFiles(fs, req)
+	return resp, err
+}
+
+func walkFiles(fs afero.Fs, req workspacesdk.WalkRequest) (workspacesdk.WalkResponse, HTTPResponseCode, error) {
+	if err := requireAbsolutePath("path", req.Path); err != nil {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, err
+	}
+	if req.MaxDepth < 0 {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, xerrors.Errorf("max_depth must not be negative, got %d", req.MaxDepth)
+	}
+	if req.MaxFileSize < 0 {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, xerrors.Errorf("max_file_size must not be negative, got %d", req.MaxFileSize)
+	}
+	maxEntries := req.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = workspacesdk.WalkDefaultMaxEntries
+	}
+	if maxEntries < 0 || maxEntries > workspacesdk.WalkMaxEntries {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, xerrors.Errorf("max_entries must be between 1 and %d, got %d", workspacesdk.WalkMaxEntries, maxEntries)
+	}
+	if _, err := filepath.Match(req.Pattern, ""); err != nil {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, xerrors.Errorf("invalid pattern %q: %w", req.Pattern, err)
+	}
+	filter := archive.Filter{Exclude: req.Exclude}
+	if err := filter.Validate(); err != nil {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, err
+	}
+	if strings.ContainsAny(req.IgnoreFile, `/\`) {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, xerrors.Errorf("ignore_file must be a file name, got %q", req.IgnoreFile)
+	}
+	// Patterns containing a separator match the path relative to the root,
+	// otherwise only the base name is matched, similar to `find -name`.
+	pattern := filepath.ToSlash(req.Pattern)
+	matchRelative := strings.Contains(pattern, "/")
+
+	root := filepath.Clean(req.Path)
+	// codeql[go/path-injection] - The intent is to allow the user to navigate to any directory in their workspace.
+	stat, err := fs.Stat(root)
+	if err != nil {
+		return workspacesdk.WalkResponse{}, fileErrorStatus(err), err
+	}
+	if !stat.IsDir() {
+		return workspacesdk.WalkResponse{}, http.StatusBadRequest, xerrors.Errorf("path %q is not a directory", root)
+	}
+
+	// Ignore patterns are loaded as directories are entered, like for search.
+	var patterns []gitignore.Pattern
+	ignored := func(path string, isDir bool) bool {
+		return len(patterns) > 0 && gitignore.NewMatcher(patterns).Match(ignore.FilePathToParts(path), isDir)
+	}
+
+	resp := workspacesdk.WalkResponse{
+		Entries: []workspacesdk.FileInfo{},
+	}
+	err = afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Skip entries we cannot read rather than failing the whole walk.
+			return nil
+		}
+		if path != root {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			if !filter.Match(filepath.ToSlash(rel)) || ignored(path, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if info.IsDir() && req.IgnoreFile != "" {
+			// Unreadable ignore files are skipped like unreadable entries.
+			dirPatterns, _ := ignore.ReadIgnoreFile(fs, path, req.IgnoreFile)
+			patterns = append(patterns, dirPatterns...)
+		}
+		if path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		depth := strings.Count(rel, "/") + 1
+
+		include := true
+		if pattern != "" {
+			name := info.Name()
+			if matchRelative {
+				name = rel
+			}
+			include, _ = filepath.Match(pattern, name)
+		}
+		if !info.IsDir() && req.MaxFileSize > 0 && info.Size() > req.MaxFileSize {
+			include = false
+		}
+		if include {
+			if len(resp.Entries) >= maxEntries {
+				resp.Truncated = true
+				return errWalkLimitReached
+			}
+			entry := toFileInfo(path, info)
+			entry.IsSymlink = info.Mode()&os.ModeSymlink != 0
+			if req.Hash && info.Mode().IsRegular() {
+				// Files that cannot be read are listed without a hash.
+				entry.Hash, _ = hashFile(fs, path)
+			}
+			resp.Entries = append(resp.Entries, entry)
+		}
+
+		if info.IsDir() && req.MaxDepth > 0 && depth >= req.MaxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil && !xerrors.Is(err, errWalkLimitReached) {
+		return workspacesdk.WalkResponse{}, fileErrorStatus(err), xerrors.Errorf("walk %q: %w", root, err)
+	}
+
+	return resp, 0, nil
+}
+
+func hashFile(fs afero.Fs, path string) (string, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
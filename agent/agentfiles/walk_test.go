@@ -0,0 +1,261 @@
+package agentfiles_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	tmpdir := os.TempDir()
+	root := filepath.Join(tmpdir, "walk")
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := newTestFs(afero.NewMemMapFs(), func(call, file string) error {
+		return nil
+	})
+	api := agentfiles.NewAPI(logger, fs)
+
+	for path, content := range map[string]string{
+		"a.go":           "package a",
+		"b.txt":          "some longer text",
+		"sub/c.go":       "package c",
+		"sub/deep/d.go":  "package d",
+		"sub/deep/e.txt": "e",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		err := fs.MkdirAll(filepath.Dir(path), 0o755)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, path, []byte(content), 0o644)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name      string
+		req       workspacesdk.WalkRequest
+		expected  []string
+		truncated bool
+		errCode   int
+		error     string
+	}{
+		{
+			name:    "NoPath",
+			errCode: http.StatusBadRequest,
+			error:   "\"path\" is required",
+		},
+		{
+			name:    "RelativePath",
+			req:     workspacesdk.WalkRequest{Path: "relative"},
+			errCode: http.StatusBadRequest,
+			error:   "file path must be absolute",
+		},
+		{
+			name:    "NonExistent",
+			req:     workspacesdk.WalkRequest{Path: filepath.Join(tmpdir, "does-not-exist")},
+			errCode: http.StatusNotFound,
+			error:   "file does not exist",
+		},
+		{
+			name:    "NotADirectory",
+			req:     workspacesdk.WalkRequest{Path: filepath.Join(root, "a.go")},
+			errCode: http.StatusBadRequest,
+			error:   "is not a directory",
+		},
+		{
+			name:    "BadPattern",
+			req:     workspacesdk.WalkRequest{Path: root, Pattern: "["},
+			errCode: http.StatusBadRequest,
+			error:   "invalid pattern",
+		},
+		{
+			name:    "TooManyEntries",
+			req:     workspacesdk.WalkRequest{Path: root, MaxEntries: workspacesdk.WalkMaxEntries + 1},
+			errCode: http.StatusBadRequest,
+			error:   "max_entries must be between",
+		},
+		{
+			name:     "All",
+			req:      workspacesdk.WalkRequest{Path: root},
+			expected: []string{"a.go", "b.txt", "sub", "sub/c.go", "sub/deep", "sub/deep/d.go", "sub/deep/e.txt"},
+		},
+		{
+			name:     "PatternBaseName",
+			req:      workspacesdk.WalkRequest{Path: root, Pattern: "*.go"},
+			expected: []string{"a.go", "sub/c.go", "sub/deep/d.go"},
+		},
+		{
+			name:     "PatternRelativePath",
+			req:      workspacesdk.WalkRequest{Path: root, Pattern: "sub/*/*.txt"},
+			expected: []string{"sub/deep/e.txt"},
+		},
+		{
+			name:     "MaxDepth",
+			req:      workspacesdk.WalkRequest{Path: root, MaxDepth: 2},
+			expected: []string{"a.go", "b.txt", "sub", "sub/c.go", "sub/deep"},
+		},
+		{
+			name:     "MaxFileSize",
+			req:      workspacesdk.WalkRequest{Path: root, Pattern: "*.txt", MaxFileSize: 10},
+			expected: []string{"sub/deep/e.txt"},
+		},
+		{
+			name:      "MaxEntries",
+			req:       workspacesdk.WalkRequest{Path: root, MaxEntries: 2},
+			expected:  []string{"a.go", "b.txt"},
+			truncated: true,
+		},
+		{
+			name:     "MaxEntriesExact",
+			req:      workspacesdk.WalkRequest{Path: root, Pattern: "*.go", MaxEntries: 3},
+			expected: []string{"a.go", "sub/c.go", "sub/deep/d.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := doJSONRequest(ctx, t, api, "/walk", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var resp workspacesdk.WalkResponse
+			err := json.NewDecoder(w.Body).Decode(&resp)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(resp.Entries))
+			for _, entry := range resp.Entries {
+				rel, err := filepath.Rel(root, entry.AbsolutePathString)
+				require.NoError(t, err)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			require.Equal(t, tt.expected, got)
+			require.Equal(t, tt.truncated, resp.Truncated)
+		})
+	}
+}
+
+func TestWalkFilters(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(os.TempDir(), "walk-filters")
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	fs := afero.NewMemMapFs()
+	api := agentfiles.NewAPI(logger, fs)
+
+	for path, content := range map[string]string{
+		".syncignore":             "*.log\n",
+		"a.go":                    "package a",
+		"debug.log":               "log",
+		"node_modules/dep/dep.js": "dep",
+		"sub/.syncignore":         "build/\n",
+		"sub/b.go":                "package b",
+		"sub/build/out":           "out",
+		"sub/trace.log":           "log",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, fs.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+	}
+
+	tests := []struct {
+		name     string
+		req      workspacesdk.WalkRequest
+		expected []string
+		errCode  int
+		error    string
+	}{
+		{
+			name:    "BadExclude",
+			req:     workspacesdk.WalkRequest{Path: root, Exclude: []string{"["}},
+			errCode: http.StatusBadRequest,
+			error:   "invalid pattern",
+		},
+		{
+			name:    "IgnoreFilePath",
+			req:     workspacesdk.WalkRequest{Path: root, IgnoreFile: "sub/.syncignore"},
+			errCode: http.StatusBadRequest,
+			error:   "ignore_file must be a file name",
+		},
+		{
+			name:     "Exclude",
+			req:      workspacesdk.WalkRequest{Path: root, Exclude: []string{"node_modules", "sub"}},
+			expected: []string{".syncignore", "a.go", "debug.log"},
+		},
+		{
+			name:     "IgnoreFile",
+			req:      workspacesdk.WalkRequest{Path: root, IgnoreFile: ".syncignore", Exclude: []string{"node_modules"}},
+			expected: []string{".syncignore", "a.go", "sub", "sub/.syncignore", "sub/b.go"},
+		},
+		{
+			name:     "IgnoreFileWithPattern",
+			req:      workspacesdk.WalkRequest{Path: root, IgnoreFile: ".syncignore", Pattern: "*.go"},
+			expected: []string{"a.go", "sub/b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+			defer cancel()
+
+			w := doJSONRequest(ctx, t, api, "/walk", tt.req)
+			if tt.errCode != 0 {
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+				return
+			}
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var resp workspacesdk.WalkResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+			got := make([]string, 0, len(resp.Entries))
+			for _, entry := range resp.Entries {
+				rel, err := filepath.Rel(root, entry.AbsolutePathString)
+				require.NoError(t, err)
+				got = append(got, filepath.ToSlash(rel))
+				require.Empty(t, entry.Hash)
+			}
+			require.Equal(t, tt.expected, got)
+		})
+	}
+
+	t.Run("Hash", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := agentfiles.Walk(fs, workspacesdk.WalkRequest{Path: filepath.Join(root, "sub"), Hash: true})
+		require.NoError(t, err)
+		hashes := make(map[string]string)
+		for _, entry := range resp.Entries {
+			hashes[entry.Name] = entry.Hash
+		}
+		require.Equal(t, map[string]string{
+			".syncignore": workspacesdk.FileHash([]byte("build/\n")),
+			"b.go":        workspacesdk.FileHash([]byte("package b")),
+			"build":       "",
+			"out":         workspacesdk.FileHash([]byte("out")),
+			"trace.log":   workspacesdk.FileHash([]byte("log")),
+		}, hashes)
+	})
+}
@@ -0,0 +1,434 @@
+package agentfiles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/archive"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/quartz"
+	"github.com/coder/websocket"
+)
+
+var errTooManyWatchedDirectories = xerrors.Errorf("cannot watch more than %d directories, narrow the watched paths or add exclude patterns", workspacesdk.WatchMaxDirectories)
+
+// HandleWatch streams change events for a set of paths over a websocket.
+func (api *API) HandleWatch(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	parser := httpapi.NewQueryParamParser()
+	recursive := parser.Boolean(query, false, "recursive")
+	exclude := parser.Strings(query, nil, "exclude")
+	debounce := parser.Duration(query, workspacesdk.WatchDefaultDebounce, "debounce")
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Query parameters have invalid values.",
+			Validations: parser.Errors,
+		})
+		return
+	}
+	// Paths may contain commas, so they are not parsed as a list.
+	req := workspacesdk.WatchRequest{
+		Paths:     query["path"],
+		Recursive: recursive,
+		Exclude:   exclude,
+		Debounce:  debounce,
+	}
+
+	watcher, status, err := newFileWatcher(api.logger, api.clock, api.filesystem, req)
+	if err != nil {
+		httpapi.Write(ctx, rw, status, codersdk.Response{
+			Message: err.Error(),
+		})
+		return
+	}
+	defer watcher.Close()
+
+	conn, err := websocket.Accept(rw, r, &websocket.AcceptOptions{
+		// We want `NoContextTakeover` compression to balance improving
+		// bandwidth cost/latency with minimal memory usage overhead.
+		CompressionMode: websocket.CompressionNoContextTakeover,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to upgrade connection to websocket.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Here we close the websocket for reading, so that the websocket library will handle pings and
+	// close frames.
+	_ = conn.CloseRead(context.Background())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx, wsNetConn := codersdk.WebsocketNetConn(ctx, conn, websocket.MessageText)
+	defer wsNetConn.Close()
+
+	go httpapi.HeartbeatClose(ctx, api.logger, cancel, conn)
+
+	encoder := json.NewEncoder(wsNetConn)
+	err = watcher.run(ctx, req.Debounce, func(ev workspacesdk.WatchEvent) error {
+		return encoder.Encode(ev)
+	})
+	if err != nil && ctx.Err() == nil {
+		api.logger.Error(ctx, "workspace agent watch", slog.Error(err))
+	}
+}
+
+// watchSubscription is one of the paths of a watch request.
+type watchSubscription struct {
+	path  string
+	isDir bool
+}
+
+type fileWatcher struct {
+	logger     slog.Logger
+	clock      quartz.Clock
+	filesystem afero.Fs
+	watcher    *fsnotify.Watcher
+	subs       []watchSubscription
+	recursive  bool
+	filter     archive.Filter
+	// watched holds the directories registered with fsnotify.
+	watched map[string]bool
+}
+
+// Watch streams change events for the paths of req on fs, the same way the
+// watch endpoint does, so that clients can watch local files too. fs must be
+// backed by the operating system, and clock drives the debounce. The watch is
+// established when Watch returns, and the channel is closed once the closer is
+// called.
+func Watch(ctx context.Context, logger slog.Logger, clock quartz.Clock, fs afero.Fs, req workspacesdk.WatchRequest) (<-chan workspacesdk.WatchEvent, io.Closer, error) {
+	if req.Debounce == 0 {
+		req.Debounce = workspacesdk.WatchDefaultDebounce
+	}
+	watcher, _, err := newFileWatcher(logger, clock, fs, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan workspacesdk.WatchEvent, 64)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		defer close(events)
+		defer watcher.Close()
+		err := watcher.run(ctx, req.Debounce, func(ev workspacesdk.WatchEvent) error {
+			select {
+			case events <- ev:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.Error(ctx, "local file watch", slog.Error(err))
+		}
+	}()
+	return events, closeFunc(func() error {
+		cancel()
+		<-closed
+		return nil
+	}), nil
+}
+
+type closeFunc func() error
+
+func (f closeFunc) Close() error { return f() }
+
+func newFileWatcher(logger slog.Logger, clock quartz.Clock, filesystem afero.Fs, req workspacesdk.WatchRequest) (*fileWatcher, HTTPResponseCode, error) {
+	if len(req.Paths) == 0 {
+		return nil, http.StatusBadRequest, xerrors.New("at least one path is required")
+	}
+	if req.Debounce < 0 || req.Debounce > workspacesdk.WatchMaxDebounce {
+		return nil, http.StatusBadRequest, xerrors.Errorf("debounce must be between 0s and %s, got %s", workspacesdk.WatchMaxDebounce, req.Debounce)
+	}
+	filter := archive.Filter{Exclude: req.Exclude}
+	if err := filter.Validate(); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	subs := make([]watchSubscription, 0, len(req.Paths))
+	for _, path := range req.Paths {
+		if err := requireAbsolutePath("path", path); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		path = filepath.Clean(path)
+		// codeql[go/path-injection] - The intent is to allow the user to watch any path in their workspace.
+		stat, err := filesystem.Stat(path)
+		if err != nil {
+			return nil, fileErrorStatus(err), err
+		}
+		subs = append(subs, watchSubscription{path: path, isDir: stat.IsDir()})
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, http.StatusInternalServerError, xerrors.Errorf("create watcher: %w", err)
+	}
+	fw := &fileWatcher{
+		logger:     logger,
+		clock:      clock,
+		filesystem: filesystem,
+		watcher:    w,
+		subs:       subs,
+		recursive:  req.Recursive,
+		filter:     filter,
+		watched:    make(map[string]bool),
+	}
+	for _, sub := range subs {
+		if sub.isDir {
+			err = fw.addDir(sub.path)
+		} else {
+			// Watch the parent directory so that the file keeps being
+			// observed if it is replaced, e.g. by an editor saving atomically.
+			err = fw.add(filepath.Dir(sub.path))
+		}
+		if err != nil {
+			_ = fw.Close()
+			if errors.Is(err, errTooManyWatchedDirectories) {
+				return nil, http.StatusBadRequest, err
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+	return fw, 0, nil
+}
+
+func (fw *fileWatcher) Close() error {
+	return fw.watcher.Close()
+}
+
+func (fw *fileWatcher) add(dir string) error {
+	if fw.watched[dir] {
+		return nil
+	}
+	if len(fw.watched) >= workspacesdk.WatchMaxDirectories {
+		return errTooManyWatchedDirectories
+	}
+	if err := fw.watcher.Add(dir); err != nil {
+		return xerrors.Errorf("watch %q: %w", dir, err)
+	}
+	fw.watched[dir] = true
+	return nil
+}
+
+// addDir watches dir and, for recursive watches, all of its subdirectories
+// that are not excluded.
+func (fw *fileWatcher) addDir(dir string) error {
+	if !fw.recursive {
+		return fw.add(dir)
+	}
+	return afero.Walk(fw.filesystem, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			// The directory may have been removed while walking.
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != dir && !fw.match(path) {
+			return filepath.SkipDir
+		}
+		return fw.add(path)
+	})
+}
+
+// unwatch forgets dir and its subdirectories after they were removed or
+// renamed.
+func (fw *fileWatcher) unwatch(dir string) {
+	for path := range fw.watched {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			// Removed directories are dropped by fsnotify automatically, so
+			// errors are expected here.
+			_ = fw.watcher.Remove(path)
+			delete(fw.watched, path)
+		}
+	}
+}
+
+// match reports whether path is covered by a subscription and not excluded.
+func (fw *fileWatcher) match(path string) bool {
+	for _, sub := range fw.subs {
+		if !sub.isDir {
+			if path == sub.path && fw.filter.Match(filepath.Base(path)) {
+				return true
+			}
+			continue
+		}
+		rel, err := filepath.Rel(sub.path, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		if rel == "." {
+			return true
+		}
+		rel = filepath.ToSlash(rel)
+		if !fw.recursive && strings.Contains(rel, "/") {
+			continue
+		}
+		if fw.filter.Match(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// toEvent converts an fsnotify event, registering watches for directories
+// created inside recursive watches.
+func (fw *fileWatcher) toEvent(ev fsnotify.Event) (workspacesdk.WatchEvent, bool) {
+	path := filepath.Clean(ev.Name)
+	if !fw.match(path) {
+		return workspacesdk.WatchEvent{}, false
+	}
+
+	out := workspacesdk.WatchEvent{Path: path}
+	switch {
+	case ev.Has(fsnotify.Create):
+		out.Op = workspacesdk.WatchOpCreate
+		if stat, err := fw.filesystem.Stat(path); err == nil && stat.IsDir() {
+			out.IsDir = true
+			if fw.recursive {
+				if err := fw.addDir(path); err != nil {
+					fw.logger.Warn(context.Background(), "unable to watch new directory", slog.F("path", path), slog.Error(err))
+				}
+			}
+		}
+	case ev.Has(fsnotify.Write):
+		out.Op = workspacesdk.WatchOpModify
+	case ev.Has(fsnotify.Remove):
+		out.Op = workspacesdk.WatchOpDelete
+		out.IsDir = fw.watched[path]
+		fw.unwatch(path)
+	case ev.Has(fsnotify.Rename):
+		out.Op = workspacesdk.WatchOpRename
+		out.IsDir = fw.watched[path]
+		fw.unwatch(path)
+	default:
+		// Permission changes are not reported.
+		return workspacesdk.WatchEvent{}, false
+	}
+	return out, true
+}
+
+// run sends events until the context is canceled or send fails. Events are
+// collected for the debounce duration after the first pending event and
+// coalesced per path.
+func (fw *fileWatcher) run(ctx context.Context, debounce time.Duration, send func(workspacesdk.WatchEvent) error) error {
+	var (
+		pending = make(map[string]workspacesdk.WatchEvent)
+		order   []string
+		timer   *quartz.Timer
+		flushC  <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	queue := func(ev workspacesdk.WatchEvent) {
+		prev, ok := pending[ev.Path]
+		if !ok {
+			pending[ev.Path] = ev
+			order = append(order, ev.Path)
+		} else if op, keep := coalesceWatchOp(prev.Op, ev.Op); keep {
+			ev.Op = op
+			ev.IsDir = ev.IsDir || prev.IsDir
+			pending[ev.Path] = ev
+		} else {
+			delete(pending, ev.Path)
+		}
+		if flushC == nil {
+			timer = fw.clock.NewTimer(debounce, "agentfiles", "watch")
+			flushC = timer.C
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-fw.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if out, ok := fw.toEvent(ev); ok {
+				queue(out)
+			}
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				queue(workspacesdk.WatchEvent{Op: workspacesdk.WatchOpOverflow})
+				continue
+			}
+			fw.logger.Warn(ctx, "file watcher error", slog.Error(err))
+		case <-flushC:
+			flushC = nil
+			for _, path := range order {
+				ev, ok := pending[path]
+				if !ok {
+					continue
+				}
+				delete(pending, path)
+				if err := send(ev); err != nil {
+					return xerrors.Errorf("send event: %w", err)
+				}
+			}
+			order = order[:0]
+		}
+	}
+}
+
+// coalesceWatchOp combines two consecutive operations on the same path. It
+// returns false if the operations cancel out, e.g. a file that was created and
+// deleted within the debounce window.
+func coalesceWatchOp(prev, next workspacesdk.WatchOp) (workspacesdk.WatchOp, bool) {
+	switch prev {
+	case workspacesdk.WatchOpCreate:
+		switch next {
+		case workspacesdk.WatchOpDelete, workspacesdk.WatchOpRename:
+			return "", false
+		default:
+			return workspacesdk.WatchOpCreate, true
+		}
+	case workspacesdk.WatchOpDelete, workspacesdk.WatchOpRename:
+		switch next {
+		case workspacesdk.WatchOpCreate, workspacesdk.WatchOpModify:
+			// The path was replaced, e.g. by an editor saving atomically.
+			return workspacesdk.WatchOpModify, true
+		default:
+			return next, true
+		}
+	case workspacesdk.WatchOpOverflow:
+		return workspacesdk.WatchOpOverflow, true
+	default:
+		if next == workspacesdk.WatchOpCreate {
+			return workspacesdk.WatchOpModify, true
+		}
+		return next, true
+	}
+}
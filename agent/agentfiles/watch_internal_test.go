@@ -0,0 +1,39 @@
+package agentfiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+func TestCoalesceWatchOp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prev, next workspacesdk.WatchOp
+		expected   workspacesdk.WatchOp
+		keep       bool
+	}{
+		{workspacesdk.WatchOpCreate, workspacesdk.WatchOpModify, workspacesdk.WatchOpCreate, true},
+		{workspacesdk.WatchOpCreate, workspacesdk.WatchOpDelete, "", false},
+		{workspacesdk.WatchOpCreate, workspacesdk.WatchOpRename, "", false},
+		{workspacesdk.WatchOpModify, workspacesdk.WatchOpModify, workspacesdk.WatchOpModify, true},
+		{workspacesdk.WatchOpModify, workspacesdk.WatchOpDelete, workspacesdk.WatchOpDelete, true},
+		{workspacesdk.WatchOpModify, workspacesdk.WatchOpCreate, workspacesdk.WatchOpModify, true},
+		{workspacesdk.WatchOpDelete, workspacesdk.WatchOpCreate, workspacesdk.WatchOpModify, true},
+		{workspacesdk.WatchOpRename, workspacesdk.WatchOpCreate, workspacesdk.WatchOpModify, true},
+		{workspacesdk.WatchOpRename, workspacesdk.WatchOpDelete, workspacesdk.WatchOpDelete, true},
+		{workspacesdk.WatchOpOverflow, workspacesdk.WatchOpOverflow, workspacesdk.WatchOpOverflow, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.prev)+"_"+string(tt.next), func(t *testing.T) {
+			t.Parallel()
+
+			op, keep := coalesceWatchOp(tt.prev, tt.next)
+			require.Equal(t, tt.keep, keep)
+			require.Equal(t, tt.expected, op)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package agentfiles_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
+	"github.com/coder/websocket"
+)
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		tmpdir := t.TempDir()
+		logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+		api := agentfiles.NewAPI(logger, afero.NewOsFs())
+
+		tests := []struct {
+			name    string
+			query   url.Values
+			errCode int
+			error   string
+		}{
+			{
+				name:    "NoPath",
+				query:   url.Values{},
+				errCode: http.StatusBadRequest,
+				error:   "at least one path is required",
+			},
+			{
+				name:    "RelativePath",
+				query:   url.Values{"path": {"relative"}},
+				errCode: http.StatusBadRequest,
+				error:   "file path must be absolute",
+			},
+			{
+				name:    "NonExistent",
+				query:   url.Values{"path": {filepath.Join(tmpdir, "does-not-exist")}},
+				errCode: http.StatusNotFound,
+			},
+			{
+				name:    "InvalidDebounce",
+				query:   url.Values{"path": {tmpdir}, "debounce": {"soon"}},
+				errCode: http.StatusBadRequest,
+				error:   "Query parameters have invalid values",
+			},
+			{
+				name:    "DebounceTooLong",
+				query:   url.Values{"path": {tmpdir}, "debounce": {"1h"}},
+				errCode: http.StatusBadRequest,
+				error:   "debounce must be between",
+			},
+			{
+				name:    "InvalidPattern",
+				query:   url.Values{"path": {tmpdir}, "exclude": {"["}},
+				errCode: http.StatusBadRequest,
+				error:   "invalid pattern",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctx := testutil.Context(t, testutil.WaitShort)
+				w := httptest.NewRecorder()
+				r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/watch?"+tt.query.Encode(), nil)
+				api.Routes().ServeHTTP(w, r)
+				requireErrorResponse(t, w, tt.errCode, tt.error)
+			})
+		}
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS != "linux" {
+			t.Skip("event semantics are specific to inotify")
+		}
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "node_modules"), 0o755))
+
+		events := dialWatch(ctx, t, url.Values{
+			"path":      {dir},
+			"recursive": {"true"},
+			"exclude":   {"node_modules"},
+			"debounce":  {"50ms"},
+		})
+
+		// Writes after a create within the debounce window are coalesced.
+		file := filepath.Join(dir, "a.txt")
+		require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+		require.NoError(t, os.WriteFile(file, []byte("ab"), 0o600))
+		require.Equal(t, workspacesdk.WatchEvent{Path: file, Op: workspacesdk.WatchOpCreate}, testutil.RequireReceive(ctx, t, events))
+
+		// Excluded directories are not watched.
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "dep.js"), []byte("x"), 0o600))
+
+		// New directories are watched.
+		sub := filepath.Join(dir, "sub")
+		require.NoError(t, os.Mkdir(sub, 0o755))
+		require.Equal(t, workspacesdk.WatchEvent{Path: sub, Op: workspacesdk.WatchOpCreate, IsDir: true}, testutil.RequireReceive(ctx, t, events))
+		nested := filepath.Join(sub, "b.txt")
+		require.NoError(t, os.WriteFile(nested, []byte("b"), 0o600))
+		require.Equal(t, workspacesdk.WatchEvent{Path: nested, Op: workspacesdk.WatchOpCreate}, testutil.RequireReceive(ctx, t, events))
+
+		require.NoError(t, os.Rename(file, filepath.Join(sub, "c.txt")))
+		got := []workspacesdk.WatchEvent{
+			testutil.RequireReceive(ctx, t, events),
+			testutil.RequireReceive(ctx, t, events),
+		}
+		require.ElementsMatch(t, []workspacesdk.WatchEvent{
+			{Path: file, Op: workspacesdk.WatchOpRename},
+			{Path: filepath.Join(sub, "c.txt"), Op: workspacesdk.WatchOpCreate},
+		}, got)
+
+		require.NoError(t, os.RemoveAll(sub))
+		got = nil
+		for len(got) < 3 {
+			got = append(got, testutil.RequireReceive(ctx, t, events))
+		}
+		require.ElementsMatch(t, []workspacesdk.WatchEvent{
+			{Path: nested, Op: workspacesdk.WatchOpDelete},
+			{Path: filepath.Join(sub, "c.txt"), Op: workspacesdk.WatchOpDelete},
+			{Path: sub, Op: workspacesdk.WatchOpDelete, IsDir: true},
+		}, got)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS != "linux" {
+			t.Skip("event semantics are specific to inotify")
+		}
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		dir := t.TempDir()
+		file := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+		sibling := filepath.Join(dir, "other.yaml")
+		require.NoError(t, os.WriteFile(sibling, []byte("a"), 0o600))
+
+		events := dialWatch(ctx, t, url.Values{
+			"path":     {file},
+			"debounce": {"10ms"},
+		})
+
+		require.NoError(t, os.WriteFile(sibling, []byte("b"), 0o600))
+		require.NoError(t, os.WriteFile(file, []byte("b"), 0o600))
+		require.Equal(t, workspacesdk.WatchEvent{Path: file, Op: workspacesdk.WatchOpModify}, testutil.RequireReceive(ctx, t, events))
+
+		// Replacing the file, as editors do when saving atomically, is still
+		// observed since the parent directory is watched.
+		tmp := filepath.Join(dir, ".config.yaml.tmp")
+		require.NoError(t, os.WriteFile(tmp, []byte("c"), 0o600))
+		require.NoError(t, os.Rename(tmp, file))
+		require.Equal(t, workspacesdk.WatchEvent{Path: file, Op: workspacesdk.WatchOpCreate}, testutil.RequireReceive(ctx, t, events))
+	})
+
+	t.Run("Local", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		logger := slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+		dir := t.TempDir()
+
+		events, closer, err := agentfiles.Watch(ctx, logger, quartz.NewReal(), afero.NewOsFs(), workspacesdk.WatchRequest{
+			Paths:    []string{dir},
+			Debounce: 10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		// The watch is established once Watch returns.
+		file := filepath.Join(dir, "a.txt")
+		require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+		require.Equal(t, workspacesdk.WatchEvent{Path: file, Op: workspacesdk.WatchOpCreate}, testutil.RequireReceive(ctx, t, events))
+
+		require.NoError(t, closer.Close())
+		_, ok := <-events
+		require.False(t, ok, "events should be closed")
+
+		_, _, err = agentfiles.Watch(ctx, logger, quartz.NewReal(), afero.NewOsFs(), workspacesdk.WatchRequest{})
+		require.ErrorContains(t, err, "at least one path is required")
+	})
+
+	t.Run("Debounce", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		logger := slogtest.Make(t, nil).Leveled(slog.LevelDebug)
+		clock := quartz.NewMock(t)
+		trap := clock.Trap().NewTimer("agentfiles", "watch")
+		dir := t.TempDir()
+		debounce := time.Second
+
+		events, closer, err := agentfiles.Watch(ctx, logger, clock, afero.NewOsFs(), workspacesdk.WatchRequest{
+			Paths:    []string{dir},
+			Debounce: debounce,
+		})
+		require.NoError(t, err)
+		defer closer.Close()
+
+		// The first event starts the debounce timer.
+		file := filepath.Join(dir, "a.txt")
+		require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+		trap.MustWait(ctx).MustRelease(ctx)
+		trap.Close()
+
+		// Events arriving before the timer fires are coalesced with the
+		// pending create.
+		require.NoError(t, os.WriteFile(file, []byte("ab"), 0o600))
+		select {
+		case ev := <-events:
+			t.Fatalf("unexpected event before debounce elapsed: %+v", ev)
+		default:
+		}
+
+		clock.Advance(debounce).MustWait(ctx)
+		require.Equal(t, workspacesdk.WatchEvent{Path: file, Op: workspacesdk.WatchOpCreate}, testutil.RequireReceive(ctx, t, events))
+	})
+}
+
+// dialWatch starts a watch against a real filesystem and returns the received
+// events.
+func dialWatch(ctx context.Context, t *testing.T, query url.Values) <-chan workspacesdk.WatchEvent {
+	t.Helper()
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	api := agentfiles.NewAPI(logger, afero.NewOsFs())
+	srv := httptest.NewServer(api.Routes())
+	t.Cleanup(srv.Close)
+
+	conn, res, err := websocket.Dial(ctx, srv.URL+"/watch?"+query.Encode(), nil)
+	require.NoError(t, err)
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+	t.Cleanup(func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	})
+
+	events := make(chan workspacesdk.WatchEvent, 16)
+	go func() {
+		defer close(events)
+		for {
+			_, msg, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			var ev workspacesdk.WatchEvent
+			if err := json.Unmarshal(msg, &ev); err != nil {
+				return
+			}
+			events <- ev
+		}
+	}()
+	return events
+}
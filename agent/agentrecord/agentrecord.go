@@ -0,0 +1,395 @@
+// Package agentrecord records interactive terminal sessions on the agent as
+// asciicast files and uploads them to coderd once the session ends.
+package agentrecord
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/asciicast"
+)
+
+const (
+	// DefaultMaxSize is the default maximum size of a single recording.
+	// Events past the limit are dropped and the recording is marked as
+	// truncated.
+	DefaultMaxSize = 32 << 20
+	// uploadChunkSize is the size of the parts a recording is uploaded in,
+	// well below the maximum agent API message size.
+	uploadChunkSize = 1 << 20
+	// pendingLimit is the number of finished recordings that are kept on disk
+	// while waiting to be uploaded, e.g. while coderd is unreachable.
+	pendingLimit = 64
+)
+
+// Config is the recording configuration of the workspace's template.
+type Config struct {
+	Enabled      bool
+	IncludeInput bool
+}
+
+// UploadClient is the part of the agent API used to upload recordings.
+type UploadClient interface {
+	UploadSessionRecording(ctx context.Context, req *proto.UploadSessionRecordingRequest) (*proto.UploadSessionRecordingResponse, error)
+}
+
+type Options struct {
+	Logger slog.Logger
+	// Config returns the current recording configuration. Recording is
+	// disabled if nil.
+	Config func() Config
+	// Dir is where recordings are written while the session is running.
+	// Defaults to os.TempDir().
+	Dir string
+	// MaxSize is the maximum size of a recording in bytes. Defaults to
+	// DefaultMaxSize.
+	MaxSize int64
+}
+
+// Manager creates recorders for sessions and uploads finished recordings.
+type Manager struct {
+	opts Options
+
+	mu      sync.Mutex
+	pending []*pendingRecording
+	update  chan struct{}
+}
+
+func NewManager(opts Options) *Manager {
+	if opts.Config == nil {
+		opts.Config = func() Config { return Config{} }
+	}
+	if opts.Dir == "" {
+		opts.Dir = os.TempDir()
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultMaxSize
+	}
+	return &Manager{
+		opts:   opts,
+		update: make(chan struct{}, 1),
+	}
+}
+
+// Start starts recording a session. It returns nil if recording is disabled
+// or the recording could not be created, all methods of Recorder are safe to
+// call on nil.
+func (m *Manager) Start(connectionID uuid.UUID, typ proto.Connection_Type, width, height int, command string) *Recorder {
+	if m == nil {
+		return nil
+	}
+	cfg := m.opts.Config()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	id := uuid.New()
+	logger := m.opts.Logger.With(slog.F("recording_id", id), slog.F("connection_id", connectionID))
+	f, err := os.CreateTemp(m.opts.Dir, "coder-session-*.cast")
+	if err != nil {
+		logger.Error(context.Background(), "create session recording file", slog.Error(err))
+		return nil
+	}
+
+	now := time.Now()
+	r := &Recorder{
+		manager: m,
+		logger:  logger,
+		file:    f,
+		limit:   m.opts.MaxSize,
+		start:   now,
+		rec: pendingRecording{
+			path:         f.Name(),
+			id:           id,
+			connectionID: connectionID,
+			typ:          typ,
+			startedAt:    now,
+			includeInput: cfg.IncludeInput,
+		},
+	}
+	r.buf = bufio.NewWriter(&countingWriter{w: f, n: &r.size})
+	r.w, err = asciicast.NewWriter(r.buf, asciicast.Header{
+		Width:     width,
+		Height:    height,
+		Timestamp: now.Unix(),
+		Command:   command,
+	})
+	if err != nil {
+		logger.Error(context.Background(), "write session recording header", slog.Error(err))
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil
+	}
+	logger.Debug(context.Background(), "started session recording")
+	return r
+}
+
+// Run uploads finished recordings until the context is canceled. Recordings
+// that fail to upload because the connection was lost are retried on the next
+// call.
+func (m *Manager) Run(ctx context.Context, client UploadClient) error {
+	for {
+		for {
+			m.mu.Lock()
+			if len(m.pending) == 0 {
+				m.mu.Unlock()
+				break
+			}
+			rec := m.pending[0]
+			m.mu.Unlock()
+
+			logger := m.opts.Logger.With(slog.F("recording_id", rec.id), slog.F("connection_id", rec.connectionID))
+			err := m.upload(ctx, client, rec)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// Don't fail the loop, just like connection reports, a
+				// recording that coderd rejects would otherwise be retried
+				// forever.
+				logger.Warn(ctx, "failed to upload session recording", slog.Error(err))
+			} else {
+				logger.Debug(ctx, "uploaded session recording", slog.F("size", rec.offset))
+			}
+
+			m.mu.Lock()
+			m.pending[0] = nil
+			m.pending = m.pending[1:]
+			m.mu.Unlock()
+			_ = os.Remove(rec.path)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.update:
+		}
+	}
+}
+
+func (m *Manager) upload(ctx context.Context, client UploadClient, rec *pendingRecording) error {
+	f, err := os.Open(rec.path)
+	if err != nil {
+		return xerrors.Errorf("open recording: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(rec.offset, io.SeekStart); err != nil {
+		return xerrors.Errorf("seek recording: %w", err)
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if errors.Is(err, io.EOF) && rec.offset > 0 {
+			return nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return xerrors.Errorf("read recording: %w", err)
+		}
+		req := &proto.UploadSessionRecordingRequest{
+			Id:     rec.id[:],
+			Offset: rec.offset,
+			Data:   buf[:n],
+		}
+		if rec.offset == 0 {
+			req.ConnectionId = rec.connectionID[:]
+			req.Type = rec.typ
+			req.StartedAt = timestamppb.New(rec.startedAt)
+			req.EndedAt = timestamppb.New(rec.endedAt)
+			req.IncludeInput = rec.includeInput
+			req.Truncated = rec.truncated
+		}
+		if _, err := client.UploadSessionRecording(ctx, req); err != nil {
+			return err
+		}
+		rec.offset += int64(n)
+		if n < len(buf) {
+			return nil
+		}
+	}
+}
+
+func (m *Manager) enqueue(rec *pendingRecording) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.pending) >= pendingLimit {
+		m.opts.Logger.Warn(context.Background(), "session recording upload buffer limit reached, dropping recording",
+			slog.F("limit", pendingLimit),
+			slog.F("recording_id", rec.id),
+			slog.F("connection_id", rec.connectionID),
+		)
+		_ = os.Remove(rec.path)
+		return
+	}
+	m.pending = append(m.pending, rec)
+	select {
+	case m.update <- struct{}{}:
+	default:
+	}
+}
+
+type pendingRecording struct {
+	path         string
+	id           uuid.UUID
+	connectionID uuid.UUID
+	typ          proto.Connection_Type
+	startedAt    time.Time
+	endedAt      time.Time
+	includeInput bool
+	truncated    bool
+	// offset is the number of bytes already uploaded. It is only accessed by
+	// the upload loop.
+	offset int64
+}
+
+// Recorder records a single session. It is safe for concurrent use and all
+// methods may be called on a nil Recorder.
+type Recorder struct {
+	manager *Manager
+	logger  slog.Logger
+	start   time.Time
+	limit   int64
+
+	mu     sync.Mutex
+	file   *os.File
+	buf    *bufio.Writer
+	w      *asciicast.Writer
+	size   int64
+	closed bool
+	rec    pendingRecording
+}
+
+// Output records data written to the terminal.
+func (r *Recorder) Output(p []byte) {
+	r.write(asciicast.EventTypeOutput, p)
+}
+
+// Input records data typed by the user. It is dropped unless the template
+// enables recording input.
+func (r *Recorder) Input(p []byte) {
+	if r == nil || !r.rec.includeInput {
+		return
+	}
+	r.write(asciicast.EventTypeInput, p)
+}
+
+// Resize records a change of the terminal size.
+func (r *Recorder) Resize(width, height int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.writable(0) {
+		return
+	}
+	err := r.w.WriteEvent(asciicast.Event{
+		Time: time.Since(r.start),
+		Type: asciicast.EventTypeResize,
+		Data: asciicast.ResizeData(width, height),
+	})
+	r.checkErr(err)
+}
+
+// OutputWriter returns a writer that records terminal output, for use with
+// io.TeeReader. Writes never fail.
+func (r *Recorder) OutputWriter() io.Writer {
+	return recorderWriter(r.Output)
+}
+
+// InputWriter returns a writer that records user input, for use with
+// io.TeeReader. Writes never fail.
+func (r *Recorder) InputWriter() io.Writer {
+	return recorderWriter(r.Input)
+}
+
+func (r *Recorder) write(typ asciicast.EventType, p []byte) {
+	if r == nil || len(p) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.writable(len(p)) {
+		return
+	}
+	r.checkErr(r.w.WriteData(time.Since(r.start), typ, p))
+}
+
+// writable reports whether an event of about n bytes may be written, marking
+// the recording as truncated once it would exceed the limit. The caller must
+// hold r.mu.
+func (r *Recorder) writable(n int) bool {
+	if r.closed || r.rec.truncated {
+		return false
+	}
+	if r.size+int64(r.buf.Buffered())+int64(n) > r.limit {
+		r.rec.truncated = true
+		r.logger.Info(context.Background(), "session recording reached size limit, truncating", slog.F("limit", r.limit))
+		return false
+	}
+	return true
+}
+
+// checkErr stops recording after a write error. The caller must hold r.mu.
+func (r *Recorder) checkErr(err error) {
+	if err == nil {
+		return
+	}
+	r.logger.Warn(context.Background(), "write session recording", slog.Error(err))
+	r.rec.truncated = true
+}
+
+// Close finishes the recording and queues it for upload.
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.rec.endedAt = time.Now()
+
+	err := r.buf.Flush()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		r.logger.Error(context.Background(), "finish session recording", slog.Error(err))
+		_ = os.Remove(r.rec.path)
+		return
+	}
+	rec := r.rec
+	r.manager.enqueue(&rec)
+}
+
+type recorderWriter func(p []byte)
+
+func (w recorderWriter) Write(p []byte) (int, error) {
+	w(p)
+	return len(p), nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
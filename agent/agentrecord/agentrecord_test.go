@@ -0,0 +1,204 @@
+package agentrecord_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/agent/agentrecord"
+	"github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/asciicast"
+	"github.com/coder/coder/v2/testutil"
+)
+
+type fakeUploader struct {
+	mu    sync.Mutex
+	fail  error
+	reqs  []*proto.UploadSessionRecordingRequest
+	calls chan struct{}
+}
+
+func newFakeUploader() *fakeUploader {
+	return &fakeUploader{calls: make(chan struct{}, 64)}
+}
+
+func (f *fakeUploader) UploadSessionRecording(_ context.Context, req *proto.UploadSessionRecordingRequest) (*proto.UploadSessionRecordingResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	defer func() { f.calls <- struct{}{} }()
+	if f.fail != nil {
+		return nil, f.fail
+	}
+	f.reqs = append(f.reqs, req)
+	return &proto.UploadSessionRecordingResponse{}, nil
+}
+
+func (f *fakeUploader) requests() []*proto.UploadSessionRecordingRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*proto.UploadSessionRecordingRequest(nil), f.reqs...)
+}
+
+func readEvents(t *testing.T, data []byte) (asciicast.Header, []asciicast.Event) {
+	t.Helper()
+	r, header, err := asciicast.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	var events []asciicast.Event
+	for {
+		ev, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		ev.Time = 0
+		events = append(events, ev)
+	}
+	return header, events
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		m := agentrecord.NewManager(agentrecord.Options{
+			Logger: slogtest.Make(t, nil),
+			Dir:    t.TempDir(),
+		})
+		r := m.Start(uuid.New(), proto.Connection_SSH, 80, 24, "bash")
+		require.Nil(t, r)
+		// A nil recorder is a no-op.
+		r.Output([]byte("hello"))
+		r.Input([]byte("hello"))
+		r.Resize(1, 1)
+		r.Close()
+
+		var nilManager *agentrecord.Manager
+		require.Nil(t, nilManager.Start(uuid.New(), proto.Connection_SSH, 80, 24, ""))
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		m := agentrecord.NewManager(agentrecord.Options{
+			Logger: slogtest.Make(t, nil),
+			Config: func() agentrecord.Config { return agentrecord.Config{Enabled: true} },
+			Dir:    dir,
+		})
+		connID := uuid.New()
+		r := m.Start(connID, proto.Connection_SSH, 80, 24, "bash")
+		require.NotNil(t, r)
+		r.Output([]byte("hello\r\n"))
+		// Input is not recorded unless enabled.
+		r.Input([]byte("secret\r"))
+		r.Resize(100, 50)
+		r.Close()
+		// Closing twice is fine.
+		r.Close()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		uploader := newFakeUploader()
+		done := make(chan error, 1)
+		go func() { done <- m.Run(runCtx, uploader) }()
+		testutil.TryReceive(ctx, t, uploader.calls)
+		cancel()
+		require.ErrorIs(t, testutil.TryReceive(ctx, t, done), context.Canceled)
+
+		reqs := uploader.requests()
+		require.Len(t, reqs, 1)
+		req := reqs[0]
+		require.Equal(t, connID[:], req.GetConnectionId())
+		require.Equal(t, proto.Connection_SSH, req.GetType())
+		require.EqualValues(t, 0, req.GetOffset())
+		require.False(t, req.GetIncludeInput())
+		require.False(t, req.GetTruncated())
+		require.False(t, req.GetEndedAt().AsTime().Before(req.GetStartedAt().AsTime()))
+
+		header, events := readEvents(t, req.GetData())
+		require.Equal(t, 80, header.Width)
+		require.Equal(t, 24, header.Height)
+		require.Equal(t, "bash", header.Command)
+		require.Equal(t, []asciicast.Event{
+			{Type: asciicast.EventTypeOutput, Data: "hello\r\n"},
+			{Type: asciicast.EventTypeResize, Data: "100x50"},
+		}, events)
+
+		// The file is removed once uploaded.
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("InputAndTruncate", func(t *testing.T) {
+		t.Parallel()
+
+		m := agentrecord.NewManager(agentrecord.Options{
+			Logger: slogtest.Make(t, nil),
+			Config: func() agentrecord.Config {
+				return agentrecord.Config{Enabled: true, IncludeInput: true}
+			},
+			Dir:     t.TempDir(),
+			MaxSize: 200,
+		})
+		r := m.Start(uuid.New(), proto.Connection_RECONNECTING_PTY, 80, 24, "")
+		require.NotNil(t, r)
+		_, _ = r.InputWriter().Write([]byte("ls\r"))
+		_, _ = r.OutputWriter().Write(bytes.Repeat([]byte("x"), 500))
+		// Smaller events after the limit are dropped too.
+		r.Output([]byte("y"))
+		r.Close()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		uploader := newFakeUploader()
+		go func() { _ = m.Run(runCtx, uploader) }()
+		testutil.TryReceive(ctx, t, uploader.calls)
+
+		req := uploader.requests()[0]
+		require.True(t, req.GetIncludeInput())
+		require.True(t, req.GetTruncated())
+		require.LessOrEqual(t, len(req.GetData()), 200)
+		_, events := readEvents(t, req.GetData())
+		require.Equal(t, []asciicast.Event{
+			{Type: asciicast.EventTypeInput, Data: "ls\r"},
+		}, events)
+	})
+
+	t.Run("UploadError", func(t *testing.T) {
+		t.Parallel()
+
+		m := agentrecord.NewManager(agentrecord.Options{
+			Logger: slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}),
+			Config: func() agentrecord.Config { return agentrecord.Config{Enabled: true} },
+			Dir:    t.TempDir(),
+		})
+		m.Start(uuid.New(), proto.Connection_SSH, 80, 24, "").Close()
+		m.Start(uuid.New(), proto.Connection_SSH, 80, 24, "").Close()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		uploader := newFakeUploader()
+		uploader.fail = xerrors.New("rejected")
+		go func() { _ = m.Run(runCtx, uploader) }()
+
+		// A rejected recording is dropped rather than blocking the ones
+		// queued after it.
+		testutil.TryReceive(ctx, t, uploader.calls)
+		testutil.TryReceive(ctx, t, uploader.calls)
+	})
+}
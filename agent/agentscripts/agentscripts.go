@@ -23,6 +23,7 @@ import (
 	"cdr.dev/slog/v3"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/agent/unit"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
@@ -56,10 +57,17 @@ type Options struct {
 	SSHServer       *agentssh.Server
 	Filesystem      afero.Fs
 	GetScriptLogger func(logSourceID uuid.UUID) ScriptLogger
+	// UnitManager tracks the startup scripts that declare a unit name, so
+	// that they only start once the units they depend on have completed.
+	// If nil, the runner uses a private manager.
+	UnitManager *unit.Manager
 }
 
 // New creates a runner for the provided scripts.
 func New(opts Options) *Runner {
+	if opts.UnitManager == nil {
+		opts.UnitManager = unit.NewManager()
+	}
 	cronCtx, cronCtxCancel := context.WithCancel(context.Background())
 	return &Runner{
 		Options:       opts,
@@ -211,7 +219,7 @@ func (r *Runner) Execute(ctx context.Context, option ExecuteOption) error {
 		return initErr
 	}
 
-	var eg errgroup.Group
+	var scripts []codersdk.WorkspaceAgentScript
 	for _, script := range r.scripts {
 		runScript := (option == ExecuteStartScripts && script.RunOnStart) ||
 			(option == ExecuteStopScripts && script.RunOnStop) ||
@@ -221,9 +229,25 @@ func (r *Runner) Execute(ctx context.Context, option ExecuteOption) error {
 		if !runScript {
 			continue
 		}
+		scripts = append(scripts, script)
+	}
+
+	// Only startup scripts are ordered by their dependencies. Stop and cron
+	// scripts run independently of each other.
+	ordered := option == ExecuteStartScripts
+	if ordered {
+		r.registerUnits(ctx, scripts)
+	}
 
+	var eg errgroup.Group
+	for _, script := range scripts {
 		eg.Go(func() error {
-			err := r.trackRun(ctx, script, option)
+			var err error
+			if ordered && script.UnitName != "" {
+				err = r.trackUnitRun(ctx, script, option)
+			} else {
+				err = r.trackRun(ctx, script, option)
+			}
 			if err != nil {
 				return xerrors.Errorf("run agent script %q: %w", script.LogSourceID, err)
 			}
@@ -233,6 +257,71 @@ func (r *Runner) Execute(ctx context.Context, option ExecuteOption) error {
 	return eg.Wait()
 }
 
+// registerUnits registers each script that declares a unit name with the unit
+// manager, along with its dependencies. Dependencies on units that are not
+// part of this set of scripts are ignored, since nothing would ever complete
+// them.
+func (r *Runner) registerUnits(ctx context.Context, scripts []codersdk.WorkspaceAgentScript) {
+	units := make(map[string]struct{}, len(scripts))
+	for _, script := range scripts {
+		if script.UnitName == "" {
+			continue
+		}
+		units[script.UnitName] = struct{}{}
+		err := r.UnitManager.Register(unit.ID(script.UnitName))
+		if err != nil && !errors.Is(err, unit.ErrUnitAlreadyRegistered) {
+			r.Logger.Warn(ctx, "register script unit", slog.F("unit", script.UnitName), slog.Error(err))
+		}
+	}
+
+	for _, script := range scripts {
+		if script.UnitName == "" {
+			continue
+		}
+		for _, dependsOn := range script.DependsOn {
+			if _, ok := units[dependsOn]; !ok {
+				r.Logger.Warn(ctx, "ignoring dependency on unit that is not a startup script",
+					slog.F("unit", script.UnitName), slog.F("depends_on", dependsOn))
+				continue
+			}
+			err := r.UnitManager.AddDependency(unit.ID(script.UnitName), unit.ID(dependsOn), unit.StatusComplete)
+			if err != nil {
+				r.Logger.Warn(ctx, "add script unit dependency",
+					slog.F("unit", script.UnitName), slog.F("depends_on", dependsOn), slog.Error(err))
+			}
+		}
+	}
+}
+
+// trackUnitRun waits for the dependencies of the script's unit to complete,
+// runs the script and reports the outcome to the unit manager. If a
+// dependency fails, the script is not run.
+func (r *Runner) trackUnitRun(ctx context.Context, script codersdk.WorkspaceAgentScript, option ExecuteOption) error {
+	id := unit.ID(script.UnitName)
+	logger := r.Logger.With(slog.F("unit", id), slog.F("log_source_id", script.LogSourceID))
+
+	err := r.UnitManager.WaitReady(ctx, id)
+	if err != nil {
+		return xerrors.Errorf("wait for dependencies of unit %q: %w", id, err)
+	}
+
+	err = r.UnitManager.UpdateStatus(id, unit.StatusStarted)
+	if err != nil && !errors.Is(err, unit.ErrSameStatusAlreadySet) {
+		logger.Warn(ctx, "mark script unit as started", slog.Error(err))
+	}
+
+	runErr := r.trackRun(ctx, script, option)
+	if runErr != nil {
+		err = r.UnitManager.Fail(id, runErr.Error())
+	} else {
+		err = r.UnitManager.UpdateStatus(id, unit.StatusComplete)
+	}
+	if err != nil && !errors.Is(err, unit.ErrSameStatusAlreadySet) {
+		logger.Warn(ctx, "update script unit status", slog.Error(err))
+	}
+	return runErr
+}
+
 // trackRun wraps "run" with metrics.
 func (r *Runner) trackRun(ctx context.Context, script codersdk.WorkspaceAgentScript, option ExecuteOption) error {
 	err := r.run(ctx, script, option)
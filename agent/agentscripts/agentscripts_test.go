@@ -19,6 +19,7 @@ import (
 	"github.com/coder/coder/v2/agent/agentscripts"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/agent/unit"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
 	"github.com/coder/coder/v2/testutil"
@@ -152,6 +153,113 @@ func TestScriptReportsTiming(t *testing.T) {
 	require.GreaterOrEqual(t, timing.End.AsTime(), timing.Start.AsTime())
 }
 
+func TestExecuteUnitDependencies(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("scripts use sh syntax")
+	}
+
+	t.Run("WaitsForDependencies", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		fLogger := newFakeScriptLogger()
+		manager := unit.NewManager()
+		runner := setupWithUnitManager(t, func(uuid.UUID) agentscripts.ScriptLogger {
+			return fLogger
+		}, manager)
+		defer runner.Close()
+
+		aAPI := agenttest.NewFakeAgentAPI(t, testutil.Logger(t), nil, nil)
+		err := runner.Init([]codersdk.WorkspaceAgentScript{{
+			LogSourceID: uuid.New(),
+			Script:      "echo second",
+			RunOnStart:  true,
+			UnitName:    "coder_script.second",
+			DependsOn:   []string{"coder_script.first"},
+		}, {
+			LogSourceID: uuid.New(),
+			Script:      "sleep 0.5 && echo first",
+			RunOnStart:  true,
+			UnitName:    "coder_script.first",
+		}}, aAPI.ScriptCompleted)
+		require.NoError(t, err)
+		require.NoError(t, runner.Execute(ctx, agentscripts.ExecuteStartScripts))
+
+		first := testutil.TryReceive(ctx, t, fLogger.logs)
+		second := testutil.TryReceive(ctx, t, fLogger.logs)
+		require.Equal(t, "first", first.Output)
+		require.Equal(t, "second", second.Output)
+
+		for _, id := range []unit.ID{"coder_script.first", "coder_script.second"} {
+			u, err := manager.Unit(id)
+			require.NoError(t, err)
+			require.Equal(t, unit.StatusComplete, u.Status())
+		}
+	})
+
+	t.Run("FailedDependencyBlocksScript", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		fLogger := newFakeScriptLogger()
+		manager := unit.NewManager()
+		runner := setupWithUnitManager(t, func(uuid.UUID) agentscripts.ScriptLogger {
+			return fLogger
+		}, manager)
+		defer runner.Close()
+
+		aAPI := agenttest.NewFakeAgentAPI(t, testutil.Logger(t), nil, nil)
+		err := runner.Init([]codersdk.WorkspaceAgentScript{{
+			LogSourceID: uuid.New(),
+			Script:      "exit 1",
+			RunOnStart:  true,
+			UnitName:    "coder_script.first",
+		}, {
+			LogSourceID: uuid.New(),
+			Script:      "echo second",
+			RunOnStart:  true,
+			UnitName:    "coder_script.second",
+			DependsOn:   []string{"coder_script.first"},
+		}}, aAPI.ScriptCompleted)
+		require.NoError(t, err)
+		require.Error(t, runner.Execute(ctx, agentscripts.ExecuteStartScripts))
+
+		first, err := manager.Unit("coder_script.first")
+		require.NoError(t, err)
+		require.Equal(t, unit.StatusFailed, first.Status())
+		second, err := manager.Unit("coder_script.second")
+		require.NoError(t, err)
+		require.Equal(t, unit.StatusBlocked, second.Status())
+
+		// The dependent script never ran.
+		select {
+		case log := <-fLogger.logs:
+			t.Fatalf("unexpected log from blocked script: %q", log.Output)
+		default:
+		}
+	})
+
+	t.Run("IgnoresUnknownDependencies", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		runner := setup(t, nil)
+		defer runner.Close()
+
+		aAPI := agenttest.NewFakeAgentAPI(t, testutil.Logger(t), nil, nil)
+		err := runner.Init([]codersdk.WorkspaceAgentScript{{
+			LogSourceID: uuid.New(),
+			Script:      "true",
+			RunOnStart:  true,
+			UnitName:    "coder_script.only",
+			DependsOn:   []string{"coder_script.cron_only"},
+		}}, aAPI.ScriptCompleted)
+		require.NoError(t, err)
+		require.NoError(t, runner.Execute(ctx, agentscripts.ExecuteStartScripts))
+	})
+}
+
 // TestCronClose exists because cron.Run() can happen after cron.Close().
 // If this happens, there used to be a deadlock.
 func TestCronClose(t *testing.T) {
@@ -298,6 +406,11 @@ func (*executeOptionTestLogger) Flush(context.Context) error {
 }
 
 func setup(t *testing.T, getScriptLogger func(logSourceID uuid.UUID) agentscripts.ScriptLogger) *agentscripts.Runner {
+	t.Helper()
+	return setupWithUnitManager(t, getScriptLogger, nil)
+}
+
+func setupWithUnitManager(t *testing.T, getScriptLogger func(logSourceID uuid.UUID) agentscripts.ScriptLogger, unitManager *unit.Manager) *agentscripts.Runner {
 	t.Helper()
 	if getScriptLogger == nil {
 		// noop
@@ -319,6 +432,7 @@ func setup(t *testing.T, getScriptLogger func(logSourceID uuid.UUID) agentscript
 		SSHServer:       s,
 		Filesystem:      fs,
 		GetScriptLogger: getScriptLogger,
+		UnitManager:     unitManager,
 	})
 }
 
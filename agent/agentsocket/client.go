@@ -2,8 +2,10 @@ package agentsocket
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"storj.io/drpc"
 	"storj.io/drpc/drpcconn"
 
@@ -15,7 +17,8 @@ import (
 type Option func(*options)
 
 type options struct {
-	path string
+	path        string
+	unitManager *unit.Manager
 }
 
 // WithPath sets the socket path. If not provided or empty, the client will
@@ -29,6 +32,15 @@ func WithPath(path string) Option {
 	}
 }
 
+// WithUnitManager sets the unit manager used by the server to track unit
+// dependencies. If not provided, the server creates its own. This option has
+// no effect on clients.
+func WithUnitManager(m *unit.Manager) Option {
+	return func(opts *options) {
+		opts.unitManager = m
+	}
+}
+
 // Client provides a client for communicating with the workspace agentsocket API.
 type Client struct {
 	client proto.DRPCAgentSocketClient
@@ -79,10 +91,20 @@ func (c *Client) SyncStart(ctx context.Context, unitName unit.ID) error {
 
 // SyncWant declares a dependency between units.
 func (c *Client) SyncWant(ctx context.Context, unitName, dependsOn unit.ID) error {
-	_, err := c.client.SyncWant(ctx, &proto.SyncWantRequest{
+	return c.SyncWantWithTimeout(ctx, unitName, dependsOn, 0)
+}
+
+// SyncWantWithTimeout declares a dependency between units. If timeout is
+// positive, the unit is marked as failed if it has not completed in time.
+func (c *Client) SyncWantWithTimeout(ctx context.Context, unitName, dependsOn unit.ID, timeout time.Duration) error {
+	req := &proto.SyncWantRequest{
 		Unit:      string(unitName),
 		DependsOn: string(dependsOn),
-	})
+	}
+	if timeout > 0 {
+		req.Timeout = durationpb.New(timeout)
+	}
+	_, err := c.client.SyncWant(ctx, req)
 	return err
 }
 
@@ -94,6 +116,31 @@ func (c *Client) SyncComplete(ctx context.Context, unitName unit.ID) error {
 	return err
 }
 
+// SyncFail marks a unit as failed in the dependency graph.
+func (c *Client) SyncFail(ctx context.Context, unitName unit.ID, reason string) error {
+	_, err := c.client.SyncFail(ctx, &proto.SyncFailRequest{
+		Unit:   string(unitName),
+		Reason: reason,
+	})
+	return err
+}
+
+// SyncReset removes all units and dependencies, including any state persisted
+// across agent restarts.
+func (c *Client) SyncReset(ctx context.Context) error {
+	_, err := c.client.SyncReset(ctx, &proto.SyncResetRequest{})
+	return err
+}
+
+// SyncGraph returns the dependency graph of all units in DOT format.
+func (c *Client) SyncGraph(ctx context.Context) (string, error) {
+	resp, err := c.client.SyncGraph(ctx, &proto.SyncGraphRequest{})
+	if err != nil {
+		return "", xerrors.Errorf("sync graph: %w", err)
+	}
+	return resp.Dot, nil
+}
+
 // SyncReady requests whether a unit is ready to be started. That is, all dependencies are satisfied.
 func (c *Client) SyncReady(ctx context.Context, unitName unit.ID) (bool, error) {
 	resp, err := c.client.SyncReady(ctx, &proto.SyncReadyRequest{
@@ -125,10 +172,12 @@ func (c *Client) SyncStatus(ctx context.Context, unitName unit.ID) (SyncStatusRe
 	}
 
 	return SyncStatusResponse{
-		UnitName:     unitName,
-		Status:       unit.Status(resp.Status),
-		IsReady:      resp.IsReady,
-		Dependencies: dependencies,
+		UnitName:      unitName,
+		Status:        unit.Status(resp.Status),
+		IsReady:       resp.IsReady,
+		Dependencies:  dependencies,
+		FailureReason: resp.FailureReason,
+		BlockedBy:     unit.ID(resp.BlockedBy),
 	}, nil
 }
 
@@ -138,6 +187,10 @@ type SyncStatusResponse struct {
 	Status       unit.Status      `table:"status" json:"status"`
 	IsReady      bool             `table:"ready" json:"is_ready"`
 	Dependencies []DependencyInfo `table:"dependencies" json:"dependencies"`
+	// FailureReason is set when Status is unit.StatusFailed.
+	FailureReason string `table:"-" json:"failure_reason,omitempty"`
+	// BlockedBy is set when Status is unit.StatusBlocked.
+	BlockedBy unit.ID `table:"-" json:"blocked_by,omitempty"`
 }
 
 // DependencyInfo contains information about a unit dependency.
@@ -42,8 +42,11 @@ type DRPCAgentSocketClient interface {
 	SyncStart(ctx context.Context, in *SyncStartRequest) (*SyncStartResponse, error)
 	SyncWant(ctx context.Context, in *SyncWantRequest) (*SyncWantResponse, error)
 	SyncComplete(ctx context.Context, in *SyncCompleteRequest) (*SyncCompleteResponse, error)
+	SyncFail(ctx context.Context, in *SyncFailRequest) (*SyncFailResponse, error)
+	SyncReset(ctx context.Context, in *SyncResetRequest) (*SyncResetResponse, error)
 	SyncReady(ctx context.Context, in *SyncReadyRequest) (*SyncReadyResponse, error)
 	SyncStatus(ctx context.Context, in *SyncStatusRequest) (*SyncStatusResponse, error)
+	SyncGraph(ctx context.Context, in *SyncGraphRequest) (*SyncGraphResponse, error)
 }
 
 type drpcAgentSocketClient struct {
@@ -92,6 +95,24 @@ func (c *drpcAgentSocketClient) SyncComplete(ctx context.Context, in *SyncComple
 	return out, nil
 }
 
+func (c *drpcAgentSocketClient) SyncFail(ctx context.Context, in *SyncFailRequest) (*SyncFailResponse, error) {
+	out := new(SyncFailResponse)
+	err := c.cc.Invoke(ctx, "/coder.agentsocket.v1.AgentSocket/SyncFail", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcAgentSocketClient) SyncReset(ctx context.Context, in *SyncResetRequest) (*SyncResetResponse, error) {
+	out := new(SyncResetResponse)
+	err := c.cc.Invoke(ctx, "/coder.agentsocket.v1.AgentSocket/SyncReset", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *drpcAgentSocketClient) SyncReady(ctx context.Context, in *SyncReadyRequest) (*SyncReadyResponse, error) {
 	out := new(SyncReadyResponse)
 	err := c.cc.Invoke(ctx, "/coder.agentsocket.v1.AgentSocket/SyncReady", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}, in, out)
@@ -110,13 +131,25 @@ func (c *drpcAgentSocketClient) SyncStatus(ctx context.Context, in *SyncStatusRe
 	return out, nil
 }
 
+func (c *drpcAgentSocketClient) SyncGraph(ctx context.Context, in *SyncGraphRequest) (*SyncGraphResponse, error) {
+	out := new(SyncGraphResponse)
+	err := c.cc.Invoke(ctx, "/coder.agentsocket.v1.AgentSocket/SyncGraph", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCAgentSocketServer interface {
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	SyncStart(context.Context, *SyncStartRequest) (*SyncStartResponse, error)
 	SyncWant(context.Context, *SyncWantRequest) (*SyncWantResponse, error)
 	SyncComplete(context.Context, *SyncCompleteRequest) (*SyncCompleteResponse, error)
+	SyncFail(context.Context, *SyncFailRequest) (*SyncFailResponse, error)
+	SyncReset(context.Context, *SyncResetRequest) (*SyncResetResponse, error)
 	SyncReady(context.Context, *SyncReadyRequest) (*SyncReadyResponse, error)
 	SyncStatus(context.Context, *SyncStatusRequest) (*SyncStatusResponse, error)
+	SyncGraph(context.Context, *SyncGraphRequest) (*SyncGraphResponse, error)
 }
 
 type DRPCAgentSocketUnimplementedServer struct{}
@@ -137,6 +170,14 @@ func (s *DRPCAgentSocketUnimplementedServer) SyncComplete(context.Context, *Sync
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCAgentSocketUnimplementedServer) SyncFail(context.Context, *SyncFailRequest) (*SyncFailResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCAgentSocketUnimplementedServer) SyncReset(context.Context, *SyncResetRequest) (*SyncResetResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 func (s *DRPCAgentSocketUnimplementedServer) SyncReady(context.Context, *SyncReadyRequest) (*SyncReadyResponse, error) {
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
@@ -145,9 +186,13 @@ func (s *DRPCAgentSocketUnimplementedServer) SyncStatus(context.Context, *SyncSt
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCAgentSocketUnimplementedServer) SyncGraph(context.Context, *SyncGraphRequest) (*SyncGraphResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 type DRPCAgentSocketDescription struct{}
 
-func (DRPCAgentSocketDescription) NumMethods() int { return 6 }
+func (DRPCAgentSocketDescription) NumMethods() int { return 9 }
 
 func (DRPCAgentSocketDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -188,6 +233,24 @@ func (DRPCAgentSocketDescription) Method(n int) (string, drpc.Encoding, drpc.Rec
 					)
 			}, DRPCAgentSocketServer.SyncComplete, true
 	case 4:
+		return "/coder.agentsocket.v1.AgentSocket/SyncFail", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentSocketServer).
+					SyncFail(
+						ctx,
+						in1.(*SyncFailRequest),
+					)
+			}, DRPCAgentSocketServer.SyncFail, true
+	case 5:
+		return "/coder.agentsocket.v1.AgentSocket/SyncReset", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentSocketServer).
+					SyncReset(
+						ctx,
+						in1.(*SyncResetRequest),
+					)
+			}, DRPCAgentSocketServer.SyncReset, true
+	case 6:
 		return "/coder.agentsocket.v1.AgentSocket/SyncReady", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCAgentSocketServer).
@@ -196,7 +259,7 @@ func (DRPCAgentSocketDescription) Method(n int) (string, drpc.Encoding, drpc.Rec
 						in1.(*SyncReadyRequest),
 					)
 			}, DRPCAgentSocketServer.SyncReady, true
-	case 5:
+	case 7:
 		return "/coder.agentsocket.v1.AgentSocket/SyncStatus", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCAgentSocketServer).
@@ -205,6 +268,15 @@ func (DRPCAgentSocketDescription) Method(n int) (string, drpc.Encoding, drpc.Rec
 						in1.(*SyncStatusRequest),
 					)
 			}, DRPCAgentSocketServer.SyncStatus, true
+	case 8:
+		return "/coder.agentsocket.v1.AgentSocket/SyncGraph", drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAgentSocketServer).
+					SyncGraph(
+						ctx,
+						in1.(*SyncGraphRequest),
+					)
+			}, DRPCAgentSocketServer.SyncGraph, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -278,6 +350,38 @@ func (x *drpcAgentSocket_SyncCompleteStream) SendAndClose(m *SyncCompleteRespons
 	return x.CloseSend()
 }
 
+type DRPCAgentSocket_SyncFailStream interface {
+	drpc.Stream
+	SendAndClose(*SyncFailResponse) error
+}
+
+type drpcAgentSocket_SyncFailStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgentSocket_SyncFailStream) SendAndClose(m *SyncFailResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCAgentSocket_SyncResetStream interface {
+	drpc.Stream
+	SendAndClose(*SyncResetResponse) error
+}
+
+type drpcAgentSocket_SyncResetStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgentSocket_SyncResetStream) SendAndClose(m *SyncResetResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCAgentSocket_SyncReadyStream interface {
 	drpc.Stream
 	SendAndClose(*SyncReadyResponse) error
@@ -309,3 +413,19 @@ func (x *drpcAgentSocket_SyncStatusStream) SendAndClose(m *SyncStatusResponse) e
 	}
 	return x.CloseSend()
 }
+
+type DRPCAgentSocket_SyncGraphStream interface {
+	drpc.Stream
+	SendAndClose(*SyncGraphResponse) error
+}
+
+type drpcAgentSocket_SyncGraphStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAgentSocket_SyncGraphStream) SendAndClose(m *SyncGraphResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_agent_agentsocket_proto_agentsocket_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
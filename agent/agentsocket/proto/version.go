@@ -8,10 +8,21 @@ import "github.com/coder/coder/v2/apiversion"
 //   - Initial release
 //   - Ping
 //   - Sync operations: SyncStart, SyncWant, SyncComplete, SyncWait, SyncStatus
+//
+// API v1.1:
+//   - SyncFail to report unit failures
+//   - Timeout on SyncWant to fail units that do not complete in time
+//   - Failure reason and blocking unit in SyncStatus
+//
+// API v1.2:
+//   - SyncReset to clear all units and persisted state
+//
+// API v1.3:
+//   - SyncGraph to export the dependency graph in DOT format
 
 const (
 	CurrentMajor = 1
-	CurrentMinor = 0
+	CurrentMinor = 3
 )
 
 var CurrentVersion = apiversion.New(CurrentMajor, CurrentMinor)
@@ -73,6 +73,12 @@ func (s *DRPCAgentSocketService) SyncWant(_ context.Context, req *proto.SyncWant
 		return nil, xerrors.Errorf("cannot add dependency: %w", err)
 	}
 
+	if req.Timeout != nil {
+		if err := s.unitManager.SetTimeout(unitID, req.Timeout.AsDuration()); err != nil {
+			return nil, xerrors.Errorf("cannot set timeout: %w", err)
+		}
+	}
+
 	return &proto.SyncWantResponse{}, nil
 }
 
@@ -91,6 +97,56 @@ func (s *DRPCAgentSocketService) SyncComplete(_ context.Context, req *proto.Sync
 	return &proto.SyncCompleteResponse{}, nil
 }
 
+// SyncFail marks a unit as failed in the dependency graph. Units that
+// depend on it become blocked.
+func (s *DRPCAgentSocketService) SyncFail(_ context.Context, req *proto.SyncFailRequest) (*proto.SyncFailResponse, error) {
+	if s.unitManager == nil {
+		return nil, xerrors.Errorf("cannot fail unit: %w", ErrUnitManagerNotAvailable)
+	}
+
+	unitID := unit.ID(req.Unit)
+
+	// A unit may fail before it ever managed to start, for example while
+	// waiting for its dependencies.
+	if err := s.unitManager.Register(unitID); err != nil && !errors.Is(err, unit.ErrUnitAlreadyRegistered) {
+		return nil, xerrors.Errorf("cannot fail unit %q: %w", req.Unit, err)
+	}
+
+	if err := s.unitManager.Fail(unitID, req.Reason); err != nil {
+		return nil, xerrors.Errorf("cannot fail unit %q: %w", req.Unit, err)
+	}
+
+	return &proto.SyncFailResponse{}, nil
+}
+
+// SyncReset removes all units and dependencies from the dependency graph,
+// including any state persisted across agent restarts.
+func (s *DRPCAgentSocketService) SyncReset(_ context.Context, _ *proto.SyncResetRequest) (*proto.SyncResetResponse, error) {
+	if s.unitManager == nil {
+		return nil, xerrors.Errorf("cannot reset units: %w", ErrUnitManagerNotAvailable)
+	}
+
+	if err := s.unitManager.Reset(); err != nil {
+		return nil, xerrors.Errorf("cannot reset units: %w", err)
+	}
+
+	return &proto.SyncResetResponse{}, nil
+}
+
+// SyncGraph exports the dependency graph of all units in DOT format.
+func (s *DRPCAgentSocketService) SyncGraph(_ context.Context, _ *proto.SyncGraphRequest) (*proto.SyncGraphResponse, error) {
+	if s.unitManager == nil {
+		return nil, xerrors.Errorf("cannot export graph: %w", ErrUnitManagerNotAvailable)
+	}
+
+	dot, err := s.unitManager.ExportDOT("units")
+	if err != nil {
+		return nil, xerrors.Errorf("cannot export graph: %w", err)
+	}
+
+	return &proto.SyncGraphResponse{Dot: dot}, nil
+}
+
 // SyncReady checks whether a unit is ready to be started. That is, all dependencies are satisfied.
 func (s *DRPCAgentSocketService) SyncReady(_ context.Context, req *proto.SyncReadyRequest) (*proto.SyncReadyResponse, error) {
 	if s.unitManager == nil {
@@ -145,8 +201,10 @@ func (s *DRPCAgentSocketService) SyncStatus(_ context.Context, req *proto.SyncSt
 		return nil, xerrors.Errorf("cannot get status for unit %q: %w", req.Unit, err)
 	}
 	return &proto.SyncStatusResponse{
-		Status:       string(u.Status()),
-		IsReady:      isReady,
-		Dependencies: depInfos,
+		Status:        string(u.Status()),
+		IsReady:       isReady,
+		Dependencies:  depInfos,
+		FailureReason: u.FailureReason(),
+		BlockedBy:     string(u.BlockedBy()),
 	}, nil
 }
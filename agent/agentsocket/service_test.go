@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/coder/coder/v2/agent/agentsocket"
 	"github.com/coder/coder/v2/agent/unit"
 	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
 )
 
 // newSocketClient creates a DRPC client connected to the Unix socket at the given path.
@@ -357,4 +359,104 @@ func TestDRPCAgentSocketService(t *testing.T) {
 			require.True(t, ready)
 		})
 	})
+
+	t.Run("SyncFail", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("BlocksDependents", func(t *testing.T) {
+			t.Parallel()
+
+			socketPath := filepath.Join(testutil.TempDirUnixSocket(t), "test.sock")
+			ctx := testutil.Context(t, testutil.WaitShort)
+			server, err := agentsocket.NewServer(
+				slog.Make().Leveled(slog.LevelDebug),
+				agentsocket.WithPath(socketPath),
+			)
+			require.NoError(t, err)
+			defer server.Close()
+
+			client := newSocketClient(ctx, t, socketPath)
+
+			err = client.SyncWant(ctx, "test-unit", "dependency-unit")
+			require.NoError(t, err)
+
+			// The dependency fails before it was ever started.
+			err = client.SyncFail(ctx, "dependency-unit", "clone failed")
+			require.NoError(t, err)
+
+			status, err := client.SyncStatus(ctx, "dependency-unit")
+			require.NoError(t, err)
+			require.Equal(t, unit.StatusFailed, status.Status)
+			require.Equal(t, "clone failed", status.FailureReason)
+
+			status, err = client.SyncStatus(ctx, "test-unit")
+			require.NoError(t, err)
+			require.Equal(t, unit.StatusBlocked, status.Status)
+			require.Equal(t, unit.ID("dependency-unit"), status.BlockedBy)
+			require.False(t, status.IsReady)
+		})
+
+		t.Run("WantTimeout", func(t *testing.T) {
+			t.Parallel()
+
+			socketPath := filepath.Join(testutil.TempDirUnixSocket(t), "test.sock")
+			ctx := testutil.Context(t, testutil.WaitShort)
+			clock := quartz.NewMock(t)
+			server, err := agentsocket.NewServer(
+				slog.Make().Leveled(slog.LevelDebug),
+				agentsocket.WithPath(socketPath),
+				agentsocket.WithUnitManager(unit.NewManager(unit.WithClock(clock))),
+			)
+			require.NoError(t, err)
+			defer server.Close()
+
+			client := newSocketClient(ctx, t, socketPath)
+
+			err = client.SyncWantWithTimeout(ctx, "test-unit", "dependency-unit", time.Minute)
+			require.NoError(t, err)
+
+			clock.Advance(time.Minute).MustWait(ctx)
+
+			status, err := client.SyncStatus(ctx, "test-unit")
+			require.NoError(t, err)
+			require.Equal(t, unit.StatusFailed, status.Status)
+			require.Equal(t, "timed out after 1m0s", status.FailureReason)
+		})
+	})
+
+	t.Run("SyncReset", func(t *testing.T) {
+		t.Parallel()
+
+		socketPath := filepath.Join(testutil.TempDirUnixSocket(t), "test.sock")
+		ctx := testutil.Context(t, testutil.WaitShort)
+		journal, err := unit.OpenJournal(t.TempDir())
+		require.NoError(t, err)
+		defer journal.Close()
+		server, err := agentsocket.NewServer(
+			slog.Make().Leveled(slog.LevelDebug),
+			agentsocket.WithPath(socketPath),
+			agentsocket.WithUnitManager(unit.NewManager(unit.WithJournal(journal))),
+		)
+		require.NoError(t, err)
+		defer server.Close()
+
+		client := newSocketClient(ctx, t, socketPath)
+
+		err = client.SyncWant(ctx, "test-unit", "dependency-unit")
+		require.NoError(t, err)
+		err = client.SyncStart(ctx, "dependency-unit")
+		require.NoError(t, err)
+
+		err = client.SyncReset(ctx)
+		require.NoError(t, err)
+
+		// All units are forgotten, so the dependency can be started again.
+		status, err := client.SyncStatus(ctx, "test-unit")
+		require.NoError(t, err)
+		require.Equal(t, unit.StatusNotRegistered, status.Status)
+		require.Empty(t, status.Dependencies)
+
+		err = client.SyncStart(ctx, "dependency-unit")
+		require.NoError(t, err)
+	})
 }
@@ -1,6 +1,7 @@
 package agenttest
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/maps"
 	"golang.org/x/xerrors"
+	protobuf "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"storj.io/drpc/drpcmux"
@@ -124,8 +126,8 @@ func (c *Client) Close() {
 	c.derpMapOnce.Do(func() { close(c.derpMapUpdates) })
 }
 
-func (c *Client) ConnectRPC28(ctx context.Context) (
-	agentproto.DRPCAgentClient28, proto.DRPCTailnetClient28, error,
+func (c *Client) ConnectRPC210(ctx context.Context) (
+	agentproto.DRPCAgentClient210, proto.DRPCTailnetClient210, error,
 ) {
 	conn, lis := drpcsdk.MemTransportPipe()
 	c.LastWorkspaceAgent = func() {
@@ -189,6 +191,12 @@ func (c *Client) GetConnectionReports() []*agentproto.ReportConnectionRequest {
 	return c.fakeAgentAPI.GetConnectionReports()
 }
 
+// GetSessionRecordings returns the uploaded session recordings, with the data
+// of all parts combined.
+func (c *Client) GetSessionRecordings() []*agentproto.UploadSessionRecordingRequest {
+	return c.fakeAgentAPI.GetSessionRecordings()
+}
+
 func (c *Client) GetSubAgents() []*agentproto.SubAgent {
 	return c.fakeAgentAPI.GetSubAgents()
 }
@@ -219,6 +227,7 @@ type FakeAgentAPI struct {
 	metadata            map[string]agentsdk.Metadata
 	timings             []*agentproto.Timing
 	connectionReports   []*agentproto.ReportConnectionRequest
+	sessionRecordings   []*agentproto.UploadSessionRecordingRequest
 	subAgents           map[uuid.UUID]*agentproto.SubAgent
 	subAgentDirs        map[uuid.UUID]string
 	subAgentDisplayApps map[uuid.UUID][]agentproto.CreateSubAgentRequest_DisplayApp
@@ -415,6 +424,39 @@ func (f *FakeAgentAPI) GetConnectionReports() []*agentproto.ReportConnectionRequ
 	return slices.Clone(f.connectionReports)
 }
 
+func (f *FakeAgentAPI) UploadSessionRecording(_ context.Context, req *agentproto.UploadSessionRecordingRequest) (*agentproto.UploadSessionRecordingResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, rec := range f.sessionRecordings {
+		if !bytes.Equal(rec.Id, req.Id) {
+			continue
+		}
+		if req.Offset != int64(len(rec.Data)) {
+			return nil, xerrors.Errorf("recording part at offset %d does not follow %d bytes", req.Offset, len(rec.Data))
+		}
+		rec.Data = append(rec.Data, req.Data...)
+		return &agentproto.UploadSessionRecordingResponse{}, nil
+	}
+	if req.Offset != 0 {
+		return nil, xerrors.Errorf("recording %x not found", req.Id)
+	}
+	rec, _ := protobuf.Clone(req).(*agentproto.UploadSessionRecordingRequest)
+	f.sessionRecordings = append(f.sessionRecordings, rec)
+	return &agentproto.UploadSessionRecordingResponse{}, nil
+}
+
+func (f *FakeAgentAPI) GetSessionRecordings() []*agentproto.UploadSessionRecordingRequest {
+	f.Lock()
+	defer f.Unlock()
+	recordings := make([]*agentproto.UploadSessionRecordingRequest, 0, len(f.sessionRecordings))
+	for _, rec := range f.sessionRecordings {
+		clone, _ := protobuf.Clone(rec).(*agentproto.UploadSessionRecordingRequest)
+		recordings = append(recordings, clone)
+	}
+	return recordings
+}
+
 func (f *FakeAgentAPI) CreateSubAgent(ctx context.Context, req *agentproto.CreateSubAgentRequest) (*agentproto.CreateSubAgentResponse, error) {
 	f.Lock()
 	defer f.Unlock()
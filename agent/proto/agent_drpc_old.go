@@ -79,3 +79,17 @@ type DRPCAgentClient27 interface {
 type DRPCAgentClient28 interface {
 	DRPCAgentClient27
 }
+
+// DRPCAgentClient29 is the Agent API at v2.9. It adds the UnitName and
+// DependsOn fields to the WorkspaceAgentScript message.
+type DRPCAgentClient29 interface {
+	DRPCAgentClient28
+}
+
+// DRPCAgentClient210 is the Agent API at v2.10. It adds the
+// UploadSessionRecording RPC and the session recording settings to the
+// manifest.
+type DRPCAgentClient210 interface {
+	DRPCAgentClient29
+	UploadSessionRecording(ctx context.Context, in *UploadSessionRecordingRequest) (*UploadSessionRecordingResponse, error)
+}
@@ -0,0 +1,51 @@
+package reconnectingpty
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+// Routes returns the HTTP handler for listing and killing reconnecting ptys.
+func (s *Server) Routes() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", s.handleListSessions)
+	r.Delete("/{id}", s.handleKillSession)
+	return r
+}
+
+func (s *Server) handleListSessions(rw http.ResponseWriter, r *http.Request) {
+	httpapi.Write(r.Context(), rw, http.StatusOK, workspacesdk.ReconnectingPTYSessionsResponse{
+		Sessions: s.Sessions(),
+	})
+}
+
+func (s *Server) handleKillSession(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid reconnecting pty ID.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	if !s.Kill(id) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: fmt.Sprintf("Reconnecting pty %s not found.", id),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: fmt.Sprintf("Killed reconnecting pty %s.", id),
+	})
+}
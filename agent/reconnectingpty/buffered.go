@@ -198,11 +198,13 @@ func (rpty *bufferedReconnectingPTY) Attach(ctx context.Context, connID string,
 	go heartbeat(ctx, rpty.timer, rpty.timeout)
 
 	// Resize the PTY to initial height + width.
-	err = rpty.ptty.Resize(height, width)
-	if err != nil {
-		// We can continue after this, it's not fatal!
-		logger.Warn(ctx, "reconnecting PTY initial resize failed, but will continue", slog.Error(err))
-		rpty.metrics.WithLabelValues("resize").Add(1)
+	if height != 0 && width != 0 {
+		err = rpty.ptty.Resize(height, width)
+		if err != nil {
+			// We can continue after this, it's not fatal!
+			logger.Warn(ctx, "reconnecting PTY initial resize failed, but will continue", slog.Error(err))
+			rpty.metrics.WithLabelValues("resize").Add(1)
+		}
 	}
 
 	// Pipe conn -> pty and block.  pty -> conn is handled in newBuffered().
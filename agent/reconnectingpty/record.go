@@ -0,0 +1,67 @@
+package reconnectingpty
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+
+	"github.com/coder/coder/v2/agent/agentrecord"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+// maxPendingRequest is the largest partial request buffered while waiting for
+// the rest of it. Clients send keystrokes and pastes, so anything larger is
+// not recorded.
+const maxPendingRequest = 1 << 20
+
+// recordingConn records what a single connection sees and sends. Output is
+// recorded as written to the connection, including any scrollback replayed on
+// reconnect, and input is decoded from the JSON requests read from it.
+type recordingConn struct {
+	net.Conn
+	rec     *agentrecord.Recorder
+	pending []byte
+}
+
+func newRecordingConn(conn net.Conn, rec *agentrecord.Recorder) net.Conn {
+	return &recordingConn{Conn: conn, rec: rec}
+}
+
+func (c *recordingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.recordInput(p[:n])
+	}
+	return n, err
+}
+
+func (c *recordingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	if n > 0 {
+		c.rec.Output(p[:n])
+	}
+	return n, err
+}
+
+// recordInput decodes the complete requests in the data read so far. Reads
+// only happen on the connection's read loop, so no locking is needed.
+func (c *recordingConn) recordInput(p []byte) {
+	c.pending = append(c.pending, p...)
+	decoder := json.NewDecoder(bytes.NewReader(c.pending))
+	var consumed int64
+	for {
+		var req workspacesdk.ReconnectingPTYRequest
+		if err := decoder.Decode(&req); err != nil {
+			break
+		}
+		consumed = decoder.InputOffset()
+		c.rec.Input([]byte(req.Data))
+		if req.Height != 0 && req.Width != 0 {
+			c.rec.Resize(int(req.Width), int(req.Height))
+		}
+	}
+	c.pending = c.pending[consumed:]
+	if len(c.pending) > maxPendingRequest {
+		c.pending = nil
+	}
+}
@@ -53,6 +53,14 @@ type screenReconnectingPTY struct {
 	// reset as long as there are active connections.
 	timer   *time.Timer
 	timeout time.Duration
+
+	// sizeMutex guards height and width, which hold the size last requested by
+	// a connection that may resize the session.  Attaches which do not specify
+	// a size, like those of read-only connections, spawn their screen client at
+	// this size so screen does not reflow the session for everyone else.
+	sizeMutex sync.Mutex
+	height    uint16
+	width     uint16
 }
 
 // newScreen creates a new screen-backed reconnecting PTY.  It writes config
@@ -201,10 +209,33 @@ func (rpty *screenReconnectingPTY) Attach(ctx context.Context, _ string, conn ne
 	}()
 
 	// Pipe conn -> pty and block.
-	readConnLoop(ctx, conn, ptty, rpty.metrics, logger)
+	readConnLoop(ctx, conn, &screenClientPTY{PTYCmd: ptty, rpty: rpty}, rpty.metrics, logger)
 	return nil
 }
 
+// size returns the size to spawn a screen client at, remembering the given
+// size unless it is zero.
+func (rpty *screenReconnectingPTY) size(height, width uint16) (uint16, uint16) {
+	rpty.sizeMutex.Lock()
+	defer rpty.sizeMutex.Unlock()
+	if height == 0 || width == 0 {
+		return rpty.height, rpty.width
+	}
+	rpty.height, rpty.width = height, width
+	return height, width
+}
+
+// screenClientPTY remembers the size of resizes to a screen client.
+type screenClientPTY struct {
+	pty.PTYCmd
+	rpty *screenReconnectingPTY
+}
+
+func (p *screenClientPTY) Resize(height, width uint16) error {
+	height, width = p.rpty.size(height, width)
+	return p.PTYCmd.Resize(height, width)
+}
+
 // doAttach spawns the screen client and starts the heartbeat.  It exists
 // separately only so we can defer the mutex unlock which is not possible in
 // Attach since it blocks.
@@ -215,6 +246,8 @@ func (rpty *screenReconnectingPTY) doAttach(ctx context.Context, conn net.Conn,
 
 	logger.Debug(ctx, "spawning screen client", slog.F("screen_id", rpty.id))
 
+	height, width = rpty.size(height, width)
+
 	// Wrap the command with screen and tie it to the connection's context.
 	cmd := rpty.execer.PTYCommandContext(ctx, "screen", append([]string{
 		// -S is for setting the session's name.
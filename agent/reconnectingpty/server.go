@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"net"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,6 +17,7 @@ import (
 
 	"cdr.dev/slog/v3"
 	"github.com/coder/coder/v2/agent/agentcontainers"
+	"github.com/coder/coder/v2/agent/agentrecord"
 	"github.com/coder/coder/v2/agent/agentssh"
 	"github.com/coder/coder/v2/agent/usershell"
 	"github.com/coder/coder/v2/codersdk/workspacesdk"
@@ -30,11 +33,16 @@ type Server struct {
 	reportConnection reportConnectionFunc
 	connCount        atomic.Int64
 	reconnectingPTYs sync.Map
-	timeout          time.Duration
+	// sessions maps reconnecting pty IDs to their *session metadata.
+	sessions sync.Map
+	timeout  time.Duration
 	// Experimental: allow connecting to running containers via Docker exec.
 	// Note that this is different from the devcontainers feature, which uses
 	// subagents.
 	ExperimentalContainers bool
+	// StartRecording returns a recorder for the connection with the given ID,
+	// or nil if it should not be recorded.
+	StartRecording func(id uuid.UUID, width, height int, command string) *agentrecord.Recorder
 }
 
 // NewServer returns a new ReconnectingPTY server
@@ -58,6 +66,9 @@ func NewServer(logger slog.Logger, commandCreator *agentssh.Server, reportConnec
 	for _, o := range opts {
 		o(s)
 	}
+	if s.StartRecording == nil {
+		s.StartRecording = func(uuid.UUID, int, int, string) *agentrecord.Recorder { return nil }
+	}
 	return s
 }
 
@@ -88,7 +99,8 @@ func (s *Server) Serve(ctx, hardCtx context.Context, l net.Listener) (retErr err
 		}
 
 		wg.Add(1)
-		disconnected := s.reportConnection(uuid.New(), remoteAddrString)
+		reportID := uuid.New()
+		disconnected := s.reportConnection(reportID, remoteAddrString)
 		closed := make(chan struct{})
 		go func() {
 			defer wg.Done()
@@ -103,7 +115,7 @@ func (s *Server) Serve(ctx, hardCtx context.Context, l net.Listener) (retErr err
 		go func() {
 			defer close(closed)
 			defer wg.Done()
-			err := s.handleConn(ctx, clog, conn)
+			err := s.handleConn(ctx, clog, conn, reportID)
 			if err != nil {
 				if ctx.Err() != nil {
 					disconnected(1, "server shutting down")
@@ -123,7 +135,47 @@ func (s *Server) ConnCount() int64 {
 	return s.connCount.Load()
 }
 
-func (s *Server) handleConn(ctx context.Context, logger slog.Logger, conn net.Conn) (retErr error) {
+// Sessions returns the running reconnecting ptys ordered by start time.
+func (s *Server) Sessions() []workspacesdk.ReconnectingPTYSession {
+	sessions := []workspacesdk.ReconnectingPTYSession{}
+	s.sessions.Range(func(_, value any) bool {
+		if sess, ok := value.(*session); ok {
+			sessions = append(sessions, sess.info())
+		}
+		return true
+	})
+	slices.SortFunc(sessions, func(a, b workspacesdk.ReconnectingPTYSession) int {
+		if c := a.StartedAt.Compare(b.StartedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return sessions
+}
+
+// Kill closes the reconnecting pty with the given ID, which kills its process
+// and disconnects all attached connections.  It returns false if no such
+// session exists.
+func (s *Server) Kill(id uuid.UUID) bool {
+	value, ok := s.sessions.Load(id)
+	if !ok {
+		return false
+	}
+	sess, ok := value.(*session)
+	if !ok {
+		return false
+	}
+	s.logger.Info(context.Background(), "killing reconnecting pty", slog.F("message_id", id))
+	sess.rpty.Close(xerrors.New("reconnecting pty killed"))
+	// Remove the session right away so that it is no longer listed while the
+	// process exits.
+	s.sessions.CompareAndDelete(id, sess)
+	return true
+}
+
+// handleConn serves a single connection. The reportID is the ID the
+// connection was reported with, which its recording is stored under.
+func (s *Server) handleConn(ctx context.Context, logger slog.Logger, conn net.Conn, reportID uuid.UUID) (retErr error) {
 	defer conn.Close()
 	s.connectionsTotal.Add(1)
 	s.connCount.Add(1)
@@ -156,7 +208,7 @@ func (s *Server) handleConn(ctx context.Context, logger slog.Logger, conn net.Co
 	}
 
 	connectionID := uuid.NewString()
-	connLogger := logger.With(slog.F("message_id", msg.ID), slog.F("connection_id", connectionID), slog.F("container", msg.Container), slog.F("container_user", msg.ContainerUser))
+	connLogger := logger.With(slog.F("message_id", msg.ID), slog.F("connection_id", connectionID), slog.F("container", msg.Container), slog.F("container_user", msg.ContainerUser), slog.F("read_only", msg.ReadOnly))
 	connLogger.Debug(ctx, "starting handler")
 
 	defer func() {
@@ -172,9 +224,23 @@ func (s *Server) handleConn(ctx context.Context, logger slog.Logger, conn net.Co
 	}()
 
 	var rpty ReconnectingPTY
+	var sess *session
 	sendConnected := make(chan ReconnectingPTY, 1)
-	// On store, reserve this ID to prevent multiple concurrent new connections.
-	waitReady, ok := s.reconnectingPTYs.LoadOrStore(msg.ID, sendConnected)
+	var (
+		waitReady any
+		ok        bool
+	)
+	if msg.ReadOnly {
+		// Read-only connections can only watch existing sessions.
+		waitReady, ok = s.reconnectingPTYs.Load(msg.ID)
+		if !ok {
+			close(sendConnected) // Unused.
+			return xerrors.Errorf("reconnecting pty %s not found", msg.ID)
+		}
+	} else {
+		// On store, reserve this ID to prevent multiple concurrent new connections.
+		waitReady, ok = s.reconnectingPTYs.LoadOrStore(msg.ID, sendConnected)
+	}
 	if ok {
 		close(sendConnected) // Unused.
 		connLogger.Debug(ctx, "connecting to existing reconnecting pty")
@@ -187,6 +253,9 @@ func (s *Server) handleConn(ctx context.Context, logger slog.Logger, conn net.Co
 			return xerrors.Errorf("reconnecting pty closed before connection")
 		}
 		c <- rpty // Put it back for the next reconnect.
+		if value, ok := s.sessions.Load(msg.ID); ok {
+			sess, _ = value.(*session)
+		}
 	} else {
 		connLogger.Debug(ctx, "creating new reconnecting pty")
 
@@ -234,13 +303,36 @@ func (s *Server) handleConn(ctx context.Context, logger slog.Logger, conn net.Co
 			}
 		}()
 
+		sess = newSession(msg.ID, rpty, msg.Command, msg.Container)
+		s.sessions.Store(msg.ID, sess)
+
 		go func() {
 			rpty.Wait()
 			s.reconnectingPTYs.Delete(msg.ID)
+			s.sessions.CompareAndDelete(msg.ID, sess)
 		}()
 
 		connected = true
 		sendConnected <- rpty
 	}
-	return rpty.Attach(ctx, connectionID, conn, msg.Height, msg.Width, connLogger)
+	if sess != nil {
+		var detach func()
+		conn, detach = sess.attach(connectionID, conn, msg.ReadOnly)
+		defer detach()
+	}
+	command := msg.Command
+	if sess != nil {
+		command = sess.command
+	}
+	if rec := s.StartRecording(reportID, int(msg.Width), int(msg.Height), command); rec != nil {
+		defer rec.Close()
+		conn = newRecordingConn(conn, rec)
+	}
+	height, width := msg.Height, msg.Width
+	if msg.ReadOnly {
+		// Read-only connections watch the session at the size chosen by its
+		// writers, so they must not resize the pty.
+		height, width = 0, 0
+	}
+	return rpty.Attach(ctx, connectionID, conn, height, width, connLogger)
 }
@@ -0,0 +1,124 @@
+package reconnectingpty
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+// session holds the metadata of a reconnecting pty used for listing sessions
+// and tracking who is attached.
+type session struct {
+	id        uuid.UUID
+	rpty      ReconnectingPTY
+	command   string
+	container string
+	startedAt time.Time
+	// lastActivity is the last time data passed through any connection, in
+	// nanoseconds since the Unix epoch.
+	lastActivity atomic.Int64
+
+	mu    sync.Mutex
+	conns map[string]workspacesdk.ReconnectingPTYConnection
+}
+
+func newSession(id uuid.UUID, rpty ReconnectingPTY, command, container string) *session {
+	now := time.Now()
+	s := &session{
+		id:        id,
+		rpty:      rpty,
+		command:   command,
+		container: container,
+		startedAt: now,
+		conns:     map[string]workspacesdk.ReconnectingPTYConnection{},
+	}
+	s.lastActivity.Store(now.UnixNano())
+	return s
+}
+
+// attach records the connection and returns a wrapped connection that tracks
+// activity and, for read-only connections, discards all input.  The returned
+// function must be called once the connection detaches.
+func (s *session) attach(connID string, conn net.Conn, readOnly bool) (net.Conn, func()) {
+	remoteAddr := ""
+	if addr := conn.RemoteAddr(); addr != nil {
+		remoteAddr = addr.String()
+	}
+
+	s.mu.Lock()
+	s.conns[connID] = workspacesdk.ReconnectingPTYConnection{
+		ID:         connID,
+		RemoteAddr: remoteAddr,
+		ReadOnly:   readOnly,
+		AttachedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	return &sessionConn{Conn: conn, session: s, readOnly: readOnly}, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.conns, connID)
+	}
+}
+
+func (s *session) touch() {
+	s.lastActivity.Store(time.Now().UnixNano())
+}
+
+func (s *session) info() workspacesdk.ReconnectingPTYSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conns := make([]workspacesdk.ReconnectingPTYConnection, 0, len(s.conns))
+	for _, c := range s.conns {
+		conns = append(conns, c)
+	}
+	return workspacesdk.ReconnectingPTYSession{
+		ID:             s.id,
+		Command:        s.command,
+		Container:      s.container,
+		StartedAt:      s.startedAt,
+		LastActivityAt: time.Unix(0, s.lastActivity.Load()),
+		Connections:    conns,
+	}
+}
+
+// sessionConn records activity on the session and drops the input of
+// read-only connections.
+type sessionConn struct {
+	net.Conn
+	session  *session
+	readOnly bool
+}
+
+func (c *sessionConn) Read(p []byte) (int, error) {
+	if c.readOnly {
+		// Read-only connections may neither write to the pty nor resize it, so
+		// consume input until the connection closes without passing any of it
+		// on.
+		for {
+			_, err := c.Conn.Read(p)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.session.touch()
+	}
+	return n, err
+}
+
+func (c *sessionConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	if n > 0 {
+		c.session.touch()
+	}
+	return n, err
+}
@@ -0,0 +1,197 @@
+package unit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// JournalFileName is the name of the journal file within the directory
+// passed to OpenJournal.
+const JournalFileName = "coder-agent-units.jsonl"
+
+// journalOp identifies the Manager mutation recorded by a journal entry.
+type journalOp string
+
+const (
+	journalOpRegister journalOp = "register"
+	journalOpDepend   journalOp = "depend"
+	journalOpStatus   journalOp = "status"
+	journalOpTimeout  journalOp = "timeout"
+)
+
+// journalEntry is a single line in the journal file. Only the fields relevant
+// to the operation are set.
+type journalEntry struct {
+	Op        journalOp     `json:"op"`
+	Unit      ID            `json:"unit"`
+	DependsOn ID            `json:"depends_on,omitempty"`
+	Status    Status        `json:"status,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Timeout   time.Duration `json:"timeout,omitempty"`
+	Deadline  time.Time     `json:"deadline,omitempty"`
+}
+
+// Journal persists Manager mutations to an append-only file so that the
+// dependency graph and unit statuses survive agent restarts. A Journal is
+// attached to a Manager with WithJournal, which replays the recorded entries.
+type Journal struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	entries []journalEntry
+}
+
+// OpenJournal opens the journal in the given directory, creating it if it does
+// not exist, and loads any existing entries for replay. A truncated trailing
+// entry, as left behind by a crash during a write, is ignored.
+func OpenJournal(dir string) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, xerrors.Errorf("create journal directory: %w", err)
+	}
+
+	path := filepath.Join(dir, JournalFileName)
+	entries, err := readJournal(path)
+	if err != nil {
+		return nil, xerrors.Errorf("read journal: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, xerrors.Errorf("open journal: %w", err)
+	}
+
+	return &Journal{
+		path:    path,
+		file:    file,
+		entries: entries,
+	}, nil
+}
+
+func readJournal(path string) ([]journalEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []journalEntry
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A line without a trailing newline was not completely written.
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var entry journalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, xerrors.Errorf("decode entry %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// Path returns the path of the journal file.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// append writes an entry to the end of the journal and syncs it to disk.
+func (j *Journal) append(entry journalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return xerrors.Errorf("encode journal entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return xerrors.New("journal is closed")
+	}
+	if _, err := j.file.Write(data); err != nil {
+		return xerrors.Errorf("write journal entry: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return xerrors.Errorf("sync journal: %w", err)
+	}
+	return nil
+}
+
+// rewrite atomically replaces the journal contents with the given entries.
+func (j *Journal) rewrite(entries []journalEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return xerrors.Errorf("encode journal entry: %w", err)
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return xerrors.New("journal is closed")
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return xerrors.Errorf("write journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		_ = os.Remove(tmp)
+		return xerrors.Errorf("replace journal: %w", err)
+	}
+
+	// The open file still refers to the replaced journal, so reopen it.
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return xerrors.Errorf("reopen journal: %w", err)
+	}
+	_ = j.file.Close()
+	j.file = file
+	return nil
+}
+
+// takeEntries returns the entries loaded by OpenJournal and releases them.
+func (j *Journal) takeEntries() []journalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := j.entries
+	j.entries = nil
+	return entries
+}
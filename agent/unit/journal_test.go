@@ -0,0 +1,195 @@
+package unit_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/unit"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
+)
+
+// restart simulates an agent restart by closing the journal and opening a
+// new manager over the same directory.
+func restart(t *testing.T, dir string, journal *unit.Journal, opts ...unit.ManagerOption) (*unit.Manager, *unit.Journal) {
+	t.Helper()
+
+	if journal != nil {
+		require.NoError(t, journal.Close())
+	}
+	journal, err := unit.OpenJournal(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = journal.Close()
+	})
+
+	return unit.NewManager(append(opts, unit.WithJournal(journal))...), journal
+}
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReplaysStateAfterRestart", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		manager, journal := restart(t, dir, nil)
+
+		// Given: A depends on B, B has completed, and C has failed
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.Register(unitB))
+		require.NoError(t, manager.Register(unitC))
+		require.NoError(t, manager.Register(unitD))
+		require.NoError(t, manager.AddDependency(unitA, unitB, unit.StatusComplete))
+		require.NoError(t, manager.AddDependency(unitD, unitC, unit.StatusComplete))
+		require.NoError(t, manager.UpdateStatus(unitB, unit.StatusStarted))
+		require.NoError(t, manager.UpdateStatus(unitB, unit.StatusComplete))
+		require.NoError(t, manager.Fail(unitC, "exit status 1"))
+
+		// When: the agent restarts
+		manager, _ = restart(t, dir, journal)
+
+		// Then: statuses, dependencies and readiness are restored
+		u, err := manager.Unit(unitB)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusComplete, u.Status())
+
+		isReady, err := manager.IsReady(unitA)
+		require.NoError(t, err)
+		assert.True(t, isReady)
+		dependencies, err := manager.GetAllDependencies(unitA)
+		require.NoError(t, err)
+		require.Len(t, dependencies, 1)
+		assert.Equal(t, unitB, dependencies[0].DependsOn)
+
+		u, err = manager.Unit(unitC)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusFailed, u.Status())
+		assert.Equal(t, "exit status 1", u.FailureReason())
+
+		u, err = manager.Unit(unitD)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusBlocked, u.Status())
+		assert.Equal(t, unitC, u.BlockedBy())
+
+		// Then: a registered unit cannot be registered again
+		err = manager.Register(unitA)
+		require.ErrorIs(t, err, unit.ErrUnitAlreadyRegistered)
+	})
+
+	t.Run("SurvivesMultipleRestarts", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		manager, journal := restart(t, dir, nil)
+
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.UpdateStatus(unitA, unit.StatusStarted))
+
+		manager, journal = restart(t, dir, journal)
+		require.NoError(t, manager.UpdateStatus(unitA, unit.StatusComplete))
+
+		manager, _ = restart(t, dir, journal)
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusComplete, u.Status())
+	})
+
+	t.Run("IgnoresTruncatedEntry", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		manager, journal := restart(t, dir, nil)
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, journal.Close())
+
+		// Given: the agent crashed while writing an entry
+		f, err := os.OpenFile(filepath.Join(dir, unit.JournalFileName), os.O_APPEND|os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"op":"register","un`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		// Then: the complete entries are replayed
+		manager, _ = restart(t, dir, nil)
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusPending, u.Status())
+	})
+
+	t.Run("CorruptJournal", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, unit.JournalFileName), []byte("not json\n"), 0o600)
+		require.NoError(t, err)
+
+		_, err = unit.OpenJournal(dir)
+		require.Error(t, err)
+	})
+
+	t.Run("ResetClearsJournal", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		manager, journal := restart(t, dir, nil)
+
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.AddDependency(unitA, unitB, unit.StatusComplete))
+
+		// When: the state is reset
+		require.NoError(t, manager.Reset())
+
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusNotRegistered, u.Status())
+
+		// Then: nothing is replayed after a restart
+		manager, _ = restart(t, dir, journal)
+		u, err = manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusNotRegistered, u.Status())
+		_, err = manager.GetAllDependencies(unitA)
+		require.ErrorIs(t, err, unit.ErrUnitNotFound)
+	})
+
+	t.Run("ResumesTimeouts", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dir := t.TempDir()
+		manager, journal := restart(t, dir, nil, unit.WithClock(quartz.NewMock(t)))
+
+		// Given: A and B must complete within one and two minutes
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.Register(unitB))
+		require.NoError(t, manager.SetTimeout(unitA, time.Minute))
+		require.NoError(t, manager.SetTimeout(unitB, 2*time.Minute))
+
+		// When: the agent comes back after 90 seconds
+		clock := quartz.NewMock(t)
+		clock.Set(clock.Now().Add(90 * time.Second)).MustWait(ctx)
+		manager, _ = restart(t, dir, journal, unit.WithClock(clock))
+
+		// Then: A has failed immediately and B has its remaining time
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusFailed, u.Status())
+		assert.Equal(t, "timed out after 1m0s", u.FailureReason())
+
+		u, err = manager.Unit(unitB)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusPending, u.Status())
+
+		clock.Advance(30 * time.Second).MustWait(ctx)
+		u, err = manager.Unit(unitB)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusFailed, u.Status())
+		assert.Equal(t, "timed out after 2m0s", u.FailureReason())
+	})
+}
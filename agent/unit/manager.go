@@ -1,13 +1,18 @@
 package unit
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/coderd/util/slice"
+	"github.com/coder/quartz"
 )
 
 var (
@@ -19,6 +24,9 @@ var (
 	ErrSameStatusAlreadySet     = xerrors.New("same status already set")
 	ErrCycleDetected            = xerrors.New("cycle detected")
 	ErrFailedToAddDependency    = xerrors.New("failed to add dependency")
+	ErrInvalidTimeout           = xerrors.New("timeout must be positive")
+	ErrJournalWrite             = xerrors.New("failed to write journal")
+	ErrUnitBlocked              = xerrors.New("unit is blocked by a failed dependency")
 )
 
 // Status represents the status of a unit.
@@ -39,6 +47,11 @@ const (
 	StatusPending       Status = "pending"
 	StatusStarted       Status = "started"
 	StatusComplete      Status = "completed"
+	StatusFailed        Status = "failed"
+	// StatusBlocked is assigned by the Manager to pending units that can never
+	// become ready because one of their dependencies, direct or transitive,
+	// has failed.
+	StatusBlocked Status = "blocked"
 )
 
 // ID provides a type narrowed representation of the unique identifier of a unit.
@@ -56,6 +69,12 @@ type Unit struct {
 	// Only the Manager can calculate whether a unit is ready based on knowledge of the dependency graph.
 	// To discourage use of an outdated readiness value, only the Manager should set and return this field.
 	ready bool
+	// failureReason describes why the unit failed. It is only set when the
+	// status is StatusFailed.
+	failureReason string
+	// blockedBy is the failed unit that prevents this unit from becoming ready.
+	// It is only set when the status is StatusBlocked.
+	blockedBy ID
 }
 
 func (u Unit) ID() ID {
@@ -66,6 +85,16 @@ func (u Unit) Status() Status {
 	return u.status
 }
 
+// FailureReason returns the reason the unit failed, if any.
+func (u Unit) FailureReason() string {
+	return u.failureReason
+}
+
+// BlockedBy returns the ID of the failed unit that blocks this unit, if any.
+func (u Unit) BlockedBy() ID {
+	return u.blockedBy
+}
+
 // Dependency represents a dependency relationship between units.
 type Dependency struct {
 	Unit           ID
@@ -75,25 +104,79 @@ type Dependency struct {
 	IsSatisfied    bool
 }
 
+// FailureCallback is invoked when a unit transitions to StatusFailed, either
+// explicitly or because its timeout elapsed. It is not invoked for units that
+// become blocked as a consequence.
+type FailureCallback func(id ID, reason string)
+
+// ManagerOption configures optional behavior of a Manager.
+type ManagerOption func(*Manager)
+
+// WithClock sets the clock used to enforce unit timeouts.
+func WithClock(clock quartz.Clock) ManagerOption {
+	return func(m *Manager) {
+		m.clock = clock
+	}
+}
+
+// WithFailureCallback sets a callback that is invoked whenever a unit fails.
+// The callback is called without the Manager lock held.
+func WithFailureCallback(cb FailureCallback) ManagerOption {
+	return func(m *Manager) {
+		m.onFailure = cb
+	}
+}
+
+// WithJournal persists the state of the Manager to the given journal. Entries
+// already recorded in the journal are replayed when the Manager is created,
+// without invoking the failure callback. Timeouts that elapsed while the
+// Manager was not running fail their unit immediately.
+func WithJournal(j *Journal) ManagerOption {
+	return func(m *Manager) {
+		m.journal = j
+	}
+}
+
 // Manager provides reactive dependency tracking over a Graph.
 // It manages Unit registration, dependency relationships, and status updates
 // with automatic recalculation of readiness when dependencies are satisfied.
 type Manager struct {
 	mu sync.RWMutex
 
+	clock     quartz.Clock
+	onFailure FailureCallback
+	journal   *Journal
+
 	// The underlying graph that stores dependency relationships
 	graph *Graph[Status, ID]
 
 	// Store vertex instances for each unit to ensure consistent references
 	units map[ID]Unit
+
+	// timers holds the pending timeout for each unit that has one.
+	timers map[ID]*quartz.Timer
+
+	// changed is closed and replaced whenever units, dependencies or
+	// statuses change, to wake up callers of WaitReady.
+	changed chan struct{}
 }
 
 // NewManager creates a new Manager instance.
-func NewManager() *Manager {
-	return &Manager{
-		graph: &Graph[Status, ID]{},
-		units: make(map[ID]Unit),
+func NewManager(opts ...ManagerOption) *Manager {
+	m := &Manager{
+		clock:   quartz.NewReal(),
+		graph:   &Graph[Status, ID]{},
+		units:   make(map[ID]Unit),
+		timers:  make(map[ID]*quartz.Timer),
+		changed: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	if m.journal != nil {
+		m.replay(m.journal.takeEntries())
+	}
+	return m
 }
 
 // Register adds a unit to the manager if it is not already registered.
@@ -110,13 +193,22 @@ func (m *Manager) Register(id ID) error {
 		return xerrors.Errorf("registering unit %q: %w", id, ErrUnitAlreadyRegistered)
 	}
 
+	m.registerUnsafe(id)
+
+	return m.record(journalEntry{Op: journalOpRegister, Unit: id})
+}
+
+// registerUnsafe adds a unit in the pending state. Dependencies declared
+// before the unit was registered are taken into account.
+// This method assumes the caller holds the write lock.
+func (m *Manager) registerUnsafe(id ID) {
 	m.units[id] = Unit{
 		id:     id,
 		status: StatusPending,
 		ready:  true,
 	}
-
-	return nil
+	m.recalculateReadinessUnsafe(id)
+	m.notifyUnsafe()
 }
 
 // registered checks if a unit is registered in the manager.
@@ -153,6 +245,36 @@ func (m *Manager) IsReady(id ID) (bool, error) {
 	return m.units[id].ready, nil
 }
 
+// WaitReady blocks until all dependencies of the unit are satisfied or the
+// context is canceled. Unregistered units are considered ready. If a
+// dependency fails, so that the unit becomes blocked, an error wrapping
+// ErrUnitBlocked is returned.
+func (m *Manager) WaitReady(ctx context.Context, id ID) error {
+	if id == "" {
+		return xerrors.Errorf("unit ID cannot be empty: %w", ErrUnitIDRequired)
+	}
+
+	for {
+		m.mu.RLock()
+		u := m.units[id]
+		changed := m.changed
+		m.mu.RUnlock()
+
+		switch {
+		case u.status == StatusBlocked:
+			return xerrors.Errorf("unit %q is blocked by %q: %w", id, u.blockedBy, ErrUnitBlocked)
+		case u.status == StatusNotRegistered || u.ready:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
 // AddDependency adds a dependency relationship between units.
 // The unit depends on the dependsOn unit reaching the requiredStatus.
 func (m *Manager) AddDependency(unit ID, dependsOn ID, requiredStatus Status) error {
@@ -177,30 +299,84 @@ func (m *Manager) AddDependency(unit ID, dependsOn ID, requiredStatus Status) er
 
 	// Recalculate readiness for the unit since it now has a new dependency
 	m.recalculateReadinessUnsafe(unit)
+	m.notifyUnsafe()
 
-	return nil
+	return m.record(journalEntry{Op: journalOpDepend, Unit: unit, DependsOn: dependsOn, Status: requiredStatus})
 }
 
 // UpdateStatus updates a unit's status and recalculates readiness for affected dependents.
 func (m *Manager) UpdateStatus(unit ID, newStatus Status) error {
+	return m.setStatus(unit, newStatus, "")
+}
+
+// Fail marks a unit as failed with the given reason. Pending units that depend
+// on it, directly or transitively, are marked as blocked.
+func (m *Manager) Fail(unit ID, reason string) error {
+	return m.setStatus(unit, StatusFailed, reason)
+}
+
+func (m *Manager) setStatus(unit ID, newStatus Status, reason string) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	switch {
 	case unit == "":
+		m.mu.Unlock()
 		return xerrors.Errorf("updating status for unit %q: %w", unit, ErrUnitIDRequired)
 	case !m.registered(unit):
+		m.mu.Unlock()
 		return xerrors.Errorf("unit %q must be registered first: %w", unit, ErrUnitNotFound)
 	}
 
 	u := m.units[unit]
 	if u.status == newStatus {
+		m.mu.Unlock()
 		return xerrors.Errorf("checking status for unit %q: %w", unit, ErrSameStatusAlreadySet)
 	}
 
+	m.updateStatusUnsafe(unit, newStatus, reason)
+	err := m.record(journalEntry{Op: journalOpStatus, Unit: unit, Status: newStatus, Reason: reason})
+	onFailure := m.onFailure
+	m.mu.Unlock()
+
+	if newStatus == StatusFailed && onFailure != nil {
+		onFailure(unit, reason)
+	}
+
+	return err
+}
+
+// updateStatusUnsafe sets the status of a registered unit and recalculates
+// readiness for its dependents. This method assumes the caller holds the write lock.
+func (m *Manager) updateStatusUnsafe(unit ID, newStatus Status, reason string) {
+	u := m.units[unit]
 	u.status = newStatus
+	u.failureReason = ""
+	u.blockedBy = ""
+	if newStatus == StatusFailed {
+		u.failureReason = reason
+	}
 	m.units[unit] = u
 
+	// A unit that has completed or failed can no longer time out.
+	if newStatus == StatusComplete || newStatus == StatusFailed {
+		m.stopTimerUnsafe(unit)
+	}
+
+	m.recalculateDependentsUnsafe(unit)
+	m.notifyUnsafe()
+}
+
+// notifyUnsafe wakes up all callers of WaitReady so that they re-evaluate
+// the state of their unit.
+// This method assumes the caller holds the write lock.
+func (m *Manager) notifyUnsafe() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// recalculateDependentsUnsafe recalculates readiness for all units that depend
+// on the given unit. This method assumes the caller holds the write lock.
+func (m *Manager) recalculateDependentsUnsafe(unit ID) {
 	// Get all units that depend on this one (reverse adjacent vertices)
 	dependents := m.graph.GetReverseAdjacentVertices(unit)
 
@@ -208,28 +384,255 @@ func (m *Manager) UpdateStatus(unit ID, newStatus Status) error {
 	for _, dependent := range dependents {
 		m.recalculateReadinessUnsafe(dependent.From)
 	}
-
-	return nil
 }
 
 // recalculateReadinessUnsafe recalculates the readiness state for a unit.
+// Pending units with a failed or blocked dependency become blocked, and
+// blocked units whose dependencies recover become pending again. Changes in
+// blocked state are propagated to dependents, which terminates because the
+// graph is acyclic.
 // This method assumes the caller holds the write lock.
 func (m *Manager) recalculateReadinessUnsafe(unit ID) {
 	u := m.units[unit]
 	dependencies := m.graph.GetForwardAdjacentVertices(unit)
 
 	allSatisfied := true
+	var blockedBy ID
 	for _, dependency := range dependencies {
 		requiredStatus := dependency.Edge
 		dependsOnUnit := m.units[dependency.To]
 		if dependsOnUnit.status != requiredStatus {
 			allSatisfied = false
-			break
+		}
+		if blockedBy == "" {
+			switch dependsOnUnit.status {
+			case StatusFailed:
+				blockedBy = dependency.To
+			case StatusBlocked:
+				blockedBy = dependsOnUnit.blockedBy
+			}
 		}
 	}
 
 	u.ready = allSatisfied
+	prevStatus, prevBlockedBy := u.status, u.blockedBy
+	switch {
+	case blockedBy != "" && (u.status == StatusPending || u.status == StatusBlocked):
+		u.status = StatusBlocked
+		u.blockedBy = blockedBy
+	case blockedBy == "" && u.status == StatusBlocked:
+		u.status = StatusPending
+		u.blockedBy = ""
+	}
 	m.units[unit] = u
+
+	// A blocked unit cannot make progress, so it must not time out either.
+	if u.status == StatusBlocked {
+		m.stopTimerUnsafe(unit)
+	}
+
+	if u.status != prevStatus || u.blockedBy != prevBlockedBy {
+		m.recalculateDependentsUnsafe(unit)
+	}
+}
+
+// SetTimeout fails the unit with a timeout reason if it has not completed
+// within the given duration. Setting a new timeout replaces any existing one.
+func (m *Manager) SetTimeout(unit ID, timeout time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case unit == "":
+		return xerrors.Errorf("setting timeout for unit %q: %w", unit, ErrUnitIDRequired)
+	case timeout <= 0:
+		return xerrors.Errorf("setting timeout for unit %q: %w", unit, ErrInvalidTimeout)
+	case !m.registered(unit):
+		return xerrors.Errorf("unit %q must be registered first: %w", unit, ErrUnitNotFound)
+	}
+
+	m.startTimerUnsafe(unit, timeout, timeout)
+
+	return m.record(journalEntry{
+		Op:       journalOpTimeout,
+		Unit:     unit,
+		Timeout:  timeout,
+		Deadline: m.clock.Now().Add(timeout),
+	})
+}
+
+// startTimerUnsafe fails the unit after the remaining duration, replacing any
+// existing timeout. The original timeout is used in the failure reason.
+// Blocked units are not timed.
+// This method assumes the caller holds the write lock.
+func (m *Manager) startTimerUnsafe(unit ID, remaining, timeout time.Duration) {
+	m.stopTimerUnsafe(unit)
+	if m.units[unit].status == StatusBlocked {
+		return
+	}
+
+	var timer *quartz.Timer
+	timer = m.clock.AfterFunc(remaining, func() {
+		m.expire(unit, timer, timeout)
+	}, "unit", "timeout")
+	m.timers[unit] = timer
+}
+
+// expire fails a unit whose timeout has elapsed. The timer is compared against
+// the current one to ignore timeouts that were replaced or stopped while the
+// callback was being scheduled.
+func (m *Manager) expire(unit ID, timer *quartz.Timer, timeout time.Duration) {
+	m.mu.Lock()
+	if m.timers[unit] != timer {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.timers, unit)
+
+	u := m.units[unit]
+	if u.status == StatusComplete || u.status == StatusFailed {
+		m.mu.Unlock()
+		return
+	}
+
+	reason := fmt.Sprintf("timed out after %s", timeout)
+	m.updateStatusUnsafe(unit, StatusFailed, reason)
+	// There is no caller to report a journal error to. If the entry is lost,
+	// the timeout is replayed and fails the unit again after a restart.
+	_ = m.record(journalEntry{Op: journalOpStatus, Unit: unit, Status: StatusFailed, Reason: reason})
+	onFailure := m.onFailure
+	m.mu.Unlock()
+
+	if onFailure != nil {
+		onFailure(unit, reason)
+	}
+}
+
+// stopTimerUnsafe stops and forgets the timeout for a unit, if any.
+// This method assumes the caller holds the write lock.
+func (m *Manager) stopTimerUnsafe(unit ID) {
+	if timer, ok := m.timers[unit]; ok {
+		timer.Stop()
+		delete(m.timers, unit)
+	}
+}
+
+// Reset removes all units, dependencies and timeouts from the manager and
+// clears its journal, if any.
+func (m *Manager) Reset() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id := range m.timers {
+		m.stopTimerUnsafe(id)
+	}
+	m.graph = &Graph[Status, ID]{}
+	m.units = make(map[ID]Unit)
+	m.notifyUnsafe()
+
+	if m.journal != nil {
+		if err := m.journal.rewrite(nil); err != nil {
+			return xerrors.Errorf("reset journal: %w", errors.Join(ErrJournalWrite, err))
+		}
+	}
+	return nil
+}
+
+// record appends an entry to the journal, if any. The in-memory state has
+// already been updated when this is called, so a returned error means that
+// the change will not survive a restart.
+// This method assumes the caller holds the write lock.
+func (m *Manager) record(entry journalEntry) error {
+	if m.journal == nil {
+		return nil
+	}
+	if err := m.journal.append(entry); err != nil {
+		return xerrors.Errorf("recording %s for unit %q: %w", entry.Op, entry.Unit, errors.Join(ErrJournalWrite, err))
+	}
+	return nil
+}
+
+// replay applies journal entries to an empty manager and compacts the journal
+// to a snapshot of the resulting state. Entries that no longer apply, such as
+// duplicate registrations, are skipped.
+func (m *Manager) replay(entries []journalEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	deadlines := make(map[ID]journalEntry)
+	for _, entry := range entries {
+		switch entry.Op {
+		case journalOpRegister:
+			if entry.Unit != "" && !m.registered(entry.Unit) {
+				m.registerUnsafe(entry.Unit)
+			}
+		case journalOpDepend:
+			if err := m.graph.AddEdge(entry.Unit, entry.DependsOn, entry.Status); err == nil {
+				m.recalculateReadinessUnsafe(entry.Unit)
+			}
+		case journalOpStatus:
+			if m.registered(entry.Unit) {
+				m.updateStatusUnsafe(entry.Unit, entry.Status, entry.Reason)
+				if entry.Status == StatusComplete || entry.Status == StatusFailed {
+					delete(deadlines, entry.Unit)
+				}
+			}
+		case journalOpTimeout:
+			if m.registered(entry.Unit) {
+				deadlines[entry.Unit] = entry
+			}
+		}
+	}
+
+	for id, entry := range deadlines {
+		if m.units[id].status == StatusBlocked {
+			continue
+		}
+		remaining := entry.Deadline.Sub(now)
+		if remaining <= 0 {
+			m.updateStatusUnsafe(id, StatusFailed, fmt.Sprintf("timed out after %s", entry.Timeout))
+			continue
+		}
+		m.startTimerUnsafe(id, remaining, entry.Timeout)
+	}
+
+	// Compacting is an optimization. If it fails, the journal still holds
+	// the full history and can be appended to.
+	_ = m.journal.rewrite(m.snapshotUnsafe(deadlines))
+}
+
+// snapshotUnsafe returns journal entries that reproduce the current state.
+// Blocked units are recorded as pending, since blocking is derived from the
+// status of their dependencies.
+// This method assumes the caller holds the write lock.
+func (m *Manager) snapshotUnsafe(deadlines map[ID]journalEntry) []journalEntry {
+	ids := make([]ID, 0, len(m.units))
+	for id := range m.units {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var entries []journalEntry
+	for _, id := range ids {
+		entries = append(entries, journalEntry{Op: journalOpRegister, Unit: id})
+	}
+	for _, id := range ids {
+		for _, dependency := range m.graph.GetForwardAdjacentVertices(id) {
+			entries = append(entries, journalEntry{Op: journalOpDepend, Unit: id, DependsOn: dependency.To, Status: dependency.Edge})
+		}
+	}
+	for _, id := range ids {
+		u := m.units[id]
+		switch u.status {
+		case StatusStarted, StatusComplete, StatusFailed:
+			entries = append(entries, journalEntry{Op: journalOpStatus, Unit: id, Status: u.status, Reason: u.failureReason})
+		}
+		if _, ok := m.timers[id]; ok {
+			entries = append(entries, deadlines[id])
+		}
+	}
+	return entries
 }
 
 // GetGraph returns the underlying graph for visualization and debugging.
@@ -285,6 +688,46 @@ func (m *Manager) GetUnmetDependencies(unit ID) ([]Dependency, error) {
 }
 
 // ExportDOT exports the dependency graph to DOT format for visualization.
+// Nodes are labeled with the unit name and status, and edges with the status
+// the dependency must reach. Units that are depended on but not registered
+// are included with the status "not registered".
 func (m *Manager) ExportDOT(name string) (string, error) {
-	return m.graph.ToDOT(name)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]ID, 0, len(m.units))
+	for id := range m.units {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	nodes := slices.Clone(ids)
+	var edges []Edge[Status, ID]
+	for _, id := range ids {
+		dependencies := m.graph.GetForwardAdjacentVertices(id)
+		slices.SortFunc(dependencies, func(a, b Edge[Status, ID]) int {
+			return strings.Compare(string(a.To), string(b.To))
+		})
+		for _, dependency := range dependencies {
+			edges = append(edges, dependency)
+			if !m.registered(dependency.To) && !slices.Contains(nodes, dependency.To) {
+				nodes = append(nodes, dependency.To)
+			}
+		}
+	}
+	slices.Sort(nodes)
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "strict digraph %q {\n", name)
+	_, _ = fmt.Fprintln(&b, "  // Node definitions.")
+	for _, id := range nodes {
+		_, _ = fmt.Fprintf(&b, "  %q [label=%q];\n", id, fmt.Sprintf("%s\n(%s)", id, m.units[id].status))
+	}
+	_, _ = fmt.Fprintln(&b)
+	_, _ = fmt.Fprintln(&b, "  // Edge definitions.")
+	for _, edge := range edges {
+		_, _ = fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Edge)
+	}
+	_, _ = fmt.Fprint(&b, "}")
+	return b.String(), nil
 }
@@ -1,12 +1,17 @@
 package unit_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/agent/unit"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
 )
 
 const (
@@ -434,6 +439,291 @@ func TestManager_UpdateStatus(t *testing.T) {
 	})
 }
 
+func TestManager_Fail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FailBlocksTransitiveDependents", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+
+		// Given units A, B, and C are registered
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+		err = manager.Register(unitC)
+		require.NoError(t, err)
+
+		// Create chain: A depends on B, B depends on C
+		err = manager.AddDependency(unitA, unitB, unit.StatusComplete)
+		require.NoError(t, err)
+		err = manager.AddDependency(unitB, unitC, unit.StatusComplete)
+		require.NoError(t, err)
+
+		// When: Unit C fails
+		err = manager.Fail(unitC, "exit status 1")
+		require.NoError(t, err)
+
+		// Then: Unit C is failed with the given reason
+		u, err := manager.Unit(unitC)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusFailed, u.Status())
+		assert.Equal(t, "exit status 1", u.FailureReason())
+
+		// Then: Units A and B are blocked by Unit C
+		for _, id := range []unit.ID{unitA, unitB} {
+			u, err = manager.Unit(id)
+			require.NoError(t, err)
+			assert.Equal(t, unit.StatusBlocked, u.Status())
+			assert.Equal(t, unitC, u.BlockedBy())
+			isReady, err := manager.IsReady(id)
+			require.NoError(t, err)
+			assert.False(t, isReady)
+		}
+	})
+
+	t.Run("DependencyOnFailedUnitIsBlocked", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+
+		// Given: Unit B has already failed
+		err = manager.Fail(unitB, "")
+		require.NoError(t, err)
+
+		// When: Unit A declares a dependency on Unit B
+		err = manager.AddDependency(unitA, unitB, unit.StatusComplete)
+		require.NoError(t, err)
+
+		// Then: Unit A is blocked immediately
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusBlocked, u.Status())
+		assert.Equal(t, unitB, u.BlockedBy())
+	})
+
+	t.Run("RecoveryUnblocksDependents", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+		err = manager.AddDependency(unitA, unitB, unit.StatusComplete)
+		require.NoError(t, err)
+
+		// Given: Unit B failed and blocked Unit A
+		err = manager.Fail(unitB, "flaky")
+		require.NoError(t, err)
+
+		// When: Unit B is retried and completes
+		err = manager.UpdateStatus(unitB, unit.StatusStarted)
+		require.NoError(t, err)
+		err = manager.UpdateStatus(unitB, unit.StatusComplete)
+		require.NoError(t, err)
+
+		// Then: Unit A is pending and ready again
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusPending, u.Status())
+		assert.Empty(t, u.BlockedBy())
+		isReady, err := manager.IsReady(unitA)
+		require.NoError(t, err)
+		assert.True(t, isReady)
+
+		u, err = manager.Unit(unitB)
+		require.NoError(t, err)
+		assert.Empty(t, u.FailureReason())
+	})
+
+	t.Run("StartedDependentIsNotBlocked", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+		err = manager.AddDependency(unitA, unitB, unit.StatusStarted)
+		require.NoError(t, err)
+
+		// Given: Unit A started after Unit B started
+		err = manager.UpdateStatus(unitB, unit.StatusStarted)
+		require.NoError(t, err)
+		err = manager.UpdateStatus(unitA, unit.StatusStarted)
+		require.NoError(t, err)
+
+		// When: Unit B fails
+		err = manager.Fail(unitB, "")
+		require.NoError(t, err)
+
+		// Then: Unit A keeps its status
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusStarted, u.Status())
+	})
+
+	t.Run("FailureCallback", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu     sync.Mutex
+			failed = map[unit.ID]string{}
+		)
+		manager := unit.NewManager(unit.WithFailureCallback(func(id unit.ID, reason string) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed[id] = reason
+		}))
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+		err = manager.AddDependency(unitA, unitB, unit.StatusComplete)
+		require.NoError(t, err)
+
+		err = manager.Fail(unitB, "boom")
+		require.NoError(t, err)
+
+		// Then: only the failed unit is reported, not the blocked one
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, map[unit.ID]string{unitB: "boom"}, failed)
+	})
+
+	t.Run("FailUnregisteredUnit", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+
+		err := manager.Fail(unitA, "")
+		require.ErrorIs(t, err, unit.ErrUnitNotFound)
+	})
+}
+
+func TestManager_SetTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TimeoutFailsStuckUnit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		clock := quartz.NewMock(t)
+		manager := unit.NewManager(unit.WithClock(clock))
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+		err = manager.AddDependency(unitA, unitB, unit.StatusComplete)
+		require.NoError(t, err)
+
+		// Given: Unit B must complete within a minute
+		err = manager.SetTimeout(unitB, time.Minute)
+		require.NoError(t, err)
+		err = manager.UpdateStatus(unitB, unit.StatusStarted)
+		require.NoError(t, err)
+
+		// When: the timeout elapses
+		clock.Advance(time.Minute).MustWait(ctx)
+
+		// Then: Unit B has failed and Unit A is blocked
+		u, err := manager.Unit(unitB)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusFailed, u.Status())
+		assert.Equal(t, "timed out after 1m0s", u.FailureReason())
+
+		u, err = manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusBlocked, u.Status())
+		assert.Equal(t, unitB, u.BlockedBy())
+	})
+
+	t.Run("CompletedUnitDoesNotTimeOut", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		clock := quartz.NewMock(t)
+		manager := unit.NewManager(unit.WithClock(clock))
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.SetTimeout(unitA, time.Minute)
+		require.NoError(t, err)
+
+		// Given: Unit A completes before its timeout
+		err = manager.UpdateStatus(unitA, unit.StatusStarted)
+		require.NoError(t, err)
+		err = manager.UpdateStatus(unitA, unit.StatusComplete)
+		require.NoError(t, err)
+
+		// When: the timeout would have elapsed
+		clock.Advance(time.Minute).MustWait(ctx)
+
+		// Then: Unit A is still complete
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusComplete, u.Status())
+	})
+
+	t.Run("BlockedUnitDoesNotTimeOut", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		clock := quartz.NewMock(t)
+		var failed []unit.ID
+		manager := unit.NewManager(unit.WithClock(clock), unit.WithFailureCallback(func(id unit.ID, _ string) {
+			failed = append(failed, id)
+		}))
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.Register(unitB)
+		require.NoError(t, err)
+		err = manager.AddDependency(unitA, unitB, unit.StatusComplete)
+		require.NoError(t, err)
+		err = manager.SetTimeout(unitA, time.Minute)
+		require.NoError(t, err)
+
+		// Given: Unit A becomes blocked because Unit B fails
+		err = manager.Fail(unitB, "exit status 1")
+		require.NoError(t, err)
+
+		// When: Unit A's timeout would have elapsed
+		clock.Advance(time.Minute).MustWait(ctx)
+
+		// Then: Unit A is still blocked by Unit B rather than timed out
+		u, err := manager.Unit(unitA)
+		require.NoError(t, err)
+		assert.Equal(t, unit.StatusBlocked, u.Status())
+		assert.Equal(t, unitB, u.BlockedBy())
+		assert.Equal(t, []unit.ID{unitB}, failed)
+	})
+
+	t.Run("InvalidTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+
+		err := manager.Register(unitA)
+		require.NoError(t, err)
+		err = manager.SetTimeout(unitA, 0)
+		require.ErrorIs(t, err, unit.ErrInvalidTimeout)
+		err = manager.SetTimeout(unitB, time.Minute)
+		require.ErrorIs(t, err, unit.ErrUnitNotFound)
+	})
+}
+
 func TestManager_GetUnmetDependencies(t *testing.T) {
 	t.Parallel()
 
@@ -688,6 +978,80 @@ func TestManager_IsReady(t *testing.T) {
 	})
 }
 
+func TestManager_WaitReady(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReturnsWhenDependencyCompletes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		manager := unit.NewManager()
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.Register(unitB))
+		require.NoError(t, manager.AddDependency(unitA, unitB, unit.StatusComplete))
+
+		done := make(chan error, 1)
+		go func() {
+			done <- manager.WaitReady(ctx, unitA)
+		}()
+
+		require.NoError(t, manager.UpdateStatus(unitB, unit.StatusStarted))
+		select {
+		case err := <-done:
+			t.Fatalf("WaitReady returned before the dependency completed: %v", err)
+		default:
+		}
+
+		require.NoError(t, manager.UpdateStatus(unitB, unit.StatusComplete))
+		require.NoError(t, testutil.RequireReceive(ctx, t, done))
+	})
+
+	t.Run("ReadyAndUnregisteredUnits", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		manager := unit.NewManager()
+		require.NoError(t, manager.Register(unitA))
+
+		require.NoError(t, manager.WaitReady(ctx, unitA))
+		require.NoError(t, manager.WaitReady(ctx, unitB))
+	})
+
+	t.Run("BlockedByFailedDependency", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		manager := unit.NewManager()
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.Register(unitB))
+		require.NoError(t, manager.Register(unitC))
+		require.NoError(t, manager.AddDependency(unitA, unitB, unit.StatusComplete))
+		require.NoError(t, manager.AddDependency(unitB, unitC, unit.StatusComplete))
+
+		done := make(chan error, 1)
+		go func() {
+			done <- manager.WaitReady(ctx, unitA)
+		}()
+
+		require.NoError(t, manager.Fail(unitC, "boom"))
+		err := testutil.RequireReceive(ctx, t, done)
+		require.ErrorIs(t, err, unit.ErrUnitBlocked)
+		require.ErrorContains(t, err, string(unitC))
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(testutil.Context(t, testutil.WaitShort))
+		manager := unit.NewManager()
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.AddDependency(unitA, unitB, unit.StatusComplete))
+
+		cancel()
+		require.ErrorIs(t, manager.WaitReady(ctx, unitA), context.Canceled)
+	})
+}
+
 func TestManager_ToDOT(t *testing.T) {
 	t.Parallel()
 
@@ -740,4 +1104,36 @@ func TestManager_ToDOT(t *testing.T) {
 		assert.NotEmpty(t, dot)
 		assert.Contains(t, dot, "digraph")
 	})
+
+	t.Run("ExportUnitNamesAndStatuses", func(t *testing.T) {
+		t.Parallel()
+
+		manager := unit.NewManager()
+		require.NoError(t, manager.Register(unitA))
+		require.NoError(t, manager.Register(unitB))
+		require.NoError(t, manager.AddDependency(unitA, unitB, unit.StatusComplete))
+		require.NoError(t, manager.AddDependency(unitA, unitC, unit.StatusComplete))
+		require.NoError(t, manager.UpdateStatus(unitB, unit.StatusStarted))
+
+		dot, err := manager.ExportDOT("units")
+		require.NoError(t, err)
+		require.Equal(t, `strict digraph "units" {
+  // Node definitions.
+  "serviceA" [label="serviceA\n(pending)"];
+  "serviceB" [label="serviceB\n(started)"];
+  "serviceC" [label="serviceC\n(not registered)"];
+
+  // Edge definitions.
+  "serviceA" -> "serviceB" [label="completed"];
+  "serviceA" -> "serviceC" [label="completed"];
+}`, dot)
+	})
+
+	t.Run("ExportEmptyGraph", func(t *testing.T) {
+		t.Parallel()
+
+		dot, err := unit.NewManager().ExportDOT("units")
+		require.NoError(t, err)
+		assert.Contains(t, dot, "digraph")
+	})
 }
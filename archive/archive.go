@@ -102,6 +102,13 @@ func WriteZip(w io.Writer, tarReader *tar.Reader, maxSize int64) error {
 		if err != nil {
 			return err
 		}
+		// Zip stores the target of a symbolic link as its content.
+		if tarHeader.Typeflag == tar.TypeSymlink {
+			if _, err := io.WriteString(zipEntry, tarHeader.Linkname); err != nil {
+				return err
+			}
+			continue
+		}
 
 		_, err = io.CopyN(zipEntry, tarReader, maxSize)
 		if errors.Is(err, io.EOF) {
@@ -0,0 +1,372 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+)
+
+// ErrUnsafePath is returned when an archive entry would be extracted outside
+// of the target directory.
+var ErrUnsafePath = xerrors.New("archive entry path is not local")
+
+// Filter selects which entries of a directory tree are archived or
+// extracted. Patterns use path.Match syntax. A pattern containing a "/" is
+// matched against the slash-separated path relative to the directory root,
+// otherwise it is matched against each path element, so "node_modules"
+// matches a directory of that name at any depth. A pattern matches an entry
+// if it matches the entry itself or any of its parent directories.
+type Filter struct {
+	// Include, if not empty, limits entries to those matching at least one
+	// pattern.
+	Include []string
+	// Exclude skips entries matching any pattern, taking precedence over
+	// Include.
+	Exclude []string
+}
+
+// Validate returns an error if any pattern is malformed.
+func (f Filter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return xerrors.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the entry at the slash-separated relative path rel
+// passes the filter.
+func (f Filter) Match(rel string) bool {
+	if matchAny(f.Exclude, rel) {
+		return false
+	}
+	return len(f.Include) == 0 || matchAny(f.Include, rel)
+}
+
+func matchAny(patterns []string, rel string) bool {
+	elems := strings.Split(rel, "/")
+	for _, pattern := range patterns {
+		hasSlash := strings.Contains(pattern, "/")
+		for i := range elems {
+			subject := elems[i]
+			if hasSlash {
+				subject = strings.Join(elems[:i+1], "/")
+			}
+			if ok, _ := path.Match(pattern, subject); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TarDir writes the contents of dir on fsys to w as a tar archive. Entry names
+// are relative to dir. Symbolic links are archived as links and other
+// non-regular files are skipped.
+func TarDir(w io.Writer, fsys afero.Fs, dir string, filter Filter) error {
+	tarWriter := tar.NewWriter(w)
+
+	err := afero.Walk(fsys, dir, func(file string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if matchAny(filter.Exclude, rel) {
+			if fileInfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if len(filter.Include) > 0 && !matchAny(filter.Include, rel) {
+			// Keep descending as included entries may be nested. Parent
+			// directories are created on extraction.
+			return nil
+		}
+
+		var link string
+		if fileInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
+			reader, ok := fsys.(afero.LinkReader)
+			if !ok {
+				return nil
+			}
+			link, err = reader.ReadlinkIfPossible(file)
+			if err != nil {
+				return err
+			}
+		} else if !fileInfo.IsDir() && !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(fileInfo, link)
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+		// tar.FileInfoHeader() will do this, but filepath.Rel() calls filepath.Clean()
+		// which strips trailing path separators for directories.
+		if fileInfo.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := fsys.Open(file)
+		if err != nil {
+			return err
+		}
+		defer data.Close()
+		_, err = io.Copy(tarWriter, data)
+		if err != nil {
+			return err
+		}
+		return data.Close()
+	})
+	if err != nil {
+		return err
+	}
+	return tarWriter.Close()
+}
+
+// ZipDir writes the contents of dir on fsys to w as a zip archive. See TarDir.
+func ZipDir(w io.Writer, fsys afero.Fs, dir string, filter Filter) error {
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(TarDir(pw, fsys, dir, filter))
+	}()
+	err := WriteZip(w, tar.NewReader(pr), math.MaxInt64)
+	// Unblock TarDir if WriteZip stopped early.
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+// UntarDir extracts the tar archive read from r into dir on fsys, creating dir
+// if it does not exist. Directories, regular files and symbolic links are
+// extracted, other entries are skipped. Entries that would escape dir, and
+// symbolic links whose targets would, return ErrUnsafePath. Symbolic links
+// return an error if fsys does not support them.
+func UntarDir(fsys afero.Fs, dir string, r io.Reader, filter Filter) error {
+	if err := fsys.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	x := newExtractor(fsys, dir, filter)
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// #nosec G115 - Safe conversion as tar header mode fits within uint32
+		mode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = x.entry(header.Name, true, mode, header.ModTime, nil)
+		case tar.TypeReg:
+			err = x.entry(header.Name, false, mode, header.ModTime, tarReader)
+		case tar.TypeSymlink:
+			err = x.symlink(header.Name, header.Linkname)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UnzipDir extracts the zip archive zr into dir on fsys. Symbolic links are
+// stored as entries whose content is the link target. See UntarDir.
+func UnzipDir(fsys afero.Fs, dir string, zr *zip.Reader, filter Filter) error {
+	if err := fsys.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	x := newExtractor(fsys, dir, filter)
+	for _, file := range zr.File {
+		info := file.FileInfo()
+		isDir := info.IsDir() || strings.HasSuffix(file.Name, "/")
+		isLink := info.Mode()&os.ModeSymlink == os.ModeSymlink
+		if !isDir && !isLink && !info.Mode().IsRegular() {
+			continue
+		}
+		err := func() error {
+			var data io.Reader
+			if !isDir {
+				rc, err := file.Open()
+				if err != nil {
+					return err
+				}
+				defer rc.Close()
+				data = rc
+			}
+			if isLink {
+				// Link targets are short, anything longer is not a valid path.
+				link, err := io.ReadAll(io.LimitReader(data, 4096))
+				if err != nil {
+					return xerrors.Errorf("extract %q: %w", file.Name, err)
+				}
+				return x.symlink(file.Name, string(link))
+			}
+			return x.entry(file.Name, isDir, info.Mode().Perm(), file.Modified, data)
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractor extracts archive entries into dir. It remembers the symbolic
+// links it creates, so that later entries cannot be written through them.
+type extractor struct {
+	fsys   afero.Fs
+	dir    string
+	filter Filter
+	links  map[string]struct{}
+}
+
+func newExtractor(fsys afero.Fs, dir string, filter Filter) *extractor {
+	return &extractor{
+		fsys:   fsys,
+		dir:    dir,
+		filter: filter,
+		links:  make(map[string]struct{}),
+	}
+}
+
+// target returns the path on fsys of the entry with the given name, and
+// whether it passes the filter.
+func (x *extractor) target(name string) (string, bool, error) {
+	rel := path.Clean(strings.TrimSuffix(name, "/"))
+	if rel == "." {
+		return "", false, nil
+	}
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", false, xerrors.Errorf("%q: %w", name, ErrUnsafePath)
+	}
+	// Entries beneath a link extracted earlier would be written to
+	// wherever it points.
+	for parent := path.Dir(rel); parent != "."; parent = path.Dir(parent) {
+		if _, ok := x.links[parent]; ok {
+			return "", false, xerrors.Errorf("%q: %w", name, ErrUnsafePath)
+		}
+	}
+	if !x.filter.Match(rel) {
+		return "", false, nil
+	}
+	return filepath.Join(x.dir, filepath.FromSlash(rel)), true, nil
+}
+
+func (x *extractor) entry(name string, isDir bool, mode os.FileMode, modTime time.Time, data io.Reader) error {
+	target, ok, err := x.target(name)
+	if err != nil || !ok {
+		return err
+	}
+	if isDir {
+		if mode == 0 {
+			mode = 0o755
+		}
+		return x.fsys.MkdirAll(target, mode)
+	}
+
+	if mode == 0 {
+		mode = 0o644
+	}
+	if err := x.fsys.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	file, err := x.fsys.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, data)
+	if err != nil {
+		_ = file.Close()
+		return xerrors.Errorf("extract %q: %w", name, err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if !modTime.IsZero() {
+		return x.fsys.Chtimes(target, modTime, modTime)
+	}
+	return nil
+}
+
+// symlink creates a symbolic link to link. The target must be relative and
+// stay within dir. It may only climb out of the link's own directory with
+// leading ".." elements, as a ".." following another element could climb
+// out of a directory the link points to instead.
+func (x *extractor) symlink(name, link string) error {
+	target, ok, err := x.target(name)
+	if err != nil || !ok {
+		return err
+	}
+	if !localLink(path.Clean(strings.TrimSuffix(name, "/")), link) {
+		return xerrors.Errorf("%q links to %q: %w", name, link, ErrUnsafePath)
+	}
+	linker, ok := x.fsys.(afero.Linker)
+	if !ok {
+		return xerrors.Errorf("extract %q: symbolic links are not supported", name)
+	}
+
+	if err := x.fsys.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	if err := x.fsys.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return xerrors.Errorf("extract %q: %w", name, err)
+	}
+	if err := linker.SymlinkIfPossible(filepath.FromSlash(link), target); err != nil {
+		return xerrors.Errorf("extract %q: %w", name, err)
+	}
+	x.links[path.Clean(strings.TrimSuffix(name, "/"))] = struct{}{}
+	return nil
+}
+
+// localLink reports whether the link target of the entry at the relative path
+// rel stays within the extraction directory.
+func localLink(rel, link string) bool {
+	// Backslashes are separators on Windows.
+	if link == "" || path.IsAbs(link) || filepath.IsAbs(link) || strings.Contains(link, `\`) {
+		return false
+	}
+	descended := false
+	for _, elem := range strings.Split(link, "/") {
+		switch elem {
+		case "", ".":
+		case "..":
+			if descended {
+				return false
+			}
+		default:
+			descended = true
+		}
+	}
+	return filepath.IsLocal(filepath.FromSlash(path.Join(path.Dir(rel), link)))
+}
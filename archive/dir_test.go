@@ -0,0 +1,303 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/archive"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter archive.Filter
+		rel    string
+		match  bool
+	}{
+		{name: "Empty", rel: "a/b.go", match: true},
+		{name: "IncludeBaseName", filter: archive.Filter{Include: []string{"*.go"}}, rel: "a/b.go", match: true},
+		{name: "IncludeBaseNameNoMatch", filter: archive.Filter{Include: []string{"*.go"}}, rel: "a/b.txt", match: false},
+		{name: "IncludeParent", filter: archive.Filter{Include: []string{"src"}}, rel: "src/deep/b.txt", match: true},
+		{name: "IncludeRelativePath", filter: archive.Filter{Include: []string{"a/*.go"}}, rel: "a/b.go", match: true},
+		{name: "IncludeRelativePathNoMatch", filter: archive.Filter{Include: []string{"a/*.go"}}, rel: "c/a/b.go", match: false},
+		{name: "ExcludeAnyDepth", filter: archive.Filter{Exclude: []string{"node_modules"}}, rel: "web/node_modules/x/index.js", match: false},
+		{name: "ExcludeWins", filter: archive.Filter{Include: []string{"*.go"}, Exclude: []string{"vendor"}}, rel: "vendor/a.go", match: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.match, tt.filter.Match(tt.rel))
+		})
+	}
+
+	require.Error(t, archive.Filter{Exclude: []string{"["}}.Validate())
+	require.NoError(t, archive.Filter{Include: []string{"*.go", "a/b"}}.Validate())
+}
+
+func TestTarDir(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		t.Parallel()
+
+		src := newDirFs(t)
+		var buf bytes.Buffer
+		err := archive.TarDir(&buf, src, "/src", archive.Filter{})
+		require.NoError(t, err)
+
+		dst := afero.NewMemMapFs()
+		err = archive.UntarDir(dst, "/dst", &buf, archive.Filter{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"a.go", "b.txt", "node_modules/dep.js", "src/c.go", "src/empty/"}, listFiles(t, dst, "/dst"))
+		b, err := afero.ReadFile(dst, "/dst/src/c.go")
+		require.NoError(t, err)
+		require.Equal(t, "package c", string(b))
+		stat, err := dst.Stat("/dst/b.txt")
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		t.Parallel()
+
+		src := newDirFs(t)
+		var buf bytes.Buffer
+		err := archive.TarDir(&buf, src, "/src", archive.Filter{
+			Include: []string{"*.go", "*.js"},
+			Exclude: []string{"node_modules"},
+		})
+		require.NoError(t, err)
+
+		var names []string
+		tr := tar.NewReader(&buf)
+		for {
+			header, err := tr.Next()
+			if err != nil {
+				break
+			}
+			names = append(names, header.Name)
+		}
+		require.Equal(t, []string{"a.go", "src/c.go"}, names)
+	})
+
+	t.Run("FilterOnExtract", func(t *testing.T) {
+		t.Parallel()
+
+		src := newDirFs(t)
+		var buf bytes.Buffer
+		err := archive.TarDir(&buf, src, "/src", archive.Filter{})
+		require.NoError(t, err)
+
+		dst := afero.NewMemMapFs()
+		err = archive.UntarDir(dst, "/dst", &buf, archive.Filter{Include: []string{"src"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"src/c.go", "src/empty/"}, listFiles(t, dst, "/dst"))
+	})
+
+	t.Run("UnsafePath", func(t *testing.T) {
+		t.Parallel()
+
+		for _, name := range []string{"../escape", "/abs", "a/../../escape"} {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte("x"))
+			require.NoError(t, err)
+			require.NoError(t, tw.Close())
+
+			dst := afero.NewMemMapFs()
+			err = archive.UntarDir(dst, "/dst", &buf, archive.Filter{})
+			require.ErrorIs(t, err, archive.ErrUnsafePath, name)
+		}
+	})
+
+	t.Run("Symlinks", func(t *testing.T) {
+		t.Parallel()
+
+		src := newSymlinkDir(t)
+		var buf bytes.Buffer
+		err := archive.TarDir(&buf, afero.NewOsFs(), src, archive.Filter{})
+		require.NoError(t, err)
+
+		dst := filepath.Join(t.TempDir(), "dst")
+		err = archive.UntarDir(afero.NewOsFs(), dst, &buf, archive.Filter{})
+		require.NoError(t, err)
+		requireSymlinks(t, dst)
+	})
+
+	t.Run("UnsafeSymlink", func(t *testing.T) {
+		t.Parallel()
+
+		if runtime.GOOS == "windows" {
+			t.Skip("Creating symbolic links requires elevated privileges on Windows.")
+		}
+
+		for _, entries := range [][]*tar.Header{
+			{{Name: "abs", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}},
+			{{Name: "parent", Linkname: "..", Typeflag: tar.TypeSymlink}},
+			{{Name: "a/escape", Linkname: "../../escape", Typeflag: tar.TypeSymlink}},
+			{{Name: "nested", Linkname: "a/../../escape", Typeflag: tar.TypeSymlink}},
+			// "x/.." is dir itself lexically, but the parent of wherever
+			// "x" points.
+			{{Name: "after", Linkname: "x/..", Typeflag: tar.TypeSymlink}},
+			// Entries must not be written through links.
+			{
+				{Name: "self", Linkname: ".", Typeflag: tar.TypeSymlink},
+				{Name: "self/file", Mode: 0o644, Typeflag: tar.TypeReg},
+			},
+		} {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, header := range entries {
+				require.NoError(t, tw.WriteHeader(header))
+			}
+			require.NoError(t, tw.Close())
+
+			err := archive.UntarDir(afero.NewOsFs(), t.TempDir(), &buf, archive.Filter{})
+			require.ErrorIs(t, err, archive.ErrUnsafePath, entries[len(entries)-1].Name)
+		}
+	})
+
+	t.Run("SymlinksUnsupported", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "link", Linkname: "a.go", Typeflag: tar.TypeSymlink}))
+		require.NoError(t, tw.Close())
+
+		// Links are not dropped silently.
+		err := archive.UntarDir(afero.NewMemMapFs(), "/dst", &buf, archive.Filter{})
+		require.Error(t, err)
+	})
+}
+
+func TestZipDir(t *testing.T) {
+	t.Parallel()
+
+	src := newDirFs(t)
+	var buf bytes.Buffer
+	err := archive.ZipDir(&buf, src, "/src", archive.Filter{Exclude: []string{"node_modules"}})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	dst := afero.NewMemMapFs()
+	err = archive.UnzipDir(dst, "/dst", zr, archive.Filter{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.go", "b.txt", "src/c.go", "src/empty/"}, listFiles(t, dst, "/dst"))
+	b, err := afero.ReadFile(dst, "/dst/a.go")
+	require.NoError(t, err)
+	require.Equal(t, "package a", string(b))
+
+	t.Run("Symlinks", func(t *testing.T) {
+		t.Parallel()
+
+		src := newSymlinkDir(t)
+		var buf bytes.Buffer
+		err := archive.ZipDir(&buf, afero.NewOsFs(), src, archive.Filter{})
+		require.NoError(t, err)
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+
+		dst := filepath.Join(t.TempDir(), "dst")
+		err = archive.UnzipDir(afero.NewOsFs(), dst, zr, archive.Filter{})
+		require.NoError(t, err)
+		requireSymlinks(t, dst)
+	})
+}
+
+// newSymlinkDir returns a directory on disk containing a file, and links to
+// the file and to a directory.
+func newSymlinkDir(t *testing.T) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("Creating symbolic links requires elevated privileges on Windows.")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "c.go"), []byte("package c"), 0o644))
+	require.NoError(t, os.Symlink("src/c.go", filepath.Join(dir, "link.go")))
+	require.NoError(t, os.Symlink("../c.go", filepath.Join(dir, "src", "sub", "up.go")))
+	require.NoError(t, os.Symlink("src", filepath.Join(dir, "srclink")))
+	return dir
+}
+
+// requireSymlinks asserts that dir holds the links of newSymlinkDir.
+func requireSymlinks(t *testing.T, dir string) {
+	t.Helper()
+
+	for name, want := range map[string]string{
+		"link.go":       "src/c.go",
+		"src/sub/up.go": "../c.go",
+		"srclink":       "src",
+	} {
+		link, err := os.Readlink(filepath.Join(dir, filepath.FromSlash(name)))
+		require.NoError(t, err, name)
+		require.Equal(t, filepath.FromSlash(want), link, name)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "srclink", "sub", "up.go"))
+	require.NoError(t, err)
+	require.Equal(t, "package c", string(b))
+}
+
+func newDirFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for name, content := range map[string]string{
+		"a.go":                "package a",
+		"src/c.go":            "package c",
+		"node_modules/dep.js": "module.exports = {}",
+	} {
+		name = filepath.Join("/src", filepath.FromSlash(name))
+		require.NoError(t, fs.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, afero.WriteFile(fs, name, []byte(content), 0o644))
+	}
+	require.NoError(t, afero.WriteFile(fs, "/src/b.txt", []byte("secret"), 0o600))
+	require.NoError(t, fs.MkdirAll("/src/src/empty", 0o755))
+	return fs
+}
+
+// listFiles returns the files and empty directories under dir, relative to
+// dir. Empty directories have a trailing slash.
+func listFiles(t *testing.T, fs afero.Fs, dir string) []string {
+	t.Helper()
+
+	var names []string
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		rel, err := filepath.Rel(dir, path)
+		require.NoError(t, err)
+		rel = filepath.ToSlash(rel)
+		if !info.IsDir() {
+			names = append(names, rel)
+			return nil
+		}
+		entries, err := afero.ReadDir(fs, path)
+		require.NoError(t, err)
+		if len(entries) == 0 {
+			names = append(names, rel+"/")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(names)
+	return names
+}
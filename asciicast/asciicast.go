@@ -0,0 +1,245 @@
+// Package asciicast reads and writes terminal session recordings in the
+// asciicast v2 format, as used by asciinema.
+//
+// See https://docs.asciinema.org/manual/asciicast/v2/ for the specification.
+package asciicast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+// Version is the asciicast format version written and read by this package.
+const Version = 2
+
+// MediaType is the media type of asciicast v2 files.
+const MediaType = "application/x-asciicast"
+
+// Header is the first line of a recording.
+type Header struct {
+	Version int `json:"version"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	// Timestamp is the start of the recording in seconds since the Unix
+	// epoch.
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// EventType is the type of an event in a recording.
+type EventType string
+
+const (
+	// EventTypeOutput is data written to the terminal.
+	EventTypeOutput EventType = "o"
+	// EventTypeInput is data typed by the user.
+	EventTypeInput EventType = "i"
+	// EventTypeResize is a change of the terminal size, with data in the
+	// form "COLSxROWS".
+	EventTypeResize EventType = "r"
+	// EventTypeMarker is a marker, e.g. a chapter or breakpoint.
+	EventTypeMarker EventType = "m"
+)
+
+// Event is a single line after the header of a recording.
+type Event struct {
+	// Time is the time since the start of the recording.
+	Time time.Duration
+	Type EventType
+	Data string
+}
+
+// MarshalJSON encodes the event as a [time, type, data] array.
+func (e Event) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := json.Marshal(e.Type)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	_ = buf.WriteByte('[')
+	_, _ = buf.WriteString(strconv.FormatFloat(e.Time.Seconds(), 'f', 6, 64))
+	_ = buf.WriteByte(',')
+	_, _ = buf.Write(typ)
+	_ = buf.WriteByte(',')
+	_, _ = buf.Write(data)
+	_ = buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes an event from a [time, type, data] array.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return xerrors.Errorf("event must have 3 elements, got %d", len(raw))
+	}
+	var seconds float64
+	if err := json.Unmarshal(raw[0], &seconds); err != nil {
+		return xerrors.Errorf("event time: %w", err)
+	}
+	if seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return xerrors.Errorf("invalid event time %v", seconds)
+	}
+	if err := json.Unmarshal(raw[1], &e.Type); err != nil {
+		return xerrors.Errorf("event type: %w", err)
+	}
+	if err := json.Unmarshal(raw[2], &e.Data); err != nil {
+		return xerrors.Errorf("event data: %w", err)
+	}
+	e.Time = time.Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// ResizeData formats the data of a resize event.
+func ResizeData(width, height int) string {
+	return strconv.Itoa(width) + "x" + strconv.Itoa(height)
+}
+
+// ParseResizeData parses the data of a resize event.
+func ParseResizeData(data string) (width, height int, err error) {
+	w, h, ok := strings.Cut(data, "x")
+	if !ok {
+		return 0, 0, xerrors.Errorf("invalid resize data %q", data)
+	}
+	width, err = strconv.Atoi(w)
+	if err != nil {
+		return 0, 0, xerrors.Errorf("invalid width in resize data %q: %w", data, err)
+	}
+	height, err = strconv.Atoi(h)
+	if err != nil {
+		return 0, 0, xerrors.Errorf("invalid height in resize data %q: %w", data, err)
+	}
+	return width, height, nil
+}
+
+// Writer writes a recording. It is not safe for concurrent use.
+type Writer struct {
+	w io.Writer
+	// pending holds the incomplete UTF-8 sequence at the end of the data
+	// of the last event of each type, so that multi-byte characters split
+	// across writes are not mangled.
+	pending map[EventType][]byte
+}
+
+// NewWriter writes the header to w and returns a Writer for the events.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	header.Version = Version
+	b, err := json.Marshal(header)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal header: %w", err)
+	}
+	if _, err := w.Write(append(b, '\n')); err != nil {
+		return nil, xerrors.Errorf("write header: %w", err)
+	}
+	return &Writer{w: w, pending: make(map[EventType][]byte)}, nil
+}
+
+// WriteEvent writes a single event.
+func (w *Writer) WriteEvent(ev Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return xerrors.Errorf("marshal event: %w", err)
+	}
+	if _, err := w.w.Write(append(b, '\n')); err != nil {
+		return xerrors.Errorf("write event: %w", err)
+	}
+	return nil
+}
+
+// WriteData writes raw terminal data as an event of the given type. Invalid
+// UTF-8 is replaced, and a multi-byte character that is split across calls is
+// written with the call that completes it.
+func (w *Writer) WriteData(t time.Duration, typ EventType, p []byte) error {
+	data := append(w.pending[typ], p...)
+	cut := incompleteSuffix(data)
+	w.pending[typ] = append([]byte(nil), data[cut:]...)
+	data = data[:cut]
+	if len(data) == 0 {
+		return nil
+	}
+	return w.WriteEvent(Event{Time: t, Type: typ, Data: string(bytes.ToValidUTF8(data, []byte(string(utf8.RuneError))))})
+}
+
+// incompleteSuffix returns the index at which a trailing, incomplete UTF-8
+// sequence starts, or len(p) if p does not end with one.
+func incompleteSuffix(p []byte) int {
+	// A UTF-8 sequence is at most utf8.UTFMax bytes long, so only the last
+	// few bytes need to be inspected.
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(p[i]) {
+			continue
+		}
+		if !utf8.FullRune(p[i:]) {
+			return i
+		}
+		break
+	}
+	return len(p)
+}
+
+// Reader reads the events of a recording.
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// maxLineSize is the largest event that is read. Terminal output is written
+// in chunks far smaller than this.
+const maxLineSize = 4 << 20
+
+// NewReader reads the header from r and returns a Reader for the events.
+func NewReader(r io.Reader) (*Reader, Header, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, Header{}, xerrors.Errorf("read header: %w", err)
+		}
+		return nil, Header{}, xerrors.New("recording is empty")
+	}
+	var header Header
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, Header{}, xerrors.Errorf("decode header: %w", err)
+	}
+	if header.Version != Version {
+		return nil, Header{}, xerrors.Errorf("unsupported asciicast version %d", header.Version)
+	}
+	return &Reader{scanner: scanner, line: 1}, header, nil
+}
+
+// Next returns the next event. It returns io.EOF at the end of the recording.
+func (r *Reader) Next() (Event, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return Event{}, xerrors.Errorf("decode event on line %d: %w", r.line, err)
+		}
+		return ev, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return Event{}, xerrors.Errorf("read event: %w", err)
+	}
+	return Event{}, io.EOF
+}
@@ -0,0 +1,128 @@
+package asciicast_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/asciicast"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWriteRead(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w, err := asciicast.NewWriter(&buf, asciicast.Header{Width: 80, Height: 24, Command: "bash"})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteData(100*time.Millisecond, asciicast.EventTypeOutput, []byte("hello\r\n")))
+	require.NoError(t, w.WriteData(200*time.Millisecond, asciicast.EventTypeInput, []byte("ls\r")))
+	require.NoError(t, w.WriteEvent(asciicast.Event{Time: 1500 * time.Millisecond, Type: asciicast.EventTypeResize, Data: asciicast.ResizeData(120, 40)}))
+
+	require.Equal(t, `{"version":2,"width":80,"height":24,"command":"bash"}
+[0.100000,"o","hello\r\n"]
+[0.200000,"i","ls\r"]
+[1.500000,"r","120x40"]
+`, buf.String())
+
+	r, header, err := asciicast.NewReader(&buf)
+	require.NoError(t, err)
+	require.Equal(t, asciicast.Header{Version: 2, Width: 80, Height: 24, Command: "bash"}, header)
+
+	var events []asciicast.Event
+	for {
+		ev, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		events = append(events, ev)
+	}
+	require.Equal(t, []asciicast.Event{
+		{Time: 100 * time.Millisecond, Type: asciicast.EventTypeOutput, Data: "hello\r\n"},
+		{Time: 200 * time.Millisecond, Type: asciicast.EventTypeInput, Data: "ls\r"},
+		{Time: 1500 * time.Millisecond, Type: asciicast.EventTypeResize, Data: "120x40"},
+	}, events)
+}
+
+func TestWriteDataSplitRune(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w, err := asciicast.NewWriter(&buf, asciicast.Header{Width: 80, Height: 24})
+	require.NoError(t, err)
+
+	// "é" is encoded as 0xc3 0xa9 and split across two writes, with an
+	// input event in between that must not consume the pending byte.
+	require.NoError(t, w.WriteData(0, asciicast.EventTypeOutput, []byte("caf\xc3")))
+	require.NoError(t, w.WriteData(0, asciicast.EventTypeInput, []byte("x")))
+	require.NoError(t, w.WriteData(time.Second, asciicast.EventTypeOutput, []byte("\xa9!")))
+	// Invalid sequences are replaced rather than producing invalid JSON.
+	require.NoError(t, w.WriteData(time.Second, asciicast.EventTypeOutput, []byte("\xff\n")))
+
+	r, _, err := asciicast.NewReader(&buf)
+	require.NoError(t, err)
+	var data []string
+	for {
+		ev, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data = append(data, ev.Data)
+	}
+	require.Equal(t, []string{"caf", "x", "é!", "�\n"}, data)
+}
+
+func TestReaderErrors(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := asciicast.NewReader(strings.NewReader(""))
+	require.ErrorContains(t, err, "recording is empty")
+
+	_, _, err = asciicast.NewReader(strings.NewReader(`{"version":1}`))
+	require.ErrorContains(t, err, "unsupported asciicast version 1")
+
+	r, _, err := asciicast.NewReader(strings.NewReader("{\"version\":2}\n[0.1,\"o\"]\n"))
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.ErrorContains(t, err, "decode event on line 2")
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	recording := `{"version":2,"width":80,"height":24}
+[0.5,"o","one "]
+[0.6,"i","ignored"]
+[30,"r","100x50"]
+[60,"o","two"]
+`
+	ctx := testutil.Context(t, testutil.WaitShort)
+	var out bytes.Buffer
+	var sizes []string
+	start := time.Now()
+	err := asciicast.Replay(ctx, strings.NewReader(recording), &out, asciicast.ReplayOptions{
+		Speed:   2,
+		MaxIdle: 10 * time.Millisecond,
+		OnResize: func(width, height int) {
+			sizes = append(sizes, asciicast.ResizeData(width, height))
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "one two", out.String())
+	require.Equal(t, []string{"100x50"}, sizes)
+	// Idle time is capped, so the minute-long recording replays quickly.
+	require.Less(t, time.Since(start), testutil.WaitShort)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = asciicast.Replay(canceled, strings.NewReader(recording), io.Discard, asciicast.ReplayOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
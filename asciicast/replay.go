@@ -0,0 +1,81 @@
+package asciicast
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// ReplayOptions controls the playback of a recording.
+type ReplayOptions struct {
+	// Speed multiplies the playback speed. Values of zero or less play the
+	// recording at its original speed.
+	Speed float64
+	// MaxIdle caps the time waited between two events. Zero waits for as
+	// long as the recording did.
+	MaxIdle time.Duration
+	// Instant writes all output without waiting between events.
+	Instant bool
+	// OnResize is called for resize events, if set.
+	OnResize func(width, height int)
+}
+
+// Replay writes the output of the recording read from r to w, waiting between
+// events as the original session did. Input and marker events are skipped.
+func Replay(ctx context.Context, r io.Reader, w io.Writer, opts ReplayOptions) error {
+	reader, _, err := NewReader(r)
+	if err != nil {
+		return err
+	}
+	speed := opts.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	var last time.Duration
+	for {
+		ev, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		wait := time.Duration(float64(ev.Time-last) / speed)
+		last = ev.Time
+		if opts.MaxIdle > 0 && wait > opts.MaxIdle {
+			wait = opts.MaxIdle
+		}
+		if !opts.Instant && wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		switch ev.Type {
+		case EventTypeOutput:
+			if _, err := io.WriteString(w, ev.Data); err != nil {
+				return xerrors.Errorf("write output: %w", err)
+			}
+		case EventTypeResize:
+			if opts.OnResize == nil {
+				continue
+			}
+			if width, height, err := ParseResizeData(ev.Data); err == nil {
+				opts.OnResize(width, height)
+			}
+		default:
+		}
+	}
+}
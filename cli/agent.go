@@ -58,6 +58,7 @@ func workspaceAgent() *serpent.Command {
 		devcontainerProjectDiscovery   bool
 		devcontainerDiscoveryAutostart bool
 		socketServerEnabled            bool
+		socketJournalEnabled           bool
 		socketPath                     string
 		boundaryLogProxySocketPath     string
 	)
@@ -326,6 +327,7 @@ func workspaceAgent() *serpent.Command {
 					},
 					SocketPath:                 socketPath,
 					SocketServerEnabled:        socketServerEnabled,
+					SocketJournalEnabled:       socketJournalEnabled,
 					BoundaryLogProxySocketPath: boundaryLogProxySocketPath,
 				})
 
@@ -494,6 +496,13 @@ func workspaceAgent() *serpent.Command {
 			Description: "Enable the agent socket server.",
 			Value:       serpent.BoolOf(&socketServerEnabled),
 		},
+		{
+			Flag:        "socket-journal-enabled",
+			Default:     "false",
+			Env:         "CODER_AGENT_SOCKET_JOURNAL_ENABLED",
+			Description: "Persist unit dependency state declared through the agent socket to the script data directory, so that it survives agent restarts.",
+			Value:       serpent.BoolOf(&socketJournalEnabled),
+		},
 		{
 			Flag:        "socket-path",
 			Env:         "CODER_AGENT_SOCKET_PATH",
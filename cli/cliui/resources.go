@@ -349,3 +349,57 @@ func renderPipe(idx, total int) string {
 	}
 	return pipeMid
 }
+
+// WorkspaceResourceChanges displays the changes a planned build would make to
+// the resources of a workspace. Replacing or deleting a persistent resource
+// loses its data, so those changes are highlighted and followed by a warning.
+// ┌──────────────────────────────────────────────────────────┐
+// │ Build Preview                                            │
+// ├──────────────────────────────────────────────────────────┤
+// │ RESOURCE                         ACTION    REPLACED BY   │
+// │ docker_container.workspace       replace   image         │
+// │ docker_volume.home (persistent)  delete                  │
+// └──────────────────────────────────────────────────────────┘
+func WorkspaceResourceChanges(writer io.Writer, changes []codersdk.WorkspaceResourceChange) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(writer, "No changes. The workspace resources are up-to-date.")
+		return
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Address < changes[j].Address
+	})
+
+	tableWriter := table.NewWriter()
+	tableWriter.SetTitle("Build Preview")
+	tableWriter.SetStyle(table.StyleLight)
+	tableWriter.Style().Options.SeparateColumns = false
+	tableWriter.AppendHeader(table.Row{"Resource", "Action", "Replaced By"})
+
+	var destructive []string
+	for _, change := range changes {
+		address := change.Address
+		if change.Persistent {
+			address += " (persistent)"
+		}
+		action := string(change.Action)
+		switch change.Action {
+		case codersdk.ResourceChangeActionCreate:
+			action = pretty.Sprint(DefaultStyles.Keyword, action)
+		case codersdk.ResourceChangeActionReplace, codersdk.ResourceChangeActionDelete:
+			if change.Persistent {
+				address = pretty.Sprint(DefaultStyles.Error, address)
+				action = pretty.Sprint(DefaultStyles.Error, action)
+				destructive = append(destructive, change.Address)
+			} else {
+				action = pretty.Sprint(DefaultStyles.Warn, action)
+			}
+		}
+		tableWriter.AppendRow(table.Row{address, action, strings.Join(change.ReplacePaths, ", ")})
+	}
+	_, _ = fmt.Fprintln(writer, tableWriter.Render())
+
+	if len(destructive) > 0 {
+		Warn(writer, "The following persistent resources will be replaced or deleted and their data will be lost:", destructive...)
+	}
+}
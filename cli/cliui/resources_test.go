@@ -1,10 +1,12 @@
 package cliui_test
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/cli/cliui"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
@@ -111,3 +113,37 @@ func TestWorkspaceResources(t *testing.T) {
 		<-done
 	})
 }
+
+func TestWorkspaceResourceChanges(t *testing.T) {
+	t.Parallel()
+	t.Run("NoChanges", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cliui.WorkspaceResourceChanges(&buf, nil)
+		require.Contains(t, buf.String(), "No changes")
+	})
+
+	t.Run("PersistentReplacement", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cliui.WorkspaceResourceChanges(&buf, []codersdk.WorkspaceResourceChange{{
+			Address: "docker_container.workspace",
+			Type:    "docker_container",
+			Name:    "workspace",
+			Action:  codersdk.ResourceChangeActionReplace,
+			ReplacePaths: []string{
+				"image",
+			},
+		}, {
+			Address:    "docker_volume.home",
+			Type:       "docker_volume",
+			Name:       "home",
+			Action:     codersdk.ResourceChangeActionDelete,
+			Persistent: true,
+		}})
+		out := buf.String()
+		require.Contains(t, out, "docker_container.workspace")
+		require.Contains(t, out, "docker_volume.home (persistent)")
+		require.Contains(t, out, "data will be lost")
+	})
+}
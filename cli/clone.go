@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/archive"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/cli/cliutil"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) clone() *serpent.Command {
+	var (
+		copyHome bool
+		exclude  []string
+	)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "clone <[owner/]workspace[.agent]> <[owner/]new-workspace>",
+		Short:       "Create a copy of a workspace",
+		Long: "The new workspace is created from the template version, parameters, " +
+			"autostart schedule and time to shutdown of the source workspace, and " +
+			"records the source workspace it was cloned from. With --copy-home, the " +
+			"home directory of the source workspace agent is streamed into the new " +
+			"workspace through the agents once the new workspace is ready. The source " +
+			"workspace must be running to copy its home directory.\n\n" + FormatExamples(
+			Example{
+				Description: "Clone a workspace",
+				Command:     "coder clone my-workspace my-workspace-2",
+			},
+			Example{
+				Description: "Clone a workspace including its home directory, skipping caches",
+				Command:     "coder clone --copy-home --exclude .cache --exclude node_modules my-workspace my-workspace-2",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			filter := archive.Filter{Exclude: exclude}
+			if err := filter.Validate(); err != nil {
+				return err
+			}
+			if len(exclude) > 0 && !copyHome {
+				return xerrors.New("--exclude can only be used with --copy-home")
+			}
+
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+
+			// The agent name is only used to pick the home directory to copy.
+			sourceName, agentName, _ := strings.Cut(inv.Args[0], ".")
+			source, err := namedWorkspace(ctx, client, sourceName)
+			if err != nil {
+				return xerrors.Errorf("get source workspace: %w", err)
+			}
+			if copyHome && source.LatestBuild.Transition != codersdk.WorkspaceTransitionStart {
+				return xerrors.Errorf("workspace %q must be running to copy its home directory", source.Name)
+			}
+
+			workspaceOwner, workspaceName, err := splitNamedWorkspace(inv.Args[1])
+			if err != nil {
+				return err
+			}
+			err = codersdk.NameValid(workspaceName)
+			if err != nil {
+				return xerrors.Errorf("workspace name %q is invalid: %w", workspaceName, err)
+			}
+			_, err = client.WorkspaceByOwnerAndName(ctx, workspaceOwner, workspaceName, codersdk.WorkspaceOptions{})
+			if err == nil {
+				return xerrors.Errorf("a workspace already exists named %q", workspaceName)
+			}
+
+			sourceParameters, err := client.WorkspaceBuildParameters(ctx, source.LatestBuild.ID)
+			if err != nil {
+				return xerrors.Errorf("get source workspace build parameters: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Coder will use the template %q at version %q of the source workspace.\n",
+				source.TemplateName, source.LatestBuild.TemplateVersionName)
+
+			richParameters, err := prepWorkspaceBuild(inv, client, prepWorkspaceBuildArgs{
+				Action:            WorkspaceCreate,
+				TemplateVersionID: source.LatestBuild.TemplateVersionID,
+				NewWorkspaceName:  workspaceName,
+				Owner:             workspaceOwner,
+
+				SourceWorkspaceParameters: sourceParameters,
+			})
+			if err != nil {
+				return xerrors.Errorf("prepare build: %w", err)
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Confirm clone of %s?", cliui.Keyword(source.Name)),
+				IsConfirm: true,
+			})
+			if err != nil {
+				return err
+			}
+
+			workspace, err := client.CreateUserWorkspace(ctx, workspaceOwner, codersdk.CreateWorkspaceRequest{
+				TemplateVersionID:   source.LatestBuild.TemplateVersionID,
+				Name:                workspaceName,
+				AutostartSchedule:   source.AutostartSchedule,
+				TTLMillis:           source.TTLMillis,
+				RichParameterValues: richParameters,
+				AutomaticUpdates:    source.AutomaticUpdates,
+				SourceWorkspaceID:   source.ID,
+			})
+			if err != nil {
+				return xerrors.Errorf("create workspace: %w", err)
+			}
+
+			cliutil.WarnMatchedProvisioners(inv.Stderr, workspace.LatestBuild.MatchedProvisioners, workspace.LatestBuild.Job)
+
+			err = cliui.WorkspaceBuild(ctx, inv.Stdout, client, workspace.LatestBuild.ID)
+			if err != nil {
+				return xerrors.Errorf("watch build: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(
+				inv.Stdout,
+				"\nThe %s workspace has been cloned from %s at %s!\n",
+				cliui.Keyword(workspace.Name),
+				cliui.Keyword(source.Name),
+				cliui.Timestamp(time.Now()),
+			)
+
+			if !copyHome {
+				return nil
+			}
+			agentSuffix := ""
+			if agentName != "" {
+				agentSuffix = "." + agentName
+			}
+			return r.copyWorkspaceHome(ctx, inv, client,
+				source.OwnerName+"/"+source.Name+agentSuffix,
+				workspace.OwnerName+"/"+workspace.Name+agentSuffix,
+				filter,
+			)
+		},
+		Options: serpent.OptionSet{
+			{
+				Flag:        "copy-home",
+				Env:         "CODER_CLONE_COPY_HOME",
+				Description: "Copy the home directory of the source workspace into the new workspace once it is ready.",
+				Value:       serpent.BoolOf(&copyHome),
+			},
+			{
+				Flag:        "exclude",
+				Description: "Skip home directory entries matching these glob patterns. Patterns without a \"/\" match any path element, otherwise they match the path relative to the home directory.",
+				Value:       serpent.StringArrayOf(&exclude),
+			},
+			cliui.SkipPromptOption(),
+		},
+	}
+	return cmd
+}
+
+// copyWorkspaceHome streams the home directory of the source agent into the
+// home directory of the destination agent, waiting for the destination agent
+// to be ready first.
+func (r *RootCmd) copyWorkspaceHome(ctx context.Context, inv *serpent.Invocation, client *codersdk.Client, source, destination string, filter archive.Filter) error {
+	_, sourceAgent, _, err := GetWorkspaceAndAgent(ctx, inv, client, false, source)
+	if err != nil {
+		return xerrors.Errorf("get source agent: %w", err)
+	}
+	_, destinationAgent, _, err := GetWorkspaceAndAgent(ctx, inv, client, false, destination)
+	if err != nil {
+		return xerrors.Errorf("get new agent: %w", err)
+	}
+
+	destinationConn, err := r.dialAgent(ctx, inv, client, destinationAgent, true)
+	if err != nil {
+		return err
+	}
+	defer destinationConn.Close()
+	sourceConn, err := r.dialAgent(ctx, inv, client, sourceAgent, false)
+	if err != nil {
+		return err
+	}
+	defer sourceConn.Close()
+
+	sourceHome, err := resolveRemotePath(ctx, sourceConn, "")
+	if err != nil {
+		return err
+	}
+	destinationHome, err := resolveRemotePath(ctx, destinationConn, "")
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(inv.Stdout, "Copying home directory %s of %s...\n", sourceHome, source)
+	archiveReader, err := sourceConn.DownloadArchive(ctx, workspacesdk.ArchiveRequest{
+		Path:    sourceHome,
+		Exclude: filter.Exclude,
+	})
+	if err != nil {
+		return xerrors.Errorf("download home directory: %w", err)
+	}
+	defer archiveReader.Close()
+	err = destinationConn.UploadArchive(ctx, workspacesdk.ArchiveRequest{Path: destinationHome}, archiveReader)
+	if err != nil {
+		return xerrors.Errorf("upload home directory: %w", err)
+	}
+	_, _ = fmt.Fprintf(inv.Stdout, "Copied home directory into %s of %s.\n", destinationHome, destination)
+	return nil
+}
@@ -0,0 +1,197 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisioner/echo"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, prepareEchoResponses([]*proto.RichParameter{
+			{Name: "region", Type: "string", DefaultValue: "us", Mutable: true},
+		}))
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, member, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref("CRON_TZ=UTC 30 9 * * 1-5")
+			cwr.TTLMillis = ptr.Ref((8 * time.Hour).Milliseconds())
+			cwr.RichParameterValues = []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}
+		})
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, source.LatestBuild.ID)
+
+		// The clone keeps the source version even when it is not active.
+		version2 := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, prepareEchoResponses([]*proto.RichParameter{
+			{Name: "region", Type: "string", DefaultValue: "us", Mutable: true},
+		}), func(ctvr *codersdk.CreateTemplateVersionRequest) {
+			ctvr.TemplateID = template.ID
+		})
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version2.ID)
+		coderdtest.UpdateActiveTemplateVersion(t, client, template.ID, version2.ID)
+
+		inv, root := clitest.New(t, "clone", source.Name, "clone", "-y")
+		clitest.SetupConfig(t, member, root)
+		ctx := testutil.Context(t, testutil.WaitLong)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		clone, err := member.WorkspaceByOwnerAndName(ctx, codersdk.Me, "clone", codersdk.WorkspaceOptions{})
+		require.NoError(t, err)
+		require.True(t, clone.SourceWorkspaceID.Valid)
+		require.Equal(t, source.ID, clone.SourceWorkspaceID.UUID)
+		require.Equal(t, version.ID, clone.LatestBuild.TemplateVersionID)
+		require.Equal(t, source.AutostartSchedule, clone.AutostartSchedule)
+		require.Equal(t, source.TTLMillis, clone.TTLMillis)
+
+		params, err := member.WorkspaceBuildParameters(ctx, clone.LatestBuild.ID)
+		require.NoError(t, err)
+		require.Equal(t, []codersdk.WorkspaceBuildParameter{{Name: "region", Value: "eu"}}, params)
+
+		// The source is not recorded on regular workspaces.
+		source, err = member.Workspace(ctx, source.ID)
+		require.NoError(t, err)
+		require.False(t, source.SourceWorkspaceID.Valid)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, source.LatestBuild.ID)
+
+		inv, root := clitest.New(t, "clone", source.Name, source.Name, "-y")
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.ErrorContains(t, err, "a workspace already exists")
+	})
+
+	t.Run("ExcludeWithoutCopyHome", func(t *testing.T) {
+		t.Parallel()
+
+		inv, _ := clitest.New(t, "clone", "--exclude", ".cache", "a", "b")
+		err := inv.Run()
+		require.ErrorContains(t, err, "--exclude can only be used with --copy-home")
+	})
+
+	t.Run("CopyHomeSymlinks", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS == "windows" {
+			t.Skip("Creating symbolic links requires elevated privileges on Windows.")
+		}
+
+		// The clone is built by the provisioner, while the source is
+		// inserted directly so that both agents have their own token.
+		authToken := uuid.NewString()
+		client, store := coderdtest.NewWithDatabase(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionPlan:  echo.PlanComplete,
+			ProvisionGraph: echo.ProvisionGraphWithAgent(authToken),
+		})
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		source := dbfake.WorkspaceBuild(t, store, database.WorkspaceTable{
+			OrganizationID: owner.OrganizationID,
+			OwnerID:        owner.UserID,
+			TemplateID:     template.ID,
+		}).WithAgent().Do()
+
+		home, err := os.UserHomeDir()
+		require.NoError(t, err)
+		sourceRoot, cloneRoot := t.TempDir(), t.TempDir()
+		sourceHome := filepath.Join(sourceRoot, home)
+		require.NoError(t, os.MkdirAll(filepath.Join(sourceHome, "src"), 0o755))
+		require.NoError(t, os.MkdirAll(filepath.Join(cloneRoot, home), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(sourceHome, "src", "main.go"), []byte("package main"), 0o600))
+		require.NoError(t, os.Symlink("src/main.go", filepath.Join(sourceHome, "main.go")))
+		require.NoError(t, os.Symlink("src", filepath.Join(sourceHome, "code")))
+
+		_ = agenttest.New(t, client.URL, source.AgentToken, func(opts *agent.Options) {
+			opts.Filesystem = newRootedFs(sourceRoot)
+		})
+		_ = coderdtest.AwaitWorkspaceAgents(t, client, source.Workspace.ID)
+
+		inv, root := clitest.New(t, "clone", "--copy-home", source.Workspace.Name, "clone", "-y")
+		clitest.SetupConfig(t, client, root)
+		ctx := testutil.Context(t, testutil.WaitSuperLong)
+		cmdDone := tGo(t, func() {
+			err := inv.WithContext(ctx).Run()
+			assert.NoError(t, err)
+		})
+
+		// Start the agent of the clone once it has been built.
+		var clone codersdk.Workspace
+		require.Eventually(t, func() bool {
+			clone, err = client.WorkspaceByOwnerAndName(ctx, codersdk.Me, "clone", codersdk.WorkspaceOptions{})
+			return err == nil
+		}, testutil.WaitLong, testutil.IntervalFast)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, clone.LatestBuild.ID)
+		_ = agenttest.New(t, client.URL, authToken, func(opts *agent.Options) {
+			opts.Filesystem = newRootedFs(cloneRoot)
+		})
+		<-cmdDone
+
+		cloneHome := filepath.Join(cloneRoot, home)
+		link, err := os.Readlink(filepath.Join(cloneHome, "main.go"))
+		require.NoError(t, err)
+		require.Equal(t, "src/main.go", link)
+		link, err = os.Readlink(filepath.Join(cloneHome, "code"))
+		require.NoError(t, err)
+		require.Equal(t, "src", link)
+		b, err := os.ReadFile(filepath.Join(cloneHome, "code", "main.go"))
+		require.NoError(t, err)
+		require.Equal(t, "package main", string(b))
+	})
+}
+
+// rootedFs is a filesystem rooted in a directory on disk, so that agents in
+// the same process can have their own home directory. Unlike
+// afero.BasePathFs, it keeps the targets of symbolic links as they are.
+type rootedFs struct {
+	*afero.BasePathFs
+	root string
+}
+
+func newRootedFs(root string) rootedFs {
+	//nolint:forcetypeassert // NewBasePathFs always returns a *BasePathFs.
+	return rootedFs{BasePathFs: afero.NewBasePathFs(afero.NewOsFs(), root).(*afero.BasePathFs), root: root}
+}
+
+func (fs rootedFs) SymlinkIfPossible(oldname, newname string) error {
+	return os.Symlink(oldname, filepath.Join(fs.root, newname))
+}
+
+func (fs rootedFs) ReadlinkIfPossible(name string) (string, error) {
+	return os.Readlink(filepath.Join(fs.root, name))
+}
@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/sloghuman"
+	"github.com/coder/coder/v2/archive"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/serpent"
+)
+
+// copyTarget is one side of a copy, either a local path or a path inside a
+// workspace.
+type copyTarget struct {
+	// Workspace is the workspace (and optionally agent) to copy to or from.
+	// Empty for local paths.
+	Workspace string
+	Path      string
+}
+
+func (t copyTarget) remote() bool {
+	return t.Workspace != ""
+}
+
+// parseCopyTarget parses "[owner/]workspace[.agent]:path" as a remote target,
+// anything else is a local path.
+func parseCopyTarget(arg string) copyTarget {
+	workspace, p, ok := strings.Cut(arg, ":")
+	if !ok || workspace == "" ||
+		// Windows drive letters, e.g. C:\Users.
+		len(workspace) == 1 ||
+		strings.HasPrefix(workspace, ".") ||
+		strings.HasPrefix(workspace, "/") ||
+		strings.HasPrefix(workspace, "~") ||
+		strings.Contains(workspace, `\`) {
+		return copyTarget{Path: arg}
+	}
+	return copyTarget{Workspace: workspace, Path: p}
+}
+
+func (r *RootCmd) cp() *serpent.Command {
+	var (
+		include          []string
+		exclude          []string
+		disableAutostart bool
+	)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "cp <source> <destination>",
+		Short:       "Copy files and directories to or from a workspace",
+		Long: "Exactly one of source or destination must be a workspace path of the form " +
+			"\"[owner/]workspace[.agent]:path\". Relative workspace paths are resolved " +
+			"against the home directory of the workspace user. When the source is a " +
+			"directory its contents are copied into the destination directory, which is " +
+			"created if it does not exist. Directories are streamed as a tar archive " +
+			"through the workspace agent.\n\n" + FormatExamples(
+			Example{
+				Description: "Copy a local directory into a workspace",
+				Command:     "coder cp ./repo my-workspace:/home/coder/repo",
+			},
+			Example{
+				Description: "Copy a directory from a workspace, skipping dependencies",
+				Command:     "coder cp --exclude node_modules --exclude .git my-workspace:repo ./repo",
+			},
+			Example{
+				Description: "Copy a single file to a specific agent",
+				Command:     "coder cp ./config.yaml my-workspace.main:/etc/app/config.yaml",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			src := parseCopyTarget(inv.Args[0])
+			dst := parseCopyTarget(inv.Args[1])
+			if src.remote() == dst.remote() {
+				return xerrors.New("exactly one of source or destination must be a workspace path, e.g. \"my-workspace:/home/coder\"")
+			}
+			filter := archive.Filter{Include: include, Exclude: exclude}
+			if err := filter.Validate(); err != nil {
+				return err
+			}
+
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			remote := src
+			if dst.remote() {
+				remote = dst
+			}
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, remote.Workspace, !disableAutostart)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			remotePath, err := resolveRemotePath(ctx, conn, remote.Path)
+			if err != nil {
+				return err
+			}
+
+			if dst.remote() {
+				return copyToWorkspace(ctx, conn, src.Path, remotePath, filter)
+			}
+			return copyFromWorkspace(ctx, conn, remotePath, dst.Path, filter)
+		},
+		Options: serpent.OptionSet{
+			{
+				Flag:        "include",
+				Description: "Only copy directory entries matching these glob patterns. Patterns without a \"/\" match any path element, otherwise they match the path relative to the source directory.",
+				Value:       serpent.StringArrayOf(&include),
+			},
+			{
+				Flag:        "exclude",
+				Description: "Skip directory entries matching these glob patterns. Takes precedence over --include.",
+				Value:       serpent.StringArrayOf(&exclude),
+			},
+			{
+				Flag:        "disable-autostart",
+				Description: "Disable starting the workspace automatically when connecting.",
+				Env:         "CODER_CP_DISABLE_AUTOSTART",
+				Value:       serpent.BoolOf(&disableAutostart),
+				Default:     "false",
+			},
+		},
+	}
+	return cmd
+}
+
+// dialWorkspaceAgent waits for the agent of the named workspace to connect and
+// dials it.
+func (r *RootCmd) dialWorkspaceAgent(ctx context.Context, inv *serpent.Invocation, client *codersdk.Client, workspaceName string, autostart bool) (workspacesdk.AgentConn, error) {
+	_, workspaceAgent, _, err := GetWorkspaceAndAgent(ctx, inv, client, autostart, workspaceName)
+	if err != nil {
+		return nil, err
+	}
+	return r.dialAgent(ctx, inv, client, workspaceAgent, false)
+}
+
+// dialAgent waits for the agent to connect and dials it. If wait is true it
+// also waits for the agent startup scripts to finish.
+func (r *RootCmd) dialAgent(ctx context.Context, inv *serpent.Invocation, client *codersdk.Client, workspaceAgent codersdk.WorkspaceAgent, wait bool) (workspacesdk.AgentConn, error) {
+	appearanceConfig := initAppearance(ctx, client)
+
+	err := cliui.Agent(ctx, inv.Stderr, workspaceAgent.ID, cliui.AgentOptions{
+		Fetch:   client.WorkspaceAgent,
+		Wait:    wait,
+		DocsURL: appearanceConfig.DocsURL,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("await agent: %w", err)
+	}
+
+	opts := &workspacesdk.DialAgentOptions{}
+	if r.verbose {
+		opts.Logger = inv.Logger.AppendSinks(sloghuman.Sink(inv.Stderr)).Leveled(slog.LevelDebug)
+	}
+	if r.disableDirect {
+		opts.BlockEndpoints = true
+	}
+	if !r.disableNetworkTelemetry {
+		opts.EnableTelemetry = true
+	}
+	return workspacesdk.New(client).DialAgent(ctx, workspaceAgent.ID, opts)
+}
+
+// resolveRemotePath makes p absolute by joining relative paths onto the home
+// directory of the workspace user.
+func resolveRemotePath(ctx context.Context, conn workspacesdk.AgentConn, p string) (string, error) {
+	// The agent may run on Windows, so accept either absolute path syntax.
+	if path.IsAbs(p) || filepath.IsAbs(p) || filepath.VolumeName(p) != "" {
+		return p, nil
+	}
+	home, err := conn.LS(ctx, "", workspacesdk.LSRequest{
+		Path:       []string{},
+		Relativity: workspacesdk.LSRelativityHome,
+	})
+	if err != nil {
+		return "", xerrors.Errorf("resolve home directory: %w", err)
+	}
+	if p == "" {
+		return home.AbsolutePathString, nil
+	}
+	return strings.TrimSuffix(home.AbsolutePathString, "/") + "/" + p, nil
+}
+
+func copyToWorkspace(ctx context.Context, conn workspacesdk.AgentConn, src, dst string, filter archive.Filter) error {
+	stat, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if !stat.IsDir() {
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if strings.HasSuffix(dst, "/") {
+			dst += filepath.Base(src)
+		} else if remote, err := conn.Stat(ctx, dst); err == nil && remote.IsDir {
+			dst += "/" + filepath.Base(src)
+		}
+		err = conn.WriteFile(ctx, dst, f)
+		if err != nil {
+			return xerrors.Errorf("write %q: %w", dst, err)
+		}
+		return nil
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(archive.TarDir(pw, afero.NewOsFs(), src, filter))
+	}()
+	err = conn.UploadArchive(ctx, workspacesdk.ArchiveRequest{
+		Path:   dst,
+		Format: workspacesdk.ArchiveFormatTar,
+	}, pr)
+	_ = pr.CloseWithError(err)
+	if err != nil {
+		return xerrors.Errorf("upload %q: %w", src, err)
+	}
+	return nil
+}
+
+func copyFromWorkspace(ctx context.Context, conn workspacesdk.AgentConn, src, dst string, filter archive.Filter) error {
+	stat, err := conn.Stat(ctx, src)
+	if err != nil {
+		return xerrors.Errorf("stat %q: %w", src, err)
+	}
+
+	if !stat.IsDir {
+		if strings.HasSuffix(dst, string(filepath.Separator)) {
+			dst = filepath.Join(dst, stat.Name)
+		} else if local, err := os.Stat(dst); err == nil && local.IsDir() {
+			dst = filepath.Join(dst, stat.Name)
+		}
+		rc, _, err := conn.ReadFile(ctx, src, 0, 0)
+		if err != nil {
+			return xerrors.Errorf("read %q: %w", src, err)
+		}
+		defer rc.Close()
+
+		mode, err := strconv.ParseUint(stat.Mode, 8, 32)
+		if err != nil {
+			mode = 0o644
+		}
+		f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(mode))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, rc)
+		if err != nil {
+			_ = f.Close()
+			return xerrors.Errorf("write %q: %w", dst, err)
+		}
+		return f.Close()
+	}
+
+	rc, err := conn.DownloadArchive(ctx, workspacesdk.ArchiveRequest{
+		Path:    src,
+		Format:  workspacesdk.ArchiveFormatTar,
+		Include: filter.Include,
+		Exclude: filter.Exclude,
+	})
+	if err != nil {
+		return xerrors.Errorf("download %q: %w", src, err)
+	}
+	defer rc.Close()
+
+	err = archive.UntarDir(afero.NewOsFs(), dst, rc, archive.Filter{})
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return xerrors.Errorf("download %q: archive was truncated, check the workspace agent logs", src)
+		}
+		return xerrors.Errorf("extract %q: %w", src, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseCopyTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		arg  string
+		want copyTarget
+	}{
+		{"./dir", copyTarget{Path: "./dir"}},
+		{"/abs/dir", copyTarget{Path: "/abs/dir"}},
+		{"./weird:name", copyTarget{Path: "./weird:name"}},
+		{"~/dir:name", copyTarget{Path: "~/dir:name"}},
+		{`C:\Users\coder`, copyTarget{Path: `C:\Users\coder`}},
+		{`dir\sub:name`, copyTarget{Path: `dir\sub:name`}},
+		{":path", copyTarget{Path: ":path"}},
+		{"ws:/home/coder", copyTarget{Workspace: "ws", Path: "/home/coder"}},
+		{"ws:", copyTarget{Workspace: "ws", Path: ""}},
+		{"ws.main:repo", copyTarget{Workspace: "ws.main", Path: "repo"}},
+		{"owner/ws:/tmp/a:b", copyTarget{Workspace: "owner/ws", Path: "/tmp/a:b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, parseCopyTarget(tt.arg))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent"
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestCp(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (func(args ...string) error, afero.Fs) {
+		t.Helper()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		_ = coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		return func(args ...string) error {
+			for i, arg := range args {
+				args[i] = os.Expand(arg, func(s string) string {
+					if s == "ws" {
+						return workspace.Name
+					}
+					return "$" + s
+				})
+			}
+			inv, root := clitest.New(t, append([]string{"cp"}, args...)...)
+			clitest.SetupConfig(t, client, root)
+			ctx := testutil.Context(t, testutil.WaitLong)
+			return inv.WithContext(ctx).Run()
+		}, fs
+	}
+
+	t.Run("BothLocal", func(t *testing.T) {
+		t.Parallel()
+
+		inv, _ := clitest.New(t, "cp", "./a", "./b")
+		err := inv.Run()
+		require.ErrorContains(t, err, "exactly one of source or destination must be a workspace path")
+	})
+
+	t.Run("UploadDirectory", func(t *testing.T) {
+		t.Parallel()
+
+		run, fs := setup(t)
+		src := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "node_modules"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("b"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "node_modules", "dep.js"), []byte("dep"), 0o600))
+
+		dst := filepath.Join(os.TempDir(), "cp-upload")
+		err := run("--exclude", "node_modules", src, "${ws}:"+dst)
+		require.NoError(t, err)
+
+		b, err := afero.ReadFile(fs, filepath.Join(dst, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a", string(b))
+		b, err = afero.ReadFile(fs, filepath.Join(dst, "sub", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(b))
+		_, err = fs.Stat(filepath.Join(dst, "node_modules"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("DownloadDirectory", func(t *testing.T) {
+		t.Parallel()
+
+		run, fs := setup(t)
+		src := filepath.Join(os.TempDir(), "cp-download")
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "a.go"), []byte("package a"), 0o644))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(src, "sub", "b.txt"), []byte("b"), 0o644))
+
+		dst := filepath.Join(t.TempDir(), "out")
+		err := run("--include", "*.go", "${ws}:"+src, dst)
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(filepath.Join(dst, "a.go"))
+		require.NoError(t, err)
+		require.Equal(t, "package a", string(b))
+		_, err = os.Stat(filepath.Join(dst, "sub", "b.txt"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		t.Parallel()
+
+		run, fs := setup(t)
+		local := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, os.WriteFile(local, []byte("content"), 0o600))
+
+		remote := filepath.Join(os.TempDir(), "cp-file.txt")
+		err := run(local, "${ws}:"+remote)
+		require.NoError(t, err)
+		b, err := afero.ReadFile(fs, remote)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(b))
+
+		downloaded := filepath.Join(t.TempDir(), "downloaded.txt")
+		err = run("${ws}:"+remote, downloaded)
+		require.NoError(t, err)
+		b, err = os.ReadFile(downloaded)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(b))
+	})
+}
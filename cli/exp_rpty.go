@@ -4,9 +4,11 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mattn/go-isatty"
@@ -69,17 +71,221 @@ func (r *RootCmd) rptyCommand() *serpent.Command {
 		},
 		Short: "Establish an RPTY session with a workspace/agent.",
 		Use:   "rpty",
+		Children: []*serpent.Command{
+			r.rptyList(),
+			r.rptyAttach(),
+			r.rptyKill(),
+		},
 	}
 
 	return cmd
 }
 
+type rptySessionRow struct {
+	// For JSON format:
+	workspacesdk.ReconnectingPTYSession `table:"-"`
+
+	// For table format:
+	ID           string    `json:"-" table:"id"`
+	Command      string    `json:"-" table:"command"`
+	StartedAt    time.Time `json:"-" table:"started at,default_sort"`
+	LastActivity time.Time `json:"-" table:"last activity"`
+	Attached     int       `json:"-" table:"attached"`
+	ReadOnly     int       `json:"-" table:"read only"`
+}
+
+func (r *RootCmd) rptyList() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]rptySessionRow{}, []string{"id", "command", "started at", "last activity", "attached", "read only"}),
+		cliui.JSONFormat(),
+	)
+
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the RPTY sessions running in a workspace/agent.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, inv.Args[0], false)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			res, err := conn.ReconnectingPTYSessions(ctx)
+			if err != nil {
+				return xerrors.Errorf("list sessions: %w", err)
+			}
+
+			rows := make([]rptySessionRow, 0, len(res.Sessions))
+			for _, session := range res.Sessions {
+				row := rptySessionRow{
+					ReconnectingPTYSession: session,
+					ID:                     session.ID.String(),
+					Command:                session.Command,
+					StartedAt:              session.StartedAt,
+					LastActivity:           session.LastActivityAt,
+					Attached:               len(session.Connections),
+				}
+				if row.Command == "" {
+					row.Command = "(shell)"
+				}
+				for _, c := range session.Connections {
+					if c.ReadOnly {
+						row.ReadOnly++
+					}
+				}
+				rows = append(rows, row)
+			}
+
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+			if out == "" {
+				cliui.Info(inv.Stderr, "No RPTY sessions found.")
+				return nil
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) rptyAttach() *serpent.Command {
+	var readOnly bool
+
+	return &serpent.Command{
+		Use:   "attach <workspace> <id>",
+		Short: "Attach to an RPTY session running in a workspace/agent.",
+		Long: "Attach to an existing RPTY session, for example one opened in a web terminal. " +
+			"The ID may be abbreviated to a unique prefix. Read-only attaches show the session output " +
+			"without forwarding any input; press Ctrl+C to detach.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+		),
+		Options: serpent.OptionSet{
+			{
+				Name:        "read-only",
+				Description: "Watch the session without sending input or resizing it.",
+				Flag:        "read-only",
+				Default:     "false",
+				Value:       serpent.BoolOf(&readOnly),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			if r.disableDirect {
+				return xerrors.New("direct connections are disabled, but you can try websocat ;-)")
+			}
+			ctx := inv.Context()
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			session, err := r.findRPTYSession(ctx, inv, client, inv.Args[0], inv.Args[1])
+			if err != nil {
+				return err
+			}
+			return handleRPTY(inv, client, handleRPTYArgs{
+				NamedWorkspace: inv.Args[0],
+				Container:      session.Container,
+				ReconnectID:    session.ID.String(),
+				ReadOnly:       readOnly,
+			})
+		},
+	}
+}
+
+func (r *RootCmd) rptyKill() *serpent.Command {
+	return &serpent.Command{
+		Use:   "kill <workspace> <id>",
+		Short: "Kill an RPTY session running in a workspace/agent.",
+		Long:  "Kill the process of an RPTY session and disconnect everyone attached to it. The ID may be abbreviated to a unique prefix.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, inv.Args[0], false)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			res, err := conn.ReconnectingPTYSessions(ctx)
+			if err != nil {
+				return xerrors.Errorf("list sessions: %w", err)
+			}
+			session, err := matchRPTYSession(res.Sessions, inv.Args[1])
+			if err != nil {
+				return err
+			}
+			err = conn.KillReconnectingPTY(ctx, session.ID)
+			if err != nil {
+				return xerrors.Errorf("kill session: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Killed RPTY session %s\n", session.ID)
+			return nil
+		},
+	}
+}
+
+// findRPTYSession looks up a running session in the named workspace by ID or
+// unique ID prefix.
+func (r *RootCmd) findRPTYSession(ctx context.Context, inv *serpent.Invocation, client *codersdk.Client, workspace, id string) (workspacesdk.ReconnectingPTYSession, error) {
+	conn, err := r.dialWorkspaceAgent(ctx, inv, client, workspace, false)
+	if err != nil {
+		return workspacesdk.ReconnectingPTYSession{}, err
+	}
+	defer conn.Close()
+
+	res, err := conn.ReconnectingPTYSessions(ctx)
+	if err != nil {
+		return workspacesdk.ReconnectingPTYSession{}, xerrors.Errorf("list sessions: %w", err)
+	}
+	return matchRPTYSession(res.Sessions, id)
+}
+
+func matchRPTYSession(sessions []workspacesdk.ReconnectingPTYSession, id string) (workspacesdk.ReconnectingPTYSession, error) {
+	var matches []workspacesdk.ReconnectingPTYSession
+	for _, session := range sessions {
+		if session.ID.String() == id {
+			return session, nil
+		}
+		if strings.HasPrefix(session.ID.String(), id) {
+			matches = append(matches, session)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return workspacesdk.ReconnectingPTYSession{}, xerrors.Errorf("RPTY session %q not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return workspacesdk.ReconnectingPTYSession{}, xerrors.Errorf("RPTY session ID %q is ambiguous, it matches %d sessions", id, len(matches))
+	}
+}
+
 type handleRPTYArgs struct {
 	Command        []string
 	Container      string
 	ContainerUser  string
 	NamedWorkspace string
 	ReconnectID    string
+	ReadOnly       bool
 }
 
 func handleRPTY(inv *serpent.Invocation, client *codersdk.Client, args handleRPTYArgs) error {
@@ -130,9 +336,11 @@ func handleRPTY(inv *serpent.Invocation, client *codersdk.Client, args handleRPT
 		}
 	}
 
-	// Set stdin to raw mode so that control characters work.
+	// Set stdin to raw mode so that control characters work.  Read-only
+	// attaches do not send input, so leave the terminal alone and let Ctrl+C
+	// detach.
 	stdinFile, validIn := inv.Stdin.(*os.File)
-	if validIn && isatty.IsTerminal(stdinFile.Fd()) {
+	if validIn && !args.ReadOnly && isatty.IsTerminal(stdinFile.Fd()) {
 		inState, err := pty.MakeInputRaw(stdinFile.Fd())
 		if err != nil {
 			return xerrors.Errorf("failed to set input terminal to raw mode: %w", err)
@@ -160,6 +368,7 @@ func handleRPTY(inv *serpent.Invocation, client *codersdk.Client, args handleRPT
 		Width:         termWidth,
 		Height:        termHeight,
 		BackendType:   backend,
+		ReadOnly:      args.ReadOnly,
 	})
 	if err != nil {
 		return xerrors.Errorf("open reconnecting PTY: %w", err)
@@ -172,6 +381,16 @@ func handleRPTY(inv *serpent.Invocation, client *codersdk.Client, args handleRPT
 	})
 	defer closeUsage()
 
+	if args.ReadOnly {
+		// The agent ignores input from read-only connections.
+		go func() {
+			<-ctx.Done()
+			_ = conn.Close()
+		}()
+		_, _ = io.Copy(inv.Stdout, conn)
+		return nil
+	}
+
 	br := bufio.NewScanner(inv.Stdin)
 	// Split on bytes, otherwise you have to send a newline to flush the buffer.
 	br.Split(bufio.ScanBytes)
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+func TestMatchRPTYSession(t *testing.T) {
+	t.Parallel()
+
+	sessions := []workspacesdk.ReconnectingPTYSession{
+		{ID: uuid.MustParse("0f2c1d4e-0000-4000-8000-000000000001")},
+		{ID: uuid.MustParse("0f2c9a7b-0000-4000-8000-000000000002")},
+		{ID: uuid.MustParse("a1b2c3d4-0000-4000-8000-000000000003")},
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		expected uuid.UUID
+		error    string
+	}{
+		{
+			name:     "Exact",
+			id:       "0f2c1d4e-0000-4000-8000-000000000001",
+			expected: sessions[0].ID,
+		},
+		{
+			name:     "Prefix",
+			id:       "a1",
+			expected: sessions[2].ID,
+		},
+		{
+			name:  "Ambiguous",
+			id:    "0f2c",
+			error: "is ambiguous",
+		},
+		{
+			name:  "NotFound",
+			id:    "ffff",
+			error: "not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			session, err := matchRPTYSession(sessions, tt.id)
+			if tt.error != "" {
+				require.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, session.ID)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cli_test
 
 import (
+	"bytes"
 	"runtime"
 	"testing"
 
@@ -67,6 +68,52 @@ func TestExpRpty(t *testing.T) {
 		<-cmdDone
 	})
 
+	t.Run("Sessions", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		reconnectID := uuid.New()
+		inv, root := clitest.New(t, "exp", "rpty", workspace.Name, "-r", reconnectID.String())
+		clitest.SetupConfig(t, client, root)
+		pty := ptytest.New(t).Attach(inv)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_ = agenttest.New(t, client.URL, agentToken)
+		_ = coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+
+		cmdDone := tGo(t, func() {
+			err := inv.WithContext(ctx).Run()
+			assert.NoError(t, err)
+		})
+		pty.WriteLine("echo shared")
+		pty.ExpectMatchContext(ctx, "shared")
+
+		inv, root = clitest.New(t, "exp", "rpty", "ls", workspace.Name)
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), reconnectID.String())
+
+		inv, root = clitest.New(t, "exp", "rpty", "kill", workspace.Name, reconnectID.String()[:8])
+		clitest.SetupConfig(t, client, root)
+		out.Reset()
+		inv.Stdout = &out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "Killed RPTY session "+reconnectID.String())
+
+		// Killing the session disconnects the attached client.
+		<-cmdDone
+
+		inv, root = clitest.New(t, "exp", "rpty", "attach", workspace.Name, reconnectID.String(), "--read-only")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "not found")
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,781 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/archive"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/pretty"
+	"github.com/coder/quartz"
+	"github.com/coder/serpent"
+)
+
+const (
+	fileSyncPreferLocal     = "local"
+	fileSyncPreferWorkspace = "workspace"
+
+	// fileSyncTempPrefix is the prefix of the temporary files downloads are
+	// written to before they are moved into place. They are never synced.
+	fileSyncTempPrefix = ".coder-file-sync-"
+	// fileSyncSettle is how long to wait for further changes after a change
+	// was observed, so that bursts of changes are synced in a single pass.
+	fileSyncSettle = 250 * time.Millisecond
+)
+
+func (r *RootCmd) fileSync() *serpent.Command {
+	var (
+		watch            bool
+		exclude          []string
+		ignoreFile       string
+		prefer           string
+		disableAutostart bool
+	)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "file-sync <local-dir> <[owner/]workspace[.agent]:dir>",
+		Short:       "Synchronize a local directory with a directory in a workspace",
+		Long: "Files are transferred through the workspace agent, no SSH server is " +
+			"required in the workspace. The first pass compares the contents of both " +
+			"directories by hash: files that only exist on one side are copied to the " +
+			"other and files that differ are reported as conflicts. Afterwards, changes " +
+			"on either side, including deletions, are applied to the other side. A file " +
+			"changed on both sides is a conflict and is left untouched unless --prefer " +
+			"is set. Only regular files and directories are synchronized, symbolic links " +
+			"are skipped. Entries matching the gitignore-style patterns of --ignore-file " +
+			"files on either side are not synchronized.\n\n" + FormatExamples(
+			Example{
+				Description: "Synchronize a project once",
+				Command:     "coder file-sync ./repo my-workspace:repo",
+			},
+			Example{
+				Description: "Keep synchronizing until interrupted, skipping dependencies",
+				Command:     "coder file-sync --watch --exclude node_modules --exclude .git ./repo my-workspace:repo",
+			},
+			Example{
+				Description: "Resolve conflicts in favor of the local files",
+				Command:     "coder file-sync --prefer local ./repo my-workspace:repo",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, stop := inv.SignalNotifyContext(inv.Context(), StopSignals...)
+			defer stop()
+
+			local := parseCopyTarget(inv.Args[0])
+			remote := parseCopyTarget(inv.Args[1])
+			if local.remote() || !remote.remote() {
+				return xerrors.New("the first argument must be a local directory and the second a workspace directory, e.g. \"my-workspace:repo\"")
+			}
+			switch prefer {
+			case "", fileSyncPreferLocal, fileSyncPreferWorkspace:
+			default:
+				return xerrors.Errorf("--prefer must be %q or %q, got %q", fileSyncPreferLocal, fileSyncPreferWorkspace, prefer)
+			}
+			if err := (archive.Filter{Exclude: exclude}).Validate(); err != nil {
+				return err
+			}
+			localRoot, err := filepath.Abs(local.Path)
+			if err != nil {
+				return xerrors.Errorf("resolve local directory: %w", err)
+			}
+
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, remote.Workspace, !disableAutostart)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			remoteRoot, err := resolveRemotePath(ctx, conn, remote.Path)
+			if err != nil {
+				return err
+			}
+
+			s := &fileSyncer{
+				remote:     conn,
+				localRoot:  localRoot,
+				remoteRoot: remoteRoot,
+				exclude:    append(slices.Clone(exclude), fileSyncTempPrefix+"*"),
+				ignoreFile: ignoreFile,
+				prefer:     prefer,
+				out:        inv.Stderr,
+				clock:      quartz.NewReal(),
+			}
+			if err := s.prepare(ctx); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(inv.Stderr, "Synchronizing %s with %s\n",
+				pretty.Sprint(cliui.DefaultStyles.Code, localRoot),
+				pretty.Sprint(cliui.DefaultStyles.Code, remote.Workspace+":"+remoteRoot))
+
+			if !watch {
+				res, err := s.sync(ctx)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(inv.Stderr, res.String())
+				if len(s.conflicts) > 0 {
+					return xerrors.Errorf("%d conflicting paths were not synchronized, resolve them or rerun with --prefer", len(s.conflicts))
+				}
+				return nil
+			}
+
+			err = s.watch(ctx, inv.Logger, func(res fileSyncResult) {
+				_, _ = fmt.Fprintln(inv.Stderr, res.String())
+				_, _ = fmt.Fprintln(inv.Stderr, "Watching for changes, press Ctrl+C to stop.")
+			})
+			if err != nil && ctx.Err() == nil {
+				return err
+			}
+			return nil
+		},
+		Options: serpent.OptionSet{
+			{
+				Flag:          "watch",
+				FlagShorthand: "w",
+				Description:   "Keep synchronizing changes until interrupted.",
+				Value:         serpent.BoolOf(&watch),
+			},
+			{
+				Flag:        "exclude",
+				Description: "Skip entries matching these glob patterns on both sides. Patterns without a \"/\" match any path element, otherwise they match the path relative to the synchronized directory.",
+				Value:       serpent.StringArrayOf(&exclude),
+			},
+			{
+				Flag:        "ignore-file",
+				Description: "Name of the files containing gitignore-style patterns of entries to skip. Patterns apply to the directory of the file and below. Set to an empty string to disable ignore files.",
+				Default:     ".syncignore",
+				Value:       serpent.StringOf(&ignoreFile),
+			},
+			{
+				Flag:        "prefer",
+				Description: "Resolve conflicts in favor of one side, either \"local\" or \"workspace\". By default conflicting paths are reported and left untouched.",
+				Value:       serpent.StringOf(&prefer),
+			},
+			{
+				Flag:        "disable-autostart",
+				Description: "Disable starting the workspace automatically when connecting.",
+				Env:         "CODER_FILE_SYNC_DISABLE_AUTOSTART",
+				Value:       serpent.BoolOf(&disableAutostart),
+				Default:     "false",
+			},
+		},
+	}
+	return cmd
+}
+
+// fileSyncRemote is the part of workspacesdk.AgentConn used to synchronize
+// files.
+type fileSyncRemote interface {
+	Walk(ctx context.Context, req workspacesdk.WalkRequest) (workspacesdk.WalkResponse, error)
+	ReadFile(ctx context.Context, path string, offset, limit int64) (io.ReadCloser, string, error)
+	WriteFile(ctx context.Context, path string, reader io.Reader) error
+	Stat(ctx context.Context, path string) (workspacesdk.FileInfo, error)
+	Mkdir(ctx context.Context, req workspacesdk.MkdirRequest) error
+	Delete(ctx context.Context, req workspacesdk.DeleteRequest) error
+	Chmod(ctx context.Context, req workspacesdk.ChmodRequest) error
+	Watch(ctx context.Context, logger slog.Logger, req workspacesdk.WatchRequest) (<-chan workspacesdk.WatchEvent, io.Closer, error)
+}
+
+// fileSyncStat is the metadata used to detect changes to a file without
+// reading it.
+type fileSyncStat struct {
+	size    int64
+	modTime time.Time
+}
+
+func (s fileSyncStat) equal(other fileSyncStat) bool {
+	return s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// fileSyncEntry is a file or directory found while scanning either side.
+type fileSyncEntry struct {
+	isDir bool
+	stat  fileSyncStat
+	mode  string
+	// hash is only known for workspace files during the first pass, and
+	// computed on demand for local files.
+	hash string
+}
+
+// fileSyncBase is the state of a path after it was last synchronized. A path
+// changed on one side if it no longer matches the base.
+type fileSyncBase struct {
+	isDir  bool
+	hash   string
+	local  fileSyncStat
+	remote fileSyncStat
+}
+
+type fileSyncResult struct {
+	uploaded   int
+	downloaded int
+	deleted    int
+	conflicts  int
+}
+
+func (r fileSyncResult) String() string {
+	s := fmt.Sprintf("Synchronized: %d uploaded, %d downloaded, %d deleted", r.uploaded, r.downloaded, r.deleted)
+	if r.conflicts > 0 {
+		s += fmt.Sprintf(", %d conflicts", r.conflicts)
+	}
+	return s + "."
+}
+
+// fileSyncer synchronizes a local directory with a workspace directory. It
+// keeps the state of every path after it was last synchronized, which lets
+// it tell which side changed and propagate deletions. The state only lives in
+// memory, so the first pass cannot detect deletions and only copies new files.
+type fileSyncer struct {
+	remote     fileSyncRemote
+	localRoot  string
+	remoteRoot string
+	exclude    []string
+	ignoreFile string
+	prefer     string
+	out        io.Writer
+	clock      quartz.Clock
+
+	// base is keyed by the slash-separated path relative to the roots. It is
+	// nil until the first pass completed.
+	base map[string]fileSyncBase
+	// conflicts holds the paths that were reported as conflicting, so that
+	// each conflict is only reported once.
+	conflicts map[string]bool
+	// localHashes caches the hashes of local files by their metadata.
+	localHashes map[string]fileSyncHash
+}
+
+type fileSyncHash struct {
+	stat fileSyncStat
+	hash string
+}
+
+// prepare creates missing roots and makes sure both are directories.
+func (s *fileSyncer) prepare(ctx context.Context) error {
+	s.remoteRoot = strings.TrimRight(s.remoteRoot, `/\`)
+	if s.remoteRoot == "" {
+		s.remoteRoot = "/"
+	}
+	s.conflicts = make(map[string]bool)
+	s.localHashes = make(map[string]fileSyncHash)
+
+	if err := os.MkdirAll(s.localRoot, 0o755); err != nil {
+		return xerrors.Errorf("create local directory: %w", err)
+	}
+	stat, err := s.remote.Stat(ctx, s.remoteRoot)
+	if err != nil {
+		err = s.remote.Mkdir(ctx, workspacesdk.MkdirRequest{Path: s.remoteRoot, Parents: true})
+		if err != nil {
+			return xerrors.Errorf("create workspace directory %q: %w", s.remoteRoot, err)
+		}
+		return nil
+	}
+	if !stat.IsDir {
+		return xerrors.Errorf("workspace path %q is not a directory", s.remoteRoot)
+	}
+	return nil
+}
+
+func (s *fileSyncer) walkRequest(root string, hash bool) workspacesdk.WalkRequest {
+	return workspacesdk.WalkRequest{
+		Path:       root,
+		MaxEntries: workspacesdk.WalkMaxEntries,
+		Exclude:    s.exclude,
+		IgnoreFile: s.ignoreFile,
+		Hash:       hash,
+	}
+}
+
+func (s *fileSyncer) scanLocal() (map[string]fileSyncEntry, error) {
+	resp, err := agentfiles.Walk(afero.NewOsFs(), s.walkRequest(s.localRoot, false))
+	if err != nil {
+		return nil, xerrors.Errorf("scan local directory: %w", err)
+	}
+	if resp.Truncated {
+		return nil, s.tooManyEntries(s.localRoot)
+	}
+	entries := make(map[string]fileSyncEntry, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		if entry.IsSymlink {
+			continue
+		}
+		rel, err := filepath.Rel(s.localRoot, entry.AbsolutePathString)
+		if err != nil {
+			return nil, err
+		}
+		entries[filepath.ToSlash(rel)] = toFileSyncEntry(entry)
+	}
+	return entries, nil
+}
+
+func (s *fileSyncer) scanRemote(ctx context.Context, hash bool) (map[string]fileSyncEntry, error) {
+	resp, err := s.remote.Walk(ctx, s.walkRequest(s.remoteRoot, hash))
+	if err != nil {
+		return nil, xerrors.Errorf("scan workspace directory: %w", err)
+	}
+	if resp.Truncated {
+		return nil, s.tooManyEntries(s.remoteRoot)
+	}
+	entries := make(map[string]fileSyncEntry, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		if entry.IsSymlink {
+			continue
+		}
+		rel := strings.TrimLeft(strings.TrimPrefix(entry.AbsolutePathString, s.remoteRoot), `/\`)
+		if rel == "" {
+			continue
+		}
+		if strings.Contains(s.remoteRoot, `\`) {
+			// The workspace runs Windows.
+			rel = strings.ReplaceAll(rel, `\`, "/")
+		}
+		entries[rel] = toFileSyncEntry(entry)
+	}
+	return entries, nil
+}
+
+func (s *fileSyncer) tooManyEntries(root string) error {
+	return xerrors.Errorf("%q contains more than %d entries, skip some of them with --exclude or an ignore file", root, workspacesdk.WalkMaxEntries)
+}
+
+func toFileSyncEntry(info workspacesdk.FileInfo) fileSyncEntry {
+	entry := fileSyncEntry{isDir: info.IsDir, mode: info.Mode, hash: info.Hash}
+	if !info.IsDir {
+		entry.stat = fileSyncStat{size: info.Size, modTime: info.ModTime}
+	}
+	return entry
+}
+
+func (s *fileSyncer) localPath(rel string) string {
+	return filepath.Join(s.localRoot, filepath.FromSlash(rel))
+}
+
+func (s *fileSyncer) remotePath(rel string) string {
+	return strings.TrimSuffix(s.remoteRoot, "/") + "/" + rel
+}
+
+// localHash returns the hash of a local file, reusing the last hash if its
+// metadata did not change.
+func (s *fileSyncer) localHash(rel string, stat fileSyncStat) (string, error) {
+	if cached, ok := s.localHashes[rel]; ok && cached.stat.equal(stat) {
+		return cached.hash, nil
+	}
+	f, err := os.Open(s.localPath(rel))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	s.localHashes[rel] = fileSyncHash{stat: stat, hash: hash}
+	return hash, nil
+}
+
+// remoteHash downloads a workspace file to hash it.
+func (s *fileSyncer) remoteHash(ctx context.Context, rel string) (string, error) {
+	rc, _, err := s.remote.ReadFile(ctx, s.remotePath(rel), 0, 0)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// localChanged reports whether a local path differs from its base. Files
+// whose metadata changed are hashed, so that touching a file is not a change.
+func (s *fileSyncer) localChanged(rel string, entry fileSyncEntry, exists bool, base fileSyncBase, hasBase bool) (bool, error) {
+	if !hasBase || !exists {
+		return exists != hasBase, nil
+	}
+	if entry.isDir || base.isDir {
+		return entry.isDir != base.isDir, nil
+	}
+	if entry.stat.equal(base.local) {
+		return false, nil
+	}
+	hash, err := s.localHash(rel, entry.stat)
+	if err != nil {
+		return false, err
+	}
+	if hash != base.hash {
+		return true, nil
+	}
+	base.local = entry.stat
+	s.base[rel] = base
+	return false, nil
+}
+
+// remoteChanged reports whether a workspace path differs from its base.
+// Workspace files are only compared by metadata, as hashing them requires
+// downloading them.
+func remoteChanged(entry fileSyncEntry, exists bool, base fileSyncBase, hasBase bool) bool {
+	if !hasBase || !exists {
+		return exists != hasBase
+	}
+	if entry.isDir || base.isDir {
+		return entry.isDir != base.isDir
+	}
+	return !entry.stat.equal(base.remote)
+}
+
+// sync runs a single synchronization pass.
+func (s *fileSyncer) sync(ctx context.Context) (fileSyncResult, error) {
+	initial := s.base == nil
+	local, err := s.scanLocal()
+	if err != nil {
+		return fileSyncResult{}, err
+	}
+	// The first pass compares contents, later passes only need hashes for
+	// files changed on both sides.
+	remote, err := s.scanRemote(ctx, initial)
+	if err != nil {
+		return fileSyncResult{}, err
+	}
+	if initial {
+		s.base = make(map[string]fileSyncBase)
+	}
+
+	paths := make([]string, 0, len(local)+len(remote))
+	for rel := range local {
+		paths = append(paths, rel)
+	}
+	for rel := range remote {
+		if _, ok := local[rel]; !ok {
+			paths = append(paths, rel)
+		}
+	}
+	for rel := range s.base {
+		_, inLocal := local[rel]
+		_, inRemote := remote[rel]
+		if !inLocal && !inRemote {
+			paths = append(paths, rel)
+		}
+	}
+	// Sorting visits parents before their children. Deletions are applied
+	// afterwards in reverse order, so that directories are emptied first.
+	slices.Sort(paths)
+
+	var (
+		res     fileSyncResult
+		deletes []func() error
+	)
+	for _, rel := range paths {
+		l, inLocal := local[rel]
+		r, inRemote := remote[rel]
+		base, hasBase := s.base[rel]
+
+		lChanged, err := s.localChanged(rel, l, inLocal, base, hasBase)
+		if err != nil {
+			// The file may have been removed since the scan, the next pass
+			// will pick that up.
+			continue
+		}
+		rChanged := remoteChanged(r, inRemote, base, hasBase)
+
+		push, pull := lChanged && !rChanged, rChanged && !lChanged
+		if lChanged && rChanged {
+			resolved, err := s.resolveBoth(ctx, rel, l, inLocal, r, inRemote)
+			if err != nil {
+				return res, err
+			}
+			if resolved {
+				delete(s.conflicts, rel)
+				continue
+			}
+			switch s.prefer {
+			case fileSyncPreferLocal:
+				push = true
+			case fileSyncPreferWorkspace:
+				pull = true
+			default:
+				res.conflicts++
+				if !s.conflicts[rel] {
+					s.conflicts[rel] = true
+					cliui.Warnf(s.out, "Conflict: %s changed both locally and in the workspace, skipping it", rel)
+				}
+				continue
+			}
+		}
+		delete(s.conflicts, rel)
+
+		switch {
+		case push && !inLocal:
+			deletes = append(deletes, func() error {
+				err := s.remote.Delete(ctx, workspacesdk.DeleteRequest{Path: s.remotePath(rel)})
+				if err != nil {
+					cliui.Warnf(s.out, "Unable to delete %s in the workspace: %s", rel, err)
+					return nil
+				}
+				delete(s.base, rel)
+				res.deleted++
+				_, _ = fmt.Fprintf(s.out, "deleted %s in the workspace\n", rel)
+				return nil
+			})
+		case push:
+			if err := s.upload(ctx, rel, l); err != nil {
+				return res, xerrors.Errorf("upload %s: %w", rel, err)
+			}
+			if !l.isDir {
+				res.uploaded++
+				_, _ = fmt.Fprintf(s.out, "uploaded %s\n", rel)
+			}
+		case pull && !inRemote:
+			deletes = append(deletes, func() error {
+				if !s.localUnchanged(rel, l) {
+					return nil
+				}
+				if err := os.Remove(s.localPath(rel)); err != nil {
+					cliui.Warnf(s.out, "Unable to delete %s locally: %s", rel, err)
+					return nil
+				}
+				delete(s.base, rel)
+				res.deleted++
+				_, _ = fmt.Fprintf(s.out, "deleted %s locally\n", rel)
+				return nil
+			})
+		case pull:
+			if inLocal && !s.localUnchanged(rel, l) {
+				// Changed since the scan, the next pass compares it again.
+				continue
+			}
+			if err := s.download(ctx, rel, r); err != nil {
+				return res, xerrors.Errorf("download %s: %w", rel, err)
+			}
+			if !r.isDir {
+				res.downloaded++
+				_, _ = fmt.Fprintf(s.out, "downloaded %s\n", rel)
+			}
+		}
+	}
+	for i := len(deletes) - 1; i >= 0; i-- {
+		if err := deletes[i](); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// resolveBoth handles a path that changed on both sides. It returns true if
+// both sides ended up the same, e.g. the same file was created on both.
+func (s *fileSyncer) resolveBoth(ctx context.Context, rel string, l fileSyncEntry, inLocal bool, r fileSyncEntry, inRemote bool) (bool, error) {
+	switch {
+	case !inLocal && !inRemote:
+		delete(s.base, rel)
+		return true, nil
+	case !inLocal || !inRemote || l.isDir != r.isDir:
+		return false, nil
+	case l.isDir:
+		s.base[rel] = fileSyncBase{isDir: true}
+		return true, nil
+	}
+
+	localHash, err := s.localHash(rel, l.stat)
+	if err != nil {
+		return false, xerrors.Errorf("hash %s: %w", rel, err)
+	}
+	remoteHash := r.hash
+	if remoteHash == "" {
+		remoteHash, err = s.remoteHash(ctx, rel)
+		if err != nil {
+			return false, xerrors.Errorf("hash %s in the workspace: %w", rel, err)
+		}
+	}
+	if localHash != remoteHash {
+		return false, nil
+	}
+	s.base[rel] = fileSyncBase{hash: localHash, local: l.stat, remote: r.stat}
+	return true, nil
+}
+
+// localUnchanged reports whether a local path still matches what the scan
+// found, so that changes made during a pass are never overwritten.
+func (s *fileSyncer) localUnchanged(rel string, scanned fileSyncEntry) bool {
+	info, err := os.Lstat(s.localPath(rel))
+	if err != nil {
+		return false
+	}
+	if info.IsDir() || scanned.isDir {
+		return info.IsDir() == scanned.isDir
+	}
+	return scanned.stat.equal(fileSyncStat{size: info.Size(), modTime: info.ModTime()})
+}
+
+func (s *fileSyncer) upload(ctx context.Context, rel string, entry fileSyncEntry) error {
+	dst := s.remotePath(rel)
+	if entry.isDir {
+		err := s.remote.Mkdir(ctx, workspacesdk.MkdirRequest{Path: dst, Parents: true, Mode: entry.mode})
+		if err != nil {
+			return err
+		}
+		s.base[rel] = fileSyncBase{isDir: true}
+		return nil
+	}
+
+	f, err := os.Open(s.localPath(rel))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if err := s.remote.WriteFile(ctx, dst, io.TeeReader(f, h)); err != nil {
+		return err
+	}
+	stat, err := s.remote.Stat(ctx, dst)
+	if err != nil {
+		return err
+	}
+	if entry.mode != "" && stat.Mode != entry.mode {
+		if err := s.remote.Chmod(ctx, workspacesdk.ChmodRequest{Path: dst, Mode: entry.mode}); err != nil {
+			return err
+		}
+	}
+	s.base[rel] = fileSyncBase{
+		hash:   hex.EncodeToString(h.Sum(nil)),
+		local:  entry.stat,
+		remote: fileSyncStat{size: stat.Size, modTime: stat.ModTime},
+	}
+	return nil
+}
+
+// download writes a workspace file to a temporary file that is moved into
+// place once complete.
+func (s *fileSyncer) download(ctx context.Context, rel string, entry fileSyncEntry) error {
+	dst := s.localPath(rel)
+	mode := fs.FileMode(0o644)
+	if parsed, err := strconv.ParseUint(entry.mode, 8, 32); err == nil {
+		mode = fs.FileMode(parsed)
+	}
+	if entry.isDir {
+		if err := os.MkdirAll(dst, mode|0o700); err != nil {
+			return err
+		}
+		s.base[rel] = fileSyncBase{isDir: true}
+		return nil
+	}
+
+	rc, _, err := s.remote.ReadFile(ctx, s.remotePath(rel), 0, 0)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), fileSyncTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), rc)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return err
+	}
+	info, err := os.Stat(dst)
+	if err != nil {
+		return err
+	}
+	s.base[rel] = fileSyncBase{
+		hash:   hex.EncodeToString(h.Sum(nil)),
+		local:  fileSyncStat{size: info.Size(), modTime: info.ModTime()},
+		remote: entry.stat,
+	}
+	return nil
+}
+
+// watch runs a first pass and then another pass whenever either side
+// changes, until the context is canceled or watching fails.
+func (s *fileSyncer) watch(ctx context.Context, logger slog.Logger, onInitialPass func(fileSyncResult)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watchReq := func(root string) workspacesdk.WatchRequest {
+		return workspacesdk.WatchRequest{
+			Paths:     []string{root},
+			Recursive: true,
+			Exclude:   s.exclude,
+		}
+	}
+	// Start watching before the first pass, so that no change is missed.
+	remoteEvents, closer, err := s.remote.Watch(ctx, logger, watchReq(s.remoteRoot))
+	if err != nil {
+		return xerrors.Errorf("watch workspace directory: %w", err)
+	}
+	defer closer.Close()
+
+	localEvents, localCloser, err := agentfiles.Watch(ctx, logger, s.clock, afero.NewOsFs(), watchReq(s.localRoot))
+	if err != nil {
+		return xerrors.Errorf("watch local directory: %w", err)
+	}
+	defer localCloser.Close()
+
+	res, err := s.sync(ctx)
+	if err != nil {
+		return err
+	}
+	onInitialPass(res)
+
+	// Passes start once no change was observed for fileSyncSettle.
+	settle := s.clock.NewTimer(fileSyncSettle, "filesync", "settle")
+	settle.Stop()
+	defer settle.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-localEvents:
+			if !ok {
+				return xerrors.New("watching the local directory stopped")
+			}
+			settle.Reset(fileSyncSettle, "filesync", "settle")
+		case _, ok := <-remoteEvents:
+			if !ok {
+				return xerrors.New("watching the workspace directory stopped, is the workspace still running?")
+			}
+			settle.Reset(fileSyncSettle, "filesync", "settle")
+		case <-settle.C:
+			if _, err := s.sync(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
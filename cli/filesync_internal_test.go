@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/agent/agentfiles"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
+)
+
+// localSyncRemote implements fileSyncRemote on the local filesystem, the same
+// way the agent does.
+type localSyncRemote struct{}
+
+func (localSyncRemote) Walk(_ context.Context, req workspacesdk.WalkRequest) (workspacesdk.WalkResponse, error) {
+	return agentfiles.Walk(afero.NewOsFs(), req)
+}
+
+func (localSyncRemote) ReadFile(_ context.Context, path string, _, _ int64) (io.ReadCloser, string, error) {
+	f, err := os.Open(path)
+	return f, "application/octet-stream", err
+}
+
+func (localSyncRemote) WriteFile(_ context.Context, path string, reader io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+func (localSyncRemote) Stat(_ context.Context, path string) (workspacesdk.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return workspacesdk.FileInfo{}, err
+	}
+	return workspacesdk.FileInfo{
+		Name:               info.Name(),
+		AbsolutePathString: path,
+		IsDir:              info.IsDir(),
+		Size:               info.Size(),
+		Mode:               "0" + strconv.FormatUint(uint64(info.Mode().Perm()), 8),
+		ModTime:            info.ModTime(),
+	}, nil
+}
+
+func (localSyncRemote) Mkdir(_ context.Context, req workspacesdk.MkdirRequest) error {
+	return os.MkdirAll(req.Path, 0o755)
+}
+
+func (localSyncRemote) Delete(_ context.Context, req workspacesdk.DeleteRequest) error {
+	return os.Remove(req.Path)
+}
+
+func (localSyncRemote) Chmod(_ context.Context, req workspacesdk.ChmodRequest) error {
+	mode, err := strconv.ParseUint(req.Mode, 8, 32)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(req.Path, os.FileMode(mode))
+}
+
+func (localSyncRemote) Watch(ctx context.Context, logger slog.Logger, req workspacesdk.WatchRequest) (<-chan workspacesdk.WatchEvent, io.Closer, error) {
+	return agentfiles.Watch(ctx, logger, quartz.NewReal(), afero.NewOsFs(), req)
+}
+
+func writeSyncFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func readSyncFile(t *testing.T, root, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	return string(b)
+}
+
+func newTestFileSyncer(t *testing.T, prefer string) (*fileSyncer, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	s := &fileSyncer{
+		remote:     localSyncRemote{},
+		localRoot:  t.TempDir(),
+		remoteRoot: t.TempDir(),
+		exclude:    []string{"node_modules", fileSyncTempPrefix + "*"},
+		ignoreFile: ".syncignore",
+		prefer:     prefer,
+		out:        &out,
+		clock:      quartz.NewReal(),
+	}
+	require.NoError(t, s.prepare(context.Background()))
+	return s, &out
+}
+
+func Test_fileSyncer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reconcile", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		s, out := newTestFileSyncer(t, "")
+		writeSyncFiles(t, s.localRoot, map[string]string{
+			".syncignore":      "*.log\n",
+			"a.txt":            "a",
+			"same.txt":         "same",
+			"conflict.txt":     "local",
+			"debug.log":        "log",
+			"node_modules/dep": "dep",
+		})
+		writeSyncFiles(t, s.remoteRoot, map[string]string{
+			"b/c.txt":      "c",
+			"same.txt":     "same",
+			"conflict.txt": "workspace",
+		})
+
+		res, err := s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{uploaded: 2, downloaded: 1, conflicts: 1}, res)
+		require.Equal(t, "a", readSyncFile(t, s.remoteRoot, "a.txt"))
+		require.Equal(t, "*.log\n", readSyncFile(t, s.remoteRoot, ".syncignore"))
+		require.Equal(t, "c", readSyncFile(t, s.localRoot, "b/c.txt"))
+		require.Equal(t, "local", readSyncFile(t, s.localRoot, "conflict.txt"))
+		require.Equal(t, "workspace", readSyncFile(t, s.remoteRoot, "conflict.txt"))
+		require.NoFileExists(t, filepath.Join(s.remoteRoot, "debug.log"))
+		require.NoDirExists(t, filepath.Join(s.remoteRoot, "node_modules"))
+		require.Contains(t, out.String(), "Conflict: conflict.txt")
+
+		// A second pass with no changes does nothing and reports the
+		// conflict only once.
+		out.Reset()
+		res, err = s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{conflicts: 1}, res)
+		require.Empty(t, out.String())
+
+		// Changes and deletions are applied to the other side.
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "changed locally"})
+		writeSyncFiles(t, s.remoteRoot, map[string]string{"same.txt": "changed in the workspace"})
+		require.NoError(t, os.RemoveAll(filepath.Join(s.remoteRoot, "b")))
+		res, err = s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{uploaded: 1, downloaded: 1, deleted: 2, conflicts: 1}, res)
+		require.Equal(t, "changed locally", readSyncFile(t, s.remoteRoot, "a.txt"))
+		require.Equal(t, "changed in the workspace", readSyncFile(t, s.localRoot, "same.txt"))
+		require.NoDirExists(t, filepath.Join(s.localRoot, "b"))
+
+		// Resolving the conflict by hand clears it.
+		writeSyncFiles(t, s.localRoot, map[string]string{"conflict.txt": "workspace"})
+		res, err = s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{}, res)
+		require.Empty(t, s.conflicts)
+	})
+
+	t.Run("ConflictAfterFirstPass", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		s, _ := newTestFileSyncer(t, "")
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "a"})
+		_, err := s.sync(ctx)
+		require.NoError(t, err)
+
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "local"})
+		writeSyncFiles(t, s.remoteRoot, map[string]string{"a.txt": "workspace"})
+		res, err := s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{conflicts: 1}, res)
+
+		// Deleting a file that changed on the other side is a conflict too.
+		require.NoError(t, os.Remove(filepath.Join(s.localRoot, "a.txt")))
+		res, err = s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{conflicts: 1}, res)
+		require.Equal(t, "workspace", readSyncFile(t, s.remoteRoot, "a.txt"))
+	})
+
+	t.Run("PreferLocal", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		s, _ := newTestFileSyncer(t, fileSyncPreferLocal)
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "local"})
+		writeSyncFiles(t, s.remoteRoot, map[string]string{"a.txt": "workspace"})
+		res, err := s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{uploaded: 1}, res)
+		require.Equal(t, "local", readSyncFile(t, s.remoteRoot, "a.txt"))
+	})
+
+	t.Run("PreferWorkspace", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		s, _ := newTestFileSyncer(t, fileSyncPreferWorkspace)
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "local"})
+		writeSyncFiles(t, s.remoteRoot, map[string]string{"a.txt": "workspace"})
+		res, err := s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{downloaded: 1}, res)
+		require.Equal(t, "workspace", readSyncFile(t, s.localRoot, "a.txt"))
+	})
+
+	t.Run("Touch", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		s, _ := newTestFileSyncer(t, "")
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "a"})
+		_, err := s.sync(ctx)
+		require.NoError(t, err)
+
+		// Only the metadata changed, so nothing is uploaded.
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(s.localRoot, "a.txt"), later, later))
+		res, err := s.sync(ctx)
+		require.NoError(t, err)
+		require.Equal(t, fileSyncResult{}, res)
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(testutil.Context(t, testutil.WaitLong))
+		defer cancel()
+
+		s, _ := newTestFileSyncer(t, "")
+		clock := quartz.NewMock(t)
+		s.clock = clock
+		writeSyncFiles(t, s.localRoot, map[string]string{"a.txt": "a"})
+
+		initial := make(chan fileSyncResult, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- s.watch(ctx, testutil.Logger(t), func(res fileSyncResult) {
+				initial <- res
+			})
+		}()
+		require.Equal(t, fileSyncResult{uploaded: 1}, testutil.RequireReceive(ctx, t, initial))
+
+		writeSyncFiles(t, s.localRoot, map[string]string{"dir/b.txt": "b"})
+		writeSyncFiles(t, s.remoteRoot, map[string]string{"c.txt": "c"})
+		// Both the local watch debounce and the settle delay run on the mock
+		// clock, so changes are only synced as the clock advances.
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			if d, ok := clock.Peek(); ok {
+				clock.Advance(d).MustWait(ctx)
+			}
+			b, err := os.ReadFile(filepath.Join(s.remoteRoot, "dir", "b.txt"))
+			assert.NoError(t, err)
+			assert.Equal(t, "b", string(b))
+			c, err := os.ReadFile(filepath.Join(s.localRoot, "c.txt"))
+			assert.NoError(t, err)
+			assert.Equal(t, "c", string(c))
+		}, testutil.WaitMedium, testutil.IntervalFast)
+
+		cancel()
+		err := testutil.RequireReceive(testutil.Context(t, testutil.WaitShort), t, done)
+		require.True(t, xerrors.Is(err, context.Canceled), "unexpected error: %v", err)
+	})
+}
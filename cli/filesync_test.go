@@ -0,0 +1,77 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/pty/ptytest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestFileSync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LocalTarget", func(t *testing.T) {
+		t.Parallel()
+
+		inv, _ := clitest.New(t, "file-sync", "./a", "./b")
+		err := inv.Run()
+		require.ErrorContains(t, err, "the first argument must be a local directory")
+	})
+
+	t.Run("BadPrefer", func(t *testing.T) {
+		t.Parallel()
+
+		inv, _ := clitest.New(t, "file-sync", "--prefer", "newest", "./a", "ws:b")
+		err := inv.Run()
+		require.ErrorContains(t, err, "--prefer must be")
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken)
+		_ = coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		local := t.TempDir()
+		remote := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(local, "local.txt"), []byte("local"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(remote, "remote.txt"), []byte("remote"), 0o600))
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "file-sync", "--watch", local, workspace.Name+":"+remote)
+		clitest.SetupConfig(t, client, root)
+		pty := ptytest.New(t).Attach(inv)
+		clitest.Start(t, inv.WithContext(ctx))
+
+		pty.ExpectMatchContext(ctx, "Synchronized: 1 uploaded, 1 downloaded, 0 deleted.")
+		pty.ExpectMatchContext(ctx, "Watching for changes")
+		require.Equal(t, "local", readFile(t, filepath.Join(remote, "local.txt")))
+		require.Equal(t, "remote", readFile(t, filepath.Join(local, "remote.txt")))
+
+		require.NoError(t, os.Remove(filepath.Join(local, "remote.txt")))
+		require.NoError(t, os.WriteFile(filepath.Join(remote, "local.txt"), []byte("changed"), 0o600))
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			_, err := os.Stat(filepath.Join(remote, "remote.txt"))
+			assert.ErrorIs(t, err, os.ErrNotExist)
+			b, err := os.ReadFile(filepath.Join(local, "local.txt"))
+			assert.NoError(t, err)
+			assert.Equal(t, "changed", string(b))
+		}, testutil.WaitLong, testutil.IntervalMedium)
+	})
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(b)
+}
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,17 +19,50 @@ import (
 	"github.com/coder/serpent"
 )
 
+// logLevels are the log levels in increasing order of severity.
+var logLevels = []codersdk.LogLevel{
+	codersdk.LogLevelTrace,
+	codersdk.LogLevelDebug,
+	codersdk.LogLevelInfo,
+	codersdk.LogLevelWarn,
+	codersdk.LogLevelError,
+}
+
 func (r *RootCmd) logs() *serpent.Command {
 	var (
 		buildNumberArg int64
 		followArg      bool
+		stagesArg      []string
+		agentsArg      []string
+		sourcesArg     []string
+		levelArg       string
+		grepArg        string
+		sinceArg       string
+		outputArg      string
 	)
 	cmd := &serpent.Command{
-		Use:   "logs <workspace>",
+		Use:   "logs <workspace> [build-number]",
 		Short: "View logs for a workspace",
-		Long:  "View logs for a workspace",
+		Long: FormatExamples(
+			Example{
+				Description: "Show the warnings and errors of the previous build",
+				Command:     "coder logs my-workspace -n -1 --level warn",
+			},
+			Example{
+				Description: "Search the logs of build 3",
+				Command:     "coder logs my-workspace 3 --grep docker_volume",
+			},
+			Example{
+				Description: "Follow the logs of a startup script",
+				Command:     "coder logs my-workspace --agent main --source \"Startup Script\" --follow",
+			},
+			Example{
+				Description: "Export the logs of the last hour as JSON",
+				Command:     "coder logs my-workspace --since 1h --output json > logs.json",
+			},
+		),
 		Middleware: serpent.Chain(
-			serpent.RequireNArgs(1),
+			serpent.RequireRangeArgs(1, 2),
 		),
 		Options: serpent.OptionSet{
 			{
@@ -45,6 +81,50 @@ func (r *RootCmd) logs() *serpent.Command {
 				Value:         serpent.BoolOf(&followArg),
 				Default:       "false",
 			},
+			{
+				Name:        "Stage",
+				Flag:        "stage",
+				Description: "Only show build logs from the given provisioner stages, for example \"Planning infrastructure\". Agent logs are not shown when set.",
+				Value:       serpent.StringArrayOf(&stagesArg),
+			},
+			{
+				Name:        "Agent",
+				Flag:        "agent",
+				Description: "Only show logs of the given agents. Build logs are not shown when set.",
+				Value:       serpent.StringArrayOf(&agentsArg),
+			},
+			{
+				Name:        "Source",
+				Flag:        "source",
+				Description: "Only show agent logs from the given log sources, for example \"Startup Script\". Build logs are not shown when set.",
+				Value:       serpent.StringArrayOf(&sourcesArg),
+			},
+			{
+				Name:        "Level",
+				Flag:        "level",
+				Description: "Only show logs at or above the given level.",
+				Value:       serpent.EnumOf(&levelArg, "trace", "debug", "info", "warn", "error"),
+			},
+			{
+				Name:        "Grep",
+				Flag:        "grep",
+				Description: "Only show logs whose output contains the given text, case-insensitive.",
+				Value:       serpent.StringOf(&grepArg),
+			},
+			{
+				Name:        "Since",
+				Flag:        "since",
+				Description: "Only show logs emitted since the given RFC3339 timestamp, or within the given duration, for example 30m.",
+				Value:       serpent.StringOf(&sinceArg),
+			},
+			{
+				Name:          "Output",
+				Flag:          "output",
+				FlagShorthand: "o",
+				Description:   "Output format. JSON output prints one log object per line.",
+				Value:         serpent.EnumOf(&outputArg, "plain", "json"),
+				Default:       "plain",
+			},
 		},
 		Handler: func(inv *serpent.Invocation) error {
 			ctx := inv.Context()
@@ -52,6 +132,35 @@ func (r *RootCmd) logs() *serpent.Command {
 			if err != nil {
 				return err
 			}
+
+			if len(inv.Args) > 1 {
+				if buildNumberArg != 0 {
+					return xerrors.New("the build number cannot be set both as an argument and with --build-number")
+				}
+				buildNumberArg, err = strconv.ParseInt(inv.Args[1], 10, 64)
+				if err != nil {
+					return xerrors.Errorf("invalid build number %q: %w", inv.Args[1], err)
+				}
+			}
+			if len(stagesArg) > 0 && (len(agentsArg) > 0 || len(sourcesArg) > 0) {
+				return xerrors.New("--stage selects build logs and cannot be combined with --agent or --source")
+			}
+			filter := logsFilter{
+				stages:  stagesArg,
+				agents:  agentsArg,
+				sources: sourcesArg,
+				search:  grepArg,
+			}
+			if levelArg != "" {
+				filter.levels = logLevels[slices.Index(logLevels, codersdk.LogLevel(levelArg)):]
+			}
+			if sinceArg != "" {
+				filter.since, err = parseLogsSince(sinceArg, time.Now())
+				if err != nil {
+					return err
+				}
+			}
+
 			ws, err := namedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return xerrors.Errorf("failed to get workspace: %w", err)
@@ -75,18 +184,28 @@ func (r *RootCmd) logs() *serpent.Command {
 				}
 				bld = wb
 			}
-			cliui.Infof(inv.Stdout, "--- Logs for workspace build #%d (ID: %s Template Version: %s) ---", bld.BuildNumber, bld.ID, bld.TemplateVersionName)
-			logs, logsCh, err := workspaceLogs(ctx, client, bld, followArg)
+
+			jsonOutput := outputArg == "json"
+			if !jsonOutput {
+				cliui.Infof(inv.Stdout, "--- Logs for workspace build #%d (ID: %s Template Version: %s) ---", bld.BuildNumber, bld.ID, bld.TemplateVersionName)
+			}
+			logs, logsCh, err := workspaceLogs(ctx, client, bld, filter, followArg)
 			if err != nil {
 				return err
 			}
 			for _, log := range logs {
-				_, _ = fmt.Fprintln(inv.Stdout, log.text)
+				if err := writeLogLine(inv.Stdout, log, jsonOutput); err != nil {
+					return err
+				}
 			}
 			if followArg {
-				_, _ = fmt.Fprintln(inv.Stdout, "--- Streaming logs ---")
+				if !jsonOutput {
+					_, _ = fmt.Fprintln(inv.Stdout, "--- Streaming logs ---")
+				}
 				for log := range logsCh {
-					_, _ = fmt.Fprintln(inv.Stdout, log.text)
+					if err := writeLogLine(inv.Stdout, log, jsonOutput); err != nil {
+						return err
+					}
 				}
 			}
 			return nil
@@ -95,16 +214,184 @@ func (r *RootCmd) logs() *serpent.Command {
 	return cmd
 }
 
+// parseLogsSince parses a --since value, which is either an RFC3339 timestamp
+// or a duration relative to now.
+func parseLogsSince(value string, now time.Time) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("invalid --since %q: must be an RFC3339 timestamp or a duration", value)
+	}
+	if d < 0 {
+		return time.Time{}, xerrors.Errorf("invalid --since %q: duration must not be negative", value)
+	}
+	return now.Add(-d), nil
+}
+
+func writeLogLine(w io.Writer, log logLine, jsonOutput bool) error {
+	if !jsonOutput {
+		_, _ = fmt.Fprintln(w, log.text)
+		return nil
+	}
+	out, err := json.Marshal(log.entry)
+	if err != nil {
+		return xerrors.Errorf("marshal log: %w", err)
+	}
+	_, _ = fmt.Fprintln(w, string(out))
+	return nil
+}
+
+// logsFilter selects the logs shown by coder logs. Logs are filtered by the
+// server, so only matching logs are transferred.
+type logsFilter struct {
+	stages  []string
+	agents  []string
+	sources []string
+	levels  []codersdk.LogLevel
+	search  string
+	since   time.Time
+}
+
+func (f logsFilter) provisionerJobLogsFilter() codersdk.ProvisionerJobLogsFilter {
+	return codersdk.ProvisionerJobLogsFilter{
+		Stages: f.stages,
+		Levels: f.levels,
+		Search: f.search,
+		Since:  f.since,
+	}
+}
+
+// includeBuildLogs reports whether build logs are shown at all. They are
+// omitted when selecting agents or log sources.
+func (f logsFilter) includeBuildLogs() bool {
+	return len(f.agents) == 0 && len(f.sources) == 0
+}
+
+// includeAgentLogs reports whether agent logs are shown at all. Agent logs
+// have no stages, so they are omitted when filtering by stage.
+func (f logsFilter) includeAgentLogs() bool {
+	return len(f.stages) == 0
+}
+
+// workspaceAgentLogsFilter returns the filter for the logs of the given agent,
+// and false if none of its logs are shown.
+func (f logsFilter) workspaceAgentLogsFilter(agent codersdk.WorkspaceAgent) (codersdk.WorkspaceAgentLogsFilter, bool) {
+	if !f.includeAgentLogs() {
+		return codersdk.WorkspaceAgentLogsFilter{}, false
+	}
+	if len(f.agents) > 0 && !slices.Contains(f.agents, agent.Name) {
+		return codersdk.WorkspaceAgentLogsFilter{}, false
+	}
+	filter := codersdk.WorkspaceAgentLogsFilter{
+		Levels: f.levels,
+		Search: f.search,
+		Since:  f.since,
+	}
+	if len(f.sources) > 0 {
+		for _, src := range agent.LogSources {
+			if slices.Contains(f.sources, src.DisplayName) {
+				filter.SourceIDs = append(filter.SourceIDs, src.ID)
+			}
+		}
+		if len(filter.SourceIDs) == 0 {
+			return codersdk.WorkspaceAgentLogsFilter{}, false
+		}
+	}
+	return filter, true
+}
+
+// validate checks that the agents and log sources of the filter exist in the
+// build, so a typo doesn't silently show no logs.
+func (f logsFilter) validate(wb codersdk.WorkspaceBuild) error {
+	var (
+		agentNames  []string
+		sourceNames []string
+	)
+	for _, res := range wb.Resources {
+		for _, agt := range res.Agents {
+			agentNames = append(agentNames, agt.Name)
+			if len(f.agents) > 0 && !slices.Contains(f.agents, agt.Name) {
+				continue
+			}
+			for _, src := range agt.LogSources {
+				if !slices.Contains(sourceNames, src.DisplayName) {
+					sourceNames = append(sourceNames, src.DisplayName)
+				}
+			}
+		}
+	}
+	for _, name := range f.agents {
+		if !slices.Contains(agentNames, name) {
+			return xerrors.Errorf("agent %q not found in build #%d, available agents: %s", name, wb.BuildNumber, strings.Join(agentNames, ", "))
+		}
+	}
+	for _, name := range f.sources {
+		if !slices.Contains(sourceNames, name) {
+			return xerrors.Errorf("log source %q not found in build #%d, available log sources: %s", name, wb.BuildNumber, strings.Join(sourceNames, ", "))
+		}
+	}
+	return nil
+}
+
 type logLine struct {
-	ts   time.Time // for sorting
-	text string
+	ts    time.Time // for sorting
+	text  string
+	entry logEntry
+}
+
+// logEntry is the JSON representation of a log line.
+type logEntry struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Level     codersdk.LogLevel `json:"level"`
+	// Source is either "provisioner" for build logs or "agent" for agent
+	// logs.
+	Source    string `json:"source"`
+	Stage     string `json:"stage,omitempty"`
+	Agent     string `json:"agent,omitempty"`
+	LogSource string `json:"log_source,omitempty"`
+	Output    string `json:"output"`
 }
 
-// workspaceLogs fetches logs for the given workspace build. If follow is true,
-// the returned channel will stream new logs as they are emitted. Otherwise,
-// the channel will be closed immediately.
+func provisionerLogLine(log codersdk.ProvisionerJobLog) logLine {
+	return logLine{
+		ts:   log.CreatedAt,
+		text: log.Text(),
+		entry: logEntry{
+			CreatedAt: log.CreatedAt,
+			Level:     log.Level,
+			Source:    "provisioner",
+			Stage:     log.Stage,
+			Output:    log.Output,
+		},
+	}
+}
+
+func agentLogLine(log codersdk.WorkspaceAgentLog, agentName, sourceName string) logLine {
+	return logLine{
+		ts:   log.CreatedAt,
+		text: log.Text(agentName, sourceName),
+		entry: logEntry{
+			CreatedAt: log.CreatedAt,
+			Level:     log.Level,
+			Source:    "agent",
+			Agent:     agentName,
+			LogSource: sourceName,
+			Output:    log.Output,
+		},
+	}
+}
+
+// workspaceLogs fetches logs matching the filter for the given workspace
+// build. If follow is true, the returned channel will stream new logs as they
+// are emitted. Otherwise, the channel will be closed immediately.
 // nolint: revive // control flag is appropriate here
-func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.WorkspaceBuild, follow bool) ([]logLine, <-chan logLine, error) {
+func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.WorkspaceBuild, filter logsFilter, follow bool) ([]logLine, <-chan logLine, error) {
+	if err := filter.validate(wb); err != nil {
+		return nil, nil, err
+	}
+
 	logs := make([]logLine, 0)
 	logsCh := make(chan logLine)
 	followCh := make(chan logLine)
@@ -112,42 +399,38 @@ func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.Wor
 	var fetchGroup, followGroup errgroup.Group
 
 	buildLogsAfterCh := make(chan int64)
-	fetchGroup.Go(func() error {
-		var afterID int64
-		defer func() {
-			if !follow {
-				return
+	if filter.includeBuildLogs() {
+		fetchGroup.Go(func() error {
+			var afterID int64
+			defer func() {
+				if !follow {
+					return
+				}
+				buildLogsAfterCh <- afterID
+			}()
+			buildLogsC, closer, err := client.WorkspaceBuildLogsAfterWithFilter(ctx, wb.ID, 0, filter.provisionerJobLogsFilter())
+			if err != nil {
+				return xerrors.Errorf("failed to get build logs: %w", err)
 			}
-			buildLogsAfterCh <- afterID
-		}()
-		buildLogsC, closer, err := client.WorkspaceBuildLogsAfter(ctx, wb.ID, 0)
-		if err != nil {
-			return xerrors.Errorf("failed to get build logs: %w", err)
-		}
-		defer closer.Close()
-		for log := range buildLogsC {
-			afterID = log.ID
-			logsCh <- logLine{
-				ts:   log.CreatedAt,
-				text: log.Text(),
+			defer closer.Close()
+			for log := range buildLogsC {
+				afterID = log.ID
+				logsCh <- provisionerLogLine(log)
 			}
-		}
-		return nil
-	})
+			return nil
+		})
+	}
 
-	if follow {
+	if follow && filter.includeBuildLogs() {
 		followGroup.Go(func() error {
 			afterID := <-buildLogsAfterCh
-			buildLogsC, closer, err := client.WorkspaceBuildLogsAfter(ctx, wb.ID, afterID)
+			buildLogsC, closer, err := client.WorkspaceBuildLogsAfterWithFilter(ctx, wb.ID, afterID, filter.provisionerJobLogsFilter())
 			if err != nil {
 				return xerrors.Errorf("failed to follow build logs: %w", err)
 			}
 			defer closer.Close()
 			for log := range buildLogsC {
-				followCh <- logLine{
-					ts:   log.CreatedAt,
-					text: log.Text(),
-				}
+				followCh <- provisionerLogLine(log)
 			}
 			return nil
 		})
@@ -155,6 +438,10 @@ func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.Wor
 
 	for _, res := range wb.Resources {
 		for _, agt := range res.Agents {
+			agentFilter, ok := filter.workspaceAgentLogsFilter(agt)
+			if !ok {
+				continue
+			}
 			logSrcNames := make(map[uuid.UUID]string)
 			for _, src := range agt.LogSources {
 				logSrcNames[src.ID] = src.DisplayName
@@ -168,7 +455,7 @@ func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.Wor
 					}
 					agentLogsAfterCh <- afterID
 				}()
-				agentLogsCh, closer, err := client.WorkspaceAgentLogsAfter(ctx, agt.ID, 0, false)
+				agentLogsCh, closer, err := client.WorkspaceAgentLogsAfterWithFilter(ctx, agt.ID, 0, false, agentFilter)
 				if err != nil {
 					return xerrors.Errorf("failed to get agent logs: %w", err)
 				}
@@ -176,10 +463,7 @@ func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.Wor
 				for logChunk := range agentLogsCh {
 					for _, log := range logChunk {
 						afterID = log.ID
-						logsCh <- logLine{
-							ts:   log.CreatedAt,
-							text: log.Text(agt.Name, logSrcNames[log.SourceID]),
-						}
+						logsCh <- agentLogLine(log, agt.Name, logSrcNames[log.SourceID])
 					}
 				}
 				return nil
@@ -188,17 +472,14 @@ func workspaceLogs(ctx context.Context, client *codersdk.Client, wb codersdk.Wor
 			if follow {
 				followGroup.Go(func() error {
 					afterID := <-agentLogsAfterCh
-					agentLogsCh, closer, err := client.WorkspaceAgentLogsAfter(ctx, agt.ID, afterID, true)
+					agentLogsCh, closer, err := client.WorkspaceAgentLogsAfterWithFilter(ctx, agt.ID, afterID, true, agentFilter)
 					if err != nil {
 						return xerrors.Errorf("failed to follow agent logs: %w", err)
 					}
 					defer closer.Close()
 					for logChunk := range agentLogsCh {
 						for _, log := range logChunk {
-							followCh <- logLine{
-								ts:   log.CreatedAt,
-								text: log.Text(agt.Name, logSrcNames[log.SourceID]),
-							}
+							followCh <- agentLogLine(log, agt.Name, logSrcNames[log.SourceID])
 						}
 					}
 					return nil
@@ -1,9 +1,11 @@
 package cli_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -101,6 +103,167 @@ func TestLogsCmd(t *testing.T) {
 		assertAntagonist(t, wb2, stdout.String())
 	})
 
+	t.Run("build number argument", func(t *testing.T) {
+		t.Parallel()
+
+		inv, root := clitest.New(t, "logs", wb1.Workspace.Name, fmt.Sprintf("%d", wb1.Build.BuildNumber))
+		clitest.SetupConfig(t, memberClient, root)
+		ctx := testutil.Context(t, testutil.WaitShort)
+		var stdout strings.Builder
+		inv.Stdout = &stdout
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err, "failed to fetch logs for build number argument")
+		assertLogOutput(t, wb1, stdout.String())
+		assertAntagonist(t, wb2, stdout.String())
+	})
+
+	t.Run("build number argument and flag", func(t *testing.T) {
+		t.Parallel()
+
+		inv, root := clitest.New(t, "logs", wb1.Workspace.Name, "1", "-n", "1")
+		clitest.SetupConfig(t, memberClient, root)
+		ctx := testutil.Context(t, testutil.WaitShort)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "cannot be set both")
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		wb := testWorkspace(t, db, memberUser.ID, owner.OrganizationID)
+		_ = dbgen.ProvisionerJobLog(t, db, database.ProvisionerJobLog{
+			JobID:  wb.Build.JobID,
+			Stage:  "Applying",
+			Level:  database.LogLevelError,
+			Output: "failed to pull image",
+		})
+		for _, agt := range wb.Agents {
+			_ = dbgen.WorkspaceAgentLog(t, db, database.WorkspaceAgentLog{
+				AgentID: agt.ID,
+				Level:   database.LogLevelWarn,
+				Output:  "startup script exited with code 1",
+			})
+		}
+
+		for _, tc := range []struct {
+			name     string
+			args     []string
+			contains []string
+			excludes []string
+		}{
+			{
+				name:     "level",
+				args:     []string{"--level", "warn"},
+				contains: []string{"failed to pull image", "startup script exited with code 1"},
+				excludes: []string{"test provisioner log for build", "test agent log for agent"},
+			},
+			{
+				name:     "stage",
+				args:     []string{"--stage", "Applying"},
+				contains: []string{"failed to pull image"},
+				excludes: []string{"test provisioner log for build", "startup script exited with code 1"},
+			},
+			{
+				name:     "grep",
+				args:     []string{"--grep", "STARTUP SCRIPT"},
+				contains: []string{"startup script exited with code 1"},
+				excludes: []string{"failed to pull image", "test agent log for agent"},
+			},
+			{
+				name:     "since",
+				args:     []string{"--since", time.Now().Add(time.Hour).Format(time.RFC3339)},
+				excludes: []string{"failed to pull image", "startup script exited with code 1"},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				inv, root := clitest.New(t, append([]string{"logs", wb.Workspace.Name}, tc.args...)...)
+				clitest.SetupConfig(t, memberClient, root)
+				ctx := testutil.Context(t, testutil.WaitShort)
+				var stdout strings.Builder
+				inv.Stdout = &stdout
+				err := inv.WithContext(ctx).Run()
+				require.NoError(t, err)
+				for _, c := range tc.contains {
+					require.Contains(t, stdout.String(), c)
+				}
+				for _, e := range tc.excludes {
+					require.NotContains(t, stdout.String(), e)
+				}
+			})
+		}
+	})
+
+	t.Run("agent log source", func(t *testing.T) {
+		t.Parallel()
+
+		wb := testWorkspace(t, db, memberUser.ID, owner.OrganizationID)
+		agt := wb.Agents[0]
+		startup := dbgen.WorkspaceAgentLogSource(t, db, database.WorkspaceAgentLogSource{
+			WorkspaceAgentID: agt.ID,
+			DisplayName:      "Startup Script",
+		})
+		dotfiles := dbgen.WorkspaceAgentLogSource(t, db, database.WorkspaceAgentLogSource{
+			WorkspaceAgentID: agt.ID,
+			DisplayName:      "Dotfiles",
+		})
+		_ = dbgen.WorkspaceAgentLog(t, db, database.WorkspaceAgentLog{
+			AgentID:     agt.ID,
+			LogSourceID: startup.ID,
+			Output:      "cloning repository",
+		})
+		_ = dbgen.WorkspaceAgentLog(t, db, database.WorkspaceAgentLog{
+			AgentID:     agt.ID,
+			LogSourceID: dotfiles.ID,
+			Output:      "installing dotfiles",
+		})
+
+		inv, root := clitest.New(t, "logs", wb.Workspace.Name, "--agent", agt.Name, "--source", "Startup Script")
+		clitest.SetupConfig(t, memberClient, root)
+		ctx := testutil.Context(t, testutil.WaitShort)
+		var stdout strings.Builder
+		inv.Stdout = &stdout
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, stdout.String(), "[agent."+agt.Name+"|Startup Script] cloning repository")
+		require.NotContains(t, stdout.String(), "installing dotfiles")
+		require.NotContains(t, stdout.String(), "test provisioner log for build")
+
+		inv, root = clitest.New(t, "logs", wb.Workspace.Name, "--source", "Does Not Exist")
+		clitest.SetupConfig(t, memberClient, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `log source "Does Not Exist" not found`)
+
+		inv, root = clitest.New(t, "logs", wb.Workspace.Name, "--agent", "nope")
+		clitest.SetupConfig(t, memberClient, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `agent "nope" not found`)
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		t.Parallel()
+
+		inv, root := clitest.New(t, "logs", wb1.Workspace.Name, "--output", "json")
+		clitest.SetupConfig(t, memberClient, root)
+		ctx := testutil.Context(t, testutil.WaitShort)
+		var stdout strings.Builder
+		inv.Stdout = &stdout
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		sources := map[string]bool{}
+		for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+			var entry struct {
+				Source string `json:"source"`
+				Output string `json:"output"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry), "line %q is not JSON", line)
+			sources[entry.Source] = true
+		}
+		require.Equal(t, map[string]bool{"provisioner": true, "agent": true}, sources)
+	})
+
 	t.Run("build out of range", func(t *testing.T) {
 		t.Parallel()
 
@@ -111,10 +111,13 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 
 		// Workspace Commands
 		r.autoupdate(),
+		r.clone(),
 		r.configSSH(),
+		r.cp(),
 		r.Create(CreateOptions{}),
 		r.deleteWorkspace(),
 		r.favorite(),
+		r.fileSync(),
 		r.list(),
 		r.logs(),
 		r.open(),
@@ -122,6 +125,7 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		r.rename(),
 		r.restart(),
 		r.schedules(),
+		r.sessionRecordings(),
 		r.show(),
 		r.speedtest(),
 		r.ssh(),
@@ -131,6 +135,7 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		r.unfavorite(),
 		r.update(),
 		r.whoami(),
+		r.workspaces(),
 
 		// Hidden
 		r.connectCmd(),
@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/asciicast"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/pretty"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) sessionRecordings() *serpent.Command {
+	return &serpent.Command{
+		Use:   "recordings",
+		Short: "List, download and replay recordings of workspace terminal sessions",
+		Long:  "Templates can record the interactive terminal sessions of their workspaces, see `coder templates edit --session-recording`. Recordings are stored in the asciicast v2 format and can be read by anyone allowed to read connection logs.",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.sessionRecordingsList(),
+			r.sessionRecordingsDownload(),
+			r.sessionRecordingsReplay(),
+		},
+	}
+}
+
+type sessionRecordingRow struct {
+	// For JSON format:
+	codersdk.WorkspaceSessionRecording `table:"-"`
+
+	// For table format:
+	ID           string        `json:"-" table:"id"`
+	ConnectionID string        `json:"-" table:"connection id"`
+	Type         string        `json:"-" table:"type"`
+	StartedAt    time.Time     `json:"-" table:"started at,default_sort"`
+	Duration     time.Duration `json:"-" table:"duration"`
+	Size         string        `json:"-" table:"size"`
+	Input        bool          `json:"-" table:"input"`
+	Truncated    bool          `json:"-" table:"truncated"`
+}
+
+func (r *RootCmd) sessionRecordingsList() *serpent.Command {
+	var (
+		limit     int64
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]sessionRecordingRow{}, []string{"id", "type", "started at", "duration", "size", "truncated"}),
+			cliui.JSONFormat(),
+		)
+	)
+
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Short:   "List the session recordings of a workspace, most recent first",
+		Aliases: []string{"ls"},
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+
+			recordings, err := client.WorkspaceSessionRecordings(inv.Context(), workspace.ID, codersdk.Pagination{
+				Limit: int(limit),
+			})
+			if err != nil {
+				return xerrors.Errorf("list session recordings: %w", err)
+			}
+			if len(recordings) == 0 && formatter.FormatID() == "table" {
+				cliui.Info(inv.Stderr, "No session recordings found for "+workspace.Name+".")
+				return nil
+			}
+
+			rows := make([]sessionRecordingRow, 0, len(recordings))
+			for _, rec := range recordings {
+				rows = append(rows, sessionRecordingRow{
+					WorkspaceSessionRecording: rec,
+					ID:                        rec.ID.String(),
+					ConnectionID:              rec.ConnectionID.String(),
+					Type:                      string(rec.Type),
+					StartedAt:                 rec.StartedAt,
+					Duration:                  rec.EndedAt.Sub(rec.StartedAt).Round(time.Second),
+					Size:                      humanize.IBytes(uint64(rec.Size)), //nolint:gosec // Sizes are never negative.
+					Input:                     rec.IncludeInput,
+					Truncated:                 rec.Truncated,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+		Options: serpent.OptionSet{
+			{
+				Flag:          "limit",
+				FlagShorthand: "n",
+				Description:   "Maximum number of recordings to list. Zero lists all of them.",
+				Default:       "25",
+				Value:         serpent.Int64Of(&limit),
+			},
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) sessionRecordingsDownload() *serpent.Command {
+	var output string
+
+	cmd := &serpent.Command{
+		Use:   "download <workspace> <recording-id>",
+		Short: "Download a session recording as an asciicast v2 file",
+		Long: FormatExamples(
+			Example{
+				Description: "Download a recording and play it with asciinema",
+				Command:     "coder recordings download my-workspace 6f1c8e1a-8a5e-4b0e-9f3b-8d6a3e1c2b4d -o session.cast && asciinema play session.cast",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			workspace, recordingID, err := sessionRecordingArgs(inv, client)
+			if err != nil {
+				return err
+			}
+
+			rc, err := client.WorkspaceSessionRecording(inv.Context(), workspace.ID, recordingID)
+			if err != nil {
+				return xerrors.Errorf("download session recording: %w", err)
+			}
+			defer rc.Close()
+
+			if output == "-" {
+				_, err = io.Copy(inv.Stdout, rc)
+				return err
+			}
+			if output == "" {
+				output = recordingID.String() + ".cast"
+			}
+			f, err := os.Create(output)
+			if err != nil {
+				return xerrors.Errorf("create output file: %w", err)
+			}
+			defer f.Close()
+			n, err := io.Copy(f, rc)
+			if err != nil {
+				return xerrors.Errorf("write output file: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return xerrors.Errorf("close output file: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stderr, "Saved %s to %s\n", humanize.IBytes(uint64(n)), pretty.Sprint(cliui.DefaultStyles.Code, output))
+			return nil
+		},
+		Options: serpent.OptionSet{
+			{
+				Flag:          "output",
+				FlagShorthand: "o",
+				Description:   "Path to write the recording to, or \"-\" for stdout. Defaults to <recording-id>.cast.",
+				Value:         serpent.StringOf(&output),
+			},
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) sessionRecordingsReplay() *serpent.Command {
+	var (
+		file    string
+		speed   float64
+		maxIdle time.Duration
+		instant bool
+	)
+
+	cmd := &serpent.Command{
+		Use:   "replay [<workspace> <recording-id>]",
+		Short: "Replay a session recording in the terminal",
+		Long: FormatExamples(
+			Example{
+				Description: "Replay a recording at twice the speed, skipping long pauses",
+				Command:     "coder recordings replay my-workspace 6f1c8e1a-8a5e-4b0e-9f3b-8d6a3e1c2b4d --speed 2 --max-idle 1s",
+			},
+			Example{
+				Description: "Replay a downloaded recording",
+				Command:     "coder recordings replay --file session.cast",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireRangeArgs(0, 2),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			var rc io.ReadCloser
+			switch {
+			case file != "" && len(inv.Args) == 0:
+				f, err := os.Open(file)
+				if err != nil {
+					return xerrors.Errorf("open recording: %w", err)
+				}
+				rc = f
+			case file == "" && len(inv.Args) == 2:
+				client, err := r.InitClient(inv)
+				if err != nil {
+					return err
+				}
+				workspace, recordingID, err := sessionRecordingArgs(inv, client)
+				if err != nil {
+					return err
+				}
+				rc, err = client.WorkspaceSessionRecording(inv.Context(), workspace.ID, recordingID)
+				if err != nil {
+					return xerrors.Errorf("download session recording: %w", err)
+				}
+			default:
+				return xerrors.New("specify either a workspace and recording ID, or --file")
+			}
+			defer rc.Close()
+
+			return asciicast.Replay(inv.Context(), rc, inv.Stdout, asciicast.ReplayOptions{
+				Speed:   speed,
+				MaxIdle: maxIdle,
+				Instant: instant,
+			})
+		},
+		Options: serpent.OptionSet{
+			{
+				Flag:        "file",
+				Description: "Replay a local asciicast file instead of downloading a recording.",
+				Value:       serpent.StringOf(&file),
+			},
+			{
+				Flag:        "speed",
+				Description: "Playback speed multiplier.",
+				Default:     "1",
+				Value:       serpent.Float64Of(&speed),
+			},
+			{
+				Flag:        "max-idle",
+				Description: "Maximum time to wait between two events. Zero waits as long as the session did.",
+				Default:     "0s",
+				Value:       serpent.DurationOf(&maxIdle),
+			},
+			{
+				Flag:        "instant",
+				Description: "Print the whole recording without waiting between events.",
+				Value:       serpent.BoolOf(&instant),
+			},
+		},
+	}
+	return cmd
+}
+
+// sessionRecordingArgs resolves the workspace and recording ID arguments.
+func sessionRecordingArgs(inv *serpent.Invocation, client *codersdk.Client) (codersdk.Workspace, uuid.UUID, error) {
+	recordingID, err := uuid.Parse(inv.Args[1])
+	if err != nil {
+		return codersdk.Workspace{}, uuid.Nil, xerrors.Errorf("invalid recording ID %q: %w", inv.Args[1], err)
+	}
+	workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+	if err != nil {
+		return codersdk.Workspace{}, uuid.Nil, xerrors.Errorf("get workspace: %w", err)
+	}
+	return workspace, recordingID, nil
+}
@@ -0,0 +1,88 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+const testRecording = `{"version":2,"width":80,"height":24}
+[0.1,"o","$ "]
+[0.5,"i","echo hi\r"]
+[0.6,"o","echo hi\r\nhi\r\n"]
+`
+
+func TestSessionRecordings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReplayFile", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(t.TempDir(), "session.cast")
+		require.NoError(t, os.WriteFile(file, []byte(testRecording), 0o600))
+
+		inv, _ := clitest.New(t, "recordings", "replay", "--file", file, "--instant")
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.WithContext(testutil.Context(t, testutil.WaitShort)).Run()
+		require.NoError(t, err)
+		// Input is not replayed, only what the terminal showed.
+		require.Equal(t, "$ echo hi\r\nhi\r\n", out.String())
+	})
+
+	t.Run("ReplayArgs", func(t *testing.T) {
+		t.Parallel()
+
+		inv, _ := clitest.New(t, "recordings", "replay")
+		err := inv.Run()
+		require.ErrorContains(t, err, "specify either a workspace and recording ID, or --file")
+	})
+
+	t.Run("ListAndDownload", func(t *testing.T) {
+		t.Parallel()
+
+		client, db := coderdtest.NewWithDatabase(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		r := dbfake.WorkspaceBuild(t, db, database.WorkspaceTable{
+			OrganizationID: owner.OrganizationID,
+			OwnerID:        owner.UserID,
+		}).WithAgent().Do()
+		recording := dbgen.WorkspaceSessionRecording(t, db, database.WorkspaceSessionRecording{
+			OrganizationID: owner.OrganizationID,
+			WorkspaceID:    r.Workspace.ID,
+			AgentID:        r.Agents[0].ID,
+		}, []byte(testRecording))
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "recordings", "list", r.Workspace.Name, "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		var recordings []codersdk.WorkspaceSessionRecording
+		require.NoError(t, json.Unmarshal(out.Bytes(), &recordings))
+		require.Len(t, recordings, 1)
+		require.Equal(t, recording.ID, recordings[0].ID)
+
+		file := filepath.Join(t.TempDir(), "session.cast")
+		inv, root = clitest.New(t, "recordings", "download", r.Workspace.Name, recording.ID.String(), "-o", file)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, testRecording, string(data))
+	})
+}
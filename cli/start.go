@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -18,7 +19,8 @@ func (r *RootCmd) start() *serpent.Command {
 		parameterFlags workspaceParameterFlags
 		bflags         buildFlags
 
-		noWait bool
+		noWait  bool
+		preview bool
 	)
 
 	cmd := &serpent.Command{
@@ -35,6 +37,11 @@ func (r *RootCmd) start() *serpent.Command {
 				Value:       serpent.BoolOf(&noWait),
 				Hidden:      false,
 			},
+			{
+				Flag:        "preview",
+				Description: "Plan the start without building the workspace and show the changes it would make to the workspace resources.",
+				Value:       serpent.BoolOf(&preview),
+			},
 			cliui.SkipPromptOption(),
 		},
 		Handler: func(inv *serpent.Invocation) error {
@@ -47,6 +54,9 @@ func (r *RootCmd) start() *serpent.Command {
 			if err != nil {
 				return err
 			}
+			if preview {
+				return previewWorkspaceBuild(inv, client, workspace, parameterFlags, bflags, WorkspaceStart)
+			}
 			var build codersdk.WorkspaceBuild
 			switch workspace.LatestBuild.Status {
 			case codersdk.WorkspaceStatusPending:
@@ -203,3 +213,50 @@ func startWorkspace(inv *serpent.Invocation, client *codersdk.Client, workspace
 
 	return build, nil
 }
+
+// previewWorkspaceBuild plans a start of the workspace with the same version
+// and parameters a real build would use, and displays the changes the build
+// would make to the workspace resources. No build is created.
+func previewWorkspaceBuild(inv *serpent.Invocation, client *codersdk.Client, workspace codersdk.Workspace, parameterFlags workspaceParameterFlags, buildFlags buildFlags, action WorkspaceCLIAction) error {
+	req, err := buildWorkspaceStartRequest(inv, client, workspace, parameterFlags, buildFlags, action)
+	if err != nil {
+		return err
+	}
+
+	job, err := client.CreateWorkspaceDryRun(inv.Context(), workspace.ID, codersdk.CreateWorkspaceDryRunRequest{
+		TemplateVersionID:   req.TemplateVersionID,
+		RichParameterValues: req.RichParameterValues,
+	})
+	if err != nil {
+		return xerrors.Errorf("begin workspace build preview: %w", err)
+	}
+	versionID := req.TemplateVersionID
+	if job.Input.TemplateVersionID != nil {
+		versionID = *job.Input.TemplateVersionID
+	}
+
+	_, _ = fmt.Fprintf(inv.Stdout, "Planning build of the %s workspace...\n", cliui.Keyword(workspace.Name))
+	err = cliui.ProvisionerJob(inv.Context(), inv.Stdout, cliui.ProvisionerJobOptions{
+		Fetch: func() (codersdk.ProvisionerJob, error) {
+			return client.TemplateVersionDryRun(inv.Context(), versionID, job.ID)
+		},
+		Cancel: func() error {
+			return client.CancelTemplateVersionDryRun(inv.Context(), versionID, job.ID)
+		},
+		Logs: func() (<-chan codersdk.ProvisionerJobLog, io.Closer, error) {
+			return client.TemplateVersionDryRunLogsAfter(inv.Context(), versionID, job.ID, 0)
+		},
+		// Don't show log output for the plan unless there's an error.
+		Silent: true,
+	})
+	if err != nil {
+		return xerrors.Errorf("plan workspace build: %w", err)
+	}
+
+	changes, err := client.TemplateVersionDryRunResourceChanges(inv.Context(), versionID, job.ID)
+	if err != nil {
+		return xerrors.Errorf("get planned resource changes: %w", err)
+	}
+	cliui.WorkspaceResourceChanges(inv.Stdout, changes)
+	return nil
+}
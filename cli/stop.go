@@ -11,7 +11,10 @@ import (
 )
 
 func (r *RootCmd) stop() *serpent.Command {
-	var bflags buildFlags
+	var (
+		bflags    buildFlags
+		hibernate bool
+	)
 	cmd := &serpent.Command{
 		Annotations: workspaceCommand,
 		Use:         "stop <workspace>",
@@ -20,6 +23,12 @@ func (r *RootCmd) stop() *serpent.Command {
 			serpent.RequireNArgs(1),
 		),
 		Options: serpent.OptionSet{
+			{
+				Flag: "hibernate",
+				Description: "Hibernate the workspace instead of stopping it. Compute is released while persistent resources are retained, " +
+					"provided the template distinguishes the \"hibernate\" transition. Otherwise the workspace is stopped.",
+				Value: serpent.BoolOf(&hibernate),
+			},
 			cliui.SkipPromptOption(),
 		},
 		Handler: func(inv *serpent.Invocation) error {
@@ -28,8 +37,13 @@ func (r *RootCmd) stop() *serpent.Command {
 				return err
 			}
 
+			transition, verb, done := codersdk.WorkspaceTransitionStop, "stop", "stopped"
+			if hibernate {
+				transition, verb, done = codersdk.WorkspaceTransitionHibernate, "hibernate", "hibernated"
+			}
+
 			_, err = cliui.Prompt(inv, cliui.PromptOptions{
-				Text:      "Confirm stop workspace?",
+				Text:      fmt.Sprintf("Confirm %s workspace?", verb),
 				IsConfirm: true,
 			})
 			if err != nil {
@@ -41,7 +55,7 @@ func (r *RootCmd) stop() *serpent.Command {
 				return err
 			}
 
-			build, err := stopWorkspace(inv, client, workspace, bflags)
+			build, err := stopWorkspace(inv, client, workspace, transition, bflags)
 			if err != nil {
 				return err
 			}
@@ -53,8 +67,9 @@ func (r *RootCmd) stop() *serpent.Command {
 
 			_, _ = fmt.Fprintf(
 				inv.Stdout,
-				"\nThe %s workspace has been stopped at %s!\n",
+				"\nThe %s workspace has been %s at %s!\n",
 				cliui.Keyword(workspace.Name),
+				done,
 				cliui.Timestamp(time.Now()),
 			)
 			return nil
@@ -65,7 +80,7 @@ func (r *RootCmd) stop() *serpent.Command {
 	return cmd
 }
 
-func stopWorkspace(inv *serpent.Invocation, client *codersdk.Client, workspace codersdk.Workspace, bflags buildFlags) (codersdk.WorkspaceBuild, error) {
+func stopWorkspace(inv *serpent.Invocation, client *codersdk.Client, workspace codersdk.Workspace, transition codersdk.WorkspaceTransition, bflags buildFlags) (codersdk.WorkspaceBuild, error) {
 	if workspace.LatestBuild.Job.Status == codersdk.ProvisionerJobPending {
 		// cliutil.WarnMatchedProvisioners also checks if the job is pending
 		// but we still want to avoid users spamming multiple builds that will
@@ -81,7 +96,7 @@ func stopWorkspace(inv *serpent.Invocation, client *codersdk.Client, workspace c
 		}
 	}
 	wbr := codersdk.CreateWorkspaceBuildRequest{
-		Transition: codersdk.WorkspaceTransitionStop,
+		Transition: transition,
 	}
 	if bflags.provisionerLogDebug {
 		wbr.LogLevel = codersdk.ProvisionerLogLevelDebug
@@ -19,6 +19,8 @@ func (r *RootCmd) syncCommand() *serpent.Command {
 			r.syncStart(&socketPath),
 			r.syncWant(&socketPath),
 			r.syncComplete(&socketPath),
+			r.syncFail(&socketPath),
+			r.syncReset(&socketPath),
 			r.syncStatus(&socketPath),
 		},
 		Options: serpent.OptionSet{
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/agent/agentsocket"
+	"github.com/coder/coder/v2/agent/unit"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/serpent"
+)
+
+func (*RootCmd) syncFail(socketPath *string) *serpent.Command {
+	var reason string
+
+	cmd := &serpent.Command{
+		Use:   "fail <unit>",
+		Short: "Mark a unit as failed",
+		Long:  "Mark a unit as failed. Units that depend on it, directly or transitively, are marked as blocked instead of waiting forever, and the failure is reported in the workspace agent's startup logs.",
+		Handler: func(i *serpent.Invocation) error {
+			ctx := i.Context()
+
+			if len(i.Args) != 1 {
+				return xerrors.New("exactly one unit name is required")
+			}
+			unit := unit.ID(i.Args[0])
+
+			opts := []agentsocket.Option{}
+			if *socketPath != "" {
+				opts = append(opts, agentsocket.WithPath(*socketPath))
+			}
+
+			client, err := agentsocket.NewClient(ctx, opts...)
+			if err != nil {
+				return xerrors.Errorf("connect to agent socket: %w", err)
+			}
+			defer client.Close()
+
+			if err := client.SyncFail(ctx, unit, reason); err != nil {
+				return xerrors.Errorf("mark unit as failed: %w", err)
+			}
+
+			cliui.Info(i.Stdout, "Success")
+
+			return nil
+		},
+	}
+
+	cmd.Options = append(cmd.Options, serpent.Option{
+		Flag:        "reason",
+		Description: "A short description of why the unit failed.",
+		Value:       serpent.StringOf(&reason),
+	})
+
+	return cmd
+}
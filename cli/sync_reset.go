@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/agent/agentsocket"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/serpent"
+)
+
+func (*RootCmd) syncReset(socketPath *string) *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "reset",
+		Short: "Remove all units and dependencies",
+		Long:  "Remove all units and their dependencies from the agent, including any state persisted across agent restarts. Units that are currently waiting for dependencies will no longer be able to start.",
+		Handler: func(i *serpent.Invocation) error {
+			ctx := i.Context()
+
+			if len(i.Args) != 0 {
+				return xerrors.New("no arguments are expected")
+			}
+
+			opts := []agentsocket.Option{}
+			if *socketPath != "" {
+				opts = append(opts, agentsocket.WithPath(*socketPath))
+			}
+
+			client, err := agentsocket.NewClient(ctx, opts...)
+			if err != nil {
+				return xerrors.Errorf("connect to agent socket: %w", err)
+			}
+			defer client.Close()
+
+			if err := client.SyncReset(ctx); err != nil {
+				return xerrors.Errorf("reset units failed: %w", err)
+			}
+
+			cliui.Info(i.Stdout, "Success")
+
+			return nil
+		},
+	}
+
+	return cmd
+}
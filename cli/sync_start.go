@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"golang.org/x/xerrors"
@@ -48,9 +49,9 @@ func (*RootCmd) syncStart(socketPath *string) *serpent.Command {
 			}
 			defer client.Close()
 
-			ready, err := client.SyncReady(ctx, unitName)
+			ready, err := checkUnitReady(ctx, client, unitName)
 			if err != nil {
-				return xerrors.Errorf("error checking dependencies: %w", err)
+				return err
 			}
 
 			if !ready {
@@ -64,13 +65,22 @@ func (*RootCmd) syncStart(socketPath *string) *serpent.Command {
 					select {
 					case <-ctx.Done():
 						if ctx.Err() == context.DeadlineExceeded {
+							// Report the failure so that units depending on this
+							// one are blocked instead of waiting forever. The
+							// command context has expired, so use a fresh one.
+							failCtx, failCancel := context.WithTimeout(context.WithoutCancel(ctx), syncPollInterval)
+							defer failCancel()
+							reason := fmt.Sprintf("timed out after %s waiting for dependencies", timeout)
+							if err := client.SyncFail(failCtx, unitName, reason); err != nil {
+								cliui.Warnf(i.Stderr, "Failed to report failure of unit '%s': %v", unitName, err)
+							}
 							return xerrors.Errorf("timeout waiting for dependencies of unit '%s'", unitName)
 						}
 						return ctx.Err()
 					case <-ticker.C:
-						ready, err := client.SyncReady(ctx, unitName)
+						ready, err := checkUnitReady(ctx, client, unitName)
 						if err != nil {
-							return xerrors.Errorf("error checking dependencies: %w", err)
+							return err
 						}
 						if ready {
 							break pollLoop
@@ -98,3 +108,22 @@ func (*RootCmd) syncStart(socketPath *string) *serpent.Command {
 
 	return cmd
 }
+
+// checkUnitReady reports whether the dependencies of a unit are satisfied. It
+// returns an error if the unit has failed or is blocked by a failed dependency,
+// since it will never become ready in that case.
+func checkUnitReady(ctx context.Context, client *agentsocket.Client, unitName unit.ID) (bool, error) {
+	status, err := client.SyncStatus(ctx, unitName)
+	if err != nil {
+		return false, xerrors.Errorf("error checking dependencies: %w", err)
+	}
+
+	switch status.Status {
+	case unit.StatusFailed:
+		return false, xerrors.Errorf("unit '%s' has failed: %s", unitName, status.FailureReason)
+	case unit.StatusBlocked:
+		return false, xerrors.Errorf("unit '%s' is blocked by failed unit '%s'", unitName, status.BlockedBy)
+	}
+
+	return status.IsReady, nil
+}
@@ -12,6 +12,8 @@ import (
 )
 
 func (*RootCmd) syncStatus(socketPath *string) *serpent.Command {
+	var graph bool
+
 	formatter := cliui.NewOutputFormatter(
 		cliui.ChangeFormatterData(
 			cliui.TableFormat(
@@ -34,16 +36,18 @@ func (*RootCmd) syncStatus(socketPath *string) *serpent.Command {
 	)
 
 	cmd := &serpent.Command{
-		Use:   "status <unit>",
+		Use:   "status [<unit>]",
 		Short: "Show unit status and dependency state",
-		Long:  "Show the current status of a unit, whether it is ready to start, and lists its dependencies. Shows which dependencies are satisfied and which are still pending. Supports multiple output formats.",
+		Long:  "Show the current status of a unit, whether it is ready to start, and lists its dependencies. Shows which dependencies are satisfied and which are still pending. Supports multiple output formats. With --graph, prints the dependency graph of all units in DOT format instead.",
 		Handler: func(i *serpent.Invocation) error {
 			ctx := i.Context()
 
-			if len(i.Args) != 1 {
+			if graph && len(i.Args) != 0 {
+				return xerrors.New("no unit name may be given with --graph")
+			}
+			if !graph && len(i.Args) != 1 {
 				return xerrors.New("exactly one unit name is required")
 			}
-			unit := unit.ID(i.Args[0])
 
 			opts := []agentsocket.Option{}
 			if *socketPath != "" {
@@ -56,13 +60,31 @@ func (*RootCmd) syncStatus(socketPath *string) *serpent.Command {
 			}
 			defer client.Close()
 
+			if graph {
+				dot, err := client.SyncGraph(ctx)
+				if err != nil {
+					return xerrors.Errorf("get graph failed: %w", err)
+				}
+				_, _ = fmt.Fprintln(i.Stdout, dot)
+				return nil
+			}
+
+			unit := unit.ID(i.Args[0])
+
 			statusResp, err := client.SyncStatus(ctx, unit)
 			if err != nil {
 				return xerrors.Errorf("get status failed: %w", err)
 			}
 
 			var out string
-			header := fmt.Sprintf("Unit: %s\nStatus: %s\nReady: %t\n\nDependencies:\n", unit, statusResp.Status, statusResp.IsReady)
+			header := fmt.Sprintf("Unit: %s\nStatus: %s\n", unit, statusResp.Status)
+			if statusResp.FailureReason != "" {
+				header += fmt.Sprintf("Failure reason: %s\n", statusResp.FailureReason)
+			}
+			if statusResp.BlockedBy != "" {
+				header += fmt.Sprintf("Blocked by: %s\n", statusResp.BlockedBy)
+			}
+			header += fmt.Sprintf("Ready: %t\n\nDependencies:\n", statusResp.IsReady)
 			if formatter.FormatID() == "table" && len(statusResp.Dependencies) == 0 {
 				out = header + "No dependencies found"
 			} else {
@@ -82,6 +104,11 @@ func (*RootCmd) syncStatus(socketPath *string) *serpent.Command {
 		},
 	}
 
+	cmd.Options = append(cmd.Options, serpent.Option{
+		Flag:        "graph",
+		Description: "Print the dependency graph of all units in DOT format, for example to render with Graphviz.",
+		Value:       serpent.BoolOf(&graph),
+	})
 	formatter.AttachOptions(&cmd.Options)
 	return cmd
 }
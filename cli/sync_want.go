@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"time"
+
 	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/agent/agentsocket"
@@ -10,6 +12,8 @@ import (
 )
 
 func (*RootCmd) syncWant(socketPath *string) *serpent.Command {
+	var timeout time.Duration
+
 	cmd := &serpent.Command{
 		Use:   "want <unit> <depends-on>",
 		Short: "Declare that a unit depends on another unit completing before it can start",
@@ -34,7 +38,7 @@ func (*RootCmd) syncWant(socketPath *string) *serpent.Command {
 			}
 			defer client.Close()
 
-			if err := client.SyncWant(ctx, dependentUnit, dependsOn); err != nil {
+			if err := client.SyncWantWithTimeout(ctx, dependentUnit, dependsOn, timeout); err != nil {
 				return xerrors.Errorf("declare dependency failed: %w", err)
 			}
 
@@ -44,5 +48,11 @@ func (*RootCmd) syncWant(socketPath *string) *serpent.Command {
 		},
 	}
 
+	cmd.Options = append(cmd.Options, serpent.Option{
+		Flag:        "timeout",
+		Description: "Mark the unit as failed if it has not completed within this duration (e.g., 30s, 5m). Units that depend on it become blocked. Disabled by default.",
+		Value:       serpent.DurationOf(&timeout),
+	})
+
 	return cmd
 }
@@ -34,6 +34,10 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 		requireActiveVersion           bool
 		deprecationMessage             string
 		disableEveryone                bool
+		sessionRecording               bool
+		sessionRecordingIncludeInput   bool
+		maintenanceWindowSchedule      string
+		hibernateIdleThreshold         time.Duration
 		orgContext                     = NewOrganizationContext()
 	)
 
@@ -168,6 +172,25 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				disableEveryoneGroup = disableEveryone
 			}
 
+			var sessionRecordingEnabled, sessionRecordingInput *bool
+			if userSetOption(inv, "session-recording") {
+				sessionRecordingEnabled = &sessionRecording
+			}
+			if userSetOption(inv, "session-recording-include-input") {
+				sessionRecordingInput = &sessionRecordingIncludeInput
+			}
+
+			var maintenanceWindow *string
+			if userSetOption(inv, "maintenance-window") {
+				maintenanceWindow = &maintenanceWindowSchedule
+			}
+
+			var hibernateIdleThresholdMillis *int64
+			if userSetOption(inv, "hibernate-idle-threshold") {
+				millis := hibernateIdleThreshold.Milliseconds()
+				hibernateIdleThresholdMillis = &millis
+			}
+
 			req := codersdk.UpdateTemplateMeta{
 				Name:               name,
 				DisplayName:        &displayName,
@@ -191,6 +214,10 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				RequireActiveVersion:           requireActiveVersion,
 				DeprecationMessage:             deprecated,
 				DisableEveryoneGroupAccess:     disableEveryoneGroup,
+				SessionRecordingEnabled:        sessionRecordingEnabled,
+				SessionRecordingIncludeInput:   sessionRecordingInput,
+				MaintenanceWindowSchedule:      maintenanceWindow,
+				HibernateIdleThresholdMillis:   hibernateIdleThresholdMillis,
 			}
 
 			_, err = client.UpdateTemplateMeta(inv.Context(), template.ID, req)
@@ -303,6 +330,31 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 			Value:   serpent.BoolOf(&disableEveryone),
 			Default: "false",
 		},
+		{
+			Flag: "session-recording",
+			Description: "Record interactive terminal sessions, such as SSH and reconnecting PTY sessions, in workspaces of this template. " +
+				"Recordings can be listed and replayed with \"coder recordings\".",
+			Value:   serpent.BoolOf(&sessionRecording),
+			Default: "false",
+		},
+		{
+			Flag:        "session-recording-include-input",
+			Description: "Include the input typed during a session in session recordings. Input may contain secrets such as passwords.",
+			Value:       serpent.BoolOf(&sessionRecordingIncludeInput),
+			Default:     "false",
+		},
+		{
+			Flag: "maintenance-window",
+			Description: "A cron time range during which idle workspaces running an outdated template version are restarted onto the active version, " +
+				"e.g. \"CRON_TZ=Europe/Berlin * 2-4 * * 6\" for Saturdays from 02:00 to 04:59. Pass an empty string to disable the maintenance window.",
+			Value: serpent.StringOf(&maintenanceWindowSchedule),
+		},
+		{
+			Flag: "hibernate-idle-threshold",
+			Description: "Hibernate instead of stop workspaces that reach their autostop deadline after being inactive for less than this duration. " +
+				"Templates implement hibernation through the \"hibernate\" transition of the coder_workspace data source. Pass 0h to always stop workspaces.",
+			Value: serpent.DurationOf(&hibernateIdleThreshold),
+		},
 		cliui.SkipPromptOption(),
 	}
 	orgContext.AttachOptions(cmd)
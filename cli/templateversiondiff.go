@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) templateVersionsDiff() *serpent.Command {
+	orgContext := NewOrganizationContext()
+	formatter := cliui.NewOutputFormatter(
+		cliui.ChangeFormatterData(cliui.TextFormat(), func(data any) (any, error) {
+			diff, ok := data.(codersdk.TemplateVersionDiff)
+			if !ok {
+				return nil, xerrors.Errorf("expected type %T, got %T", diff, data)
+			}
+			return formatTemplateVersionDiff(diff), nil
+		}),
+		cliui.JSONFormat(),
+	)
+	cmd := &serpent.Command{
+		Use:   "diff <template> <from> <to>",
+		Short: "Show the changes between two versions of a template",
+		Long: "Compares the source files, parameters, variables and the resources " +
+			"planned on import of two template versions. Source files are shown as " +
+			"a unified diff.\n\n" + FormatExamples(
+			Example{
+				Description: "Show what changed between two versions of a template",
+				Command:     "coder templates versions diff my-template v1 v2",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(3),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+			organization, err := orgContext.Selected(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+			template, err := client.TemplateByName(ctx, organization.ID, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get template by name: %w", err)
+			}
+			from, err := client.TemplateVersionByName(ctx, template.ID, inv.Args[1])
+			if err != nil {
+				return xerrors.Errorf("get template version %q: %w", inv.Args[1], err)
+			}
+			to, err := client.TemplateVersionByName(ctx, template.ID, inv.Args[2])
+			if err != nil {
+				return xerrors.Errorf("get template version %q: %w", inv.Args[2], err)
+			}
+
+			diff, err := client.TemplateVersionDiff(ctx, from.ID, to.ID)
+			if err != nil {
+				return xerrors.Errorf("diff template versions: %w", err)
+			}
+			out, err := formatter.Format(ctx, diff)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+// formatTemplateVersionDiff renders the source file changes as a unified diff,
+// followed by a summary of the changed parameters, variables and resources.
+func formatTemplateVersionDiff(diff codersdk.TemplateVersionDiff) string {
+	var sb strings.Builder
+	for _, file := range diff.Files {
+		if file.Binary {
+			from, to := "a/"+file.Path, "b/"+file.Path
+			switch file.Change {
+			case codersdk.TemplateVersionDiffChangeAdded:
+				from = "/dev/null"
+			case codersdk.TemplateVersionDiffChangeRemoved:
+				to = "/dev/null"
+			}
+			_, _ = fmt.Fprintf(&sb, "Binary files %s and %s differ\n", from, to)
+			continue
+		}
+		_, _ = sb.WriteString(file.Diff)
+	}
+
+	if len(diff.Parameters) > 0 {
+		writeTemplateVersionDiffSection(&sb, "Parameters")
+		for _, p := range diff.Parameters {
+			writeTemplateVersionItemDiff(&sb, p.Name, p.Change, p.Fields)
+			if len(p.OptionsAdded) > 0 {
+				_, _ = fmt.Fprintf(&sb, "    options added: %s\n", quoteAll(p.OptionsAdded))
+			}
+			if len(p.OptionsRemoved) > 0 {
+				_, _ = fmt.Fprintf(&sb, "    options removed: %s\n", quoteAll(p.OptionsRemoved))
+			}
+		}
+	}
+	if len(diff.Variables) > 0 {
+		writeTemplateVersionDiffSection(&sb, "Variables")
+		for _, v := range diff.Variables {
+			writeTemplateVersionItemDiff(&sb, v.Name, v.Change, v.Fields)
+		}
+	}
+	if len(diff.Resources) > 0 {
+		writeTemplateVersionDiffSection(&sb, "Resources")
+		for _, r := range diff.Resources {
+			writeTemplateVersionItemDiff(&sb, r.Name, r.Change, r.Fields)
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "No changes."
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writeTemplateVersionDiffSection(sb *strings.Builder, title string) {
+	if sb.Len() > 0 {
+		_, _ = sb.WriteString("\n")
+	}
+	_, _ = fmt.Fprintf(sb, "%s:\n", title)
+}
+
+func writeTemplateVersionItemDiff(sb *strings.Builder, name string, change codersdk.TemplateVersionDiffChange, fields []codersdk.TemplateVersionFieldDiff) {
+	switch change {
+	case codersdk.TemplateVersionDiffChangeAdded:
+		_, _ = fmt.Fprintf(sb, "+ %s\n", name)
+	case codersdk.TemplateVersionDiffChangeRemoved:
+		_, _ = fmt.Fprintf(sb, "- %s\n", name)
+	default:
+		_, _ = fmt.Fprintf(sb, "~ %s\n", name)
+	}
+	for _, field := range fields {
+		_, _ = fmt.Fprintf(sb, "    %s: %q -> %q\n", field.Field, field.From, field.To)
+	}
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func TestFormatTemplateVersionDiff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoChanges", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "No changes.", formatTemplateVersionDiff(codersdk.TemplateVersionDiff{}))
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		t.Parallel()
+		out := formatTemplateVersionDiff(codersdk.TemplateVersionDiff{
+			Files: []codersdk.TemplateVersionFileDiff{
+				{
+					Path:   "main.tf",
+					Change: codersdk.TemplateVersionDiffChangeModified,
+					Diff:   "--- a/main.tf\n+++ b/main.tf\n@@ -1,1 +1,1 @@\n-a\n+b\n",
+				},
+				{
+					Path:   "logo.png",
+					Change: codersdk.TemplateVersionDiffChangeAdded,
+					Binary: true,
+				},
+			},
+			Parameters: []codersdk.TemplateVersionParameterDiff{
+				{
+					Name:   "region",
+					Change: codersdk.TemplateVersionDiffChangeModified,
+					Fields: []codersdk.TemplateVersionFieldDiff{
+						{Field: "default_value", From: "us", To: "eu"},
+					},
+					OptionsAdded:   []string{"eu"},
+					OptionsRemoved: []string{"ap"},
+				},
+			},
+			Variables: []codersdk.TemplateVersionItemDiff{
+				{Name: "image", Change: codersdk.TemplateVersionDiffChangeAdded},
+			},
+			Resources: []codersdk.TemplateVersionItemDiff{
+				{Name: "docker_volume.home", Change: codersdk.TemplateVersionDiffChangeRemoved},
+			},
+		})
+		require.Equal(t, `--- a/main.tf
++++ b/main.tf
+@@ -1,1 +1,1 @@
+-a
++b
+Binary files /dev/null and b/logo.png differ
+
+Parameters:
+~ region
+    default_value: "us" -> "eu"
+    options added: "eu"
+    options removed: "ap"
+
+Variables:
++ image
+
+Resources:
+- docker_volume.home`, out)
+	})
+}
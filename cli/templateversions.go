@@ -33,6 +33,7 @@ func (r *RootCmd) templateVersions() *serpent.Command {
 			r.archiveTemplateVersion(),
 			r.unarchiveTemplateVersion(),
 			r.templateVersionsPromote(),
+			r.templateVersionsDiff(),
 		},
 	}
 
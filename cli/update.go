@@ -14,6 +14,8 @@ func (r *RootCmd) update() *serpent.Command {
 	var (
 		parameterFlags workspaceParameterFlags
 		bflags         buildFlags
+
+		preview bool
 	)
 	cmd := &serpent.Command{
 		Annotations: workspaceCommand,
@@ -23,6 +25,13 @@ func (r *RootCmd) update() *serpent.Command {
 		Middleware: serpent.Chain(
 			serpent.RequireNArgs(1),
 		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "preview",
+				Description: "Plan the update without stopping or building the workspace and show the changes it would make to the workspace resources.",
+				Value:       serpent.BoolOf(&preview),
+			},
+		},
 		Handler: func(inv *serpent.Invocation) error {
 			client, err := r.InitClient(inv)
 			if err != nil {
@@ -37,12 +46,15 @@ func (r *RootCmd) update() *serpent.Command {
 				_, _ = fmt.Fprintf(inv.Stdout, "Workspace is up-to-date.\n")
 				return nil
 			}
+			if preview {
+				return previewWorkspaceBuild(inv, client, workspace, parameterFlags, bflags, WorkspaceUpdate)
+			}
 
 			// #17840: If the workspace is already running, we will stop it before
 			// updating. Simply performing a new start transition may not work if the
 			// template specifies ignore_changes.
 			if workspace.LatestBuild.Transition == codersdk.WorkspaceTransitionStart {
-				build, err := stopWorkspace(inv, client, workspace, bflags)
+				build, err := stopWorkspace(inv, client, workspace, codersdk.WorkspaceTransitionStop, bflags)
 				if err != nil {
 					return xerrors.Errorf("stop workspace: %w", err)
 				}
@@ -97,6 +97,56 @@ func TestUpdate(t *testing.T) {
 		require.Equal(t, version1.ID.String(), prevBuild.TemplateVersionID.String(), "previous build must have the old template version")
 	})
 
+	t.Run("Preview", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version1 := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version1.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version1.ID)
+		ws := coderdtest.CreateWorkspace(t, member, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, ws.LatestBuild.ID)
+
+		version2 := coderdtest.UpdateTemplateVersion(t, client, owner.OrganizationID, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionApply: echo.ApplyComplete,
+			ProvisionPlan: []*proto.Response{{
+				Type: &proto.Response_Plan{
+					Plan: &proto.PlanComplete{
+						ResourceChanges: []*proto.ResourceChange{{
+							Address:      "docker_container.workspace",
+							Type:         "docker_container",
+							Name:         "workspace",
+							Action:       "replace",
+							ReplacePaths: []string{"image"},
+						}},
+					},
+				},
+			}},
+		}, template.ID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version2.ID)
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := client.UpdateActiveTemplateVersion(ctx, template.ID, codersdk.UpdateActiveTemplateVersion{
+			ID: version2.ID,
+		})
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "update", ws.Name, "--preview")
+		clitest.SetupConfig(t, member, root)
+		pty := ptytest.New(t).Attach(inv)
+		clitest.Start(t, inv)
+		pty.ExpectMatch("docker_container.workspace")
+		pty.ExpectMatch("replace")
+
+		// The preview must not build the workspace.
+		ws, err = member.Workspace(ctx, ws.ID)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), ws.LatestBuild.BuildNumber)
+		require.True(t, ws.Outdated)
+	})
+
 	t.Run("Stopped", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,365 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/pretty"
+	"github.com/coder/serpent"
+)
+
+const (
+	bulkActionStart   = "start"
+	bulkActionStop    = "stop"
+	bulkActionRestart = "restart"
+	bulkActionUpdate  = "update"
+	bulkActionDelete  = "delete"
+)
+
+const (
+	bulkStatusPlanned   = "planned"
+	bulkStatusSkipped   = "skipped"
+	bulkStatusSucceeded = "succeeded"
+	bulkStatusFailed    = "failed"
+)
+
+// bulkWorkspaceResult is the outcome of a bulk action on a single workspace.
+// The same rows are used for the dry-run preview, in which case the status is
+// either planned or skipped.
+type bulkWorkspaceResult struct {
+	WorkspaceID   uuid.UUID `json:"workspace_id" table:"-"`
+	WorkspaceName string    `json:"workspace_name" table:"workspace,default_sort"`
+	Template      string    `json:"template" table:"template"`
+	Action        string    `json:"action" table:"action"`
+	Status        string    `json:"status" table:"status"`
+	// Detail is the reason a workspace was skipped or the error of a failed
+	// action.
+	Detail     string `json:"detail,omitempty" table:"detail"`
+	DurationMS int64  `json:"duration_ms" table:"-"`
+	// DurationDisplay is the rounded duration for the table format.
+	DurationDisplay string `json:"-" table:"duration"`
+}
+
+func (r *RootCmd) workspacesBulk() *serpent.Command {
+	var (
+		filter      cliui.WorkspaceFilter
+		parallelism int64
+		dryRun      bool
+		orphan      bool
+		bflags      buildFlags
+		formatter   = cliui.NewOutputFormatter(
+			cliui.TableFormat([]bulkWorkspaceResult{}, []string{"workspace", "template", "action", "status", "detail", "duration"}),
+			cliui.JSONFormat(),
+		)
+	)
+	cmd := &serpent.Command{
+		Use:   "bulk <start|stop|restart|update|delete>",
+		Short: "Run an action on all workspaces matching a search query",
+		Long: "The workspaces are selected with the same query syntax as \"coder list --search\". " +
+			"Builds are run concurrently and a report of the outcome for each workspace is " +
+			"printed once all of them have completed.\n\n" + FormatExamples(
+			Example{
+				Description: "Preview which workspaces of a template would be updated",
+				Command:     `coder workspaces bulk update --search "template:docker outdated:true" --dry-run`,
+			},
+			Example{
+				Description: "Stop all of your running workspaces",
+				Command:     `coder workspaces bulk stop --search "owner:me status:running"`,
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "parallelism",
+				Env:         "CODER_BULK_PARALLELISM",
+				Description: "Number of workspace builds to run at the same time.",
+				Default:     "4",
+				Value:       serpent.Int64Of(&parallelism),
+			},
+			{
+				Flag:        "dry-run",
+				Description: "Show the workspaces that would be affected without changing them.",
+				Value:       serpent.BoolOf(&dryRun),
+			},
+			{
+				Flag:        "orphan",
+				Description: "Delete workspaces without deleting their resources. Only valid for the delete action.",
+				Value:       serpent.BoolOf(&orphan),
+			},
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			action := inv.Args[0]
+			switch action {
+			case bulkActionStart, bulkActionStop, bulkActionRestart, bulkActionUpdate, bulkActionDelete:
+			default:
+				return xerrors.Errorf("unknown action %q, must be one of start, stop, restart, update or delete", action)
+			}
+			if orphan && action != bulkActionDelete {
+				return xerrors.New("--orphan can only be used with the delete action")
+			}
+			if parallelism < 1 {
+				return xerrors.New("--parallelism must be at least 1")
+			}
+
+			client, err := r.InitClient(inv)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.Workspaces(ctx, filter.Filter())
+			if err != nil {
+				return xerrors.Errorf("query workspaces: %w", err)
+			}
+
+			results := make([]bulkWorkspaceResult, 0, len(res.Workspaces))
+			pending := make([]int, 0, len(res.Workspaces))
+			for _, workspace := range res.Workspaces {
+				result := bulkWorkspaceResult{
+					WorkspaceID:   workspace.ID,
+					WorkspaceName: workspace.FullName(),
+					Template:      workspace.TemplateName,
+					Action:        action,
+					Status:        bulkStatusPlanned,
+				}
+				if reason := bulkSkipReason(action, workspace); reason != "" {
+					result.Status = bulkStatusSkipped
+					result.Detail = reason
+				} else {
+					pending = append(pending, len(results))
+				}
+				results = append(results, result)
+			}
+
+			if dryRun {
+				return writeBulkWorkspaceResults(inv, formatter, results)
+			}
+			if len(pending) == 0 {
+				cliui.Infof(inv.Stderr, "No workspaces to %s.", action)
+				return writeBulkWorkspaceResults(inv, formatter, results)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stderr, "The following workspaces will be affected by %s:\n", cliui.Code(action))
+			for _, i := range pending {
+				_, _ = fmt.Fprintf(inv.Stderr, "  %s\n", results[i].WorkspaceName)
+			}
+			_, _ = fmt.Fprintln(inv.Stderr)
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Confirm %s of %d workspace(s)?", action, len(pending)),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			var (
+				progressMu sync.Mutex
+				completed  int
+			)
+			var eg errgroup.Group
+			eg.SetLimit(int(parallelism))
+			for _, i := range pending {
+				workspace := res.Workspaces[i]
+				eg.Go(func() error {
+					start := time.Now()
+					err := runBulkAction(ctx, client, workspace, action, orphan, bflags)
+
+					progressMu.Lock()
+					defer progressMu.Unlock()
+					completed++
+					result := &results[i]
+					duration := time.Since(start)
+					result.DurationMS = duration.Milliseconds()
+					result.DurationDisplay = duration.Round(time.Second).String()
+					result.Status = bulkStatusSucceeded
+					if err != nil {
+						result.Status = bulkStatusFailed
+						result.Detail = err.Error()
+						_, _ = fmt.Fprintf(inv.Stderr, "[%d/%d] %s %s: %s\n", completed, len(pending),
+							pretty.Sprint(cliui.DefaultStyles.Error, "✘"), cliui.Keyword(result.WorkspaceName), err)
+						return nil
+					}
+					_, _ = fmt.Fprintf(inv.Stderr, "[%d/%d] %s %s (%s)\n", completed, len(pending),
+						pretty.Sprint(cliui.DefaultStyles.Keyword, "✔"), cliui.Keyword(result.WorkspaceName), result.DurationDisplay)
+					return nil
+				})
+			}
+			// Failures are recorded in the results, so this never returns an
+			// error.
+			_ = eg.Wait()
+			_, _ = fmt.Fprintln(inv.Stderr)
+
+			err = writeBulkWorkspaceResults(inv, formatter, results)
+			if err != nil {
+				return err
+			}
+			failed := 0
+			for _, result := range results {
+				if result.Status == bulkStatusFailed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return xerrors.Errorf("%s failed for %d of %d workspace(s)", action, failed, len(pending))
+			}
+			return nil
+		},
+	}
+	filter.AttachOptions(&cmd.Options)
+	formatter.AttachOptions(&cmd.Options)
+	cmd.Options = append(cmd.Options, bflags.cliOptions()...)
+	return cmd
+}
+
+func writeBulkWorkspaceResults(inv *serpent.Invocation, formatter *cliui.OutputFormatter, results []bulkWorkspaceResult) error {
+	out, err := formatter.Format(inv.Context(), results)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(inv.Stdout, out)
+	return err
+}
+
+// bulkSkipReason returns why the action does not apply to the workspace, or
+// an empty string if it does.
+func bulkSkipReason(action string, workspace codersdk.Workspace) string {
+	job := workspace.LatestBuild.Job
+	if job.Status == codersdk.ProvisionerJobPending || job.Status == codersdk.ProvisionerJobRunning || job.Status == codersdk.ProvisionerJobCanceling {
+		return "a build is already in progress"
+	}
+	if workspace.LatestBuild.Transition == codersdk.WorkspaceTransitionDelete && job.Status == codersdk.ProvisionerJobSucceeded {
+		return "workspace is deleted"
+	}
+	running := workspace.LatestBuild.Transition == codersdk.WorkspaceTransitionStart && job.Status == codersdk.ProvisionerJobSucceeded
+	stopped := workspace.LatestBuild.Transition == codersdk.WorkspaceTransitionStop && job.Status == codersdk.ProvisionerJobSucceeded
+	switch action {
+	case bulkActionStart:
+		if running {
+			return "workspace is already running"
+		}
+	case bulkActionStop:
+		if stopped {
+			return "workspace is already stopped"
+		}
+	case bulkActionUpdate:
+		if !workspace.Outdated {
+			return "workspace is up-to-date"
+		}
+	}
+	return ""
+}
+
+// runBulkAction runs the action on the workspace and waits for the resulting
+// builds to complete. Unlike the single workspace commands it never prompts,
+// so builds reuse the parameters of the previous build.
+func runBulkAction(ctx context.Context, client *codersdk.Client, workspace codersdk.Workspace, action string, orphan bool, bflags buildFlags) error {
+	request := func(transition codersdk.WorkspaceTransition) codersdk.CreateWorkspaceBuildRequest {
+		req := codersdk.CreateWorkspaceBuildRequest{Transition: transition}
+		if bflags.provisionerLogDebug {
+			req.LogLevel = codersdk.ProvisionerLogLevelDebug
+		}
+		if transition == codersdk.WorkspaceTransitionStart && bflags.reason != "" {
+			req.Reason = codersdk.CreateWorkspaceBuildReason(bflags.reason)
+		}
+		return req
+	}
+	build := func(req codersdk.CreateWorkspaceBuildRequest) error {
+		b, err := client.CreateWorkspaceBuild(ctx, workspace.ID, req)
+		if err != nil {
+			return err
+		}
+		return waitForWorkspaceBuild(ctx, client, b.ID)
+	}
+	start := func(update bool) error {
+		if workspace.DormantAt != nil {
+			err := client.UpdateWorkspaceDormancy(ctx, workspace.ID, codersdk.UpdateWorkspaceDormancy{Dormant: false})
+			if err != nil {
+				return xerrors.Errorf("activate workspace: %w", err)
+			}
+		}
+		req := request(codersdk.WorkspaceTransitionStart)
+		if update || workspace.AutomaticUpdates == codersdk.AutomaticUpdatesAlways {
+			req.TemplateVersionID = workspace.TemplateActiveVersionID
+		}
+		err := build(req)
+		// Policy may require the workspace to be started with the active
+		// template version.
+		if cerr, ok := codersdk.AsError(err); ok && cerr.StatusCode() == http.StatusForbidden && !update {
+			req.TemplateVersionID = workspace.TemplateActiveVersionID
+			err = build(req)
+		}
+		return err
+	}
+	stopIfStarted := func() error {
+		if workspace.LatestBuild.Transition != codersdk.WorkspaceTransitionStart {
+			return nil
+		}
+		if err := build(request(codersdk.WorkspaceTransitionStop)); err != nil {
+			return xerrors.Errorf("stop workspace: %w", err)
+		}
+		return nil
+	}
+
+	switch action {
+	case bulkActionStart:
+		return start(false)
+	case bulkActionStop:
+		return build(request(codersdk.WorkspaceTransitionStop))
+	case bulkActionRestart:
+		if err := stopIfStarted(); err != nil {
+			return err
+		}
+		return start(false)
+	case bulkActionUpdate:
+		// Stop running workspaces first, as a start transition on its own may
+		// not apply changes when the template uses ignore_changes.
+		if err := stopIfStarted(); err != nil {
+			return err
+		}
+		return start(true)
+	case bulkActionDelete:
+		req := request(codersdk.WorkspaceTransitionDelete)
+		req.Orphan = orphan
+		return build(req)
+	default:
+		return xerrors.Errorf("unknown action %q", action)
+	}
+}
+
+// waitForWorkspaceBuild polls the build until its job has completed.
+func waitForWorkspaceBuild(ctx context.Context, client *codersdk.Client, buildID uuid.UUID) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		build, err := client.WorkspaceBuild(ctx, buildID)
+		if err != nil {
+			return xerrors.Errorf("get workspace build: %w", err)
+		}
+		switch build.Job.Status {
+		case codersdk.ProvisionerJobSucceeded:
+			return nil
+		case codersdk.ProvisionerJobFailed:
+			return xerrors.Errorf("%s build failed: %s", build.Transition, build.Job.Error)
+		case codersdk.ProvisionerJobCanceled:
+			return xerrors.Errorf("%s build was canceled", build.Transition)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func TestBulkSkipReason(t *testing.T) {
+	t.Parallel()
+
+	workspace := func(transition codersdk.WorkspaceTransition, status codersdk.ProvisionerJobStatus, outdated bool) codersdk.Workspace {
+		return codersdk.Workspace{
+			Outdated: outdated,
+			LatestBuild: codersdk.WorkspaceBuild{
+				Transition: transition,
+				Job:        codersdk.ProvisionerJob{Status: status},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		action    string
+		workspace codersdk.Workspace
+		skipped   bool
+	}{
+		{"StartRunning", bulkActionStart, workspace(codersdk.WorkspaceTransitionStart, codersdk.ProvisionerJobSucceeded, false), true},
+		{"StartStopped", bulkActionStart, workspace(codersdk.WorkspaceTransitionStop, codersdk.ProvisionerJobSucceeded, false), false},
+		{"StartFailed", bulkActionStart, workspace(codersdk.WorkspaceTransitionStart, codersdk.ProvisionerJobFailed, false), false},
+		{"StopStopped", bulkActionStop, workspace(codersdk.WorkspaceTransitionStop, codersdk.ProvisionerJobSucceeded, false), true},
+		{"StopRunning", bulkActionStop, workspace(codersdk.WorkspaceTransitionStart, codersdk.ProvisionerJobSucceeded, false), false},
+		{"RestartStopped", bulkActionRestart, workspace(codersdk.WorkspaceTransitionStop, codersdk.ProvisionerJobSucceeded, false), false},
+		{"UpdateUpToDate", bulkActionUpdate, workspace(codersdk.WorkspaceTransitionStart, codersdk.ProvisionerJobSucceeded, false), true},
+		{"UpdateOutdated", bulkActionUpdate, workspace(codersdk.WorkspaceTransitionStart, codersdk.ProvisionerJobSucceeded, true), false},
+		{"InProgress", bulkActionDelete, workspace(codersdk.WorkspaceTransitionStart, codersdk.ProvisionerJobRunning, false), true},
+		{"Deleted", bulkActionDelete, workspace(codersdk.WorkspaceTransitionDelete, codersdk.ProvisionerJobSucceeded, false), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.skipped, bulkSkipReason(tc.action, tc.workspace) != "")
+		})
+	}
+}
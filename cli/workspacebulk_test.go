@@ -0,0 +1,140 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspacesBulk(t *testing.T) {
+	t.Parallel()
+
+	type bulkResult struct {
+		WorkspaceName string `json:"workspace_name"`
+		Action        string `json:"action"`
+		Status        string `json:"status"`
+		Detail        string `json:"detail"`
+	}
+
+	setup := func(t *testing.T) (*codersdk.Client, codersdk.Template, []codersdk.Workspace) {
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		workspaces := make([]codersdk.Workspace, 0, 3)
+		for range 3 {
+			workspace := coderdtest.CreateWorkspace(t, member, template.ID)
+			coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+			workspaces = append(workspaces, workspace)
+		}
+		return member, template, workspaces
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		t.Parallel()
+		member, _, workspaces := setup(t)
+
+		inv, root := clitest.New(t, "workspaces", "bulk", "stop", "--dry-run", "--output", "json")
+		clitest.SetupConfig(t, member, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.WithContext(testutil.Context(t, testutil.WaitLong)).Run()
+		require.NoError(t, err)
+
+		var results []bulkResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+		require.Len(t, results, len(workspaces))
+		for _, result := range results {
+			require.Equal(t, "stop", result.Action)
+			require.Equal(t, "planned", result.Status)
+		}
+
+		// Nothing should have been stopped.
+		ctx := testutil.Context(t, testutil.WaitShort)
+		for _, workspace := range workspaces {
+			workspace, err := member.Workspace(ctx, workspace.ID)
+			require.NoError(t, err)
+			require.Equal(t, codersdk.WorkspaceTransitionStart, workspace.LatestBuild.Transition)
+		}
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		t.Parallel()
+		member, _, workspaces := setup(t)
+
+		// Only stop the first workspace, so the bulk stop skips it.
+		ctx := testutil.Context(t, testutil.WaitLong)
+		build, err := member.CreateWorkspaceBuild(ctx, workspaces[0].ID, codersdk.CreateWorkspaceBuildRequest{
+			Transition: codersdk.WorkspaceTransitionStop,
+		})
+		require.NoError(t, err)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, member, build.ID)
+
+		inv, root := clitest.New(t, "workspaces", "bulk", "stop", "--output", "json", "--parallelism", "2", "-y")
+		clitest.SetupConfig(t, member, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var results []bulkResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+		require.Len(t, results, len(workspaces))
+		statuses := make(map[string]string)
+		for _, result := range results {
+			statuses[result.WorkspaceName] = result.Status
+		}
+		require.Equal(t, "skipped", statuses[workspaces[0].FullName()])
+		require.Equal(t, "succeeded", statuses[workspaces[1].FullName()])
+		require.Equal(t, "succeeded", statuses[workspaces[2].FullName()])
+
+		for _, workspace := range workspaces {
+			workspace, err := member.Workspace(ctx, workspace.ID)
+			require.NoError(t, err)
+			require.Equal(t, codersdk.WorkspaceTransitionStop, workspace.LatestBuild.Transition)
+			require.Equal(t, codersdk.ProvisionerJobSucceeded, workspace.LatestBuild.Job.Status)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		t.Parallel()
+		member, _, workspaces := setup(t)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "workspaces", "bulk", "delete", "--search", "name:"+workspaces[1].Name, "--output", "json", "-y")
+		clitest.SetupConfig(t, member, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var results []bulkResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+		require.Len(t, results, 1)
+		require.Equal(t, "succeeded", results[0].Status)
+
+		res, err := member.Workspaces(ctx, codersdk.WorkspaceFilter{})
+		require.NoError(t, err)
+		require.Len(t, res.Workspaces, len(workspaces)-1)
+	})
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		inv, root := clitest.New(t, "workspaces", "bulk", "hibernate")
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.ErrorContains(t, err, "unknown action")
+	})
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) workspaces() *serpent.Command {
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "workspaces",
+		Short:       "Manage many workspaces at once",
+		Aliases:     []string{"workspace"},
+		Long: FormatExamples(
+			Example{
+				Description: "Update all outdated workspaces of a template",
+				Command:     `coder workspaces bulk update --search "template:docker outdated:true"`,
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.workspacesBulk(),
+		},
+	}
+	return cmd
+}
@@ -57,6 +57,7 @@ type API struct {
 	*ConnLogAPI
 	*SubAgentAPI
 	*BoundaryLogsAPI
+	*SessionRecordingsAPI
 	*tailnet.DRPCService
 
 	cachedWorkspaceFields *CachedWorkspaceFields
@@ -209,6 +210,13 @@ func New(opts Options, workspace database.Workspace) *API {
 		Log:              opts.Log,
 	}
 
+	api.SessionRecordingsAPI = &SessionRecordingsAPI{
+		AgentFn:   api.agent,
+		Workspace: api.cachedWorkspaceFields,
+		Database:  opts.Database,
+		Log:       opts.Log,
+	}
+
 	api.DRPCService = &tailnet.DRPCService{
 		CoordPtr:                opts.TailnetCoordinator,
 		Logger:                  opts.Log,
@@ -89,6 +89,13 @@ func (a *ManifestAPI) GetManifest(ctx context.Context, _ *agentproto.GetManifest
 		return nil, xerrors.Errorf("fetching workspace agent data: %w", err)
 	}
 
+	// nolint:gocritic // The agent may not read the template, but needs to
+	// know whether sessions are recorded.
+	template, err := a.Database.GetTemplateByID(dbauthz.AsSystemRestricted(ctx), workspace.TemplateID)
+	if err != nil {
+		return nil, xerrors.Errorf("getting template by id: %w", err)
+	}
+
 	appSlug := appurl.ApplicationURL{
 		AppSlugOrPort: "{{port}}",
 		AgentName:     workspaceAgent.Name,
@@ -135,6 +142,9 @@ func (a *ManifestAPI) GetManifest(ctx context.Context, _ *agentproto.GetManifest
 		DerpForceWebsockets:      a.DerpForceWebSockets,
 		ParentId:                 parentID,
 
+		SessionRecordingEnabled:      template.SessionRecordingEnabled,
+		SessionRecordingIncludeInput: template.SessionRecordingEnabled && template.SessionRecordingIncludeInput,
+
 		DerpMap:       tailnet.DERPMapToProto(a.DerpMapFn()),
 		Scripts:       dbAgentScriptsToProto(scripts),
 		Apps:          apps,
@@ -193,6 +203,8 @@ func dbAgentScriptToProto(script database.WorkspaceAgentScript) *agentproto.Work
 		RunOnStop:        script.RunOnStop,
 		StartBlocksLogin: script.StartBlocksLogin,
 		Timeout:          durationpb.New(time.Duration(script.TimeoutSeconds) * time.Second),
+		UnitName:         script.UnitName,
+		DependsOn:        script.DependsOnUnits(),
 	}
 }
 
@@ -50,6 +50,12 @@ func TestGetManifest(t *testing.T) {
 			OwnerID:       owner.ID,
 			OwnerUsername: owner.Username,
 			Name:          "cool-workspace",
+			TemplateID:    uuid.New(),
+		}
+		template = database.Template{
+			ID:                           workspace.TemplateID,
+			SessionRecordingEnabled:      true,
+			SessionRecordingIncludeInput: true,
 		}
 		agent = database.WorkspaceAgent{
 			ID:   uuid.New(),
@@ -338,6 +344,7 @@ func TestGetManifest(t *testing.T) {
 		}).Return(metadata, nil)
 		mDB.EXPECT().GetWorkspaceAgentDevcontainersByAgentID(gomock.Any(), agent.ID).Return(devcontainers, nil)
 		mDB.EXPECT().GetWorkspaceByID(gomock.Any(), workspace.ID).Return(workspace, nil)
+		mDB.EXPECT().GetTemplateByID(gomock.Any(), workspace.TemplateID).Return(template, nil)
 
 		got, err := api.GetManifest(context.Background(), &agentproto.GetManifestRequest{})
 		require.NoError(t, err)
@@ -364,6 +371,9 @@ func TestGetManifest(t *testing.T) {
 			Apps:          protoApps,
 			Metadata:      protoMetadata,
 			Devcontainers: protoDevcontainers,
+
+			SessionRecordingEnabled:      true,
+			SessionRecordingIncludeInput: true,
 		}
 
 		// Log got and expected with spew.
@@ -405,6 +415,7 @@ func TestGetManifest(t *testing.T) {
 		}).Return([]database.WorkspaceAgentMetadatum{}, nil)
 		mDB.EXPECT().GetWorkspaceAgentDevcontainersByAgentID(gomock.Any(), childAgent.ID).Return([]database.WorkspaceAgentDevcontainer{}, nil)
 		mDB.EXPECT().GetWorkspaceByID(gomock.Any(), workspace.ID).Return(workspace, nil)
+		mDB.EXPECT().GetTemplateByID(gomock.Any(), workspace.TemplateID).Return(template, nil)
 
 		got, err := api.GetManifest(context.Background(), &agentproto.GetManifestRequest{})
 		require.NoError(t, err)
@@ -431,6 +442,9 @@ func TestGetManifest(t *testing.T) {
 			Apps:          []*agentproto.WorkspaceApp{},
 			Metadata:      []*agentproto.WorkspaceAgentMetadata_Description{},
 			Devcontainers: []*agentproto.WorkspaceAgentDevcontainer{},
+
+			SessionRecordingEnabled:      true,
+			SessionRecordingIncludeInput: true,
 		}
 
 		require.Equal(t, expected, got)
@@ -528,6 +542,7 @@ func TestGetManifest(t *testing.T) {
 		}).Return(metadata, nil)
 		mDB.EXPECT().GetWorkspaceAgentDevcontainersByAgentID(gomock.Any(), agent.ID).Return(devcontainers, nil)
 		mDB.EXPECT().GetWorkspaceByID(gomock.Any(), workspace.ID).Return(workspace, nil)
+		mDB.EXPECT().GetTemplateByID(gomock.Any(), workspace.TemplateID).Return(template, nil)
 
 		got, err := api.GetManifest(context.Background(), &agentproto.GetManifestRequest{})
 		require.NoError(t, err)
@@ -553,6 +568,9 @@ func TestGetManifest(t *testing.T) {
 			Apps:          protoApps,
 			Metadata:      protoMetadata,
 			Devcontainers: protoDevcontainers,
+
+			SessionRecordingEnabled:      true,
+			SessionRecordingIncludeInput: true,
 		}
 
 		// Log got and expected with spew.
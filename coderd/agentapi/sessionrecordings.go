@@ -0,0 +1,112 @@
+package agentapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/agent/agentrecord"
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+)
+
+// MaxSessionRecordingSize is the maximum size of a stored recording. Agents
+// stop recording at agentrecord.DefaultMaxSize, measuring events before they
+// are encoded, so the last event may exceed it slightly. The agent runs in the
+// workspace, so the limit is enforced here as well.
+const MaxSessionRecordingSize = agentrecord.DefaultMaxSize + 1<<20
+
+type SessionRecordingsAPI struct {
+	AgentFn   func(context.Context) (database.WorkspaceAgent, error)
+	Workspace *CachedWorkspaceFields
+	Database  database.Store
+	Log       slog.Logger
+}
+
+func (a *SessionRecordingsAPI) UploadSessionRecording(ctx context.Context, req *agentproto.UploadSessionRecordingRequest) (*agentproto.UploadSessionRecordingResponse, error) {
+	id, err := uuid.FromBytes(req.GetId())
+	if err != nil {
+		return nil, xerrors.Errorf("recording id from bytes: %w", err)
+	}
+	if req.GetOffset() < 0 {
+		return nil, xerrors.Errorf("invalid offset %d", req.GetOffset())
+	}
+	if req.GetOffset() > MaxSessionRecordingSize-int64(len(req.GetData())) {
+		return nil, xerrors.Errorf("session recording exceeds the maximum size of %d bytes", MaxSessionRecordingSize)
+	}
+
+	workspaceAgent, err := a.AgentFn(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("get agent: %w", err)
+	}
+
+	// Recordings are only readable by those allowed to read connection logs,
+	// which the agent is not, so writes are made as the connection logger.
+	// nolint:gocritic // The agent is authenticated and the recording is
+	// scoped to its own workspace below.
+	logCtx := dbauthz.AsConnectionLogger(ctx)
+
+	if req.GetOffset() > 0 {
+		rows, err := a.Database.AppendWorkspaceSessionRecordingData(logCtx, database.AppendWorkspaceSessionRecordingDataParams{
+			ID:          id,
+			AgentID:     workspaceAgent.ID,
+			OffsetBytes: req.GetOffset(),
+			Data:        req.GetData(),
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("append session recording data: %w", err)
+		}
+		if rows == 0 {
+			return nil, xerrors.Errorf("session recording %s not found or offset %d does not match its size", id, req.GetOffset())
+		}
+		return &agentproto.UploadSessionRecordingResponse{}, nil
+	}
+
+	connectionID, err := uuid.FromBytes(req.GetConnectionId())
+	if err != nil {
+		return nil, xerrors.Errorf("connection id from bytes: %w", err)
+	}
+	connectionType, err := db2sdk.ConnectionLogConnectionTypeFromAgentProtoConnectionType(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	ws, ok := a.Workspace.AsWorkspaceIdentity()
+	if !ok {
+		workspace, err := a.Database.GetWorkspaceByAgentID(ctx, workspaceAgent.ID)
+		if err != nil {
+			return nil, xerrors.Errorf("get workspace by agent id: %w", err)
+		}
+		ws = database.WorkspaceIdentityFromWorkspace(workspace)
+	}
+
+	err = a.Database.InsertWorkspaceSessionRecording(logCtx, database.InsertWorkspaceSessionRecordingParams{
+		ID:             id,
+		OrganizationID: ws.OrganizationID,
+		WorkspaceID:    ws.ID,
+		AgentID:        workspaceAgent.ID,
+		ConnectionID:   connectionID,
+		Type:           connectionType,
+		StartedAt:      req.GetStartedAt().AsTime(),
+		EndedAt:        req.GetEndedAt().AsTime(),
+		CreatedAt:      dbtime.Now(),
+		IncludeInput:   req.GetIncludeInput(),
+		Truncated:      req.GetTruncated(),
+		Data:           req.GetData(),
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("insert session recording: %w", err)
+	}
+	a.Log.Debug(ctx, "stored session recording",
+		slog.F("recording_id", id),
+		slog.F("connection_id", connectionID),
+		slog.F("size", len(req.GetData())),
+	)
+
+	return &agentproto.UploadSessionRecordingResponse{}, nil
+}
@@ -0,0 +1,141 @@
+package agentapi_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentproto "github.com/coder/coder/v2/agent/proto"
+	"github.com/coder/coder/v2/coderd/agentapi"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmock"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+)
+
+func TestUploadSessionRecording(t *testing.T) {
+	t.Parallel()
+
+	var (
+		workspace = database.Workspace{
+			ID:             uuid.New(),
+			OrganizationID: uuid.New(),
+			OwnerID:        uuid.New(),
+			Name:           "cool-workspace",
+		}
+		agent = database.WorkspaceAgent{
+			ID: uuid.New(),
+		}
+	)
+
+	newAPI := func(t *testing.T) (*agentapi.SessionRecordingsAPI, *dbmock.MockStore) {
+		mDB := dbmock.NewMockStore(gomock.NewController(t))
+		return &agentapi.SessionRecordingsAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			Workspace: &agentapi.CachedWorkspaceFields{},
+			Database:  mDB,
+		}, mDB
+	}
+
+	t.Run("Insert", func(t *testing.T) {
+		t.Parallel()
+
+		api, mDB := newAPI(t)
+		id, connID := uuid.New(), uuid.New()
+		startedAt := dbtime.Now().Add(-time.Minute)
+		endedAt := dbtime.Now()
+		data := []byte("{\"version\":2,\"width\":80,\"height\":24}\n")
+
+		mDB.EXPECT().GetWorkspaceByAgentID(gomock.Any(), agent.ID).Return(workspace, nil)
+		mDB.EXPECT().InsertWorkspaceSessionRecording(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg database.InsertWorkspaceSessionRecordingParams) error {
+				require.Equal(t, id, arg.ID)
+				require.Equal(t, workspace.OrganizationID, arg.OrganizationID)
+				require.Equal(t, workspace.ID, arg.WorkspaceID)
+				require.Equal(t, agent.ID, arg.AgentID)
+				require.Equal(t, connID, arg.ConnectionID)
+				require.Equal(t, database.ConnectionTypeSsh, arg.Type)
+				require.True(t, startedAt.Equal(arg.StartedAt))
+				require.True(t, endedAt.Equal(arg.EndedAt))
+				require.True(t, arg.IncludeInput)
+				require.False(t, arg.Truncated)
+				require.Equal(t, data, arg.Data)
+				return nil
+			})
+
+		_, err := api.UploadSessionRecording(context.Background(), &agentproto.UploadSessionRecordingRequest{
+			Id:           id[:],
+			ConnectionId: connID[:],
+			Type:         agentproto.Connection_SSH,
+			StartedAt:    timestamppb.New(startedAt),
+			EndedAt:      timestamppb.New(endedAt),
+			IncludeInput: true,
+			Data:         data,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		t.Parallel()
+
+		api, mDB := newAPI(t)
+		id := uuid.New()
+		mDB.EXPECT().AppendWorkspaceSessionRecordingData(gomock.Any(), database.AppendWorkspaceSessionRecordingDataParams{
+			ID:          id,
+			AgentID:     agent.ID,
+			OffsetBytes: 10,
+			Data:        []byte("more"),
+		}).Return(int64(1), nil)
+
+		_, err := api.UploadSessionRecording(context.Background(), &agentproto.UploadSessionRecordingRequest{
+			Id:     id[:],
+			Offset: 10,
+			Data:   []byte("more"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("AppendOffsetMismatch", func(t *testing.T) {
+		t.Parallel()
+
+		api, mDB := newAPI(t)
+		id := uuid.New()
+		mDB.EXPECT().AppendWorkspaceSessionRecordingData(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+		_, err := api.UploadSessionRecording(context.Background(), &agentproto.UploadSessionRecordingRequest{
+			Id:     id[:],
+			Offset: 10,
+			Data:   []byte("more"),
+		})
+		require.ErrorContains(t, err, "does not match its size")
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		t.Parallel()
+
+		// The recording is rejected before it reaches the database.
+		api, _ := newAPI(t)
+		id := uuid.New()
+
+		_, err := api.UploadSessionRecording(context.Background(), &agentproto.UploadSessionRecordingRequest{
+			Id:     id[:],
+			Offset: agentapi.MaxSessionRecordingSize - 2,
+			Data:   []byte("more"),
+		})
+		require.ErrorContains(t, err, "exceeds the maximum size")
+
+		_, err = api.UploadSessionRecording(context.Background(), &agentproto.UploadSessionRecordingRequest{
+			Id:     id[:],
+			Offset: math.MaxInt64,
+			Data:   []byte("more"),
+		})
+		require.ErrorContains(t, err, "exceeds the maximum size")
+	})
+}
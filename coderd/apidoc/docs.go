@@ -1941,6 +1941,58 @@ const docTemplate = `{
                 }
             }
         },
+        "/notifications/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Returns the most recently enqueued notification messages along with the outcome of their most recent\ndelivery attempt, such as the status code with which a webhook request was rejected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notification deliveries",
+                "operationId": "get-notification-deliveries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "smtp",
+                            "webhook",
+                            "inbox",
+                            "webpush",
+                            "slack",
+                            "teams"
+                        ],
+                        "type": "string",
+                        "description": "Delivery method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 25,
+                        "description": "Maximum number of deliveries, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/notifications/dispatch-methods": {
             "get": {
                 "security": [
@@ -3745,6 +3797,141 @@ const docTemplate = `{
                 }
             }
         },
+        "/organizations/{organization}/notifications/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get organization notification webhooks",
+                "operationId": "get-organization-notification-webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.OrganizationNotificationWebhook"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}/notifications/webhooks/{method}": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Notifications delivered by the method are sent to the webhook of every organization the recipient\nis a member of which configured one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Update organization notification webhook",
+                "operationId": "update-organization-notification-webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "slack",
+                            "teams"
+                        ],
+                        "type": "string",
+                        "description": "Notification method",
+                        "name": "method",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateOrganizationNotificationWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OrganizationNotificationWebhook"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Delete organization notification webhook",
+                "operationId": "delete-organization-notification-webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "slack",
+                            "teams"
+                        ],
+                        "type": "string",
+                        "description": "Notification method",
+                        "name": "method",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
         "/organizations/{organization}/paginated-members": {
             "get": {
                 "security": [
@@ -6278,8 +6465,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/templates/{template}/prebuilds/invalidate": {
-            "post": {
+        "/templates/{template}/prebuilds/adaptive": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -6289,10 +6476,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Prebuilds"
                 ],
-                "summary": "Invalidate presets for template",
-                "operationId": "invalidate-presets-for-template",
+                "summary": "Get adaptive prebuilds settings for template",
+                "operationId": "get-adaptive-prebuilds-settings-for-template",
                 "parameters": [
                     {
                         "type": "string",
@@ -6307,27 +6494,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.InvalidatePresetsResponse"
+                            "$ref": "#/definitions/codersdk.TemplateAdaptivePrebuilds"
                         }
                     }
                 }
-            }
-        },
-        "/templates/{template}/versions": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Prebuilds"
                 ],
-                "summary": "List template versions by template ID",
-                "operationId": "list-template-versions-by-template-id",
+                "summary": "Update adaptive prebuilds settings for template",
+                "operationId": "update-adaptive-prebuilds-settings-for-template",
                 "parameters": [
                     {
                         "type": "string",
@@ -6338,70 +6526,42 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "After ID",
-                        "name": "after_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include archived versions in the list",
-                        "name": "include_archived",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page offset",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Adaptive prebuilds settings request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateAdaptivePrebuilds"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.TemplateVersion"
-                            }
+                            "$ref": "#/definitions/codersdk.TemplateAdaptivePrebuilds"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/templates/{template}/prebuilds/forecast": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the forecasted and actual prebuild claims of each preset of the template's\nactive version, per hour, for the past and the next 24 hours.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Prebuilds"
                 ],
-                "summary": "Update active template version by template ID",
-                "operationId": "update-active-template-version-by-template-id",
+                "summary": "Get prebuilds forecast for template",
+                "operationId": "get-prebuilds-forecast-for-template",
                 "parameters": [
-                    {
-                        "description": "Modified template version",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateActiveTemplateVersion"
-                        }
-                    },
                     {
                         "type": "string",
                         "format": "uuid",
@@ -6415,30 +6575,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.TemplatePrebuildsForecast"
                         }
                     }
                 }
             }
         },
-        "/templates/{template}/versions/archive": {
+        "/templates/{template}/prebuilds/invalidate": {
             "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Archive template unused versions by template id",
-                "operationId": "archive-template-unused-versions-by-template-id",
+                "summary": "Invalidate presets for template",
+                "operationId": "invalidate-presets-for-template",
                 "parameters": [
                     {
                         "type": "string",
@@ -6447,28 +6604,19 @@ const docTemplate = `{
                         "name": "template",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Archive request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.ArchiveTemplateVersionsRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.InvalidatePresetsResponse"
                         }
                     }
                 }
             }
         },
-        "/templates/{template}/versions/{templateversionname}": {
+        "/templates/{template}/prebuilds/readiness": {
             "get": {
                 "security": [
                     {
@@ -6479,10 +6627,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Prebuilds"
                 ],
-                "summary": "Get template version by template ID and name",
-                "operationId": "get-template-version-by-template-id-and-name",
+                "summary": "Get prebuild readiness checks for template",
+                "operationId": "get-prebuild-readiness-checks-for-template",
                 "parameters": [
                     {
                         "type": "string",
@@ -6491,9 +6639,497 @@ const docTemplate = `{
                         "name": "template",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.TemplatePresetReadinessChecks"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/prebuilds/readiness/{preset}": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Prebuilt workspaces of the preset must pass the readiness checks once their agents are\nready, before they can be claimed. Prebuilt workspaces that fail the checks are replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prebuilds"
+                ],
+                "summary": "Update prebuild readiness checks for template preset",
+                "operationId": "update-prebuild-readiness-checks-for-template-preset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset name",
+                        "name": "preset",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Readiness checks request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateTemplatePresetReadinessChecksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplatePresetReadinessChecks"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Prebuilds"
+                ],
+                "summary": "Delete prebuild readiness checks for template preset",
+                "operationId": "delete-prebuild-readiness-checks-for-template-preset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preset name",
+                        "name": "preset",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/templates/{template}/prebuilds/status": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Returns the running prebuilt workspaces of the template, along with the results of\ntheir preset's readiness checks and whether they can be claimed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prebuilds"
+                ],
+                "summary": "Get prebuilds status for template",
+                "operationId": "get-prebuilds-status-for-template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplatePrebuildsStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/schedule/groups": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Get template group schedule overrides",
+                "operationId": "get-template-group-schedule-overrides",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.TemplateGroupScheduleOverride"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/schedule/groups/{group}": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Upsert template group schedule override",
+                "operationId": "upsert-template-group-schedule-override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Group ID",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule override",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpsertTemplateGroupScheduleOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateGroupScheduleOverride"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Delete template group schedule override",
+                "operationId": "delete-template-group-schedule-override",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Group ID",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/templates/{template}/schedule/users/{user}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Get effective template schedule for user",
+                "operationId": "get-effective-template-schedule-for-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UserTemplateSchedule"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "List template versions by template ID",
+                "operationId": "list-template-versions-by-template-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "After ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived versions in the list",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.TemplateVersion"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Update active template version by template ID",
+                "operationId": "update-active-template-version-by-template-id",
+                "parameters": [
+                    {
+                        "description": "Modified template version",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateActiveTemplateVersion"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/versions/archive": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Archive template unused versions by template id",
+                "operationId": "archive-template-unused-versions-by-template-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Archive request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ArchiveTemplateVersionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/versions/{templateversionname}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get template version by template ID and name",
+                "operationId": "get-template-version-by-template-id-and-name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
                         "description": "Template version name",
                         "name": "templateversionname",
                         "in": "path",
@@ -6587,14 +7223,49 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.TemplateVersion"
+                            "$ref": "#/definitions/codersdk.TemplateVersion"
+                        }
+                    }
+                }
+            }
+        },
+        "/templateversions/{templateversion}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Archive template version",
+                "operationId": "archive-template-version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template version ID",
+                        "name": "templateversion",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
                         }
                     }
                 }
             }
         },
-        "/templateversions/{templateversion}/archive": {
-            "post": {
+        "/templateversions/{templateversion}/cancel": {
+            "patch": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -6606,8 +7277,8 @@ const docTemplate = `{
                 "tags": [
                     "Templates"
                 ],
-                "summary": "Archive template version",
-                "operationId": "archive-template-version",
+                "summary": "Cancel template version by ID",
+                "operationId": "cancel-template-version-by-id",
                 "parameters": [
                     {
                         "type": "string",
@@ -6628,8 +7299,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/templateversions/{templateversion}/cancel": {
-            "patch": {
+        "/templateversions/{templateversion}/diff": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -6641,8 +7312,8 @@ const docTemplate = `{
                 "tags": [
                     "Templates"
                 ],
-                "summary": "Cancel template version by ID",
-                "operationId": "cancel-template-version-by-id",
+                "summary": "Get template version diff",
+                "operationId": "get-template-version-diff",
                 "parameters": [
                     {
                         "type": "string",
@@ -6651,13 +7322,21 @@ const docTemplate = `{
                         "name": "templateversion",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template version ID to compare against",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiff"
                         }
                     }
                 }
@@ -6913,6 +7592,52 @@ const docTemplate = `{
                 }
             }
         },
+        "/templateversions/{templateversion}/dry-run/{jobID}/resource-changes": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get template version dry-run resource changes by job ID",
+                "operationId": "get-template-version-dry-run-resource-changes-by-job-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template version ID",
+                        "name": "templateversion",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Job ID",
+                        "name": "jobID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceResourceChange"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/templateversions/{templateversion}/dry-run/{jobID}/resources": {
             "get": {
                 "security": [
@@ -8428,27 +9153,224 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/login-type": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user login type",
+                "operationId": "get-user-login-type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UserLoginType"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get user notification preferences",
+                "operationId": "get-user-notification-preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationPreference"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Update user notification preferences",
+                "operationId": "update-user-notification-preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateUserNotificationPreferences"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationPreference"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/targets": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get user notification targets",
+                "operationId": "get-user-notification-targets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationTarget"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Notification targets are created unverified, and only receive notifications once verified\nwith the code from their test message.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Create user notification target",
+                "operationId": "create-user-notification-target",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification target",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateNotificationTargetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.NotificationTarget"
                         }
                     }
                 }
             }
         },
-        "/users/{user}/login-type": {
-            "get": {
+        "/users/{user}/notifications/targets/{notification_target}": {
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Get user login type",
-                "operationId": "get-user-login-type",
+                "summary": "Delete user notification target",
+                "operationId": "delete-user-notification-target",
                 "parameters": [
                     {
                         "type": "string",
@@ -8456,33 +9378,36 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Notification target ID",
+                        "name": "notification_target",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UserLoginType"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/users/{user}/notifications/preferences": {
-            "get": {
+        "/users/{user}/notifications/targets/{notification_target}/test": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "The test message contains a code with which the notification target can be verified.\nSending a new test message invalidates the code of the previous one.",
                 "tags": [
                     "Notifications"
                 ],
-                "summary": "Get user notification preferences",
-                "operationId": "get-user-notification-preferences",
+                "summary": "Send a test message to user notification target",
+                "operationId": "send-a-test-message-to-user-notification-target",
                 "parameters": [
                     {
                         "type": "string",
@@ -8490,21 +9415,25 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Notification target ID",
+                        "name": "notification_target",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.NotificationPreference"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/users/{user}/notifications/targets/{notification_target}/verify": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -8519,34 +9448,39 @@ const docTemplate = `{
                 "tags": [
                     "Notifications"
                 ],
-                "summary": "Update user notification preferences",
-                "operationId": "update-user-notification-preferences",
+                "summary": "Verify user notification target",
+                "operationId": "verify-user-notification-target",
                 "parameters": [
-                    {
-                        "description": "Preferences",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateUserNotificationPreferences"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "User ID, name, or me",
                         "name": "user",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Notification target ID",
+                        "name": "notification_target",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Verification code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.VerifyNotificationTargetRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.NotificationPreference"
-                            }
+                            "$ref": "#/definitions/codersdk.NotificationTarget"
                         }
                     }
                 }
@@ -10145,6 +11079,39 @@ const docTemplate = `{
                         "description": "Log output format. Accepted: 'json' (default), 'text' (plain text with RFC3339 timestamps and ANSI colors). Not supported with follow=true.",
                         "name": "format",
                         "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return logs from these log source IDs",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return logs with these levels: trace, debug, info, warn or error",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return logs whose output contains this text, case-insensitive",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only return logs created at or after this time",
+                        "name": "since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -10450,6 +11417,39 @@ const docTemplate = `{
                         "description": "Log output format. Accepted: 'json' (default), 'text' (plain text with RFC3339 timestamps and ANSI colors). Not supported with follow=true.",
                         "name": "format",
                         "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return logs from these stages",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return logs with these levels: trace, debug, info, warn or error",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return logs whose output contains this text, case-insensitive",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only return logs created at or after this time",
+                        "name": "since",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -11508,6 +12508,54 @@ const docTemplate = `{
                 }
             }
         },
+        "/workspaces/{workspace}/dry-run": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "description": "Plans a start of the workspace without applying it, to preview\nthe changes to its resources. The returned job is a template\nversion dry-run; its logs and resource changes are read with the\ntemplate version dry-run endpoints.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Builds"
+                ],
+                "summary": "Create workspace dry-run",
+                "operationId": "create-workspace-dry-run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dry-run request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceDryRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ProvisionerJob"
+                        }
+                    }
+                }
+            }
+        },
         "/workspaces/{workspace}/extend": {
             "put": {
                 "security": [
@@ -11727,25 +12775,101 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.WorkspaceAgentPortShare"
+                            "$ref": "#/definitions/codersdk.WorkspaceAgentPortShare"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PortSharing"
+                ],
+                "summary": "Delete workspace agent port share",
+                "operationId": "delete-workspace-agent-port-share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delete port sharing level request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.DeleteWorkspaceAgentPortShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspace}/resolve-autostart": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Resolve workspace autostart by id.",
+                "operationId": "resolve-workspace-autostart-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ResolveAutostartResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/workspaces/{workspace}/session-recordings": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PortSharing"
+                    "Workspaces"
                 ],
-                "summary": "Delete workspace agent port share",
-                "operationId": "delete-workspace-agent-port-share",
+                "summary": "Get workspace session recordings",
+                "operationId": "get-workspace-session-recordings",
                 "parameters": [
                     {
                         "type": "string",
@@ -11756,37 +12880,43 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete port sharing level request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.DeleteWorkspaceAgentPortShareRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceSessionRecording"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/workspaces/{workspace}/resolve-autostart": {
+        "/workspaces/{workspace}/session-recordings/{recording}": {
             "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Resolve workspace autostart by id.",
-                "operationId": "resolve-workspace-autostart-by-id",
+                "summary": "Download workspace session recording",
+                "operationId": "download-workspace-session-recording",
                 "parameters": [
                     {
                         "type": "string",
@@ -11795,14 +12925,19 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Recording ID",
+                        "name": "recording",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.ResolveAutostartResponse"
-                        }
+                        "description": "OK"
                     }
                 }
             }
@@ -13556,7 +14691,8 @@ const docTemplate = `{
                 "jetbrains_connection",
                 "task_auto_pause",
                 "task_manual_pause",
-                "task_resume"
+                "task_resume",
+                "maintenance"
             ],
             "x-enum-varnames": [
                 "BuildReasonInitiator",
@@ -13570,7 +14706,8 @@ const docTemplate = `{
                 "BuildReasonJetbrainsConnection",
                 "BuildReasonTaskAutoPause",
                 "BuildReasonTaskManualPause",
-                "BuildReasonTaskResume"
+                "BuildReasonTaskResume",
+                "BuildReasonMaintenance"
             ]
         },
         "codersdk.CORSBehavior": {
@@ -13705,6 +14842,11 @@ const docTemplate = `{
                 "exit_code": {
                     "description": "ExitCode is the exit code of the SSH session. It is omitted if a\ndisconnect event with the same connection ID has not yet been seen.",
                     "type": "integer"
+                },
+                "session_recording_id": {
+                    "description": "SessionRecordingID is the ID of the recording of the session, if the\ntemplate records sessions and the recording has been uploaded.",
+                    "type": "string",
+                    "format": "uuid"
                 }
             }
         },
@@ -13857,6 +14999,30 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.CreateNotificationTargetRequest": {
+            "type": "object",
+            "required": [
+                "method",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "smtp",
+                        "webhook",
+                        "inbox",
+                        "webpush"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.CreateOrganizationRequest": {
             "type": "object",
             "required": [
@@ -14323,7 +15489,8 @@ const docTemplate = `{
                     "enum": [
                         "start",
                         "stop",
-                        "delete"
+                        "delete",
+                        "hibernate"
                     ],
                     "allOf": [
                         {
@@ -14333,6 +15500,23 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.CreateWorkspaceDryRunRequest": {
+            "type": "object",
+            "properties": {
+                "rich_parameter_values": {
+                    "description": "RichParameterValues override the parameters of the latest build.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceBuildParameter"
+                    }
+                },
+                "template_version_id": {
+                    "description": "TemplateVersionID is the version to plan the build with. The active\nversion of the workspace's template is used if unset.",
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.CreateWorkspaceProxyRequest": {
             "type": "object",
             "required": [
@@ -14373,6 +15557,11 @@ const docTemplate = `{
                         "$ref": "#/definitions/codersdk.WorkspaceBuildParameter"
                     }
                 },
+                "source_workspace_id": {
+                    "description": "SourceWorkspaceID records the workspace this workspace is cloned from.\nThe source workspace must use the same template and be readable by the\ncaller.",
+                    "type": "string",
+                    "format": "uuid"
+                },
                 "template_id": {
                     "description": "TemplateID specifies which template should be used for creating the workspace.",
                     "type": "string",
@@ -15893,6 +17082,76 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.NotificationDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt_count": {
+                    "description": "AttemptCount is the number of delivery attempts made so far; every attempt after the first is a retry.",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "message_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "smtp",
+                        "webhook",
+                        "inbox",
+                        "webpush",
+                        "slack",
+                        "teams"
+                    ]
+                },
+                "next_retry_after": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "response_status_code": {
+                    "description": "ResponseStatusCode is the HTTP status code with which the last delivery attempt was rejected, for methods which\ndeliver over HTTP.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "leased",
+                        "sent",
+                        "permanent_failure",
+                        "temporary_failure",
+                        "unknown",
+                        "inhibited"
+                    ]
+                },
+                "status_reason": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "template_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.NotificationMethodsResponse": {
             "type": "object",
             "properties": {
@@ -15917,9 +17176,58 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
+                "target_ids": {
+                    "description": "TargetIDs are the notification targets to which notifications of this template are delivered, instead of by\nthe template's method. Only verified targets receive notifications.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "codersdk.NotificationTarget": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the webhook endpoint or email address notifications are delivered to. It is empty for methods which\ndeliver to the user directly, such as inbox and webpush.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "smtp",
+                        "webhook",
+                        "inbox",
+                        "webpush"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string",
                     "format": "date-time"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "verified_at": {
+                    "type": "string",
+                    "format": "date-time"
                 }
             }
         },
@@ -16134,6 +17442,13 @@ const docTemplate = `{
                             "$ref": "#/definitions/serpent.URL"
                         }
                     ]
+                },
+                "signing_secrets": {
+                    "description": "The secrets with which request bodies are signed, see WebhookSignatureHeader.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -16883,6 +18198,33 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.OrganizationNotificationWebhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "slack",
+                        "teams"
+                    ]
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "codersdk.OrganizationSyncSettings": {
             "type": "object",
             "properties": {
@@ -17208,22 +18550,125 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
-                "app_name": {
-                    "$ref": "#/definitions/codersdk.UsageAppName"
+                "app_name": {
+                    "$ref": "#/definitions/codersdk.UsageAppName"
+                }
+            }
+        },
+        "codersdk.PprofConfig": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/serpent.HostPort"
+                },
+                "enable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.PrebuildForecastHour": {
+            "type": "object",
+            "properties": {
+                "actual_claims": {
+                    "description": "ActualClaims is the number of prebuilds claimed during the hour. It is zero for hours in the future.",
+                    "type": "integer"
+                },
+                "desired_instances": {
+                    "type": "integer"
+                },
+                "forecast_claims": {
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "codersdk.PrebuildPresetForecast": {
+            "type": "object",
+            "properties": {
+                "adaptive": {
+                    "description": "Adaptive is true if the desired instances of the preset are forecasted from its claim history.",
+                    "type": "boolean"
+                },
+                "desired_instances": {
+                    "type": "integer"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.PrebuildForecastHour"
+                    }
+                },
+                "preset_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "preset_name": {
+                    "type": "string"
                 }
             }
         },
-        "codersdk.PprofConfig": {
+        "codersdk.PrebuildReadinessCheck": {
             "type": "object",
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/serpent.HostPort"
+                "app": {
+                    "description": "App is the slug of an app that must be healthy. Required for app checks.",
+                    "type": "string"
                 },
-                "enable": {
-                    "type": "boolean"
+                "metadata_key": {
+                    "description": "MetadataKey is the key of the agent metadata item to check. Required for metadata checks.",
+                    "type": "string"
+                },
+                "metadata_value": {
+                    "description": "MetadataValue is a regular expression the agent metadata value must match. Required for metadata checks.",
+                    "type": "string"
+                },
+                "script": {
+                    "description": "Script is the display name of an agent script that must exit successfully. Required for script checks.",
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "script",
+                        "metadata",
+                        "app"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.PrebuildReadinessCheckType"
+                        }
+                    ]
                 }
             }
         },
+        "codersdk.PrebuildReadinessCheckType": {
+            "type": "string",
+            "enum": [
+                "script",
+                "metadata",
+                "app"
+            ],
+            "x-enum-varnames": [
+                "PrebuildReadinessCheckTypeScript",
+                "PrebuildReadinessCheckTypeMetadata",
+                "PrebuildReadinessCheckTypeApp"
+            ]
+        },
+        "codersdk.PrebuildReadinessStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "passed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "PrebuildReadinessStatusPending",
+                "PrebuildReadinessStatusPassed",
+                "PrebuildReadinessStatusFailed"
+            ]
+        },
         "codersdk.PrebuildsConfig": {
             "type": "object",
             "properties": {
@@ -17253,6 +18698,61 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.PrebuiltWorkspaceStatus": {
+            "type": "object",
+            "properties": {
+                "agents_ready": {
+                    "description": "AgentsReady is true if all agents of the prebuilt workspace are ready.",
+                    "type": "boolean"
+                },
+                "checked_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "eligible": {
+                    "description": "Eligible is true if the prebuilt workspace can be claimed.",
+                    "type": "boolean"
+                },
+                "failure_reasons": {
+                    "description": "FailureReasons explains why the readiness checks are pending or failed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preset_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "preset_name": {
+                    "type": "string"
+                },
+                "readiness_status": {
+                    "description": "ReadinessStatus is the result of the preset's readiness checks for the latest build.\nIt is empty if the preset has no readiness checks.",
+                    "enum": [
+                        "pending",
+                        "passed",
+                        "failed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.PrebuildReadinessStatus"
+                        }
+                    ]
+                },
+                "workspace_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "workspace_name": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.Preset": {
             "type": "object",
             "properties": {
@@ -18257,6 +19757,21 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.ResourceChangeAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "replace",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "ResourceChangeActionCreate",
+                "ResourceChangeActionUpdate",
+                "ResourceChangeActionReplace",
+                "ResourceChangeActionDelete"
+            ]
+        },
         "codersdk.ResourceType": {
             "type": "string",
             "enum": [
@@ -18285,7 +19800,11 @@ const docTemplate = `{
                 "idp_sync_settings_role",
                 "workspace_agent",
                 "workspace_app",
-                "task"
+                "task",
+                "organization_notification_webhook",
+                "template_preset_readiness_checks",
+                "template_group_schedule_override",
+                "template_adaptive_prebuilds"
             ],
             "x-enum-varnames": [
                 "ResourceTypeTemplate",
@@ -18313,7 +19832,11 @@ const docTemplate = `{
                 "ResourceTypeIdpSyncSettingsRole",
                 "ResourceTypeWorkspaceAgent",
                 "ResourceTypeWorkspaceApp",
-                "ResourceTypeTask"
+                "ResourceTypeTask",
+                "ResourceTypeOrganizationNotificationWebhook",
+                "ResourceTypeTemplatePresetReadinessChecks",
+                "ResourceTypeTemplateGroupScheduleOverride",
+                "ResourceTypeTemplateAdaptivePrebuilds"
             ]
         },
         "codersdk.Response": {
@@ -18999,6 +20522,10 @@ const docTemplate = `{
                     "description": "FailureTTLMillis, TimeTilDormantMillis, and TimeTilDormantAutoDeleteMillis are enterprise-only. Their\nvalues are used if your license is entitled to use the advanced\ntemplate scheduling feature.",
                     "type": "integer"
                 },
+                "hibernate_idle_threshold_ms": {
+                    "description": "HibernateIdleThresholdMillis makes autostop hibernate workspaces that\nhave been inactive for less than this duration instead of stopping\nthem. 0 means workspaces are always stopped.",
+                    "type": "integer"
+                },
                 "icon": {
                     "type": "string"
                 },
@@ -19006,6 +20533,10 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
+                "maintenance_window_schedule": {
+                    "description": "MaintenanceWindowSchedule is a cron time range, e.g. \"* 2-4 * * 6\",\nduring which idle workspaces running an outdated template version are\nrestarted onto the active version. Empty when unset.",
+                    "type": "string"
+                },
                 "max_port_share_level": {
                     "$ref": "#/definitions/codersdk.WorkspaceAgentPortShareLevel"
                 },
@@ -19036,6 +20567,14 @@ const docTemplate = `{
                     "description": "RequireActiveVersion mandates that workspaces are built with the active\ntemplate version.",
                     "type": "boolean"
                 },
+                "session_recording_enabled": {
+                    "description": "SessionRecordingEnabled records interactive terminal sessions, such as\nSSH and reconnecting PTY sessions, in workspaces of this template.",
+                    "type": "boolean"
+                },
+                "session_recording_include_input": {
+                    "description": "SessionRecordingIncludeInput also records the input typed during a\nsession. Only used when SessionRecordingEnabled is set.",
+                    "type": "boolean"
+                },
                 "time_til_dormant_autodelete_ms": {
                     "type": "integer"
                 },
@@ -19068,6 +20607,23 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateAdaptivePrebuilds": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "lookback_weeks": {
+                    "type": "integer"
+                },
+                "max_instances": {
+                    "type": "integer"
+                },
+                "min_instances": {
+                    "type": "integer"
+                }
+            }
+        },
         "codersdk.TemplateAppUsage": {
             "type": "object",
             "properties": {
@@ -19256,6 +20812,55 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateGroupScheduleOverride": {
+            "type": "object",
+            "properties": {
+                "autostart_blocked_days": {
+                    "description": "AutostartBlockedDays are days of the week on which autostart is not\nallowed, in addition to the days the template does not allow.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "monday",
+                            "tuesday",
+                            "wednesday",
+                            "thursday",
+                            "friday",
+                            "saturday",
+                            "sunday"
+                        ]
+                    }
+                },
+                "autostop_requirement": {
+                    "description": "AutostopRequirement adds days on which restarts are required to the\ntemplate's autostop requirement. A Weeks value of 0 keeps the template's\ninterval.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateAutostopRequirement"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "group_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "max_ttl_ms": {
+                    "description": "MaxTTLMillis caps the time until shutdown of workspaces, including\nworkspaces that would otherwise never stop. 0 means no cap.",
+                    "type": "integer"
+                },
+                "template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "codersdk.TemplateInsightsIntervalReport": {
             "type": "object",
             "properties": {
@@ -19385,6 +20990,50 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplatePrebuildsForecast": {
+            "type": "object",
+            "properties": {
+                "presets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.PrebuildPresetForecast"
+                    }
+                }
+            }
+        },
+        "codersdk.TemplatePrebuildsStatus": {
+            "type": "object",
+            "properties": {
+                "prebuilds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.PrebuiltWorkspaceStatus"
+                    }
+                }
+            }
+        },
+        "codersdk.TemplatePresetReadinessChecks": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.PrebuildReadinessCheck"
+                    }
+                },
+                "preset_name": {
+                    "type": "string"
+                },
+                "timeout_ms": {
+                    "description": "TimeoutMillis is how long checks may remain pending after the agents are ready\nbefore the prebuilt workspace is considered unhealthy and replaced.",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "codersdk.TemplateRole": {
             "type": "string",
             "enum": [
@@ -19539,6 +21188,57 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionDiff": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionFileDiff"
+                    }
+                },
+                "from_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionParameterDiff"
+                    }
+                },
+                "resources": {
+                    "description": "Resources are the resources declared by the Terraform plan of the\ntemplate version import, keyed by their address.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionItemDiff"
+                    }
+                },
+                "to_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionItemDiff"
+                    }
+                }
+            }
+        },
+        "codersdk.TemplateVersionDiffChange": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "modified"
+            ],
+            "x-enum-varnames": [
+                "TemplateVersionDiffChangeAdded",
+                "TemplateVersionDiffChangeRemoved",
+                "TemplateVersionDiffChangeModified"
+            ]
+        },
         "codersdk.TemplateVersionExternalAuth": {
             "type": "object",
             "properties": {
@@ -19548,19 +21248,88 @@ const docTemplate = `{
                 "authenticated": {
                     "type": "boolean"
                 },
-                "display_icon": {
-                    "type": "string"
+                "display_icon": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "optional": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.TemplateVersionFieldDiff": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.TemplateVersionFileDiff": {
+            "type": "object",
+            "properties": {
+                "binary": {
+                    "description": "Binary is true for files that are not text or too large to diff. Diff\nis empty for those.",
+                    "type": "boolean"
+                },
+                "change": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffChange"
+                        }
+                    ]
                 },
-                "display_name": {
+                "diff": {
+                    "description": "Diff is the unified diff of the file.",
                     "type": "string"
                 },
-                "id": {
+                "path": {
                     "type": "string"
+                }
+            }
+        },
+        "codersdk.TemplateVersionItemDiff": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffChange"
+                        }
+                    ]
                 },
-                "optional": {
-                    "type": "boolean"
+                "fields": {
+                    "description": "Fields lists the changed fields of modified items.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionFieldDiff"
+                    }
                 },
-                "type": {
+                "name": {
                     "type": "string"
                 }
             }
@@ -19652,6 +21421,46 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionParameterDiff": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffChange"
+                        }
+                    ]
+                },
+                "fields": {
+                    "description": "Fields lists the changed fields of modified parameters, including\nchanges to options that exist in both versions.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionFieldDiff"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options_added": {
+                    "description": "OptionsAdded and OptionsRemoved list the values of options only present\nin one of the versions.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "options_removed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "codersdk.TemplateVersionParameterOption": {
             "type": "object",
             "properties": {
@@ -19841,6 +21650,17 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateOrganizationNotificationWebhookRequest": {
+            "type": "object",
+            "required": [
+                "endpoint"
+            ],
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.UpdateOrganizationRequest": {
             "type": "object",
             "properties": {
@@ -19958,9 +21778,17 @@ const docTemplate = `{
                 "failure_ttl_ms": {
                     "type": "integer"
                 },
+                "hibernate_idle_threshold_ms": {
+                    "description": "HibernateIdleThresholdMillis makes autostop hibernate workspaces that\nhave been inactive for less than this duration instead of stopping\nthem. 0 disables hibernation on autostop.",
+                    "type": "integer"
+                },
                 "icon": {
                     "type": "string"
                 },
+                "maintenance_window_schedule": {
+                    "description": "MaintenanceWindowSchedule is a cron time range, e.g. \"* 2-4 * * 6\",\nduring which idle outdated workspaces are restarted onto the active\nversion. An empty string disables the maintenance window.",
+                    "type": "string"
+                },
                 "max_port_share_level": {
                     "$ref": "#/definitions/codersdk.WorkspaceAgentPortShareLevel"
                 },
@@ -19971,6 +21799,14 @@ const docTemplate = `{
                     "description": "RequireActiveVersion mandates workspaces built using this template\nuse the active version of the template. This option has no\neffect on template admins.",
                     "type": "boolean"
                 },
+                "session_recording_enabled": {
+                    "description": "SessionRecordingEnabled records interactive terminal sessions in\nworkspaces of this template. Sessions that are already running are not\naffected.",
+                    "type": "boolean"
+                },
+                "session_recording_include_input": {
+                    "description": "SessionRecordingIncludeInput also records the input typed during a\nsession, which may contain secrets such as passwords.",
+                    "type": "boolean"
+                },
                 "time_til_dormant_autodelete_ms": {
                     "type": "integer"
                 },
@@ -19991,6 +21827,20 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateTemplatePresetReadinessChecksRequest": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.PrebuildReadinessCheck"
+                    }
+                },
+                "timeout_ms": {
+                    "type": "integer"
+                }
+            }
+        },
         "codersdk.UpdateUserAppearanceSettingsRequest": {
             "type": "object",
             "required": [
@@ -20014,6 +21864,16 @@ const docTemplate = `{
                     "additionalProperties": {
                         "type": "boolean"
                     }
+                },
+                "template_targets_map": {
+                    "description": "TemplateTargetsMap routes notifications of the given templates to the given notification targets, replacing\ntheir current routes. An empty list of targets restores delivery by the template's method.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
@@ -20145,6 +22005,32 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpsertTemplateGroupScheduleOverrideRequest": {
+            "type": "object",
+            "properties": {
+                "autostart_blocked_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "monday",
+                            "tuesday",
+                            "wednesday",
+                            "thursday",
+                            "friday",
+                            "saturday",
+                            "sunday"
+                        ]
+                    }
+                },
+                "autostop_requirement": {
+                    "$ref": "#/definitions/codersdk.TemplateAutostopRequirement"
+                },
+                "max_ttl_ms": {
+                    "type": "integer"
+                }
+            }
+        },
         "codersdk.UpsertWorkspaceAgentPortShareRequest": {
             "type": "object",
             "properties": {
@@ -20517,6 +22403,49 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UserTemplateSchedule": {
+            "type": "object",
+            "properties": {
+                "allow_user_autostart": {
+                    "type": "boolean"
+                },
+                "allow_user_autostop": {
+                    "type": "boolean"
+                },
+                "autostart_requirement": {
+                    "$ref": "#/definitions/codersdk.TemplateAutostartRequirement"
+                },
+                "autostop_requirement": {
+                    "$ref": "#/definitions/codersdk.TemplateAutostopRequirement"
+                },
+                "default_ttl_ms": {
+                    "type": "integer"
+                },
+                "group_ids": {
+                    "description": "GroupIDs are the groups of the user that have schedule overrides for\nthe template.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "max_ttl_ms": {
+                    "type": "integer"
+                },
+                "quiet_hours_schedule": {
+                    "description": "QuietHoursSchedule is the user's quiet hours schedule, during which\nrestarts required by the autostop requirement happen. It is empty if\nquiet hours are not enabled on the deployment.",
+                    "type": "string"
+                },
+                "template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.ValidateUserPasswordRequest": {
             "type": "object",
             "required": [
@@ -20576,6 +22505,17 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.VerifyNotificationTargetRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.WebpushSubscription": {
             "type": "object",
             "properties": {
@@ -20687,6 +22627,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/codersdk.SharedWorkspaceActor"
                     }
                 },
+                "source_workspace_id": {
+                    "description": "SourceWorkspaceID, if set, is the workspace this workspace was cloned\nfrom.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/uuid.NullUUID"
+                        }
+                    ]
+                },
                 "task_id": {
                     "description": "TaskID, if set, indicates that the workspace is relevant to the given codersdk.Task.",
                     "allOf": [
@@ -21270,6 +23218,12 @@ const docTemplate = `{
                 "cron": {
                     "type": "string"
                 },
+                "depends_on": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "display_name": {
                     "type": "string"
                 },
@@ -21298,6 +23252,9 @@ const docTemplate = `{
                 },
                 "timeout": {
                     "type": "integer"
+                },
+                "unit_name": {
+                    "type": "string"
                 }
             }
         },
@@ -21607,7 +23564,8 @@ const docTemplate = `{
                     "enum": [
                         "start",
                         "stop",
-                        "delete"
+                        "delete",
+                        "hibernate"
                     ],
                     "allOf": [
                         {
@@ -21924,7 +23882,8 @@ const docTemplate = `{
                     "enum": [
                         "start",
                         "stop",
-                        "delete"
+                        "delete",
+                        "hibernate"
                     ],
                     "allOf": [
                         {
@@ -21934,6 +23893,44 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceResourceChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "enum": [
+                        "create",
+                        "update",
+                        "replace",
+                        "delete"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.ResourceChangeAction"
+                        }
+                    ]
+                },
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "persistent": {
+                    "description": "Persistent is true if the resource is kept while the workspace is\nstopped, such as a home volume. Replacing or deleting a persistent\nresource loses its data.",
+                    "type": "boolean"
+                },
+                "replace_paths": {
+                    "description": "ReplacePaths are the attribute paths which force the resource to be\nreplaced.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.WorkspaceResourceMetadata": {
             "type": "object",
             "properties": {
@@ -21961,6 +23958,55 @@ const docTemplate = `{
                 "WorkspaceRoleDeleted"
             ]
         },
+        "codersdk.WorkspaceSessionRecording": {
+            "type": "object",
+            "properties": {
+                "agent_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "connection_id": {
+                    "description": "ConnectionID is the ID of the connection log entry of the session.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "ended_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "include_input": {
+                    "description": "IncludeInput is true if the recording contains what the user typed.",
+                    "type": "boolean"
+                },
+                "size": {
+                    "description": "Size is the size of the recording in bytes.",
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "truncated": {
+                    "description": "Truncated is true if the session exceeded the maximum recording size\nand its end is missing.",
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/codersdk.ConnectionType"
+                },
+                "workspace_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.WorkspaceSharingSettings": {
             "type": "object",
             "properties": {
@@ -22001,12 +24047,17 @@ const docTemplate = `{
             "enum": [
                 "start",
                 "stop",
-                "delete"
+                "delete",
+                "hibernate"
             ],
+            "x-enum-comments": {
+                "WorkspaceTransitionHibernate": "WorkspaceTransitionHibernate releases the workspace's compute while retaining persistent resources. Templates can tell it apart from a stop through the ` + "`" + `transition` + "`" + ` attribute of the ` + "`" + `coder_workspace` + "`" + ` data source."
+            },
             "x-enum-varnames": [
                 "WorkspaceTransitionStart",
                 "WorkspaceTransitionStop",
-                "WorkspaceTransitionDelete"
+                "WorkspaceTransitionDelete",
+                "WorkspaceTransitionHibernate"
             ]
         },
         "codersdk.WorkspaceUser": {
@@ -32,7 +32,11 @@ type Auditable interface {
 		idpsync.OrganizationSyncSettings |
 		idpsync.GroupSyncSettings |
 		idpsync.RoleSyncSettings |
-		database.TaskTable
+		database.TaskTable |
+		database.OrganizationNotificationWebhook |
+		database.TemplatePresetReadinessCheck |
+		database.TemplateGroupScheduleOverride |
+		database.TemplateAdaptivePrebuild
 }
 
 // Map is a map of changed fields in an audited resource. It maps field names to
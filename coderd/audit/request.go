@@ -132,6 +132,14 @@ func ResourceTarget[T Auditable](tgt T) string {
 		return "Organization Role Sync"
 	case database.TaskTable:
 		return typed.Name
+	case database.OrganizationNotificationWebhook:
+		return string(typed.Method)
+	case database.TemplatePresetReadinessCheck:
+		return typed.PresetName
+	case database.TemplateGroupScheduleOverride:
+		return typed.GroupID.String()
+	case database.TemplateAdaptivePrebuild:
+		return "Adaptive Prebuilds"
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceTarget", tgt))
 	}
@@ -196,6 +204,14 @@ func ResourceID[T Auditable](tgt T) uuid.UUID {
 		return noID // Org field on audit log has org id
 	case database.TaskTable:
 		return typed.ID
+	case database.OrganizationNotificationWebhook:
+		return noID // Org field on audit log has org id, and the target is the method
+	case database.TemplatePresetReadinessCheck:
+		return typed.TemplateID
+	case database.TemplateGroupScheduleOverride:
+		return typed.TemplateID
+	case database.TemplateAdaptivePrebuild:
+		return typed.TemplateID
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceID", tgt))
 	}
@@ -251,6 +267,14 @@ func ResourceType[T Auditable](tgt T) database.ResourceType {
 		return database.ResourceTypeIdpSyncSettingsGroup
 	case database.TaskTable:
 		return database.ResourceTypeTask
+	case database.OrganizationNotificationWebhook:
+		return database.ResourceTypeOrganizationNotificationWebhook
+	case database.TemplatePresetReadinessCheck:
+		return database.ResourceTypeTemplatePresetReadinessChecks
+	case database.TemplateGroupScheduleOverride:
+		return database.ResourceTypeTemplateGroupScheduleOverride
+	case database.TemplateAdaptivePrebuild:
+		return database.ResourceTypeTemplateAdaptivePrebuilds
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceType", typed))
 	}
@@ -309,6 +333,14 @@ func ResourceRequiresOrgID[T Auditable]() bool {
 		return true
 	case database.TaskTable:
 		return true
+	case database.OrganizationNotificationWebhook:
+		return true
+	case database.TemplatePresetReadinessCheck:
+		return true
+	case database.TemplateGroupScheduleOverride:
+		return true
+	case database.TemplateAdaptivePrebuild:
+		return true
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceRequiresOrgID", tgt))
 	}
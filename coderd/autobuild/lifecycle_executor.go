@@ -272,7 +272,7 @@ func (e *Executor) runOnce(t time.Time) Stats {
 						return xerrors.Errorf("get latest provisioner job: %w", err)
 					}
 
-					templateSchedule, err := (*(e.templateScheduleStore.Load())).Get(e.ctx, tx, ws.TemplateID)
+					templateSchedule, err := (*(e.templateScheduleStore.Load())).GetForUser(e.ctx, tx, ws.TemplateID, ws.OwnerID)
 					if err != nil {
 						return xerrors.Errorf("get template scheduling options: %w", err)
 					}
@@ -307,6 +307,11 @@ func (e *Executor) runOnce(t time.Time) Stats {
 					accessControl := (*(e.accessControlStore.Load())).GetTemplateAccessControl(tmpl)
 
 					nextTransition, reason, err := getNextTransition(user, ws, latestBuild, latestJob, templateSchedule, currentTick)
+					if err != nil {
+						// Outdated workspaces may still be restarted onto the
+						// active version by the template's maintenance window.
+						nextTransition, reason, err = getMaintenanceTransition(user, ws, tmpl, latestBuild, latestJob, currentTick)
+					}
 					if err != nil {
 						log.Debug(e.ctx, "skipping workspace", slog.Error(err))
 						// err is used to indicate that a workspace is not eligible
@@ -341,7 +346,7 @@ func (e *Executor) runOnce(t time.Time) Stats {
 							BuildMetrics(e.workspaceBuilderMetrics)
 						log.Debug(e.ctx, "auto building workspace", slog.F("transition", nextTransition))
 						if nextTransition == database.WorkspaceTransitionStart &&
-							useActiveVersion(accessControl, ws) {
+							(reason == database.BuildReasonMaintenance || useActiveVersion(accessControl, ws)) {
 							log.Debug(e.ctx, "autostarting with active version")
 							builder = builder.ActiveVersion()
 
@@ -502,6 +507,8 @@ func (e *Executor) runOnce(t time.Time) Stats {
 		e.log.Error(e.ctx, "workspace scheduling errgroup failed", slog.Error(err))
 	}
 
+	e.notifyMaintenanceWindows(currentTick)
+
 	return stats
 }
 
@@ -529,7 +536,7 @@ func getNextTransition(
 		if ws.TaskID.Valid {
 			return database.WorkspaceTransitionStop, database.BuildReasonTaskAutoPause, nil
 		}
-		return database.WorkspaceTransitionStop, database.BuildReasonAutostop, nil
+		return autostopTransition(user, ws, templateSchedule, currentTick), database.BuildReasonAutostop, nil
 	case isEligibleForAutostart(user, ws, latestBuild, latestJob, templateSchedule, currentTick):
 		return database.WorkspaceTransitionStart, database.BuildReasonAutostart, nil
 	case isEligibleForFailedStop(latestBuild, latestJob, templateSchedule, currentTick):
@@ -571,9 +578,9 @@ func isEligibleForAutostart(user database.User, ws database.Workspace, build dat
 		return false
 	}
 
-	// If the last transition for the workspace was not 'stop' then the workspace
-	// cannot be started.
-	if build.Transition != database.WorkspaceTransitionStop {
+	// If the last transition for the workspace was not 'stop' or 'hibernate'
+	// then the workspace cannot be started.
+	if build.Transition != database.WorkspaceTransitionStop && build.Transition != database.WorkspaceTransitionHibernate {
 		return false
 	}
 
@@ -616,6 +623,20 @@ func isEligibleForAutostop(user database.User, ws database.Workspace, build data
 		!currentTick.Before(build.Deadline)
 }
 
+// autostopTransition returns the transition used to autostop the workspace.
+// Workspaces that were used recently are hibernated so they can be resumed
+// quickly, while workspaces that have been inactive for longer than the
+// template's hibernate idle threshold, or whose owner is suspended, are
+// stopped.
+func autostopTransition(user database.User, ws database.Workspace, templateSchedule schedule.TemplateScheduleOptions, currentTick time.Time) database.WorkspaceTransition {
+	if user.Status != database.UserStatusSuspended &&
+		templateSchedule.HibernateIdleThreshold > 0 &&
+		currentTick.Sub(ws.LastUsedAt) < templateSchedule.HibernateIdleThreshold {
+		return database.WorkspaceTransitionHibernate
+	}
+	return database.WorkspaceTransitionStop
+}
+
 // isEligibleForDormantStop returns true if the workspace should be dormant
 // for breaching the inactivity threshold of the template.
 func isEligibleForDormantStop(ws database.Workspace, templateSchedule schedule.TemplateScheduleOptions, currentTick time.Time) bool {
@@ -112,6 +112,79 @@ func Test_getNextTransition_TaskAutoPause(t *testing.T) {
 	}
 }
 
+func Test_getNextTransition_Hibernate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	okUser := database.User{Status: database.UserStatusActive}
+	okBuild := database.WorkspaceBuild{
+		Transition: database.WorkspaceTransitionStart,
+		Deadline:   now.Add(-time.Hour),
+	}
+	okJob := database.ProvisionerJob{
+		JobStatus: database.ProvisionerJobStatusSucceeded,
+	}
+	hibernateSchedule := schedule.TemplateScheduleOptions{
+		HibernateIdleThreshold: 4 * time.Hour,
+	}
+
+	testCases := []struct {
+		Name               string
+		User               database.User
+		LastUsedAt         time.Time
+		TemplateSchedule   schedule.TemplateScheduleOptions
+		ExpectedTransition database.WorkspaceTransition
+	}{
+		{
+			Name:               "RecentlyUsed",
+			User:               okUser,
+			LastUsedAt:         now.Add(-time.Hour),
+			TemplateSchedule:   hibernateSchedule,
+			ExpectedTransition: database.WorkspaceTransitionHibernate,
+		},
+		{
+			Name:               "IdleBeyondThreshold",
+			User:               okUser,
+			LastUsedAt:         now.Add(-5 * time.Hour),
+			TemplateSchedule:   hibernateSchedule,
+			ExpectedTransition: database.WorkspaceTransitionStop,
+		},
+		{
+			Name:               "ThresholdDisabled",
+			User:               okUser,
+			LastUsedAt:         now.Add(-time.Hour),
+			TemplateSchedule:   schedule.TemplateScheduleOptions{},
+			ExpectedTransition: database.WorkspaceTransitionStop,
+		},
+		{
+			Name:               "SuspendedUser",
+			User:               database.User{Status: database.UserStatusSuspended},
+			LastUsedAt:         now.Add(-time.Hour),
+			TemplateSchedule:   hibernateSchedule,
+			ExpectedTransition: database.WorkspaceTransitionStop,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			transition, reason, err := getNextTransition(
+				tc.User,
+				database.Workspace{LastUsedAt: tc.LastUsedAt},
+				okBuild,
+				okJob,
+				tc.TemplateSchedule,
+				now,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedTransition, transition)
+			require.Equal(t, database.BuildReasonAutostop, reason)
+		})
+	}
+}
+
 func Test_isEligibleForAutostart(t *testing.T) {
 	t.Parallel()
 
@@ -237,6 +310,20 @@ func Test_isEligibleForAutostart(t *testing.T) {
 			Tick:             okTick,
 			ExpectedResponse: false,
 		},
+		{
+			Name:      "BuildTransitionHibernate",
+			User:      okUser,
+			Workspace: okWorkspace,
+			Build: func(b database.WorkspaceBuild) database.WorkspaceBuild {
+				cpy := b
+				cpy.Transition = database.WorkspaceTransitionHibernate
+				return cpy
+			}(okBuild),
+			Job:              okJob,
+			TemplateSchedule: okTemplateSchedule,
+			Tick:             okTick,
+			ExpectedResponse: true,
+		},
 		{
 			Name:      "BuildTransitionNotStop",
 			User:      okUser,
@@ -1339,6 +1339,140 @@ func TestNotifications(t *testing.T) {
 	})
 }
 
+func TestExecutorMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	// setup provisions a running workspace, then activates a new template
+	// version so the workspace is outdated, and sets the maintenance window.
+	setup := func(t *testing.T, window string) (*codersdk.Client, database.Store, chan time.Time, chan autobuild.Stats, *notificationstest.FakeEnqueuer, codersdk.Workspace, codersdk.TemplateVersion) {
+		t.Helper()
+
+		var (
+			ctx        = testutil.Context(t, testutil.WaitLong)
+			tickCh     = make(chan time.Time)
+			statsCh    = make(chan autobuild.Stats)
+			enqueuer   = &notificationstest.FakeEnqueuer{}
+			client, db = coderdtest.NewWithDatabase(t, &coderdtest.Options{
+				AutobuildTicker:          tickCh,
+				IncludeProvisionerDaemon: true,
+				AutobuildStats:           statsCh,
+				NotificationsEnqueuer:    enqueuer,
+			})
+			workspace = mustProvisionWorkspace(t, client)
+		)
+		require.Equal(t, codersdk.WorkspaceTransitionStart, workspace.LatestBuild.Transition)
+
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, workspace.OrganizationID, nil, workspace.TemplateID)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, newVersion.ID)
+		require.NoError(t, client.UpdateActiveTemplateVersion(ctx, workspace.TemplateID, codersdk.UpdateActiveTemplateVersion{
+			ID: newVersion.ID,
+		}))
+
+		template, err := client.Template(ctx, workspace.TemplateID)
+		require.NoError(t, err)
+		_, err = client.UpdateTemplateMeta(ctx, template.ID, codersdk.UpdateTemplateMeta{
+			DefaultTTLMillis:          template.DefaultTTLMillis,
+			ActivityBumpMillis:        template.ActivityBumpMillis,
+			MaintenanceWindowSchedule: ptr.Ref(window),
+		})
+		require.NoError(t, err)
+
+		return client, db, tickCh, statsCh, enqueuer, workspace, newVersion
+	}
+
+	tick := func(t *testing.T, db database.Store, tickCh chan time.Time, statsCh chan autobuild.Stats, workspace codersdk.Workspace, tickTime time.Time) autobuild.Stats {
+		t.Helper()
+
+		p, err := coderdtest.GetProvisionerForTags(db, time.Now(), workspace.OrganizationID, nil)
+		require.NoError(t, err)
+		coderdtest.UpdateProvisionerLastSeenAt(t, db, p.ID, tickTime)
+		go func() {
+			tickCh <- tickTime
+		}()
+		return testutil.TryReceive(testutil.Context(t, testutil.WaitShort), t, statsCh)
+	}
+
+	t.Run("RestartsIdleWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		client, db, tickCh, statsCh, enqueuer, workspace, newVersion := setup(t, "* * * * *")
+
+		// When: the executor ticks while the workspace has been idle.
+		tickTime := workspace.LastUsedAt.Add(30 * time.Minute)
+		stats := tick(t, db, tickCh, statsCh, workspace, tickTime)
+
+		// Then: the workspace is stopped for maintenance.
+		require.Len(t, stats.Errors, 0)
+		require.Equal(t, database.WorkspaceTransitionStop, stats.Transitions[workspace.ID])
+		ws := coderdtest.MustWorkspace(t, client, workspace.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, ws.LatestBuild.ID)
+		require.Equal(t, codersdk.BuildReasonMaintenance, ws.LatestBuild.Reason)
+
+		// When: the executor ticks again after the stop completed.
+		stats = tick(t, db, tickCh, statsCh, workspace, tickTime.Add(time.Minute))
+
+		// Then: the workspace is started on the active version.
+		require.Len(t, stats.Errors, 0)
+		require.Equal(t, database.WorkspaceTransitionStart, stats.Transitions[workspace.ID])
+		ws = coderdtest.MustWorkspace(t, client, workspace.ID)
+		require.Equal(t, codersdk.BuildReasonMaintenance, ws.LatestBuild.Reason)
+		require.Equal(t, newVersion.ID, ws.LatestBuild.TemplateVersionID)
+
+		sent := enqueuer.Sent(notificationstest.WithTemplateID(notifications.TemplateWorkspaceAutoUpdated))
+		require.Len(t, sent, 1)
+		require.Equal(t, workspace.OwnerID, sent[0].UserID)
+		require.Equal(t, "maintenance", sent[0].Labels["reason"])
+	})
+
+	t.Run("SkipsActiveWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		client, db, tickCh, statsCh, _, workspace, _ := setup(t, "* * * * *")
+
+		// When: the executor ticks shortly after the workspace was used.
+		stats := tick(t, db, tickCh, statsCh, workspace, workspace.LastUsedAt.Add(time.Minute))
+
+		// Then: the workspace is left running.
+		require.Len(t, stats.Errors, 0)
+		require.Len(t, stats.Transitions, 0)
+		ws := coderdtest.MustWorkspace(t, client, workspace.ID)
+		require.Equal(t, workspace.LatestBuild.ID, ws.LatestBuild.ID)
+	})
+
+	t.Run("Notifications", func(t *testing.T) {
+		t.Parallel()
+
+		_, db, tickCh, statsCh, enqueuer, workspace, newVersion := setup(t, "* 2-4 * * 6")
+
+		// When: the executor ticks an hour before the window opens.
+		opens := time.Date(2024, 10, 12, 2, 0, 0, 0, time.UTC)
+		stats := tick(t, db, tickCh, statsCh, workspace, opens.Add(-time.Hour))
+		require.Len(t, stats.Errors, 0)
+
+		// Then: the owner of the outdated workspace is notified.
+		sent := enqueuer.Sent(notificationstest.WithTemplateID(notifications.TemplateWorkspaceMaintenanceScheduled))
+		require.Len(t, sent, 1)
+		require.Equal(t, workspace.OwnerID, sent[0].UserID)
+		require.Equal(t, workspace.Name, sent[0].Labels["name"])
+		require.Equal(t, newVersion.Name, sent[0].Labels["template_version_name"])
+		require.Equal(t, "2024-10-12 02:00 UTC", sent[0].Labels["window_start"])
+		require.Contains(t, sent[0].Targets, workspace.ID)
+
+		// When: the executor ticks when the window closes.
+		stats = tick(t, db, tickCh, statsCh, workspace, opens.Add(3*time.Hour))
+		require.Len(t, stats.Errors, 0)
+
+		// Then: the template admins receive a report listing the workspace
+		// that is still outdated.
+		sent = enqueuer.Sent(notificationstest.WithTemplateID(notifications.TemplateMaintenanceWindowReport))
+		require.Len(t, sent, 1)
+		require.Equal(t, workspace.OwnerID, sent[0].UserID)
+		require.Equal(t, newVersion.Name, sent[0].Labels["template_version_name"])
+		require.Len(t, sent[0].Data["updated_workspaces"], 0)
+		require.Len(t, sent[0].Data["outdated_workspaces"], 1)
+	})
+}
+
 // TestExecutorPrebuilds verifies AGPL behavior for prebuilt workspaces.
 // It ensures that workspace schedules do not trigger while the workspace
 // is still in a prebuilt state. Scheduling behavior only applies after the
@@ -0,0 +1,307 @@
+package autobuild
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/schedule/cron"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// maintenanceIdleThreshold is how long a workspace must have gone unused
+	// before a maintenance window restarts it. Active connections keep
+	// bumping last_used_at, so workspaces that are in use are skipped.
+	maintenanceIdleThreshold = 15 * time.Minute
+	// maintenanceNotifyLead is how long before a maintenance window opens
+	// that owners of outdated workspaces are notified.
+	maintenanceNotifyLead = time.Hour
+	// maintenanceWindowMaxLength bounds the search for the start of a
+	// maintenance window. Windows are expressed as weekly time ranges.
+	maintenanceWindowMaxLength = 7 * 24 * time.Hour
+)
+
+// getMaintenanceTransition returns the transition for a workspace that is
+// being updated by its template's maintenance window. Outdated workspaces are
+// first stopped and, once the stop has succeeded, started again on the active
+// template version. An error is returned when the workspace is not eligible.
+func getMaintenanceTransition(
+	user database.User,
+	ws database.Workspace,
+	tmpl database.Template,
+	latestBuild database.WorkspaceBuild,
+	latestJob database.ProvisionerJob,
+	currentTick time.Time,
+) (
+	database.WorkspaceTransition,
+	database.BuildReason,
+	error,
+) {
+	switch {
+	case isEligibleForMaintenanceStart(user, ws, latestBuild, latestJob):
+		return database.WorkspaceTransitionStart, database.BuildReasonMaintenance, nil
+	case isEligibleForMaintenanceStop(user, ws, tmpl, latestBuild, latestJob, currentTick):
+		return database.WorkspaceTransitionStop, database.BuildReasonMaintenance, nil
+	default:
+		return "", "", xerrors.Errorf("workspace not eligible for maintenance")
+	}
+}
+
+// isEligibleForMaintenanceStart returns true if the workspace was stopped by a
+// maintenance window and should be started again. This does not depend on the
+// window still being open, so a workspace is never left stopped.
+func isEligibleForMaintenanceStart(user database.User, ws database.Workspace, build database.WorkspaceBuild, job database.ProvisionerJob) bool {
+	return user.Status == database.UserStatusActive &&
+		!ws.DormantAt.Valid &&
+		build.Transition == database.WorkspaceTransitionStop &&
+		build.Reason == database.BuildReasonMaintenance &&
+		job.JobStatus == database.ProvisionerJobStatusSucceeded
+}
+
+// isEligibleForMaintenanceStop returns true if the workspace runs an outdated
+// template version, the template's maintenance window is open and the
+// workspace has been idle for long enough that nobody is connected to it.
+func isEligibleForMaintenanceStop(user database.User, ws database.Workspace, tmpl database.Template, build database.WorkspaceBuild, job database.ProvisionerJob, currentTick time.Time) bool {
+	if user.Status != database.UserStatusActive {
+		return false
+	}
+
+	// Task workspaces are paused and resumed by their own lifecycle.
+	if ws.DormantAt.Valid || ws.TaskID.Valid {
+		return false
+	}
+
+	if build.Transition != database.WorkspaceTransitionStart ||
+		job.JobStatus != database.ProvisionerJobStatusSucceeded ||
+		build.TemplateVersionID == tmpl.ActiveVersionID {
+		return false
+	}
+
+	if !isMaintenanceWindowOpen(tmpl.MaintenanceWindowSchedule, currentTick) {
+		return false
+	}
+
+	return currentTick.Sub(ws.LastUsedAt) >= maintenanceIdleThreshold
+}
+
+// isMaintenanceWindowOpen returns true if t falls within the maintenance
+// window described by the cron time range raw.
+func isMaintenanceWindowOpen(raw string, t time.Time) bool {
+	if raw == "" {
+		return false
+	}
+	sched, err := cron.TimeRange(raw)
+	if err != nil {
+		return false
+	}
+	return sched.IsWithinRange(t)
+}
+
+// maintenanceWindowOpens returns true if the maintenance window opens at the
+// minute of t.
+func maintenanceWindowOpens(sched *cron.Schedule, t time.Time) bool {
+	return sched.IsWithinRange(t) && !sched.IsWithinRange(t.Add(-time.Minute))
+}
+
+// maintenanceWindowCloses returns true if the maintenance window closed at the
+// minute of t.
+func maintenanceWindowCloses(sched *cron.Schedule, t time.Time) bool {
+	return !sched.IsWithinRange(t) && sched.IsWithinRange(t.Add(-time.Minute))
+}
+
+// maintenanceWindowStart returns the time the maintenance window that closed
+// at end was opened. Time ranges only have hour granularity, so the search
+// steps back an hour at a time.
+func maintenanceWindowStart(sched *cron.Schedule, end time.Time) time.Time {
+	start := end
+	for end.Sub(start) < maintenanceWindowMaxLength && sched.IsWithinRange(start.Add(-time.Minute)) {
+		start = start.Add(-time.Hour)
+	}
+	return start
+}
+
+// notifyMaintenanceWindows notifies the owners of outdated workspaces ahead of
+// their template's maintenance window, and reports what was updated to the
+// template admins once the window closes. Every replica runs the executor, so
+// duplicate notifications are discarded by the enqueuer's deduplication.
+func (e *Executor) notifyMaintenanceWindows(currentTick time.Time) {
+	templates, err := e.db.GetTemplatesWithMaintenanceWindow(e.ctx)
+	if err != nil {
+		e.log.Error(e.ctx, "get templates with maintenance window", slog.Error(err))
+		return
+	}
+
+	for _, tmpl := range templates {
+		log := e.log.With(slog.F("template_id", tmpl.ID), slog.F("template_name", tmpl.Name))
+
+		sched, err := cron.TimeRange(tmpl.MaintenanceWindowSchedule)
+		if err != nil {
+			log.Warn(e.ctx, "invalid maintenance window schedule", slog.F("schedule", tmpl.MaintenanceWindowSchedule), slog.Error(err))
+			continue
+		}
+
+		notifyOwners := maintenanceWindowOpens(sched, currentTick.Add(maintenanceNotifyLead))
+		reportAdmins := maintenanceWindowCloses(sched, currentTick)
+		if !notifyOwners && !reportAdmins {
+			continue
+		}
+
+		workspaces, err := e.db.GetWorkspacesForTemplateMaintenance(e.ctx, tmpl.ID)
+		if err != nil {
+			log.Error(e.ctx, "get workspaces for template maintenance", slog.Error(err))
+			continue
+		}
+
+		activeVersion, err := e.db.GetTemplateVersionByID(e.ctx, tmpl.ActiveVersionID)
+		if err != nil {
+			log.Error(e.ctx, "get active template version", slog.Error(err))
+			continue
+		}
+
+		if notifyOwners {
+			windowStart := currentTick.Add(maintenanceNotifyLead).In(sched.Location())
+			e.notifyMaintenanceScheduled(log, tmpl, activeVersion, workspaces, windowStart)
+		}
+		if reportAdmins {
+			e.reportMaintenanceWindow(log, tmpl, activeVersion, workspaces, maintenanceWindowStart(sched, currentTick))
+		}
+	}
+}
+
+// notifyMaintenanceScheduled notifies the owners of running, outdated
+// workspaces that their workspace may be restarted at windowStart.
+func (e *Executor) notifyMaintenanceScheduled(log slog.Logger, tmpl database.Template, activeVersion database.TemplateVersion, workspaces []database.GetWorkspacesForTemplateMaintenanceRow, windowStart time.Time) {
+	for _, ws := range workspaces {
+		if !isOutdatedMaintenanceWorkspace(ws, tmpl) || ws.Transition != database.WorkspaceTransitionStart {
+			continue
+		}
+
+		if _, err := e.notificationsEnqueuer.Enqueue(e.ctx, ws.OwnerID, notifications.TemplateWorkspaceMaintenanceScheduled,
+			map[string]string{
+				"name":                  ws.Name,
+				"template":              templateDisplayName(tmpl),
+				"template_version_name": activeVersion.Name,
+				"window_start":          windowStart.Format("2006-01-02 15:04 MST"),
+			}, "lifecycle_executor",
+			// Associate this notification with all the related entities.
+			ws.ID, ws.OwnerID, tmpl.ID, tmpl.OrganizationID,
+		); err != nil {
+			log.Warn(e.ctx, "failed to notify of scheduled maintenance", slog.F("workspace_id", ws.ID), slog.Error(err))
+		}
+	}
+}
+
+// reportMaintenanceWindow reports the workspaces that were updated during the
+// maintenance window opened at windowStart, those that failed to start on the
+// active version and those that are still outdated to the template admins of
+// the template's organization.
+func (e *Executor) reportMaintenanceWindow(log slog.Logger, tmpl database.Template, activeVersion database.TemplateVersion, workspaces []database.GetWorkspacesForTemplateMaintenanceRow, windowStart time.Time) {
+	updated := []map[string]any{}
+	failed := []map[string]any{}
+	outdated := []map[string]any{}
+	for _, ws := range workspaces {
+		entry := map[string]any{
+			"id":             ws.ID.String(),
+			"name":           ws.Name,
+			"owner_username": ws.OwnerUsername,
+		}
+		restarted := ws.Reason == database.BuildReasonMaintenance &&
+			ws.Transition == database.WorkspaceTransitionStart &&
+			!ws.BuildCreatedAt.Before(windowStart)
+		switch {
+		case restarted && ws.JobStatus == database.ProvisionerJobStatusSucceeded:
+			updated = append(updated, entry)
+		case restarted && ws.JobStatus == database.ProvisionerJobStatusFailed:
+			failed = append(failed, entry)
+		case isOutdatedMaintenanceWorkspace(ws, tmpl):
+			outdated = append(outdated, entry)
+		}
+	}
+	if len(updated) == 0 && len(failed) == 0 && len(outdated) == 0 {
+		return
+	}
+
+	admins, err := findTemplateAdmins(e.ctx, e.db, tmpl.OrganizationID)
+	if err != nil {
+		log.Error(e.ctx, "find template admins", slog.Error(err))
+		return
+	}
+
+	for _, admin := range admins {
+		if _, err := e.notificationsEnqueuer.EnqueueWithData(e.ctx, admin, notifications.TemplateMaintenanceWindowReport,
+			map[string]string{
+				"template_name":         tmpl.Name,
+				"template_display_name": templateDisplayName(tmpl),
+				"template_version_name": activeVersion.Name,
+			},
+			map[string]any{
+				"updated_workspaces":  updated,
+				"failed_workspaces":   failed,
+				"outdated_workspaces": outdated,
+			}, "lifecycle_executor",
+			// Associate this notification with all the related entities.
+			tmpl.ID, tmpl.OrganizationID,
+		); err != nil {
+			log.Warn(e.ctx, "failed to send maintenance window report", slog.F("user_id", admin), slog.Error(err))
+		}
+	}
+}
+
+// isOutdatedMaintenanceWorkspace returns true if the workspace does not run
+// the active template version and would be considered by a maintenance window.
+func isOutdatedMaintenanceWorkspace(ws database.GetWorkspacesForTemplateMaintenanceRow, tmpl database.Template) bool {
+	return !ws.DormantAt.Valid &&
+		ws.TemplateVersionID != tmpl.ActiveVersionID
+}
+
+func templateDisplayName(tmpl database.Template) string {
+	if tmpl.DisplayName != "" {
+		return tmpl.DisplayName
+	}
+	return tmpl.Name
+}
+
+// findTemplateAdmins returns the IDs of the template admins and owners that
+// are members of the given organization, sorted by username.
+func findTemplateAdmins(ctx context.Context, db database.Store, organizationID uuid.UUID) ([]uuid.UUID, error) {
+	users, err := db.GetUsers(ctx, database.GetUsersParams{
+		RbacRole: []string{codersdk.RoleTemplateAdmin, codersdk.RoleOwner},
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("get template admins: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	usernames := make(map[uuid.UUID]string, len(users))
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+		userIDs = append(userIDs, user.ID)
+	}
+
+	memberships, err := db.GetOrganizationIDsByMemberIDs(ctx, userIDs)
+	if err != nil {
+		return nil, xerrors.Errorf("get organization IDs by member IDs: %w", err)
+	}
+
+	var admins []uuid.UUID
+	for _, entry := range memberships {
+		if slices.Contains(entry.OrganizationIDs, organizationID) {
+			admins = append(admins, entry.UserID)
+		}
+	}
+	sort.Slice(admins, func(i, j int) bool {
+		return usernames[admins[i]] < usernames[admins[j]]
+	})
+	return admins, nil
+}
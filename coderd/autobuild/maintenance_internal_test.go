@@ -0,0 +1,223 @@
+package autobuild
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/schedule/cron"
+)
+
+func Test_getMaintenanceTransition(t *testing.T) {
+	t.Parallel()
+
+	// Saturday, 2024-10-12 03:00 UTC is within the window.
+	inWindow := time.Date(2024, 10, 12, 3, 0, 0, 0, time.UTC)
+	outsideWindow := time.Date(2024, 10, 12, 6, 0, 0, 0, time.UTC)
+
+	activeVersionID := uuid.New()
+	outdatedVersionID := uuid.New()
+
+	okUser := database.User{Status: database.UserStatusActive}
+	okTemplate := database.Template{
+		ActiveVersionID:           activeVersionID,
+		MaintenanceWindowSchedule: "* 2-4 * * 6",
+	}
+	okWorkspace := database.Workspace{
+		LastUsedAt: inWindow.Add(-time.Hour),
+	}
+	okBuild := database.WorkspaceBuild{
+		Transition:        database.WorkspaceTransitionStart,
+		TemplateVersionID: outdatedVersionID,
+	}
+	okJob := database.ProvisionerJob{
+		JobStatus: database.ProvisionerJobStatusSucceeded,
+	}
+	maintenanceStop := database.WorkspaceBuild{
+		Transition:        database.WorkspaceTransitionStop,
+		Reason:            database.BuildReasonMaintenance,
+		TemplateVersionID: outdatedVersionID,
+	}
+
+	testCases := []struct {
+		Name               string
+		User               database.User
+		Workspace          database.Workspace
+		Template           database.Template
+		Build              database.WorkspaceBuild
+		Job                database.ProvisionerJob
+		Tick               time.Time
+		ExpectedTransition database.WorkspaceTransition
+	}{
+		{
+			Name:               "Stop",
+			User:               okUser,
+			Workspace:          okWorkspace,
+			Template:           okTemplate,
+			Build:              okBuild,
+			Job:                okJob,
+			Tick:               inWindow,
+			ExpectedTransition: database.WorkspaceTransitionStop,
+		},
+		{
+			Name:      "OutsideWindow",
+			User:      okUser,
+			Workspace: okWorkspace,
+			Template:  okTemplate,
+			Build:     okBuild,
+			Job:       okJob,
+			Tick:      outsideWindow,
+		},
+		{
+			Name:      "NoWindow",
+			User:      okUser,
+			Workspace: okWorkspace,
+			Template: database.Template{
+				ActiveVersionID: activeVersionID,
+			},
+			Build: okBuild,
+			Job:   okJob,
+			Tick:  inWindow,
+		},
+		{
+			Name:      "UpToDate",
+			User:      okUser,
+			Workspace: okWorkspace,
+			Template:  okTemplate,
+			Build: database.WorkspaceBuild{
+				Transition:        database.WorkspaceTransitionStart,
+				TemplateVersionID: activeVersionID,
+			},
+			Job:  okJob,
+			Tick: inWindow,
+		},
+		{
+			Name: "RecentlyUsed",
+			User: okUser,
+			Workspace: database.Workspace{
+				LastUsedAt: inWindow.Add(-time.Minute),
+			},
+			Template: okTemplate,
+			Build:    okBuild,
+			Job:      okJob,
+			Tick:     inWindow,
+		},
+		{
+			Name: "Dormant",
+			User: okUser,
+			Workspace: database.Workspace{
+				LastUsedAt: inWindow.Add(-time.Hour),
+				DormantAt:  sql.NullTime{Time: inWindow.Add(-time.Hour), Valid: true},
+			},
+			Template: okTemplate,
+			Build:    okBuild,
+			Job:      okJob,
+			Tick:     inWindow,
+		},
+		{
+			Name: "Task",
+			User: okUser,
+			Workspace: database.Workspace{
+				LastUsedAt: inWindow.Add(-time.Hour),
+				TaskID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			},
+			Template: okTemplate,
+			Build:    okBuild,
+			Job:      okJob,
+			Tick:     inWindow,
+		},
+		{
+			Name:      "SuspendedUser",
+			User:      database.User{Status: database.UserStatusSuspended},
+			Workspace: okWorkspace,
+			Template:  okTemplate,
+			Build:     okBuild,
+			Job:       okJob,
+			Tick:      inWindow,
+		},
+		{
+			Name:      "FailedBuild",
+			User:      okUser,
+			Workspace: okWorkspace,
+			Template:  okTemplate,
+			Build:     okBuild,
+			Job: database.ProvisionerJob{
+				JobStatus: database.ProvisionerJobStatusFailed,
+			},
+			Tick: inWindow,
+		},
+		{
+			Name:               "StartAfterMaintenanceStop",
+			User:               okUser,
+			Workspace:          okWorkspace,
+			Template:           okTemplate,
+			Build:              maintenanceStop,
+			Job:                okJob,
+			Tick:               inWindow,
+			ExpectedTransition: database.WorkspaceTransitionStart,
+		},
+		{
+			// A workspace stopped for maintenance is started even if the
+			// window closed in the meantime.
+			Name:               "StartAfterWindowClosed",
+			User:               okUser,
+			Workspace:          okWorkspace,
+			Template:           okTemplate,
+			Build:              maintenanceStop,
+			Job:                okJob,
+			Tick:               outsideWindow,
+			ExpectedTransition: database.WorkspaceTransitionStart,
+		},
+		{
+			Name:      "NoStartAfterFailedStop",
+			User:      okUser,
+			Workspace: okWorkspace,
+			Template:  okTemplate,
+			Build:     maintenanceStop,
+			Job: database.ProvisionerJob{
+				JobStatus: database.ProvisionerJobStatusFailed,
+			},
+			Tick: inWindow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			transition, reason, err := getMaintenanceTransition(tc.User, tc.Workspace, tc.Template, tc.Build, tc.Job, tc.Tick)
+			if tc.ExpectedTransition == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedTransition, transition)
+			require.Equal(t, database.BuildReasonMaintenance, reason)
+		})
+	}
+}
+
+func Test_maintenanceWindowBoundaries(t *testing.T) {
+	t.Parallel()
+
+	sched, err := cron.TimeRange("CRON_TZ=Europe/Berlin * 2-4 * * 6")
+	require.NoError(t, err)
+
+	berlin := sched.Location()
+	opens := time.Date(2024, 10, 12, 2, 0, 0, 0, berlin)
+	closes := time.Date(2024, 10, 12, 5, 0, 0, 0, berlin)
+
+	require.True(t, maintenanceWindowOpens(sched, opens))
+	require.False(t, maintenanceWindowOpens(sched, opens.Add(time.Minute)))
+	require.False(t, maintenanceWindowOpens(sched, opens.Add(-time.Minute)))
+
+	require.True(t, maintenanceWindowCloses(sched, closes))
+	require.False(t, maintenanceWindowCloses(sched, closes.Add(-time.Minute)))
+	require.False(t, maintenanceWindowCloses(sched, closes.Add(time.Minute)))
+
+	require.True(t, maintenanceWindowStart(sched, closes).Equal(opens))
+}
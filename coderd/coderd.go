@@ -1273,6 +1273,11 @@ func New(options *Options) *API {
 					r.Get("/{job}", api.provisionerJob)
 					r.Get("/", api.provisionerJobs)
 				})
+				r.Route("/notifications/webhooks", func(r chi.Router) {
+					r.Get("/", api.organizationNotificationWebhooks)
+					r.Put("/{method}", api.putOrganizationNotificationWebhook)
+					r.Delete("/{method}", api.deleteOrganizationNotificationWebhook)
+				})
 			})
 		})
 		r.Route("/templates", func(r chi.Router) {
@@ -1317,11 +1322,13 @@ func New(options *Options) *API {
 			r.Get("/variables", api.templateVersionVariables)
 			r.Get("/presets", api.templateVersionPresets)
 			r.Get("/resources", api.templateVersionResources)
+			r.Get("/diff", api.templateVersionDiff)
 			r.Get("/logs", api.templateVersionLogs)
 			r.Route("/dry-run", func(r chi.Router) {
 				r.Post("/", api.postTemplateVersionDryRun)
 				r.Get("/{jobID}", api.templateVersionDryRun)
 				r.Get("/{jobID}/resources", api.templateVersionDryRunResources)
+				r.Get("/{jobID}/resource-changes", api.templateVersionDryRunResourceChanges)
 				r.Get("/{jobID}/logs", api.templateVersionDryRunLogs)
 				r.Get("/{jobID}/matched-provisioners", api.templateVersionDryRunMatchedProvisioners)
 				r.Patch("/{jobID}/cancel", api.patchTemplateVersionDryRunCancel)
@@ -1445,6 +1452,16 @@ func New(options *Options) *API {
 								r.Get("/", api.userNotificationPreferences)
 								r.Put("/", api.putUserNotificationPreferences)
 							})
+							r.Route("/targets", func(r chi.Router) {
+								r.Get("/", api.userNotificationTargets)
+								r.Post("/", api.postUserNotificationTarget)
+								r.Route("/{notification_target}", func(r chi.Router) {
+									r.Use(httpmw.ExtractNotificationTargetParam(options.Database))
+									r.Delete("/", api.deleteUserNotificationTarget)
+									r.With(notificationTargetTestRateLimit()).Post("/test", api.postUserNotificationTargetTest)
+									r.Post("/verify", api.postUserNotificationTargetVerify)
+								})
+							})
 						})
 						r.Route("/webpush", func(r chi.Router) {
 							r.Post("/subscription", api.postUserWebpushSubscription)
@@ -1530,6 +1547,7 @@ func New(options *Options) *API {
 					r.Get("/", api.workspaceBuilds)
 					r.Post("/", api.postWorkspaceBuilds)
 				})
+				r.Post("/dry-run", api.postWorkspaceDryRun)
 				r.Route("/autostart", func(r chi.Router) {
 					r.Put("/", api.putWorkspaceAutostart)
 				})
@@ -1551,6 +1569,10 @@ func New(options *Options) *API {
 					r.Delete("/", api.deleteWorkspaceAgentPortShare)
 				})
 				r.Get("/timings", api.workspaceTimings)
+				r.Route("/session-recordings", func(r chi.Router) {
+					r.Get("/", api.workspaceSessionRecordings)
+					r.Get("/{recording}", api.workspaceSessionRecording)
+				})
 				r.Route("/acl", func(r chi.Router) {
 					r.Use(
 						httpmw.RequireExperiment(api.Experiments, codersdk.ExperimentWorkspaceSharing),
@@ -1738,6 +1760,7 @@ func New(options *Options) *API {
 				r.Get("/custom", api.customNotificationTemplates)
 			})
 			r.Get("/dispatch-methods", api.notificationDispatchMethods)
+			r.Get("/deliveries", api.notificationDeliveries)
 			r.Post("/test", api.postTestNotification)
 			r.Post("/custom", api.postCustomNotification)
 		})
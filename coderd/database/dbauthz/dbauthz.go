@@ -178,7 +178,7 @@ func workspaceTransitionAction(transition database.WorkspaceTransition) (policy.
 	switch transition {
 	case database.WorkspaceTransitionStart:
 		return policy.ActionWorkspaceStart, nil
-	case database.WorkspaceTransitionStop:
+	case database.WorkspaceTransitionStop, database.WorkspaceTransitionHibernate:
 		return policy.ActionWorkspaceStop, nil
 	case database.WorkspaceTransitionDelete:
 		return policy.ActionDelete, nil
@@ -1485,6 +1485,13 @@ func (q *querier) AllUserIDs(ctx context.Context, includeSystem bool) ([]uuid.UU
 	return q.db.AllUserIDs(ctx, includeSystem)
 }
 
+func (q *querier) AppendWorkspaceSessionRecordingData(ctx context.Context, arg database.AppendWorkspaceSessionRecordingDataParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceConnectionLog); err != nil {
+		return 0, err
+	}
+	return q.db.AppendWorkspaceSessionRecordingData(ctx, arg)
+}
+
 func (q *querier) ArchiveUnusedTemplateVersions(ctx context.Context, arg database.ArchiveUnusedTemplateVersionsParams) ([]uuid.UUID, error) {
 	tpl, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
 	if err != nil {
@@ -1764,6 +1771,11 @@ func (q *querier) DeleteLicense(ctx context.Context, id int32) (int32, error) {
 	return id, nil
 }
 
+func (q *querier) DeleteNotificationTargetByID(ctx context.Context, id uuid.UUID) error {
+	// Notification targets are part of the user's notification preferences, which cannot be deleted.
+	return fetchAndExec(q.log, q.auth, policy.ActionUpdate, q.db.GetNotificationTargetByID, q.db.DeleteNotificationTargetByID)(ctx, id)
+}
+
 func (q *querier) DeleteOAuth2ProviderAppByClientID(ctx context.Context, id uuid.UUID) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceOauth2App); err != nil {
 		return err
@@ -1893,6 +1905,12 @@ func (q *querier) DeleteOrganizationMember(ctx context.Context, arg database.Del
 	}, q.db.DeleteOrganizationMember)(ctx, arg)
 }
 
+func (q *querier) DeleteOrganizationNotificationWebhook(ctx context.Context, arg database.DeleteOrganizationNotificationWebhookParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationTemplate.InOrg(arg.OrganizationID)); err != nil {
+		return err
+	}
+	return q.db.DeleteOrganizationNotificationWebhook(ctx, arg)
+}
 func (q *querier) DeleteProvisionerKey(ctx context.Context, id uuid.UUID) error {
 	return deleteQ(q.log, q.auth, q.db.GetProvisionerKeyByID, q.db.DeleteProvisionerKey)(ctx, id)
 }
@@ -1911,6 +1929,13 @@ func (q *querier) DeleteRuntimeConfig(ctx context.Context, key string) error {
 	return q.db.DeleteRuntimeConfig(ctx, key)
 }
 
+func (q *querier) DeleteStalePrebuildReadiness(ctx context.Context) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourcePrebuiltWorkspace); err != nil {
+		return err
+	}
+	return q.db.DeleteStalePrebuildReadiness(ctx)
+}
+
 func (q *querier) DeleteTailnetPeer(ctx context.Context, arg database.DeleteTailnetPeerParams) (database.DeleteTailnetPeerRow, error) {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceTailnetCoordinator); err != nil {
 		return database.DeleteTailnetPeerRow{}, err
@@ -1938,6 +1963,35 @@ func (q *querier) DeleteTask(ctx context.Context, arg database.DeleteTaskParams)
 	return q.db.DeleteTask(ctx, arg)
 }
 
+func (q *querier) DeleteTemplateGroupScheduleOverride(ctx context.Context, arg database.DeleteTemplateGroupScheduleOverrideParams) (database.TemplateGroupScheduleOverride, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplateGroupScheduleOverride{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateGroupScheduleOverride{}, err
+	}
+	return q.db.DeleteTemplateGroupScheduleOverride(ctx, arg)
+}
+
+func (q *querier) DeleteTemplatePresetReadinessChecks(ctx context.Context, arg database.DeleteTemplatePresetReadinessChecksParams) (database.TemplatePresetReadinessCheck, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplatePresetReadinessCheck{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplatePresetReadinessCheck{}, err
+	}
+	return q.db.DeleteTemplatePresetReadinessChecks(ctx, arg)
+}
+
+func (q *querier) DeleteUserNotificationPreferenceTargets(ctx context.Context, arg database.DeleteUserNotificationPreferenceTargetsParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationPreference.WithOwner(arg.UserID.String())); err != nil {
+		return err
+	}
+	return q.db.DeleteUserNotificationPreferenceTargets(ctx, arg)
+}
+
 func (q *querier) DeleteUserSecret(ctx context.Context, id uuid.UUID) error {
 	// First get the secret to check ownership
 	secret, err := q.GetUserSecret(ctx, id)
@@ -2368,6 +2422,15 @@ func (q *querier) GetEligibleProvisionerDaemonsByProvisionerJobIDs(ctx context.C
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.GetEligibleProvisionerDaemonsByProvisionerJobIDs)(ctx, provisionerJobIDs)
 }
 
+func (q *querier) GetEnabledTemplateAdaptivePrebuilds(ctx context.Context) ([]database.TemplateAdaptivePrebuild, error) {
+	// Adaptive prebuild settings are part of the template's prebuild configuration,
+	// so if you can access templates - you can access them as well.
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceTemplate.All()); err != nil {
+		return nil, err
+	}
+	return q.db.GetEnabledTemplateAdaptivePrebuilds(ctx)
+}
+
 func (q *querier) GetExternalAuthLink(ctx context.Context, arg database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	return fetchWithAction(q.log, q.auth, policy.ActionReadPersonal, q.db.GetExternalAuthLink)(ctx, arg)
 }
@@ -2573,6 +2636,13 @@ func (q *querier) GetLogoURL(ctx context.Context) (string, error) {
 	return q.db.GetLogoURL(ctx)
 }
 
+func (q *querier) GetNotificationMessageDeliveries(ctx context.Context, arg database.GetNotificationMessageDeliveriesParams) ([]database.GetNotificationMessageDeliveriesRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationMessage); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationMessageDeliveries(ctx, arg)
+}
+
 func (q *querier) GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationMessage); err != nil {
 		return nil, err
@@ -2587,6 +2657,17 @@ func (q *querier) GetNotificationReportGeneratorLogByTemplate(ctx context.Contex
 	return q.db.GetNotificationReportGeneratorLogByTemplate(ctx, arg)
 }
 
+func (q *querier) GetNotificationTargetByID(ctx context.Context, id uuid.UUID) (database.NotificationTarget, error) {
+	return fetch(q.log, q.auth, q.db.GetNotificationTargetByID)(ctx, id)
+}
+
+func (q *querier) GetNotificationTargetsByUserID(ctx context.Context, userID uuid.UUID) ([]database.NotificationTarget, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationPreference.WithOwner(userID.String())); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationTargetsByUserID(ctx, userID)
+}
+
 func (q *querier) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationTemplate); err != nil {
 		return database.NotificationTemplate{}, err
@@ -2726,6 +2807,19 @@ func (q *querier) GetOrganizationIDsByMemberIDs(ctx context.Context, ids []uuid.
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.GetOrganizationIDsByMemberIDs)(ctx, ids)
 }
 
+func (q *querier) GetOrganizationNotificationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]database.OrganizationNotificationWebhook, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationTemplate.InOrg(organizationID)); err != nil {
+		return nil, err
+	}
+	return q.db.GetOrganizationNotificationWebhooks(ctx, organizationID)
+}
+func (q *querier) GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg database.GetOrganizationNotificationWebhooksByUserIDParams) ([]database.OrganizationNotificationWebhook, error) {
+	// Used by the Slack and Teams dispatchers to deliver messages to the recipient's organizations.
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationMessage); err != nil {
+		return nil, err
+	}
+	return q.db.GetOrganizationNotificationWebhooksByUserID(ctx, arg)
+}
 func (q *querier) GetOrganizationResourceCountByID(ctx context.Context, organizationID uuid.UUID) (database.GetOrganizationResourceCountByIDRow, error) {
 	// Can read org members
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceOrganizationMember.InOrg(organizationID)); err != nil {
@@ -2794,6 +2888,15 @@ func (q *querier) GetParameterSchemasByJobID(ctx context.Context, jobID uuid.UUI
 	return q.db.GetParameterSchemasByJobID(ctx, jobID)
 }
 
+func (q *querier) GetPrebuildClaimHistory(ctx context.Context, arg database.GetPrebuildClaimHistoryParams) ([]database.GetPrebuildClaimHistoryRow, error) {
+	// GetPrebuildClaimHistory returns when prebuilt workspaces were claimed, which
+	// requires the same access as GetPrebuildMetrics.
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceWorkspace.All()); err != nil {
+		return nil, err
+	}
+	return q.db.GetPrebuildClaimHistory(ctx, arg)
+}
+
 func (q *querier) GetPrebuildMetrics(ctx context.Context) ([]database.GetPrebuildMetricsRow, error) {
 	// GetPrebuildMetrics returns metrics related to prebuilt workspaces,
 	// such as the number of created and failed prebuilt workspaces.
@@ -2803,6 +2906,15 @@ func (q *querier) GetPrebuildMetrics(ctx context.Context) ([]database.GetPrebuil
 	return q.db.GetPrebuildMetrics(ctx)
 }
 
+func (q *querier) GetPrebuildReadiness(ctx context.Context, templateID uuid.NullUUID) ([]database.WorkspacePrebuildReadiness, error) {
+	// GetPrebuildReadiness returns the readiness check results of prebuilt workspaces,
+	// which requires the same access as GetPrebuildMetrics.
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceWorkspace.All()); err != nil {
+		return nil, err
+	}
+	return q.db.GetPrebuildReadiness(ctx, templateID)
+}
+
 func (q *querier) GetPrebuildsSettings(ctx context.Context) (string, error) {
 	return q.db.GetPrebuildsSettings(ctx)
 }
@@ -2943,6 +3055,14 @@ func (q *querier) GetProvisionerJobByIDWithLock(ctx context.Context, id uuid.UUI
 	return job, nil
 }
 
+func (q *querier) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	_, err := q.GetProvisionerJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return q.db.GetProvisionerJobResourceChangesByJobID(ctx, jobID)
+}
+
 func (q *querier) GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobTiming, error) {
 	_, err := q.GetProvisionerJobByID(ctx, jobID)
 	if err != nil {
@@ -3133,6 +3253,13 @@ func (q *querier) GetTelemetryItems(ctx context.Context) ([]database.TelemetryIt
 	return q.db.GetTelemetryItems(ctx)
 }
 
+func (q *querier) GetTemplateAdaptivePrebuildsByTemplateID(ctx context.Context, templateID uuid.UUID) (database.TemplateAdaptivePrebuild, error) {
+	if _, err := q.GetTemplateByID(ctx, templateID); err != nil {
+		return database.TemplateAdaptivePrebuild{}, err
+	}
+	return q.db.GetTemplateAdaptivePrebuildsByTemplateID(ctx, templateID)
+}
+
 func (q *querier) GetTemplateAppInsights(ctx context.Context, arg database.GetTemplateAppInsightsParams) ([]database.GetTemplateAppInsightsRow, error) {
 	if err := q.authorizeTemplateInsights(ctx, arg.TemplateIDs); err != nil {
 		return nil, err
@@ -3172,6 +3299,22 @@ func (q *querier) GetTemplateDAUs(ctx context.Context, arg database.GetTemplateD
 	return q.db.GetTemplateDAUs(ctx, arg)
 }
 
+func (q *querier) GetTemplateGroupScheduleOverrides(ctx context.Context, templateID uuid.UUID) ([]database.TemplateGroupScheduleOverride, error) {
+	// Overrides are part of the template schedule, so anyone who can read the
+	// template can read them.
+	if _, err := q.GetTemplateByID(ctx, templateID); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateGroupScheduleOverrides(ctx, templateID)
+}
+
+func (q *querier) GetTemplateGroupScheduleOverridesByUserID(ctx context.Context, arg database.GetTemplateGroupScheduleOverridesByUserIDParams) ([]database.TemplateGroupScheduleOverride, error) {
+	if _, err := q.GetTemplateByID(ctx, arg.TemplateID); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateGroupScheduleOverridesByUserID(ctx, arg)
+}
+
 func (q *querier) GetTemplateInsights(ctx context.Context, arg database.GetTemplateInsightsParams) (database.GetTemplateInsightsRow, error) {
 	if err := q.authorizeTemplateInsights(ctx, arg.TemplateIDs); err != nil {
 		return database.GetTemplateInsightsRow{}, err
@@ -3201,6 +3344,19 @@ func (q *querier) GetTemplateParameterInsights(ctx context.Context, arg database
 	return q.db.GetTemplateParameterInsights(ctx, arg)
 }
 
+func (q *querier) GetTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.NullUUID) ([]database.TemplatePresetReadinessCheck, error) {
+	// Readiness checks are part of the template's prebuild configuration,
+	// so if you can access the template - you can access them as well.
+	if templateID.Valid {
+		if _, err := q.GetTemplateByID(ctx, templateID.UUID); err != nil {
+			return nil, err
+		}
+	} else if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceTemplate.All()); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplatePresetReadinessChecks(ctx, templateID)
+}
+
 func (q *querier) GetTemplatePresetsWithPrebuilds(ctx context.Context, templateID uuid.NullUUID) ([]database.GetTemplatePresetsWithPrebuildsRow, error) {
 	// GetTemplatePresetsWithPrebuilds retrieves template versions with configured presets and prebuilds.
 	// Presets and prebuilds are part of the template, so if you can access templates - you can access them as well.
@@ -3407,6 +3563,13 @@ func (q *querier) GetTemplatesWithFilter(ctx context.Context, arg database.GetTe
 	return q.db.GetAuthorizedTemplates(ctx, arg, prep)
 }
 
+func (q *querier) GetTemplatesWithMaintenanceWindow(ctx context.Context) ([]database.Template, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplatesWithMaintenanceWindow(ctx)
+}
+
 func (q *querier) GetTotalUsageDCManagedAgentsV1(ctx context.Context, arg database.GetTotalUsageDCManagedAgentsV1Params) (int64, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceUsageEvent); err != nil {
 		return 0, err
@@ -3501,6 +3664,13 @@ func (q *querier) GetUserLinksByUserID(ctx context.Context, userID uuid.UUID) ([
 	return q.db.GetUserLinksByUserID(ctx, userID)
 }
 
+func (q *querier) GetUserNotificationPreferenceTargets(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreferenceTarget, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationPreference.WithOwner(userID.String())); err != nil {
+		return nil, err
+	}
+	return q.db.GetUserNotificationPreferenceTargets(ctx, userID)
+}
+
 func (q *querier) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationPreference.WithOwner(userID.String())); err != nil {
 		return nil, err
@@ -3606,6 +3776,14 @@ func (q *querier) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]databas
 	return q.db.GetUsersByIDs(ctx, ids)
 }
 
+func (q *querier) GetVerifiedNotificationTargetsByTemplateID(ctx context.Context, arg database.GetVerifiedNotificationTargetsByTemplateIDParams) ([]database.NotificationTarget, error) {
+	// Used by the notifications enqueuer to route messages; see FetchNewMessageMetadata.
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceNotificationMessage); err != nil {
+		return nil, err
+	}
+	return q.db.GetVerifiedNotificationTargetsByTemplateID(ctx, arg)
+}
+
 func (q *querier) GetWebpushSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]database.WebpushSubscription, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceWebpushSubscription.WithOwner(userID.String())); err != nil {
 		return nil, err
@@ -4086,6 +4264,22 @@ func (q *querier) GetWorkspaceResourcesCreatedAfter(ctx context.Context, created
 	return q.db.GetWorkspaceResourcesCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetWorkspaceSessionRecordingByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSessionRecording, error) {
+	return fetch(q.log, q.auth, q.db.GetWorkspaceSessionRecordingByID)(ctx, id)
+}
+
+func (q *querier) GetWorkspaceSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]database.WorkspaceSessionRecordingChunk, error) {
+	// Reading the chunks is authorized by reading the recording.
+	if _, err := q.GetWorkspaceSessionRecordingByID(ctx, recordingID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceSessionRecordingChunks(ctx, recordingID)
+}
+
+func (q *querier) GetWorkspaceSessionRecordingsByWorkspaceID(ctx context.Context, arg database.GetWorkspaceSessionRecordingsByWorkspaceIDParams) ([]database.WorkspaceSessionRecording, error) {
+	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.GetWorkspaceSessionRecordingsByWorkspaceID)(ctx, arg)
+}
+
 func (q *querier) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIDs []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -4120,6 +4314,13 @@ func (q *querier) GetWorkspacesEligibleForTransition(ctx context.Context, now ti
 	return q.db.GetWorkspacesEligibleForTransition(ctx, now)
 }
 
+func (q *querier) GetWorkspacesForTemplateMaintenance(ctx context.Context, templateID uuid.UUID) ([]database.GetWorkspacesForTemplateMaintenanceRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspacesForTemplateMaintenance(ctx, templateID)
+}
+
 func (q *querier) GetWorkspacesForWorkspaceMetrics(ctx context.Context) ([]database.GetWorkspacesForWorkspaceMetricsRow, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceWorkspace); err != nil {
 		return nil, err
@@ -4292,6 +4493,13 @@ func (q *querier) InsertMissingGroups(ctx context.Context, arg database.InsertMi
 	return q.db.InsertMissingGroups(ctx, arg)
 }
 
+func (q *querier) InsertNotificationTarget(ctx context.Context, arg database.InsertNotificationTargetParams) (database.NotificationTarget, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationPreference.WithOwner(arg.UserID.String())); err != nil {
+		return database.NotificationTarget{}, err
+	}
+	return q.db.InsertNotificationTarget(ctx, arg)
+}
+
 func (q *querier) InsertOAuth2ProviderApp(ctx context.Context, arg database.InsertOAuth2ProviderAppParams) (database.OAuth2ProviderApp, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceOauth2App); err != nil {
 		return database.OAuth2ProviderApp{}, err
@@ -4382,6 +4590,13 @@ func (q *querier) InsertProvisionerJobLogs(ctx context.Context, arg database.Ins
 	return q.db.InsertProvisionerJobLogs(ctx, arg)
 }
 
+func (q *querier) InsertProvisionerJobResourceChange(ctx context.Context, arg database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceProvisionerJobs); err != nil {
+		return database.ProvisionerJobResourceChange{}, err
+	}
+	return q.db.InsertProvisionerJobResourceChange(ctx, arg)
+}
+
 func (q *querier) InsertProvisionerJobTimings(ctx context.Context, arg database.InsertProvisionerJobTimingsParams) ([]database.ProvisionerJobTiming, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceProvisionerJobs); err != nil {
 		return nil, err
@@ -4528,6 +4743,13 @@ func (q *querier) InsertUserLink(ctx context.Context, arg database.InsertUserLin
 	return q.db.InsertUserLink(ctx, arg)
 }
 
+func (q *querier) InsertUserNotificationPreferenceTargets(ctx context.Context, arg database.InsertUserNotificationPreferenceTargetsParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationPreference.WithOwner(arg.UserID.String())); err != nil {
+		return -1, err
+	}
+	return q.db.InsertUserNotificationPreferenceTargets(ctx, arg)
+}
+
 func (q *querier) InsertVolumeResourceMonitor(ctx context.Context, arg database.InsertVolumeResourceMonitorParams) (database.WorkspaceAgentVolumeResourceMonitor, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceWorkspaceAgentResourceMonitor); err != nil {
 		return database.WorkspaceAgentVolumeResourceMonitor{}, err
@@ -4731,6 +4953,13 @@ func (q *querier) InsertWorkspaceResourceMetadata(ctx context.Context, arg datab
 	return q.db.InsertWorkspaceResourceMetadata(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceSessionRecording(ctx context.Context, arg database.InsertWorkspaceSessionRecordingParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceConnectionLog); err != nil {
+		return err
+	}
+	return q.db.InsertWorkspaceSessionRecording(ctx, arg)
+}
+
 func (q *querier) ListAIBridgeInterceptions(ctx context.Context, arg database.ListAIBridgeInterceptionsParams) ([]database.ListAIBridgeInterceptionsRow, error) {
 	prep, err := prepareSQLFilter(ctx, q.auth, policy.ActionRead, rbac.ResourceAibridgeInterception.Type)
 	if err != nil {
@@ -5094,6 +5323,20 @@ func (q *querier) UpdateMemoryResourceMonitor(ctx context.Context, arg database.
 	return q.db.UpdateMemoryResourceMonitor(ctx, arg)
 }
 
+func (q *querier) UpdateNotificationTargetVerificationCode(ctx context.Context, arg database.UpdateNotificationTargetVerificationCodeParams) (database.NotificationTarget, error) {
+	fetch := func(ctx context.Context, arg database.UpdateNotificationTargetVerificationCodeParams) (database.NotificationTarget, error) {
+		return q.db.GetNotificationTargetByID(ctx, arg.ID)
+	}
+	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateNotificationTargetVerificationCode)(ctx, arg)
+}
+
+func (q *querier) UpdateNotificationTargetVerifiedAt(ctx context.Context, arg database.UpdateNotificationTargetVerifiedAtParams) (database.NotificationTarget, error) {
+	fetch := func(ctx context.Context, arg database.UpdateNotificationTargetVerifiedAtParams) (database.NotificationTarget, error) {
+		return q.db.GetNotificationTargetByID(ctx, arg.ID)
+	}
+	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateNotificationTargetVerifiedAt)(ctx, arg)
+}
+
 func (q *querier) UpdateNotificationTemplateMethodByID(ctx context.Context, arg database.UpdateNotificationTemplateMethodByIDParams) (database.NotificationTemplate, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationTemplate); err != nil {
 		return database.NotificationTemplate{}, err
@@ -6073,6 +6316,19 @@ func (q *querier) UpsertOAuthSigningKey(ctx context.Context, value string) error
 	return q.db.UpsertOAuthSigningKey(ctx, value)
 }
 
+func (q *querier) UpsertOrganizationNotificationWebhook(ctx context.Context, arg database.UpsertOrganizationNotificationWebhookParams) (database.OrganizationNotificationWebhook, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceNotificationTemplate.InOrg(arg.OrganizationID)); err != nil {
+		return database.OrganizationNotificationWebhook{}, err
+	}
+	return q.db.UpsertOrganizationNotificationWebhook(ctx, arg)
+}
+func (q *querier) UpsertPrebuildReadiness(ctx context.Context, arg database.UpsertPrebuildReadinessParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourcePrebuiltWorkspace); err != nil {
+		return err
+	}
+	return q.db.UpsertPrebuildReadiness(ctx, arg)
+}
+
 func (q *querier) UpsertPrebuildsSettings(ctx context.Context, value string) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceDeploymentConfig); err != nil {
 		return err
@@ -6157,6 +6413,39 @@ func (q *querier) UpsertTelemetryItem(ctx context.Context, arg database.UpsertTe
 	return q.db.UpsertTelemetryItem(ctx, arg)
 }
 
+func (q *querier) UpsertTemplateAdaptivePrebuilds(ctx context.Context, arg database.UpsertTemplateAdaptivePrebuildsParams) (database.TemplateAdaptivePrebuild, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplateAdaptivePrebuild{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateAdaptivePrebuild{}, err
+	}
+	return q.db.UpsertTemplateAdaptivePrebuilds(ctx, arg)
+}
+
+func (q *querier) UpsertTemplateGroupScheduleOverride(ctx context.Context, arg database.UpsertTemplateGroupScheduleOverrideParams) (database.TemplateGroupScheduleOverride, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplateGroupScheduleOverride{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateGroupScheduleOverride{}, err
+	}
+	return q.db.UpsertTemplateGroupScheduleOverride(ctx, arg)
+}
+
+func (q *querier) UpsertTemplatePresetReadinessChecks(ctx context.Context, arg database.UpsertTemplatePresetReadinessChecksParams) (database.TemplatePresetReadinessCheck, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplatePresetReadinessCheck{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplatePresetReadinessCheck{}, err
+	}
+	return q.db.UpsertTemplatePresetReadinessChecks(ctx, arg)
+}
+
 func (q *querier) UpsertTemplateUsageStats(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
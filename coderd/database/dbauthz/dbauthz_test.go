@@ -362,6 +362,35 @@ func (s *MethodTestSuite) TestConnectionLogs() {
 		dbm.EXPECT().DeleteOldConnectionLogs(gomock.Any(), database.DeleteOldConnectionLogsParams{}).Return(int64(0), nil).AnyTimes()
 		check.Args(database.DeleteOldConnectionLogsParams{}).Asserts(rbac.ResourceSystem, policy.ActionDelete)
 	}))
+	s.Run("InsertWorkspaceSessionRecording", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		ws := testutil.Fake(s.T(), faker, database.WorkspaceTable{})
+		arg := database.InsertWorkspaceSessionRecordingParams{ID: uuid.New(), WorkspaceID: ws.ID, OrganizationID: ws.OrganizationID, Type: database.ConnectionTypeSsh}
+		dbm.EXPECT().InsertWorkspaceSessionRecording(gomock.Any(), arg).Return(nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceConnectionLog, policy.ActionUpdate)
+	}))
+	s.Run("AppendWorkspaceSessionRecordingData", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		arg := database.AppendWorkspaceSessionRecordingDataParams{ID: uuid.New(), OffsetBytes: 10, Data: []byte("data")}
+		dbm.EXPECT().AppendWorkspaceSessionRecordingData(gomock.Any(), arg).Return(int64(1), nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceConnectionLog, policy.ActionUpdate)
+	}))
+	s.Run("GetWorkspaceSessionRecordingByID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		rec := testutil.Fake(s.T(), faker, database.WorkspaceSessionRecording{})
+		dbm.EXPECT().GetWorkspaceSessionRecordingByID(gomock.Any(), rec.ID).Return(rec, nil).AnyTimes()
+		check.Args(rec.ID).Asserts(rec, policy.ActionRead).Returns(rec)
+	}))
+	s.Run("GetWorkspaceSessionRecordingChunks", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		rec := testutil.Fake(s.T(), faker, database.WorkspaceSessionRecording{})
+		chunks := []database.WorkspaceSessionRecordingChunk{{RecordingID: rec.ID, Data: []byte("data")}}
+		dbm.EXPECT().GetWorkspaceSessionRecordingByID(gomock.Any(), rec.ID).Return(rec, nil).AnyTimes()
+		dbm.EXPECT().GetWorkspaceSessionRecordingChunks(gomock.Any(), rec.ID).Return(chunks, nil).AnyTimes()
+		check.Args(rec.ID).Asserts(rec, policy.ActionRead).Returns(chunks)
+	}))
+	s.Run("GetWorkspaceSessionRecordingsByWorkspaceID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		rec := testutil.Fake(s.T(), faker, database.WorkspaceSessionRecording{})
+		arg := database.GetWorkspaceSessionRecordingsByWorkspaceIDParams{WorkspaceID: rec.WorkspaceID}
+		dbm.EXPECT().GetWorkspaceSessionRecordingsByWorkspaceID(gomock.Any(), arg).Return([]database.WorkspaceSessionRecording{rec}, nil).AnyTimes()
+		check.Args(arg).Asserts(rec, policy.ActionRead).Returns([]database.WorkspaceSessionRecording{rec})
+	}))
 }
 
 func (s *MethodTestSuite) TestFile() {
@@ -1114,6 +1143,34 @@ func (s *MethodTestSuite) TestTemplate() {
 		dbm.EXPECT().GetTemplateGroupRoles(gomock.Any(), t1.ID).Return([]database.TemplateGroup{}, nil).AnyTimes()
 		check.Args(t1.ID).Asserts(t1, policy.ActionUpdate)
 	}))
+	s.Run("GetTemplateGroupScheduleOverrides", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().GetTemplateGroupScheduleOverrides(gomock.Any(), t1.ID).Return([]database.TemplateGroupScheduleOverride{}, nil).AnyTimes()
+		check.Args(t1.ID).Asserts(t1, policy.ActionRead)
+	}))
+	s.Run("GetTemplateGroupScheduleOverridesByUserID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := database.GetTemplateGroupScheduleOverridesByUserIDParams{TemplateID: t1.ID, UserID: uuid.New()}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().GetTemplateGroupScheduleOverridesByUserID(gomock.Any(), arg).Return([]database.TemplateGroupScheduleOverride{}, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionRead)
+	}))
+	s.Run("UpsertTemplateGroupScheduleOverride", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := database.UpsertTemplateGroupScheduleOverrideParams{TemplateID: t1.ID, GroupID: uuid.New()}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().UpsertTemplateGroupScheduleOverride(gomock.Any(), arg).Return(database.TemplateGroupScheduleOverride{TemplateID: t1.ID, GroupID: arg.GroupID}, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("DeleteTemplateGroupScheduleOverride", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := database.DeleteTemplateGroupScheduleOverrideParams{TemplateID: t1.ID, GroupID: uuid.New()}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		override := database.TemplateGroupScheduleOverride{TemplateID: t1.ID, GroupID: arg.GroupID}
+		dbm.EXPECT().DeleteTemplateGroupScheduleOverride(gomock.Any(), arg).Return(override, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionUpdate).Returns(override)
+	}))
 	s.Run("GetTemplateUserRoles", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		t1 := testutil.Fake(s.T(), faker, database.Template{})
 		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
@@ -3039,6 +3096,15 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 		dbm.EXPECT().GetTemplates(gomock.Any()).Return([]database.Template{}, nil).AnyTimes()
 		check.Args().Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
+	s.Run("GetTemplatesWithMaintenanceWindow", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		dbm.EXPECT().GetTemplatesWithMaintenanceWindow(gomock.Any()).Return([]database.Template{}, nil).AnyTimes()
+		check.Args().Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
+	s.Run("GetWorkspacesForTemplateMaintenance", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		id := uuid.New()
+		dbm.EXPECT().GetWorkspacesForTemplateMaintenance(gomock.Any(), id).Return([]database.GetWorkspacesForTemplateMaintenanceRow{}, nil).AnyTimes()
+		check.Args(id).Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
 	s.Run("UpdateWorkspaceBuildCostByID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		b := testutil.Fake(s.T(), faker, database.WorkspaceBuild{})
 		arg := database.UpdateWorkspaceBuildCostByIDParams{ID: b.ID, DailyCost: 10}
@@ -3254,6 +3320,12 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 		dbm.EXPECT().InsertProvisionerJobTimings(gomock.Any(), arg).Return([]database.ProvisionerJobTiming{}, nil).AnyTimes()
 		check.Args(arg).Asserts(rbac.ResourceProvisionerJobs, policy.ActionUpdate)
 	}))
+	s.Run("InsertProvisionerJobResourceChange", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		j := testutil.Fake(s.T(), faker, database.ProvisionerJob{})
+		arg := database.InsertProvisionerJobResourceChangeParams{JobID: j.ID, Action: database.ResourceChangeActionReplace}
+		dbm.EXPECT().InsertProvisionerJobResourceChange(gomock.Any(), arg).Return(database.ProvisionerJobResourceChange{JobID: j.ID}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceProvisionerJobs, policy.ActionUpdate)
+	}))
 	s.Run("UpsertProvisionerDaemon", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		org := testutil.Fake(s.T(), faker, database.Organization{})
 		pd := rbac.ResourceProvisionerDaemon.InOrg(org.ID)
@@ -3573,6 +3645,16 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 		dbm.EXPECT().GetProvisionerJobTimingsByJobID(gomock.Any(), j.ID).Return([]database.ProvisionerJobTiming{}, nil).AnyTimes()
 		check.Args(j.ID).Asserts(ws, policy.ActionRead)
 	}))
+	s.Run("GetProvisionerJobResourceChangesByJobID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		j := testutil.Fake(s.T(), faker, database.ProvisionerJob{Type: database.ProvisionerJobTypeWorkspaceBuild})
+		b := testutil.Fake(s.T(), faker, database.WorkspaceBuild{JobID: j.ID})
+		ws := testutil.Fake(s.T(), faker, database.Workspace{ID: b.WorkspaceID})
+		dbm.EXPECT().GetProvisionerJobByID(gomock.Any(), j.ID).Return(j, nil).AnyTimes()
+		dbm.EXPECT().GetWorkspaceBuildByJobID(gomock.Any(), j.ID).Return(b, nil).AnyTimes()
+		dbm.EXPECT().GetWorkspaceByID(gomock.Any(), b.WorkspaceID).Return(ws, nil).AnyTimes()
+		dbm.EXPECT().GetProvisionerJobResourceChangesByJobID(gomock.Any(), j.ID).Return([]database.ProvisionerJobResourceChange{}, nil).AnyTimes()
+		check.Args(j.ID).Asserts(ws, policy.ActionRead)
+	}))
 	s.Run("GetWorkspaceAgentScriptTimingsByBuildID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		build := testutil.Fake(s.T(), faker, database.WorkspaceBuild{})
 		dbm.EXPECT().GetWorkspaceAgentScriptTimingsByBuildID(gomock.Any(), build.ID).Return([]database.GetWorkspaceAgentScriptTimingsByBuildIDRow{}, nil).AnyTimes()
@@ -3697,6 +3779,11 @@ func (s *MethodTestSuite) TestNotifications() {
 		check.Args(database.FetchNewMessageMetadataParams{UserID: u.ID}).
 			Asserts(rbac.ResourceNotificationMessage, policy.ActionRead)
 	}))
+	s.Run("GetNotificationMessageDeliveries", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		arg := database.GetNotificationMessageDeliveriesParams{Method: string(database.NotificationMethodWebhook), Limit: 10}
+		dbm.EXPECT().GetNotificationMessageDeliveries(gomock.Any(), arg).Return([]database.GetNotificationMessageDeliveriesRow{}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationMessage, policy.ActionRead)
+	}))
 	s.Run("GetNotificationMessagesByStatus", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
 		arg := database.GetNotificationMessagesByStatusParams{Status: database.NotificationMessageStatusLeased, Limit: 10}
 		dbm.EXPECT().GetNotificationMessagesByStatus(gomock.Any(), arg).Return([]database.NotificationMessage{}, nil).AnyTimes()
@@ -3750,6 +3837,31 @@ func (s *MethodTestSuite) TestNotifications() {
 		check.Args(arg).Asserts(rbac.ResourceNotificationTemplate, policy.ActionUpdate)
 	}))
 
+	// Organization notification webhooks
+	s.Run("GetOrganizationNotificationWebhooks", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		org := testutil.Fake(s.T(), faker, database.Organization{})
+		dbm.EXPECT().GetOrganizationNotificationWebhooks(gomock.Any(), org.ID).Return([]database.OrganizationNotificationWebhook{}, nil).AnyTimes()
+		check.Args(org.ID).Asserts(rbac.ResourceNotificationTemplate.InOrg(org.ID), policy.ActionRead)
+	}))
+	s.Run("GetOrganizationNotificationWebhooksByUserID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		arg := database.GetOrganizationNotificationWebhooksByUserIDParams{UserID: user.ID, Method: database.NotificationMethodSlack}
+		dbm.EXPECT().GetOrganizationNotificationWebhooksByUserID(gomock.Any(), arg).Return([]database.OrganizationNotificationWebhook{}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationMessage, policy.ActionRead)
+	}))
+	s.Run("UpsertOrganizationNotificationWebhook", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		org := testutil.Fake(s.T(), faker, database.Organization{})
+		arg := database.UpsertOrganizationNotificationWebhookParams{OrganizationID: org.ID, Method: database.NotificationMethodTeams, Endpoint: "https://example.com/webhook"}
+		dbm.EXPECT().UpsertOrganizationNotificationWebhook(gomock.Any(), arg).Return(database.OrganizationNotificationWebhook{}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationTemplate.InOrg(org.ID), policy.ActionUpdate)
+	}))
+	s.Run("DeleteOrganizationNotificationWebhook", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		org := testutil.Fake(s.T(), faker, database.Organization{})
+		arg := database.DeleteOrganizationNotificationWebhookParams{OrganizationID: org.ID, Method: database.NotificationMethodSlack}
+		dbm.EXPECT().DeleteOrganizationNotificationWebhook(gomock.Any(), arg).Return(nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationTemplate.InOrg(org.ID), policy.ActionUpdate)
+	}))
+
 	// Notification preferences
 	s.Run("GetUserNotificationPreferences", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		user := testutil.Fake(s.T(), faker, database.User{})
@@ -3762,6 +3874,67 @@ func (s *MethodTestSuite) TestNotifications() {
 		dbm.EXPECT().UpdateUserNotificationPreferences(gomock.Any(), arg).Return(int64(2), nil).AnyTimes()
 		check.Args(arg).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
 	}))
+	s.Run("GetUserNotificationPreferenceTargets", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		dbm.EXPECT().GetUserNotificationPreferenceTargets(gomock.Any(), user.ID).Return([]database.NotificationPreferenceTarget{}, nil).AnyTimes()
+		check.Args(user.ID).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionRead)
+	}))
+	s.Run("DeleteUserNotificationPreferenceTargets", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		arg := database.DeleteUserNotificationPreferenceTargetsParams{UserID: user.ID, NotificationTemplateIds: []uuid.UUID{notifications.TemplateWorkspaceDeleted}}
+		dbm.EXPECT().DeleteUserNotificationPreferenceTargets(gomock.Any(), arg).Return(nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
+	}))
+	s.Run("InsertUserNotificationPreferenceTargets", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		arg := database.InsertUserNotificationPreferenceTargetsParams{UserID: user.ID, NotificationTemplateIds: []uuid.UUID{notifications.TemplateWorkspaceDeleted}, NotificationTargetIds: []uuid.UUID{uuid.New()}}
+		dbm.EXPECT().InsertUserNotificationPreferenceTargets(gomock.Any(), arg).Return(int64(1), nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
+	}))
+
+	// Notification targets
+	s.Run("GetNotificationTargetByID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		target := testutil.Fake(s.T(), faker, database.NotificationTarget{})
+		dbm.EXPECT().GetNotificationTargetByID(gomock.Any(), target.ID).Return(target, nil).AnyTimes()
+		check.Args(target.ID).Asserts(target, policy.ActionRead).Returns(target)
+	}))
+	s.Run("GetNotificationTargetsByUserID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		dbm.EXPECT().GetNotificationTargetsByUserID(gomock.Any(), user.ID).Return([]database.NotificationTarget{}, nil).AnyTimes()
+		check.Args(user.ID).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionRead)
+	}))
+	s.Run("GetVerifiedNotificationTargetsByTemplateID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		arg := database.GetVerifiedNotificationTargetsByTemplateIDParams{UserID: user.ID, NotificationTemplateID: notifications.TemplateWorkspaceDeleted}
+		dbm.EXPECT().GetVerifiedNotificationTargetsByTemplateID(gomock.Any(), arg).Return([]database.NotificationTarget{}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationMessage, policy.ActionRead)
+	}))
+	s.Run("InsertNotificationTarget", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		user := testutil.Fake(s.T(), faker, database.User{})
+		arg := database.InsertNotificationTargetParams{UserID: user.ID, Name: "alerts", Method: database.NotificationMethodWebhook}
+		dbm.EXPECT().InsertNotificationTarget(gomock.Any(), arg).Return(database.NotificationTarget{}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceNotificationPreference.WithOwner(user.ID.String()), policy.ActionUpdate)
+	}))
+	s.Run("UpdateNotificationTargetVerificationCode", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		target := testutil.Fake(s.T(), faker, database.NotificationTarget{})
+		arg := database.UpdateNotificationTargetVerificationCodeParams{ID: target.ID, HashedVerificationCode: []byte("hash")}
+		dbm.EXPECT().GetNotificationTargetByID(gomock.Any(), target.ID).Return(target, nil).AnyTimes()
+		dbm.EXPECT().UpdateNotificationTargetVerificationCode(gomock.Any(), arg).Return(target, nil).AnyTimes()
+		check.Args(arg).Asserts(target, policy.ActionUpdate).Returns(target)
+	}))
+	s.Run("UpdateNotificationTargetVerifiedAt", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		target := testutil.Fake(s.T(), faker, database.NotificationTarget{})
+		arg := database.UpdateNotificationTargetVerifiedAtParams{ID: target.ID, VerifiedAt: dbtime.Now()}
+		dbm.EXPECT().GetNotificationTargetByID(gomock.Any(), target.ID).Return(target, nil).AnyTimes()
+		dbm.EXPECT().UpdateNotificationTargetVerifiedAt(gomock.Any(), arg).Return(target, nil).AnyTimes()
+		check.Args(arg).Asserts(target, policy.ActionUpdate).Returns(target)
+	}))
+	s.Run("DeleteNotificationTargetByID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		target := testutil.Fake(s.T(), faker, database.NotificationTarget{})
+		dbm.EXPECT().GetNotificationTargetByID(gomock.Any(), target.ID).Return(target, nil).AnyTimes()
+		dbm.EXPECT().DeleteNotificationTargetByID(gomock.Any(), target.ID).Return(nil).AnyTimes()
+		check.Args(target.ID).Asserts(target, policy.ActionUpdate).Returns()
+	}))
 
 	s.Run("GetInboxNotificationsByUserID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		u := testutil.Fake(s.T(), faker, database.User{})
@@ -3893,6 +4066,77 @@ func (s *MethodTestSuite) TestPrebuilds() {
 		dbm.EXPECT().GetPrebuildMetrics(gomock.Any()).Return([]database.GetPrebuildMetricsRow{}, nil).AnyTimes()
 		check.Args().Asserts(rbac.ResourceWorkspace.All(), policy.ActionRead)
 	}))
+	s.Run("GetPrebuildClaimHistory", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		arg := database.GetPrebuildClaimHistoryParams{TemplateIDs: []uuid.UUID{uuid.New()}, Since: dbtime.Now()}
+		dbm.EXPECT().GetPrebuildClaimHistory(gomock.Any(), arg).Return([]database.GetPrebuildClaimHistoryRow{}, nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourceWorkspace.All(), policy.ActionRead)
+	}))
+	s.Run("GetEnabledTemplateAdaptivePrebuilds", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		dbm.EXPECT().GetEnabledTemplateAdaptivePrebuilds(gomock.Any()).Return([]database.TemplateAdaptivePrebuild{}, nil).AnyTimes()
+		check.Args().Asserts(rbac.ResourceTemplate.All(), policy.ActionRead)
+	}))
+	s.Run("GetTemplateAdaptivePrebuildsByTemplateID", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().GetTemplateAdaptivePrebuildsByTemplateID(gomock.Any(), t1.ID).Return(database.TemplateAdaptivePrebuild{TemplateID: t1.ID}, nil).AnyTimes()
+		check.Args(t1.ID).Asserts(t1, policy.ActionRead)
+	}))
+	s.Run("UpsertTemplateAdaptivePrebuilds", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := database.UpsertTemplateAdaptivePrebuildsParams{TemplateID: t1.ID, Enabled: true, MaxInstances: 5, LookbackWeeks: 4}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().UpsertTemplateAdaptivePrebuilds(gomock.Any(), arg).Return(database.TemplateAdaptivePrebuild{TemplateID: t1.ID}, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("GetTemplatePresetReadinessChecks", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := uuid.NullUUID{UUID: t1.ID, Valid: true}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().GetTemplatePresetReadinessChecks(gomock.Any(), arg).Return([]database.TemplatePresetReadinessCheck{}, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionRead)
+	}))
+	s.Run("AllTemplates/GetTemplatePresetReadinessChecks", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		dbm.EXPECT().GetTemplatePresetReadinessChecks(gomock.Any(), uuid.NullUUID{}).Return([]database.TemplatePresetReadinessCheck{}, nil).AnyTimes()
+		check.Args(uuid.NullUUID{}).Asserts(rbac.ResourceTemplate.All(), policy.ActionRead)
+	}))
+	s.Run("UpsertTemplatePresetReadinessChecks", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := database.UpsertTemplatePresetReadinessChecksParams{
+			TemplateID: t1.ID,
+			PresetName: "default",
+			Checks:     database.PrebuildReadinessChecks{{Type: database.PrebuildReadinessCheckTypeScript, Script: "init"}},
+			Timeout:    int64(10 * time.Minute),
+		}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		dbm.EXPECT().UpsertTemplatePresetReadinessChecks(gomock.Any(), arg).Return(database.TemplatePresetReadinessCheck{TemplateID: t1.ID}, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("DeleteTemplatePresetReadinessChecks", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
+		t1 := testutil.Fake(s.T(), faker, database.Template{})
+		arg := database.DeleteTemplatePresetReadinessChecksParams{TemplateID: t1.ID, PresetName: "default"}
+		dbm.EXPECT().GetTemplateByID(gomock.Any(), t1.ID).Return(t1, nil).AnyTimes()
+		checks := database.TemplatePresetReadinessCheck{TemplateID: t1.ID, PresetName: "default"}
+		dbm.EXPECT().DeleteTemplatePresetReadinessChecks(gomock.Any(), arg).Return(checks, nil).AnyTimes()
+		check.Args(arg).Asserts(t1, policy.ActionUpdate).Returns(checks)
+	}))
+	s.Run("GetPrebuildReadiness", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		dbm.EXPECT().GetPrebuildReadiness(gomock.Any(), uuid.NullUUID{}).Return([]database.WorkspacePrebuildReadiness{}, nil).AnyTimes()
+		check.Args(uuid.NullUUID{}).Asserts(rbac.ResourceWorkspace.All(), policy.ActionRead)
+	}))
+	s.Run("UpsertPrebuildReadiness", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		arg := database.UpsertPrebuildReadinessParams{
+			WorkspaceID: uuid.New(),
+			BuildID:     uuid.New(),
+			Status:      database.PrebuildReadinessStatusPassed,
+			CheckedAt:   dbtime.Now(),
+		}
+		dbm.EXPECT().UpsertPrebuildReadiness(gomock.Any(), arg).Return(nil).AnyTimes()
+		check.Args(arg).Asserts(rbac.ResourcePrebuiltWorkspace, policy.ActionUpdate).Returns()
+	}))
+	s.Run("DeleteStalePrebuildReadiness", s.Mocked(func(dbm *dbmock.MockStore, _ *gofakeit.Faker, check *expects) {
+		dbm.EXPECT().DeleteStalePrebuildReadiness(gomock.Any()).Return(nil).AnyTimes()
+		check.Args().Asserts(rbac.ResourcePrebuiltWorkspace, policy.ActionUpdate).Returns()
+	}))
 	s.Run("GetOrganizationsWithPrebuildStatus", s.Mocked(func(dbm *dbmock.MockStore, faker *gofakeit.Faker, check *expects) {
 		arg := database.GetOrganizationsWithPrebuildStatusParams{
 			UserID:    uuid.New(),
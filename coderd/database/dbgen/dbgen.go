@@ -122,6 +122,46 @@ func ConnectionLog(t testing.TB, db database.Store, seed database.UpsertConnecti
 	return log
 }
 
+// WorkspaceSessionRecording inserts a session recording. Each of parts is
+// stored as a chunk, as if the agent had uploaded the recording in parts.
+func WorkspaceSessionRecording(t testing.TB, db database.Store, seed database.WorkspaceSessionRecording, parts ...[]byte) database.WorkspaceSessionRecording {
+	if len(parts) == 0 {
+		parts = [][]byte{[]byte("{\"version\":2,\"width\":80,\"height\":24}\n[0.1,\"o\",\"hello\\r\\n\"]\n")}
+	}
+	startedAt := takeFirst(seed.StartedAt, dbtime.Now().Add(-time.Minute))
+	arg := database.InsertWorkspaceSessionRecordingParams{
+		ID:             takeFirst(seed.ID, uuid.New()),
+		OrganizationID: takeFirst(seed.OrganizationID, uuid.New()),
+		WorkspaceID:    takeFirst(seed.WorkspaceID, uuid.New()),
+		AgentID:        takeFirst(seed.AgentID, uuid.New()),
+		ConnectionID:   takeFirst(seed.ConnectionID, uuid.New()),
+		Type:           takeFirst(seed.Type, database.ConnectionTypeSsh),
+		StartedAt:      startedAt,
+		EndedAt:        takeFirst(seed.EndedAt, startedAt.Add(time.Minute)),
+		CreatedAt:      takeFirst(seed.CreatedAt, dbtime.Now()),
+		IncludeInput:   seed.IncludeInput,
+		Truncated:      seed.Truncated,
+		Data:           parts[0],
+	}
+	err := db.InsertWorkspaceSessionRecording(genCtx, arg)
+	require.NoError(t, err, "insert workspace session recording")
+	offset := int64(len(parts[0]))
+	for _, part := range parts[1:] {
+		rows, err := db.AppendWorkspaceSessionRecordingData(genCtx, database.AppendWorkspaceSessionRecordingDataParams{
+			ID:          arg.ID,
+			AgentID:     arg.AgentID,
+			OffsetBytes: offset,
+			Data:        part,
+		})
+		require.NoError(t, err, "append workspace session recording data")
+		require.EqualValues(t, 1, rows, "append workspace session recording data")
+		offset += int64(len(part))
+	}
+	recording, err := db.GetWorkspaceSessionRecordingByID(genCtx, arg.ID)
+	require.NoError(t, err, "get workspace session recording")
+	return recording
+}
+
 func Template(t testing.TB, db database.Store, seed database.Template) database.Template {
 	id := takeFirst(seed.ID, uuid.New())
 	if seed.GroupACL == nil {
@@ -334,6 +374,8 @@ func WorkspaceAgentScript(t testing.TB, db database.Store, orig database.Workspa
 		TimeoutSeconds:   []int32{takeFirst(orig.TimeoutSeconds, 0)},
 		DisplayName:      []string{takeFirst(orig.DisplayName, "")},
 		ID:               []uuid.UUID{takeFirst(orig.ID, uuid.New())},
+		UnitName:         []string{takeFirst(orig.UnitName, "")},
+		DependsOn:        json.RawMessage("[" + string(takeFirstSlice(orig.DependsOn, json.RawMessage("[]"))) + "]"),
 	})
 	require.NoError(t, err, "insert workspace agent script")
 	require.NotEmpty(t, scripts, "insert workspace agent script returned no scripts")
@@ -420,6 +462,7 @@ func Workspace(t testing.TB, db database.Store, orig database.WorkspaceTable) da
 		Ttl:               orig.Ttl,
 		AutomaticUpdates:  takeFirst(orig.AutomaticUpdates, database.AutomaticUpdatesNever),
 		NextStartAt:       orig.NextStartAt,
+		SourceWorkspaceID: orig.SourceWorkspaceID,
 	})
 	require.NoError(t, err, "insert workspace")
 	if orig.Deleted {
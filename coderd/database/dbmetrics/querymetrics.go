@@ -144,6 +144,14 @@ func (m queryMetricsStore) AllUserIDs(ctx context.Context, includeSystem bool) (
 	return r0, r1
 }
 
+func (m queryMetricsStore) AppendWorkspaceSessionRecordingData(ctx context.Context, arg database.AppendWorkspaceSessionRecordingDataParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.AppendWorkspaceSessionRecordingData(ctx, arg)
+	m.queryLatencies.WithLabelValues("AppendWorkspaceSessionRecordingData").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "AppendWorkspaceSessionRecordingData").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) ArchiveUnusedTemplateVersions(ctx context.Context, arg database.ArchiveUnusedTemplateVersionsParams) ([]uuid.UUID, error) {
 	start := time.Now()
 	r0, r1 := m.s.ArchiveUnusedTemplateVersions(ctx, arg)
@@ -399,6 +407,14 @@ func (m queryMetricsStore) DeleteLicense(ctx context.Context, id int32) (int32,
 	return r0, r1
 }
 
+func (m queryMetricsStore) DeleteNotificationTargetByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteNotificationTargetByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteNotificationTargetByID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "DeleteNotificationTargetByID").Inc()
+	return r0
+}
+
 func (m queryMetricsStore) DeleteOAuth2ProviderAppByClientID(ctx context.Context, id uuid.UUID) error {
 	start := time.Now()
 	r0 := m.s.DeleteOAuth2ProviderAppByClientID(ctx, id)
@@ -527,6 +543,14 @@ func (m queryMetricsStore) DeleteOrganizationMember(ctx context.Context, arg dat
 	return r0
 }
 
+func (m queryMetricsStore) DeleteOrganizationNotificationWebhook(ctx context.Context, arg database.DeleteOrganizationNotificationWebhookParams) error {
+	start := time.Now()
+	r0 := m.s.DeleteOrganizationNotificationWebhook(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOrganizationNotificationWebhook").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "DeleteOrganizationNotificationWebhook").Inc()
+	return r0
+}
+
 func (m queryMetricsStore) DeleteProvisionerKey(ctx context.Context, id uuid.UUID) error {
 	start := time.Now()
 	r0 := m.s.DeleteProvisionerKey(ctx, id)
@@ -551,6 +575,14 @@ func (m queryMetricsStore) DeleteRuntimeConfig(ctx context.Context, key string)
 	return r0
 }
 
+func (m queryMetricsStore) DeleteStalePrebuildReadiness(ctx context.Context) error {
+	start := time.Now()
+	r0 := m.s.DeleteStalePrebuildReadiness(ctx)
+	m.queryLatencies.WithLabelValues("DeleteStalePrebuildReadiness").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "DeleteStalePrebuildReadiness").Inc()
+	return r0
+}
+
 func (m queryMetricsStore) DeleteTailnetPeer(ctx context.Context, arg database.DeleteTailnetPeerParams) (database.DeleteTailnetPeerRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.DeleteTailnetPeer(ctx, arg)
@@ -575,6 +607,30 @@ func (m queryMetricsStore) DeleteTask(ctx context.Context, arg database.DeleteTa
 	return r0, r1
 }
 
+func (m queryMetricsStore) DeleteTemplateGroupScheduleOverride(ctx context.Context, arg database.DeleteTemplateGroupScheduleOverrideParams) (database.TemplateGroupScheduleOverride, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteTemplateGroupScheduleOverride(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteTemplateGroupScheduleOverride").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "DeleteTemplateGroupScheduleOverride").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) DeleteTemplatePresetReadinessChecks(ctx context.Context, arg database.DeleteTemplatePresetReadinessChecksParams) (database.TemplatePresetReadinessCheck, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteTemplatePresetReadinessChecks(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteTemplatePresetReadinessChecks").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "DeleteTemplatePresetReadinessChecks").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) DeleteUserNotificationPreferenceTargets(ctx context.Context, arg database.DeleteUserNotificationPreferenceTargetsParams) error {
+	start := time.Now()
+	r0 := m.s.DeleteUserNotificationPreferenceTargets(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteUserNotificationPreferenceTargets").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "DeleteUserNotificationPreferenceTargets").Inc()
+	return r0
+}
+
 func (m queryMetricsStore) DeleteUserSecret(ctx context.Context, id uuid.UUID) error {
 	start := time.Now()
 	r0 := m.s.DeleteUserSecret(ctx, id)
@@ -1022,6 +1078,14 @@ func (m queryMetricsStore) GetEligibleProvisionerDaemonsByProvisionerJobIDs(ctx
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetEnabledTemplateAdaptivePrebuilds(ctx context.Context) ([]database.TemplateAdaptivePrebuild, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetEnabledTemplateAdaptivePrebuilds(ctx)
+	m.queryLatencies.WithLabelValues("GetEnabledTemplateAdaptivePrebuilds").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetEnabledTemplateAdaptivePrebuilds").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetExternalAuthLink(ctx context.Context, arg database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetExternalAuthLink(ctx, arg)
@@ -1238,6 +1302,14 @@ func (m queryMetricsStore) GetLogoURL(ctx context.Context) (string, error) {
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetNotificationMessageDeliveries(ctx context.Context, arg database.GetNotificationMessageDeliveriesParams) ([]database.GetNotificationMessageDeliveriesRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationMessageDeliveries(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetNotificationMessageDeliveries").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetNotificationMessageDeliveries").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetNotificationMessagesByStatus(ctx, arg)
@@ -1254,6 +1326,22 @@ func (m queryMetricsStore) GetNotificationReportGeneratorLogByTemplate(ctx conte
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetNotificationTargetByID(ctx context.Context, id uuid.UUID) (database.NotificationTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationTargetByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetNotificationTargetByID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetNotificationTargetByID").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) GetNotificationTargetsByUserID(ctx context.Context, userID uuid.UUID) ([]database.NotificationTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationTargetsByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetNotificationTargetsByUserID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetNotificationTargetsByUserID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetNotificationTemplateByID(ctx, id)
@@ -1414,6 +1502,22 @@ func (m queryMetricsStore) GetOrganizationIDsByMemberIDs(ctx context.Context, id
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetOrganizationNotificationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]database.OrganizationNotificationWebhook, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOrganizationNotificationWebhooks(ctx, organizationID)
+	m.queryLatencies.WithLabelValues("GetOrganizationNotificationWebhooks").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetOrganizationNotificationWebhooks").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg database.GetOrganizationNotificationWebhooksByUserIDParams) ([]database.OrganizationNotificationWebhook, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOrganizationNotificationWebhooksByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetOrganizationNotificationWebhooksByUserID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetOrganizationNotificationWebhooksByUserID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetOrganizationResourceCountByID(ctx context.Context, organizationID uuid.UUID) (database.GetOrganizationResourceCountByIDRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetOrganizationResourceCountByID(ctx, organizationID)
@@ -1454,6 +1558,14 @@ func (m queryMetricsStore) GetParameterSchemasByJobID(ctx context.Context, jobID
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetPrebuildClaimHistory(ctx context.Context, arg database.GetPrebuildClaimHistoryParams) ([]database.GetPrebuildClaimHistoryRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetPrebuildClaimHistory(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetPrebuildClaimHistory").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetPrebuildClaimHistory").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetPrebuildMetrics(ctx context.Context) ([]database.GetPrebuildMetricsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetPrebuildMetrics(ctx)
@@ -1462,6 +1574,14 @@ func (m queryMetricsStore) GetPrebuildMetrics(ctx context.Context) ([]database.G
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetPrebuildReadiness(ctx context.Context, templateID uuid.NullUUID) ([]database.WorkspacePrebuildReadiness, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetPrebuildReadiness(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetPrebuildReadiness").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetPrebuildReadiness").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetPrebuildsSettings(ctx context.Context) (string, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetPrebuildsSettings(ctx)
@@ -1582,6 +1702,14 @@ func (m queryMetricsStore) GetProvisionerJobByIDWithLock(ctx context.Context, id
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetProvisionerJobResourceChangesByJobID(ctx, jobID)
+	m.queryLatencies.WithLabelValues("GetProvisionerJobResourceChangesByJobID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetProvisionerJobResourceChangesByJobID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobTiming, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetProvisionerJobTimingsByJobID(ctx, jobID)
@@ -1790,6 +1918,14 @@ func (m queryMetricsStore) GetTelemetryItems(ctx context.Context) ([]database.Te
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetTemplateAdaptivePrebuildsByTemplateID(ctx context.Context, templateID uuid.UUID) (database.TemplateAdaptivePrebuild, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateAdaptivePrebuildsByTemplateID(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplateAdaptivePrebuildsByTemplateID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetTemplateAdaptivePrebuildsByTemplateID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetTemplateAppInsights(ctx context.Context, arg database.GetTemplateAppInsightsParams) ([]database.GetTemplateAppInsightsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTemplateAppInsights(ctx, arg)
@@ -1838,6 +1974,22 @@ func (m queryMetricsStore) GetTemplateDAUs(ctx context.Context, arg database.Get
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetTemplateGroupScheduleOverrides(ctx context.Context, templateID uuid.UUID) ([]database.TemplateGroupScheduleOverride, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateGroupScheduleOverrides(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplateGroupScheduleOverrides").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetTemplateGroupScheduleOverrides").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) GetTemplateGroupScheduleOverridesByUserID(ctx context.Context, arg database.GetTemplateGroupScheduleOverridesByUserIDParams) ([]database.TemplateGroupScheduleOverride, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateGroupScheduleOverridesByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetTemplateGroupScheduleOverridesByUserID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetTemplateGroupScheduleOverridesByUserID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetTemplateInsights(ctx context.Context, arg database.GetTemplateInsightsParams) (database.GetTemplateInsightsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTemplateInsights(ctx, arg)
@@ -1870,6 +2022,14 @@ func (m queryMetricsStore) GetTemplateParameterInsights(ctx context.Context, arg
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.NullUUID) ([]database.TemplatePresetReadinessCheck, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplatePresetReadinessChecks(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplatePresetReadinessChecks").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetTemplatePresetReadinessChecks").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetTemplatePresetsWithPrebuilds(ctx context.Context, templateID uuid.NullUUID) ([]database.GetTemplatePresetsWithPrebuildsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTemplatePresetsWithPrebuilds(ctx, templateID)
@@ -1990,6 +2150,14 @@ func (m queryMetricsStore) GetTemplatesWithFilter(ctx context.Context, arg datab
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetTemplatesWithMaintenanceWindow(ctx context.Context) ([]database.Template, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplatesWithMaintenanceWindow(ctx)
+	m.queryLatencies.WithLabelValues("GetTemplatesWithMaintenanceWindow").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetTemplatesWithMaintenanceWindow").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetTotalUsageDCManagedAgentsV1(ctx context.Context, arg database.GetTotalUsageDCManagedAgentsV1Params) (int64, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTotalUsageDCManagedAgentsV1(ctx, arg)
@@ -2070,6 +2238,14 @@ func (m queryMetricsStore) GetUserLinksByUserID(ctx context.Context, userID uuid
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetUserNotificationPreferenceTargets(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreferenceTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserNotificationPreferenceTargets(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetUserNotificationPreferenceTargets").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetUserNotificationPreferenceTargets").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetUserNotificationPreferences(ctx, userID)
@@ -2150,6 +2326,14 @@ func (m queryMetricsStore) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetVerifiedNotificationTargetsByTemplateID(ctx context.Context, arg database.GetVerifiedNotificationTargetsByTemplateIDParams) ([]database.NotificationTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetVerifiedNotificationTargetsByTemplateID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetVerifiedNotificationTargetsByTemplateID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetVerifiedNotificationTargetsByTemplateID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetWebpushSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]database.WebpushSubscription, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetWebpushSubscriptionsByUserID(ctx, userID)
@@ -2590,6 +2774,30 @@ func (m queryMetricsStore) GetWorkspaceResourcesCreatedAfter(ctx context.Context
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetWorkspaceSessionRecordingByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSessionRecording, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSessionRecordingByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSessionRecordingByID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetWorkspaceSessionRecordingByID").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) GetWorkspaceSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]database.WorkspaceSessionRecordingChunk, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSessionRecordingChunks(ctx, recordingID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSessionRecordingChunks").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetWorkspaceSessionRecordingChunks").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) GetWorkspaceSessionRecordingsByWorkspaceID(ctx context.Context, arg database.GetWorkspaceSessionRecordingsByWorkspaceIDParams) ([]database.WorkspaceSessionRecording, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSessionRecordingsByWorkspaceID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSessionRecordingsByWorkspaceID").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetWorkspaceSessionRecordingsByWorkspaceID").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx, templateIds)
@@ -2630,6 +2838,14 @@ func (m queryMetricsStore) GetWorkspacesEligibleForTransition(ctx context.Contex
 	return r0, r1
 }
 
+func (m queryMetricsStore) GetWorkspacesForTemplateMaintenance(ctx context.Context, templateID uuid.UUID) ([]database.GetWorkspacesForTemplateMaintenanceRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspacesForTemplateMaintenance(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetWorkspacesForTemplateMaintenance").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "GetWorkspacesForTemplateMaintenance").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) GetWorkspacesForWorkspaceMetrics(ctx context.Context) ([]database.GetWorkspacesForWorkspaceMetricsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetWorkspacesForWorkspaceMetrics(ctx)
@@ -2806,6 +3022,14 @@ func (m queryMetricsStore) InsertMissingGroups(ctx context.Context, arg database
 	return r0, r1
 }
 
+func (m queryMetricsStore) InsertNotificationTarget(ctx context.Context, arg database.InsertNotificationTargetParams) (database.NotificationTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertNotificationTarget(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertNotificationTarget").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "InsertNotificationTarget").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) InsertOAuth2ProviderApp(ctx context.Context, arg database.InsertOAuth2ProviderAppParams) (database.OAuth2ProviderApp, error) {
 	start := time.Now()
 	r0, r1 := m.s.InsertOAuth2ProviderApp(ctx, arg)
@@ -2894,6 +3118,14 @@ func (m queryMetricsStore) InsertProvisionerJobLogs(ctx context.Context, arg dat
 	return r0, r1
 }
 
+func (m queryMetricsStore) InsertProvisionerJobResourceChange(ctx context.Context, arg database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertProvisionerJobResourceChange(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertProvisionerJobResourceChange").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "InsertProvisionerJobResourceChange").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) InsertProvisionerJobTimings(ctx context.Context, arg database.InsertProvisionerJobTimingsParams) ([]database.ProvisionerJobTiming, error) {
 	start := time.Now()
 	r0, r1 := m.s.InsertProvisionerJobTimings(ctx, arg)
@@ -3030,6 +3262,14 @@ func (m queryMetricsStore) InsertUserLink(ctx context.Context, arg database.Inse
 	return r0, r1
 }
 
+func (m queryMetricsStore) InsertUserNotificationPreferenceTargets(ctx context.Context, arg database.InsertUserNotificationPreferenceTargetsParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertUserNotificationPreferenceTargets(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertUserNotificationPreferenceTargets").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "InsertUserNotificationPreferenceTargets").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) InsertVolumeResourceMonitor(ctx context.Context, arg database.InsertVolumeResourceMonitorParams) (database.WorkspaceAgentVolumeResourceMonitor, error) {
 	start := time.Now()
 	r0, r1 := m.s.InsertVolumeResourceMonitor(ctx, arg)
@@ -3182,6 +3422,14 @@ func (m queryMetricsStore) InsertWorkspaceResourceMetadata(ctx context.Context,
 	return r0, r1
 }
 
+func (m queryMetricsStore) InsertWorkspaceSessionRecording(ctx context.Context, arg database.InsertWorkspaceSessionRecordingParams) error {
+	start := time.Now()
+	r0 := m.s.InsertWorkspaceSessionRecording(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceSessionRecording").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "InsertWorkspaceSessionRecording").Inc()
+	return r0
+}
+
 func (m queryMetricsStore) ListAIBridgeInterceptions(ctx context.Context, arg database.ListAIBridgeInterceptionsParams) ([]database.ListAIBridgeInterceptionsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.ListAIBridgeInterceptions(ctx, arg)
@@ -3470,6 +3718,22 @@ func (m queryMetricsStore) UpdateMemoryResourceMonitor(ctx context.Context, arg
 	return r0
 }
 
+func (m queryMetricsStore) UpdateNotificationTargetVerificationCode(ctx context.Context, arg database.UpdateNotificationTargetVerificationCodeParams) (database.NotificationTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationTargetVerificationCode(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationTargetVerificationCode").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpdateNotificationTargetVerificationCode").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) UpdateNotificationTargetVerifiedAt(ctx context.Context, arg database.UpdateNotificationTargetVerifiedAtParams) (database.NotificationTarget, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationTargetVerifiedAt(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationTargetVerifiedAt").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpdateNotificationTargetVerifiedAt").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) UpdateNotificationTemplateMethodByID(ctx context.Context, arg database.UpdateNotificationTemplateMethodByIDParams) (database.NotificationTemplate, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpdateNotificationTemplateMethodByID(ctx, arg)
@@ -4181,6 +4445,22 @@ func (m queryMetricsStore) UpsertOAuthSigningKey(ctx context.Context, value stri
 	return r0
 }
 
+func (m queryMetricsStore) UpsertOrganizationNotificationWebhook(ctx context.Context, arg database.UpsertOrganizationNotificationWebhookParams) (database.OrganizationNotificationWebhook, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertOrganizationNotificationWebhook(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertOrganizationNotificationWebhook").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpsertOrganizationNotificationWebhook").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) UpsertPrebuildReadiness(ctx context.Context, arg database.UpsertPrebuildReadinessParams) error {
+	start := time.Now()
+	r0 := m.s.UpsertPrebuildReadiness(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertPrebuildReadiness").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpsertPrebuildReadiness").Inc()
+	return r0
+}
+
 func (m queryMetricsStore) UpsertPrebuildsSettings(ctx context.Context, value string) error {
 	start := time.Now()
 	r0 := m.s.UpsertPrebuildsSettings(ctx, value)
@@ -4253,6 +4533,30 @@ func (m queryMetricsStore) UpsertTelemetryItem(ctx context.Context, arg database
 	return r0
 }
 
+func (m queryMetricsStore) UpsertTemplateAdaptivePrebuilds(ctx context.Context, arg database.UpsertTemplateAdaptivePrebuildsParams) (database.TemplateAdaptivePrebuild, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertTemplateAdaptivePrebuilds(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertTemplateAdaptivePrebuilds").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpsertTemplateAdaptivePrebuilds").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) UpsertTemplateGroupScheduleOverride(ctx context.Context, arg database.UpsertTemplateGroupScheduleOverrideParams) (database.TemplateGroupScheduleOverride, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertTemplateGroupScheduleOverride(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertTemplateGroupScheduleOverride").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpsertTemplateGroupScheduleOverride").Inc()
+	return r0, r1
+}
+
+func (m queryMetricsStore) UpsertTemplatePresetReadinessChecks(ctx context.Context, arg database.UpsertTemplatePresetReadinessChecksParams) (database.TemplatePresetReadinessCheck, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertTemplatePresetReadinessChecks(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertTemplatePresetReadinessChecks").Observe(time.Since(start).Seconds())
+	m.queryCounts.WithLabelValues(httpmw.ExtractHTTPRoute(ctx), httpmw.ExtractHTTPMethod(ctx), "UpsertTemplatePresetReadinessChecks").Inc()
+	return r0, r1
+}
+
 func (m queryMetricsStore) UpsertTemplateUsageStats(ctx context.Context) error {
 	start := time.Now()
 	r0 := m.s.UpsertTemplateUsageStats(ctx)
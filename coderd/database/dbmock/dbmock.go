@@ -117,6 +117,21 @@ func (mr *MockStoreMockRecorder) AllUserIDs(ctx, includeSystem any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllUserIDs", reflect.TypeOf((*MockStore)(nil).AllUserIDs), ctx, includeSystem)
 }
 
+// AppendWorkspaceSessionRecordingData mocks base method.
+func (m *MockStore) AppendWorkspaceSessionRecordingData(ctx context.Context, arg database.AppendWorkspaceSessionRecordingDataParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendWorkspaceSessionRecordingData", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendWorkspaceSessionRecordingData indicates an expected call of AppendWorkspaceSessionRecordingData.
+func (mr *MockStoreMockRecorder) AppendWorkspaceSessionRecordingData(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendWorkspaceSessionRecordingData", reflect.TypeOf((*MockStore)(nil).AppendWorkspaceSessionRecordingData), ctx, arg)
+}
+
 // ArchiveUnusedTemplateVersions mocks base method.
 func (m *MockStore) ArchiveUnusedTemplateVersions(ctx context.Context, arg database.ArchiveUnusedTemplateVersionsParams) ([]uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -626,6 +641,20 @@ func (mr *MockStoreMockRecorder) DeleteLicense(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLicense", reflect.TypeOf((*MockStore)(nil).DeleteLicense), ctx, id)
 }
 
+// DeleteNotificationTargetByID mocks base method.
+func (m *MockStore) DeleteNotificationTargetByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationTargetByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationTargetByID indicates an expected call of DeleteNotificationTargetByID.
+func (mr *MockStoreMockRecorder) DeleteNotificationTargetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationTargetByID", reflect.TypeOf((*MockStore)(nil).DeleteNotificationTargetByID), ctx, id)
+}
+
 // DeleteOAuth2ProviderAppByClientID mocks base method.
 func (m *MockStore) DeleteOAuth2ProviderAppByClientID(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -854,6 +883,20 @@ func (mr *MockStoreMockRecorder) DeleteOrganizationMember(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationMember", reflect.TypeOf((*MockStore)(nil).DeleteOrganizationMember), ctx, arg)
 }
 
+// DeleteOrganizationNotificationWebhook mocks base method.
+func (m *MockStore) DeleteOrganizationNotificationWebhook(ctx context.Context, arg database.DeleteOrganizationNotificationWebhookParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganizationNotificationWebhook", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganizationNotificationWebhook indicates an expected call of DeleteOrganizationNotificationWebhook.
+func (mr *MockStoreMockRecorder) DeleteOrganizationNotificationWebhook(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationNotificationWebhook", reflect.TypeOf((*MockStore)(nil).DeleteOrganizationNotificationWebhook), ctx, arg)
+}
+
 // DeleteProvisionerKey mocks base method.
 func (m *MockStore) DeleteProvisionerKey(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -896,6 +939,20 @@ func (mr *MockStoreMockRecorder) DeleteRuntimeConfig(ctx, key any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuntimeConfig", reflect.TypeOf((*MockStore)(nil).DeleteRuntimeConfig), ctx, key)
 }
 
+// DeleteStalePrebuildReadiness mocks base method.
+func (m *MockStore) DeleteStalePrebuildReadiness(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStalePrebuildReadiness", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStalePrebuildReadiness indicates an expected call of DeleteStalePrebuildReadiness.
+func (mr *MockStoreMockRecorder) DeleteStalePrebuildReadiness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStalePrebuildReadiness", reflect.TypeOf((*MockStore)(nil).DeleteStalePrebuildReadiness), ctx)
+}
+
 // DeleteTailnetPeer mocks base method.
 func (m *MockStore) DeleteTailnetPeer(ctx context.Context, arg database.DeleteTailnetPeerParams) (database.DeleteTailnetPeerRow, error) {
 	m.ctrl.T.Helper()
@@ -941,6 +998,50 @@ func (mr *MockStoreMockRecorder) DeleteTask(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockStore)(nil).DeleteTask), ctx, arg)
 }
 
+// DeleteTemplateGroupScheduleOverride mocks base method.
+func (m *MockStore) DeleteTemplateGroupScheduleOverride(ctx context.Context, arg database.DeleteTemplateGroupScheduleOverrideParams) (database.TemplateGroupScheduleOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplateGroupScheduleOverride", ctx, arg)
+	ret0, _ := ret[0].(database.TemplateGroupScheduleOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTemplateGroupScheduleOverride indicates an expected call of DeleteTemplateGroupScheduleOverride.
+func (mr *MockStoreMockRecorder) DeleteTemplateGroupScheduleOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplateGroupScheduleOverride", reflect.TypeOf((*MockStore)(nil).DeleteTemplateGroupScheduleOverride), ctx, arg)
+}
+
+// DeleteTemplatePresetReadinessChecks mocks base method.
+func (m *MockStore) DeleteTemplatePresetReadinessChecks(ctx context.Context, arg database.DeleteTemplatePresetReadinessChecksParams) (database.TemplatePresetReadinessCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplatePresetReadinessChecks", ctx, arg)
+	ret0, _ := ret[0].(database.TemplatePresetReadinessCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTemplatePresetReadinessChecks indicates an expected call of DeleteTemplatePresetReadinessChecks.
+func (mr *MockStoreMockRecorder) DeleteTemplatePresetReadinessChecks(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplatePresetReadinessChecks", reflect.TypeOf((*MockStore)(nil).DeleteTemplatePresetReadinessChecks), ctx, arg)
+}
+
+// DeleteUserNotificationPreferenceTargets mocks base method.
+func (m *MockStore) DeleteUserNotificationPreferenceTargets(ctx context.Context, arg database.DeleteUserNotificationPreferenceTargetsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserNotificationPreferenceTargets", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserNotificationPreferenceTargets indicates an expected call of DeleteUserNotificationPreferenceTargets.
+func (mr *MockStoreMockRecorder) DeleteUserNotificationPreferenceTargets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserNotificationPreferenceTargets", reflect.TypeOf((*MockStore)(nil).DeleteUserNotificationPreferenceTargets), ctx, arg)
+}
+
 // DeleteUserSecret mocks base method.
 func (m *MockStore) DeleteUserSecret(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -1874,6 +1975,21 @@ func (mr *MockStoreMockRecorder) GetEligibleProvisionerDaemonsByProvisionerJobID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibleProvisionerDaemonsByProvisionerJobIDs", reflect.TypeOf((*MockStore)(nil).GetEligibleProvisionerDaemonsByProvisionerJobIDs), ctx, provisionerJobIds)
 }
 
+// GetEnabledTemplateAdaptivePrebuilds mocks base method.
+func (m *MockStore) GetEnabledTemplateAdaptivePrebuilds(ctx context.Context) ([]database.TemplateAdaptivePrebuild, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledTemplateAdaptivePrebuilds", ctx)
+	ret0, _ := ret[0].([]database.TemplateAdaptivePrebuild)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledTemplateAdaptivePrebuilds indicates an expected call of GetEnabledTemplateAdaptivePrebuilds.
+func (mr *MockStoreMockRecorder) GetEnabledTemplateAdaptivePrebuilds(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledTemplateAdaptivePrebuilds", reflect.TypeOf((*MockStore)(nil).GetEnabledTemplateAdaptivePrebuilds), ctx)
+}
+
 // GetExternalAuthLink mocks base method.
 func (m *MockStore) GetExternalAuthLink(ctx context.Context, arg database.GetExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	m.ctrl.T.Helper()
@@ -2279,6 +2395,21 @@ func (mr *MockStoreMockRecorder) GetLogoURL(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogoURL", reflect.TypeOf((*MockStore)(nil).GetLogoURL), ctx)
 }
 
+// GetNotificationMessageDeliveries mocks base method.
+func (m *MockStore) GetNotificationMessageDeliveries(ctx context.Context, arg database.GetNotificationMessageDeliveriesParams) ([]database.GetNotificationMessageDeliveriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationMessageDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]database.GetNotificationMessageDeliveriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationMessageDeliveries indicates an expected call of GetNotificationMessageDeliveries.
+func (mr *MockStoreMockRecorder) GetNotificationMessageDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationMessageDeliveries", reflect.TypeOf((*MockStore)(nil).GetNotificationMessageDeliveries), ctx, arg)
+}
+
 // GetNotificationMessagesByStatus mocks base method.
 func (m *MockStore) GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error) {
 	m.ctrl.T.Helper()
@@ -2309,6 +2440,36 @@ func (mr *MockStoreMockRecorder) GetNotificationReportGeneratorLogByTemplate(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationReportGeneratorLogByTemplate", reflect.TypeOf((*MockStore)(nil).GetNotificationReportGeneratorLogByTemplate), ctx, templateID)
 }
 
+// GetNotificationTargetByID mocks base method.
+func (m *MockStore) GetNotificationTargetByID(ctx context.Context, id uuid.UUID) (database.NotificationTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTargetByID", ctx, id)
+	ret0, _ := ret[0].(database.NotificationTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationTargetByID indicates an expected call of GetNotificationTargetByID.
+func (mr *MockStoreMockRecorder) GetNotificationTargetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTargetByID", reflect.TypeOf((*MockStore)(nil).GetNotificationTargetByID), ctx, id)
+}
+
+// GetNotificationTargetsByUserID mocks base method.
+func (m *MockStore) GetNotificationTargetsByUserID(ctx context.Context, userID uuid.UUID) ([]database.NotificationTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTargetsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]database.NotificationTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationTargetsByUserID indicates an expected call of GetNotificationTargetsByUserID.
+func (mr *MockStoreMockRecorder) GetNotificationTargetsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTargetsByUserID", reflect.TypeOf((*MockStore)(nil).GetNotificationTargetsByUserID), ctx, userID)
+}
+
 // GetNotificationTemplateByID mocks base method.
 func (m *MockStore) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
 	m.ctrl.T.Helper()
@@ -2609,6 +2770,36 @@ func (mr *MockStoreMockRecorder) GetOrganizationIDsByMemberIDs(ctx, ids any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationIDsByMemberIDs", reflect.TypeOf((*MockStore)(nil).GetOrganizationIDsByMemberIDs), ctx, ids)
 }
 
+// GetOrganizationNotificationWebhooks mocks base method.
+func (m *MockStore) GetOrganizationNotificationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]database.OrganizationNotificationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationNotificationWebhooks", ctx, organizationID)
+	ret0, _ := ret[0].([]database.OrganizationNotificationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationNotificationWebhooks indicates an expected call of GetOrganizationNotificationWebhooks.
+func (mr *MockStoreMockRecorder) GetOrganizationNotificationWebhooks(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationNotificationWebhooks", reflect.TypeOf((*MockStore)(nil).GetOrganizationNotificationWebhooks), ctx, organizationID)
+}
+
+// GetOrganizationNotificationWebhooksByUserID mocks base method.
+func (m *MockStore) GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg database.GetOrganizationNotificationWebhooksByUserIDParams) ([]database.OrganizationNotificationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationNotificationWebhooksByUserID", ctx, arg)
+	ret0, _ := ret[0].([]database.OrganizationNotificationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationNotificationWebhooksByUserID indicates an expected call of GetOrganizationNotificationWebhooksByUserID.
+func (mr *MockStoreMockRecorder) GetOrganizationNotificationWebhooksByUserID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationNotificationWebhooksByUserID", reflect.TypeOf((*MockStore)(nil).GetOrganizationNotificationWebhooksByUserID), ctx, arg)
+}
+
 // GetOrganizationResourceCountByID mocks base method.
 func (m *MockStore) GetOrganizationResourceCountByID(ctx context.Context, organizationID uuid.UUID) (database.GetOrganizationResourceCountByIDRow, error) {
 	m.ctrl.T.Helper()
@@ -2684,6 +2875,21 @@ func (mr *MockStoreMockRecorder) GetParameterSchemasByJobID(ctx, jobID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameterSchemasByJobID", reflect.TypeOf((*MockStore)(nil).GetParameterSchemasByJobID), ctx, jobID)
 }
 
+// GetPrebuildClaimHistory mocks base method.
+func (m *MockStore) GetPrebuildClaimHistory(ctx context.Context, arg database.GetPrebuildClaimHistoryParams) ([]database.GetPrebuildClaimHistoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrebuildClaimHistory", ctx, arg)
+	ret0, _ := ret[0].([]database.GetPrebuildClaimHistoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrebuildClaimHistory indicates an expected call of GetPrebuildClaimHistory.
+func (mr *MockStoreMockRecorder) GetPrebuildClaimHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrebuildClaimHistory", reflect.TypeOf((*MockStore)(nil).GetPrebuildClaimHistory), ctx, arg)
+}
+
 // GetPrebuildMetrics mocks base method.
 func (m *MockStore) GetPrebuildMetrics(ctx context.Context) ([]database.GetPrebuildMetricsRow, error) {
 	m.ctrl.T.Helper()
@@ -2699,6 +2905,21 @@ func (mr *MockStoreMockRecorder) GetPrebuildMetrics(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrebuildMetrics", reflect.TypeOf((*MockStore)(nil).GetPrebuildMetrics), ctx)
 }
 
+// GetPrebuildReadiness mocks base method.
+func (m *MockStore) GetPrebuildReadiness(ctx context.Context, templateID uuid.NullUUID) ([]database.WorkspacePrebuildReadiness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrebuildReadiness", ctx, templateID)
+	ret0, _ := ret[0].([]database.WorkspacePrebuildReadiness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrebuildReadiness indicates an expected call of GetPrebuildReadiness.
+func (mr *MockStoreMockRecorder) GetPrebuildReadiness(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrebuildReadiness", reflect.TypeOf((*MockStore)(nil).GetPrebuildReadiness), ctx, templateID)
+}
+
 // GetPrebuildsSettings mocks base method.
 func (m *MockStore) GetPrebuildsSettings(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -2924,6 +3145,21 @@ func (mr *MockStoreMockRecorder) GetProvisionerJobByIDWithLock(ctx, id any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionerJobByIDWithLock", reflect.TypeOf((*MockStore)(nil).GetProvisionerJobByIDWithLock), ctx, id)
 }
 
+// GetProvisionerJobResourceChangesByJobID mocks base method.
+func (m *MockStore) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvisionerJobResourceChangesByJobID", ctx, jobID)
+	ret0, _ := ret[0].([]database.ProvisionerJobResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvisionerJobResourceChangesByJobID indicates an expected call of GetProvisionerJobResourceChangesByJobID.
+func (mr *MockStoreMockRecorder) GetProvisionerJobResourceChangesByJobID(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionerJobResourceChangesByJobID", reflect.TypeOf((*MockStore)(nil).GetProvisionerJobResourceChangesByJobID), ctx, jobID)
+}
+
 // GetProvisionerJobTimingsByJobID mocks base method.
 func (m *MockStore) GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]database.ProvisionerJobTiming, error) {
 	m.ctrl.T.Helper()
@@ -3314,6 +3550,21 @@ func (mr *MockStoreMockRecorder) GetTelemetryItems(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTelemetryItems", reflect.TypeOf((*MockStore)(nil).GetTelemetryItems), ctx)
 }
 
+// GetTemplateAdaptivePrebuildsByTemplateID mocks base method.
+func (m *MockStore) GetTemplateAdaptivePrebuildsByTemplateID(ctx context.Context, templateID uuid.UUID) (database.TemplateAdaptivePrebuild, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateAdaptivePrebuildsByTemplateID", ctx, templateID)
+	ret0, _ := ret[0].(database.TemplateAdaptivePrebuild)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateAdaptivePrebuildsByTemplateID indicates an expected call of GetTemplateAdaptivePrebuildsByTemplateID.
+func (mr *MockStoreMockRecorder) GetTemplateAdaptivePrebuildsByTemplateID(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateAdaptivePrebuildsByTemplateID", reflect.TypeOf((*MockStore)(nil).GetTemplateAdaptivePrebuildsByTemplateID), ctx, templateID)
+}
+
 // GetTemplateAppInsights mocks base method.
 func (m *MockStore) GetTemplateAppInsights(ctx context.Context, arg database.GetTemplateAppInsightsParams) ([]database.GetTemplateAppInsightsRow, error) {
 	m.ctrl.T.Helper()
@@ -3419,6 +3670,36 @@ func (mr *MockStoreMockRecorder) GetTemplateGroupRoles(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateGroupRoles", reflect.TypeOf((*MockStore)(nil).GetTemplateGroupRoles), ctx, id)
 }
 
+// GetTemplateGroupScheduleOverrides mocks base method.
+func (m *MockStore) GetTemplateGroupScheduleOverrides(ctx context.Context, templateID uuid.UUID) ([]database.TemplateGroupScheduleOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateGroupScheduleOverrides", ctx, templateID)
+	ret0, _ := ret[0].([]database.TemplateGroupScheduleOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateGroupScheduleOverrides indicates an expected call of GetTemplateGroupScheduleOverrides.
+func (mr *MockStoreMockRecorder) GetTemplateGroupScheduleOverrides(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateGroupScheduleOverrides", reflect.TypeOf((*MockStore)(nil).GetTemplateGroupScheduleOverrides), ctx, templateID)
+}
+
+// GetTemplateGroupScheduleOverridesByUserID mocks base method.
+func (m *MockStore) GetTemplateGroupScheduleOverridesByUserID(ctx context.Context, arg database.GetTemplateGroupScheduleOverridesByUserIDParams) ([]database.TemplateGroupScheduleOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateGroupScheduleOverridesByUserID", ctx, arg)
+	ret0, _ := ret[0].([]database.TemplateGroupScheduleOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateGroupScheduleOverridesByUserID indicates an expected call of GetTemplateGroupScheduleOverridesByUserID.
+func (mr *MockStoreMockRecorder) GetTemplateGroupScheduleOverridesByUserID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateGroupScheduleOverridesByUserID", reflect.TypeOf((*MockStore)(nil).GetTemplateGroupScheduleOverridesByUserID), ctx, arg)
+}
+
 // GetTemplateInsights mocks base method.
 func (m *MockStore) GetTemplateInsights(ctx context.Context, arg database.GetTemplateInsightsParams) (database.GetTemplateInsightsRow, error) {
 	m.ctrl.T.Helper()
@@ -3479,6 +3760,21 @@ func (mr *MockStoreMockRecorder) GetTemplateParameterInsights(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateParameterInsights", reflect.TypeOf((*MockStore)(nil).GetTemplateParameterInsights), ctx, arg)
 }
 
+// GetTemplatePresetReadinessChecks mocks base method.
+func (m *MockStore) GetTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.NullUUID) ([]database.TemplatePresetReadinessCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatePresetReadinessChecks", ctx, templateID)
+	ret0, _ := ret[0].([]database.TemplatePresetReadinessCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatePresetReadinessChecks indicates an expected call of GetTemplatePresetReadinessChecks.
+func (mr *MockStoreMockRecorder) GetTemplatePresetReadinessChecks(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatePresetReadinessChecks", reflect.TypeOf((*MockStore)(nil).GetTemplatePresetReadinessChecks), ctx, templateID)
+}
+
 // GetTemplatePresetsWithPrebuilds mocks base method.
 func (m *MockStore) GetTemplatePresetsWithPrebuilds(ctx context.Context, templateID uuid.NullUUID) ([]database.GetTemplatePresetsWithPrebuildsRow, error) {
 	m.ctrl.T.Helper()
@@ -3719,6 +4015,21 @@ func (mr *MockStoreMockRecorder) GetTemplatesWithFilter(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesWithFilter", reflect.TypeOf((*MockStore)(nil).GetTemplatesWithFilter), ctx, arg)
 }
 
+// GetTemplatesWithMaintenanceWindow mocks base method.
+func (m *MockStore) GetTemplatesWithMaintenanceWindow(ctx context.Context) ([]database.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesWithMaintenanceWindow", ctx)
+	ret0, _ := ret[0].([]database.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatesWithMaintenanceWindow indicates an expected call of GetTemplatesWithMaintenanceWindow.
+func (mr *MockStoreMockRecorder) GetTemplatesWithMaintenanceWindow(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesWithMaintenanceWindow", reflect.TypeOf((*MockStore)(nil).GetTemplatesWithMaintenanceWindow), ctx)
+}
+
 // GetTotalUsageDCManagedAgentsV1 mocks base method.
 func (m *MockStore) GetTotalUsageDCManagedAgentsV1(ctx context.Context, arg database.GetTotalUsageDCManagedAgentsV1Params) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3869,6 +4180,21 @@ func (mr *MockStoreMockRecorder) GetUserLinksByUserID(ctx, userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinksByUserID", reflect.TypeOf((*MockStore)(nil).GetUserLinksByUserID), ctx, userID)
 }
 
+// GetUserNotificationPreferenceTargets mocks base method.
+func (m *MockStore) GetUserNotificationPreferenceTargets(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreferenceTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNotificationPreferenceTargets", ctx, userID)
+	ret0, _ := ret[0].([]database.NotificationPreferenceTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserNotificationPreferenceTargets indicates an expected call of GetUserNotificationPreferenceTargets.
+func (mr *MockStoreMockRecorder) GetUserNotificationPreferenceTargets(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotificationPreferenceTargets", reflect.TypeOf((*MockStore)(nil).GetUserNotificationPreferenceTargets), ctx, userID)
+}
+
 // GetUserNotificationPreferences mocks base method.
 func (m *MockStore) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
 	m.ctrl.T.Helper()
@@ -4019,6 +4345,21 @@ func (mr *MockStoreMockRecorder) GetUsersByIDs(ctx, ids any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockStore)(nil).GetUsersByIDs), ctx, ids)
 }
 
+// GetVerifiedNotificationTargetsByTemplateID mocks base method.
+func (m *MockStore) GetVerifiedNotificationTargetsByTemplateID(ctx context.Context, arg database.GetVerifiedNotificationTargetsByTemplateIDParams) ([]database.NotificationTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedNotificationTargetsByTemplateID", ctx, arg)
+	ret0, _ := ret[0].([]database.NotificationTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedNotificationTargetsByTemplateID indicates an expected call of GetVerifiedNotificationTargetsByTemplateID.
+func (mr *MockStoreMockRecorder) GetVerifiedNotificationTargetsByTemplateID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedNotificationTargetsByTemplateID", reflect.TypeOf((*MockStore)(nil).GetVerifiedNotificationTargetsByTemplateID), ctx, arg)
+}
+
 // GetWebpushSubscriptionsByUserID mocks base method.
 func (m *MockStore) GetWebpushSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]database.WebpushSubscription, error) {
 	m.ctrl.T.Helper()
@@ -4844,6 +5185,51 @@ func (mr *MockStoreMockRecorder) GetWorkspaceResourcesCreatedAfter(ctx, createdA
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceResourcesCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetWorkspaceResourcesCreatedAfter), ctx, createdAt)
 }
 
+// GetWorkspaceSessionRecordingByID mocks base method.
+func (m *MockStore) GetWorkspaceSessionRecordingByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSessionRecordingByID", ctx, id)
+	ret0, _ := ret[0].(database.WorkspaceSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSessionRecordingByID indicates an expected call of GetWorkspaceSessionRecordingByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceSessionRecordingByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSessionRecordingByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSessionRecordingByID), ctx, id)
+}
+
+// GetWorkspaceSessionRecordingChunks mocks base method.
+func (m *MockStore) GetWorkspaceSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]database.WorkspaceSessionRecordingChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSessionRecordingChunks", ctx, recordingID)
+	ret0, _ := ret[0].([]database.WorkspaceSessionRecordingChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSessionRecordingChunks indicates an expected call of GetWorkspaceSessionRecordingChunks.
+func (mr *MockStoreMockRecorder) GetWorkspaceSessionRecordingChunks(ctx, recordingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSessionRecordingChunks", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSessionRecordingChunks), ctx, recordingID)
+}
+
+// GetWorkspaceSessionRecordingsByWorkspaceID mocks base method.
+func (m *MockStore) GetWorkspaceSessionRecordingsByWorkspaceID(ctx context.Context, arg database.GetWorkspaceSessionRecordingsByWorkspaceIDParams) ([]database.WorkspaceSessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSessionRecordingsByWorkspaceID", ctx, arg)
+	ret0, _ := ret[0].([]database.WorkspaceSessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSessionRecordingsByWorkspaceID indicates an expected call of GetWorkspaceSessionRecordingsByWorkspaceID.
+func (mr *MockStoreMockRecorder) GetWorkspaceSessionRecordingsByWorkspaceID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSessionRecordingsByWorkspaceID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSessionRecordingsByWorkspaceID), ctx, arg)
+}
+
 // GetWorkspaceUniqueOwnerCountByTemplateIDs mocks base method.
 func (m *MockStore) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	m.ctrl.T.Helper()
@@ -4919,6 +5305,21 @@ func (mr *MockStoreMockRecorder) GetWorkspacesEligibleForTransition(ctx, now any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesEligibleForTransition", reflect.TypeOf((*MockStore)(nil).GetWorkspacesEligibleForTransition), ctx, now)
 }
 
+// GetWorkspacesForTemplateMaintenance mocks base method.
+func (m *MockStore) GetWorkspacesForTemplateMaintenance(ctx context.Context, templateID uuid.UUID) ([]database.GetWorkspacesForTemplateMaintenanceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspacesForTemplateMaintenance", ctx, templateID)
+	ret0, _ := ret[0].([]database.GetWorkspacesForTemplateMaintenanceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesForTemplateMaintenance indicates an expected call of GetWorkspacesForTemplateMaintenance.
+func (mr *MockStoreMockRecorder) GetWorkspacesForTemplateMaintenance(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesForTemplateMaintenance", reflect.TypeOf((*MockStore)(nil).GetWorkspacesForTemplateMaintenance), ctx, templateID)
+}
+
 // GetWorkspacesForWorkspaceMetrics mocks base method.
 func (m *MockStore) GetWorkspacesForWorkspaceMetrics(ctx context.Context) ([]database.GetWorkspacesForWorkspaceMetricsRow, error) {
 	m.ctrl.T.Helper()
@@ -5259,6 +5660,21 @@ func (mr *MockStoreMockRecorder) InsertMissingGroups(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMissingGroups", reflect.TypeOf((*MockStore)(nil).InsertMissingGroups), ctx, arg)
 }
 
+// InsertNotificationTarget mocks base method.
+func (m *MockStore) InsertNotificationTarget(ctx context.Context, arg database.InsertNotificationTargetParams) (database.NotificationTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNotificationTarget", ctx, arg)
+	ret0, _ := ret[0].(database.NotificationTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNotificationTarget indicates an expected call of InsertNotificationTarget.
+func (mr *MockStoreMockRecorder) InsertNotificationTarget(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNotificationTarget", reflect.TypeOf((*MockStore)(nil).InsertNotificationTarget), ctx, arg)
+}
+
 // InsertOAuth2ProviderApp mocks base method.
 func (m *MockStore) InsertOAuth2ProviderApp(ctx context.Context, arg database.InsertOAuth2ProviderAppParams) (database.OAuth2ProviderApp, error) {
 	m.ctrl.T.Helper()
@@ -5424,6 +5840,21 @@ func (mr *MockStoreMockRecorder) InsertProvisionerJobLogs(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProvisionerJobLogs", reflect.TypeOf((*MockStore)(nil).InsertProvisionerJobLogs), ctx, arg)
 }
 
+// InsertProvisionerJobResourceChange mocks base method.
+func (m *MockStore) InsertProvisionerJobResourceChange(ctx context.Context, arg database.InsertProvisionerJobResourceChangeParams) (database.ProvisionerJobResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProvisionerJobResourceChange", ctx, arg)
+	ret0, _ := ret[0].(database.ProvisionerJobResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertProvisionerJobResourceChange indicates an expected call of InsertProvisionerJobResourceChange.
+func (mr *MockStoreMockRecorder) InsertProvisionerJobResourceChange(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProvisionerJobResourceChange", reflect.TypeOf((*MockStore)(nil).InsertProvisionerJobResourceChange), ctx, arg)
+}
+
 // InsertProvisionerJobTimings mocks base method.
 func (m *MockStore) InsertProvisionerJobTimings(ctx context.Context, arg database.InsertProvisionerJobTimingsParams) ([]database.ProvisionerJobTiming, error) {
 	m.ctrl.T.Helper()
@@ -5672,6 +6103,21 @@ func (mr *MockStoreMockRecorder) InsertUserLink(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserLink", reflect.TypeOf((*MockStore)(nil).InsertUserLink), ctx, arg)
 }
 
+// InsertUserNotificationPreferenceTargets mocks base method.
+func (m *MockStore) InsertUserNotificationPreferenceTargets(ctx context.Context, arg database.InsertUserNotificationPreferenceTargetsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserNotificationPreferenceTargets", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUserNotificationPreferenceTargets indicates an expected call of InsertUserNotificationPreferenceTargets.
+func (mr *MockStoreMockRecorder) InsertUserNotificationPreferenceTargets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserNotificationPreferenceTargets", reflect.TypeOf((*MockStore)(nil).InsertUserNotificationPreferenceTargets), ctx, arg)
+}
+
 // InsertVolumeResourceMonitor mocks base method.
 func (m *MockStore) InsertVolumeResourceMonitor(ctx context.Context, arg database.InsertVolumeResourceMonitorParams) (database.WorkspaceAgentVolumeResourceMonitor, error) {
 	m.ctrl.T.Helper()
@@ -5952,6 +6398,20 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceResourceMetadata(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceResourceMetadata", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceResourceMetadata), ctx, arg)
 }
 
+// InsertWorkspaceSessionRecording mocks base method.
+func (m *MockStore) InsertWorkspaceSessionRecording(ctx context.Context, arg database.InsertWorkspaceSessionRecordingParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceSessionRecording", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWorkspaceSessionRecording indicates an expected call of InsertWorkspaceSessionRecording.
+func (mr *MockStoreMockRecorder) InsertWorkspaceSessionRecording(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceSessionRecording", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceSessionRecording), ctx, arg)
+}
+
 // ListAIBridgeInterceptions mocks base method.
 func (m *MockStore) ListAIBridgeInterceptions(ctx context.Context, arg database.ListAIBridgeInterceptionsParams) ([]database.ListAIBridgeInterceptionsRow, error) {
 	m.ctrl.T.Helper()
@@ -6527,6 +6987,36 @@ func (mr *MockStoreMockRecorder) UpdateMemoryResourceMonitor(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemoryResourceMonitor", reflect.TypeOf((*MockStore)(nil).UpdateMemoryResourceMonitor), ctx, arg)
 }
 
+// UpdateNotificationTargetVerificationCode mocks base method.
+func (m *MockStore) UpdateNotificationTargetVerificationCode(ctx context.Context, arg database.UpdateNotificationTargetVerificationCodeParams) (database.NotificationTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationTargetVerificationCode", ctx, arg)
+	ret0, _ := ret[0].(database.NotificationTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationTargetVerificationCode indicates an expected call of UpdateNotificationTargetVerificationCode.
+func (mr *MockStoreMockRecorder) UpdateNotificationTargetVerificationCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTargetVerificationCode", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTargetVerificationCode), ctx, arg)
+}
+
+// UpdateNotificationTargetVerifiedAt mocks base method.
+func (m *MockStore) UpdateNotificationTargetVerifiedAt(ctx context.Context, arg database.UpdateNotificationTargetVerifiedAtParams) (database.NotificationTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationTargetVerifiedAt", ctx, arg)
+	ret0, _ := ret[0].(database.NotificationTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationTargetVerifiedAt indicates an expected call of UpdateNotificationTargetVerifiedAt.
+func (mr *MockStoreMockRecorder) UpdateNotificationTargetVerifiedAt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTargetVerifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTargetVerifiedAt), ctx, arg)
+}
+
 // UpdateNotificationTemplateMethodByID mocks base method.
 func (m *MockStore) UpdateNotificationTemplateMethodByID(ctx context.Context, arg database.UpdateNotificationTemplateMethodByIDParams) (database.NotificationTemplate, error) {
 	m.ctrl.T.Helper()
@@ -7803,6 +8293,35 @@ func (mr *MockStoreMockRecorder) UpsertOAuthSigningKey(ctx, value any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOAuthSigningKey", reflect.TypeOf((*MockStore)(nil).UpsertOAuthSigningKey), ctx, value)
 }
 
+// UpsertOrganizationNotificationWebhook mocks base method.
+func (m *MockStore) UpsertOrganizationNotificationWebhook(ctx context.Context, arg database.UpsertOrganizationNotificationWebhookParams) (database.OrganizationNotificationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrganizationNotificationWebhook", ctx, arg)
+	ret0, _ := ret[0].(database.OrganizationNotificationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOrganizationNotificationWebhook indicates an expected call of UpsertOrganizationNotificationWebhook.
+func (mr *MockStoreMockRecorder) UpsertOrganizationNotificationWebhook(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrganizationNotificationWebhook", reflect.TypeOf((*MockStore)(nil).UpsertOrganizationNotificationWebhook), ctx, arg)
+}
+
+// UpsertPrebuildReadiness mocks base method.
+func (m *MockStore) UpsertPrebuildReadiness(ctx context.Context, arg database.UpsertPrebuildReadinessParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPrebuildReadiness", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPrebuildReadiness indicates an expected call of UpsertPrebuildReadiness.
+func (mr *MockStoreMockRecorder) UpsertPrebuildReadiness(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPrebuildReadiness", reflect.TypeOf((*MockStore)(nil).UpsertPrebuildReadiness), ctx, arg)
+}
+
 // UpsertPrebuildsSettings mocks base method.
 func (m *MockStore) UpsertPrebuildsSettings(ctx context.Context, value string) error {
 	m.ctrl.T.Helper()
@@ -7934,6 +8453,51 @@ func (mr *MockStoreMockRecorder) UpsertTelemetryItem(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTelemetryItem", reflect.TypeOf((*MockStore)(nil).UpsertTelemetryItem), ctx, arg)
 }
 
+// UpsertTemplateAdaptivePrebuilds mocks base method.
+func (m *MockStore) UpsertTemplateAdaptivePrebuilds(ctx context.Context, arg database.UpsertTemplateAdaptivePrebuildsParams) (database.TemplateAdaptivePrebuild, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTemplateAdaptivePrebuilds", ctx, arg)
+	ret0, _ := ret[0].(database.TemplateAdaptivePrebuild)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTemplateAdaptivePrebuilds indicates an expected call of UpsertTemplateAdaptivePrebuilds.
+func (mr *MockStoreMockRecorder) UpsertTemplateAdaptivePrebuilds(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplateAdaptivePrebuilds", reflect.TypeOf((*MockStore)(nil).UpsertTemplateAdaptivePrebuilds), ctx, arg)
+}
+
+// UpsertTemplateGroupScheduleOverride mocks base method.
+func (m *MockStore) UpsertTemplateGroupScheduleOverride(ctx context.Context, arg database.UpsertTemplateGroupScheduleOverrideParams) (database.TemplateGroupScheduleOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTemplateGroupScheduleOverride", ctx, arg)
+	ret0, _ := ret[0].(database.TemplateGroupScheduleOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTemplateGroupScheduleOverride indicates an expected call of UpsertTemplateGroupScheduleOverride.
+func (mr *MockStoreMockRecorder) UpsertTemplateGroupScheduleOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplateGroupScheduleOverride", reflect.TypeOf((*MockStore)(nil).UpsertTemplateGroupScheduleOverride), ctx, arg)
+}
+
+// UpsertTemplatePresetReadinessChecks mocks base method.
+func (m *MockStore) UpsertTemplatePresetReadinessChecks(ctx context.Context, arg database.UpsertTemplatePresetReadinessChecksParams) (database.TemplatePresetReadinessCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTemplatePresetReadinessChecks", ctx, arg)
+	ret0, _ := ret[0].(database.TemplatePresetReadinessCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTemplatePresetReadinessChecks indicates an expected call of UpsertTemplatePresetReadinessChecks.
+func (mr *MockStoreMockRecorder) UpsertTemplatePresetReadinessChecks(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplatePresetReadinessChecks", reflect.TypeOf((*MockStore)(nil).UpsertTemplatePresetReadinessChecks), ctx, arg)
+}
+
 // UpsertTemplateUsageStats mocks base method.
 func (m *MockStore) UpsertTemplateUsageStats(ctx context.Context) error {
 	m.ctrl.T.Helper()
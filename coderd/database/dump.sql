@@ -257,7 +257,8 @@ CREATE TYPE build_reason AS ENUM (
     'jetbrains_connection',
     'task_auto_pause',
     'task_manual_pause',
-    'task_resume'
+    'task_resume',
+    'maintenance'
 );
 
 CREATE TYPE connection_status AS ENUM (
@@ -347,7 +348,10 @@ CREATE TYPE notification_message_status AS ENUM (
 CREATE TYPE notification_method AS ENUM (
     'smtp',
     'webhook',
-    'inbox'
+    'inbox',
+    'webpush',
+    'slack',
+    'teams'
 );
 
 CREATE TYPE notification_template_kind AS ENUM (
@@ -398,6 +402,12 @@ CREATE TYPE port_share_protocol AS ENUM (
     'https'
 );
 
+CREATE TYPE prebuild_readiness_status AS ENUM (
+    'pending',
+    'passed',
+    'failed'
+);
+
 CREATE TYPE prebuild_status AS ENUM (
     'healthy',
     'hard_limited',
@@ -446,6 +456,13 @@ CREATE TYPE provisioner_type AS ENUM (
     'terraform'
 );
 
+CREATE TYPE resource_change_action AS ENUM (
+    'create',
+    'update',
+    'replace',
+    'delete'
+);
+
 CREATE TYPE resource_type AS ENUM (
     'organization',
     'template',
@@ -472,7 +489,11 @@ CREATE TYPE resource_type AS ENUM (
     'workspace_agent',
     'workspace_app',
     'prebuilds_settings',
-    'task'
+    'task',
+    'organization_notification_webhook',
+    'template_preset_readiness_checks',
+    'template_group_schedule_override',
+    'template_adaptive_prebuilds'
 );
 
 CREATE TYPE startup_script_behavior AS ENUM (
@@ -570,7 +591,8 @@ CREATE TYPE workspace_app_status_state AS ENUM (
 CREATE TYPE workspace_transition AS ENUM (
     'start',
     'stop',
-    'delete'
+    'delete',
+    'hibernate'
 );
 
 CREATE FUNCTION aggregate_usage_event() RETURNS trigger
@@ -653,7 +675,8 @@ BEGIN
                                      NEW.method,
                                      NEW.payload::text,
                                      ARRAY_TO_STRING(NEW.targets, ','),
-                                     DATE_TRUNC('day', NEW.created_at AT TIME ZONE 'UTC')::text
+                                     DATE_TRUNC('day', NEW.created_at AT TIME ZONE 'UTC')::text,
+                                     NEW.notification_target_id
                            ));
     RETURN NEW;
 END;
@@ -1425,11 +1448,26 @@ CREATE TABLE notification_messages (
     leased_until timestamp with time zone,
     next_retry_after timestamp with time zone,
     queued_seconds double precision,
-    dedupe_hash text
+    dedupe_hash text,
+    notification_target_id uuid,
+    response_status_code integer
 );
 
 COMMENT ON COLUMN notification_messages.dedupe_hash IS 'Auto-generated by insert/update trigger, used to prevent duplicate notifications from being enqueued on the same day';
 
+COMMENT ON COLUMN notification_messages.notification_target_id IS 'The user''s notification target the message is delivered to, if any. Otherwise, the message is delivered using the deployment-wide configuration of its method.';
+
+COMMENT ON COLUMN notification_messages.response_status_code IS 'The HTTP status code of the response to the last delivery attempt, if it failed with one. Only set for methods which deliver over HTTP, such as webhook.';
+
+CREATE TABLE notification_preference_targets (
+    user_id uuid NOT NULL,
+    notification_template_id uuid NOT NULL,
+    notification_target_id uuid NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+COMMENT ON TABLE notification_preference_targets IS 'Routes notifications of a template to the user''s own notification targets, instead of the deployment-wide notification method.';
+
 CREATE TABLE notification_preferences (
     user_id uuid NOT NULL,
     notification_template_id uuid NOT NULL,
@@ -1445,6 +1483,25 @@ CREATE TABLE notification_report_generator_logs (
 
 COMMENT ON TABLE notification_report_generator_logs IS 'Log of generated reports for users.';
 
+CREATE TABLE notification_targets (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    name text NOT NULL,
+    method notification_method NOT NULL,
+    address text DEFAULT ''::text NOT NULL,
+    hashed_verification_code bytea,
+    verification_code_expires_at timestamp with time zone,
+    verified_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE notification_targets IS 'Delivery targets registered by users to receive their own notifications, such as a personal webhook endpoint or email address.';
+
+COMMENT ON COLUMN notification_targets.address IS 'The webhook endpoint or email address notifications are delivered to. Empty for the inbox and webpush methods, which deliver to the user''s own inbox and push subscriptions.';
+
+COMMENT ON COLUMN notification_targets.verified_at IS 'When the user confirmed the code of a test message delivered to the target. Notifications are only delivered to verified targets.';
+
 CREATE TABLE notification_templates (
     id uuid NOT NULL,
     name text NOT NULL,
@@ -1583,6 +1640,18 @@ COMMENT ON COLUMN oauth2_provider_apps.registration_access_token IS 'RFC 7592: H
 
 COMMENT ON COLUMN oauth2_provider_apps.registration_client_uri IS 'RFC 7592: URI for client configuration endpoint';
 
+CREATE TABLE organization_notification_webhooks (
+    organization_id uuid NOT NULL,
+    method notification_method NOT NULL,
+    endpoint text NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE organization_notification_webhooks IS 'Incoming webhooks of chat services, such as Slack or Microsoft Teams, to which notifications of the organization''s members are delivered.';
+
+COMMENT ON COLUMN organization_notification_webhooks.method IS 'The notification method delivered by the webhook, either slack or teams.';
+
 CREATE TABLE organizations (
     id uuid NOT NULL,
     name text NOT NULL,
@@ -1663,6 +1732,19 @@ CREATE SEQUENCE provisioner_job_logs_id_seq
 
 ALTER SEQUENCE provisioner_job_logs_id_seq OWNED BY provisioner_job_logs.id;
 
+CREATE TABLE provisioner_job_resource_changes (
+    job_id uuid NOT NULL,
+    address text NOT NULL,
+    type text NOT NULL,
+    name text NOT NULL,
+    action resource_change_action NOT NULL,
+    replace_paths text[] DEFAULT '{}'::text[] NOT NULL
+);
+
+COMMENT ON TABLE provisioner_job_resource_changes IS 'Changes to resources planned by a template version dry-run. Used to preview builds of existing workspaces.';
+
+COMMENT ON COLUMN provisioner_job_resource_changes.replace_paths IS 'The attribute paths which force the resource to be replaced.';
+
 CREATE VIEW provisioner_job_stats AS
 SELECT
     NULL::uuid AS job_id,
@@ -2044,6 +2126,58 @@ COMMENT ON COLUMN telemetry_locks.event_type IS 'The type of event that was sent
 
 COMMENT ON COLUMN telemetry_locks.period_ending_at IS 'The heartbeat period end timestamp.';
 
+CREATE TABLE template_adaptive_prebuilds (
+    template_id uuid NOT NULL,
+    enabled boolean DEFAULT false NOT NULL,
+    min_instances integer DEFAULT 0 NOT NULL,
+    max_instances integer DEFAULT 0 NOT NULL,
+    lookback_weeks integer DEFAULT 4 NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_adaptive_prebuilds IS 'Demand-driven sizing of the prebuild pools of a template. When enabled, the desired instances of each preset with prebuilds are forecasted from its claim history instead of the preset configuration.';
+
+COMMENT ON COLUMN template_adaptive_prebuilds.min_instances IS 'The lower bound of the forecasted desired instances of each preset.';
+
+COMMENT ON COLUMN template_adaptive_prebuilds.max_instances IS 'The upper bound of the forecasted desired instances of each preset.';
+
+COMMENT ON COLUMN template_adaptive_prebuilds.lookback_weeks IS 'The number of weeks of claim history used to forecast claims.';
+
+CREATE TABLE template_group_schedule_overrides (
+    template_id uuid NOT NULL,
+    group_id uuid NOT NULL,
+    max_ttl bigint DEFAULT 0 NOT NULL,
+    autostart_block_days_of_week smallint DEFAULT 0 NOT NULL,
+    autostop_requirement_days_of_week smallint DEFAULT 0 NOT NULL,
+    autostop_requirement_weeks bigint DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_group_schedule_overrides IS 'Schedule restrictions applied on top of the template schedule to workspaces owned by members of a group. Members of several groups get the strictest combination.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.max_ttl IS 'The maximum workspace TTL in nanoseconds. 0 means the template TTL is not restricted.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.autostart_block_days_of_week IS 'A bitmap of days of week that autostart is blocked on, in addition to the days blocked by the template.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.autostop_requirement_days_of_week IS 'A bitmap of days of week that workspaces must be stopped on, in addition to the days required by the template.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.autostop_requirement_weeks IS 'The number of weeks between autostop requirement days. 0 means the template value is used.';
+
+CREATE TABLE template_preset_readiness_checks (
+    template_id uuid NOT NULL,
+    preset_name text NOT NULL,
+    checks jsonb DEFAULT '[]'::jsonb NOT NULL,
+    timeout bigint DEFAULT '600000000000'::bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_preset_readiness_checks IS 'Checks that prebuilt workspaces of a template preset must pass once their agents are ready, before they can be claimed. Presets are matched by name, so that the checks apply to all versions of the template.';
+
+COMMENT ON COLUMN template_preset_readiness_checks.timeout IS 'How long checks may remain pending after the agents are ready before the prebuilt workspace is considered unhealthy, in nanoseconds.';
+
 CREATE TABLE template_usage_stats (
     start_time timestamp with time zone NOT NULL,
     end_time timestamp with time zone NOT NULL,
@@ -2292,7 +2426,11 @@ CREATE TABLE templates (
     max_port_sharing_level app_sharing_level DEFAULT 'owner'::app_sharing_level NOT NULL,
     use_classic_parameter_flow boolean DEFAULT false NOT NULL,
     cors_behavior cors_behavior DEFAULT 'simple'::cors_behavior NOT NULL,
-    disable_module_cache boolean DEFAULT false NOT NULL
+    disable_module_cache boolean DEFAULT false NOT NULL,
+    session_recording_enabled boolean DEFAULT false NOT NULL,
+    session_recording_include_input boolean DEFAULT false NOT NULL,
+    maintenance_window_schedule text DEFAULT ''::text NOT NULL,
+    hibernate_idle_threshold bigint DEFAULT 0 NOT NULL
 );
 
 COMMENT ON COLUMN templates.default_ttl IS 'The default duration for autostop for workspaces created from this template.';
@@ -2313,6 +2451,14 @@ COMMENT ON COLUMN templates.autostart_block_days_of_week IS 'A bitmap of days of
 
 COMMENT ON COLUMN templates.deprecated IS 'If set to a non empty string, the template will no longer be able to be used. The message will be displayed to the user.';
 
+COMMENT ON COLUMN templates.session_recording_enabled IS 'Whether interactive terminal sessions in workspaces of this template are recorded by the agent.';
+
+COMMENT ON COLUMN templates.session_recording_include_input IS 'Whether session recordings include the input typed by the user in addition to the output.';
+
+COMMENT ON COLUMN templates.maintenance_window_schedule IS 'A cron time range (e.g. "* 2-4 * * 6") during which idle outdated workspaces are restarted onto the active template version. Empty when no maintenance window is configured.';
+
+COMMENT ON COLUMN templates.hibernate_idle_threshold IS 'Workspaces that reach their autostop deadline after being idle for less than this duration (in nanoseconds) are hibernated instead of stopped. 0 disables hibernation on autostop.';
+
 COMMENT ON COLUMN templates.use_classic_parameter_flow IS 'Determines whether to default to the dynamic parameter creation flow for this template or continue using the legacy classic parameter creation flow.This is a template wide setting, the template admin can revert to the classic flow if there are any issues. An escape hatch is required, as workspace creation is a core workflow and cannot break. This column will be removed when the dynamic parameter creation flow is stable.';
 
 CREATE VIEW template_with_names AS
@@ -2347,6 +2493,10 @@ CREATE VIEW template_with_names AS
     templates.use_classic_parameter_flow,
     templates.cors_behavior,
     templates.disable_module_cache,
+    templates.session_recording_enabled,
+    templates.session_recording_include_input,
+    templates.maintenance_window_schedule,
+    templates.hibernate_idle_threshold,
     COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
     COALESCE(visible_users.username, ''::text) AS created_by_username,
     COALESCE(visible_users.name, ''::text) AS created_by_name,
@@ -2551,9 +2701,15 @@ CREATE TABLE workspace_agent_scripts (
     run_on_stop boolean NOT NULL,
     timeout_seconds integer NOT NULL,
     display_name text NOT NULL,
-    id uuid DEFAULT gen_random_uuid() NOT NULL
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    unit_name text DEFAULT ''::text NOT NULL,
+    depends_on jsonb DEFAULT '[]'::jsonb NOT NULL
 );
 
+COMMENT ON COLUMN workspace_agent_scripts.unit_name IS 'The name of the startup coordination unit that represents this script in the agent.';
+
+COMMENT ON COLUMN workspace_agent_scripts.depends_on IS 'A JSON array of unit names that must complete before this script runs.';
+
 CREATE SEQUENCE workspace_agent_startup_logs_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -2738,12 +2894,15 @@ CREATE TABLE workspaces (
     next_start_at timestamp with time zone,
     group_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
     user_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+    source_workspace_id uuid,
     CONSTRAINT group_acl_is_object CHECK ((jsonb_typeof(group_acl) = 'object'::text)),
     CONSTRAINT user_acl_is_object CHECK ((jsonb_typeof(user_acl) = 'object'::text))
 );
 
 COMMENT ON COLUMN workspaces.favorite IS 'Favorite is true if the workspace owner has favorited the workspace.';
 
+COMMENT ON COLUMN workspaces.source_workspace_id IS 'The workspace this workspace was cloned from, if any.';
+
 CREATE VIEW workspace_latest_builds AS
  SELECT latest_build.id,
     latest_build.workspace_id,
@@ -2805,6 +2964,18 @@ CREATE TABLE workspace_resources (
     module_path text
 );
 
+CREATE TABLE workspace_prebuild_readiness (
+    workspace_id uuid NOT NULL,
+    build_id uuid NOT NULL,
+    status prebuild_readiness_status NOT NULL,
+    failure_reasons text[] DEFAULT '{}'::text[] NOT NULL,
+    checked_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_prebuild_readiness IS 'The result of evaluating the readiness checks of a prebuilt workspace''s preset against the workspace''s latest build.';
+
+COMMENT ON COLUMN workspace_prebuild_readiness.failure_reasons IS 'Why the readiness checks are pending or failed.';
+
 CREATE VIEW workspace_prebuilds AS
  WITH all_prebuilds AS (
          SELECT w.id,
@@ -2901,6 +3072,39 @@ CREATE SEQUENCE workspace_resource_metadata_id_seq
 
 ALTER SEQUENCE workspace_resource_metadata_id_seq OWNED BY workspace_resource_metadata.id;
 
+CREATE TABLE workspace_session_recording_chunks (
+    recording_id uuid NOT NULL,
+    offset_bytes bigint NOT NULL,
+    data bytea NOT NULL
+);
+
+COMMENT ON TABLE workspace_session_recording_chunks IS 'The data of session recordings. Recordings larger than the maximum agent API message size are uploaded in parts, which are stored as separate chunks.';
+
+COMMENT ON COLUMN workspace_session_recording_chunks.offset_bytes IS 'The offset of the chunk in the recording, in bytes.';
+
+CREATE TABLE workspace_session_recordings (
+    id uuid NOT NULL,
+    organization_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    agent_id uuid NOT NULL,
+    connection_id uuid NOT NULL,
+    type connection_type NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    ended_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    include_input boolean DEFAULT false NOT NULL,
+    truncated boolean DEFAULT false NOT NULL,
+    size bigint DEFAULT 0 NOT NULL
+);
+
+COMMENT ON TABLE workspace_session_recordings IS 'Recordings of interactive terminal sessions in asciicast v2 format, uploaded by the agent when the session ends.';
+
+COMMENT ON COLUMN workspace_session_recordings.connection_id IS 'The connection ID reported by the agent for the session. Matches connection_logs.connection_id.';
+
+COMMENT ON COLUMN workspace_session_recordings.truncated IS 'Whether the agent stopped recording because the recording reached its maximum size.';
+
+COMMENT ON COLUMN workspace_session_recordings.size IS 'The total size of the recording''s chunks in bytes.';
+
 CREATE VIEW workspaces_expanded AS
  SELECT workspaces.id,
     workspaces.created_at,
@@ -2920,6 +3124,7 @@ CREATE VIEW workspaces_expanded AS
     workspaces.next_start_at,
     workspaces.group_acl,
     workspaces.user_acl,
+    workspaces.source_workspace_id,
     visible_users.avatar_url AS owner_avatar_url,
     visible_users.username AS owner_username,
     visible_users.name AS owner_name,
@@ -3036,12 +3241,21 @@ ALTER TABLE ONLY licenses
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY notification_preference_targets
+    ADD CONSTRAINT notification_preference_targets_pkey PRIMARY KEY (user_id, notification_template_id, notification_target_id);
+
 ALTER TABLE ONLY notification_preferences
     ADD CONSTRAINT notification_preferences_pkey PRIMARY KEY (user_id, notification_template_id);
 
 ALTER TABLE ONLY notification_report_generator_logs
     ADD CONSTRAINT notification_report_generator_logs_pkey PRIMARY KEY (notification_template_id);
 
+ALTER TABLE ONLY notification_targets
+    ADD CONSTRAINT notification_targets_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY notification_targets
+    ADD CONSTRAINT notification_targets_user_id_name_key UNIQUE (user_id, name);
+
 ALTER TABLE ONLY notification_templates
     ADD CONSTRAINT notification_templates_name_key UNIQUE (name);
 
@@ -3072,6 +3286,9 @@ ALTER TABLE ONLY oauth2_provider_apps
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_pkey PRIMARY KEY (organization_id, user_id);
 
+ALTER TABLE ONLY organization_notification_webhooks
+    ADD CONSTRAINT organization_notification_webhooks_pkey PRIMARY KEY (organization_id, method);
+
 ALTER TABLE ONLY organizations
     ADD CONSTRAINT organizations_pkey PRIMARY KEY (id);
 
@@ -3093,6 +3310,9 @@ ALTER TABLE ONLY provisioner_daemons
 ALTER TABLE ONLY provisioner_job_logs
     ADD CONSTRAINT provisioner_job_logs_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY provisioner_job_resource_changes
+    ADD CONSTRAINT provisioner_job_resource_changes_pkey PRIMARY KEY (job_id, address);
+
 ALTER TABLE ONLY provisioner_jobs
     ADD CONSTRAINT provisioner_jobs_pkey PRIMARY KEY (id);
 
@@ -3126,6 +3346,15 @@ ALTER TABLE ONLY telemetry_items
 ALTER TABLE ONLY telemetry_locks
     ADD CONSTRAINT telemetry_locks_pkey PRIMARY KEY (event_type, period_ending_at);
 
+ALTER TABLE ONLY template_adaptive_prebuilds
+    ADD CONSTRAINT template_adaptive_prebuilds_pkey PRIMARY KEY (template_id);
+
+ALTER TABLE ONLY template_group_schedule_overrides
+    ADD CONSTRAINT template_group_schedule_overrides_pkey PRIMARY KEY (template_id, group_id);
+
+ALTER TABLE ONLY template_preset_readiness_checks
+    ADD CONSTRAINT template_preset_readiness_checks_pkey PRIMARY KEY (template_id, preset_name);
+
 ALTER TABLE ONLY template_usage_stats
     ADD CONSTRAINT template_usage_stats_pkey PRIMARY KEY (start_time, template_id, user_id);
 
@@ -3249,6 +3478,9 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_build_number_key UNIQUE (workspace_id, build_number);
 
+ALTER TABLE ONLY workspace_prebuild_readiness
+    ADD CONSTRAINT workspace_prebuild_readiness_pkey PRIMARY KEY (workspace_id);
+
 ALTER TABLE ONLY workspace_proxies
     ADD CONSTRAINT workspace_proxies_pkey PRIMARY KEY (id);
 
@@ -3264,6 +3496,12 @@ ALTER TABLE ONLY workspace_resource_metadata
 ALTER TABLE ONLY workspace_resources
     ADD CONSTRAINT workspace_resources_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY workspace_session_recording_chunks
+    ADD CONSTRAINT workspace_session_recording_chunks_pkey PRIMARY KEY (recording_id, offset_bytes);
+
+ALTER TABLE ONLY workspace_session_recordings
+    ADD CONSTRAINT workspace_session_recordings_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_pkey PRIMARY KEY (id);
 
@@ -3371,6 +3609,10 @@ CREATE INDEX idx_workspace_app_statuses_workspace_id_created_at ON workspace_app
 
 CREATE INDEX idx_workspace_builds_initiator_id ON workspace_builds USING btree (initiator_id);
 
+CREATE UNIQUE INDEX idx_workspace_session_recordings_connection_id ON workspace_session_recordings USING btree (workspace_id, connection_id);
+
+CREATE INDEX idx_workspace_session_recordings_workspace_id ON workspace_session_recordings USING btree (workspace_id, started_at DESC);
+
 CREATE UNIQUE INDEX notification_messages_dedupe_hash_idx ON notification_messages USING btree (dedupe_hash);
 
 CREATE UNIQUE INDEX organizations_single_default_org ON organizations USING btree (is_default) WHERE (is_default = true);
@@ -3600,18 +3842,33 @@ ALTER TABLE ONLY jfrog_xray_scans
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_messages
+    ADD CONSTRAINT notification_messages_notification_target_id_fkey FOREIGN KEY (notification_target_id) REFERENCES notification_targets(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_notification_template_id_fkey FOREIGN KEY (notification_template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_preference_targets
+    ADD CONSTRAINT notification_preference_targets_notification_target_id_fkey FOREIGN KEY (notification_target_id) REFERENCES notification_targets(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_preference_targets
+    ADD CONSTRAINT notification_preference_targets_notification_template_id_fkey FOREIGN KEY (notification_template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_preference_targets
+    ADD CONSTRAINT notification_preference_targets_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY notification_preferences
     ADD CONSTRAINT notification_preferences_notification_template_id_fkey FOREIGN KEY (notification_template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY notification_preferences
     ADD CONSTRAINT notification_preferences_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_targets
+    ADD CONSTRAINT notification_targets_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY oauth2_provider_app_codes
     ADD CONSTRAINT oauth2_provider_app_codes_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
 
@@ -3633,6 +3890,9 @@ ALTER TABLE ONLY organization_members
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_user_id_uuid_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY organization_notification_webhooks
+    ADD CONSTRAINT organization_notification_webhooks_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY parameter_schemas
     ADD CONSTRAINT parameter_schemas_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
@@ -3645,6 +3905,9 @@ ALTER TABLE ONLY provisioner_daemons
 ALTER TABLE ONLY provisioner_job_logs
     ADD CONSTRAINT provisioner_job_logs_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY provisioner_job_resource_changes
+    ADD CONSTRAINT provisioner_job_resource_changes_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY provisioner_job_timings
     ADD CONSTRAINT provisioner_job_timings_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
@@ -3684,6 +3947,18 @@ ALTER TABLE ONLY tasks
 ALTER TABLE ONLY tasks
     ADD CONSTRAINT tasks_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY template_adaptive_prebuilds
+    ADD CONSTRAINT template_adaptive_prebuilds_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY template_group_schedule_overrides
+    ADD CONSTRAINT template_group_schedule_overrides_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY template_group_schedule_overrides
+    ADD CONSTRAINT template_group_schedule_overrides_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY template_preset_readiness_checks
+    ADD CONSTRAINT template_preset_readiness_checks_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY template_version_parameters
     ADD CONSTRAINT template_version_parameters_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
 
@@ -3825,18 +4100,39 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_modules
     ADD CONSTRAINT workspace_modules_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_prebuild_readiness
+    ADD CONSTRAINT workspace_prebuild_readiness_build_id_fkey FOREIGN KEY (build_id) REFERENCES workspace_builds(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_prebuild_readiness
+    ADD CONSTRAINT workspace_prebuild_readiness_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_resource_metadata
     ADD CONSTRAINT workspace_resource_metadata_workspace_resource_id_fkey FOREIGN KEY (workspace_resource_id) REFERENCES workspace_resources(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY workspace_resources
     ADD CONSTRAINT workspace_resources_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_session_recording_chunks
+    ADD CONSTRAINT workspace_session_recording_chunks_recording_id_fkey FOREIGN KEY (recording_id) REFERENCES workspace_session_recordings(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_session_recordings
+    ADD CONSTRAINT workspace_session_recordings_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_session_recordings
+    ADD CONSTRAINT workspace_session_recordings_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_session_recordings
+    ADD CONSTRAINT workspace_session_recordings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE RESTRICT;
 
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE RESTRICT;
 
+ALTER TABLE ONLY workspaces
+    ADD CONSTRAINT workspaces_source_workspace_id_fkey FOREIGN KEY (source_workspace_id) REFERENCES workspaces(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE RESTRICT;
 
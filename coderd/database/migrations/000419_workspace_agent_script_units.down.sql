@@ -0,0 +1,3 @@
+ALTER TABLE workspace_agent_scripts
+	DROP COLUMN depends_on,
+	DROP COLUMN unit_name;
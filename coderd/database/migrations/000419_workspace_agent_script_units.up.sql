@@ -0,0 +1,6 @@
+ALTER TABLE workspace_agent_scripts
+	ADD COLUMN unit_name text NOT NULL DEFAULT '',
+	ADD COLUMN depends_on jsonb NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN workspace_agent_scripts.unit_name IS 'The name of the startup coordination unit that represents this script in the agent.';
+COMMENT ON COLUMN workspace_agent_scripts.depends_on IS 'A JSON array of unit names that must complete before this script runs.';
@@ -0,0 +1,56 @@
+DROP VIEW template_with_names;
+ALTER TABLE templates
+	ADD COLUMN session_recording_enabled BOOL NOT NULL DEFAULT false,
+	ADD COLUMN session_recording_include_input BOOL NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN templates.session_recording_enabled IS 'Whether interactive terminal sessions in workspaces of this template are recorded by the agent.';
+COMMENT ON COLUMN templates.session_recording_include_input IS 'Whether session recordings include the input typed by the user in addition to the output.';
+
+CREATE VIEW template_with_names AS
+SELECT templates.*,
+	   COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
+	   COALESCE(visible_users.username, ''::text) AS created_by_username,
+	   COALESCE(visible_users.name, ''::text) AS created_by_name,
+	   COALESCE(organizations.name, ''::text) AS organization_name,
+	   COALESCE(organizations.display_name, ''::text) AS organization_display_name,
+	   COALESCE(organizations.icon, ''::text) AS organization_icon
+FROM ((templates
+	LEFT JOIN visible_users ON ((templates.created_by = visible_users.id)))
+	LEFT JOIN organizations ON ((templates.organization_id = organizations.id)));
+
+COMMENT ON VIEW template_with_names IS 'Joins in the display name information such as username, avatar, and organization name.';
+
+CREATE TABLE workspace_session_recordings (
+	id uuid NOT NULL,
+	organization_id uuid NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+	workspace_id uuid NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+	agent_id uuid NOT NULL REFERENCES workspace_agents (id) ON DELETE CASCADE,
+	connection_id uuid NOT NULL,
+	type connection_type NOT NULL,
+	started_at timestamp with time zone NOT NULL,
+	ended_at timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	include_input boolean NOT NULL DEFAULT false,
+	truncated boolean NOT NULL DEFAULT false,
+	size bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE workspace_session_recordings IS 'Recordings of interactive terminal sessions in asciicast v2 format, uploaded by the agent when the session ends.';
+COMMENT ON COLUMN workspace_session_recordings.connection_id IS 'The connection ID reported by the agent for the session. Matches connection_logs.connection_id.';
+COMMENT ON COLUMN workspace_session_recordings.truncated IS 'Whether the agent stopped recording because the recording reached its maximum size.';
+COMMENT ON COLUMN workspace_session_recordings.size IS 'The total size of the recording''s chunks in bytes.';
+
+CREATE INDEX idx_workspace_session_recordings_workspace_id ON workspace_session_recordings USING btree (workspace_id, started_at DESC);
+
+CREATE UNIQUE INDEX idx_workspace_session_recordings_connection_id ON workspace_session_recordings USING btree (workspace_id, connection_id);
+
+CREATE TABLE workspace_session_recording_chunks (
+	recording_id uuid NOT NULL REFERENCES workspace_session_recordings (id) ON DELETE CASCADE,
+	offset_bytes bigint NOT NULL,
+	data bytea NOT NULL,
+	PRIMARY KEY (recording_id, offset_bytes)
+);
+
+COMMENT ON TABLE workspace_session_recording_chunks IS 'The data of session recordings. Recordings larger than the maximum agent API message size are uploaded in parts, which are stored as separate chunks.';
+COMMENT ON COLUMN workspace_session_recording_chunks.offset_bytes IS 'The offset of the chunk in the recording, in bytes.';
@@ -0,0 +1,25 @@
+DELETE FROM notification_templates WHERE id IN (
+	'6d088baf-3132-4ca5-9915-0b85bbe69b67',
+	'aa9c27c5-abed-4782-90f7-9becc8722cc3'
+);
+
+DROP VIEW template_with_names;
+ALTER TABLE templates
+	DROP COLUMN maintenance_window_schedule;
+
+CREATE VIEW template_with_names AS
+SELECT templates.*,
+	   COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
+	   COALESCE(visible_users.username, ''::text) AS created_by_username,
+	   COALESCE(visible_users.name, ''::text) AS created_by_name,
+	   COALESCE(organizations.name, ''::text) AS organization_name,
+	   COALESCE(organizations.display_name, ''::text) AS organization_display_name,
+	   COALESCE(organizations.icon, ''::text) AS organization_icon
+FROM ((templates
+	LEFT JOIN visible_users ON ((templates.created_by = visible_users.id)))
+	LEFT JOIN organizations ON ((templates.organization_id = organizations.id)));
+
+COMMENT ON VIEW template_with_names IS 'Joins in the display name information such as username, avatar, and organization name.';
+
+-- Note: Cannot remove enum values in PostgreSQL.
+-- The build_reason enum value 'maintenance' will remain but become unused.
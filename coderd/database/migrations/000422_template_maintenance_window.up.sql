@@ -0,0 +1,82 @@
+DROP VIEW template_with_names;
+ALTER TABLE templates
+	ADD COLUMN maintenance_window_schedule text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN templates.maintenance_window_schedule IS 'A cron time range (e.g. "* 2-4 * * 6") during which idle outdated workspaces are restarted onto the active template version. Empty when no maintenance window is configured.';
+
+CREATE VIEW template_with_names AS
+SELECT templates.*,
+	   COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
+	   COALESCE(visible_users.username, ''::text) AS created_by_username,
+	   COALESCE(visible_users.name, ''::text) AS created_by_name,
+	   COALESCE(organizations.name, ''::text) AS organization_name,
+	   COALESCE(organizations.display_name, ''::text) AS organization_display_name,
+	   COALESCE(organizations.icon, ''::text) AS organization_icon
+FROM ((templates
+	LEFT JOIN visible_users ON ((templates.created_by = visible_users.id)))
+	LEFT JOIN organizations ON ((templates.organization_id = organizations.id)));
+
+COMMENT ON VIEW template_with_names IS 'Joins in the display name information such as username, avatar, and organization name.';
+
+ALTER TYPE build_reason ADD VALUE IF NOT EXISTS 'maintenance';
+
+INSERT INTO notification_templates (
+	id,
+	name,
+	title_template,
+	body_template,
+	actions,
+	"group",
+	method,
+	kind,
+	enabled_by_default
+) VALUES (
+			 '6d088baf-3132-4ca5-9915-0b85bbe69b67',
+			 'Workspace Maintenance Scheduled',
+			 E'Workspace "{{.Labels.name}}" will be updated during maintenance',
+			 E'Your workspace **{{.Labels.name}}** is running an outdated version of the template **{{.Labels.template}}**.\n\n' ||
+			 E'A maintenance window starts at **{{.Labels.window_start}}**. If the workspace has no active connections at that time, it will be restarted on the active template version **{{.Labels.template_version_name}}**.',
+			 '[
+				 {
+					 "label": "View workspace",
+					 "url": "{{base_url}}/@{{.UserUsername}}/{{.Labels.name}}"
+				 }
+			 ]'::jsonb,
+			 'Workspace Events',
+			 NULL,
+			 'system'::notification_template_kind,
+			 true
+		 );
+
+INSERT INTO notification_templates (
+	id,
+	name,
+	title_template,
+	body_template,
+	actions,
+	"group",
+	method,
+	kind,
+	enabled_by_default
+) VALUES (
+			 'aa9c27c5-abed-4782-90f7-9becc8722cc3',
+			 'Report: Template Maintenance Window',
+			 E'Maintenance window report for template "{{.Labels.template_display_name}}"',
+			 E'The maintenance window of the template **{{.Labels.template_display_name}}** has ended.\n\n' ||
+			 E'{{if .Data.updated_workspaces}}The following workspaces were updated to the active version **{{.Labels.template_version_name}}**:\n' ||
+			 E'{{range $workspace := .Data.updated_workspaces}}\n- {{$workspace.owner_username}} / {{$workspace.name}}{{end}}\n{{else}}No workspaces were updated.\n{{end}}\n' ||
+			 E'{{if .Data.failed_workspaces}}The following workspaces failed to start on the active version:\n' ||
+			 E'{{range $workspace := .Data.failed_workspaces}}\n- {{$workspace.owner_username}} / {{$workspace.name}}{{end}}\n{{end}}\n' ||
+			 E'{{if .Data.outdated_workspaces}}The following workspaces are still outdated:\n' ||
+			 E'{{range $workspace := .Data.outdated_workspaces}}\n- {{$workspace.owner_username}} / {{$workspace.name}}{{end}}\n{{end}}',
+			 '[
+				 {
+					 "label": "View workspaces",
+					 "url": "{{base_url}}/workspaces?filter=template%3A{{.Labels.template_name}}"
+				 }
+			 ]'::jsonb,
+			 'Template Events',
+			 NULL,
+			 'system'::notification_template_kind,
+			 true
+		 );
@@ -0,0 +1,20 @@
+CREATE TYPE resource_change_action AS ENUM (
+    'create',
+    'update',
+    'replace',
+    'delete'
+);
+
+CREATE TABLE provisioner_job_resource_changes (
+    job_id uuid NOT NULL REFERENCES provisioner_jobs (id) ON DELETE CASCADE,
+    address text NOT NULL,
+    type text NOT NULL,
+    name text NOT NULL,
+    action resource_change_action NOT NULL,
+    replace_paths text[] NOT NULL DEFAULT '{}'::text[],
+    PRIMARY KEY (job_id, address)
+);
+
+COMMENT ON TABLE provisioner_job_resource_changes IS 'Changes to resources planned by a template version dry-run. Used to preview builds of existing workspaces.';
+
+COMMENT ON COLUMN provisioner_job_resource_changes.replace_paths IS 'The attribute paths which force the resource to be replaced.';
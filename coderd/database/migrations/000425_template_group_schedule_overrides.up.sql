@@ -0,0 +1,21 @@
+CREATE TABLE template_group_schedule_overrides (
+    template_id uuid NOT NULL REFERENCES templates (id) ON DELETE CASCADE,
+    group_id uuid NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    max_ttl bigint NOT NULL DEFAULT 0,
+    autostart_block_days_of_week smallint NOT NULL DEFAULT 0,
+    autostop_requirement_days_of_week smallint NOT NULL DEFAULT 0,
+    autostop_requirement_weeks bigint NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (template_id, group_id)
+);
+
+COMMENT ON TABLE template_group_schedule_overrides IS 'Schedule restrictions applied on top of the template schedule to workspaces owned by members of a group. Members of several groups get the strictest combination.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.max_ttl IS 'The maximum workspace TTL in nanoseconds. 0 means the template TTL is not restricted.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.autostart_block_days_of_week IS 'A bitmap of days of week that autostart is blocked on, in addition to the days blocked by the template.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.autostop_requirement_days_of_week IS 'A bitmap of days of week that workspaces must be stopped on, in addition to the days required by the template.';
+
+COMMENT ON COLUMN template_group_schedule_overrides.autostop_requirement_weeks IS 'The number of weeks between autostop requirement days. 0 means the template value is used.';
@@ -0,0 +1,17 @@
+CREATE TABLE template_adaptive_prebuilds (
+    template_id uuid NOT NULL PRIMARY KEY REFERENCES templates (id) ON DELETE CASCADE,
+    enabled boolean NOT NULL DEFAULT false,
+    min_instances integer NOT NULL DEFAULT 0,
+    max_instances integer NOT NULL DEFAULT 0,
+    lookback_weeks integer NOT NULL DEFAULT 4,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_adaptive_prebuilds IS 'Demand-driven sizing of the prebuild pools of a template. When enabled, the desired instances of each preset with prebuilds are forecasted from its claim history instead of the preset configuration.';
+
+COMMENT ON COLUMN template_adaptive_prebuilds.min_instances IS 'The lower bound of the forecasted desired instances of each preset.';
+
+COMMENT ON COLUMN template_adaptive_prebuilds.max_instances IS 'The upper bound of the forecasted desired instances of each preset.';
+
+COMMENT ON COLUMN template_adaptive_prebuilds.lookback_weeks IS 'The number of weeks of claim history used to forecast claims.';
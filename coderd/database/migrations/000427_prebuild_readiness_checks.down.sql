@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS workspace_prebuild_readiness;
+
+DROP TABLE IF EXISTS template_preset_readiness_checks;
+
+DROP TYPE IF EXISTS prebuild_readiness_status;
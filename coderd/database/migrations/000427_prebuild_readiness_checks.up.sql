@@ -0,0 +1,31 @@
+CREATE TYPE prebuild_readiness_status AS ENUM (
+    'pending',
+    'passed',
+    'failed'
+);
+
+CREATE TABLE template_preset_readiness_checks (
+    template_id uuid NOT NULL REFERENCES templates (id) ON DELETE CASCADE,
+    preset_name text NOT NULL,
+    checks jsonb NOT NULL DEFAULT '[]'::jsonb,
+    timeout bigint NOT NULL DEFAULT 600000000000,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (template_id, preset_name)
+);
+
+COMMENT ON TABLE template_preset_readiness_checks IS 'Checks that prebuilt workspaces of a template preset must pass once their agents are ready, before they can be claimed. Presets are matched by name, so that the checks apply to all versions of the template.';
+
+COMMENT ON COLUMN template_preset_readiness_checks.timeout IS 'How long checks may remain pending after the agents are ready before the prebuilt workspace is considered unhealthy, in nanoseconds.';
+
+CREATE TABLE workspace_prebuild_readiness (
+    workspace_id uuid NOT NULL PRIMARY KEY REFERENCES workspaces (id) ON DELETE CASCADE,
+    build_id uuid NOT NULL REFERENCES workspace_builds (id) ON DELETE CASCADE,
+    status prebuild_readiness_status NOT NULL,
+    failure_reasons text[] NOT NULL DEFAULT '{}'::text[],
+    checked_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_prebuild_readiness IS 'The result of evaluating the readiness checks of a prebuilt workspace''s preset against the workspace''s latest build.';
+
+COMMENT ON COLUMN workspace_prebuild_readiness.failure_reasons IS 'Why the readiness checks are pending or failed.';
@@ -0,0 +1,24 @@
+DELETE FROM notification_templates WHERE id = 'e3f5d1a8-6b2c-4f0e-9d7a-1c4b8e2f6a93';
+
+CREATE OR REPLACE FUNCTION compute_notification_message_dedupe_hash() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.dedupe_hash := MD5(CONCAT_WS(':',
+                                     NEW.notification_template_id,
+                                     NEW.user_id,
+                                     NEW.method,
+                                     NEW.payload::text,
+                                     ARRAY_TO_STRING(NEW.targets, ','),
+                                     DATE_TRUNC('day', NEW.created_at AT TIME ZONE 'UTC')::text
+                           ));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE notification_messages DROP COLUMN notification_target_id;
+
+DROP TABLE notification_preference_targets;
+
+DROP TABLE notification_targets;
+
+-- The webpush value of the notification_method enum can not be removed.
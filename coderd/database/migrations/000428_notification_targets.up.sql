@@ -0,0 +1,84 @@
+-- The migration is about an enum value change
+-- As we can not remove a value from an enum, we can let the down migration empty
+-- In order to avoid any failure, we use ADD VALUE IF NOT EXISTS to add the value
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'webpush';
+
+CREATE TABLE notification_targets (
+    id uuid NOT NULL PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    method notification_method NOT NULL,
+    address text NOT NULL DEFAULT '',
+    hashed_verification_code bytea,
+    verification_code_expires_at timestamp with time zone,
+    verified_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    UNIQUE (user_id, name)
+);
+
+COMMENT ON TABLE notification_targets IS 'Delivery targets registered by users to receive their own notifications, such as a personal webhook endpoint or email address.';
+
+COMMENT ON COLUMN notification_targets.address IS 'The webhook endpoint or email address notifications are delivered to. Empty for the inbox and webpush methods, which deliver to the user''s own inbox and push subscriptions.';
+
+COMMENT ON COLUMN notification_targets.verified_at IS 'When the user confirmed the code of a test message delivered to the target. Notifications are only delivered to verified targets.';
+
+CREATE TABLE notification_preference_targets (
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    notification_template_id uuid NOT NULL REFERENCES notification_templates (id) ON DELETE CASCADE,
+    notification_target_id uuid NOT NULL REFERENCES notification_targets (id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, notification_template_id, notification_target_id)
+);
+
+COMMENT ON TABLE notification_preference_targets IS 'Routes notifications of a template to the user''s own notification targets, instead of the deployment-wide notification method.';
+
+ALTER TABLE notification_messages
+    ADD COLUMN notification_target_id uuid REFERENCES notification_targets (id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN notification_messages.notification_target_id IS 'The user''s notification target the message is delivered to, if any. Otherwise, the message is delivered using the deployment-wide configuration of its method.';
+
+-- Messages of the same notification delivered to multiple targets of the same method must not be deduplicated.
+CREATE OR REPLACE FUNCTION compute_notification_message_dedupe_hash() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.dedupe_hash := MD5(CONCAT_WS(':',
+                                     NEW.notification_template_id,
+                                     NEW.user_id,
+                                     NEW.method,
+                                     NEW.payload::text,
+                                     ARRAY_TO_STRING(NEW.targets, ','),
+                                     DATE_TRUNC('day', NEW.created_at AT TIME ZONE 'UTC')::text,
+                                     NEW.notification_target_id
+                           ));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+INSERT INTO notification_templates (
+	id,
+	name,
+	title_template,
+	body_template,
+	actions,
+	"group",
+	method,
+	kind,
+	enabled_by_default
+) VALUES (
+			 'e3f5d1a8-6b2c-4f0e-9d7a-1c4b8e2f6a93',
+			 'Notification Target Verification',
+			 E'Verify your notification target "{{.Labels.target_name}}"',
+			 E'Use the code **{{.Labels.code}}** to verify the notification target **{{.Labels.target_name}}**. The code expires in {{.Labels.expires_in}}.\n\n' ||
+			 E'If you did not add this notification target, you can ignore this message.',
+			 '[
+				 {
+					 "label": "View notification settings",
+					 "url": "{{base_url}}/settings/notifications"
+				 }
+			 ]'::jsonb,
+			 'Notification Events',
+			 NULL,
+			 'system'::notification_template_kind,
+			 true
+		 );
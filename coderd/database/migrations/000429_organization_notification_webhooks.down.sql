@@ -0,0 +1,3 @@
+DROP TABLE organization_notification_webhooks;
+
+-- The slack and teams values of the notification_method enum can not be removed.
@@ -0,0 +1,18 @@
+-- The migration is about an enum value change
+-- As we can not remove a value from an enum, we can let the down migration empty
+-- In order to avoid any failure, we use ADD VALUE IF NOT EXISTS to add the value
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'slack';
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'teams';
+
+CREATE TABLE organization_notification_webhooks (
+    organization_id uuid NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    method notification_method NOT NULL,
+    endpoint text NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (organization_id, method)
+);
+
+COMMENT ON TABLE organization_notification_webhooks IS 'Incoming webhooks of chat services, such as Slack or Microsoft Teams, to which notifications of the organization''s members are delivered.';
+
+COMMENT ON COLUMN organization_notification_webhooks.method IS 'The notification method delivered by the webhook, either slack or teams.';
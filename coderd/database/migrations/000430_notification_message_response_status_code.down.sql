@@ -0,0 +1 @@
+ALTER TABLE notification_messages DROP COLUMN response_status_code;
@@ -0,0 +1,3 @@
+ALTER TABLE notification_messages ADD COLUMN response_status_code integer;
+
+COMMENT ON COLUMN notification_messages.response_status_code IS 'The HTTP status code of the response to the last delivery attempt, if it failed with one. Only set for methods which deliver over HTTP, such as webhook.';
@@ -0,0 +1,37 @@
+INSERT INTO workspace_session_recordings (
+	id,
+	organization_id,
+	workspace_id,
+	agent_id,
+	connection_id,
+	type,
+	started_at,
+	ended_at,
+	created_at,
+	include_input,
+	truncated,
+	size
+) VALUES (
+	'b1a4c7e4-5f0e-4b8e-9a55-0d2f3c6e8a71',
+	'bb640d07-ca8a-4869-b6bc-ae61ebb2fda1',
+	'3a9a1feb-e89d-457c-9d53-ac751b198ebe',
+	'a90934b3-34da-400a-a583-fa4dcb3b4668',
+	'c4a9e2d0-8f3b-4e1a-bd6c-2a7f9e0b3d15',
+	'ssh',
+	'2025-01-01 10:00:00+00',
+	'2025-01-01 10:05:00+00',
+	'2025-01-01 10:05:01+00',
+	false,
+	false,
+	59
+);
+
+INSERT INTO workspace_session_recording_chunks (
+	recording_id,
+	offset_bytes,
+	data
+) VALUES (
+	'b1a4c7e4-5f0e-4b8e-9a55-0d2f3c6e8a71',
+	0,
+	'\x7b2276657273696f6e223a322c227769647468223a38302c22686569676874223a32347d0a5b302e312c226f222c2268656c6c6f5c725c6e225d0a'
+);
@@ -0,0 +1,5 @@
+INSERT INTO groups (id, name, organization_id)
+VALUES ('6ad3a7c2-5d85-4b1e-9a3f-8f1e0c2d4b71', 'contractors', '20362772-802a-4a72-8e4f-3648b4bfd168');
+
+INSERT INTO template_group_schedule_overrides (template_id, group_id, max_ttl, autostart_block_days_of_week, autostop_requirement_days_of_week, autostop_requirement_weeks, created_at, updated_at)
+VALUES ('6b298946-7a4f-47ac-9158-b03b08740a41', '6ad3a7c2-5d85-4b1e-9a3f-8f1e0c2d4b71', 14400000000000, 96, 16, 1, '2025-02-07 07:46:19.513317 +00:00', '2025-02-07 07:46:19.513317 +00:00');
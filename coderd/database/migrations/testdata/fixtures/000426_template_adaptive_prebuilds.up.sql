@@ -0,0 +1,2 @@
+INSERT INTO template_adaptive_prebuilds (template_id, enabled, min_instances, max_instances, lookback_weeks, created_at, updated_at)
+VALUES ('6b298946-7a4f-47ac-9158-b03b08740a41', true, 1, 10, 4, '2025-02-07 07:46:19.513317 +00:00', '2025-02-07 07:46:19.513317 +00:00');
@@ -0,0 +1,5 @@
+INSERT INTO template_preset_readiness_checks (template_id, preset_name, checks, timeout, created_at, updated_at)
+VALUES ('6b298946-7a4f-47ac-9158-b03b08740a41', 'default', '[{"type": "script", "script": "Clone repository"}]', 600000000000, '2025-02-07 07:46:19.513317 +00:00', '2025-02-07 07:46:19.513317 +00:00');
+
+INSERT INTO workspace_prebuild_readiness (workspace_id, build_id, status, failure_reasons, checked_at)
+VALUES ('3a9a1feb-e89d-457c-9d53-ac751b198ebe', 'a8c0b8c5-c9a8-4f33-93a4-8142e6858244', 'failed', '{"script \"Clone repository\" exited with code 128"}', '2025-02-07 07:46:19.513317 +00:00');
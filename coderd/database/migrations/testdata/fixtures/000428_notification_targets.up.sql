@@ -0,0 +1,5 @@
+INSERT INTO notification_targets (id, user_id, name, method, address, verified_at, created_at, updated_at)
+VALUES ('b7b5f4e2-8c3d-4a1e-9f6b-2d7c1e5a3b90', '30095c71-380b-457a-8995-97b8ee6e5307', 'personal-webhook', 'webhook', 'https://example.com/hooks/coder', '2025-02-07 07:46:19.513317 +00:00', '2025-02-07 07:46:19.513317 +00:00', '2025-02-07 07:46:19.513317 +00:00');
+
+INSERT INTO notification_preference_targets (user_id, notification_template_id, notification_target_id)
+VALUES ('30095c71-380b-457a-8995-97b8ee6e5307', 'c425f63e-716a-4bf4-ae24-78348f706c3f', 'b7b5f4e2-8c3d-4a1e-9f6b-2d7c1e5a3b90');
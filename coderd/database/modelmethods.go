@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"slices"
 	"sort"
 	"strconv"
@@ -133,6 +134,12 @@ func (w ConnectionLog) RBACObject() rbac.Object {
 	return obj
 }
 
+// RBACObject returns the connection log object of the workspace, as session
+// recordings are part of the connection history of a workspace.
+func (r WorkspaceSessionRecording) RBACObject() rbac.Object {
+	return rbac.ResourceConnectionLog.WithID(r.ID).InOrg(r.OrganizationID)
+}
+
 // TaskTable converts a Task to it's reduced version.
 // A more generalized solution is to use json marshaling to
 // consistently keep these two structs in sync.
@@ -344,6 +351,14 @@ func (i InboxNotification) RBACObject() rbac.Object {
 		WithOwner(i.UserID.String())
 }
 
+// RBACObject returns the notification preference resource of the target's
+// owner, since notification targets are managed alongside the preferences.
+func (t NotificationTarget) RBACObject() rbac.Object {
+	return rbac.ResourceNotificationPreference.
+		WithID(t.ID).
+		WithOwner(t.UserID.String())
+}
+
 // RBACObjectNoTemplate is for orphaned template versions.
 func (v TemplateVersion) RBACObjectNoTemplate() rbac.Object {
 	return rbac.ResourceTemplate.InOrg(v.OrganizationID)
@@ -400,6 +415,7 @@ func (w Workspace) WorkspaceTable() WorkspaceTable {
 		NextStartAt:       w.NextStartAt,
 		GroupACL:          w.GroupACL,
 		UserACL:           w.UserACL,
+		SourceWorkspaceID: w.SourceWorkspaceID,
 	}
 }
 
@@ -634,6 +650,19 @@ func (a WorkspaceAgent) Status(inactiveTimeout time.Duration) WorkspaceAgentConn
 	return status
 }
 
+// DependsOnUnits returns the unit names that the script depends on. Malformed
+// values are treated as no dependencies.
+func (s WorkspaceAgentScript) DependsOnUnits() []string {
+	if len(s.DependsOn) == 0 {
+		return nil
+	}
+	var units []string
+	if err := json.Unmarshal(s.DependsOn, &units); err != nil || len(units) == 0 {
+		return nil
+	}
+	return units
+}
+
 func ConvertUserRows(rows []GetUsersRow) []User {
 	users := make([]User, len(rows))
 	for i, r := range rows {
@@ -957,6 +957,7 @@ const (
 	BuildReasonTaskAutoPause       BuildReason = "task_auto_pause"
 	BuildReasonTaskManualPause     BuildReason = "task_manual_pause"
 	BuildReasonTaskResume          BuildReason = "task_resume"
+	BuildReasonMaintenance         BuildReason = "maintenance"
 )
 
 func (e *BuildReason) Scan(src interface{}) error {
@@ -1009,7 +1010,8 @@ func (e BuildReason) Valid() bool {
 		BuildReasonJetbrainsConnection,
 		BuildReasonTaskAutoPause,
 		BuildReasonTaskManualPause,
-		BuildReasonTaskResume:
+		BuildReasonTaskResume,
+		BuildReasonMaintenance:
 		return true
 	}
 	return false
@@ -1031,6 +1033,7 @@ func AllBuildReasonValues() []BuildReason {
 		BuildReasonTaskAutoPause,
 		BuildReasonTaskManualPause,
 		BuildReasonTaskResume,
+		BuildReasonMaintenance,
 	}
 }
 
@@ -1745,6 +1748,9 @@ const (
 	NotificationMethodSmtp    NotificationMethod = "smtp"
 	NotificationMethodWebhook NotificationMethod = "webhook"
 	NotificationMethodInbox   NotificationMethod = "inbox"
+	NotificationMethodWebpush NotificationMethod = "webpush"
+	NotificationMethodSlack   NotificationMethod = "slack"
+	NotificationMethodTeams   NotificationMethod = "teams"
 )
 
 func (e *NotificationMethod) Scan(src interface{}) error {
@@ -1786,7 +1792,10 @@ func (e NotificationMethod) Valid() bool {
 	switch e {
 	case NotificationMethodSmtp,
 		NotificationMethodWebhook,
-		NotificationMethodInbox:
+		NotificationMethodInbox,
+		NotificationMethodWebpush,
+		NotificationMethodSlack,
+		NotificationMethodTeams:
 		return true
 	}
 	return false
@@ -1797,6 +1806,9 @@ func AllNotificationMethodValues() []NotificationMethod {
 		NotificationMethodSmtp,
 		NotificationMethodWebhook,
 		NotificationMethodInbox,
+		NotificationMethodWebpush,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
 	}
 }
 
@@ -2240,6 +2252,67 @@ func AllPortShareProtocolValues() []PortShareProtocol {
 	}
 }
 
+type PrebuildReadinessStatus string
+
+const (
+	PrebuildReadinessStatusPending PrebuildReadinessStatus = "pending"
+	PrebuildReadinessStatusPassed  PrebuildReadinessStatus = "passed"
+	PrebuildReadinessStatusFailed  PrebuildReadinessStatus = "failed"
+)
+
+func (e *PrebuildReadinessStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PrebuildReadinessStatus(s)
+	case string:
+		*e = PrebuildReadinessStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PrebuildReadinessStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPrebuildReadinessStatus struct {
+	PrebuildReadinessStatus PrebuildReadinessStatus `json:"prebuild_readiness_status"`
+	Valid                   bool                    `json:"valid"` // Valid is true if PrebuildReadinessStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPrebuildReadinessStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PrebuildReadinessStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PrebuildReadinessStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPrebuildReadinessStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PrebuildReadinessStatus), nil
+}
+
+func (e PrebuildReadinessStatus) Valid() bool {
+	switch e {
+	case PrebuildReadinessStatusPending,
+		PrebuildReadinessStatusPassed,
+		PrebuildReadinessStatusFailed:
+		return true
+	}
+	return false
+}
+
+func AllPrebuildReadinessStatusValues() []PrebuildReadinessStatus {
+	return []PrebuildReadinessStatus{
+		PrebuildReadinessStatusPending,
+		PrebuildReadinessStatusPassed,
+		PrebuildReadinessStatusFailed,
+	}
+}
+
 type PrebuildStatus string
 
 const (
@@ -2675,35 +2748,103 @@ func AllProvisionerTypeValues() []ProvisionerType {
 	}
 }
 
+type ResourceChangeAction string
+
+const (
+	ResourceChangeActionCreate  ResourceChangeAction = "create"
+	ResourceChangeActionUpdate  ResourceChangeAction = "update"
+	ResourceChangeActionReplace ResourceChangeAction = "replace"
+	ResourceChangeActionDelete  ResourceChangeAction = "delete"
+)
+
+func (e *ResourceChangeAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ResourceChangeAction(s)
+	case string:
+		*e = ResourceChangeAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ResourceChangeAction: %T", src)
+	}
+	return nil
+}
+
+type NullResourceChangeAction struct {
+	ResourceChangeAction ResourceChangeAction `json:"resource_change_action"`
+	Valid                bool                 `json:"valid"` // Valid is true if ResourceChangeAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullResourceChangeAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.ResourceChangeAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ResourceChangeAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullResourceChangeAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ResourceChangeAction), nil
+}
+
+func (e ResourceChangeAction) Valid() bool {
+	switch e {
+	case ResourceChangeActionCreate,
+		ResourceChangeActionUpdate,
+		ResourceChangeActionReplace,
+		ResourceChangeActionDelete:
+		return true
+	}
+	return false
+}
+
+func AllResourceChangeActionValues() []ResourceChangeAction {
+	return []ResourceChangeAction{
+		ResourceChangeActionCreate,
+		ResourceChangeActionUpdate,
+		ResourceChangeActionReplace,
+		ResourceChangeActionDelete,
+	}
+}
+
 type ResourceType string
 
 const (
-	ResourceTypeOrganization                ResourceType = "organization"
-	ResourceTypeTemplate                    ResourceType = "template"
-	ResourceTypeTemplateVersion             ResourceType = "template_version"
-	ResourceTypeUser                        ResourceType = "user"
-	ResourceTypeWorkspace                   ResourceType = "workspace"
-	ResourceTypeGitSshKey                   ResourceType = "git_ssh_key"
-	ResourceTypeApiKey                      ResourceType = "api_key"
-	ResourceTypeGroup                       ResourceType = "group"
-	ResourceTypeWorkspaceBuild              ResourceType = "workspace_build"
-	ResourceTypeLicense                     ResourceType = "license"
-	ResourceTypeWorkspaceProxy              ResourceType = "workspace_proxy"
-	ResourceTypeConvertLogin                ResourceType = "convert_login"
-	ResourceTypeHealthSettings              ResourceType = "health_settings"
-	ResourceTypeOauth2ProviderApp           ResourceType = "oauth2_provider_app"
-	ResourceTypeOauth2ProviderAppSecret     ResourceType = "oauth2_provider_app_secret"
-	ResourceTypeCustomRole                  ResourceType = "custom_role"
-	ResourceTypeOrganizationMember          ResourceType = "organization_member"
-	ResourceTypeNotificationsSettings       ResourceType = "notifications_settings"
-	ResourceTypeNotificationTemplate        ResourceType = "notification_template"
-	ResourceTypeIdpSyncSettingsOrganization ResourceType = "idp_sync_settings_organization"
-	ResourceTypeIdpSyncSettingsGroup        ResourceType = "idp_sync_settings_group"
-	ResourceTypeIdpSyncSettingsRole         ResourceType = "idp_sync_settings_role"
-	ResourceTypeWorkspaceAgent              ResourceType = "workspace_agent"
-	ResourceTypeWorkspaceApp                ResourceType = "workspace_app"
-	ResourceTypePrebuildsSettings           ResourceType = "prebuilds_settings"
-	ResourceTypeTask                        ResourceType = "task"
+	ResourceTypeOrganization                    ResourceType = "organization"
+	ResourceTypeTemplate                        ResourceType = "template"
+	ResourceTypeTemplateVersion                 ResourceType = "template_version"
+	ResourceTypeUser                            ResourceType = "user"
+	ResourceTypeWorkspace                       ResourceType = "workspace"
+	ResourceTypeGitSshKey                       ResourceType = "git_ssh_key"
+	ResourceTypeApiKey                          ResourceType = "api_key"
+	ResourceTypeGroup                           ResourceType = "group"
+	ResourceTypeWorkspaceBuild                  ResourceType = "workspace_build"
+	ResourceTypeLicense                         ResourceType = "license"
+	ResourceTypeWorkspaceProxy                  ResourceType = "workspace_proxy"
+	ResourceTypeConvertLogin                    ResourceType = "convert_login"
+	ResourceTypeHealthSettings                  ResourceType = "health_settings"
+	ResourceTypeOauth2ProviderApp               ResourceType = "oauth2_provider_app"
+	ResourceTypeOauth2ProviderAppSecret         ResourceType = "oauth2_provider_app_secret"
+	ResourceTypeCustomRole                      ResourceType = "custom_role"
+	ResourceTypeOrganizationMember              ResourceType = "organization_member"
+	ResourceTypeNotificationsSettings           ResourceType = "notifications_settings"
+	ResourceTypeNotificationTemplate            ResourceType = "notification_template"
+	ResourceTypeIdpSyncSettingsOrganization     ResourceType = "idp_sync_settings_organization"
+	ResourceTypeIdpSyncSettingsGroup            ResourceType = "idp_sync_settings_group"
+	ResourceTypeIdpSyncSettingsRole             ResourceType = "idp_sync_settings_role"
+	ResourceTypeWorkspaceAgent                  ResourceType = "workspace_agent"
+	ResourceTypeWorkspaceApp                    ResourceType = "workspace_app"
+	ResourceTypePrebuildsSettings               ResourceType = "prebuilds_settings"
+	ResourceTypeTask                            ResourceType = "task"
+	ResourceTypeOrganizationNotificationWebhook ResourceType = "organization_notification_webhook"
+	ResourceTypeTemplatePresetReadinessChecks   ResourceType = "template_preset_readiness_checks"
+	ResourceTypeTemplateGroupScheduleOverride   ResourceType = "template_group_schedule_override"
+	ResourceTypeTemplateAdaptivePrebuilds       ResourceType = "template_adaptive_prebuilds"
 )
 
 func (e *ResourceType) Scan(src interface{}) error {
@@ -2768,7 +2909,11 @@ func (e ResourceType) Valid() bool {
 		ResourceTypeWorkspaceAgent,
 		ResourceTypeWorkspaceApp,
 		ResourceTypePrebuildsSettings,
-		ResourceTypeTask:
+		ResourceTypeTask,
+		ResourceTypeOrganizationNotificationWebhook,
+		ResourceTypeTemplatePresetReadinessChecks,
+		ResourceTypeTemplateGroupScheduleOverride,
+		ResourceTypeTemplateAdaptivePrebuilds:
 		return true
 	}
 	return false
@@ -2802,6 +2947,10 @@ func AllResourceTypeValues() []ResourceType {
 		ResourceTypeWorkspaceApp,
 		ResourceTypePrebuildsSettings,
 		ResourceTypeTask,
+		ResourceTypeOrganizationNotificationWebhook,
+		ResourceTypeTemplatePresetReadinessChecks,
+		ResourceTypeTemplateGroupScheduleOverride,
+		ResourceTypeTemplateAdaptivePrebuilds,
 	}
 }
 
@@ -3573,9 +3722,10 @@ func AllWorkspaceAppStatusStateValues() []WorkspaceAppStatusState {
 type WorkspaceTransition string
 
 const (
-	WorkspaceTransitionStart  WorkspaceTransition = "start"
-	WorkspaceTransitionStop   WorkspaceTransition = "stop"
-	WorkspaceTransitionDelete WorkspaceTransition = "delete"
+	WorkspaceTransitionStart     WorkspaceTransition = "start"
+	WorkspaceTransitionStop      WorkspaceTransition = "stop"
+	WorkspaceTransitionDelete    WorkspaceTransition = "delete"
+	WorkspaceTransitionHibernate WorkspaceTransition = "hibernate"
 )
 
 func (e *WorkspaceTransition) Scan(src interface{}) error {
@@ -3617,7 +3767,8 @@ func (e WorkspaceTransition) Valid() bool {
 	switch e {
 	case WorkspaceTransitionStart,
 		WorkspaceTransitionStop,
-		WorkspaceTransitionDelete:
+		WorkspaceTransitionDelete,
+		WorkspaceTransitionHibernate:
 		return true
 	}
 	return false
@@ -3628,6 +3779,7 @@ func AllWorkspaceTransitionValues() []WorkspaceTransition {
 		WorkspaceTransitionStart,
 		WorkspaceTransitionStop,
 		WorkspaceTransitionDelete,
+		WorkspaceTransitionHibernate,
 	}
 }
 
@@ -3931,6 +4083,10 @@ type NotificationMessage struct {
 	QueuedSeconds          sql.NullFloat64           `db:"queued_seconds" json:"queued_seconds"`
 	// Auto-generated by insert/update trigger, used to prevent duplicate notifications from being enqueued on the same day
 	DedupeHash sql.NullString `db:"dedupe_hash" json:"dedupe_hash"`
+	// The user's notification target the message is delivered to, if any. Otherwise, the message is delivered using the deployment-wide configuration of its method.
+	NotificationTargetID uuid.NullUUID `db:"notification_target_id" json:"notification_target_id"`
+	// The HTTP status code of the response to the last delivery attempt, if it failed with one. Only set for methods which deliver over HTTP, such as webhook.
+	ResponseStatusCode sql.NullInt32 `db:"response_status_code" json:"response_status_code"`
 }
 
 type NotificationPreference struct {
@@ -3941,12 +4097,36 @@ type NotificationPreference struct {
 	UpdatedAt              time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// Routes notifications of a template to the user's own notification targets, instead of the deployment-wide notification method.
+type NotificationPreferenceTarget struct {
+	UserID                 uuid.UUID `db:"user_id" json:"user_id"`
+	NotificationTemplateID uuid.UUID `db:"notification_template_id" json:"notification_template_id"`
+	NotificationTargetID   uuid.UUID `db:"notification_target_id" json:"notification_target_id"`
+	CreatedAt              time.Time `db:"created_at" json:"created_at"`
+}
+
 // Log of generated reports for users.
 type NotificationReportGeneratorLog struct {
 	NotificationTemplateID uuid.UUID `db:"notification_template_id" json:"notification_template_id"`
 	LastGeneratedAt        time.Time `db:"last_generated_at" json:"last_generated_at"`
 }
 
+// Delivery targets registered by users to receive their own notifications, such as a personal webhook endpoint or email address.
+type NotificationTarget struct {
+	ID     uuid.UUID          `db:"id" json:"id"`
+	UserID uuid.UUID          `db:"user_id" json:"user_id"`
+	Name   string             `db:"name" json:"name"`
+	Method NotificationMethod `db:"method" json:"method"`
+	// The webhook endpoint or email address notifications are delivered to. Empty for the inbox and webpush methods, which deliver to the user's own inbox and push subscriptions.
+	Address                   string       `db:"address" json:"address"`
+	HashedVerificationCode    []byte       `db:"hashed_verification_code" json:"hashed_verification_code"`
+	VerificationCodeExpiresAt sql.NullTime `db:"verification_code_expires_at" json:"verification_code_expires_at"`
+	// When the user confirmed the code of a test message delivered to the target. Notifications are only delivered to verified targets.
+	VerifiedAt sql.NullTime `db:"verified_at" json:"verified_at"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time    `db:"updated_at" json:"updated_at"`
+}
+
 // Templates from which to create notification messages.
 type NotificationTemplate struct {
 	ID            uuid.UUID      `db:"id" json:"id"`
@@ -4075,6 +4255,16 @@ type OrganizationMember struct {
 	Roles          []string  `db:"roles" json:"roles"`
 }
 
+// Incoming webhooks of chat services, such as Slack or Microsoft Teams, to which notifications of the organization's members are delivered.
+type OrganizationNotificationWebhook struct {
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	// The notification method delivered by the webhook, either slack or teams.
+	Method    NotificationMethod `db:"method" json:"method"`
+	Endpoint  string             `db:"endpoint" json:"endpoint"`
+	CreatedAt time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `db:"updated_at" json:"updated_at"`
+}
+
 type ParameterSchema struct {
 	ID                       uuid.UUID                  `db:"id" json:"id"`
 	CreatedAt                time.Time                  `db:"created_at" json:"created_at"`
@@ -4159,6 +4349,17 @@ type ProvisionerJobLog struct {
 	ID        int64     `db:"id" json:"id"`
 }
 
+// Changes to resources planned by a template version dry-run. Used to preview builds of existing workspaces.
+type ProvisionerJobResourceChange struct {
+	JobID   uuid.UUID            `db:"job_id" json:"job_id"`
+	Address string               `db:"address" json:"address"`
+	Type    string               `db:"type" json:"type"`
+	Name    string               `db:"name" json:"name"`
+	Action  ResourceChangeAction `db:"action" json:"action"`
+	// The attribute paths which force the resource to be replaced.
+	ReplacePaths []string `db:"replace_paths" json:"replace_paths"`
+}
+
 type ProvisionerJobStat struct {
 	JobID          uuid.UUID            `db:"job_id" json:"job_id"`
 	JobStatus      ProvisionerJobStatus `db:"job_status" json:"job_status"`
@@ -4340,6 +4541,10 @@ type Template struct {
 	UseClassicParameterFlow       bool            `db:"use_classic_parameter_flow" json:"use_classic_parameter_flow"`
 	CorsBehavior                  CorsBehavior    `db:"cors_behavior" json:"cors_behavior"`
 	DisableModuleCache            bool            `db:"disable_module_cache" json:"disable_module_cache"`
+	SessionRecordingEnabled       bool            `db:"session_recording_enabled" json:"session_recording_enabled"`
+	SessionRecordingIncludeInput  bool            `db:"session_recording_include_input" json:"session_recording_include_input"`
+	MaintenanceWindowSchedule     string          `db:"maintenance_window_schedule" json:"maintenance_window_schedule"`
+	HibernateIdleThreshold        int64           `db:"hibernate_idle_threshold" json:"hibernate_idle_threshold"`
 	CreatedByAvatarURL            string          `db:"created_by_avatar_url" json:"created_by_avatar_url"`
 	CreatedByUsername             string          `db:"created_by_username" json:"created_by_username"`
 	CreatedByName                 string          `db:"created_by_name" json:"created_by_name"`
@@ -4348,6 +4553,47 @@ type Template struct {
 	OrganizationIcon              string          `db:"organization_icon" json:"organization_icon"`
 }
 
+// Demand-driven sizing of the prebuild pools of a template. When enabled, the desired instances of each preset with prebuilds are forecasted from its claim history instead of the preset configuration.
+type TemplateAdaptivePrebuild struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	Enabled    bool      `db:"enabled" json:"enabled"`
+	// The lower bound of the forecasted desired instances of each preset.
+	MinInstances int32 `db:"min_instances" json:"min_instances"`
+	// The upper bound of the forecasted desired instances of each preset.
+	MaxInstances int32 `db:"max_instances" json:"max_instances"`
+	// The number of weeks of claim history used to forecast claims.
+	LookbackWeeks int32     `db:"lookback_weeks" json:"lookback_weeks"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Schedule restrictions applied on top of the template schedule to workspaces owned by members of a group. Members of several groups get the strictest combination.
+type TemplateGroupScheduleOverride struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	GroupID    uuid.UUID `db:"group_id" json:"group_id"`
+	// The maximum workspace TTL in nanoseconds. 0 means the template TTL is not restricted.
+	MaxTTL int64 `db:"max_ttl" json:"max_ttl"`
+	// A bitmap of days of week that autostart is blocked on, in addition to the days blocked by the template.
+	AutostartBlockDaysOfWeek int16 `db:"autostart_block_days_of_week" json:"autostart_block_days_of_week"`
+	// A bitmap of days of week that workspaces must be stopped on, in addition to the days required by the template.
+	AutostopRequirementDaysOfWeek int16 `db:"autostop_requirement_days_of_week" json:"autostop_requirement_days_of_week"`
+	// The number of weeks between autostop requirement days. 0 means the template value is used.
+	AutostopRequirementWeeks int64     `db:"autostop_requirement_weeks" json:"autostop_requirement_weeks"`
+	CreatedAt                time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt                time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Checks that prebuilt workspaces of a template preset must pass once their agents are ready, before they can be claimed. Presets are matched by name, so that the checks apply to all versions of the template.
+type TemplatePresetReadinessCheck struct {
+	TemplateID uuid.UUID               `db:"template_id" json:"template_id"`
+	PresetName string                  `db:"preset_name" json:"preset_name"`
+	Checks     PrebuildReadinessChecks `db:"checks" json:"checks"`
+	// How long checks may remain pending after the agents are ready before the prebuilt workspace is considered unhealthy, in nanoseconds.
+	Timeout   int64     `db:"timeout" json:"timeout"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
 type TemplateTable struct {
 	ID              uuid.UUID       `db:"id" json:"id"`
 	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
@@ -4390,6 +4636,14 @@ type TemplateTable struct {
 	UseClassicParameterFlow bool         `db:"use_classic_parameter_flow" json:"use_classic_parameter_flow"`
 	CorsBehavior            CorsBehavior `db:"cors_behavior" json:"cors_behavior"`
 	DisableModuleCache      bool         `db:"disable_module_cache" json:"disable_module_cache"`
+	// Whether interactive terminal sessions in workspaces of this template are recorded by the agent.
+	SessionRecordingEnabled bool `db:"session_recording_enabled" json:"session_recording_enabled"`
+	// Whether session recordings include the input typed by the user in addition to the output.
+	SessionRecordingIncludeInput bool `db:"session_recording_include_input" json:"session_recording_include_input"`
+	// A cron time range (e.g. "* 2-4 * * 6") during which idle outdated workspaces are restarted onto the active template version. Empty when no maintenance window is configured.
+	MaintenanceWindowSchedule string `db:"maintenance_window_schedule" json:"maintenance_window_schedule"`
+	// Workspaces that reach their autostop deadline after being idle for less than this duration (in nanoseconds) are hibernated instead of stopped. 0 disables hibernation on autostop.
+	HibernateIdleThreshold int64 `db:"hibernate_idle_threshold" json:"hibernate_idle_threshold"`
 }
 
 // Records aggregated usage statistics for templates/users. All usage is rounded up to the nearest minute.
@@ -4701,6 +4955,7 @@ type Workspace struct {
 	NextStartAt             sql.NullTime            `db:"next_start_at" json:"next_start_at"`
 	GroupACL                WorkspaceACL            `db:"group_acl" json:"group_acl"`
 	UserACL                 WorkspaceACL            `db:"user_acl" json:"user_acl"`
+	SourceWorkspaceID       uuid.NullUUID           `db:"source_workspace_id" json:"source_workspace_id"`
 	OwnerAvatarUrl          string                  `db:"owner_avatar_url" json:"owner_avatar_url"`
 	OwnerUsername           string                  `db:"owner_username" json:"owner_username"`
 	OwnerName               string                  `db:"owner_name" json:"owner_name"`
@@ -4846,6 +5101,10 @@ type WorkspaceAgentScript struct {
 	TimeoutSeconds   int32     `db:"timeout_seconds" json:"timeout_seconds"`
 	DisplayName      string    `db:"display_name" json:"display_name"`
 	ID               uuid.UUID `db:"id" json:"id"`
+	// The name of the startup coordination unit that represents this script in the agent.
+	UnitName string `db:"unit_name" json:"unit_name"`
+	// A JSON array of unit names that must complete before this script runs.
+	DependsOn json.RawMessage `db:"depends_on" json:"depends_on"`
 }
 
 type WorkspaceAgentScriptTiming struct {
@@ -5065,6 +5324,16 @@ type WorkspacePrebuildBuild struct {
 	BuildNumber             int32               `db:"build_number" json:"build_number"`
 }
 
+// The result of evaluating the readiness checks of a prebuilt workspace's preset against the workspace's latest build.
+type WorkspacePrebuildReadiness struct {
+	WorkspaceID uuid.UUID               `db:"workspace_id" json:"workspace_id"`
+	BuildID     uuid.UUID               `db:"build_id" json:"build_id"`
+	Status      PrebuildReadinessStatus `db:"status" json:"status"`
+	// Why the readiness checks are pending or failed.
+	FailureReasons []string  `db:"failure_reasons" json:"failure_reasons"`
+	CheckedAt      time.Time `db:"checked_at" json:"checked_at"`
+}
+
 type WorkspaceProxy struct {
 	ID          uuid.UUID `db:"id" json:"id"`
 	Name        string    `db:"name" json:"name"`
@@ -5110,6 +5379,33 @@ type WorkspaceResourceMetadatum struct {
 	ID                  int64          `db:"id" json:"id"`
 }
 
+// Recordings of interactive terminal sessions in asciicast v2 format, uploaded by the agent when the session ends.
+type WorkspaceSessionRecording struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	WorkspaceID    uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	AgentID        uuid.UUID `db:"agent_id" json:"agent_id"`
+	// The connection ID reported by the agent for the session. Matches connection_logs.connection_id.
+	ConnectionID uuid.UUID      `db:"connection_id" json:"connection_id"`
+	Type         ConnectionType `db:"type" json:"type"`
+	StartedAt    time.Time      `db:"started_at" json:"started_at"`
+	EndedAt      time.Time      `db:"ended_at" json:"ended_at"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	IncludeInput bool           `db:"include_input" json:"include_input"`
+	// Whether the agent stopped recording because the recording reached its maximum size.
+	Truncated bool `db:"truncated" json:"truncated"`
+	// The total size of the recording's chunks in bytes.
+	Size int64 `db:"size" json:"size"`
+}
+
+// The data of session recordings. Recordings larger than the maximum agent API message size are uploaded in parts, which are stored as separate chunks.
+type WorkspaceSessionRecordingChunk struct {
+	RecordingID uuid.UUID `db:"recording_id" json:"recording_id"`
+	// The offset of the chunk in the recording, in bytes.
+	OffsetBytes int64  `db:"offset_bytes" json:"offset_bytes"`
+	Data        []byte `db:"data" json:"data"`
+}
+
 type WorkspaceTable struct {
 	ID                uuid.UUID        `db:"id" json:"id"`
 	CreatedAt         time.Time        `db:"created_at" json:"created_at"`
@@ -5130,4 +5426,6 @@ type WorkspaceTable struct {
 	NextStartAt sql.NullTime `db:"next_start_at" json:"next_start_at"`
 	GroupACL    WorkspaceACL `db:"group_acl" json:"group_acl"`
 	UserACL     WorkspaceACL `db:"user_acl" json:"user_acl"`
+	// The workspace this workspace was cloned from, if any.
+	SourceWorkspaceID uuid.NullUUID `db:"source_workspace_id" json:"source_workspace_id"`
 }
@@ -50,6 +50,10 @@ type sqlcQuerier interface {
 	ActivityBumpWorkspace(ctx context.Context, arg ActivityBumpWorkspaceParams) error
 	// AllUserIDs returns all UserIDs regardless of user status or deletion.
 	AllUserIDs(ctx context.Context, includeSystem bool) ([]uuid.UUID, error)
+	// Recordings larger than the maximum agent API message size are uploaded in
+	// parts. The offset guards against parts being applied out of order or twice,
+	// and the agent ID against an agent appending to another agent's recording.
+	AppendWorkspaceSessionRecordingData(ctx context.Context, arg AppendWorkspaceSessionRecordingDataParams) (int64, error)
 	// Archiving templates is a soft delete action, so is reversible.
 	// Archiving prevents the version from being used and discovered
 	// by listing.
@@ -96,6 +100,7 @@ type sqlcQuerier interface {
 	DeleteGroupByID(ctx context.Context, id uuid.UUID) error
 	DeleteGroupMemberFromGroup(ctx context.Context, arg DeleteGroupMemberFromGroupParams) error
 	DeleteLicense(ctx context.Context, id int32) (int32, error)
+	DeleteNotificationTargetByID(ctx context.Context, id uuid.UUID) error
 	DeleteOAuth2ProviderAppByClientID(ctx context.Context, id uuid.UUID) error
 	DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error
 	DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error
@@ -125,12 +130,20 @@ type sqlcQuerier interface {
 	DeleteOldWorkspaceAgentLogs(ctx context.Context, threshold time.Time) (int64, error)
 	DeleteOldWorkspaceAgentStats(ctx context.Context) error
 	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error
+	DeleteOrganizationNotificationWebhook(ctx context.Context, arg DeleteOrganizationNotificationWebhookParams) error
 	DeleteProvisionerKey(ctx context.Context, id uuid.UUID) error
 	DeleteReplicasUpdatedBefore(ctx context.Context, updatedAt time.Time) error
 	DeleteRuntimeConfig(ctx context.Context, key string) error
+	// DeleteStalePrebuildReadiness deletes the readiness check results of workspaces
+	// that are no longer prebuilt workspaces, because they were claimed or deleted.
+	// Failed results of deleted prebuilds are kept while their template version is active.
+	DeleteStalePrebuildReadiness(ctx context.Context) error
 	DeleteTailnetPeer(ctx context.Context, arg DeleteTailnetPeerParams) (DeleteTailnetPeerRow, error)
 	DeleteTailnetTunnel(ctx context.Context, arg DeleteTailnetTunnelParams) (DeleteTailnetTunnelRow, error)
 	DeleteTask(ctx context.Context, arg DeleteTaskParams) (uuid.UUID, error)
+	DeleteTemplateGroupScheduleOverride(ctx context.Context, arg DeleteTemplateGroupScheduleOverrideParams) (TemplateGroupScheduleOverride, error)
+	DeleteTemplatePresetReadinessChecks(ctx context.Context, arg DeleteTemplatePresetReadinessChecksParams) (TemplatePresetReadinessCheck, error)
+	DeleteUserNotificationPreferenceTargets(ctx context.Context, arg DeleteUserNotificationPreferenceTargetsParams) error
 	DeleteUserSecret(ctx context.Context, id uuid.UUID) error
 	DeleteWebpushSubscriptionByUserIDAndEndpoint(ctx context.Context, arg DeleteWebpushSubscriptionByUserIDAndEndpointParams) error
 	DeleteWebpushSubscriptions(ctx context.Context, ids []uuid.UUID) error
@@ -214,6 +227,7 @@ type sqlcQuerier interface {
 	GetDeploymentWorkspaceAgentUsageStats(ctx context.Context, createdAt time.Time) (GetDeploymentWorkspaceAgentUsageStatsRow, error)
 	GetDeploymentWorkspaceStats(ctx context.Context) (GetDeploymentWorkspaceStatsRow, error)
 	GetEligibleProvisionerDaemonsByProvisionerJobIDs(ctx context.Context, provisionerJobIds []uuid.UUID) ([]GetEligibleProvisionerDaemonsByProvisionerJobIDsRow, error)
+	GetEnabledTemplateAdaptivePrebuilds(ctx context.Context) ([]TemplateAdaptivePrebuild, error)
 	GetExternalAuthLink(ctx context.Context, arg GetExternalAuthLinkParams) (ExternalAuthLink, error)
 	GetExternalAuthLinksByUserID(ctx context.Context, userID uuid.UUID) ([]ExternalAuthLink, error)
 	GetFailedWorkspaceBuildsByTemplateID(ctx context.Context, arg GetFailedWorkspaceBuildsByTemplateIDParams) ([]GetFailedWorkspaceBuildsByTemplateIDRow, error)
@@ -257,9 +271,14 @@ type sqlcQuerier interface {
 	GetLicenseByID(ctx context.Context, id int32) (License, error)
 	GetLicenses(ctx context.Context) ([]License, error)
 	GetLogoURL(ctx context.Context) (string, error)
+	// Returns the most recently enqueued notification messages, optionally only those of the given method, along with the
+	// outcome of their last delivery attempt.
+	GetNotificationMessageDeliveries(ctx context.Context, arg GetNotificationMessageDeliveriesParams) ([]GetNotificationMessageDeliveriesRow, error)
 	GetNotificationMessagesByStatus(ctx context.Context, arg GetNotificationMessagesByStatusParams) ([]NotificationMessage, error)
 	// Fetch the notification report generator log indicating recent activity.
 	GetNotificationReportGeneratorLogByTemplate(ctx context.Context, templateID uuid.UUID) (NotificationReportGeneratorLog, error)
+	GetNotificationTargetByID(ctx context.Context, id uuid.UUID) (NotificationTarget, error)
+	GetNotificationTargetsByUserID(ctx context.Context, userID uuid.UUID) ([]NotificationTarget, error)
 	GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (NotificationTemplate, error)
 	GetNotificationTemplatesByKind(ctx context.Context, kind NotificationTemplateKind) ([]NotificationTemplate, error)
 	GetNotificationsSettings(ctx context.Context) (string, error)
@@ -281,6 +300,9 @@ type sqlcQuerier interface {
 	GetOrganizationByID(ctx context.Context, id uuid.UUID) (Organization, error)
 	GetOrganizationByName(ctx context.Context, arg GetOrganizationByNameParams) (Organization, error)
 	GetOrganizationIDsByMemberIDs(ctx context.Context, ids []uuid.UUID) ([]GetOrganizationIDsByMemberIDsRow, error)
+	GetOrganizationNotificationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]OrganizationNotificationWebhook, error)
+	// Returns the incoming webhooks of the given method configured by the organizations the user is a member of.
+	GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg GetOrganizationNotificationWebhooksByUserIDParams) ([]OrganizationNotificationWebhook, error)
 	GetOrganizationResourceCountByID(ctx context.Context, organizationID uuid.UUID) (GetOrganizationResourceCountByIDRow, error)
 	GetOrganizations(ctx context.Context, arg GetOrganizationsParams) ([]Organization, error)
 	GetOrganizationsByUserID(ctx context.Context, arg GetOrganizationsByUserIDParams) ([]Organization, error)
@@ -288,7 +310,16 @@ type sqlcQuerier interface {
 	// membership status for the prebuilds system user (org membership, group existence, group membership).
 	GetOrganizationsWithPrebuildStatus(ctx context.Context, arg GetOrganizationsWithPrebuildStatusParams) ([]GetOrganizationsWithPrebuildStatusRow, error)
 	GetParameterSchemasByJobID(ctx context.Context, jobID uuid.UUID) ([]ParameterSchema, error)
+	// GetPrebuildClaimHistory returns when prebuilt workspaces of the given templates were claimed,
+	// along with the name of the preset they were built from. Presets are matched by name because
+	// each template version has its own presets.
+	// A prebuilt workspace is claimed by the first build that was not initiated by the prebuilds system user.
+	GetPrebuildClaimHistory(ctx context.Context, arg GetPrebuildClaimHistoryParams) ([]GetPrebuildClaimHistoryRow, error)
 	GetPrebuildMetrics(ctx context.Context) ([]GetPrebuildMetricsRow, error)
+	// GetPrebuildReadiness returns the readiness check results of prebuilt workspaces.
+	// Results of earlier builds are stale and therefore omitted.
+	// If template_id is specified, only the results of that template's prebuilt workspaces are returned.
+	GetPrebuildReadiness(ctx context.Context, templateID uuid.NullUUID) ([]WorkspacePrebuildReadiness, error)
 	GetPrebuildsSettings(ctx context.Context) (string, error)
 	GetPresetByID(ctx context.Context, presetID uuid.UUID) (GetPresetByIDRow, error)
 	GetPresetByWorkspaceBuildID(ctx context.Context, workspaceBuildID uuid.UUID) (TemplateVersionPreset, error)
@@ -298,6 +329,7 @@ type sqlcQuerier interface {
 	// Each preset is associated with exactly one template version ID.
 	// For each preset, the query checks the last hard_limit builds.
 	// If all of them failed, the preset is considered to have hit the hard failure limit.
+	// Builds of prebuilds which failed their preset's readiness checks count as failed builds.
 	// The query returns a list of preset IDs that have reached this failure threshold.
 	// Only active template versions with configured presets are considered.
 	// For each preset, check the last hard_limit builds.
@@ -311,6 +343,7 @@ type sqlcQuerier interface {
 	// Query returns a list of preset IDs for which we should backoff.
 	// Only active template versions with configured presets are considered.
 	// We also return the number of failed workspace builds that occurred during the lookback period.
+	// Builds of prebuilds which failed their preset's readiness checks count as failed builds.
 	//
 	// NOTE:
 	// - To **decide whether to back off**, we look at up to the N most recent builds (within the defined lookback period).
@@ -332,6 +365,7 @@ type sqlcQuerier interface {
 	// Gets a provisioner job by ID with exclusive lock.
 	// Blocks until the row is available for update.
 	GetProvisionerJobByIDWithLock(ctx context.Context, id uuid.UUID) (ProvisionerJob, error)
+	GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]ProvisionerJobResourceChange, error)
 	GetProvisionerJobTimingsByJobID(ctx context.Context, jobID uuid.UUID) ([]ProvisionerJobTiming, error)
 	GetProvisionerJobsByIDs(ctx context.Context, ids []uuid.UUID) ([]ProvisionerJob, error)
 	GetProvisionerJobsByIDsWithQueuePosition(ctx context.Context, arg GetProvisionerJobsByIDsWithQueuePositionParams) ([]GetProvisionerJobsByIDsWithQueuePositionRow, error)
@@ -361,6 +395,7 @@ type sqlcQuerier interface {
 	GetTaskSnapshot(ctx context.Context, taskID uuid.UUID) (TaskSnapshot, error)
 	GetTelemetryItem(ctx context.Context, key string) (TelemetryItem, error)
 	GetTelemetryItems(ctx context.Context) ([]TelemetryItem, error)
+	GetTemplateAdaptivePrebuildsByTemplateID(ctx context.Context, templateID uuid.UUID) (TemplateAdaptivePrebuild, error)
 	// GetTemplateAppInsights returns the aggregate usage of each app in a given
 	// timeframe. The result can be filtered on template_ids, meaning only user data
 	// from workspaces based on those templates will be included.
@@ -372,6 +407,10 @@ type sqlcQuerier interface {
 	GetTemplateByID(ctx context.Context, id uuid.UUID) (Template, error)
 	GetTemplateByOrganizationAndName(ctx context.Context, arg GetTemplateByOrganizationAndNameParams) (Template, error)
 	GetTemplateDAUs(ctx context.Context, arg GetTemplateDAUsParams) ([]GetTemplateDAUsRow, error)
+	GetTemplateGroupScheduleOverrides(ctx context.Context, templateID uuid.UUID) ([]TemplateGroupScheduleOverride, error)
+	// Returns the schedule overrides of a template that apply to a user through
+	// their group memberships.
+	GetTemplateGroupScheduleOverridesByUserID(ctx context.Context, arg GetTemplateGroupScheduleOverridesByUserIDParams) ([]TemplateGroupScheduleOverride, error)
 	// GetTemplateInsights returns the aggregate user-produced usage of all
 	// workspaces in a given timeframe. The template IDs, active users, and
 	// usage_seconds all reflect any usage in the template, including apps.
@@ -395,6 +434,9 @@ type sqlcQuerier interface {
 	// created in the timeframe and return the aggregate usage counts of parameter
 	// values.
 	GetTemplateParameterInsights(ctx context.Context, arg GetTemplateParameterInsightsParams) ([]GetTemplateParameterInsightsRow, error)
+	// GetTemplatePresetReadinessChecks returns the readiness checks of template presets.
+	// If template_id is specified, only the checks of that template are returned.
+	GetTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.NullUUID) ([]TemplatePresetReadinessCheck, error)
 	// GetTemplatePresetsWithPrebuilds retrieves template versions with configured presets and prebuilds.
 	// It also returns the number of desired instances for each preset.
 	// If template_id is specified, only template versions associated with that template will be returned.
@@ -413,6 +455,7 @@ type sqlcQuerier interface {
 	GetTemplateVersionsCreatedAfter(ctx context.Context, createdAt time.Time) ([]TemplateVersion, error)
 	GetTemplates(ctx context.Context) ([]Template, error)
 	GetTemplatesWithFilter(ctx context.Context, arg GetTemplatesWithFilterParams) ([]Template, error)
+	GetTemplatesWithMaintenanceWindow(ctx context.Context) ([]Template, error)
 	// Gets the total number of managed agents created between two dates. Uses the
 	// aggregate table to avoid large scans or a complex index on the usage_events
 	// table.
@@ -442,6 +485,7 @@ type sqlcQuerier interface {
 	GetUserLinkByLinkedID(ctx context.Context, linkedID string) (UserLink, error)
 	GetUserLinkByUserIDLoginType(ctx context.Context, arg GetUserLinkByUserIDLoginTypeParams) (UserLink, error)
 	GetUserLinksByUserID(ctx context.Context, userID uuid.UUID) ([]UserLink, error)
+	GetUserNotificationPreferenceTargets(ctx context.Context, userID uuid.UUID) ([]NotificationPreferenceTarget, error)
 	GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]NotificationPreference, error)
 	GetUserSecret(ctx context.Context, id uuid.UUID) (UserSecret, error)
 	GetUserSecretByUserIDAndName(ctx context.Context, arg GetUserSecretByUserIDAndNameParams) (UserSecret, error)
@@ -468,6 +512,8 @@ type sqlcQuerier interface {
 	// to look up references to actions. eg. a user could build a workspace
 	// for another user, then be deleted... we still want them to appear!
 	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error)
+	// Returns the verified notification targets to which the user routes notifications of the given template.
+	GetVerifiedNotificationTargetsByTemplateID(ctx context.Context, arg GetVerifiedNotificationTargetsByTemplateIDParams) ([]NotificationTarget, error)
 	GetWebpushSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]WebpushSubscription, error)
 	GetWebpushVAPIDKeys(ctx context.Context) (GetWebpushVAPIDKeysRow, error)
 	GetWorkspaceACLByID(ctx context.Context, id uuid.UUID) (GetWorkspaceACLByIDRow, error)
@@ -533,6 +579,9 @@ type sqlcQuerier interface {
 	GetWorkspaceResourcesByJobID(ctx context.Context, jobID uuid.UUID) ([]WorkspaceResource, error)
 	GetWorkspaceResourcesByJobIDs(ctx context.Context, ids []uuid.UUID) ([]WorkspaceResource, error)
 	GetWorkspaceResourcesCreatedAfter(ctx context.Context, createdAt time.Time) ([]WorkspaceResource, error)
+	GetWorkspaceSessionRecordingByID(ctx context.Context, id uuid.UUID) (WorkspaceSessionRecording, error)
+	GetWorkspaceSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]WorkspaceSessionRecordingChunk, error)
+	GetWorkspaceSessionRecordingsByWorkspaceID(ctx context.Context, arg GetWorkspaceSessionRecordingsByWorkspaceIDParams) ([]WorkspaceSessionRecording, error)
 	GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error)
 	// build_params is used to filter by build parameters if present.
 	// It has to be a CTE because the set returning function 'unnest' cannot
@@ -541,6 +590,10 @@ type sqlcQuerier interface {
 	GetWorkspacesAndAgentsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]GetWorkspacesAndAgentsByOwnerIDRow, error)
 	GetWorkspacesByTemplateID(ctx context.Context, templateID uuid.UUID) ([]WorkspaceTable, error)
 	GetWorkspacesEligibleForTransition(ctx context.Context, now time.Time) ([]GetWorkspacesEligibleForTransitionRow, error)
+	// Returns the workspaces of a template together with their latest build. Used
+	// by the lifecycle executor to notify owners ahead of a maintenance window and
+	// to report on the outcome once the window closes.
+	GetWorkspacesForTemplateMaintenance(ctx context.Context, templateID uuid.UUID) ([]GetWorkspacesForTemplateMaintenanceRow, error)
 	GetWorkspacesForWorkspaceMetrics(ctx context.Context) ([]GetWorkspacesForWorkspaceMetricsRow, error)
 	InsertAIBridgeInterception(ctx context.Context, arg InsertAIBridgeInterceptionParams) (AIBridgeInterception, error)
 	InsertAIBridgeTokenUsage(ctx context.Context, arg InsertAIBridgeTokenUsageParams) (AIBridgeTokenUsage, error)
@@ -570,6 +623,7 @@ type sqlcQuerier interface {
 	// values for avatar, display name, and quota allowance (all zero values).
 	// If the name conflicts, do nothing.
 	InsertMissingGroups(ctx context.Context, arg InsertMissingGroupsParams) ([]Group, error)
+	InsertNotificationTarget(ctx context.Context, arg InsertNotificationTargetParams) (NotificationTarget, error)
 	InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAuth2ProviderAppParams) (OAuth2ProviderApp, error)
 	InsertOAuth2ProviderAppCode(ctx context.Context, arg InsertOAuth2ProviderAppCodeParams) (OAuth2ProviderAppCode, error)
 	InsertOAuth2ProviderAppSecret(ctx context.Context, arg InsertOAuth2ProviderAppSecretParams) (OAuth2ProviderAppSecret, error)
@@ -581,6 +635,7 @@ type sqlcQuerier interface {
 	InsertPresetPrebuildSchedule(ctx context.Context, arg InsertPresetPrebuildScheduleParams) (TemplateVersionPresetPrebuildSchedule, error)
 	InsertProvisionerJob(ctx context.Context, arg InsertProvisionerJobParams) (ProvisionerJob, error)
 	InsertProvisionerJobLogs(ctx context.Context, arg InsertProvisionerJobLogsParams) ([]ProvisionerJobLog, error)
+	InsertProvisionerJobResourceChange(ctx context.Context, arg InsertProvisionerJobResourceChangeParams) (ProvisionerJobResourceChange, error)
 	InsertProvisionerJobTimings(ctx context.Context, arg InsertProvisionerJobTimingsParams) ([]ProvisionerJobTiming, error)
 	InsertProvisionerKey(ctx context.Context, arg InsertProvisionerKeyParams) (ProvisionerKey, error)
 	InsertReplica(ctx context.Context, arg InsertReplicaParams) (Replica, error)
@@ -608,6 +663,8 @@ type sqlcQuerier interface {
 	// InsertUserGroupsByName adds a user to all provided groups, if they exist.
 	InsertUserGroupsByName(ctx context.Context, arg InsertUserGroupsByNameParams) error
 	InsertUserLink(ctx context.Context, arg InsertUserLinkParams) (UserLink, error)
+	// Routes notifications of the given templates to the given targets. Targets which do not belong to the user are ignored.
+	InsertUserNotificationPreferenceTargets(ctx context.Context, arg InsertUserNotificationPreferenceTargetsParams) (int64, error)
 	InsertVolumeResourceMonitor(ctx context.Context, arg InsertVolumeResourceMonitorParams) (WorkspaceAgentVolumeResourceMonitor, error)
 	InsertWebpushSubscription(ctx context.Context, arg InsertWebpushSubscriptionParams) (WebpushSubscription, error)
 	InsertWorkspace(ctx context.Context, arg InsertWorkspaceParams) (WorkspaceTable, error)
@@ -627,6 +684,7 @@ type sqlcQuerier interface {
 	InsertWorkspaceProxy(ctx context.Context, arg InsertWorkspaceProxyParams) (WorkspaceProxy, error)
 	InsertWorkspaceResource(ctx context.Context, arg InsertWorkspaceResourceParams) (WorkspaceResource, error)
 	InsertWorkspaceResourceMetadata(ctx context.Context, arg InsertWorkspaceResourceMetadataParams) ([]WorkspaceResourceMetadatum, error)
+	InsertWorkspaceSessionRecording(ctx context.Context, arg InsertWorkspaceSessionRecordingParams) error
 	ListAIBridgeInterceptions(ctx context.Context, arg ListAIBridgeInterceptionsParams) ([]ListAIBridgeInterceptionsRow, error)
 	// Finds all unique AI Bridge interception telemetry summaries combinations
 	// (provider, model, client) in the given timeframe for telemetry reporting.
@@ -680,6 +738,9 @@ type sqlcQuerier interface {
 	UpdateInboxNotificationReadStatus(ctx context.Context, arg UpdateInboxNotificationReadStatusParams) error
 	UpdateMemberRoles(ctx context.Context, arg UpdateMemberRolesParams) (OrganizationMember, error)
 	UpdateMemoryResourceMonitor(ctx context.Context, arg UpdateMemoryResourceMonitorParams) error
+	UpdateNotificationTargetVerificationCode(ctx context.Context, arg UpdateNotificationTargetVerificationCodeParams) (NotificationTarget, error)
+	// Marks the notification target as verified, and clears its verification code so that it cannot be reused.
+	UpdateNotificationTargetVerifiedAt(ctx context.Context, arg UpdateNotificationTargetVerifiedAtParams) (NotificationTarget, error)
 	UpdateNotificationTemplateMethodByID(ctx context.Context, arg UpdateNotificationTemplateMethodByIDParams) (NotificationTemplate, error)
 	UpdateOAuth2ProviderAppByClientID(ctx context.Context, arg UpdateOAuth2ProviderAppByClientIDParams) (OAuth2ProviderApp, error)
 	UpdateOAuth2ProviderAppByID(ctx context.Context, arg UpdateOAuth2ProviderAppByIDParams) (OAuth2ProviderApp, error)
@@ -781,6 +842,8 @@ type sqlcQuerier interface {
 	UpsertNotificationsSettings(ctx context.Context, value string) error
 	UpsertOAuth2GithubDefaultEligible(ctx context.Context, eligible bool) error
 	UpsertOAuthSigningKey(ctx context.Context, value string) error
+	UpsertOrganizationNotificationWebhook(ctx context.Context, arg UpsertOrganizationNotificationWebhookParams) (OrganizationNotificationWebhook, error)
+	UpsertPrebuildReadiness(ctx context.Context, arg UpsertPrebuildReadinessParams) error
 	UpsertPrebuildsSettings(ctx context.Context, value string) error
 	UpsertProvisionerDaemon(ctx context.Context, arg UpsertProvisionerDaemonParams) (ProvisionerDaemon, error)
 	UpsertRuntimeConfig(ctx context.Context, arg UpsertRuntimeConfigParams) error
@@ -790,6 +853,9 @@ type sqlcQuerier interface {
 	UpsertTaskSnapshot(ctx context.Context, arg UpsertTaskSnapshotParams) error
 	UpsertTaskWorkspaceApp(ctx context.Context, arg UpsertTaskWorkspaceAppParams) (TaskWorkspaceApp, error)
 	UpsertTelemetryItem(ctx context.Context, arg UpsertTelemetryItemParams) error
+	UpsertTemplateAdaptivePrebuilds(ctx context.Context, arg UpsertTemplateAdaptivePrebuildsParams) (TemplateAdaptivePrebuild, error)
+	UpsertTemplateGroupScheduleOverride(ctx context.Context, arg UpsertTemplateGroupScheduleOverrideParams) (TemplateGroupScheduleOverride, error)
+	UpsertTemplatePresetReadinessChecks(ctx context.Context, arg UpsertTemplatePresetReadinessChecksParams) (TemplatePresetReadinessCheck, error)
 	// This query aggregates the workspace_agent_stats and workspace_app_stats data
 	// into a single table for efficient storage and querying. Half-hour buckets are
 	// used to store the data, and the minutes are summed for each user and template
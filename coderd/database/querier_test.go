@@ -5053,6 +5053,10 @@ type createPrebuiltWorkspaceOpts struct {
 	createdAt      time.Time
 	readyAgents    int
 	notReadyAgents int
+	// failedReadiness marks the build as having failed its preset's readiness checks.
+	failedReadiness bool
+	// deleted deletes the workspace after its build, as is done to replace prebuilds that failed their readiness checks.
+	deleted bool
 }
 
 func createPrebuiltWorkspace(
@@ -5126,7 +5130,7 @@ func createPrebuiltWorkspace(
 	if opts != nil {
 		createdAt = opts.createdAt
 	}
-	dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+	build := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
 		CreatedAt:         createdAt,
 		WorkspaceID:       workspace.ID,
 		TemplateVersionID: extTmplVersion.ID,
@@ -5139,6 +5143,39 @@ func createPrebuiltWorkspace(
 			Valid: true,
 		},
 	})
+
+	if opts != nil && opts.failedReadiness {
+		err := db.UpsertPrebuildReadiness(ctx, database.UpsertPrebuildReadinessParams{
+			WorkspaceID:    workspace.ID,
+			BuildID:        build.ID,
+			Status:         database.PrebuildReadinessStatusFailed,
+			FailureReasons: []string{`script "Clone repository" failed with exit code 128: exit_failure`},
+			CheckedAt:      build.CreatedAt,
+		})
+		require.NoError(t, err)
+	}
+
+	if opts != nil && opts.deleted {
+		deleteJob := dbgen.ProvisionerJob(t, db, nil, database.ProvisionerJob{
+			Type:           database.ProvisionerJobTypeWorkspaceBuild,
+			OrganizationID: orgID,
+			CreatedAt:      build.CreatedAt.Add(time.Second),
+		})
+		dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			CreatedAt:         build.CreatedAt.Add(time.Second),
+			WorkspaceID:       workspace.ID,
+			TemplateVersionID: extTmplVersion.ID,
+			BuildNumber:       2,
+			Transition:        database.WorkspaceTransitionDelete,
+			InitiatorID:       tmpl.CreatedBy,
+			JobID:             deleteJob.ID,
+		})
+		err := db.UpdateWorkspaceDeletedByID(ctx, database.UpdateWorkspaceDeletedByIDParams{
+			ID:      workspace.ID,
+			Deleted: true,
+		})
+		require.NoError(t, err)
+	}
 }
 
 func TestWorkspacePrebuildsView(t *testing.T) {
@@ -5287,6 +5324,50 @@ func TestGetPresetsBackoff(t *testing.T) {
 		require.Equal(t, int32(1), backoff.NumFailed)
 	})
 
+	t.Run("Readiness Failures", func(t *testing.T) {
+		t.Parallel()
+
+		db, _ := dbtestutil.NewDB(t)
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dbgen.Organization(t, db, database.Organization{
+			ID: orgID,
+		})
+		dbgen.User(t, db, database.User{
+			ID: userID,
+		})
+
+		// A prebuild which was deleted because it failed its readiness checks, and its replacement which failed too.
+		tmpl := createTemplate(t, db, orgID, userID)
+		tmplV1 := createTmplVersionAndPreset(t, db, tmpl, tmpl.ActiveVersionID, now, nil)
+		createPrebuiltWorkspace(ctx, t, db, tmpl, tmplV1, orgID, now, &createPrebuiltWorkspaceOpts{
+			createdAt:       now.Add(-2 * time.Minute),
+			failedReadiness: true,
+			deleted:         true,
+		})
+		createPrebuiltWorkspace(ctx, t, db, tmpl, tmplV1, orgID, now, &createPrebuiltWorkspaceOpts{
+			createdAt:       now.Add(-time.Minute),
+			failedReadiness: true,
+		})
+
+		// Stale results are deleted, but failed results of the active version are kept.
+		err := db.DeleteStalePrebuildReadiness(ctx)
+		require.NoError(t, err)
+
+		backoffs, err := db.GetPresetsBackoff(ctx, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		require.Len(t, backoffs, 1)
+		backoff := backoffs[0]
+		require.Equal(t, backoff.TemplateVersionID, tmpl.ActiveVersionID)
+		require.Equal(t, backoff.PresetID, tmplV1.preset.ID)
+		require.Equal(t, int32(2), backoff.NumFailed)
+
+		hardLimitedPresets, err := db.GetPresetsAtFailureLimit(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, hardLimitedPresets, 1)
+		require.Equal(t, tmplV1.preset.ID, hardLimitedPresets[0].PresetID)
+	})
+
 	t.Run("Multiple Workspace Builds", func(t *testing.T) {
 		t.Parallel()
 
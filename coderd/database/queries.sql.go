@@ -2289,7 +2289,8 @@ SELECT
 	workspace_owner.username AS workspace_owner_username,
 	organizations.name AS organization_name,
 	organizations.display_name AS organization_display_name,
-	organizations.icon AS organization_icon
+	organizations.icon AS organization_icon,
+	workspace_session_recordings.id AS session_recording_id
 FROM
 	connection_logs
 JOIN users AS workspace_owner ON
@@ -2298,6 +2299,9 @@ LEFT JOIN users ON
 	connection_logs.user_id = users.id
 JOIN organizations ON
 	connection_logs.organization_id = organizations.id
+LEFT JOIN workspace_session_recordings ON
+	connection_logs.workspace_id = workspace_session_recordings.workspace_id
+	AND connection_logs.connection_id = workspace_session_recordings.connection_id
 WHERE
 	-- Filter organization_id
 	CASE
@@ -2332,7 +2336,7 @@ WHERE
 	-- Filter by type
 	AND CASE
 		WHEN $5 :: text != '' THEN
-			type = $5 :: connection_type
+			connection_logs.type = $5 :: connection_type
 		ELSE true
 	END
 	-- Filter by user_id
@@ -2386,7 +2390,7 @@ WHERE
 			(($13 = 'ongoing' AND disconnect_time IS NULL) OR
 			($13 = 'completed' AND disconnect_time IS NOT NULL)) AND
 			-- Exclude web events, since we don't know their close time.
-			"type" NOT IN ('workspace_app', 'port_forwarding')
+			connection_logs."type" NOT IN ('workspace_app', 'port_forwarding')
 		ELSE true
 	END
 	-- Authorize Filter clause will be injected below in
@@ -2439,6 +2443,7 @@ type GetConnectionLogsOffsetRow struct {
 	OrganizationName        string         `db:"organization_name" json:"organization_name"`
 	OrganizationDisplayName string         `db:"organization_display_name" json:"organization_display_name"`
 	OrganizationIcon        string         `db:"organization_icon" json:"organization_icon"`
+	SessionRecordingID      uuid.NullUUID  `db:"session_recording_id" json:"session_recording_id"`
 }
 
 func (q *sqlQuerier) GetConnectionLogsOffset(ctx context.Context, arg GetConnectionLogsOffsetParams) ([]GetConnectionLogsOffsetRow, error) {
@@ -2499,6 +2504,7 @@ func (q *sqlQuerier) GetConnectionLogsOffset(ctx context.Context, arg GetConnect
 			&i.OrganizationName,
 			&i.OrganizationDisplayName,
 			&i.OrganizationIcon,
+			&i.SessionRecordingID,
 		); err != nil {
 			return nil, err
 		}
@@ -5719,7 +5725,7 @@ WITH acquired AS (
                              FOR UPDATE OF nm
                                  SKIP LOCKED
                          LIMIT $4)
-            RETURNING id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash)
+            RETURNING id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash, notification_target_id)
 SELECT
     -- message
     nm.id,
@@ -5727,16 +5733,20 @@ SELECT
     nm.method,
     nm.attempt_count::int                                                 AS attempt_count,
     nm.queued_seconds::float                                              AS queued_seconds,
+    nm.notification_target_id,
     -- template
     nt.id                                                                 AS template_id,
     nt.title_template,
     nt.body_template,
     -- preferences
-    (CASE WHEN np.disabled IS NULL THEN false ELSE np.disabled END)::bool AS disabled
+    (CASE WHEN np.disabled IS NULL THEN false ELSE np.disabled END)::bool AS disabled,
+    -- target
+    COALESCE(ntg.address, '')::text                                       AS notification_target_address
 FROM acquired nm
          JOIN notification_templates nt ON nm.notification_template_id = nt.id
          LEFT JOIN notification_preferences AS np
                    ON (np.user_id = nm.user_id AND np.notification_template_id = nm.notification_template_id)
+         LEFT JOIN notification_targets AS ntg ON nm.notification_target_id = ntg.id
 `
 
 type AcquireNotificationMessagesParams struct {
@@ -5747,15 +5757,17 @@ type AcquireNotificationMessagesParams struct {
 }
 
 type AcquireNotificationMessagesRow struct {
-	ID            uuid.UUID          `db:"id" json:"id"`
-	Payload       json.RawMessage    `db:"payload" json:"payload"`
-	Method        NotificationMethod `db:"method" json:"method"`
-	AttemptCount  int32              `db:"attempt_count" json:"attempt_count"`
-	QueuedSeconds float64            `db:"queued_seconds" json:"queued_seconds"`
-	TemplateID    uuid.UUID          `db:"template_id" json:"template_id"`
-	TitleTemplate string             `db:"title_template" json:"title_template"`
-	BodyTemplate  string             `db:"body_template" json:"body_template"`
-	Disabled      bool               `db:"disabled" json:"disabled"`
+	ID                        uuid.UUID          `db:"id" json:"id"`
+	Payload                   json.RawMessage    `db:"payload" json:"payload"`
+	Method                    NotificationMethod `db:"method" json:"method"`
+	AttemptCount              int32              `db:"attempt_count" json:"attempt_count"`
+	QueuedSeconds             float64            `db:"queued_seconds" json:"queued_seconds"`
+	NotificationTargetID      uuid.NullUUID      `db:"notification_target_id" json:"notification_target_id"`
+	TemplateID                uuid.UUID          `db:"template_id" json:"template_id"`
+	TitleTemplate             string             `db:"title_template" json:"title_template"`
+	BodyTemplate              string             `db:"body_template" json:"body_template"`
+	Disabled                  bool               `db:"disabled" json:"disabled"`
+	NotificationTargetAddress string             `db:"notification_target_address" json:"notification_target_address"`
 }
 
 // Acquires the lease for a given count of notification messages, to enable concurrent dequeuing and subsequent sending.
@@ -5788,10 +5800,12 @@ func (q *sqlQuerier) AcquireNotificationMessages(ctx context.Context, arg Acquir
 			&i.Method,
 			&i.AttemptCount,
 			&i.QueuedSeconds,
+			&i.NotificationTargetID,
 			&i.TemplateID,
 			&i.TitleTemplate,
 			&i.BodyTemplate,
 			&i.Disabled,
+			&i.NotificationTargetAddress,
 		); err != nil {
 			return nil, err
 		}
@@ -5815,6 +5829,8 @@ SET queued_seconds   = 0,
                            WHEN attempt_count + 1 < $1::int THEN subquery.status
                            ELSE 'permanent_failure'::notification_message_status END,
     status_reason    = subquery.status_reason,
+    -- Zero is used for failures without an HTTP response, since arrays of parameters cannot contain NULLs.
+    response_status_code = NULLIF(subquery.response_status_code, 0),
     leased_until     = NULL,
     next_retry_after = CASE
                            WHEN (attempt_count + 1 < $1::int)
@@ -5822,17 +5838,19 @@ SET queued_seconds   = 0,
 FROM (SELECT UNNEST($3::uuid[])                             AS id,
              UNNEST($4::timestamptz[])               AS failed_at,
              UNNEST($5::notification_message_status[]) AS status,
-             UNNEST($6::text[])                  AS status_reason) AS subquery
+             UNNEST($6::text[])                  AS status_reason,
+             UNNEST($7::int[])            AS response_status_code) AS subquery
 WHERE notification_messages.id = subquery.id
 `
 
 type BulkMarkNotificationMessagesFailedParams struct {
-	MaxAttempts   int32                       `db:"max_attempts" json:"max_attempts"`
-	RetryInterval int32                       `db:"retry_interval" json:"retry_interval"`
-	IDs           []uuid.UUID                 `db:"ids" json:"ids"`
-	FailedAts     []time.Time                 `db:"failed_ats" json:"failed_ats"`
-	Statuses      []NotificationMessageStatus `db:"statuses" json:"statuses"`
-	StatusReasons []string                    `db:"status_reasons" json:"status_reasons"`
+	MaxAttempts         int32                       `db:"max_attempts" json:"max_attempts"`
+	RetryInterval       int32                       `db:"retry_interval" json:"retry_interval"`
+	IDs                 []uuid.UUID                 `db:"ids" json:"ids"`
+	FailedAts           []time.Time                 `db:"failed_ats" json:"failed_ats"`
+	Statuses            []NotificationMessageStatus `db:"statuses" json:"statuses"`
+	StatusReasons       []string                    `db:"status_reasons" json:"status_reasons"`
+	ResponseStatusCodes []int32                     `db:"response_status_codes" json:"response_status_codes"`
 }
 
 func (q *sqlQuerier) BulkMarkNotificationMessagesFailed(ctx context.Context, arg BulkMarkNotificationMessagesFailedParams) (int64, error) {
@@ -5843,6 +5861,7 @@ func (q *sqlQuerier) BulkMarkNotificationMessagesFailed(ctx context.Context, arg
 		pq.Array(arg.FailedAts),
 		pq.Array(arg.Statuses),
 		pq.Array(arg.StatusReasons),
+		pq.Array(arg.ResponseStatusCodes),
 	)
 	if err != nil {
 		return 0, err
@@ -5857,6 +5876,7 @@ SET queued_seconds   = 0,
     attempt_count    = attempt_count + 1,
     status           = 'sent'::notification_message_status,
     status_reason    = NULL,
+    response_status_code = NULL,
     leased_until     = NULL,
     next_retry_after = NULL
 FROM (SELECT UNNEST($1::uuid[])             AS id,
@@ -5891,6 +5911,17 @@ func (q *sqlQuerier) DeleteAllWebpushSubscriptions(ctx context.Context) error {
 	return err
 }
 
+const deleteNotificationTargetByID = `-- name: DeleteNotificationTargetByID :exec
+DELETE
+FROM notification_targets
+WHERE id = $1::uuid
+`
+
+func (q *sqlQuerier) DeleteNotificationTargetByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteNotificationTargetByID, id)
+	return err
+}
+
 const deleteOldNotificationMessages = `-- name: DeleteOldNotificationMessages :exec
 DELETE
 FROM notification_messages
@@ -5906,6 +5937,40 @@ func (q *sqlQuerier) DeleteOldNotificationMessages(ctx context.Context) error {
 	return err
 }
 
+const deleteOrganizationNotificationWebhook = `-- name: DeleteOrganizationNotificationWebhook :exec
+DELETE
+FROM organization_notification_webhooks
+WHERE organization_id = $1::uuid
+  AND method = $2::notification_method
+`
+
+type DeleteOrganizationNotificationWebhookParams struct {
+	OrganizationID uuid.UUID          `db:"organization_id" json:"organization_id"`
+	Method         NotificationMethod `db:"method" json:"method"`
+}
+
+func (q *sqlQuerier) DeleteOrganizationNotificationWebhook(ctx context.Context, arg DeleteOrganizationNotificationWebhookParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOrganizationNotificationWebhook, arg.OrganizationID, arg.Method)
+	return err
+}
+
+const deleteUserNotificationPreferenceTargets = `-- name: DeleteUserNotificationPreferenceTargets :exec
+DELETE
+FROM notification_preference_targets
+WHERE user_id = $1::uuid
+  AND notification_template_id = ANY ($2::uuid[])
+`
+
+type DeleteUserNotificationPreferenceTargetsParams struct {
+	UserID                  uuid.UUID   `db:"user_id" json:"user_id"`
+	NotificationTemplateIds []uuid.UUID `db:"notification_template_ids" json:"notification_template_ids"`
+}
+
+func (q *sqlQuerier) DeleteUserNotificationPreferenceTargets(ctx context.Context, arg DeleteUserNotificationPreferenceTargetsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserNotificationPreferenceTargets, arg.UserID, pq.Array(arg.NotificationTemplateIds))
+	return err
+}
+
 const deleteWebpushSubscriptionByUserIDAndEndpoint = `-- name: DeleteWebpushSubscriptionByUserIDAndEndpoint :exec
 DELETE FROM webpush_subscriptions
 WHERE user_id = $1 AND endpoint = $2
@@ -5932,7 +5997,7 @@ func (q *sqlQuerier) DeleteWebpushSubscriptions(ctx context.Context, ids []uuid.
 }
 
 const enqueueNotificationMessage = `-- name: EnqueueNotificationMessage :exec
-INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at)
+INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at, notification_target_id)
 VALUES ($1,
         $2,
         $3,
@@ -5940,7 +6005,8 @@ VALUES ($1,
         $5::jsonb,
         $6,
         $7,
-        $8)
+        $8,
+        $9)
 `
 
 type EnqueueNotificationMessageParams struct {
@@ -5952,6 +6018,7 @@ type EnqueueNotificationMessageParams struct {
 	Targets                []uuid.UUID        `db:"targets" json:"targets"`
 	CreatedBy              string             `db:"created_by" json:"created_by"`
 	CreatedAt              time.Time          `db:"created_at" json:"created_at"`
+	NotificationTargetID   uuid.NullUUID      `db:"notification_target_id" json:"notification_target_id"`
 }
 
 func (q *sqlQuerier) EnqueueNotificationMessage(ctx context.Context, arg EnqueueNotificationMessageParams) error {
@@ -5964,6 +6031,7 @@ func (q *sqlQuerier) EnqueueNotificationMessage(ctx context.Context, arg Enqueue
 		pq.Array(arg.Targets),
 		arg.CreatedBy,
 		arg.CreatedAt,
+		arg.NotificationTargetID,
 	)
 	return err
 }
@@ -6016,8 +6084,89 @@ func (q *sqlQuerier) FetchNewMessageMetadata(ctx context.Context, arg FetchNewMe
 	return i, err
 }
 
+const getNotificationMessageDeliveries = `-- name: GetNotificationMessageDeliveries :many
+SELECT nm.id,
+       nm.notification_template_id,
+       nt.name AS notification_template_name,
+       nm.user_id,
+       nm.method,
+       nm.notification_target_id,
+       nm.status,
+       nm.status_reason,
+       nm.response_status_code,
+       nm.attempt_count,
+       nm.created_at,
+       nm.updated_at,
+       nm.next_retry_after
+FROM notification_messages AS nm
+         JOIN notification_templates AS nt ON nt.id = nm.notification_template_id
+WHERE ($1::text = '' OR nm.method::text = $1::text)
+ORDER BY nm.created_at DESC, nm.id
+LIMIT $2::int
+`
+
+type GetNotificationMessageDeliveriesParams struct {
+	Method string `db:"method" json:"method"`
+	Limit  int32  `db:"limit" json:"limit"`
+}
+
+type GetNotificationMessageDeliveriesRow struct {
+	ID                       uuid.UUID                 `db:"id" json:"id"`
+	NotificationTemplateID   uuid.UUID                 `db:"notification_template_id" json:"notification_template_id"`
+	NotificationTemplateName string                    `db:"notification_template_name" json:"notification_template_name"`
+	UserID                   uuid.UUID                 `db:"user_id" json:"user_id"`
+	Method                   NotificationMethod        `db:"method" json:"method"`
+	NotificationTargetID     uuid.NullUUID             `db:"notification_target_id" json:"notification_target_id"`
+	Status                   NotificationMessageStatus `db:"status" json:"status"`
+	StatusReason             sql.NullString            `db:"status_reason" json:"status_reason"`
+	ResponseStatusCode       sql.NullInt32             `db:"response_status_code" json:"response_status_code"`
+	AttemptCount             sql.NullInt32             `db:"attempt_count" json:"attempt_count"`
+	CreatedAt                time.Time                 `db:"created_at" json:"created_at"`
+	UpdatedAt                sql.NullTime              `db:"updated_at" json:"updated_at"`
+	NextRetryAfter           sql.NullTime              `db:"next_retry_after" json:"next_retry_after"`
+}
+
+// Returns the most recently enqueued notification messages, optionally only those of the given method, along with the
+// outcome of their last delivery attempt.
+func (q *sqlQuerier) GetNotificationMessageDeliveries(ctx context.Context, arg GetNotificationMessageDeliveriesParams) ([]GetNotificationMessageDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationMessageDeliveries, arg.Method, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationMessageDeliveriesRow
+	for rows.Next() {
+		var i GetNotificationMessageDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.NotificationTemplateID,
+			&i.NotificationTemplateName,
+			&i.UserID,
+			&i.Method,
+			&i.NotificationTargetID,
+			&i.Status,
+			&i.StatusReason,
+			&i.ResponseStatusCode,
+			&i.AttemptCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.NextRetryAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationMessagesByStatus = `-- name: GetNotificationMessagesByStatus :many
-SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash
+SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash, notification_target_id, response_status_code
 FROM notification_messages
 WHERE status = $1
 LIMIT $2::int
@@ -6054,6 +6203,8 @@ func (q *sqlQuerier) GetNotificationMessagesByStatus(ctx context.Context, arg Ge
 			&i.NextRetryAfter,
 			&i.QueuedSeconds,
 			&i.DedupeHash,
+			&i.NotificationTargetID,
+			&i.ResponseStatusCode,
 		); err != nil {
 			return nil, err
 		}
@@ -6085,6 +6236,71 @@ func (q *sqlQuerier) GetNotificationReportGeneratorLogByTemplate(ctx context.Con
 	return i, err
 }
 
+const getNotificationTargetByID = `-- name: GetNotificationTargetByID :one
+SELECT id, user_id, name, method, address, hashed_verification_code, verification_code_expires_at, verified_at, created_at, updated_at
+FROM notification_targets
+WHERE id = $1::uuid
+`
+
+func (q *sqlQuerier) GetNotificationTargetByID(ctx context.Context, id uuid.UUID) (NotificationTarget, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationTargetByID, id)
+	var i NotificationTarget
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Method,
+		&i.Address,
+		&i.HashedVerificationCode,
+		&i.VerificationCodeExpiresAt,
+		&i.VerifiedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getNotificationTargetsByUserID = `-- name: GetNotificationTargetsByUserID :many
+SELECT id, user_id, name, method, address, hashed_verification_code, verification_code_expires_at, verified_at, created_at, updated_at
+FROM notification_targets
+WHERE user_id = $1::uuid
+ORDER BY name ASC
+`
+
+func (q *sqlQuerier) GetNotificationTargetsByUserID(ctx context.Context, userID uuid.UUID) ([]NotificationTarget, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationTargetsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationTarget
+	for rows.Next() {
+		var i NotificationTarget
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Method,
+			&i.Address,
+			&i.HashedVerificationCode,
+			&i.VerificationCodeExpiresAt,
+			&i.VerifiedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationTemplateByID = `-- name: GetNotificationTemplateByID :one
 SELECT id, name, title_template, body_template, actions, "group", method, kind, enabled_by_default
 FROM notification_templates
@@ -6148,6 +6364,121 @@ func (q *sqlQuerier) GetNotificationTemplatesByKind(ctx context.Context, kind No
 	return items, nil
 }
 
+const getOrganizationNotificationWebhooks = `-- name: GetOrganizationNotificationWebhooks :many
+SELECT organization_id, method, endpoint, created_at, updated_at
+FROM organization_notification_webhooks
+WHERE organization_id = $1::uuid
+ORDER BY method ASC
+`
+
+func (q *sqlQuerier) GetOrganizationNotificationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]OrganizationNotificationWebhook, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizationNotificationWebhooks, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrganizationNotificationWebhook
+	for rows.Next() {
+		var i OrganizationNotificationWebhook
+		if err := rows.Scan(
+			&i.OrganizationID,
+			&i.Method,
+			&i.Endpoint,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationNotificationWebhooksByUserID = `-- name: GetOrganizationNotificationWebhooksByUserID :many
+SELECT organization_notification_webhooks.organization_id, organization_notification_webhooks.method, organization_notification_webhooks.endpoint, organization_notification_webhooks.created_at, organization_notification_webhooks.updated_at
+FROM organization_notification_webhooks
+         JOIN organization_members AS om ON om.organization_id = organization_notification_webhooks.organization_id
+WHERE om.user_id = $1::uuid
+  AND organization_notification_webhooks.method = $2::notification_method
+ORDER BY organization_notification_webhooks.organization_id ASC
+`
+
+type GetOrganizationNotificationWebhooksByUserIDParams struct {
+	UserID uuid.UUID          `db:"user_id" json:"user_id"`
+	Method NotificationMethod `db:"method" json:"method"`
+}
+
+// Returns the incoming webhooks of the given method configured by the organizations the user is a member of.
+func (q *sqlQuerier) GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg GetOrganizationNotificationWebhooksByUserIDParams) ([]OrganizationNotificationWebhook, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizationNotificationWebhooksByUserID, arg.UserID, arg.Method)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrganizationNotificationWebhook
+	for rows.Next() {
+		var i OrganizationNotificationWebhook
+		if err := rows.Scan(
+			&i.OrganizationID,
+			&i.Method,
+			&i.Endpoint,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNotificationPreferenceTargets = `-- name: GetUserNotificationPreferenceTargets :many
+SELECT user_id, notification_template_id, notification_target_id, created_at
+FROM notification_preference_targets
+WHERE user_id = $1::uuid
+ORDER BY notification_template_id, created_at
+`
+
+func (q *sqlQuerier) GetUserNotificationPreferenceTargets(ctx context.Context, userID uuid.UUID) ([]NotificationPreferenceTarget, error) {
+	rows, err := q.db.QueryContext(ctx, getUserNotificationPreferenceTargets, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationPreferenceTarget
+	for rows.Next() {
+		var i NotificationPreferenceTarget
+		if err := rows.Scan(
+			&i.UserID,
+			&i.NotificationTemplateID,
+			&i.NotificationTargetID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserNotificationPreferences = `-- name: GetUserNotificationPreferences :many
 SELECT user_id, notification_template_id, disabled, created_at, updated_at
 FROM notification_preferences
@@ -6183,6 +6514,56 @@ func (q *sqlQuerier) GetUserNotificationPreferences(ctx context.Context, userID
 	return items, nil
 }
 
+const getVerifiedNotificationTargetsByTemplateID = `-- name: GetVerifiedNotificationTargetsByTemplateID :many
+SELECT notification_targets.id, notification_targets.user_id, notification_targets.name, notification_targets.method, notification_targets.address, notification_targets.hashed_verification_code, notification_targets.verification_code_expires_at, notification_targets.verified_at, notification_targets.created_at, notification_targets.updated_at
+FROM notification_targets
+         JOIN notification_preference_targets AS npt ON npt.notification_target_id = notification_targets.id
+WHERE npt.user_id = $1::uuid
+  AND npt.notification_template_id = $2::uuid
+  AND notification_targets.verified_at IS NOT NULL
+ORDER BY notification_targets.name ASC
+`
+
+type GetVerifiedNotificationTargetsByTemplateIDParams struct {
+	UserID                 uuid.UUID `db:"user_id" json:"user_id"`
+	NotificationTemplateID uuid.UUID `db:"notification_template_id" json:"notification_template_id"`
+}
+
+// Returns the verified notification targets to which the user routes notifications of the given template.
+func (q *sqlQuerier) GetVerifiedNotificationTargetsByTemplateID(ctx context.Context, arg GetVerifiedNotificationTargetsByTemplateIDParams) ([]NotificationTarget, error) {
+	rows, err := q.db.QueryContext(ctx, getVerifiedNotificationTargetsByTemplateID, arg.UserID, arg.NotificationTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationTarget
+	for rows.Next() {
+		var i NotificationTarget
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Method,
+			&i.Address,
+			&i.HashedVerificationCode,
+			&i.VerificationCodeExpiresAt,
+			&i.VerifiedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWebpushSubscriptionsByUserID = `-- name: GetWebpushSubscriptionsByUserID :many
 SELECT id, user_id, created_at, endpoint, endpoint_p256dh_key, endpoint_auth_key
 FROM webpush_subscriptions
@@ -6219,46 +6600,187 @@ func (q *sqlQuerier) GetWebpushSubscriptionsByUserID(ctx context.Context, userID
 	return items, nil
 }
 
-const insertWebpushSubscription = `-- name: InsertWebpushSubscription :one
-INSERT INTO webpush_subscriptions (user_id, created_at, endpoint, endpoint_p256dh_key, endpoint_auth_key)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, created_at, endpoint, endpoint_p256dh_key, endpoint_auth_key
+const insertNotificationTarget = `-- name: InsertNotificationTarget :one
+INSERT INTO notification_targets (id, user_id, name, method, address, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, name, method, address, hashed_verification_code, verification_code_expires_at, verified_at, created_at, updated_at
 `
 
-type InsertWebpushSubscriptionParams struct {
-	UserID            uuid.UUID `db:"user_id" json:"user_id"`
-	CreatedAt         time.Time `db:"created_at" json:"created_at"`
-	Endpoint          string    `db:"endpoint" json:"endpoint"`
-	EndpointP256dhKey string    `db:"endpoint_p256dh_key" json:"endpoint_p256dh_key"`
-	EndpointAuthKey   string    `db:"endpoint_auth_key" json:"endpoint_auth_key"`
+type InsertNotificationTargetParams struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	Name      string             `db:"name" json:"name"`
+	Method    NotificationMethod `db:"method" json:"method"`
+	Address   string             `db:"address" json:"address"`
+	CreatedAt time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `db:"updated_at" json:"updated_at"`
 }
 
-func (q *sqlQuerier) InsertWebpushSubscription(ctx context.Context, arg InsertWebpushSubscriptionParams) (WebpushSubscription, error) {
-	row := q.db.QueryRowContext(ctx, insertWebpushSubscription,
+func (q *sqlQuerier) InsertNotificationTarget(ctx context.Context, arg InsertNotificationTargetParams) (NotificationTarget, error) {
+	row := q.db.QueryRowContext(ctx, insertNotificationTarget,
+		arg.ID,
 		arg.UserID,
+		arg.Name,
+		arg.Method,
+		arg.Address,
 		arg.CreatedAt,
-		arg.Endpoint,
-		arg.EndpointP256dhKey,
-		arg.EndpointAuthKey,
+		arg.UpdatedAt,
 	)
-	var i WebpushSubscription
+	var i NotificationTarget
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
+		&i.Name,
+		&i.Method,
+		&i.Address,
+		&i.HashedVerificationCode,
+		&i.VerificationCodeExpiresAt,
+		&i.VerifiedAt,
 		&i.CreatedAt,
-		&i.Endpoint,
-		&i.EndpointP256dhKey,
-		&i.EndpointAuthKey,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const updateNotificationTemplateMethodByID = `-- name: UpdateNotificationTemplateMethodByID :one
-UPDATE notification_templates
-SET method = $1::notification_method
-WHERE id = $2::uuid
-RETURNING id, name, title_template, body_template, actions, "group", method, kind, enabled_by_default
-`
+const insertUserNotificationPreferenceTargets = `-- name: InsertUserNotificationPreferenceTargets :execrows
+INSERT
+INTO notification_preference_targets (user_id, notification_template_id, notification_target_id)
+SELECT $1::uuid, new_values.notification_template_id, new_values.notification_target_id
+FROM (SELECT UNNEST($2::uuid[]) AS notification_template_id,
+             UNNEST($3::uuid[])   AS notification_target_id) AS new_values
+         JOIN notification_targets ON notification_targets.id = new_values.notification_target_id
+WHERE notification_targets.user_id = $1::uuid
+ON CONFLICT DO NOTHING
+`
+
+type InsertUserNotificationPreferenceTargetsParams struct {
+	UserID                  uuid.UUID   `db:"user_id" json:"user_id"`
+	NotificationTemplateIds []uuid.UUID `db:"notification_template_ids" json:"notification_template_ids"`
+	NotificationTargetIds   []uuid.UUID `db:"notification_target_ids" json:"notification_target_ids"`
+}
+
+// Routes notifications of the given templates to the given targets. Targets which do not belong to the user are ignored.
+func (q *sqlQuerier) InsertUserNotificationPreferenceTargets(ctx context.Context, arg InsertUserNotificationPreferenceTargetsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertUserNotificationPreferenceTargets, arg.UserID, pq.Array(arg.NotificationTemplateIds), pq.Array(arg.NotificationTargetIds))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertWebpushSubscription = `-- name: InsertWebpushSubscription :one
+INSERT INTO webpush_subscriptions (user_id, created_at, endpoint, endpoint_p256dh_key, endpoint_auth_key)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, created_at, endpoint, endpoint_p256dh_key, endpoint_auth_key
+`
+
+type InsertWebpushSubscriptionParams struct {
+	UserID            uuid.UUID `db:"user_id" json:"user_id"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+	Endpoint          string    `db:"endpoint" json:"endpoint"`
+	EndpointP256dhKey string    `db:"endpoint_p256dh_key" json:"endpoint_p256dh_key"`
+	EndpointAuthKey   string    `db:"endpoint_auth_key" json:"endpoint_auth_key"`
+}
+
+func (q *sqlQuerier) InsertWebpushSubscription(ctx context.Context, arg InsertWebpushSubscriptionParams) (WebpushSubscription, error) {
+	row := q.db.QueryRowContext(ctx, insertWebpushSubscription,
+		arg.UserID,
+		arg.CreatedAt,
+		arg.Endpoint,
+		arg.EndpointP256dhKey,
+		arg.EndpointAuthKey,
+	)
+	var i WebpushSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.Endpoint,
+		&i.EndpointP256dhKey,
+		&i.EndpointAuthKey,
+	)
+	return i, err
+}
+
+const updateNotificationTargetVerificationCode = `-- name: UpdateNotificationTargetVerificationCode :one
+UPDATE notification_targets
+SET hashed_verification_code     = $1,
+    verification_code_expires_at = $2::timestamptz,
+    updated_at                   = $3
+WHERE id = $4::uuid
+RETURNING id, user_id, name, method, address, hashed_verification_code, verification_code_expires_at, verified_at, created_at, updated_at
+`
+
+type UpdateNotificationTargetVerificationCodeParams struct {
+	HashedVerificationCode    []byte    `db:"hashed_verification_code" json:"hashed_verification_code"`
+	VerificationCodeExpiresAt time.Time `db:"verification_code_expires_at" json:"verification_code_expires_at"`
+	UpdatedAt                 time.Time `db:"updated_at" json:"updated_at"`
+	ID                        uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateNotificationTargetVerificationCode(ctx context.Context, arg UpdateNotificationTargetVerificationCodeParams) (NotificationTarget, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationTargetVerificationCode,
+		arg.HashedVerificationCode,
+		arg.VerificationCodeExpiresAt,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	var i NotificationTarget
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Method,
+		&i.Address,
+		&i.HashedVerificationCode,
+		&i.VerificationCodeExpiresAt,
+		&i.VerifiedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateNotificationTargetVerifiedAt = `-- name: UpdateNotificationTargetVerifiedAt :one
+UPDATE notification_targets
+SET verified_at                  = $1::timestamptz,
+    hashed_verification_code     = NULL,
+    verification_code_expires_at = NULL,
+    updated_at                   = $1::timestamptz
+WHERE id = $2::uuid
+RETURNING id, user_id, name, method, address, hashed_verification_code, verification_code_expires_at, verified_at, created_at, updated_at
+`
+
+type UpdateNotificationTargetVerifiedAtParams struct {
+	VerifiedAt time.Time `db:"verified_at" json:"verified_at"`
+	ID         uuid.UUID `db:"id" json:"id"`
+}
+
+// Marks the notification target as verified, and clears its verification code so that it cannot be reused.
+func (q *sqlQuerier) UpdateNotificationTargetVerifiedAt(ctx context.Context, arg UpdateNotificationTargetVerifiedAtParams) (NotificationTarget, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationTargetVerifiedAt, arg.VerifiedAt, arg.ID)
+	var i NotificationTarget
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Method,
+		&i.Address,
+		&i.HashedVerificationCode,
+		&i.VerificationCodeExpiresAt,
+		&i.VerifiedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateNotificationTemplateMethodByID = `-- name: UpdateNotificationTemplateMethodByID :one
+UPDATE notification_templates
+SET method = $1::notification_method
+WHERE id = $2::uuid
+RETURNING id, name, title_template, body_template, actions, "group", method, kind, enabled_by_default
+`
 
 type UpdateNotificationTemplateMethodByIDParams struct {
 	Method NullNotificationMethod `db:"method" json:"method"`
@@ -6324,6 +6846,42 @@ func (q *sqlQuerier) UpsertNotificationReportGeneratorLog(ctx context.Context, a
 	return err
 }
 
+const upsertOrganizationNotificationWebhook = `-- name: UpsertOrganizationNotificationWebhook :one
+INSERT INTO organization_notification_webhooks (organization_id, method, endpoint, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (organization_id, method) DO UPDATE
+    SET endpoint   = EXCLUDED.endpoint,
+        updated_at = EXCLUDED.updated_at
+RETURNING organization_id, method, endpoint, created_at, updated_at
+`
+
+type UpsertOrganizationNotificationWebhookParams struct {
+	OrganizationID uuid.UUID          `db:"organization_id" json:"organization_id"`
+	Method         NotificationMethod `db:"method" json:"method"`
+	Endpoint       string             `db:"endpoint" json:"endpoint"`
+	CreatedAt      time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpsertOrganizationNotificationWebhook(ctx context.Context, arg UpsertOrganizationNotificationWebhookParams) (OrganizationNotificationWebhook, error) {
+	row := q.db.QueryRowContext(ctx, upsertOrganizationNotificationWebhook,
+		arg.OrganizationID,
+		arg.Method,
+		arg.Endpoint,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i OrganizationNotificationWebhook
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.Method,
+		&i.Endpoint,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const countUnreadInboxNotificationsByUserID = `-- name: CountUnreadInboxNotificationsByUserID :one
 SELECT COUNT(*) FROM inbox_notifications WHERE user_id = $1 AND read_at IS NULL
 `
@@ -8432,6 +8990,23 @@ WHERE w.id IN (
 		AND b.template_version_id = t.active_version_id
 		AND p.current_preset_id = $7::uuid
 		AND p.ready
+		-- Prebuilds of presets with readiness checks can only be claimed once their latest build passed the checks.
+		AND (
+			NOT EXISTS (
+				SELECT 1
+				FROM template_preset_readiness_checks rc
+					INNER JOIN template_version_presets tvp ON tvp.name = rc.preset_name
+				WHERE rc.template_id = t.id
+					AND tvp.id = p.current_preset_id
+			)
+			OR EXISTS (
+				SELECT 1
+				FROM workspace_prebuild_readiness r
+				WHERE r.workspace_id = p.id
+					AND r.build_id = b.id
+					AND r.status = 'passed'::prebuild_readiness_status
+			)
+		)
 		AND NOT t.deleted
 	LIMIT 1 FOR UPDATE OF p SKIP LOCKED -- Ensure that a concurrent request will not select the same prebuild.
 )
@@ -8575,6 +9150,68 @@ func (q *sqlQuerier) CountPendingNonActivePrebuilds(ctx context.Context) ([]Coun
 	return items, nil
 }
 
+const deleteStalePrebuildReadiness = `-- name: DeleteStalePrebuildReadiness :exec
+DELETE FROM
+	workspace_prebuild_readiness wpr
+USING
+	workspaces w
+WHERE
+	w.id = wpr.workspace_id
+	AND (
+		w.owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::uuid
+		OR (
+			w.deleted
+			-- Failed results of deleted prebuilds are kept while they count towards the backoff and
+			-- failure limit of their preset, which only consider the template's active version.
+			AND (
+				wpr.status != 'failed'::prebuild_readiness_status
+				OR NOT EXISTS (
+					SELECT 1 FROM workspace_builds wb
+					INNER JOIN templates t ON t.active_version_id = wb.template_version_id
+					WHERE wb.id = wpr.build_id
+				)
+			)
+		)
+	)
+`
+
+// DeleteStalePrebuildReadiness deletes the readiness check results of workspaces
+// that are no longer prebuilt workspaces, because they were claimed or deleted.
+// Failed results of deleted prebuilds are kept while their template version is active.
+func (q *sqlQuerier) DeleteStalePrebuildReadiness(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteStalePrebuildReadiness)
+	return err
+}
+
+const deleteTemplatePresetReadinessChecks = `-- name: DeleteTemplatePresetReadinessChecks :one
+DELETE FROM
+	template_preset_readiness_checks
+WHERE
+	template_id = $1
+	AND preset_name = $2
+RETURNING
+	template_id, preset_name, checks, timeout, created_at, updated_at
+`
+
+type DeleteTemplatePresetReadinessChecksParams struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	PresetName string    `db:"preset_name" json:"preset_name"`
+}
+
+func (q *sqlQuerier) DeleteTemplatePresetReadinessChecks(ctx context.Context, arg DeleteTemplatePresetReadinessChecksParams) (TemplatePresetReadinessCheck, error) {
+	row := q.db.QueryRowContext(ctx, deleteTemplatePresetReadinessChecks, arg.TemplateID, arg.PresetName)
+	var i TemplatePresetReadinessCheck
+	err := row.Scan(
+		&i.TemplateID,
+		&i.PresetName,
+		&i.Checks,
+		&i.Timeout,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const findMatchingPresetID = `-- name: FindMatchingPresetID :one
 WITH provided_params AS (
 	SELECT
@@ -8616,6 +9253,46 @@ func (q *sqlQuerier) FindMatchingPresetID(ctx context.Context, arg FindMatchingP
 	return template_version_preset_id, err
 }
 
+const getEnabledTemplateAdaptivePrebuilds = `-- name: GetEnabledTemplateAdaptivePrebuilds :many
+SELECT
+	template_id, enabled, min_instances, max_instances, lookback_weeks, created_at, updated_at
+FROM
+	template_adaptive_prebuilds
+WHERE
+	enabled
+`
+
+func (q *sqlQuerier) GetEnabledTemplateAdaptivePrebuilds(ctx context.Context) ([]TemplateAdaptivePrebuild, error) {
+	rows, err := q.db.QueryContext(ctx, getEnabledTemplateAdaptivePrebuilds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateAdaptivePrebuild
+	for rows.Next() {
+		var i TemplateAdaptivePrebuild
+		if err := rows.Scan(
+			&i.TemplateID,
+			&i.Enabled,
+			&i.MinInstances,
+			&i.MaxInstances,
+			&i.LookbackWeeks,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOrganizationsWithPrebuildStatus = `-- name: GetOrganizationsWithPrebuildStatus :many
 WITH orgs_with_prebuilds AS (
 	-- Get unique organizations that have presets with prebuilds configured
@@ -8703,6 +9380,66 @@ func (q *sqlQuerier) GetOrganizationsWithPrebuildStatus(ctx context.Context, arg
 	return items, nil
 }
 
+const getPrebuildClaimHistory = `-- name: GetPrebuildClaimHistory :many
+SELECT
+	w.template_id,
+	tvp.name AS preset_name,
+	claims.created_at AS claimed_at
+FROM workspace_prebuild_builds wpb
+INNER JOIN workspaces w ON w.id = wpb.workspace_id
+INNER JOIN template_version_presets tvp ON tvp.id = wpb.template_version_preset_id
+INNER JOIN LATERAL (
+	SELECT wb.created_at
+	FROM workspace_builds wb
+	WHERE wb.workspace_id = wpb.workspace_id
+		AND wb.initiator_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::uuid -- The system user responsible for prebuilds.
+	ORDER BY wb.build_number ASC
+	LIMIT 1
+) claims ON TRUE
+WHERE wpb.build_number = 1
+	AND w.template_id = ANY($1::uuid[])
+	AND claims.created_at >= $2::timestamptz
+ORDER BY claims.created_at ASC
+`
+
+type GetPrebuildClaimHistoryParams struct {
+	TemplateIDs []uuid.UUID `db:"template_ids" json:"template_ids"`
+	Since       time.Time   `db:"since" json:"since"`
+}
+
+type GetPrebuildClaimHistoryRow struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	PresetName string    `db:"preset_name" json:"preset_name"`
+	ClaimedAt  time.Time `db:"claimed_at" json:"claimed_at"`
+}
+
+// GetPrebuildClaimHistory returns when prebuilt workspaces of the given templates were claimed,
+// along with the name of the preset they were built from. Presets are matched by name because
+// each template version has its own presets.
+// A prebuilt workspace is claimed by the first build that was not initiated by the prebuilds system user.
+func (q *sqlQuerier) GetPrebuildClaimHistory(ctx context.Context, arg GetPrebuildClaimHistoryParams) ([]GetPrebuildClaimHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPrebuildClaimHistory, pq.Array(arg.TemplateIDs), arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPrebuildClaimHistoryRow
+	for rows.Next() {
+		var i GetPrebuildClaimHistoryRow
+		if err := rows.Scan(&i.TemplateID, &i.PresetName, &i.ClaimedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPrebuildMetrics = `-- name: GetPrebuildMetrics :many
 SELECT
 	t.name as template_name,
@@ -8763,18 +9500,81 @@ func (q *sqlQuerier) GetPrebuildMetrics(ctx context.Context) ([]GetPrebuildMetri
 	return items, nil
 }
 
+const getPrebuildReadiness = `-- name: GetPrebuildReadiness :many
+SELECT
+	wpr.workspace_id, wpr.build_id, wpr.status, wpr.failure_reasons, wpr.checked_at
+FROM
+	workspace_prebuild_readiness wpr
+	INNER JOIN workspace_latest_builds wlb ON wlb.workspace_id = wpr.workspace_id AND wlb.id = wpr.build_id
+	INNER JOIN workspaces w ON w.id = wpr.workspace_id
+WHERE
+	w.owner_id = 'c42fdf75-3097-471c-8c33-fb52454d81c0'::uuid -- The system user responsible for prebuilds.
+	AND NOT w.deleted
+	AND ($1::uuid IS NULL OR w.template_id = $1::uuid)
+`
+
+// GetPrebuildReadiness returns the readiness check results of prebuilt workspaces.
+// Results of earlier builds are stale and therefore omitted.
+// If template_id is specified, only the results of that template's prebuilt workspaces are returned.
+func (q *sqlQuerier) GetPrebuildReadiness(ctx context.Context, templateID uuid.NullUUID) ([]WorkspacePrebuildReadiness, error) {
+	rows, err := q.db.QueryContext(ctx, getPrebuildReadiness, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspacePrebuildReadiness
+	for rows.Next() {
+		var i WorkspacePrebuildReadiness
+		if err := rows.Scan(
+			&i.WorkspaceID,
+			&i.BuildID,
+			&i.Status,
+			pq.Array(&i.FailureReasons),
+			&i.CheckedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPresetsAtFailureLimit = `-- name: GetPresetsAtFailureLimit :many
 WITH filtered_builds AS (
-	-- Only select builds which are for prebuild creations
-	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id, wlb.job_status, tvp.desired_instances
+	-- Only select builds which are for prebuild creations.
+	-- Prebuilds which failed their preset's readiness checks count as failed builds.
+	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id,
+		CASE WHEN wpr.status = 'failed'::prebuild_readiness_status THEN 'failed'::provisioner_job_status ELSE wlb.job_status END AS job_status,
+		tvp.desired_instances
 	FROM template_version_presets tvp
 			INNER JOIN workspace_latest_builds wlb ON wlb.template_version_preset_id = tvp.id
 			INNER JOIN workspaces w ON wlb.workspace_id = w.id
 			INNER JOIN template_versions tv ON wlb.template_version_id = tv.id
 			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+			LEFT JOIN workspace_prebuild_readiness wpr ON wpr.build_id = wlb.id
 	WHERE tvp.desired_instances IS NOT NULL -- Consider only presets that have a prebuild configuration.
 		AND wlb.transition = 'start'::workspace_transition
 		AND w.owner_id = 'c42fdf75-3097-471c-8c33-fb52454d81c0'
+	UNION ALL
+	-- As do the builds of prebuilds which have since been deleted because they failed the checks.
+	SELECT wb.template_version_id, wb.created_at, tvp.id AS preset_id, 'failed'::provisioner_job_status AS job_status, tvp.desired_instances
+	FROM workspace_prebuild_readiness wpr
+			INNER JOIN workspace_builds wb ON wb.id = wpr.build_id
+			INNER JOIN template_version_presets tvp ON wb.template_version_preset_id = tvp.id
+			INNER JOIN template_versions tv ON wb.template_version_id = tv.id
+			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+	WHERE tvp.desired_instances IS NOT NULL
+		AND wpr.status = 'failed'::prebuild_readiness_status
+		AND EXISTS (
+			SELECT 1 FROM workspace_builds newer
+			WHERE newer.workspace_id = wb.workspace_id AND newer.build_number > wb.build_number
+		)
 ),
 time_sorted_builds AS (
 	-- Group builds by preset, then sort each group by created_at.
@@ -8801,6 +9601,7 @@ type GetPresetsAtFailureLimitRow struct {
 // Each preset is associated with exactly one template version ID.
 // For each preset, the query checks the last hard_limit builds.
 // If all of them failed, the preset is considered to have hit the hard failure limit.
+// Builds of prebuilds which failed their preset's readiness checks count as failed builds.
 // The query returns a list of preset IDs that have reached this failure threshold.
 // Only active template versions with configured presets are considered.
 // For each preset, check the last hard_limit builds.
@@ -8830,17 +9631,36 @@ func (q *sqlQuerier) GetPresetsAtFailureLimit(ctx context.Context, hardLimit int
 
 const getPresetsBackoff = `-- name: GetPresetsBackoff :many
 WITH filtered_builds AS (
-	-- Only select builds which are for prebuild creations
-	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id, wlb.job_status, tvp.desired_instances
+	-- Only select builds which are for prebuild creations.
+	-- Prebuilds which failed their preset's readiness checks count as failed builds.
+	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id,
+		CASE WHEN wpr.status = 'failed'::prebuild_readiness_status THEN 'failed'::provisioner_job_status ELSE wlb.job_status END AS job_status,
+		tvp.desired_instances
 	FROM template_version_presets tvp
 			INNER JOIN workspace_latest_builds wlb ON wlb.template_version_preset_id = tvp.id
 			INNER JOIN workspaces w ON wlb.workspace_id = w.id
 			INNER JOIN template_versions tv ON wlb.template_version_id = tv.id
 			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+			LEFT JOIN workspace_prebuild_readiness wpr ON wpr.build_id = wlb.id
 	WHERE tvp.desired_instances IS NOT NULL -- Consider only presets that have a prebuild configuration.
 		AND wlb.transition = 'start'::workspace_transition
 		AND w.owner_id = 'c42fdf75-3097-471c-8c33-fb52454d81c0'
 		AND NOT t.deleted
+	UNION ALL
+	-- As do the builds of prebuilds which have since been deleted because they failed the checks.
+	SELECT wb.template_version_id, wb.created_at, tvp.id AS preset_id, 'failed'::provisioner_job_status AS job_status, tvp.desired_instances
+	FROM workspace_prebuild_readiness wpr
+			INNER JOIN workspace_builds wb ON wb.id = wpr.build_id
+			INNER JOIN template_version_presets tvp ON wb.template_version_preset_id = tvp.id
+			INNER JOIN template_versions tv ON wb.template_version_id = tv.id
+			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+	WHERE tvp.desired_instances IS NOT NULL
+		AND wpr.status = 'failed'::prebuild_readiness_status
+		AND NOT t.deleted
+		AND EXISTS (
+			SELECT 1 FROM workspace_builds newer
+			WHERE newer.workspace_id = wb.workspace_id AND newer.build_number > wb.build_number
+		)
 ),
 time_sorted_builds AS (
 	-- Group builds by preset, then sort each group by created_at.
@@ -8884,6 +9704,7 @@ type GetPresetsBackoffRow struct {
 // Query returns a list of preset IDs for which we should backoff.
 // Only active template versions with configured presets are considered.
 // We also return the number of failed workspace builds that occurred during the lookback period.
+// Builds of prebuilds which failed their preset's readiness checks count as failed builds.
 //
 // NOTE:
 // - To **decide whether to back off**, we look at up to the N most recent builds (within the defined lookback period).
@@ -9018,6 +9839,73 @@ func (q *sqlQuerier) GetRunningPrebuiltWorkspaces(ctx context.Context) ([]GetRun
 	return items, nil
 }
 
+const getTemplateAdaptivePrebuildsByTemplateID = `-- name: GetTemplateAdaptivePrebuildsByTemplateID :one
+SELECT
+	template_id, enabled, min_instances, max_instances, lookback_weeks, created_at, updated_at
+FROM
+	template_adaptive_prebuilds
+WHERE
+	template_id = $1
+`
+
+func (q *sqlQuerier) GetTemplateAdaptivePrebuildsByTemplateID(ctx context.Context, templateID uuid.UUID) (TemplateAdaptivePrebuild, error) {
+	row := q.db.QueryRowContext(ctx, getTemplateAdaptivePrebuildsByTemplateID, templateID)
+	var i TemplateAdaptivePrebuild
+	err := row.Scan(
+		&i.TemplateID,
+		&i.Enabled,
+		&i.MinInstances,
+		&i.MaxInstances,
+		&i.LookbackWeeks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTemplatePresetReadinessChecks = `-- name: GetTemplatePresetReadinessChecks :many
+SELECT
+	template_id, preset_name, checks, timeout, created_at, updated_at
+FROM
+	template_preset_readiness_checks
+WHERE
+	($1::uuid IS NULL OR template_id = $1::uuid)
+ORDER BY
+	template_id, preset_name
+`
+
+// GetTemplatePresetReadinessChecks returns the readiness checks of template presets.
+// If template_id is specified, only the checks of that template are returned.
+func (q *sqlQuerier) GetTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.NullUUID) ([]TemplatePresetReadinessCheck, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplatePresetReadinessChecks, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplatePresetReadinessCheck
+	for rows.Next() {
+		var i TemplatePresetReadinessCheck
+		if err := rows.Scan(
+			&i.TemplateID,
+			&i.PresetName,
+			&i.Checks,
+			&i.Timeout,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTemplatePresetsWithPrebuilds = `-- name: GetTemplatePresetsWithPrebuilds :many
 SELECT
 		t.id                        AS template_id,
@@ -9180,6 +10068,170 @@ func (q *sqlQuerier) UpdatePrebuildProvisionerJobWithCancel(ctx context.Context,
 	return items, nil
 }
 
+const upsertPrebuildReadiness = `-- name: UpsertPrebuildReadiness :exec
+INSERT INTO
+	workspace_prebuild_readiness (
+		workspace_id,
+		build_id,
+		status,
+		failure_reasons,
+		checked_at
+	)
+VALUES
+	(
+		$1,
+		$2,
+		$3,
+		$4,
+		$5
+	)
+ON CONFLICT (workspace_id) DO UPDATE SET
+	build_id = EXCLUDED.build_id,
+	status = EXCLUDED.status,
+	failure_reasons = EXCLUDED.failure_reasons,
+	checked_at = EXCLUDED.checked_at
+`
+
+type UpsertPrebuildReadinessParams struct {
+	WorkspaceID    uuid.UUID               `db:"workspace_id" json:"workspace_id"`
+	BuildID        uuid.UUID               `db:"build_id" json:"build_id"`
+	Status         PrebuildReadinessStatus `db:"status" json:"status"`
+	FailureReasons []string                `db:"failure_reasons" json:"failure_reasons"`
+	CheckedAt      time.Time               `db:"checked_at" json:"checked_at"`
+}
+
+func (q *sqlQuerier) UpsertPrebuildReadiness(ctx context.Context, arg UpsertPrebuildReadinessParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPrebuildReadiness,
+		arg.WorkspaceID,
+		arg.BuildID,
+		arg.Status,
+		pq.Array(arg.FailureReasons),
+		arg.CheckedAt,
+	)
+	return err
+}
+
+const upsertTemplateAdaptivePrebuilds = `-- name: UpsertTemplateAdaptivePrebuilds :one
+INSERT INTO
+	template_adaptive_prebuilds (
+		template_id,
+		enabled,
+		min_instances,
+		max_instances,
+		lookback_weeks,
+		created_at,
+		updated_at
+	)
+VALUES
+	(
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7
+	)
+ON CONFLICT (template_id) DO UPDATE SET
+	enabled = EXCLUDED.enabled,
+	min_instances = EXCLUDED.min_instances,
+	max_instances = EXCLUDED.max_instances,
+	lookback_weeks = EXCLUDED.lookback_weeks,
+	updated_at = EXCLUDED.updated_at
+RETURNING
+	template_id, enabled, min_instances, max_instances, lookback_weeks, created_at, updated_at
+`
+
+type UpsertTemplateAdaptivePrebuildsParams struct {
+	TemplateID    uuid.UUID `db:"template_id" json:"template_id"`
+	Enabled       bool      `db:"enabled" json:"enabled"`
+	MinInstances  int32     `db:"min_instances" json:"min_instances"`
+	MaxInstances  int32     `db:"max_instances" json:"max_instances"`
+	LookbackWeeks int32     `db:"lookback_weeks" json:"lookback_weeks"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpsertTemplateAdaptivePrebuilds(ctx context.Context, arg UpsertTemplateAdaptivePrebuildsParams) (TemplateAdaptivePrebuild, error) {
+	row := q.db.QueryRowContext(ctx, upsertTemplateAdaptivePrebuilds,
+		arg.TemplateID,
+		arg.Enabled,
+		arg.MinInstances,
+		arg.MaxInstances,
+		arg.LookbackWeeks,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TemplateAdaptivePrebuild
+	err := row.Scan(
+		&i.TemplateID,
+		&i.Enabled,
+		&i.MinInstances,
+		&i.MaxInstances,
+		&i.LookbackWeeks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertTemplatePresetReadinessChecks = `-- name: UpsertTemplatePresetReadinessChecks :one
+INSERT INTO
+	template_preset_readiness_checks (
+		template_id,
+		preset_name,
+		checks,
+		timeout,
+		created_at,
+		updated_at
+	)
+VALUES
+	(
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6
+	)
+ON CONFLICT (template_id, preset_name) DO UPDATE SET
+	checks = EXCLUDED.checks,
+	timeout = EXCLUDED.timeout,
+	updated_at = EXCLUDED.updated_at
+RETURNING
+	template_id, preset_name, checks, timeout, created_at, updated_at
+`
+
+type UpsertTemplatePresetReadinessChecksParams struct {
+	TemplateID uuid.UUID               `db:"template_id" json:"template_id"`
+	PresetName string                  `db:"preset_name" json:"preset_name"`
+	Checks     PrebuildReadinessChecks `db:"checks" json:"checks"`
+	Timeout    int64                   `db:"timeout" json:"timeout"`
+	CreatedAt  time.Time               `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time               `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpsertTemplatePresetReadinessChecks(ctx context.Context, arg UpsertTemplatePresetReadinessChecksParams) (TemplatePresetReadinessCheck, error) {
+	row := q.db.QueryRowContext(ctx, upsertTemplatePresetReadinessChecks,
+		arg.TemplateID,
+		arg.PresetName,
+		arg.Checks,
+		arg.Timeout,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TemplatePresetReadinessCheck
+	err := row.Scan(
+		&i.TemplateID,
+		&i.PresetName,
+		&i.Checks,
+		&i.Timeout,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getActivePresetPrebuildSchedules = `-- name: GetActivePresetPrebuildSchedules :many
 SELECT
 	tvpps.id, tvpps.preset_id, tvpps.cron_expression, tvpps.desired_instances
@@ -10110,16 +11162,52 @@ WHERE
 	job_id = $1
 	AND (
 		id > $2
-	) ORDER BY id ASC
+	)
+	-- Filter by stage, if any are provided
+	AND CASE
+		WHEN cardinality($3 :: text[]) > 0 THEN
+			stage = ANY($3 :: text[])
+		ELSE true
+	END
+	-- Filter by level, if any are provided
+	AND CASE
+		WHEN cardinality($4 :: log_level[]) > 0 THEN
+			level = ANY($4 :: log_level[])
+		ELSE true
+	END
+	-- Filter by output, case-insensitive
+	AND CASE
+		WHEN $5 :: text != '' THEN
+			output ILIKE '%' || $5 || '%'
+		ELSE true
+	END
+	-- Filter by creation time
+	AND CASE
+		WHEN $6 :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			created_at >= $6
+		ELSE true
+	END
+ORDER BY id ASC
 `
 
 type GetProvisionerLogsAfterIDParams struct {
-	JobID        uuid.UUID `db:"job_id" json:"job_id"`
-	CreatedAfter int64     `db:"created_after" json:"created_after"`
+	JobID          uuid.UUID  `db:"job_id" json:"job_id"`
+	CreatedAfter   int64      `db:"created_after" json:"created_after"`
+	Stages         []string   `db:"stages" json:"stages"`
+	Levels         []LogLevel `db:"levels" json:"levels"`
+	Search         string     `db:"search" json:"search"`
+	CreatedAtSince time.Time  `db:"created_at_since" json:"created_at_since"`
 }
 
 func (q *sqlQuerier) GetProvisionerLogsAfterID(ctx context.Context, arg GetProvisionerLogsAfterIDParams) ([]ProvisionerJobLog, error) {
-	rows, err := q.db.QueryContext(ctx, getProvisionerLogsAfterID, arg.JobID, arg.CreatedAfter)
+	rows, err := q.db.QueryContext(ctx, getProvisionerLogsAfterID,
+		arg.JobID,
+		arg.CreatedAfter,
+		pq.Array(arg.Stages),
+		pq.Array(arg.Levels),
+		arg.Search,
+		arg.CreatedAtSince,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -10449,6 +11537,42 @@ func (q *sqlQuerier) GetProvisionerJobByIDWithLock(ctx context.Context, id uuid.
 	return i, err
 }
 
+const getProvisionerJobResourceChangesByJobID = `-- name: GetProvisionerJobResourceChangesByJobID :many
+SELECT job_id, address, type, name, action, replace_paths FROM provisioner_job_resource_changes
+WHERE job_id = $1
+ORDER BY address ASC
+`
+
+func (q *sqlQuerier) GetProvisionerJobResourceChangesByJobID(ctx context.Context, jobID uuid.UUID) ([]ProvisionerJobResourceChange, error) {
+	rows, err := q.db.QueryContext(ctx, getProvisionerJobResourceChangesByJobID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProvisionerJobResourceChange
+	for rows.Next() {
+		var i ProvisionerJobResourceChange
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Address,
+			&i.Type,
+			&i.Name,
+			&i.Action,
+			pq.Array(&i.ReplacePaths),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProvisionerJobTimingsByJobID = `-- name: GetProvisionerJobTimingsByJobID :many
 SELECT job_id, started_at, ended_at, stage, source, action, resource FROM provisioner_job_timings
 WHERE job_id = $1
@@ -11063,6 +12187,42 @@ func (q *sqlQuerier) InsertProvisionerJob(ctx context.Context, arg InsertProvisi
 	return i, err
 }
 
+const insertProvisionerJobResourceChange = `-- name: InsertProvisionerJobResourceChange :one
+INSERT INTO provisioner_job_resource_changes (job_id, address, type, name, action, replace_paths)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING job_id, address, type, name, action, replace_paths
+`
+
+type InsertProvisionerJobResourceChangeParams struct {
+	JobID        uuid.UUID            `db:"job_id" json:"job_id"`
+	Address      string               `db:"address" json:"address"`
+	Type         string               `db:"type" json:"type"`
+	Name         string               `db:"name" json:"name"`
+	Action       ResourceChangeAction `db:"action" json:"action"`
+	ReplacePaths []string             `db:"replace_paths" json:"replace_paths"`
+}
+
+func (q *sqlQuerier) InsertProvisionerJobResourceChange(ctx context.Context, arg InsertProvisionerJobResourceChangeParams) (ProvisionerJobResourceChange, error) {
+	row := q.db.QueryRowContext(ctx, insertProvisionerJobResourceChange,
+		arg.JobID,
+		arg.Address,
+		arg.Type,
+		arg.Name,
+		arg.Action,
+		pq.Array(arg.ReplacePaths),
+	)
+	var i ProvisionerJobResourceChange
+	err := row.Scan(
+		&i.JobID,
+		&i.Address,
+		&i.Type,
+		&i.Name,
+		&i.Action,
+		pq.Array(&i.ReplacePaths),
+	)
+	return i, err
+}
+
 const insertProvisionerJobTimings = `-- name: InsertProvisionerJobTimings :many
 INSERT INTO provisioner_job_timings (job_id, started_at, ended_at, stage, source, action, resource)
 SELECT
@@ -13676,6 +14836,204 @@ func (q *sqlQuerier) InsertTelemetryLock(ctx context.Context, arg InsertTelemetr
 	return err
 }
 
+const deleteTemplateGroupScheduleOverride = `-- name: DeleteTemplateGroupScheduleOverride :one
+DELETE FROM
+	template_group_schedule_overrides
+WHERE
+	template_id = $1
+	AND group_id = $2
+RETURNING
+	template_id, group_id, max_ttl, autostart_block_days_of_week, autostop_requirement_days_of_week, autostop_requirement_weeks, created_at, updated_at
+`
+
+type DeleteTemplateGroupScheduleOverrideParams struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	GroupID    uuid.UUID `db:"group_id" json:"group_id"`
+}
+
+func (q *sqlQuerier) DeleteTemplateGroupScheduleOverride(ctx context.Context, arg DeleteTemplateGroupScheduleOverrideParams) (TemplateGroupScheduleOverride, error) {
+	row := q.db.QueryRowContext(ctx, deleteTemplateGroupScheduleOverride, arg.TemplateID, arg.GroupID)
+	var i TemplateGroupScheduleOverride
+	err := row.Scan(
+		&i.TemplateID,
+		&i.GroupID,
+		&i.MaxTTL,
+		&i.AutostartBlockDaysOfWeek,
+		&i.AutostopRequirementDaysOfWeek,
+		&i.AutostopRequirementWeeks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTemplateGroupScheduleOverrides = `-- name: GetTemplateGroupScheduleOverrides :many
+SELECT
+	template_id, group_id, max_ttl, autostart_block_days_of_week, autostop_requirement_days_of_week, autostop_requirement_weeks, created_at, updated_at
+FROM
+	template_group_schedule_overrides
+WHERE
+	template_id = $1
+ORDER BY
+	group_id
+`
+
+func (q *sqlQuerier) GetTemplateGroupScheduleOverrides(ctx context.Context, templateID uuid.UUID) ([]TemplateGroupScheduleOverride, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateGroupScheduleOverrides, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateGroupScheduleOverride
+	for rows.Next() {
+		var i TemplateGroupScheduleOverride
+		if err := rows.Scan(
+			&i.TemplateID,
+			&i.GroupID,
+			&i.MaxTTL,
+			&i.AutostartBlockDaysOfWeek,
+			&i.AutostopRequirementDaysOfWeek,
+			&i.AutostopRequirementWeeks,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemplateGroupScheduleOverridesByUserID = `-- name: GetTemplateGroupScheduleOverridesByUserID :many
+SELECT
+	template_group_schedule_overrides.template_id, template_group_schedule_overrides.group_id, template_group_schedule_overrides.max_ttl, template_group_schedule_overrides.autostart_block_days_of_week, template_group_schedule_overrides.autostop_requirement_days_of_week, template_group_schedule_overrides.autostop_requirement_weeks, template_group_schedule_overrides.created_at, template_group_schedule_overrides.updated_at
+FROM
+	template_group_schedule_overrides
+JOIN
+	group_members_expanded
+ON
+	group_members_expanded.group_id = template_group_schedule_overrides.group_id
+WHERE
+	template_group_schedule_overrides.template_id = $1
+	AND group_members_expanded.user_id = $2
+ORDER BY
+	template_group_schedule_overrides.group_id
+`
+
+type GetTemplateGroupScheduleOverridesByUserIDParams struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// Returns the schedule overrides of a template that apply to a user through
+// their group memberships.
+func (q *sqlQuerier) GetTemplateGroupScheduleOverridesByUserID(ctx context.Context, arg GetTemplateGroupScheduleOverridesByUserIDParams) ([]TemplateGroupScheduleOverride, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateGroupScheduleOverridesByUserID, arg.TemplateID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateGroupScheduleOverride
+	for rows.Next() {
+		var i TemplateGroupScheduleOverride
+		if err := rows.Scan(
+			&i.TemplateID,
+			&i.GroupID,
+			&i.MaxTTL,
+			&i.AutostartBlockDaysOfWeek,
+			&i.AutostopRequirementDaysOfWeek,
+			&i.AutostopRequirementWeeks,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertTemplateGroupScheduleOverride = `-- name: UpsertTemplateGroupScheduleOverride :one
+INSERT INTO
+	template_group_schedule_overrides (
+		template_id,
+		group_id,
+		max_ttl,
+		autostart_block_days_of_week,
+		autostop_requirement_days_of_week,
+		autostop_requirement_weeks,
+		created_at,
+		updated_at
+	)
+VALUES
+	(
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7,
+		$8
+	)
+ON CONFLICT (template_id, group_id) DO UPDATE SET
+	max_ttl = EXCLUDED.max_ttl,
+	autostart_block_days_of_week = EXCLUDED.autostart_block_days_of_week,
+	autostop_requirement_days_of_week = EXCLUDED.autostop_requirement_days_of_week,
+	autostop_requirement_weeks = EXCLUDED.autostop_requirement_weeks,
+	updated_at = EXCLUDED.updated_at
+RETURNING
+	template_id, group_id, max_ttl, autostart_block_days_of_week, autostop_requirement_days_of_week, autostop_requirement_weeks, created_at, updated_at
+`
+
+type UpsertTemplateGroupScheduleOverrideParams struct {
+	TemplateID                    uuid.UUID `db:"template_id" json:"template_id"`
+	GroupID                       uuid.UUID `db:"group_id" json:"group_id"`
+	MaxTTL                        int64     `db:"max_ttl" json:"max_ttl"`
+	AutostartBlockDaysOfWeek      int16     `db:"autostart_block_days_of_week" json:"autostart_block_days_of_week"`
+	AutostopRequirementDaysOfWeek int16     `db:"autostop_requirement_days_of_week" json:"autostop_requirement_days_of_week"`
+	AutostopRequirementWeeks      int64     `db:"autostop_requirement_weeks" json:"autostop_requirement_weeks"`
+	CreatedAt                     time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt                     time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpsertTemplateGroupScheduleOverride(ctx context.Context, arg UpsertTemplateGroupScheduleOverrideParams) (TemplateGroupScheduleOverride, error) {
+	row := q.db.QueryRowContext(ctx, upsertTemplateGroupScheduleOverride,
+		arg.TemplateID,
+		arg.GroupID,
+		arg.MaxTTL,
+		arg.AutostartBlockDaysOfWeek,
+		arg.AutostopRequirementDaysOfWeek,
+		arg.AutostopRequirementWeeks,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TemplateGroupScheduleOverride
+	err := row.Scan(
+		&i.TemplateID,
+		&i.GroupID,
+		&i.MaxTTL,
+		&i.AutostartBlockDaysOfWeek,
+		&i.AutostopRequirementDaysOfWeek,
+		&i.AutostopRequirementWeeks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getTemplateAverageBuildTime = `-- name: GetTemplateAverageBuildTime :one
 WITH build_times AS (
 SELECT
@@ -13733,7 +15091,7 @@ func (q *sqlQuerier) GetTemplateAverageBuildTime(ctx context.Context, templateID
 
 const getTemplateByID = `-- name: GetTemplateByID :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, session_recording_enabled, session_recording_include_input, maintenance_window_schedule, hibernate_idle_threshold, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names
 WHERE
@@ -13777,6 +15135,10 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 		&i.UseClassicParameterFlow,
 		&i.CorsBehavior,
 		&i.DisableModuleCache,
+		&i.SessionRecordingEnabled,
+		&i.SessionRecordingIncludeInput,
+		&i.MaintenanceWindowSchedule,
+		&i.HibernateIdleThreshold,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 		&i.CreatedByName,
@@ -13789,7 +15151,7 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 
 const getTemplateByOrganizationAndName = `-- name: GetTemplateByOrganizationAndName :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, session_recording_enabled, session_recording_include_input, maintenance_window_schedule, hibernate_idle_threshold, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names AS templates
 WHERE
@@ -13841,6 +15203,10 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 		&i.UseClassicParameterFlow,
 		&i.CorsBehavior,
 		&i.DisableModuleCache,
+		&i.SessionRecordingEnabled,
+		&i.SessionRecordingIncludeInput,
+		&i.MaintenanceWindowSchedule,
+		&i.HibernateIdleThreshold,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 		&i.CreatedByName,
@@ -13852,7 +15218,7 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 }
 
 const getTemplates = `-- name: GetTemplates :many
-SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon FROM template_with_names AS templates
+SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, session_recording_enabled, session_recording_include_input, maintenance_window_schedule, hibernate_idle_threshold, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon FROM template_with_names AS templates
 ORDER BY (name, id) ASC
 `
 
@@ -13897,6 +15263,10 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 			&i.UseClassicParameterFlow,
 			&i.CorsBehavior,
 			&i.DisableModuleCache,
+			&i.SessionRecordingEnabled,
+			&i.SessionRecordingIncludeInput,
+			&i.MaintenanceWindowSchedule,
+			&i.HibernateIdleThreshold,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.CreatedByName,
@@ -13919,7 +15289,7 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 
 const getTemplatesWithFilter = `-- name: GetTemplatesWithFilter :many
 SELECT
-	t.id, t.created_at, t.updated_at, t.organization_id, t.deleted, t.name, t.provisioner, t.active_version_id, t.description, t.default_ttl, t.created_by, t.icon, t.user_acl, t.group_acl, t.display_name, t.allow_user_cancel_workspace_jobs, t.allow_user_autostart, t.allow_user_autostop, t.failure_ttl, t.time_til_dormant, t.time_til_dormant_autodelete, t.autostop_requirement_days_of_week, t.autostop_requirement_weeks, t.autostart_block_days_of_week, t.require_active_version, t.deprecated, t.activity_bump, t.max_port_sharing_level, t.use_classic_parameter_flow, t.cors_behavior, t.disable_module_cache, t.created_by_avatar_url, t.created_by_username, t.created_by_name, t.organization_name, t.organization_display_name, t.organization_icon
+	t.id, t.created_at, t.updated_at, t.organization_id, t.deleted, t.name, t.provisioner, t.active_version_id, t.description, t.default_ttl, t.created_by, t.icon, t.user_acl, t.group_acl, t.display_name, t.allow_user_cancel_workspace_jobs, t.allow_user_autostart, t.allow_user_autostop, t.failure_ttl, t.time_til_dormant, t.time_til_dormant_autodelete, t.autostop_requirement_days_of_week, t.autostop_requirement_weeks, t.autostart_block_days_of_week, t.require_active_version, t.deprecated, t.activity_bump, t.max_port_sharing_level, t.use_classic_parameter_flow, t.cors_behavior, t.disable_module_cache, t.session_recording_enabled, t.session_recording_include_input, t.maintenance_window_schedule, t.hibernate_idle_threshold, t.created_by_avatar_url, t.created_by_username, t.created_by_name, t.organization_name, t.organization_display_name, t.organization_icon
 FROM
 	template_with_names AS t
 LEFT JOIN
@@ -14025,21 +15395,97 @@ type GetTemplatesWithFilterParams struct {
 	HasExternalAgent sql.NullBool `db:"has_external_agent" json:"has_external_agent"`
 }
 
-func (q *sqlQuerier) GetTemplatesWithFilter(ctx context.Context, arg GetTemplatesWithFilterParams) ([]Template, error) {
-	rows, err := q.db.QueryContext(ctx, getTemplatesWithFilter,
-		arg.Deleted,
-		arg.OrganizationID,
-		arg.ExactName,
-		arg.ExactDisplayName,
-		arg.FuzzyName,
-		arg.FuzzyDisplayName,
-		pq.Array(arg.IDs),
-		arg.Deprecated,
-		arg.HasAITask,
-		arg.AuthorID,
-		arg.AuthorUsername,
-		arg.HasExternalAgent,
-	)
+func (q *sqlQuerier) GetTemplatesWithFilter(ctx context.Context, arg GetTemplatesWithFilterParams) ([]Template, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplatesWithFilter,
+		arg.Deleted,
+		arg.OrganizationID,
+		arg.ExactName,
+		arg.ExactDisplayName,
+		arg.FuzzyName,
+		arg.FuzzyDisplayName,
+		pq.Array(arg.IDs),
+		arg.Deprecated,
+		arg.HasAITask,
+		arg.AuthorID,
+		arg.AuthorUsername,
+		arg.HasExternalAgent,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Template
+	for rows.Next() {
+		var i Template
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+			&i.Deleted,
+			&i.Name,
+			&i.Provisioner,
+			&i.ActiveVersionID,
+			&i.Description,
+			&i.DefaultTTL,
+			&i.CreatedBy,
+			&i.Icon,
+			&i.UserACL,
+			&i.GroupACL,
+			&i.DisplayName,
+			&i.AllowUserCancelWorkspaceJobs,
+			&i.AllowUserAutostart,
+			&i.AllowUserAutostop,
+			&i.FailureTTL,
+			&i.TimeTilDormant,
+			&i.TimeTilDormantAutoDelete,
+			&i.AutostopRequirementDaysOfWeek,
+			&i.AutostopRequirementWeeks,
+			&i.AutostartBlockDaysOfWeek,
+			&i.RequireActiveVersion,
+			&i.Deprecated,
+			&i.ActivityBump,
+			&i.MaxPortSharingLevel,
+			&i.UseClassicParameterFlow,
+			&i.CorsBehavior,
+			&i.DisableModuleCache,
+			&i.SessionRecordingEnabled,
+			&i.SessionRecordingIncludeInput,
+			&i.MaintenanceWindowSchedule,
+			&i.HibernateIdleThreshold,
+			&i.CreatedByAvatarURL,
+			&i.CreatedByUsername,
+			&i.CreatedByName,
+			&i.OrganizationName,
+			&i.OrganizationDisplayName,
+			&i.OrganizationIcon,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemplatesWithMaintenanceWindow = `-- name: GetTemplatesWithMaintenanceWindow :many
+SELECT
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, session_recording_enabled, session_recording_include_input, maintenance_window_schedule, hibernate_idle_threshold, created_by_avatar_url, created_by_username, created_by_name, organization_name, organization_display_name, organization_icon
+FROM
+	template_with_names AS templates
+WHERE
+	deleted = false AND
+	maintenance_window_schedule != ''
+ORDER BY (name, id) ASC
+`
+
+func (q *sqlQuerier) GetTemplatesWithMaintenanceWindow(ctx context.Context) ([]Template, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplatesWithMaintenanceWindow)
 	if err != nil {
 		return nil, err
 	}
@@ -14079,6 +15525,10 @@ func (q *sqlQuerier) GetTemplatesWithFilter(ctx context.Context, arg GetTemplate
 			&i.UseClassicParameterFlow,
 			&i.CorsBehavior,
 			&i.DisableModuleCache,
+			&i.SessionRecordingEnabled,
+			&i.SessionRecordingIncludeInput,
+			&i.MaintenanceWindowSchedule,
+			&i.HibernateIdleThreshold,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.CreatedByName,
@@ -14265,7 +15715,11 @@ SET
 	max_port_sharing_level = $9,
 	use_classic_parameter_flow = $10,
 	cors_behavior = $11,
-	disable_module_cache = $12
+	disable_module_cache = $12,
+	session_recording_enabled = $13,
+	session_recording_include_input = $14,
+	maintenance_window_schedule = $15,
+	hibernate_idle_threshold = $16
 WHERE
 	id = $1
 `
@@ -14283,6 +15737,10 @@ type UpdateTemplateMetaByIDParams struct {
 	UseClassicParameterFlow      bool            `db:"use_classic_parameter_flow" json:"use_classic_parameter_flow"`
 	CorsBehavior                 CorsBehavior    `db:"cors_behavior" json:"cors_behavior"`
 	DisableModuleCache           bool            `db:"disable_module_cache" json:"disable_module_cache"`
+	SessionRecordingEnabled      bool            `db:"session_recording_enabled" json:"session_recording_enabled"`
+	SessionRecordingIncludeInput bool            `db:"session_recording_include_input" json:"session_recording_include_input"`
+	MaintenanceWindowSchedule    string          `db:"maintenance_window_schedule" json:"maintenance_window_schedule"`
+	HibernateIdleThreshold       int64           `db:"hibernate_idle_threshold" json:"hibernate_idle_threshold"`
 }
 
 func (q *sqlQuerier) UpdateTemplateMetaByID(ctx context.Context, arg UpdateTemplateMetaByIDParams) error {
@@ -14299,6 +15757,10 @@ func (q *sqlQuerier) UpdateTemplateMetaByID(ctx context.Context, arg UpdateTempl
 		arg.UseClassicParameterFlow,
 		arg.CorsBehavior,
 		arg.DisableModuleCache,
+		arg.SessionRecordingEnabled,
+		arg.SessionRecordingIncludeInput,
+		arg.MaintenanceWindowSchedule,
+		arg.HibernateIdleThreshold,
 	)
 	return err
 }
@@ -17939,7 +19401,7 @@ func (q *sqlQuerier) DeleteWorkspaceSubAgentByID(ctx context.Context, id uuid.UU
 
 const getAuthenticatedWorkspaceAgentAndBuildByAuthToken = `-- name: GetAuthenticatedWorkspaceAgentAndBuildByAuthToken :one
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl, workspaces.source_workspace_id,
 	workspace_agents.id, workspace_agents.created_at, workspace_agents.updated_at, workspace_agents.name, workspace_agents.first_connected_at, workspace_agents.last_connected_at, workspace_agents.disconnected_at, workspace_agents.resource_id, workspace_agents.auth_token, workspace_agents.auth_instance_id, workspace_agents.architecture, workspace_agents.environment_variables, workspace_agents.operating_system, workspace_agents.instance_metadata, workspace_agents.resource_metadata, workspace_agents.directory, workspace_agents.version, workspace_agents.last_connected_replica_id, workspace_agents.connection_timeout_seconds, workspace_agents.troubleshooting_url, workspace_agents.motd_file, workspace_agents.lifecycle_state, workspace_agents.expanded_directory, workspace_agents.logs_length, workspace_agents.logs_overflowed, workspace_agents.started_at, workspace_agents.ready_at, workspace_agents.subsystems, workspace_agents.display_apps, workspace_agents.api_version, workspace_agents.display_order, workspace_agents.parent_id, workspace_agents.api_key_scope, workspace_agents.deleted,
 	workspace_build_with_user.id, workspace_build_with_user.created_at, workspace_build_with_user.updated_at, workspace_build_with_user.workspace_id, workspace_build_with_user.template_version_id, workspace_build_with_user.build_number, workspace_build_with_user.transition, workspace_build_with_user.initiator_id, workspace_build_with_user.provisioner_state, workspace_build_with_user.job_id, workspace_build_with_user.deadline, workspace_build_with_user.reason, workspace_build_with_user.daily_cost, workspace_build_with_user.max_deadline, workspace_build_with_user.template_version_preset_id, workspace_build_with_user.has_ai_task, workspace_build_with_user.has_external_agent, workspace_build_with_user.initiator_by_avatar_url, workspace_build_with_user.initiator_by_username, workspace_build_with_user.initiator_by_name,
 	tasks.id AS task_id
@@ -17983,7 +19445,7 @@ WHERE
 		WHEN workspace_build_with_user.transition = 'start'
 			-- Agent's START build job succeeded.
 			AND (SELECT job_status FROM provisioner_jobs WHERE id = workspace_build_with_user.job_id) = 'succeeded'
-			-- Latest build is a STOP build whose job is still active,
+			-- Latest build is a STOP or HIBERNATE build whose job is still active,
 			-- and agent's build is immediately previous.
 			AND EXISTS (
 				SELECT 1
@@ -17996,7 +19458,7 @@ WHERE
 					FROM workspace_builds l2
 					WHERE l2.workspace_id = latest.workspace_id
 				)
-				AND latest.transition = 'stop'
+				AND latest.transition IN ('stop', 'hibernate')
 				AND pj.job_status IN ('pending', 'running')
 			) THEN TRUE
 		ELSE FALSE
@@ -18037,6 +19499,7 @@ func (q *sqlQuerier) GetAuthenticatedWorkspaceAgentAndBuildByAuthToken(ctx conte
 		&i.WorkspaceTable.NextStartAt,
 		&i.WorkspaceTable.GroupACL,
 		&i.WorkspaceTable.UserACL,
+		&i.WorkspaceTable.SourceWorkspaceID,
 		&i.WorkspaceAgent.ID,
 		&i.WorkspaceAgent.CreatedAt,
 		&i.WorkspaceAgent.UpdatedAt,
@@ -18099,7 +19562,7 @@ func (q *sqlQuerier) GetAuthenticatedWorkspaceAgentAndBuildByAuthToken(ctx conte
 const getWorkspaceAgentAndWorkspaceByID = `-- name: GetWorkspaceAgentAndWorkspaceByID :one
 SELECT
 	workspace_agents.id, workspace_agents.created_at, workspace_agents.updated_at, workspace_agents.name, workspace_agents.first_connected_at, workspace_agents.last_connected_at, workspace_agents.disconnected_at, workspace_agents.resource_id, workspace_agents.auth_token, workspace_agents.auth_instance_id, workspace_agents.architecture, workspace_agents.environment_variables, workspace_agents.operating_system, workspace_agents.instance_metadata, workspace_agents.resource_metadata, workspace_agents.directory, workspace_agents.version, workspace_agents.last_connected_replica_id, workspace_agents.connection_timeout_seconds, workspace_agents.troubleshooting_url, workspace_agents.motd_file, workspace_agents.lifecycle_state, workspace_agents.expanded_directory, workspace_agents.logs_length, workspace_agents.logs_overflowed, workspace_agents.started_at, workspace_agents.ready_at, workspace_agents.subsystems, workspace_agents.display_apps, workspace_agents.api_version, workspace_agents.display_order, workspace_agents.parent_id, workspace_agents.api_key_scope, workspace_agents.deleted,
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl, workspaces.source_workspace_id,
 	users.username as owner_username
 FROM
 	workspace_agents
@@ -18184,6 +19647,7 @@ func (q *sqlQuerier) GetWorkspaceAgentAndWorkspaceByID(ctx context.Context, id u
 		&i.WorkspaceTable.NextStartAt,
 		&i.WorkspaceTable.GroupACL,
 		&i.WorkspaceTable.UserACL,
+		&i.WorkspaceTable.SourceWorkspaceID,
 		&i.OwnerUsername,
 	)
 	return i, err
@@ -18363,16 +19827,52 @@ WHERE
 	agent_id = $1
 	AND (
 		id > $2
-	) ORDER BY id ASC
+	)
+	-- Filter by log source, if any are provided
+	AND CASE
+		WHEN cardinality($3 :: uuid[]) > 0 THEN
+			log_source_id = ANY($3 :: uuid[])
+		ELSE true
+	END
+	-- Filter by level, if any are provided
+	AND CASE
+		WHEN cardinality($4 :: log_level[]) > 0 THEN
+			level = ANY($4 :: log_level[])
+		ELSE true
+	END
+	-- Filter by output, case-insensitive
+	AND CASE
+		WHEN $5 :: text != '' THEN
+			output ILIKE '%' || $5 || '%'
+		ELSE true
+	END
+	-- Filter by creation time
+	AND CASE
+		WHEN $6 :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			created_at >= $6
+		ELSE true
+	END
+ORDER BY id ASC
 `
 
 type GetWorkspaceAgentLogsAfterParams struct {
-	AgentID      uuid.UUID `db:"agent_id" json:"agent_id"`
-	CreatedAfter int64     `db:"created_after" json:"created_after"`
+	AgentID        uuid.UUID   `db:"agent_id" json:"agent_id"`
+	CreatedAfter   int64       `db:"created_after" json:"created_after"`
+	LogSourceIds   []uuid.UUID `db:"log_source_ids" json:"log_source_ids"`
+	Levels         []LogLevel  `db:"levels" json:"levels"`
+	Search         string      `db:"search" json:"search"`
+	CreatedAtSince time.Time   `db:"created_at_since" json:"created_at_since"`
 }
 
 func (q *sqlQuerier) GetWorkspaceAgentLogsAfter(ctx context.Context, arg GetWorkspaceAgentLogsAfterParams) ([]WorkspaceAgentLog, error) {
-	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentLogsAfter, arg.AgentID, arg.CreatedAfter)
+	rows, err := q.db.QueryContext(ctx, getWorkspaceAgentLogsAfter,
+		arg.AgentID,
+		arg.CreatedAfter,
+		pq.Array(arg.LogSourceIds),
+		pq.Array(arg.Levels),
+		arg.Search,
+		arg.CreatedAtSince,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -22372,7 +23872,7 @@ WITH workspaces_with_jobs AS (
 		completed_at IS NOT NULL AND
 		canceled_at IS NULL AND
 		error IS NULL AND
-		transition = 'stop'::workspace_transition
+		transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition)
 )
 SELECT
 	pending_workspaces.count AS pending_workspaces,
@@ -22497,7 +23997,7 @@ func (q *sqlQuerier) GetWorkspaceACLByID(ctx context.Context, id uuid.UUID) (Get
 
 const getWorkspaceByAgentID = `-- name: GetWorkspaceByAgentID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
 FROM
 	workspaces_expanded as workspaces
 WHERE
@@ -22547,6 +24047,7 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 		&i.OwnerAvatarUrl,
 		&i.OwnerUsername,
 		&i.OwnerName,
@@ -22567,7 +24068,7 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 
 const getWorkspaceByID = `-- name: GetWorkspaceByID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
 FROM
 	workspaces_expanded
 WHERE
@@ -22598,6 +24099,7 @@ func (q *sqlQuerier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (Worksp
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 		&i.OwnerAvatarUrl,
 		&i.OwnerUsername,
 		&i.OwnerName,
@@ -22618,7 +24120,7 @@ func (q *sqlQuerier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (Worksp
 
 const getWorkspaceByOwnerIDAndName = `-- name: GetWorkspaceByOwnerIDAndName :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
 FROM
 	workspaces_expanded as workspaces
 WHERE
@@ -22656,6 +24158,7 @@ func (q *sqlQuerier) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg GetWo
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 		&i.OwnerAvatarUrl,
 		&i.OwnerUsername,
 		&i.OwnerName,
@@ -22676,7 +24179,7 @@ func (q *sqlQuerier) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg GetWo
 
 const getWorkspaceByResourceID = `-- name: GetWorkspaceByResourceID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
 FROM
 	workspaces_expanded as workspaces
 WHERE
@@ -22721,6 +24224,7 @@ func (q *sqlQuerier) GetWorkspaceByResourceID(ctx context.Context, resourceID uu
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 		&i.OwnerAvatarUrl,
 		&i.OwnerUsername,
 		&i.OwnerName,
@@ -22741,7 +24245,7 @@ func (q *sqlQuerier) GetWorkspaceByResourceID(ctx context.Context, resourceID uu
 
 const getWorkspaceByWorkspaceAppID = `-- name: GetWorkspaceByWorkspaceAppID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id, owner_avatar_url, owner_username, owner_name, organization_name, organization_display_name, organization_icon, organization_description, template_name, template_display_name, template_icon, template_description, task_id, group_acl_display_info, user_acl_display_info
 FROM
 	workspaces_expanded as workspaces
 WHERE
@@ -22798,6 +24302,7 @@ func (q *sqlQuerier) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspace
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 		&i.OwnerAvatarUrl,
 		&i.OwnerUsername,
 		&i.OwnerName,
@@ -22861,7 +24366,7 @@ SELECT
 ),
 filtered_workspaces AS (
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl, workspaces.owner_avatar_url, workspaces.owner_username, workspaces.owner_name, workspaces.organization_name, workspaces.organization_display_name, workspaces.organization_icon, workspaces.organization_description, workspaces.template_name, workspaces.template_display_name, workspaces.template_icon, workspaces.template_description, workspaces.task_id, workspaces.group_acl_display_info, workspaces.user_acl_display_info,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl, workspaces.source_workspace_id, workspaces.owner_avatar_url, workspaces.owner_username, workspaces.owner_name, workspaces.organization_name, workspaces.organization_display_name, workspaces.organization_icon, workspaces.organization_description, workspaces.template_name, workspaces.template_display_name, workspaces.template_icon, workspaces.template_description, workspaces.task_id, workspaces.group_acl_display_info, workspaces.user_acl_display_info,
 	latest_build.template_version_id,
 	latest_build.template_version_name,
 	latest_build.completed_at as latest_build_completed_at,
@@ -22910,7 +24415,7 @@ LEFT JOIN LATERAL (
 ) latest_build ON TRUE
 LEFT JOIN LATERAL (
 	SELECT
-		id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache
+		id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, use_classic_parameter_flow, cors_behavior, disable_module_cache, session_recording_enabled, session_recording_include_input, maintenance_window_schedule, hibernate_idle_threshold
 	FROM
 		templates
 	WHERE
@@ -22931,7 +24436,7 @@ WHERE
 					latest_build.transition = 'start'::workspace_transition
 				WHEN $4 = 'stopping' THEN
 					latest_build.job_status = 'running'::provisioner_job_status AND
-					latest_build.transition = 'stop'::workspace_transition
+					latest_build.transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition)
 				WHEN $4 = 'deleting' THEN
 					latest_build.job_status = 'running' AND
 					latest_build.transition = 'delete'::workspace_transition
@@ -22942,7 +24447,7 @@ WHERE
 			    	latest_build.transition = 'delete'::workspace_transition
 				WHEN $4 = 'stopped' THEN
 					latest_build.job_status = 'succeeded'::provisioner_job_status AND
-					latest_build.transition = 'stop'::workspace_transition
+					latest_build.transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition)
 				WHEN $4 = 'started' THEN
 					latest_build.job_status = 'succeeded'::provisioner_job_status AND
 					latest_build.transition = 'start'::workspace_transition
@@ -23146,7 +24651,7 @@ WHERE
 	-- @authorize_filter
 ), filtered_workspaces_order AS (
 	SELECT
-		fw.id, fw.created_at, fw.updated_at, fw.owner_id, fw.organization_id, fw.template_id, fw.deleted, fw.name, fw.autostart_schedule, fw.ttl, fw.last_used_at, fw.dormant_at, fw.deleting_at, fw.automatic_updates, fw.favorite, fw.next_start_at, fw.group_acl, fw.user_acl, fw.owner_avatar_url, fw.owner_username, fw.owner_name, fw.organization_name, fw.organization_display_name, fw.organization_icon, fw.organization_description, fw.template_name, fw.template_display_name, fw.template_icon, fw.template_description, fw.task_id, fw.group_acl_display_info, fw.user_acl_display_info, fw.template_version_id, fw.template_version_name, fw.latest_build_completed_at, fw.latest_build_canceled_at, fw.latest_build_error, fw.latest_build_transition, fw.latest_build_status, fw.latest_build_has_external_agent
+		fw.id, fw.created_at, fw.updated_at, fw.owner_id, fw.organization_id, fw.template_id, fw.deleted, fw.name, fw.autostart_schedule, fw.ttl, fw.last_used_at, fw.dormant_at, fw.deleting_at, fw.automatic_updates, fw.favorite, fw.next_start_at, fw.group_acl, fw.user_acl, fw.source_workspace_id, fw.owner_avatar_url, fw.owner_username, fw.owner_name, fw.organization_name, fw.organization_display_name, fw.organization_icon, fw.organization_description, fw.template_name, fw.template_display_name, fw.template_icon, fw.template_description, fw.task_id, fw.group_acl_display_info, fw.user_acl_display_info, fw.template_version_id, fw.template_version_name, fw.latest_build_completed_at, fw.latest_build_canceled_at, fw.latest_build_error, fw.latest_build_transition, fw.latest_build_status, fw.latest_build_has_external_agent
 	FROM
 		filtered_workspaces fw
 	ORDER BY
@@ -23167,7 +24672,7 @@ WHERE
 		$25
 ), filtered_workspaces_order_with_summary AS (
 	SELECT
-		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.next_start_at, fwo.group_acl, fwo.user_acl, fwo.owner_avatar_url, fwo.owner_username, fwo.owner_name, fwo.organization_name, fwo.organization_display_name, fwo.organization_icon, fwo.organization_description, fwo.template_name, fwo.template_display_name, fwo.template_icon, fwo.template_description, fwo.task_id, fwo.group_acl_display_info, fwo.user_acl_display_info, fwo.template_version_id, fwo.template_version_name, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status, fwo.latest_build_has_external_agent
+		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.next_start_at, fwo.group_acl, fwo.user_acl, fwo.source_workspace_id, fwo.owner_avatar_url, fwo.owner_username, fwo.owner_name, fwo.organization_name, fwo.organization_display_name, fwo.organization_icon, fwo.organization_description, fwo.template_name, fwo.template_display_name, fwo.template_icon, fwo.template_description, fwo.task_id, fwo.group_acl_display_info, fwo.user_acl_display_info, fwo.template_version_id, fwo.template_version_name, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status, fwo.latest_build_has_external_agent
 	FROM
 		filtered_workspaces_order fwo
 	-- Return a technical summary row with total count of workspaces.
@@ -23192,6 +24697,7 @@ WHERE
 		'0001-01-01 00:00:00+00'::timestamptz, -- next_start_at
 		'{}'::jsonb, -- group_acl
 		'{}'::jsonb, -- user_acl
+		'00000000-0000-0000-0000-000000000000'::uuid, -- source_workspace_id
 		'', -- owner_avatar_url
 		'', -- owner_username
 		'', -- owner_name
@@ -23224,7 +24730,7 @@ WHERE
 		filtered_workspaces
 )
 SELECT
-	fwos.id, fwos.created_at, fwos.updated_at, fwos.owner_id, fwos.organization_id, fwos.template_id, fwos.deleted, fwos.name, fwos.autostart_schedule, fwos.ttl, fwos.last_used_at, fwos.dormant_at, fwos.deleting_at, fwos.automatic_updates, fwos.favorite, fwos.next_start_at, fwos.group_acl, fwos.user_acl, fwos.owner_avatar_url, fwos.owner_username, fwos.owner_name, fwos.organization_name, fwos.organization_display_name, fwos.organization_icon, fwos.organization_description, fwos.template_name, fwos.template_display_name, fwos.template_icon, fwos.template_description, fwos.task_id, fwos.group_acl_display_info, fwos.user_acl_display_info, fwos.template_version_id, fwos.template_version_name, fwos.latest_build_completed_at, fwos.latest_build_canceled_at, fwos.latest_build_error, fwos.latest_build_transition, fwos.latest_build_status, fwos.latest_build_has_external_agent,
+	fwos.id, fwos.created_at, fwos.updated_at, fwos.owner_id, fwos.organization_id, fwos.template_id, fwos.deleted, fwos.name, fwos.autostart_schedule, fwos.ttl, fwos.last_used_at, fwos.dormant_at, fwos.deleting_at, fwos.automatic_updates, fwos.favorite, fwos.next_start_at, fwos.group_acl, fwos.user_acl, fwos.source_workspace_id, fwos.owner_avatar_url, fwos.owner_username, fwos.owner_name, fwos.organization_name, fwos.organization_display_name, fwos.organization_icon, fwos.organization_description, fwos.template_name, fwos.template_display_name, fwos.template_icon, fwos.template_description, fwos.task_id, fwos.group_acl_display_info, fwos.user_acl_display_info, fwos.template_version_id, fwos.template_version_name, fwos.latest_build_completed_at, fwos.latest_build_canceled_at, fwos.latest_build_error, fwos.latest_build_transition, fwos.latest_build_status, fwos.latest_build_has_external_agent,
 	tc.count
 FROM
 	filtered_workspaces_order_with_summary fwos
@@ -23281,6 +24787,7 @@ type GetWorkspacesRow struct {
 	NextStartAt                 sql.NullTime         `db:"next_start_at" json:"next_start_at"`
 	GroupACL                    json.RawMessage      `db:"group_acl" json:"group_acl"`
 	UserACL                     json.RawMessage      `db:"user_acl" json:"user_acl"`
+	SourceWorkspaceID           uuid.NullUUID        `db:"source_workspace_id" json:"source_workspace_id"`
 	OwnerAvatarUrl              string               `db:"owner_avatar_url" json:"owner_avatar_url"`
 	OwnerUsername               string               `db:"owner_username" json:"owner_username"`
 	OwnerName                   string               `db:"owner_name" json:"owner_name"`
@@ -23365,6 +24872,7 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 			&i.NextStartAt,
 			&i.GroupACL,
 			&i.UserACL,
+			&i.SourceWorkspaceID,
 			&i.OwnerAvatarUrl,
 			&i.OwnerUsername,
 			&i.OwnerName,
@@ -23482,7 +24990,7 @@ func (q *sqlQuerier) GetWorkspacesAndAgentsByOwnerID(ctx context.Context, ownerI
 }
 
 const getWorkspacesByTemplateID = `-- name: GetWorkspacesByTemplateID :many
-SELECT id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl FROM workspaces WHERE template_id = $1 AND deleted = false
+SELECT id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id FROM workspaces WHERE template_id = $1 AND deleted = false
 `
 
 func (q *sqlQuerier) GetWorkspacesByTemplateID(ctx context.Context, templateID uuid.UUID) ([]WorkspaceTable, error) {
@@ -23513,6 +25021,7 @@ func (q *sqlQuerier) GetWorkspacesByTemplateID(ctx context.Context, templateID u
 			&i.NextStartAt,
 			&i.GroupACL,
 			&i.UserACL,
+			&i.SourceWorkspaceID,
 		); err != nil {
 			return nil, err
 		}
@@ -23572,14 +25081,14 @@ WHERE
 		-- A workspace may be eligible for autostart if the following are true:
 		--   * The workspace's owner is active.
 		--   * The provisioner job did not fail.
-		--   * The workspace build was a stop transition.
+		--   * The workspace build was a stop or hibernate transition.
 		--   * The workspace is not dormant
 		--   * The workspace has an autostart schedule.
 		--   * It is after the workspace's next start time.
 		(
 			users.status = 'active'::user_status AND
 			provisioner_jobs.job_status != 'failed'::provisioner_job_status AND
-			workspace_builds.transition = 'stop'::workspace_transition AND
+			workspace_builds.transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition) AND
 			workspaces.dormant_at IS NULL AND
 			workspaces.autostart_schedule IS NOT NULL AND
 			(
@@ -23645,6 +25154,29 @@ WHERE
 			provisioner_jobs.job_status = 'failed'::provisioner_job_status AND
 			provisioner_jobs.completed_at IS NOT NULL AND
 			($1 :: timestamptz) - provisioner_jobs.completed_at > (INTERVAL '1 millisecond' * (templates.failure_ttl / 1000000))
+		) OR
+
+		-- A workspace may be eligible for a maintenance stop if the following are true:
+		--   * The template has a maintenance window.
+		--   * The workspace is not dormant.
+		--   * The workspace build was a successful start transition.
+		--   * The workspace build is not on the active template version.
+		-- Whether the window is open and the workspace is idle is checked by the
+		-- lifecycle executor.
+		(
+			templates.maintenance_window_schedule != '' AND
+			workspaces.dormant_at IS NULL AND
+			workspace_builds.transition = 'start'::workspace_transition AND
+			provisioner_jobs.job_status = 'succeeded'::provisioner_job_status AND
+			workspace_builds.template_version_id != templates.active_version_id
+		) OR
+
+		-- A workspace may be eligible for a maintenance start if the following are true:
+		--   * The workspace build was a successful stop transition made for maintenance.
+		(
+			workspace_builds.transition = 'stop'::workspace_transition AND
+			workspace_builds.reason = 'maintenance'::build_reason AND
+			provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
 		)
 	)
   	AND workspaces.deleted = 'false'
@@ -23683,6 +25215,101 @@ func (q *sqlQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, now
 	return items, nil
 }
 
+const getWorkspacesForTemplateMaintenance = `-- name: GetWorkspacesForTemplateMaintenance :many
+SELECT
+	workspaces.id,
+	workspaces.name,
+	workspaces.owner_id,
+	users.username AS owner_username,
+	workspaces.last_used_at,
+	workspaces.dormant_at,
+	latest_build.template_version_id,
+	latest_build.transition,
+	latest_build.reason,
+	latest_build.created_at AS build_created_at,
+	provisioner_jobs.job_status
+FROM
+	workspaces
+INNER JOIN
+	users ON workspaces.owner_id = users.id
+INNER JOIN LATERAL (
+	SELECT
+		workspace_builds.template_version_id,
+		workspace_builds.transition,
+		workspace_builds.reason,
+		workspace_builds.created_at,
+		workspace_builds.job_id
+	FROM
+		workspace_builds
+	WHERE
+		workspace_builds.workspace_id = workspaces.id
+	ORDER BY
+		workspace_builds.build_number DESC
+	LIMIT 1
+) AS latest_build ON TRUE
+INNER JOIN
+	provisioner_jobs ON latest_build.job_id = provisioner_jobs.id
+WHERE
+	workspaces.template_id = $1
+	AND workspaces.deleted = false
+	-- Prebuilt workspaces are handled by the prebuilds reconciliation loop.
+	AND workspaces.owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::UUID
+ORDER BY
+	users.username ASC, workspaces.name ASC
+`
+
+type GetWorkspacesForTemplateMaintenanceRow struct {
+	ID                uuid.UUID            `db:"id" json:"id"`
+	Name              string               `db:"name" json:"name"`
+	OwnerID           uuid.UUID            `db:"owner_id" json:"owner_id"`
+	OwnerUsername     string               `db:"owner_username" json:"owner_username"`
+	LastUsedAt        time.Time            `db:"last_used_at" json:"last_used_at"`
+	DormantAt         sql.NullTime         `db:"dormant_at" json:"dormant_at"`
+	TemplateVersionID uuid.UUID            `db:"template_version_id" json:"template_version_id"`
+	Transition        WorkspaceTransition  `db:"transition" json:"transition"`
+	Reason            BuildReason          `db:"reason" json:"reason"`
+	BuildCreatedAt    time.Time            `db:"build_created_at" json:"build_created_at"`
+	JobStatus         ProvisionerJobStatus `db:"job_status" json:"job_status"`
+}
+
+// Returns the workspaces of a template together with their latest build. Used
+// by the lifecycle executor to notify owners ahead of a maintenance window and
+// to report on the outcome once the window closes.
+func (q *sqlQuerier) GetWorkspacesForTemplateMaintenance(ctx context.Context, templateID uuid.UUID) ([]GetWorkspacesForTemplateMaintenanceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspacesForTemplateMaintenance, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspacesForTemplateMaintenanceRow
+	for rows.Next() {
+		var i GetWorkspacesForTemplateMaintenanceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.OwnerID,
+			&i.OwnerUsername,
+			&i.LastUsedAt,
+			&i.DormantAt,
+			&i.TemplateVersionID,
+			&i.Transition,
+			&i.Reason,
+			&i.BuildCreatedAt,
+			&i.JobStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkspacesForWorkspaceMetrics = `-- name: GetWorkspacesForWorkspaceMetrics :many
 SELECT
     u.username as owner_username,
@@ -23755,10 +25382,11 @@ INSERT INTO
 		ttl,
 		last_used_at,
 		automatic_updates,
-		next_start_at
+		next_start_at,
+		source_workspace_id
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id
 `
 
 type InsertWorkspaceParams struct {
@@ -23774,6 +25402,7 @@ type InsertWorkspaceParams struct {
 	LastUsedAt        time.Time        `db:"last_used_at" json:"last_used_at"`
 	AutomaticUpdates  AutomaticUpdates `db:"automatic_updates" json:"automatic_updates"`
 	NextStartAt       sql.NullTime     `db:"next_start_at" json:"next_start_at"`
+	SourceWorkspaceID uuid.NullUUID    `db:"source_workspace_id" json:"source_workspace_id"`
 }
 
 func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspaceParams) (WorkspaceTable, error) {
@@ -23790,6 +25419,7 @@ func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspacePar
 		arg.LastUsedAt,
 		arg.AutomaticUpdates,
 		arg.NextStartAt,
+		arg.SourceWorkspaceID,
 	)
 	var i WorkspaceTable
 	err := row.Scan(
@@ -23811,6 +25441,7 @@ func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspacePar
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 	)
 	return i, err
 }
@@ -23850,7 +25481,7 @@ SET
 WHERE
 	id = $1
 	AND deleted = false
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id
 `
 
 type UpdateWorkspaceParams struct {
@@ -23880,6 +25511,7 @@ func (q *sqlQuerier) UpdateWorkspace(ctx context.Context, arg UpdateWorkspacePar
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 	)
 	return i, err
 }
@@ -23997,7 +25629,7 @@ WHERE
 	-- dormant_at and deleting_at
 	AND owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::UUID
 RETURNING
-    workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl
+    workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.next_start_at, workspaces.group_acl, workspaces.user_acl, workspaces.source_workspace_id
 `
 
 type UpdateWorkspaceDormantDeletingAtParams struct {
@@ -24027,6 +25659,7 @@ func (q *sqlQuerier) UpdateWorkspaceDormantDeletingAt(ctx context.Context, arg U
 		&i.NextStartAt,
 		&i.GroupACL,
 		&i.UserACL,
+		&i.SourceWorkspaceID,
 	)
 	return i, err
 }
@@ -24113,7 +25746,7 @@ WHERE
 	-- should not have their dormant or deleting at set, as these are handled by the
     -- prebuilds reconciliation loop.
 	AND workspaces.owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::UUID
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, next_start_at, group_acl, user_acl, source_workspace_id
 `
 
 type UpdateWorkspacesDormantDeletingAtByTemplateIDParams struct {
@@ -24150,6 +25783,7 @@ func (q *sqlQuerier) UpdateWorkspacesDormantDeletingAtByTemplateID(ctx context.C
 			&i.NextStartAt,
 			&i.GroupACL,
 			&i.UserACL,
+			&i.SourceWorkspaceID,
 		); err != nil {
 			return nil, err
 		}
@@ -24188,7 +25822,7 @@ func (q *sqlQuerier) UpdateWorkspacesTTLByTemplateID(ctx context.Context, arg Up
 }
 
 const getWorkspaceAgentScriptsByAgentIDs = `-- name: GetWorkspaceAgentScriptsByAgentIDs :many
-SELECT workspace_agent_id, log_source_id, log_path, created_at, script, cron, start_blocks_login, run_on_start, run_on_stop, timeout_seconds, display_name, id FROM workspace_agent_scripts WHERE workspace_agent_id = ANY($1 :: uuid [ ])
+SELECT workspace_agent_id, log_source_id, log_path, created_at, script, cron, start_blocks_login, run_on_start, run_on_stop, timeout_seconds, display_name, id, unit_name, depends_on FROM workspace_agent_scripts WHERE workspace_agent_id = ANY($1 :: uuid [ ])
 `
 
 func (q *sqlQuerier) GetWorkspaceAgentScriptsByAgentIDs(ctx context.Context, ids []uuid.UUID) ([]WorkspaceAgentScript, error) {
@@ -24213,6 +25847,8 @@ func (q *sqlQuerier) GetWorkspaceAgentScriptsByAgentIDs(ctx context.Context, ids
 			&i.TimeoutSeconds,
 			&i.DisplayName,
 			&i.ID,
+			&i.UnitName,
+			&i.DependsOn,
 		); err != nil {
 			return nil, err
 		}
@@ -24229,7 +25865,7 @@ func (q *sqlQuerier) GetWorkspaceAgentScriptsByAgentIDs(ctx context.Context, ids
 
 const insertWorkspaceAgentScripts = `-- name: InsertWorkspaceAgentScripts :many
 INSERT INTO
-	workspace_agent_scripts (workspace_agent_id, created_at, log_source_id, log_path, script, cron, start_blocks_login, run_on_start, run_on_stop, timeout_seconds, display_name, id)
+	workspace_agent_scripts (workspace_agent_id, created_at, log_source_id, log_path, script, cron, start_blocks_login, run_on_start, run_on_stop, timeout_seconds, display_name, id, unit_name, depends_on)
 SELECT
 	$1 :: uuid AS workspace_agent_id,
 	$2 :: timestamptz AS created_at,
@@ -24242,23 +25878,27 @@ SELECT
 	unnest($9 :: boolean [ ]) AS run_on_stop,
 	unnest($10 :: integer [ ]) AS timeout_seconds,
 	unnest($11 :: text [ ]) AS display_name,
-	unnest($12 :: uuid [ ]) AS id
-RETURNING workspace_agent_scripts.workspace_agent_id, workspace_agent_scripts.log_source_id, workspace_agent_scripts.log_path, workspace_agent_scripts.created_at, workspace_agent_scripts.script, workspace_agent_scripts.cron, workspace_agent_scripts.start_blocks_login, workspace_agent_scripts.run_on_start, workspace_agent_scripts.run_on_stop, workspace_agent_scripts.timeout_seconds, workspace_agent_scripts.display_name, workspace_agent_scripts.id
+	unnest($12 :: uuid [ ]) AS id,
+	unnest($13 :: text [ ]) AS unit_name,
+	jsonb_array_elements($14 :: jsonb) AS depends_on
+RETURNING workspace_agent_scripts.workspace_agent_id, workspace_agent_scripts.log_source_id, workspace_agent_scripts.log_path, workspace_agent_scripts.created_at, workspace_agent_scripts.script, workspace_agent_scripts.cron, workspace_agent_scripts.start_blocks_login, workspace_agent_scripts.run_on_start, workspace_agent_scripts.run_on_stop, workspace_agent_scripts.timeout_seconds, workspace_agent_scripts.display_name, workspace_agent_scripts.id, workspace_agent_scripts.unit_name, workspace_agent_scripts.depends_on
 `
 
 type InsertWorkspaceAgentScriptsParams struct {
-	WorkspaceAgentID uuid.UUID   `db:"workspace_agent_id" json:"workspace_agent_id"`
-	CreatedAt        time.Time   `db:"created_at" json:"created_at"`
-	LogSourceID      []uuid.UUID `db:"log_source_id" json:"log_source_id"`
-	LogPath          []string    `db:"log_path" json:"log_path"`
-	Script           []string    `db:"script" json:"script"`
-	Cron             []string    `db:"cron" json:"cron"`
-	StartBlocksLogin []bool      `db:"start_blocks_login" json:"start_blocks_login"`
-	RunOnStart       []bool      `db:"run_on_start" json:"run_on_start"`
-	RunOnStop        []bool      `db:"run_on_stop" json:"run_on_stop"`
-	TimeoutSeconds   []int32     `db:"timeout_seconds" json:"timeout_seconds"`
-	DisplayName      []string    `db:"display_name" json:"display_name"`
-	ID               []uuid.UUID `db:"id" json:"id"`
+	WorkspaceAgentID uuid.UUID       `db:"workspace_agent_id" json:"workspace_agent_id"`
+	CreatedAt        time.Time       `db:"created_at" json:"created_at"`
+	LogSourceID      []uuid.UUID     `db:"log_source_id" json:"log_source_id"`
+	LogPath          []string        `db:"log_path" json:"log_path"`
+	Script           []string        `db:"script" json:"script"`
+	Cron             []string        `db:"cron" json:"cron"`
+	StartBlocksLogin []bool          `db:"start_blocks_login" json:"start_blocks_login"`
+	RunOnStart       []bool          `db:"run_on_start" json:"run_on_start"`
+	RunOnStop        []bool          `db:"run_on_stop" json:"run_on_stop"`
+	TimeoutSeconds   []int32         `db:"timeout_seconds" json:"timeout_seconds"`
+	DisplayName      []string        `db:"display_name" json:"display_name"`
+	ID               []uuid.UUID     `db:"id" json:"id"`
+	UnitName         []string        `db:"unit_name" json:"unit_name"`
+	DependsOn        json.RawMessage `db:"depends_on" json:"depends_on"`
 }
 
 func (q *sqlQuerier) InsertWorkspaceAgentScripts(ctx context.Context, arg InsertWorkspaceAgentScriptsParams) ([]WorkspaceAgentScript, error) {
@@ -24275,6 +25915,8 @@ func (q *sqlQuerier) InsertWorkspaceAgentScripts(ctx context.Context, arg Insert
 		pq.Array(arg.TimeoutSeconds),
 		pq.Array(arg.DisplayName),
 		pq.Array(arg.ID),
+		pq.Array(arg.UnitName),
+		arg.DependsOn,
 	)
 	if err != nil {
 		return nil, err
@@ -24296,6 +25938,173 @@ func (q *sqlQuerier) InsertWorkspaceAgentScripts(ctx context.Context, arg Insert
 			&i.TimeoutSeconds,
 			&i.DisplayName,
 			&i.ID,
+			&i.UnitName,
+			&i.DependsOn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const appendWorkspaceSessionRecordingData = `-- name: AppendWorkspaceSessionRecordingData :execrows
+WITH recording AS (
+	UPDATE
+		workspace_session_recordings
+	SET
+		size = size + octet_length($1 :: bytea)
+	WHERE
+		id = $2
+		AND agent_id = $3
+		AND size = $4 :: bigint
+	RETURNING
+		id
+)
+INSERT INTO
+	workspace_session_recording_chunks (recording_id, offset_bytes, data)
+SELECT
+	id, $4 :: bigint, $1 :: bytea
+FROM
+	recording
+`
+
+type AppendWorkspaceSessionRecordingDataParams struct {
+	Data        []byte    `db:"data" json:"data"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
+	OffsetBytes int64     `db:"offset_bytes" json:"offset_bytes"`
+}
+
+// Recordings larger than the maximum agent API message size are uploaded in
+// parts, each of which is stored as a chunk. The offset guards against parts
+// being applied out of order or twice, and the agent ID against an agent
+// appending to another agent's recording.
+func (q *sqlQuerier) AppendWorkspaceSessionRecordingData(ctx context.Context, arg AppendWorkspaceSessionRecordingDataParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, appendWorkspaceSessionRecordingData,
+		arg.Data,
+		arg.ID,
+		arg.AgentID,
+		arg.OffsetBytes,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getWorkspaceSessionRecordingByID = `-- name: GetWorkspaceSessionRecordingByID :one
+SELECT
+	id, organization_id, workspace_id, agent_id, connection_id, type, started_at, ended_at, created_at, include_input, truncated, size
+FROM
+	workspace_session_recordings
+WHERE
+	id = $1
+`
+
+func (q *sqlQuerier) GetWorkspaceSessionRecordingByID(ctx context.Context, id uuid.UUID) (WorkspaceSessionRecording, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceSessionRecordingByID, id)
+	var i WorkspaceSessionRecording
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.WorkspaceID,
+		&i.AgentID,
+		&i.ConnectionID,
+		&i.Type,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.CreatedAt,
+		&i.IncludeInput,
+		&i.Truncated,
+		&i.Size,
+	)
+	return i, err
+}
+
+const getWorkspaceSessionRecordingChunks = `-- name: GetWorkspaceSessionRecordingChunks :many
+SELECT
+	recording_id, offset_bytes, data
+FROM
+	workspace_session_recording_chunks
+WHERE
+	recording_id = $1
+ORDER BY
+	offset_bytes ASC
+`
+
+func (q *sqlQuerier) GetWorkspaceSessionRecordingChunks(ctx context.Context, recordingID uuid.UUID) ([]WorkspaceSessionRecordingChunk, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceSessionRecordingChunks, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceSessionRecordingChunk
+	for rows.Next() {
+		var i WorkspaceSessionRecordingChunk
+		if err := rows.Scan(&i.RecordingID, &i.OffsetBytes, &i.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceSessionRecordingsByWorkspaceID = `-- name: GetWorkspaceSessionRecordingsByWorkspaceID :many
+SELECT
+	id, organization_id, workspace_id, agent_id, connection_id, type, started_at, ended_at, created_at, include_input, truncated, size
+FROM
+	workspace_session_recordings
+WHERE
+	workspace_id = $1
+ORDER BY
+	started_at DESC, id
+OFFSET $2
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF($3 :: int, 0)
+`
+
+type GetWorkspaceSessionRecordingsByWorkspaceIDParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	OffsetOpt   int32     `db:"offset_opt" json:"offset_opt"`
+	LimitOpt    int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+func (q *sqlQuerier) GetWorkspaceSessionRecordingsByWorkspaceID(ctx context.Context, arg GetWorkspaceSessionRecordingsByWorkspaceIDParams) ([]WorkspaceSessionRecording, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceSessionRecordingsByWorkspaceID, arg.WorkspaceID, arg.OffsetOpt, arg.LimitOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceSessionRecording
+	for rows.Next() {
+		var i WorkspaceSessionRecording
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.WorkspaceID,
+			&i.AgentID,
+			&i.ConnectionID,
+			&i.Type,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.CreatedAt,
+			&i.IncludeInput,
+			&i.Truncated,
+			&i.Size,
 		); err != nil {
 			return nil, err
 		}
@@ -24309,3 +26118,67 @@ func (q *sqlQuerier) InsertWorkspaceAgentScripts(ctx context.Context, arg Insert
 	}
 	return items, nil
 }
+
+const insertWorkspaceSessionRecording = `-- name: InsertWorkspaceSessionRecording :exec
+WITH recording AS (
+	INSERT INTO
+		workspace_session_recordings (
+			id,
+			organization_id,
+			workspace_id,
+			agent_id,
+			connection_id,
+			type,
+			started_at,
+			ended_at,
+			created_at,
+			include_input,
+			truncated,
+			size
+		)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, octet_length($12 :: bytea))
+	RETURNING
+		id
+)
+INSERT INTO
+	workspace_session_recording_chunks (recording_id, offset_bytes, data)
+SELECT
+	id, 0, $12 :: bytea
+FROM
+	recording
+`
+
+type InsertWorkspaceSessionRecordingParams struct {
+	ID             uuid.UUID      `db:"id" json:"id"`
+	OrganizationID uuid.UUID      `db:"organization_id" json:"organization_id"`
+	WorkspaceID    uuid.UUID      `db:"workspace_id" json:"workspace_id"`
+	AgentID        uuid.UUID      `db:"agent_id" json:"agent_id"`
+	ConnectionID   uuid.UUID      `db:"connection_id" json:"connection_id"`
+	Type           ConnectionType `db:"type" json:"type"`
+	StartedAt      time.Time      `db:"started_at" json:"started_at"`
+	EndedAt        time.Time      `db:"ended_at" json:"ended_at"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	IncludeInput   bool           `db:"include_input" json:"include_input"`
+	Truncated      bool           `db:"truncated" json:"truncated"`
+	Data           []byte         `db:"data" json:"data"`
+}
+
+// The first part of the recording is stored as its first chunk.
+func (q *sqlQuerier) InsertWorkspaceSessionRecording(ctx context.Context, arg InsertWorkspaceSessionRecordingParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceSessionRecording,
+		arg.ID,
+		arg.OrganizationID,
+		arg.WorkspaceID,
+		arg.AgentID,
+		arg.ConnectionID,
+		arg.Type,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.CreatedAt,
+		arg.IncludeInput,
+		arg.Truncated,
+		arg.Data,
+	)
+	return err
+}
@@ -19,7 +19,8 @@ SELECT
 	workspace_owner.username AS workspace_owner_username,
 	organizations.name AS organization_name,
 	organizations.display_name AS organization_display_name,
-	organizations.icon AS organization_icon
+	organizations.icon AS organization_icon,
+	workspace_session_recordings.id AS session_recording_id
 FROM
 	connection_logs
 JOIN users AS workspace_owner ON
@@ -28,6 +29,9 @@ LEFT JOIN users ON
 	connection_logs.user_id = users.id
 JOIN organizations ON
 	connection_logs.organization_id = organizations.id
+LEFT JOIN workspace_session_recordings ON
+	connection_logs.workspace_id = workspace_session_recordings.workspace_id
+	AND connection_logs.connection_id = workspace_session_recordings.connection_id
 WHERE
 	-- Filter organization_id
 	CASE
@@ -62,7 +66,7 @@ WHERE
 	-- Filter by type
 	AND CASE
 		WHEN @type :: text != '' THEN
-			type = @type :: connection_type
+			connection_logs.type = @type :: connection_type
 		ELSE true
 	END
 	-- Filter by user_id
@@ -116,7 +120,7 @@ WHERE
 			((@status = 'ongoing' AND disconnect_time IS NULL) OR
 			(@status = 'completed' AND disconnect_time IS NOT NULL)) AND
 			-- Exclude web events, since we don't know their close time.
-			"type" NOT IN ('workspace_app', 'port_forwarding')
+			connection_logs."type" NOT IN ('workspace_app', 'port_forwarding')
 		ELSE true
 	END
 	-- Authorize Filter clause will be injected below in
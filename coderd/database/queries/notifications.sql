@@ -14,7 +14,7 @@ WHERE nt.id = @notification_template_id
   AND u.id = @user_id;
 
 -- name: EnqueueNotificationMessage :exec
-INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at)
+INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by, created_at, notification_target_id)
 VALUES (@id,
         @notification_template_id,
         @user_id,
@@ -22,7 +22,8 @@ VALUES (@id,
         @payload::jsonb,
         @targets,
         @created_by,
-        @created_at);
+        @created_at,
+        @notification_target_id);
 
 -- Acquires the lease for a given count of notification messages, to enable concurrent dequeuing and subsequent sending.
 -- Only rows that aren't already leased (or ones which are leased but have exceeded their lease period) are returned.
@@ -84,16 +85,20 @@ SELECT
     nm.method,
     nm.attempt_count::int                                                 AS attempt_count,
     nm.queued_seconds::float                                              AS queued_seconds,
+    nm.notification_target_id,
     -- template
     nt.id                                                                 AS template_id,
     nt.title_template,
     nt.body_template,
     -- preferences
-    (CASE WHEN np.disabled IS NULL THEN false ELSE np.disabled END)::bool AS disabled
+    (CASE WHEN np.disabled IS NULL THEN false ELSE np.disabled END)::bool AS disabled,
+    -- target
+    COALESCE(ntg.address, '')::text                                       AS notification_target_address
 FROM acquired nm
          JOIN notification_templates nt ON nm.notification_template_id = nt.id
          LEFT JOIN notification_preferences AS np
-                   ON (np.user_id = nm.user_id AND np.notification_template_id = nm.notification_template_id);
+                   ON (np.user_id = nm.user_id AND np.notification_template_id = nm.notification_template_id)
+         LEFT JOIN notification_targets AS ntg ON nm.notification_target_id = ntg.id;
 
 -- name: BulkMarkNotificationMessagesFailed :execrows
 UPDATE notification_messages
@@ -104,6 +109,8 @@ SET queued_seconds   = 0,
                            WHEN attempt_count + 1 < @max_attempts::int THEN subquery.status
                            ELSE 'permanent_failure'::notification_message_status END,
     status_reason    = subquery.status_reason,
+    -- Zero is used for failures without an HTTP response, since arrays of parameters cannot contain NULLs.
+    response_status_code = NULLIF(subquery.response_status_code, 0),
     leased_until     = NULL,
     next_retry_after = CASE
                            WHEN (attempt_count + 1 < @max_attempts::int)
@@ -111,7 +118,8 @@ SET queued_seconds   = 0,
 FROM (SELECT UNNEST(@ids::uuid[])                             AS id,
              UNNEST(@failed_ats::timestamptz[])               AS failed_at,
              UNNEST(@statuses::notification_message_status[]) AS status,
-             UNNEST(@status_reasons::text[])                  AS status_reason) AS subquery
+             UNNEST(@status_reasons::text[])                  AS status_reason,
+             UNNEST(@response_status_codes::int[])            AS response_status_code) AS subquery
 WHERE notification_messages.id = subquery.id;
 
 -- name: BulkMarkNotificationMessagesSent :execrows
@@ -121,6 +129,7 @@ SET queued_seconds   = 0,
     attempt_count    = attempt_count + 1,
     status           = 'sent'::notification_message_status,
     status_reason    = NULL,
+    response_status_code = NULL,
     leased_until     = NULL,
     next_retry_after = NULL
 FROM (SELECT UNNEST(@ids::uuid[])             AS id,
@@ -137,6 +146,28 @@ WHERE id IN
        FROM notification_messages AS nested
        WHERE nested.updated_at < NOW() - INTERVAL '7 days');
 
+-- Returns the most recently enqueued notification messages, optionally only those of the given method, along with the
+-- outcome of their last delivery attempt.
+-- name: GetNotificationMessageDeliveries :many
+SELECT nm.id,
+       nm.notification_template_id,
+       nt.name AS notification_template_name,
+       nm.user_id,
+       nm.method,
+       nm.notification_target_id,
+       nm.status,
+       nm.status_reason,
+       nm.response_status_code,
+       nm.attempt_count,
+       nm.created_at,
+       nm.updated_at,
+       nm.next_retry_after
+FROM notification_messages AS nm
+         JOIN notification_templates AS nt ON nt.id = nm.notification_template_id
+WHERE (@method::text = '' OR nm.method::text = @method::text)
+ORDER BY nm.created_at DESC, nm.id
+LIMIT sqlc.arg('limit')::int;
+
 -- name: GetNotificationMessagesByStatus :many
 SELECT *
 FROM notification_messages
@@ -158,6 +189,107 @@ ON CONFLICT (user_id, notification_template_id) DO UPDATE
     SET disabled   = EXCLUDED.disabled,
         updated_at = CURRENT_TIMESTAMP;
 
+-- name: GetUserNotificationPreferenceTargets :many
+SELECT *
+FROM notification_preference_targets
+WHERE user_id = @user_id::uuid
+ORDER BY notification_template_id, created_at;
+
+-- name: DeleteUserNotificationPreferenceTargets :exec
+DELETE
+FROM notification_preference_targets
+WHERE user_id = @user_id::uuid
+  AND notification_template_id = ANY (@notification_template_ids::uuid[]);
+
+-- name: InsertUserNotificationPreferenceTargets :execrows
+-- Routes notifications of the given templates to the given targets. Targets which do not belong to the user are ignored.
+INSERT
+INTO notification_preference_targets (user_id, notification_template_id, notification_target_id)
+SELECT @user_id::uuid, new_values.notification_template_id, new_values.notification_target_id
+FROM (SELECT UNNEST(@notification_template_ids::uuid[]) AS notification_template_id,
+             UNNEST(@notification_target_ids::uuid[])   AS notification_target_id) AS new_values
+         JOIN notification_targets ON notification_targets.id = new_values.notification_target_id
+WHERE notification_targets.user_id = @user_id::uuid
+ON CONFLICT DO NOTHING;
+
+-- name: GetNotificationTargetByID :one
+SELECT *
+FROM notification_targets
+WHERE id = @id::uuid;
+
+-- name: GetNotificationTargetsByUserID :many
+SELECT *
+FROM notification_targets
+WHERE user_id = @user_id::uuid
+ORDER BY name ASC;
+
+-- name: GetVerifiedNotificationTargetsByTemplateID :many
+-- Returns the verified notification targets to which the user routes notifications of the given template.
+SELECT notification_targets.*
+FROM notification_targets
+         JOIN notification_preference_targets AS npt ON npt.notification_target_id = notification_targets.id
+WHERE npt.user_id = @user_id::uuid
+  AND npt.notification_template_id = @notification_template_id::uuid
+  AND notification_targets.verified_at IS NOT NULL
+ORDER BY notification_targets.name ASC;
+
+-- name: InsertNotificationTarget :one
+INSERT INTO notification_targets (id, user_id, name, method, address, created_at, updated_at)
+VALUES (@id, @user_id, @name, @method, @address, @created_at, @updated_at)
+RETURNING *;
+
+-- name: UpdateNotificationTargetVerificationCode :one
+UPDATE notification_targets
+SET hashed_verification_code     = @hashed_verification_code,
+    verification_code_expires_at = @verification_code_expires_at::timestamptz,
+    updated_at                   = @updated_at
+WHERE id = @id::uuid
+RETURNING *;
+
+-- name: UpdateNotificationTargetVerifiedAt :one
+-- Marks the notification target as verified, and clears its verification code so that it cannot be reused.
+UPDATE notification_targets
+SET verified_at                  = @verified_at::timestamptz,
+    hashed_verification_code     = NULL,
+    verification_code_expires_at = NULL,
+    updated_at                   = @verified_at::timestamptz
+WHERE id = @id::uuid
+RETURNING *;
+
+-- name: DeleteNotificationTargetByID :exec
+DELETE
+FROM notification_targets
+WHERE id = @id::uuid;
+
+-- name: GetOrganizationNotificationWebhooks :many
+SELECT *
+FROM organization_notification_webhooks
+WHERE organization_id = @organization_id::uuid
+ORDER BY method ASC;
+
+-- name: GetOrganizationNotificationWebhooksByUserID :many
+-- Returns the incoming webhooks of the given method configured by the organizations the user is a member of.
+SELECT organization_notification_webhooks.*
+FROM organization_notification_webhooks
+         JOIN organization_members AS om ON om.organization_id = organization_notification_webhooks.organization_id
+WHERE om.user_id = @user_id::uuid
+  AND organization_notification_webhooks.method = @method::notification_method
+ORDER BY organization_notification_webhooks.organization_id ASC;
+
+-- name: UpsertOrganizationNotificationWebhook :one
+INSERT INTO organization_notification_webhooks (organization_id, method, endpoint, created_at, updated_at)
+VALUES (@organization_id, @method, @endpoint, @created_at, @updated_at)
+ON CONFLICT (organization_id, method) DO UPDATE
+    SET endpoint   = EXCLUDED.endpoint,
+        updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: DeleteOrganizationNotificationWebhook :exec
+DELETE
+FROM organization_notification_webhooks
+WHERE organization_id = @organization_id::uuid
+  AND method = @method::notification_method;
+
 -- name: UpdateNotificationTemplateMethodByID :one
 UPDATE notification_templates
 SET method = sqlc.narg('method')::notification_method
@@ -28,6 +28,23 @@ WHERE w.id IN (
 		AND b.template_version_id = t.active_version_id
 		AND p.current_preset_id = @preset_id::uuid
 		AND p.ready
+		-- Prebuilds of presets with readiness checks can only be claimed once their latest build passed the checks.
+		AND (
+			NOT EXISTS (
+				SELECT 1
+				FROM template_preset_readiness_checks rc
+					INNER JOIN template_version_presets tvp ON tvp.name = rc.preset_name
+				WHERE rc.template_id = t.id
+					AND tvp.id = p.current_preset_id
+			)
+			OR EXISTS (
+				SELECT 1
+				FROM workspace_prebuild_readiness r
+				WHERE r.workspace_id = p.id
+					AND r.build_id = b.id
+					AND r.status = 'passed'::prebuild_readiness_status
+			)
+		)
 		AND NOT t.deleted
 	LIMIT 1 FOR UPDATE OF p SKIP LOCKED -- Ensure that a concurrent request will not select the same prebuild.
 )
@@ -145,6 +162,7 @@ GROUP BY t.id, wpb.template_version_id, wpb.transition, wlb.template_version_pre
 -- Query returns a list of preset IDs for which we should backoff.
 -- Only active template versions with configured presets are considered.
 -- We also return the number of failed workspace builds that occurred during the lookback period.
+-- Builds of prebuilds which failed their preset's readiness checks count as failed builds.
 --
 -- NOTE:
 -- - To **decide whether to back off**, we look at up to the N most recent builds (within the defined lookback period).
@@ -153,17 +171,36 @@ GROUP BY t.id, wpb.template_version_id, wpb.transition, wlb.template_version_pre
 -- The number of failed builds is used downstream to determine the backoff duration.
 -- name: GetPresetsBackoff :many
 WITH filtered_builds AS (
-	-- Only select builds which are for prebuild creations
-	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id, wlb.job_status, tvp.desired_instances
+	-- Only select builds which are for prebuild creations.
+	-- Prebuilds which failed their preset's readiness checks count as failed builds.
+	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id,
+		CASE WHEN wpr.status = 'failed'::prebuild_readiness_status THEN 'failed'::provisioner_job_status ELSE wlb.job_status END AS job_status,
+		tvp.desired_instances
 	FROM template_version_presets tvp
 			INNER JOIN workspace_latest_builds wlb ON wlb.template_version_preset_id = tvp.id
 			INNER JOIN workspaces w ON wlb.workspace_id = w.id
 			INNER JOIN template_versions tv ON wlb.template_version_id = tv.id
 			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+			LEFT JOIN workspace_prebuild_readiness wpr ON wpr.build_id = wlb.id
 	WHERE tvp.desired_instances IS NOT NULL -- Consider only presets that have a prebuild configuration.
 		AND wlb.transition = 'start'::workspace_transition
 		AND w.owner_id = 'c42fdf75-3097-471c-8c33-fb52454d81c0'
 		AND NOT t.deleted
+	UNION ALL
+	-- As do the builds of prebuilds which have since been deleted because they failed the checks.
+	SELECT wb.template_version_id, wb.created_at, tvp.id AS preset_id, 'failed'::provisioner_job_status AS job_status, tvp.desired_instances
+	FROM workspace_prebuild_readiness wpr
+			INNER JOIN workspace_builds wb ON wb.id = wpr.build_id
+			INNER JOIN template_version_presets tvp ON wb.template_version_preset_id = tvp.id
+			INNER JOIN template_versions tv ON wb.template_version_id = tv.id
+			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+	WHERE tvp.desired_instances IS NOT NULL
+		AND wpr.status = 'failed'::prebuild_readiness_status
+		AND NOT t.deleted
+		AND EXISTS (
+			SELECT 1 FROM workspace_builds newer
+			WHERE newer.workspace_id = wb.workspace_id AND newer.build_number > wb.build_number
+		)
 ),
 time_sorted_builds AS (
 	-- Group builds by preset, then sort each group by created_at.
@@ -195,20 +232,39 @@ GROUP BY tsb.template_version_id, tsb.preset_id, fc.num_failed;
 -- Each preset is associated with exactly one template version ID.
 -- For each preset, the query checks the last hard_limit builds.
 -- If all of them failed, the preset is considered to have hit the hard failure limit.
+-- Builds of prebuilds which failed their preset's readiness checks count as failed builds.
 -- The query returns a list of preset IDs that have reached this failure threshold.
 -- Only active template versions with configured presets are considered.
 -- name: GetPresetsAtFailureLimit :many
 WITH filtered_builds AS (
-	-- Only select builds which are for prebuild creations
-	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id, wlb.job_status, tvp.desired_instances
+	-- Only select builds which are for prebuild creations.
+	-- Prebuilds which failed their preset's readiness checks count as failed builds.
+	SELECT wlb.template_version_id, wlb.created_at, tvp.id AS preset_id,
+		CASE WHEN wpr.status = 'failed'::prebuild_readiness_status THEN 'failed'::provisioner_job_status ELSE wlb.job_status END AS job_status,
+		tvp.desired_instances
 	FROM template_version_presets tvp
 			INNER JOIN workspace_latest_builds wlb ON wlb.template_version_preset_id = tvp.id
 			INNER JOIN workspaces w ON wlb.workspace_id = w.id
 			INNER JOIN template_versions tv ON wlb.template_version_id = tv.id
 			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+			LEFT JOIN workspace_prebuild_readiness wpr ON wpr.build_id = wlb.id
 	WHERE tvp.desired_instances IS NOT NULL -- Consider only presets that have a prebuild configuration.
 		AND wlb.transition = 'start'::workspace_transition
 		AND w.owner_id = 'c42fdf75-3097-471c-8c33-fb52454d81c0'
+	UNION ALL
+	-- As do the builds of prebuilds which have since been deleted because they failed the checks.
+	SELECT wb.template_version_id, wb.created_at, tvp.id AS preset_id, 'failed'::provisioner_job_status AS job_status, tvp.desired_instances
+	FROM workspace_prebuild_readiness wpr
+			INNER JOIN workspace_builds wb ON wb.id = wpr.build_id
+			INNER JOIN template_version_presets tvp ON wb.template_version_preset_id = tvp.id
+			INNER JOIN template_versions tv ON wb.template_version_id = tv.id
+			INNER JOIN templates t ON tv.template_id = t.id AND t.active_version_id = tv.id
+	WHERE tvp.desired_instances IS NOT NULL
+		AND wpr.status = 'failed'::prebuild_readiness_status
+		AND EXISTS (
+			SELECT 1 FROM workspace_builds newer
+			WHERE newer.workspace_id = wb.workspace_id AND newer.build_number > wb.build_number
+		)
 ),
 time_sorted_builds AS (
 	-- Group builds by preset, then sort each group by created_at.
@@ -372,3 +428,186 @@ FROM orgs_with_prebuilds owp
 LEFT JOIN prebuild_groups pg ON pg.organization_id = owp.id
 LEFT JOIN prebuild_user_membership pum ON pum.organization_id = owp.id
 LEFT JOIN prebuild_group_membership pgm ON pgm.organization_id = owp.id;
+
+-- name: GetPrebuildClaimHistory :many
+-- GetPrebuildClaimHistory returns when prebuilt workspaces of the given templates were claimed,
+-- along with the name of the preset they were built from. Presets are matched by name because
+-- each template version has its own presets.
+-- A prebuilt workspace is claimed by the first build that was not initiated by the prebuilds system user.
+SELECT
+	w.template_id,
+	tvp.name AS preset_name,
+	claims.created_at AS claimed_at
+FROM workspace_prebuild_builds wpb
+INNER JOIN workspaces w ON w.id = wpb.workspace_id
+INNER JOIN template_version_presets tvp ON tvp.id = wpb.template_version_preset_id
+INNER JOIN LATERAL (
+	SELECT wb.created_at
+	FROM workspace_builds wb
+	WHERE wb.workspace_id = wpb.workspace_id
+		AND wb.initiator_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::uuid -- The system user responsible for prebuilds.
+	ORDER BY wb.build_number ASC
+	LIMIT 1
+) claims ON TRUE
+WHERE wpb.build_number = 1
+	AND w.template_id = ANY(@template_ids::uuid[])
+	AND claims.created_at >= @since::timestamptz
+ORDER BY claims.created_at ASC;
+
+-- name: GetEnabledTemplateAdaptivePrebuilds :many
+SELECT
+	*
+FROM
+	template_adaptive_prebuilds
+WHERE
+	enabled;
+
+-- name: GetTemplateAdaptivePrebuildsByTemplateID :one
+SELECT
+	*
+FROM
+	template_adaptive_prebuilds
+WHERE
+	template_id = @template_id;
+
+-- name: UpsertTemplateAdaptivePrebuilds :one
+INSERT INTO
+	template_adaptive_prebuilds (
+		template_id,
+		enabled,
+		min_instances,
+		max_instances,
+		lookback_weeks,
+		created_at,
+		updated_at
+	)
+VALUES
+	(
+		@template_id,
+		@enabled,
+		@min_instances,
+		@max_instances,
+		@lookback_weeks,
+		@created_at,
+		@updated_at
+	)
+ON CONFLICT (template_id) DO UPDATE SET
+	enabled = EXCLUDED.enabled,
+	min_instances = EXCLUDED.min_instances,
+	max_instances = EXCLUDED.max_instances,
+	lookback_weeks = EXCLUDED.lookback_weeks,
+	updated_at = EXCLUDED.updated_at
+RETURNING
+	*;
+
+-- name: GetTemplatePresetReadinessChecks :many
+-- GetTemplatePresetReadinessChecks returns the readiness checks of template presets.
+-- If template_id is specified, only the checks of that template are returned.
+SELECT
+	*
+FROM
+	template_preset_readiness_checks
+WHERE
+	(sqlc.narg('template_id')::uuid IS NULL OR template_id = sqlc.narg('template_id')::uuid)
+ORDER BY
+	template_id, preset_name;
+
+-- name: UpsertTemplatePresetReadinessChecks :one
+INSERT INTO
+	template_preset_readiness_checks (
+		template_id,
+		preset_name,
+		checks,
+		timeout,
+		created_at,
+		updated_at
+	)
+VALUES
+	(
+		@template_id,
+		@preset_name,
+		@checks,
+		@timeout,
+		@created_at,
+		@updated_at
+	)
+ON CONFLICT (template_id, preset_name) DO UPDATE SET
+	checks = EXCLUDED.checks,
+	timeout = EXCLUDED.timeout,
+	updated_at = EXCLUDED.updated_at
+RETURNING
+	*;
+
+-- name: DeleteTemplatePresetReadinessChecks :one
+DELETE FROM
+	template_preset_readiness_checks
+WHERE
+	template_id = @template_id
+	AND preset_name = @preset_name
+RETURNING
+	*;
+
+-- name: GetPrebuildReadiness :many
+-- GetPrebuildReadiness returns the readiness check results of prebuilt workspaces.
+-- Results of earlier builds are stale and therefore omitted.
+-- If template_id is specified, only the results of that template's prebuilt workspaces are returned.
+SELECT
+	wpr.*
+FROM
+	workspace_prebuild_readiness wpr
+	INNER JOIN workspace_latest_builds wlb ON wlb.workspace_id = wpr.workspace_id AND wlb.id = wpr.build_id
+	INNER JOIN workspaces w ON w.id = wpr.workspace_id
+WHERE
+	w.owner_id = 'c42fdf75-3097-471c-8c33-fb52454d81c0'::uuid -- The system user responsible for prebuilds.
+	AND NOT w.deleted
+	AND (sqlc.narg('template_id')::uuid IS NULL OR w.template_id = sqlc.narg('template_id')::uuid);
+
+-- name: UpsertPrebuildReadiness :exec
+INSERT INTO
+	workspace_prebuild_readiness (
+		workspace_id,
+		build_id,
+		status,
+		failure_reasons,
+		checked_at
+	)
+VALUES
+	(
+		@workspace_id,
+		@build_id,
+		@status,
+		@failure_reasons,
+		@checked_at
+	)
+ON CONFLICT (workspace_id) DO UPDATE SET
+	build_id = EXCLUDED.build_id,
+	status = EXCLUDED.status,
+	failure_reasons = EXCLUDED.failure_reasons,
+	checked_at = EXCLUDED.checked_at;
+
+-- name: DeleteStalePrebuildReadiness :exec
+-- DeleteStalePrebuildReadiness deletes the readiness check results of workspaces
+-- that are no longer prebuilt workspaces, because they were claimed or deleted.
+-- Failed results of deleted prebuilds are kept while their template version is active.
+DELETE FROM
+	workspace_prebuild_readiness wpr
+USING
+	workspaces w
+WHERE
+	w.id = wpr.workspace_id
+	AND (
+		w.owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::uuid
+		OR (
+			w.deleted
+			-- Failed results of deleted prebuilds are kept while they count towards the backoff and
+			-- failure limit of their preset, which only consider the template's active version.
+			AND (
+				wpr.status != 'failed'::prebuild_readiness_status
+				OR NOT EXISTS (
+					SELECT 1 FROM workspace_builds wb
+					INNER JOIN templates t ON t.active_version_id = wb.template_version_id
+					WHERE wb.id = wpr.build_id
+				)
+			)
+		)
+	);
@@ -7,7 +7,32 @@ WHERE
 	job_id = @job_id
 	AND (
 		id > @created_after
-	) ORDER BY id ASC;
+	)
+	-- Filter by stage, if any are provided
+	AND CASE
+		WHEN cardinality(@stages :: text[]) > 0 THEN
+			stage = ANY(@stages :: text[])
+		ELSE true
+	END
+	-- Filter by level, if any are provided
+	AND CASE
+		WHEN cardinality(@levels :: log_level[]) > 0 THEN
+			level = ANY(@levels :: log_level[])
+		ELSE true
+	END
+	-- Filter by output, case-insensitive
+	AND CASE
+		WHEN @search :: text != '' THEN
+			output ILIKE '%' || @search || '%'
+		ELSE true
+	END
+	-- Filter by creation time
+	AND CASE
+		WHEN @created_at_since :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			created_at >= @created_at_since
+		ELSE true
+	END
+ORDER BY id ASC;
 
 -- name: InsertProvisionerJobLogs :many
 INSERT INTO
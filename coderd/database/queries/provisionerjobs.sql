@@ -345,3 +345,13 @@ RETURNING *;
 SELECT * FROM provisioner_job_timings
 WHERE job_id = $1
 ORDER BY started_at ASC;
+
+-- name: InsertProvisionerJobResourceChange :one
+INSERT INTO provisioner_job_resource_changes (job_id, address, type, name, action, replace_paths)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetProvisionerJobResourceChangesByJobID :many
+SELECT * FROM provisioner_job_resource_changes
+WHERE job_id = $1
+ORDER BY address ASC;
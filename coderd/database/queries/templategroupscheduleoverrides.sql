@@ -0,0 +1,67 @@
+-- name: GetTemplateGroupScheduleOverrides :many
+SELECT
+	*
+FROM
+	template_group_schedule_overrides
+WHERE
+	template_id = @template_id
+ORDER BY
+	group_id;
+
+-- name: GetTemplateGroupScheduleOverridesByUserID :many
+-- Returns the schedule overrides of a template that apply to a user through
+-- their group memberships.
+SELECT
+	template_group_schedule_overrides.*
+FROM
+	template_group_schedule_overrides
+JOIN
+	group_members_expanded
+ON
+	group_members_expanded.group_id = template_group_schedule_overrides.group_id
+WHERE
+	template_group_schedule_overrides.template_id = @template_id
+	AND group_members_expanded.user_id = @user_id
+ORDER BY
+	template_group_schedule_overrides.group_id;
+
+-- name: UpsertTemplateGroupScheduleOverride :one
+INSERT INTO
+	template_group_schedule_overrides (
+		template_id,
+		group_id,
+		max_ttl,
+		autostart_block_days_of_week,
+		autostop_requirement_days_of_week,
+		autostop_requirement_weeks,
+		created_at,
+		updated_at
+	)
+VALUES
+	(
+		@template_id,
+		@group_id,
+		@max_ttl,
+		@autostart_block_days_of_week,
+		@autostop_requirement_days_of_week,
+		@autostop_requirement_weeks,
+		@created_at,
+		@updated_at
+	)
+ON CONFLICT (template_id, group_id) DO UPDATE SET
+	max_ttl = EXCLUDED.max_ttl,
+	autostart_block_days_of_week = EXCLUDED.autostart_block_days_of_week,
+	autostop_requirement_days_of_week = EXCLUDED.autostop_requirement_days_of_week,
+	autostop_requirement_weeks = EXCLUDED.autostop_requirement_weeks,
+	updated_at = EXCLUDED.updated_at
+RETURNING
+	*;
+
+-- name: DeleteTemplateGroupScheduleOverride :one
+DELETE FROM
+	template_group_schedule_overrides
+WHERE
+	template_id = @template_id
+	AND group_id = @group_id
+RETURNING
+	*;
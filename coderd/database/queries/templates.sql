@@ -118,6 +118,17 @@ SELECT * FROM template_with_names AS templates
 ORDER BY (name, id) ASC
 ;
 
+-- name: GetTemplatesWithMaintenanceWindow :many
+SELECT
+	*
+FROM
+	template_with_names AS templates
+WHERE
+	deleted = false AND
+	maintenance_window_schedule != ''
+ORDER BY (name, id) ASC
+;
+
 -- name: InsertTemplate :exec
 INSERT INTO
 	templates (
@@ -174,7 +185,11 @@ SET
 	max_port_sharing_level = $9,
 	use_classic_parameter_flow = $10,
 	cors_behavior = $11,
-	disable_module_cache = $12
+	disable_module_cache = $12,
+	session_recording_enabled = $13,
+	session_recording_include_input = $14,
+	maintenance_window_schedule = $15,
+	hibernate_idle_threshold = $16
 WHERE
 	id = $1
 ;
@@ -197,7 +197,32 @@ WHERE
 	agent_id = $1
 	AND (
 		id > @created_after
-	) ORDER BY id ASC;
+	)
+	-- Filter by log source, if any are provided
+	AND CASE
+		WHEN cardinality(@log_source_ids :: uuid[]) > 0 THEN
+			log_source_id = ANY(@log_source_ids :: uuid[])
+		ELSE true
+	END
+	-- Filter by level, if any are provided
+	AND CASE
+		WHEN cardinality(@levels :: log_level[]) > 0 THEN
+			level = ANY(@levels :: log_level[])
+		ELSE true
+	END
+	-- Filter by output, case-insensitive
+	AND CASE
+		WHEN @search :: text != '' THEN
+			output ILIKE '%' || @search || '%'
+		ELSE true
+	END
+	-- Filter by creation time
+	AND CASE
+		WHEN @created_at_since :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			created_at >= @created_at_since
+		ELSE true
+	END
+ORDER BY id ASC;
 
 -- name: InsertWorkspaceAgentLogs :many
 WITH new_length AS (
@@ -361,7 +386,7 @@ WHERE
 		WHEN workspace_build_with_user.transition = 'start'
 			-- Agent's START build job succeeded.
 			AND (SELECT job_status FROM provisioner_jobs WHERE id = workspace_build_with_user.job_id) = 'succeeded'
-			-- Latest build is a STOP build whose job is still active,
+			-- Latest build is a STOP or HIBERNATE build whose job is still active,
 			-- and agent's build is immediately previous.
 			AND EXISTS (
 				SELECT 1
@@ -374,7 +399,7 @@ WHERE
 					FROM workspace_builds l2
 					WHERE l2.workspace_id = latest.workspace_id
 				)
-				AND latest.transition = 'stop'
+				AND latest.transition IN ('stop', 'hibernate')
 				AND pj.job_status IN ('pending', 'running')
 			) THEN TRUE
 		ELSE FALSE
@@ -179,7 +179,7 @@ WHERE
 					latest_build.transition = 'start'::workspace_transition
 				WHEN @status = 'stopping' THEN
 					latest_build.job_status = 'running'::provisioner_job_status AND
-					latest_build.transition = 'stop'::workspace_transition
+					latest_build.transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition)
 				WHEN @status = 'deleting' THEN
 					latest_build.job_status = 'running' AND
 					latest_build.transition = 'delete'::workspace_transition
@@ -190,7 +190,7 @@ WHERE
 			    	latest_build.transition = 'delete'::workspace_transition
 				WHEN @status = 'stopped' THEN
 					latest_build.job_status = 'succeeded'::provisioner_job_status AND
-					latest_build.transition = 'stop'::workspace_transition
+					latest_build.transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition)
 				WHEN @status = 'started' THEN
 					latest_build.job_status = 'succeeded'::provisioner_job_status AND
 					latest_build.transition = 'start'::workspace_transition
@@ -440,6 +440,7 @@ WHERE
 		'0001-01-01 00:00:00+00'::timestamptz, -- next_start_at
 		'{}'::jsonb, -- group_acl
 		'{}'::jsonb, -- user_acl
+		'00000000-0000-0000-0000-000000000000'::uuid, -- source_workspace_id
 		'', -- owner_avatar_url
 		'', -- owner_username
 		'', -- owner_name
@@ -511,10 +512,11 @@ INSERT INTO
 		ttl,
 		last_used_at,
 		automatic_updates,
-		next_start_at
+		next_start_at,
+		source_workspace_id
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING *;
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING *;
 
 -- name: UpdateWorkspaceDeletedByID :exec
 UPDATE
@@ -670,7 +672,7 @@ WITH workspaces_with_jobs AS (
 		completed_at IS NOT NULL AND
 		canceled_at IS NULL AND
 		error IS NULL AND
-		transition = 'stop'::workspace_transition
+		transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition)
 )
 SELECT
 	pending_workspaces.count AS pending_workspaces,
@@ -725,14 +727,14 @@ WHERE
 		-- A workspace may be eligible for autostart if the following are true:
 		--   * The workspace's owner is active.
 		--   * The provisioner job did not fail.
-		--   * The workspace build was a stop transition.
+		--   * The workspace build was a stop or hibernate transition.
 		--   * The workspace is not dormant
 		--   * The workspace has an autostart schedule.
 		--   * It is after the workspace's next start time.
 		(
 			users.status = 'active'::user_status AND
 			provisioner_jobs.job_status != 'failed'::provisioner_job_status AND
-			workspace_builds.transition = 'stop'::workspace_transition AND
+			workspace_builds.transition IN ('stop'::workspace_transition, 'hibernate'::workspace_transition) AND
 			workspaces.dormant_at IS NULL AND
 			workspaces.autostart_schedule IS NOT NULL AND
 			(
@@ -798,6 +800,29 @@ WHERE
 			provisioner_jobs.job_status = 'failed'::provisioner_job_status AND
 			provisioner_jobs.completed_at IS NOT NULL AND
 			(@now :: timestamptz) - provisioner_jobs.completed_at > (INTERVAL '1 millisecond' * (templates.failure_ttl / 1000000))
+		) OR
+
+		-- A workspace may be eligible for a maintenance stop if the following are true:
+		--   * The template has a maintenance window.
+		--   * The workspace is not dormant.
+		--   * The workspace build was a successful start transition.
+		--   * The workspace build is not on the active template version.
+		-- Whether the window is open and the workspace is idle is checked by the
+		-- lifecycle executor.
+		(
+			templates.maintenance_window_schedule != '' AND
+			workspaces.dormant_at IS NULL AND
+			workspace_builds.transition = 'start'::workspace_transition AND
+			provisioner_jobs.job_status = 'succeeded'::provisioner_job_status AND
+			workspace_builds.template_version_id != templates.active_version_id
+		) OR
+
+		-- A workspace may be eligible for a maintenance start if the following are true:
+		--   * The workspace build was a successful stop transition made for maintenance.
+		(
+			workspace_builds.transition = 'stop'::workspace_transition AND
+			workspace_builds.reason = 'maintenance'::build_reason AND
+			provisioner_jobs.job_status = 'succeeded'::provisioner_job_status
 		)
 	)
   	AND workspaces.deleted = 'false'
@@ -806,6 +831,51 @@ WHERE
 	-- prebuilds reconciliation loop.
   	AND workspaces.owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::UUID;
 
+-- name: GetWorkspacesForTemplateMaintenance :many
+-- Returns the workspaces of a template together with their latest build. Used
+-- by the lifecycle executor to notify owners ahead of a maintenance window and
+-- to report on the outcome once the window closes.
+SELECT
+	workspaces.id,
+	workspaces.name,
+	workspaces.owner_id,
+	users.username AS owner_username,
+	workspaces.last_used_at,
+	workspaces.dormant_at,
+	latest_build.template_version_id,
+	latest_build.transition,
+	latest_build.reason,
+	latest_build.created_at AS build_created_at,
+	provisioner_jobs.job_status
+FROM
+	workspaces
+INNER JOIN
+	users ON workspaces.owner_id = users.id
+INNER JOIN LATERAL (
+	SELECT
+		workspace_builds.template_version_id,
+		workspace_builds.transition,
+		workspace_builds.reason,
+		workspace_builds.created_at,
+		workspace_builds.job_id
+	FROM
+		workspace_builds
+	WHERE
+		workspace_builds.workspace_id = workspaces.id
+	ORDER BY
+		workspace_builds.build_number DESC
+	LIMIT 1
+) AS latest_build ON TRUE
+INNER JOIN
+	provisioner_jobs ON latest_build.job_id = provisioner_jobs.id
+WHERE
+	workspaces.template_id = @template_id
+	AND workspaces.deleted = false
+	-- Prebuilt workspaces are handled by the prebuilds reconciliation loop.
+	AND workspaces.owner_id != 'c42fdf75-3097-471c-8c33-fb52454d81c0'::UUID
+ORDER BY
+	users.username ASC, workspaces.name ASC;
+
 -- name: UpdateWorkspaceDormantDeletingAt :one
 UPDATE
     workspaces
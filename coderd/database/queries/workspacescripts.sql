@@ -1,6 +1,6 @@
 -- name: InsertWorkspaceAgentScripts :many
 INSERT INTO
-	workspace_agent_scripts (workspace_agent_id, created_at, log_source_id, log_path, script, cron, start_blocks_login, run_on_start, run_on_stop, timeout_seconds, display_name, id)
+	workspace_agent_scripts (workspace_agent_id, created_at, log_source_id, log_path, script, cron, start_blocks_login, run_on_start, run_on_stop, timeout_seconds, display_name, id, unit_name, depends_on)
 SELECT
 	@workspace_agent_id :: uuid AS workspace_agent_id,
 	@created_at :: timestamptz AS created_at,
@@ -13,7 +13,9 @@ SELECT
 	unnest(@run_on_stop :: boolean [ ]) AS run_on_stop,
 	unnest(@timeout_seconds :: integer [ ]) AS timeout_seconds,
 	unnest(@display_name :: text [ ]) AS display_name,
-	unnest(@id :: uuid [ ]) AS id
+	unnest(@id :: uuid [ ]) AS id,
+	unnest(@unit_name :: text [ ]) AS unit_name,
+	jsonb_array_elements(@depends_on :: jsonb) AS depends_on
 RETURNING workspace_agent_scripts.*;
 
 -- name: GetWorkspaceAgentScriptsByAgentIDs :many
@@ -0,0 +1,85 @@
+-- name: InsertWorkspaceSessionRecording :exec
+-- The first part of the recording is stored as its first chunk.
+WITH recording AS (
+	INSERT INTO
+		workspace_session_recordings (
+			id,
+			organization_id,
+			workspace_id,
+			agent_id,
+			connection_id,
+			type,
+			started_at,
+			ended_at,
+			created_at,
+			include_input,
+			truncated,
+			size
+		)
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, octet_length(@data :: bytea))
+	RETURNING
+		id
+)
+INSERT INTO
+	workspace_session_recording_chunks (recording_id, offset_bytes, data)
+SELECT
+	id, 0, @data :: bytea
+FROM
+	recording;
+
+-- name: AppendWorkspaceSessionRecordingData :execrows
+-- Recordings larger than the maximum agent API message size are uploaded in
+-- parts, each of which is stored as a chunk. The offset guards against parts
+-- being applied out of order or twice, and the agent ID against an agent
+-- appending to another agent's recording.
+WITH recording AS (
+	UPDATE
+		workspace_session_recordings
+	SET
+		size = size + octet_length(@data :: bytea)
+	WHERE
+		id = @id
+		AND agent_id = @agent_id
+		AND size = @offset_bytes :: bigint
+	RETURNING
+		id
+)
+INSERT INTO
+	workspace_session_recording_chunks (recording_id, offset_bytes, data)
+SELECT
+	id, @offset_bytes :: bigint, @data :: bytea
+FROM
+	recording;
+
+-- name: GetWorkspaceSessionRecordingsByWorkspaceID :many
+SELECT
+	*
+FROM
+	workspace_session_recordings
+WHERE
+	workspace_id = @workspace_id
+ORDER BY
+	started_at DESC, id
+OFFSET @offset_opt
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF(@limit_opt :: int, 0);
+
+-- name: GetWorkspaceSessionRecordingByID :one
+SELECT
+	*
+FROM
+	workspace_session_recordings
+WHERE
+	id = $1;
+
+-- name: GetWorkspaceSessionRecordingChunks :many
+SELECT
+	*
+FROM
+	workspace_session_recording_chunks
+WHERE
+	recording_id = $1
+ORDER BY
+	offset_bytes ASC;
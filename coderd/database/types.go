@@ -319,6 +319,50 @@ func (a UserLinkClaims) Value() (driver.Value, error) {
 	return json.Marshal(a)
 }
 
+type PrebuildReadinessCheckType string
+
+const (
+	// PrebuildReadinessCheckTypeScript checks that an agent script exited successfully.
+	PrebuildReadinessCheckTypeScript PrebuildReadinessCheckType = "script"
+	// PrebuildReadinessCheckTypeMetadata checks the value of an agent metadata item.
+	PrebuildReadinessCheckTypeMetadata PrebuildReadinessCheckType = "metadata"
+	// PrebuildReadinessCheckTypeApp checks that an app is healthy.
+	PrebuildReadinessCheckTypeApp PrebuildReadinessCheckType = "app"
+)
+
+// PrebuildReadinessChecks are the checks a prebuilt workspace must pass
+// before it can be claimed.
+type PrebuildReadinessChecks []PrebuildReadinessCheck
+
+func (c *PrebuildReadinessChecks) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), &c)
+	case []byte:
+		return json.Unmarshal(v, &c)
+	}
+	return xerrors.Errorf("unexpected type %T", src)
+}
+
+func (c PrebuildReadinessChecks) Value() (driver.Value, error) {
+	if c == nil {
+		c = PrebuildReadinessChecks{}
+	}
+	return json.Marshal(c)
+}
+
+type PrebuildReadinessCheck struct {
+	Type PrebuildReadinessCheckType `json:"type"`
+	// Script is the display name of the agent script to check.
+	Script string `json:"script,omitempty"`
+	// MetadataKey is the key of the agent metadata item to check.
+	MetadataKey string `json:"metadata_key,omitempty"`
+	// MetadataValue is a regular expression the metadata value must match.
+	MetadataValue string `json:"metadata_value,omitempty"`
+	// App is the slug of the app to check.
+	App string `json:"app,omitempty"`
+}
+
 func ParseIP(ipStr string) pqtype.Inet {
 	ip := net.ParseIP(ipStr)
 	ipNet := net.IPNet{}
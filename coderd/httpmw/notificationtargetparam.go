@@ -0,0 +1,54 @@
+package httpmw
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+type notificationTargetParamContextKey struct{}
+
+// NotificationTargetParam returns the target from the ExtractNotificationTargetParam handler.
+func NotificationTargetParam(r *http.Request) database.NotificationTarget {
+	target, ok := r.Context().Value(notificationTargetParamContextKey{}).(database.NotificationTarget)
+	if !ok {
+		panic("developer error: notification target middleware not used")
+	}
+	return target
+}
+
+// ExtractNotificationTargetParam grabs a notification target from the "notification_target" URL parameter.
+// The target must belong to the user from the ExtractUserParam handler.
+func ExtractNotificationTargetParam(db database.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			targetID, parsed := ParseUUIDParam(rw, r, "notification_target")
+			if !parsed {
+				return
+			}
+			target, err := db.GetNotificationTargetByID(ctx, targetID)
+			if httpapi.Is404Error(err) {
+				httpapi.ResourceNotFound(rw)
+				return
+			}
+			if err != nil {
+				httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+					Message: "Internal error fetching notification target.",
+					Detail:  err.Error(),
+				})
+				return
+			}
+			if target.UserID != UserParam(r).ID {
+				httpapi.ResourceNotFound(rw)
+				return
+			}
+
+			ctx = context.WithValue(ctx, notificationTargetParamContextKey{}, target)
+			next.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
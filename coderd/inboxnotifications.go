@@ -41,6 +41,8 @@ var fallbackIcons = map[uuid.UUID]string{
 	notifications.TemplateWorkspaceOutOfMemory:       codersdk.InboxNotificationFallbackIconWorkspace,
 	notifications.TemplateWorkspaceOutOfDisk:         codersdk.InboxNotificationFallbackIconWorkspace,
 
+	notifications.TemplateWorkspaceMaintenanceScheduled: codersdk.InboxNotificationFallbackIconWorkspace,
+
 	// account related notifications
 	notifications.TemplateUserAccountCreated:           codersdk.InboxNotificationFallbackIconAccount,
 	notifications.TemplateUserAccountDeleted:           codersdk.InboxNotificationFallbackIconAccount,
@@ -54,6 +56,7 @@ var fallbackIcons = map[uuid.UUID]string{
 	notifications.TemplateTemplateDeleted:             codersdk.InboxNotificationFallbackIconTemplate,
 	notifications.TemplateTemplateDeprecated:          codersdk.InboxNotificationFallbackIconTemplate,
 	notifications.TemplateWorkspaceBuildsFailedReport: codersdk.InboxNotificationFallbackIconTemplate,
+	notifications.TemplateMaintenanceWindowReport:     codersdk.InboxNotificationFallbackIconTemplate,
 }
 
 func ensureNotificationIcon(notif codersdk.InboxNotification) codersdk.InboxNotification {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/xerrors"
 
 	"cdr.dev/slog/v3"
 	"github.com/coder/coder/v2/coderd/audit"
@@ -18,6 +19,7 @@ import (
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/coderd/util/slice"
 	"github.com/coder/coder/v2/codersdk"
 )
 
@@ -181,6 +183,11 @@ func (api *API) notificationDispatchMethods(rw http.ResponseWriter, r *http.Requ
 		if nm == database.NotificationMethodInbox {
 			continue
 		}
+		// Skip webpush method as it is only available as a notification target, since it depends on the user's
+		// own subscriptions.
+		if nm == database.NotificationMethodWebpush {
+			continue
+		}
 		methods = append(methods, string(nm))
 	}
 
@@ -190,6 +197,67 @@ func (api *API) notificationDispatchMethods(rw http.ResponseWriter, r *http.Requ
 	})
 }
 
+// maxNotificationDeliveriesLimit is the maximum number of deliveries returned by the notification deliveries endpoint.
+const maxNotificationDeliveriesLimit = 100
+
+// @Summary Get notification deliveries
+// @Description Returns the most recently enqueued notification messages along with the outcome of their most recent
+// @Description delivery attempt, such as the status code with which a webhook request was rejected.
+// @ID get-notification-deliveries
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param method query string false "Delivery method" Enums(smtp,webhook,inbox,webpush,slack,teams)
+// @Param limit query int false "Maximum number of deliveries, at most 100" default(25)
+// @Success 200 {array} codersdk.NotificationDelivery
+// @Router /notifications/deliveries [get]
+func (api *API) notificationDeliveries(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	p := httpapi.NewQueryParamParser()
+	vals := r.URL.Query()
+	method := p.String(vals, "", "method")
+	limit := p.PositiveInt32(vals, 25, "limit")
+	p.ErrorExcessParams(vals)
+	if method != "" && !database.NotificationMethod(method).Valid() {
+		p.Errors = append(p.Errors, codersdk.ValidationError{
+			Field:  "method",
+			Detail: fmt.Sprintf("Invalid notification method %q.", method),
+		})
+	}
+	if limit > maxNotificationDeliveriesLimit {
+		p.Errors = append(p.Errors, codersdk.ValidationError{
+			Field:  "limit",
+			Detail: fmt.Sprintf("Limit must be at most %d.", maxNotificationDeliveriesLimit),
+		})
+	}
+	if len(p.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Query parameters have invalid values.",
+			Validations: p.Errors,
+		})
+		return
+	}
+
+	deliveries, err := api.Database.GetNotificationMessageDeliveries(ctx, database.GetNotificationMessageDeliveriesParams{
+		Method: method,
+		Limit:  limit,
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve notification deliveries.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, slice.List(deliveries, convertNotificationDelivery))
+}
+
 // @Summary Send a test notification
 // @ID send-a-test-notification
 // @Security CoderSessionToken
@@ -262,8 +330,18 @@ func (api *API) userNotificationPreferences(rw http.ResponseWriter, r *http.Requ
 		})
 		return
 	}
+	routes, err := api.Database.GetUserNotificationPreferenceTargets(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve preference targets", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
 
-	out := convertNotificationPreferences(prefs)
+	out := convertNotificationPreferences(prefs, routes)
 	httpapi.Write(ctx, rw, http.StatusOK, out)
 }
 
@@ -312,8 +390,92 @@ func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.R
 		input.Disableds = append(input.Disableds, disabled)
 	}
 
-	// Update preferences with params.
-	updated, err := api.Database.UpdateUserNotificationPreferences(ctx, input)
+	// Build the routes to the user's notification targets, which must be verified.
+	targets, err := api.Database.GetNotificationTargetsByUserID(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve notification targets", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification targets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	verified := make(map[uuid.UUID]bool, len(targets))
+	for _, target := range targets {
+		verified[target.ID] = target.VerifiedAt.Valid
+	}
+	routes := database.InsertUserNotificationPreferenceTargetsParams{UserID: user.ID}
+	rerouted := make([]uuid.UUID, 0, len(prefs.TemplateTargetsMap))
+	for tmplID, targetIDs := range prefs.TemplateTargetsMap {
+		id, err := uuid.Parse(tmplID)
+		if err != nil {
+			logger.Warn(ctx, "failed to parse notification template UUID", slog.F("input", tmplID), slog.Error(err))
+
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Unable to parse notification template UUID.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		if len(targetIDs) > 0 && !notifications.TemplateIsRoutable(id) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Notification template cannot be routed to notification targets.",
+				Detail:  fmt.Sprintf("Notifications of template %q are always delivered by the deployment's method.", id),
+			})
+			return
+		}
+
+		rerouted = append(rerouted, id)
+		for _, targetID := range targetIDs {
+			isVerified, ok := verified[targetID]
+			if !ok {
+				httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+					Message: "Notification target not found.",
+					Detail:  fmt.Sprintf("Notification target %q does not exist.", targetID),
+				})
+				return
+			}
+			if !isVerified {
+				httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+					Message: "Notification target is not verified.",
+					Detail:  fmt.Sprintf("Notification target %q must be verified before notifications can be routed to it.", targetID),
+				})
+				return
+			}
+			routes.NotificationTemplateIds = append(routes.NotificationTemplateIds, id)
+			routes.NotificationTargetIds = append(routes.NotificationTargetIds, targetID)
+		}
+	}
+
+	err = api.Database.InTx(func(tx database.Store) error {
+		// Update preferences with params.
+		updated, err := tx.UpdateUserNotificationPreferences(ctx, input)
+		if err != nil {
+			return xerrors.Errorf("update preferences: %w", err)
+		}
+		logger.Info(ctx, "updated preferences", slog.F("count", updated))
+
+		// Replace the routes of the given templates.
+		if len(rerouted) == 0 {
+			return nil
+		}
+		err = tx.DeleteUserNotificationPreferenceTargets(ctx, database.DeleteUserNotificationPreferenceTargetsParams{
+			UserID:                  user.ID,
+			NotificationTemplateIds: rerouted,
+		})
+		if err != nil {
+			return xerrors.Errorf("delete preference targets: %w", err)
+		}
+		if len(routes.NotificationTargetIds) == 0 {
+			return nil
+		}
+		if _, err := tx.InsertUserNotificationPreferenceTargets(ctx, routes); err != nil {
+			return xerrors.Errorf("insert preference targets: %w", err)
+		}
+		return nil
+	}, nil)
 	if err != nil {
 		logger.Error(ctx, "failed to update preferences", slog.Error(err))
 
@@ -325,8 +487,6 @@ func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.R
 	}
 
 	// Preferences updated, now fetch all preferences belonging to this user.
-	logger.Info(ctx, "updated preferences", slog.F("count", updated))
-
 	userPrefs, err := api.Database.GetUserNotificationPreferences(ctx, user.ID)
 	if err != nil {
 		logger.Error(ctx, "failed to retrieve preferences", slog.Error(err))
@@ -337,8 +497,18 @@ func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.R
 		})
 		return
 	}
+	userRoutes, err := api.Database.GetUserNotificationPreferenceTargets(ctx, user.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to retrieve preference targets", slog.Error(err))
+
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notifications preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
 
-	out := convertNotificationPreferences(userPrefs)
+	out := convertNotificationPreferences(userPrefs, userRoutes)
 	httpapi.Write(ctx, rw, http.StatusOK, out)
 }
 
@@ -445,14 +615,66 @@ func convertNotificationTemplates(in []database.NotificationTemplate) (out []cod
 	return out
 }
 
-func convertNotificationPreferences(in []database.NotificationPreference) (out []codersdk.NotificationPreference) {
+// convertNotificationPreferences merges the user's preferences with their routes to notification targets. Templates
+// which are routed but have no preference are enabled, as far as the user is concerned.
+func convertNotificationPreferences(in []database.NotificationPreference, routes []database.NotificationPreferenceTarget) (out []codersdk.NotificationPreference) {
+	targetIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, route := range routes {
+		targetIDs[route.NotificationTemplateID] = append(targetIDs[route.NotificationTemplateID], route.NotificationTargetID)
+	}
+	routedTo := func(templateID uuid.UUID) []uuid.UUID {
+		if ids, ok := targetIDs[templateID]; ok {
+			return ids
+		}
+		return []uuid.UUID{}
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(in))
 	for _, pref := range in {
 		out = append(out, codersdk.NotificationPreference{
 			NotificationTemplateID: pref.NotificationTemplateID,
 			Disabled:               pref.Disabled,
 			UpdatedAt:              pref.UpdatedAt,
+			TargetIDs:              routedTo(pref.NotificationTemplateID),
 		})
+		seen[pref.NotificationTemplateID] = struct{}{}
 	}
+	for _, route := range routes {
+		if _, ok := seen[route.NotificationTemplateID]; ok {
+			continue
+		}
+		out = append(out, codersdk.NotificationPreference{
+			NotificationTemplateID: route.NotificationTemplateID,
+			UpdatedAt:              route.CreatedAt,
+			TargetIDs:              routedTo(route.NotificationTemplateID),
+		})
+		seen[route.NotificationTemplateID] = struct{}{}
+	}
+
+	return out
+}
 
+func convertNotificationDelivery(delivery database.GetNotificationMessageDeliveriesRow) codersdk.NotificationDelivery {
+	out := codersdk.NotificationDelivery{
+		MessageID:          delivery.ID,
+		TemplateID:         delivery.NotificationTemplateID,
+		TemplateName:       delivery.NotificationTemplateName,
+		UserID:             delivery.UserID,
+		Method:             string(delivery.Method),
+		Status:             string(delivery.Status),
+		StatusReason:       delivery.StatusReason.String,
+		ResponseStatusCode: int(delivery.ResponseStatusCode.Int32),
+		AttemptCount:       int(delivery.AttemptCount.Int32),
+		CreatedAt:          delivery.CreatedAt,
+	}
+	if delivery.NotificationTargetID.Valid {
+		out.TargetID = &delivery.NotificationTargetID.UUID
+	}
+	if delivery.UpdatedAt.Valid {
+		out.UpdatedAt = &delivery.UpdatedAt.Time
+	}
+	if delivery.NextRetryAfter.Valid {
+		out.NextRetryAfter = &delivery.NextRetryAfter.Time
+	}
 	return out
 }
@@ -0,0 +1,116 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+)
+
+// OrganizationWebhookStore looks up the incoming webhooks of chat services configured by the organizations of a user.
+type OrganizationWebhookStore interface {
+	GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg database.GetOrganizationNotificationWebhooksByUserIDParams) ([]database.OrganizationNotificationWebhook, error)
+}
+
+// chatHandler delivers rendered messages to the incoming webhooks of a chat service, such as Slack or Microsoft Teams.
+// A message is only delivered to the webhook of the organization it concerns, i.e. one of its targets, so that details
+// of a resource are never posted to the channels of other organizations the recipient is a member of.
+type chatHandler struct {
+	method database.NotificationMethod
+	log    slog.Logger
+	store  OrganizationWebhookStore
+
+	cl *http.Client
+}
+
+func newChatHandler(method database.NotificationMethod, log slog.Logger, store OrganizationWebhookStore) chatHandler {
+	return chatHandler{method: method, log: log, store: store, cl: newHTTPClient(log)}
+}
+
+func (c *chatHandler) dispatch(payload types.MessagePayload, msg any) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		userID, err := uuid.Parse(payload.UserID)
+		if err != nil {
+			return false, xerrors.Errorf("parse user ID: %w", err)
+		}
+
+		m, err := json.Marshal(msg)
+		if err != nil {
+			return false, xerrors.Errorf("marshal payload: %w", err)
+		}
+
+		webhooks, err := c.store.GetOrganizationNotificationWebhooksByUserID(ctx, database.GetOrganizationNotificationWebhooksByUserIDParams{
+			UserID: userID,
+			Method: c.method,
+		})
+		if err != nil {
+			return true, xerrors.Errorf("get organization webhooks: %w", err)
+		}
+		webhooks = slices.DeleteFunc(webhooks, func(webhook database.OrganizationNotificationWebhook) bool {
+			return !slices.Contains(payload.Targets, webhook.OrganizationID)
+		})
+		if len(webhooks) == 0 {
+			return false, xerrors.Errorf("no %s webhook is configured by the organization the message concerns", c.method)
+		}
+
+		// A message normally concerns a single organization. Should it concern several, deliver to every webhook, even
+		// if some of them fail. The outcome is not tracked per webhook, so a retry delivers to all of them again:
+		// channels whose webhook already succeeded receive the message twice. This is accepted, since duplicated chat
+		// messages are harmless whereas lost ones are not.
+		var errs []error
+		for _, webhook := range webhooks {
+			r, err := c.post(ctx, msgID, webhook.Endpoint, m)
+			if err != nil {
+				retryable = retryable || r
+				errs = append(errs, xerrors.Errorf("organization %q: %w", webhook.OrganizationID, err))
+			}
+		}
+		return retryable, errors.Join(errs...)
+	}
+}
+
+func (c *chatHandler) post(ctx context.Context, msgID uuid.UUID, endpoint string, body []byte) (retryable bool, err error) {
+	// Outer context has a deadline (see CODER_NOTIFICATIONS_DISPATCH_TIMEOUT).
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, xerrors.Errorf("create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.cl.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return true, xerrors.Errorf("request timeout: %w", err)
+		}
+
+		return true, xerrors.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 > 2 {
+		// Body could be quite long here, let's grab the first 512B and hope it contains useful debug info.
+		respBody := make([]byte, 512)
+		lr := io.LimitReader(resp.Body, int64(len(respBody)))
+		n, err := lr.Read(respBody)
+		if err != nil && !errors.Is(err, io.EOF) {
+			c.log.Warn(ctx, "unsuccessful delivery, failed to read response body", slog.F("status_code", resp.StatusCode),
+				slog.F("msg_id", msgID), slog.Error(err))
+			return true, &UnsuccessfulResponseError{StatusCode: resp.StatusCode}
+		}
+		c.log.Warn(ctx, "unsuccessful delivery", slog.F("status_code", resp.StatusCode),
+			slog.F("response", string(respBody[:n])), slog.F("msg_id", msgID))
+		return true, &UnsuccessfulResponseError{StatusCode: resp.StatusCode}
+	}
+
+	return false, nil
+}
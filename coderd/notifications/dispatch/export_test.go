@@ -0,0 +1,6 @@
+package dispatch
+
+// AllowInternalTargets lets the handler deliver to targets on internal addresses, such as test servers.
+func (w *WebhookHandler) AllowInternalTargets() {
+	w.targetCl = w.cl
+}
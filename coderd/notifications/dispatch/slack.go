@@ -0,0 +1,85 @@
+package dispatch
+
+import (
+	"text/template"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+)
+
+// SlackHandler dispatches notification messages as Block Kit messages to the Slack incoming webhook configured by the
+// organization the message concerns.
+type SlackHandler struct {
+	chatHandler
+}
+
+func NewSlackHandler(log slog.Logger, store OrganizationWebhookStore) *SlackHandler {
+	return &SlackHandler{chatHandler: newChatHandler(database.NotificationMethodSlack, log, store)}
+}
+
+// slackMessage is a Block Kit message, see https://api.slack.com/reference/block-kit/blocks.
+type slackMessage struct {
+	// Text is shown in notifications, and by clients which cannot render blocks.
+	Text   string `json:"text"`
+	Blocks []any  `json:"blocks"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackHeaderBlock struct {
+	Type string    `json:"type"`
+	Text slackText `json:"text"`
+}
+
+// slackMarkdownBlock renders standard markdown, so the body needs no conversion to Slack's own mrkdwn format.
+type slackMarkdownBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackActionsBlock struct {
+	Type     string        `json:"type"`
+	Elements []slackButton `json:"elements"`
+}
+
+type slackButton struct {
+	Type string    `json:"type"`
+	Text slackText `json:"text"`
+	URL  string    `json:"url"`
+}
+
+func (s *SlackHandler) Dispatcher(payload types.MessagePayload, titleMarkdown, bodyMarkdown string, _ template.FuncMap) (DeliveryFunc, error) {
+	// Header blocks only support plain text.
+	title, err := markdown.PlaintextFromMarkdown(titleMarkdown)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	msg := slackMessage{
+		Text: title,
+		Blocks: []any{
+			slackHeaderBlock{Type: "header", Text: slackText{Type: "plain_text", Text: title}},
+			slackMarkdownBlock{Type: "markdown", Text: bodyMarkdown},
+		},
+	}
+	if len(payload.Actions) > 0 {
+		buttons := make([]slackButton, 0, len(payload.Actions))
+		for _, action := range payload.Actions {
+			buttons = append(buttons, slackButton{
+				Type: "button",
+				Text: slackText{Type: "plain_text", Text: action.Label},
+				URL:  action.URL,
+			})
+		}
+		msg.Blocks = append(msg.Blocks, slackActionsBlock{Type: "actions", Elements: buttons})
+	}
+
+	return s.dispatch(payload, msg), nil
+}
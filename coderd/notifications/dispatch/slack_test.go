@@ -0,0 +1,155 @@
+package dispatch_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSlack(t *testing.T) {
+	t.Parallel()
+
+	const expectedPayload = `{
+		"text": "Workspace deleted",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Workspace deleted"}},
+			{"type": "markdown", "text": "Your workspace **bobby-ws** was deleted."},
+			{"type": "actions", "elements": [
+				{"type": "button", "text": {"type": "plain_text", "text": "View workspaces"}, "url": "https://coder.com/workspaces"}
+			]}
+		]
+	}`
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	userID := uuid.New()
+	orgA, orgB := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name          string
+		userID        string
+		targets       []uuid.UUID
+		statusCode    int
+		storeErr      error
+		noWebhooks    bool
+		expectedErr   string
+		expectedRetry bool
+	}{
+		{
+			name:       "OK",
+			userID:     userID.String(),
+			targets:    []uuid.UUID{uuid.New(), orgA},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:          "NoOrganization",
+			userID:        userID.String(),
+			targets:       []uuid.UUID{uuid.New()},
+			expectedErr:   "no slack webhook is configured by the organization the message concerns",
+			expectedRetry: false,
+		},
+		{
+			name:          "InvalidUserID",
+			userID:        "invalid",
+			expectedErr:   "parse user ID",
+			expectedRetry: false,
+		},
+		{
+			name:          "NoWebhooks",
+			userID:        userID.String(),
+			targets:       []uuid.UUID{orgA},
+			noWebhooks:    true,
+			expectedErr:   "no slack webhook is configured",
+			expectedRetry: false,
+		},
+		{
+			name:          "StoreFailed",
+			userID:        userID.String(),
+			targets:       []uuid.UUID{orgA},
+			storeErr:      xerrors.New("connection refused"),
+			expectedErr:   "connection refused",
+			expectedRetry: true,
+		},
+		{
+			name:          "Non2xxResponse",
+			userID:        userID.String(),
+			targets:       []uuid.UUID{orgA},
+			statusCode:    http.StatusInternalServerError,
+			expectedErr:   "non-2xx response (500)",
+			expectedRetry: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.Context(t, testutil.WaitShort)
+
+			// Given: two organizations of the user with Slack webhooks, and one with a Teams webhook.
+			var received atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received.Add(1)
+				assert.Equal(t, "/services/org1", r.URL.Path)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, expectedPayload, string(body))
+				w.WriteHeader(tc.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			store := &fakeOrganizationWebhookStore{err: tc.storeErr}
+			if !tc.noWebhooks {
+				store.webhooks = []database.OrganizationNotificationWebhook{
+					{OrganizationID: orgA, Method: database.NotificationMethodSlack, Endpoint: server.URL + "/services/org1"},
+					{OrganizationID: orgB, Method: database.NotificationMethodSlack, Endpoint: server.URL + "/services/org2"},
+					{OrganizationID: orgA, Method: database.NotificationMethodTeams, Endpoint: server.URL + "/teams"},
+				}
+			}
+			handler := dispatch.NewSlackHandler(logger.With(slog.F("test", tc.name)), store)
+
+			// When: a notification is dispatched to the user.
+			payload := types.MessagePayload{
+				NotificationName: "Workspace Deleted",
+				UserID:           tc.userID,
+				Targets:          tc.targets,
+				Actions: []types.TemplateAction{
+					{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+				},
+			}
+			deliveryFn, err := handler.Dispatcher(payload, "Workspace *deleted*", "Your workspace **bobby-ws** was deleted.", helpers())
+			require.NoError(t, err)
+			retryable, err := deliveryFn(ctx, uuid.New())
+
+			// Then: it is only delivered to the Slack webhook of the organization it concerns, and not to those of the
+			// user's other organizations.
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				require.Equal(t, tc.expectedRetry, retryable)
+				if tc.statusCode/100 > 2 {
+					var respErr *dispatch.UnsuccessfulResponseError
+					require.ErrorAs(t, err, &respErr)
+					require.Equal(t, tc.statusCode, respErr.StatusCode)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, retryable)
+			require.EqualValues(t, 1, received.Load())
+		})
+	}
+}
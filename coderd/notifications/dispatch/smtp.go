@@ -60,6 +60,12 @@ func NewSMTPHandler(cfg codersdk.NotificationsEmailConfig, log slog.Logger) *SMT
 }
 
 func (s *SMTPHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string, helpers template.FuncMap) (DeliveryFunc, error) {
+	return s.TargetDispatcher(payload.UserEmail, payload, titleTmpl, bodyTmpl, helpers)
+}
+
+// TargetDispatcher is like Dispatcher, but delivers the notification to the email address of one of the recipient's
+// own notification targets rather than to the recipient's email address.
+func (s *SMTPHandler) TargetDispatcher(to string, payload types.MessagePayload, titleTmpl, bodyTmpl string, helpers template.FuncMap) (DeliveryFunc, error) {
 	// First render the subject & body into their own discrete strings.
 	subject, err := markdown.PlaintextFromMarkdown(titleTmpl)
 	if err != nil {
@@ -85,7 +91,7 @@ func (s *SMTPHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTm
 		return nil, xerrors.Errorf("render full plaintext template: %w", err)
 	}
 
-	return s.dispatch(subject, htmlBody, plainBody, payload.UserEmail), nil
+	return s.dispatch(subject, htmlBody, plainBody, to), nil
 }
 
 // dispatch returns a DeliveryFunc capable of delivering a notification via SMTP.
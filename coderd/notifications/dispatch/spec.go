@@ -2,6 +2,7 @@ package dispatch
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -11,3 +12,13 @@ import (
 // any error that may have arisen.
 // If (false, nil) is returned, that is considered a successful dispatch.
 type DeliveryFunc func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error)
+
+// UnsuccessfulResponseError is returned by dispatchers which deliver over HTTP when the receiver responds with a
+// non-2xx status code.
+type UnsuccessfulResponseError struct {
+	StatusCode int
+}
+
+func (e *UnsuccessfulResponseError) Error() string {
+	return fmt.Sprintf("non-2xx response (%d)", e.StatusCode)
+}
@@ -0,0 +1,91 @@
+package dispatch
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+)
+
+// ErrInternalTargetAddress is returned when a notification target's host resolves to an internal address.
+var ErrInternalTargetAddress = xerrors.New("notification target address is internal")
+
+// cgnatPrefix is the shared address space of RFC 6598, which is not covered by netip.Addr.IsPrivate.
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsInternalAddress reports whether the address is a loopback, link-local, private, or otherwise non-public address.
+// Notifications to targets owned by users are never delivered to internal addresses, so that users cannot make the
+// server send requests into its own network, such as to cloud metadata services.
+func IsInternalAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		cgnatPrefix.Contains(addr)
+}
+
+// ValidateTargetHost returns ErrInternalTargetAddress if the host is, or resolves to, an internal address. Hosts which
+// cannot be resolved are accepted, since deliveries are checked again when connecting.
+func ValidateTargetHost(ctx context.Context, resolver *net.Resolver, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if IsInternalAddress(addr) {
+			return ErrInternalTargetAddress
+		}
+		return nil
+	}
+
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil //nolint:nilerr // Unresolvable hosts are rejected when dialing.
+	}
+	for _, addr := range addrs {
+		if IsInternalAddress(addr) {
+			return ErrInternalTargetAddress
+		}
+	}
+	return nil
+}
+
+// newTargetHTTPClient creates an HTTP client for deliveries to targets owned by users, which refuses to connect to
+// internal addresses. The check happens when dialing, after the host has been resolved, so that it cannot be bypassed
+// by a host which resolves to a different address than it did when the target was created.
+func newTargetHTTPClient(log slog.Logger) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if IsInternalAddress(addr) {
+				return ErrInternalTargetAddress
+			}
+			return nil
+		},
+	}
+
+	cl := newHTTPClient(log)
+	if t, ok := cl.Transport.(*http.Transport); ok {
+		t.DialContext = dialer.DialContext
+		// Proxies usually live on internal addresses themselves, and would reach the target on our behalf.
+		t.Proxy = nil
+		return cl
+	}
+	return &http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+}
@@ -0,0 +1,88 @@
+package dispatch
+
+import (
+	"text/template"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+)
+
+// TeamsHandler dispatches notification messages as Adaptive Cards to the Microsoft Teams incoming webhook configured
+// by the organization the message concerns.
+type TeamsHandler struct {
+	chatHandler
+}
+
+func NewTeamsHandler(log slog.Logger, store OrganizationWebhookStore) *TeamsHandler {
+	return &TeamsHandler{chatHandler: newChatHandler(database.NotificationMethodTeams, log, store)}
+}
+
+// teamsMessage is a message with an Adaptive Card attachment, see https://adaptivecards.io/explorer/AdaptiveCard.html.
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string            `json:"contentType"`
+	Content     teamsAdaptiveCard `json:"content"`
+}
+
+type teamsAdaptiveCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []teamsTextBlock     `json:"body"`
+	Actions []teamsOpenURLAction `json:"actions,omitempty"`
+}
+
+// teamsTextBlock renders a subset of markdown, including emphasis, lists, and links.
+type teamsTextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+type teamsOpenURLAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func (t *TeamsHandler) Dispatcher(payload types.MessagePayload, titleMarkdown, bodyMarkdown string, _ template.FuncMap) (DeliveryFunc, error) {
+	// The title is rendered as a heading, so it is stripped of markdown.
+	title, err := markdown.PlaintextFromMarkdown(titleMarkdown)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	card := teamsAdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []teamsTextBlock{
+			{Type: "TextBlock", Text: title, Size: "Medium", Weight: "Bolder", Wrap: true},
+			{Type: "TextBlock", Text: bodyMarkdown, Wrap: true},
+		},
+	}
+	for _, action := range payload.Actions {
+		card.Actions = append(card.Actions, teamsOpenURLAction{
+			Type:  "Action.OpenUrl",
+			Title: action.Label,
+			URL:   action.URL,
+		})
+	}
+
+	return t.dispatch(payload, teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{
+			{ContentType: "application/vnd.microsoft.card.adaptive", Content: card},
+		},
+	}), nil
+}
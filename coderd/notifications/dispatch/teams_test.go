@@ -0,0 +1,134 @@
+package dispatch_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTeams(t *testing.T) {
+	t.Parallel()
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	tests := []struct {
+		name            string
+		actions         []types.TemplateAction
+		statusCode      int
+		expectedPayload string
+		expectedErr     string
+	}{
+		{
+			name: "OK",
+			actions: []types.TemplateAction{
+				{Label: "View workspace", URL: "https://coder.com/@bobby/bobby-ws"},
+				{Label: "View template", URL: "https://coder.com/templates/docker"},
+			},
+			// Workflows webhooks accept messages asynchronously.
+			statusCode: http.StatusAccepted,
+			expectedPayload: `{
+				"type": "message",
+				"attachments": [{
+					"contentType": "application/vnd.microsoft.card.adaptive",
+					"content": {
+						"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+						"type": "AdaptiveCard",
+						"version": "1.4",
+						"body": [
+							{"type": "TextBlock", "text": "Workspace deleted", "size": "Medium", "weight": "Bolder", "wrap": true},
+							{"type": "TextBlock", "text": "Your workspace **bobby-ws** was deleted.", "wrap": true}
+						],
+						"actions": [
+							{"type": "Action.OpenUrl", "title": "View workspace", "url": "https://coder.com/@bobby/bobby-ws"},
+							{"type": "Action.OpenUrl", "title": "View template", "url": "https://coder.com/templates/docker"}
+						]
+					}
+				}]
+			}`,
+		},
+		{
+			name:       "NoActions",
+			statusCode: http.StatusOK,
+			expectedPayload: `{
+				"type": "message",
+				"attachments": [{
+					"contentType": "application/vnd.microsoft.card.adaptive",
+					"content": {
+						"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+						"type": "AdaptiveCard",
+						"version": "1.4",
+						"body": [
+							{"type": "TextBlock", "text": "Workspace deleted", "size": "Medium", "weight": "Bolder", "wrap": true},
+							{"type": "TextBlock", "text": "Your workspace **bobby-ws** was deleted.", "wrap": true}
+						]
+					}
+				}]
+			}`,
+		},
+		{
+			name:        "Non2xxResponse",
+			statusCode:  http.StatusBadRequest,
+			expectedErr: "non-2xx response (400)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.Context(t, testutil.WaitShort)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				if tc.expectedPayload != "" {
+					body, err := io.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, tc.expectedPayload, string(body))
+				}
+				w.WriteHeader(tc.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			orgID := uuid.New()
+			store := &fakeOrganizationWebhookStore{
+				webhooks: []database.OrganizationNotificationWebhook{
+					{OrganizationID: orgID, Method: database.NotificationMethodTeams, Endpoint: server.URL},
+				},
+			}
+			handler := dispatch.NewTeamsHandler(logger.With(slog.F("test", tc.name)), store)
+
+			payload := types.MessagePayload{
+				NotificationName: "Workspace Deleted",
+				UserID:           uuid.NewString(),
+				Actions:          tc.actions,
+				Targets:          []uuid.UUID{orgID},
+			}
+			deliveryFn, err := handler.Dispatcher(payload, "Workspace *deleted*", "Your workspace **bobby-ws** was deleted.", helpers())
+			require.NoError(t, err)
+
+			retryable, err := deliveryFn(ctx, uuid.New())
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				require.True(t, retryable)
+				var respErr *dispatch.UnsuccessfulResponseError
+				require.ErrorAs(t, err, &respErr)
+				require.Equal(t, tc.statusCode, respErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, retryable)
+		})
+	}
+}
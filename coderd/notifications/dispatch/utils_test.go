@@ -1,5 +1,11 @@
 package dispatch_test
 
+import (
+	"context"
+
+	"github.com/coder/coder/v2/coderd/database"
+)
+
 func helpers() map[string]any {
 	return map[string]any{
 		"base_url":     func() string { return "http://test.com" },
@@ -8,3 +14,19 @@ func helpers() map[string]any {
 		"app_name":     func() string { return "Coder" },
 	}
 }
+
+// fakeOrganizationWebhookStore returns the webhooks of the requested method, regardless of the user.
+type fakeOrganizationWebhookStore struct {
+	webhooks []database.OrganizationNotificationWebhook
+	err      error
+}
+
+func (f *fakeOrganizationWebhookStore) GetOrganizationNotificationWebhooksByUserID(_ context.Context, arg database.GetOrganizationNotificationWebhooksByUserIDParams) ([]database.OrganizationNotificationWebhook, error) {
+	var webhooks []database.OrganizationNotificationWebhook
+	for _, webhook := range f.webhooks {
+		if webhook.Method == arg.Method {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, f.err
+}
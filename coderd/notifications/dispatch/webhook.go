@@ -17,14 +17,18 @@ import (
 	"github.com/coder/coder/v2/coderd/notifications/types"
 	markdown "github.com/coder/coder/v2/coderd/render"
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/quartz"
 )
 
 // WebhookHandler dispatches notification messages via an HTTP POST webhook.
 type WebhookHandler struct {
-	cfg codersdk.NotificationsWebhookConfig
-	log slog.Logger
+	cfg   codersdk.NotificationsWebhookConfig
+	log   slog.Logger
+	clock quartz.Clock
 
 	cl *http.Client
+	// targetCl delivers to targets owned by users, and refuses to connect to internal addresses.
+	targetCl *http.Client
 }
 
 // WebhookPayload describes the JSON payload to be delivered to the configured webhook endpoint.
@@ -38,7 +42,12 @@ type WebhookPayload struct {
 	BodyMarkdown  string               `json:"body_markdown"`
 }
 
-func NewWebhookHandler(cfg codersdk.NotificationsWebhookConfig, log slog.Logger) *WebhookHandler {
+func NewWebhookHandler(cfg codersdk.NotificationsWebhookConfig, log slog.Logger, clock quartz.Clock) *WebhookHandler {
+	return &WebhookHandler{cfg: cfg, log: log, clock: clock, cl: newHTTPClient(log), targetCl: newTargetHTTPClient(log)}
+}
+
+// newHTTPClient creates an HTTP client with its own transport.
+func newHTTPClient(log slog.Logger) *http.Client {
 	// Create a new transport in favor of reusing the default, since other http clients may interfere.
 	// http.Transport maintains its own connection pool, and we want to avoid cross-contamination.
 	var rt http.RoundTripper
@@ -54,7 +63,7 @@ func NewWebhookHandler(cfg codersdk.NotificationsWebhookConfig, log slog.Logger)
 		rt = t.Clone()
 	}
 
-	return &WebhookHandler{cfg: cfg, log: log, cl: &http.Client{Transport: rt}}
+	return &http.Client{Transport: rt}
 }
 
 func (w *WebhookHandler) Dispatcher(payload types.MessagePayload, titleMarkdown, bodyMarkdown string, _ template.FuncMap) (DeliveryFunc, error) {
@@ -62,6 +71,19 @@ func (w *WebhookHandler) Dispatcher(payload types.MessagePayload, titleMarkdown,
 		return nil, xerrors.New("webhook endpoint not defined")
 	}
 
+	return w.dispatcher(w.cl, w.cfg.Endpoint.String(), w.cfg.SigningSecrets.Value(), payload, titleMarkdown, bodyMarkdown)
+}
+
+// TargetDispatcher is like Dispatcher, but delivers the notification to the endpoint of one of the recipient's own
+// notification targets rather than to the configured endpoint. Endpoints on internal addresses are refused.
+//
+// These deliveries are not signed: the signing secrets authenticate the deployment to the configured endpoint, and
+// signed payloads delivered to a user's own endpoint could be replayed to it.
+func (w *WebhookHandler) TargetDispatcher(endpoint string, payload types.MessagePayload, titleMarkdown, bodyMarkdown string, _ template.FuncMap) (DeliveryFunc, error) {
+	return w.dispatcher(w.targetCl, endpoint, nil, payload, titleMarkdown, bodyMarkdown)
+}
+
+func (w *WebhookHandler) dispatcher(cl *http.Client, endpoint string, secrets []string, payload types.MessagePayload, titleMarkdown, bodyMarkdown string) (DeliveryFunc, error) {
 	titlePlaintext, err := markdown.PlaintextFromMarkdown(titleMarkdown)
 	if err != nil {
 		return nil, xerrors.Errorf("render title: %w", err)
@@ -71,10 +93,10 @@ func (w *WebhookHandler) Dispatcher(payload types.MessagePayload, titleMarkdown,
 		return nil, xerrors.Errorf("render body: %w", err)
 	}
 
-	return w.dispatch(payload, titlePlaintext, titleMarkdown, bodyPlaintext, bodyMarkdown, w.cfg.Endpoint.String()), nil
+	return w.dispatch(cl, secrets, payload, titlePlaintext, titleMarkdown, bodyPlaintext, bodyMarkdown, endpoint), nil
 }
 
-func (w *WebhookHandler) dispatch(msgPayload types.MessagePayload, titlePlaintext, titleMarkdown, bodyPlaintext, bodyMarkdown, endpoint string) DeliveryFunc {
+func (w *WebhookHandler) dispatch(cl *http.Client, secrets []string, msgPayload types.MessagePayload, titlePlaintext, titleMarkdown, bodyPlaintext, bodyMarkdown, endpoint string) DeliveryFunc {
 	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
 		// Prepare payload.
 		payload := WebhookPayload{
@@ -99,10 +121,17 @@ func (w *WebhookHandler) dispatch(msgPayload types.MessagePayload, titlePlaintex
 		}
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("X-Message-Id", msgID.String())
+		if len(secrets) > 0 {
+			// Each attempt is signed anew, so that retries are not rejected as replays by the receiver.
+			req.Header.Set(codersdk.WebhookSignatureHeader, codersdk.SignWebhookPayload(m, w.clock.Now(), secrets...))
+		}
 
 		// Send request.
-		resp, err := w.cl.Do(req)
+		resp, err := cl.Do(req)
 		if err != nil {
+			if errors.Is(err, ErrInternalTargetAddress) {
+				return false, xerrors.Errorf("request refused: %w", err)
+			}
 			if errors.Is(err, context.DeadlineExceeded) {
 				return true, xerrors.Errorf("request timeout: %w", err)
 			}
@@ -118,11 +147,13 @@ func (w *WebhookHandler) dispatch(msgPayload types.MessagePayload, titlePlaintex
 			lr := io.LimitReader(resp.Body, int64(len(respBody)))
 			n, err := lr.Read(respBody)
 			if err != nil && !errors.Is(err, io.EOF) {
-				return true, xerrors.Errorf("non-2xx response (%d), read body: %w", resp.StatusCode, err)
+				w.log.Warn(ctx, "unsuccessful delivery, failed to read response body", slog.F("status_code", resp.StatusCode),
+					slog.F("msg_id", msgID), slog.Error(err))
+				return true, &UnsuccessfulResponseError{StatusCode: resp.StatusCode}
 			}
 			w.log.Warn(ctx, "unsuccessful delivery", slog.F("status_code", resp.StatusCode),
 				slog.F("response", string(respBody[:n])), slog.F("msg_id", msgID))
-			return true, xerrors.Errorf("non-2xx response (%d)", resp.StatusCode)
+			return true, &UnsuccessfulResponseError{StatusCode: resp.StatusCode}
 		}
 
 		return false, nil
@@ -3,9 +3,12 @@ package dispatch_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"net/url"
 	"testing"
 	"time"
@@ -20,6 +23,7 @@ import (
 	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
 	"github.com/coder/serpent"
 )
 
@@ -57,6 +61,8 @@ func TestWebhook(t *testing.T) {
 				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
 				assert.Equal(t, msgID, payload.MsgID)
 				assert.Equal(t, msgID.String(), r.Header.Get("X-Message-Id"))
+				// Requests are only signed if signing secrets are configured.
+				assert.Empty(t, r.Header.Get(codersdk.WebhookSignatureHeader))
 
 				assert.Equal(t, titlePlaintext, payload.Title)
 				assert.Equal(t, titleMarkdown, payload.TitleMarkdown)
@@ -139,7 +145,7 @@ func TestWebhook(t *testing.T) {
 			cfg := codersdk.NotificationsWebhookConfig{
 				Endpoint: *serpent.URLOf(endpoint),
 			}
-			handler := dispatch.NewWebhookHandler(cfg, logger.With(slog.F("test", tc.name)))
+			handler := dispatch.NewWebhookHandler(cfg, logger.With(slog.F("test", tc.name)), quartz.NewReal())
 			deliveryFn, err := handler.Dispatcher(msgPayload, titleMarkdown, bodyMarkdown, helpers())
 			require.NoError(t, err)
 
@@ -155,3 +161,162 @@ func TestWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookTarget(t *testing.T) {
+	t.Parallel()
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	configured := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("configured endpoint should not receive messages for notification targets")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(configured.Close)
+
+	received := make(chan dispatch.WebhookPayload, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Deliveries to users' own endpoints are not signed with the deployment's secrets.
+		assert.Empty(t, r.Header.Get(codersdk.WebhookSignatureHeader))
+		var payload dispatch.WebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(target.Close)
+
+	endpoint, err := url.Parse(configured.URL)
+	require.NoError(t, err)
+	handler := dispatch.NewWebhookHandler(codersdk.NotificationsWebhookConfig{
+		Endpoint:       *serpent.URLOf(endpoint),
+		SigningSecrets: serpent.StringArray{"secret"},
+	}, logger, quartz.NewReal())
+	handler.AllowInternalTargets()
+
+	msgID := uuid.New()
+	deliveryFn, err := handler.TargetDispatcher(target.URL, types.MessagePayload{NotificationName: "test"}, "title", "body", helpers())
+	require.NoError(t, err)
+
+	retryable, err := deliveryFn(ctx, msgID)
+	require.NoError(t, err)
+	require.False(t, retryable)
+
+	payload := testutil.RequireReceive(ctx, t, received)
+	require.Equal(t, msgID, payload.MsgID)
+	require.Equal(t, "title", payload.Title)
+}
+
+func TestWebhookTargetInternalAddress(t *testing.T) {
+	t.Parallel()
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// Given: a notification target on the loopback address.
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("notification targets on internal addresses should not receive messages")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(target.Close)
+
+	handler := dispatch.NewWebhookHandler(codersdk.NotificationsWebhookConfig{}, logger, quartz.NewReal())
+	deliveryFn, err := handler.TargetDispatcher(target.URL, types.MessagePayload{NotificationName: "test"}, "title", "body", helpers())
+	require.NoError(t, err)
+
+	// When: the message is delivered.
+	retryable, err := deliveryFn(ctx, uuid.New())
+
+	// Then: the connection is refused, and not retried.
+	require.ErrorIs(t, err, dispatch.ErrInternalTargetAddress)
+	require.False(t, retryable)
+}
+
+func TestIsInternalAddress(t *testing.T) {
+	t.Parallel()
+
+	for addr, internal := range map[string]bool{
+		"127.0.0.1":       true,
+		"::1":             true,
+		"0.0.0.0":         true,
+		"10.1.2.3":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"100.64.0.1":      true,
+		"fd00::1":         true,
+		"fe80::1":         true,
+		"::ffff:10.0.0.1": true,
+		"1.1.1.1":         false,
+		"2606:4700::1111": false,
+	} {
+		require.Equal(t, internal, dispatch.IsInternalAddress(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	mClock := quartz.NewMock(t)
+	mClock.Set(time.Now().Truncate(time.Second))
+
+	// Given: a receiver which verifies signatures, and rejects the first attempt.
+	var (
+		attempts   int
+		signatures []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		header := r.Header.Get(codersdk.WebhookSignatureHeader)
+		signatures = append(signatures, header)
+
+		// The payload is signed at the time of the attempt.
+		assert.Equal(t, codersdk.SignWebhookPayload(body, mClock.Now(), "new-secret", "old-secret"), header)
+
+		// While secrets are being rotated, receivers may verify with either of them.
+		assert.NoError(t, codersdk.VerifyWebhookSignature(body, header, codersdk.DefaultWebhookSignatureTolerance, "new-secret"))
+		assert.NoError(t, codersdk.VerifyWebhookSignature(body, header, codersdk.DefaultWebhookSignatureTolerance, "old-secret"))
+		assert.ErrorIs(t, codersdk.VerifyWebhookSignature(body, header, codersdk.DefaultWebhookSignatureTolerance, "other-secret"), codersdk.ErrWebhookSignatureMismatch)
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	handler := dispatch.NewWebhookHandler(codersdk.NotificationsWebhookConfig{
+		Endpoint:       *serpent.URLOf(endpoint),
+		SigningSecrets: serpent.StringArray{"new-secret", "old-secret"},
+	}, logger, mClock)
+	deliveryFn, err := handler.Dispatcher(types.MessagePayload{NotificationName: "test"}, "title", "body", helpers())
+	require.NoError(t, err)
+
+	// When: the message is delivered.
+	retryable, err := deliveryFn(ctx, uuid.New())
+
+	// Then: the status code of the rejection is reported.
+	require.True(t, retryable)
+	var respErr *dispatch.UnsuccessfulResponseError
+	require.True(t, errors.As(err, &respErr))
+	require.Equal(t, http.StatusServiceUnavailable, respErr.StatusCode)
+
+	// When: the delivery is retried later.
+	mClock.Advance(time.Minute)
+	retryable, err = deliveryFn(ctx, uuid.New())
+
+	// Then: it is accepted.
+	require.NoError(t, err)
+	require.False(t, retryable)
+	require.Len(t, signatures, 2)
+	require.NotEmpty(t, signatures[0])
+	require.NotEmpty(t, signatures[1])
+	require.NotEqual(t, signatures[0], signatures[1])
+}
@@ -0,0 +1,72 @@
+package dispatch
+
+import (
+	"context"
+	"text/template"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// WebpushDispatcher sends a web push notification to all subscriptions of a user.
+// It is implemented by webpush.Dispatcher.
+type WebpushDispatcher interface {
+	Dispatch(ctx context.Context, userID uuid.UUID, notification codersdk.WebpushMessage) error
+}
+
+// WebpushHandler dispatches notification messages as web push notifications to the browsers the recipient subscribed.
+type WebpushHandler struct {
+	log        slog.Logger
+	dispatcher WebpushDispatcher
+}
+
+func NewWebpushHandler(log slog.Logger, dispatcher WebpushDispatcher) *WebpushHandler {
+	return &WebpushHandler{log: log, dispatcher: dispatcher}
+}
+
+func (w *WebpushHandler) Dispatcher(payload types.MessagePayload, titleMarkdown, bodyMarkdown string, _ template.FuncMap) (DeliveryFunc, error) {
+	title, err := markdown.PlaintextFromMarkdown(titleMarkdown)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+	body, err := markdown.PlaintextFromMarkdown(bodyMarkdown)
+	if err != nil {
+		return nil, xerrors.Errorf("render body: %w", err)
+	}
+
+	actions := make([]codersdk.WebpushMessageAction, 0, len(payload.Actions))
+	for _, action := range payload.Actions {
+		actions = append(actions, codersdk.WebpushMessageAction{
+			Label: action.Label,
+			URL:   action.URL,
+		})
+	}
+
+	return w.dispatch(payload.UserID, codersdk.WebpushMessage{
+		Title:   title,
+		Body:    body,
+		Actions: actions,
+	}), nil
+}
+
+func (w *WebpushHandler) dispatch(recipient string, msg codersdk.WebpushMessage) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (bool, error) {
+		userID, err := uuid.Parse(recipient)
+		if err != nil {
+			return false, xerrors.Errorf("parse user ID: %w", err)
+		}
+
+		w.log.Debug(ctx, "dispatching via web push", slog.F("msg_id", msgID))
+
+		// Subscriptions which fail are dropped by the dispatcher, so an error here is not specific to any one of them.
+		if err := w.dispatcher.Dispatch(ctx, userID, msg); err != nil {
+			return true, xerrors.Errorf("dispatch web push notification: %w", err)
+		}
+		return false, nil
+	}
+}
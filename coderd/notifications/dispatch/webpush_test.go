@@ -0,0 +1,100 @@
+package dispatch_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWebpush(t *testing.T) {
+	t.Parallel()
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		userID        string
+		dispatchErr   error
+		expectedErr   string
+		expectedRetry bool
+	}{
+		{
+			name:   "OK",
+			userID: userID.String(),
+		},
+		{
+			name:          "InvalidUserID",
+			userID:        "invalid",
+			expectedErr:   "parse user ID",
+			expectedRetry: false,
+		},
+		{
+			name:          "DispatchFailed",
+			userID:        userID.String(),
+			dispatchErr:   xerrors.New("no vapid keys"),
+			expectedErr:   "no vapid keys",
+			expectedRetry: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.Context(t, testutil.WaitShort)
+			dispatcher := &fakeWebpushDispatcher{err: tc.dispatchErr}
+			handler := dispatch.NewWebpushHandler(logger.With(slog.F("test", tc.name)), dispatcher)
+
+			payload := types.MessagePayload{
+				NotificationName: "test",
+				UserID:           tc.userID,
+				Actions: []types.TemplateAction{
+					{Label: "View my workspace", URL: "https://coder.com/workspaces/1"},
+				},
+			}
+			deliveryFn, err := handler.Dispatcher(payload, "Workspace *deleted*", "Your workspace was **deleted**.", helpers())
+			require.NoError(t, err)
+
+			retryable, err := deliveryFn(ctx, uuid.New())
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				require.Equal(t, tc.expectedRetry, retryable)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, retryable)
+
+			require.Equal(t, userID, dispatcher.userID)
+			require.Equal(t, codersdk.WebpushMessage{
+				Title: "Workspace deleted",
+				Body:  "Your workspace was deleted.",
+				Actions: []codersdk.WebpushMessageAction{
+					{Label: "View my workspace", URL: "https://coder.com/workspaces/1"},
+				},
+			}, dispatcher.msg)
+		})
+	}
+}
+
+type fakeWebpushDispatcher struct {
+	err    error
+	userID uuid.UUID
+	msg    codersdk.WebpushMessage
+}
+
+func (f *fakeWebpushDispatcher) Dispatch(_ context.Context, userID uuid.UUID, msg codersdk.WebpushMessage) error {
+	f.userID = userID
+	f.msg = msg
+	return f.err
+}
@@ -40,6 +40,7 @@ type StoreEnqueuer struct {
 	defaultMethod  database.NotificationMethod
 	defaultEnabled bool
 	inboxEnabled   bool
+	smtpEnabled    bool
 
 	// helpers holds a map of template funcs which are used when rendering templates. These need to be passed in because
 	// the template funcs will return values which are inappropriately encapsulated in this struct.
@@ -66,6 +67,7 @@ func NewStoreEnqueuer(cfg codersdk.NotificationsConfig, store Store, helpers tem
 		defaultMethod:  method,
 		defaultEnabled: cfg.Enabled(),
 		inboxEnabled:   cfg.Inbox.Enabled.Value(),
+		smtpEnabled:    cfg.SMTP.Smarthost != "",
 		helpers:        helpers,
 		clock:          clock,
 	}, nil
@@ -101,22 +103,149 @@ func (s *StoreEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID
 		return nil, xerrors.Errorf("failed encoding input labels: %w", err)
 	}
 
-	methods := []database.NotificationMethod{}
-	if metadata.CustomMethod.Valid {
-		methods = append(methods, metadata.CustomMethod.NotificationMethod)
+	var routed []database.NotificationTarget
+	if TemplateIsRoutable(templateID) {
+		routed, err = s.store.GetVerifiedNotificationTargetsByTemplateID(ctx, database.GetVerifiedNotificationTargetsByTemplateIDParams{
+			UserID:                 userID,
+			NotificationTemplateID: templateID,
+		})
+		if err != nil {
+			s.log.Warn(ctx, "failed to fetch notification targets", slog.F("template_id", templateID), slog.F("user_id", userID), slog.Error(err))
+			return nil, xerrors.Errorf("notification targets: %w", err)
+		}
+	}
+
+	deliveries := []delivery{}
+	if len(routed) > 0 {
+		// The user routes this notification to their own notification targets, which take precedence over the method
+		// set for the template (or the default one).
+		for _, target := range routed {
+			deliveries = append(deliveries, delivery{
+				method: target.Method,
+				target: uuid.NullUUID{UUID: target.ID, Valid: true},
+			})
+		}
+	} else if metadata.CustomMethod.Valid {
+		deliveries = append(deliveries, delivery{method: metadata.CustomMethod.NotificationMethod})
 	} else if s.defaultEnabled {
-		methods = append(methods, s.defaultMethod)
+		deliveries = append(deliveries, delivery{method: s.defaultMethod})
+	}
+
+	// Chat methods post to channels shared by the whole organization, so private notifications, and those which do not
+	// concern an organization that configured a webhook for the method, fall back to email, or to the inbox when email
+	// is not configured.
+	for i, d := range deliveries {
+		if !d.toChat() {
+			continue
+		}
+		if !templateIsPrivate(templateID) {
+			configured, err := s.organizationWebhookConfigured(ctx, userID, d.method, targets)
+			if err != nil {
+				return nil, xerrors.Errorf("organization webhooks: %w", err)
+			}
+			if configured {
+				continue
+			}
+		}
+		deliveries[i].method = database.NotificationMethodInbox
+		if s.smtpEnabled {
+			deliveries[i].method = database.NotificationMethodSmtp
+		}
 	}
 
 	// All the enqueued messages are enqueued both on the dispatch method set by the user (or default one) and the inbox.
 	// As the inbox is not configurable per the user and is always enabled, we always enqueue the message on the inbox.
 	// The logic is done here in order to have two completely separated processing and retries are handled separately.
-	if !slices.Contains(methods, database.NotificationMethodInbox) && s.inboxEnabled {
-		methods = append(methods, database.NotificationMethodInbox)
+	if !slices.ContainsFunc(deliveries, delivery.toInbox) && s.inboxEnabled {
+		deliveries = append(deliveries, delivery{method: database.NotificationMethodInbox})
 	}
 
+	return s.enqueue(ctx, userID, templateID, input, targets, createdBy, deliveries)
+}
+
+// EnqueueToTarget queues a notification message for delivery to the given notification target only.
+// The target does not need to be verified, nor routed for the template.
+// Returns the IDs of successfully enqueued messages, if any.
+func (s *StoreEnqueuer) EnqueueToTarget(ctx context.Context, target database.NotificationTarget, templateID uuid.UUID, labels map[string]string, data map[string]any, createdBy string) ([]uuid.UUID, error) {
+	metadata, err := s.store.FetchNewMessageMetadata(ctx, database.FetchNewMessageMetadataParams{
+		UserID:                 target.UserID,
+		NotificationTemplateID: templateID,
+	})
+	if err != nil {
+		s.log.Warn(ctx, "failed to fetch message metadata", slog.F("template_id", templateID), slog.F("user_id", target.UserID), slog.Error(err))
+		return nil, xerrors.Errorf("new message metadata: %w", err)
+	}
+
+	payload, err := s.buildPayload(metadata, labels, data, nil)
+	if err != nil {
+		s.log.Warn(ctx, "failed to build payload", slog.F("template_id", templateID), slog.F("user_id", target.UserID), slog.Error(err))
+		return nil, xerrors.Errorf("enqueue notification (payload build): %w", err)
+	}
+
+	input, err := json.Marshal(payload)
+	if err != nil {
+		return nil, xerrors.Errorf("failed encoding input labels: %w", err)
+	}
+
+	return s.enqueue(ctx, target.UserID, templateID, input, nil, createdBy, []delivery{{
+		method: target.Method,
+		target: uuid.NullUUID{UUID: target.ID, Valid: true},
+	}})
+}
+
+// TemplateIsRoutable reports whether users may route notifications of the template to their own notification targets.
+// One-time passcodes are always delivered by the deployment's method, so that a stolen session cannot be used to
+// redirect password resets to a target controlled by an attacker.
+func TemplateIsRoutable(templateID uuid.UUID) bool {
+	return templateID != TemplateUserRequestedOneTimePasscode
+}
+
+// templateIsPrivate reports whether notifications of the template contain secrets meant for the recipient alone, and
+// must therefore never be posted to shared chat channels.
+func templateIsPrivate(templateID uuid.UUID) bool {
+	return templateID == TemplateUserRequestedOneTimePasscode ||
+		templateID == TemplateNotificationTargetVerification
+}
+
+// organizationWebhookConfigured reports whether one of the organizations the message concerns, which are among its
+// targets, configured a webhook for the given chat method. Messages are only posted to the channels of those.
+func (s *StoreEnqueuer) organizationWebhookConfigured(ctx context.Context, userID uuid.UUID, method database.NotificationMethod, targets []uuid.UUID) (bool, error) {
+	if len(targets) == 0 {
+		return false, nil
+	}
+	webhooks, err := s.store.GetOrganizationNotificationWebhooksByUserID(ctx, database.GetOrganizationNotificationWebhooksByUserIDParams{
+		UserID: userID,
+		Method: method,
+	})
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(webhooks, func(webhook database.OrganizationNotificationWebhook) bool {
+		return slices.Contains(targets, webhook.OrganizationID)
+	}), nil
+}
+
+// delivery describes how a notification message is delivered: by a method, and optionally to one of the recipient's
+// own notification targets.
+type delivery struct {
+	method database.NotificationMethod
+	target uuid.NullUUID
+}
+
+func (d delivery) toInbox() bool {
+	return d.method == database.NotificationMethodInbox
+}
+
+func (d delivery) toChat() bool {
+	return d.method == database.NotificationMethodSlack || d.method == database.NotificationMethodTeams
+}
+
+// enqueue inserts a notification message for each of the given deliveries.
+// Returns the IDs of successfully enqueued messages, if any.
+func (s *StoreEnqueuer) enqueue(ctx context.Context, userID, templateID uuid.UUID, input []byte, targets []uuid.UUID, createdBy string, deliveries []delivery) ([]uuid.UUID, error) {
 	uuids := make([]uuid.UUID, 0, 2)
-	for _, method := range methods {
+	for _, d := range deliveries {
+		method := d.method
 		// TODO(DanielleMaywood):
 		// We should have a more permanent solution in the future, but for now this will work.
 		// We do not want password reset notifications to end up in Coder Inbox.
@@ -125,7 +254,7 @@ func (s *StoreEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID
 		}
 
 		id := uuid.New()
-		err = s.store.EnqueueNotificationMessage(ctx, database.EnqueueNotificationMessageParams{
+		err := s.store.EnqueueNotificationMessage(ctx, database.EnqueueNotificationMessageParams{
 			ID:                     id,
 			UserID:                 userID,
 			NotificationTemplateID: templateID,
@@ -134,6 +263,7 @@ func (s *StoreEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID
 			Targets:                targets,
 			CreatedBy:              createdBy,
 			CreatedAt:              dbtime.Time(s.clock.Now().UTC()),
+			NotificationTargetID:   d.target,
 		})
 		if err != nil {
 			// We have a trigger on the notification_messages table named `inhibit_enqueue_if_disabled` which prevents messages
@@ -229,3 +359,8 @@ func (*NoopEnqueuer) EnqueueWithData(context.Context, uuid.UUID, uuid.UUID, map[
 	// nolint:nilnil // irrelevant.
 	return nil, nil
 }
+
+func (*NoopEnqueuer) EnqueueToTarget(context.Context, database.NotificationTarget, uuid.UUID, map[string]string, map[string]any, string) ([]uuid.UUID, error) {
+	// nolint:nilnil // irrelevant.
+	return nil, nil
+}
@@ -0,0 +1,182 @@
+package notifications_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"cdr.dev/slog/v3/sloggers/slogtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmock"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/quartz"
+)
+
+func TestEnqueueOneTimePasscodeIgnoresRoutes(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	store := dbmock.NewMockStore(gomock.NewController(t))
+	userID := uuid.New()
+
+	// Given: a user whose notification targets would be looked up if the template were routable.
+	store.EXPECT().FetchNewMessageMetadata(gomock.Any(), gomock.Any()).Return(database.FetchNewMessageMetadataRow{
+		NotificationName:       "One-Time Passcode",
+		NotificationTemplateID: notifications.TemplateUserRequestedOneTimePasscode,
+		Actions:                []byte("[]"),
+		UserID:                 userID,
+	}, nil)
+	store.EXPECT().GetVerifiedNotificationTargetsByTemplateID(gomock.Any(), gomock.Any()).Times(0)
+
+	// Then: the passcode is delivered by the deployment's method, and not to any notification target.
+	store.EXPECT().EnqueueNotificationMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg database.EnqueueNotificationMessageParams) error {
+			require.Equal(t, database.NotificationMethodSmtp, arg.Method)
+			require.False(t, arg.NotificationTargetID.Valid)
+			return nil
+		})
+
+	enq, err := notifications.NewStoreEnqueuer(defaultNotificationsConfig(database.NotificationMethodSmtp), store, defaultHelpers(), slogtest.Make(t, nil), quartz.NewMock(t))
+	require.NoError(t, err)
+
+	// When: a one-time passcode is enqueued.
+	ids, err := enq.Enqueue(ctx, userID, notifications.TemplateUserRequestedOneTimePasscode, map[string]string{"one_time_passcode": "fad9020b-6562-4cdb-87f1-0486f1bea415"}, "test")
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+}
+
+func TestEnqueuePrivateTemplatesAvoidChatMethods(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		smtp     bool
+		expected []database.NotificationMethod
+	}{
+		{
+			name:     "FallbackToEmail",
+			smtp:     true,
+			expected: []database.NotificationMethod{database.NotificationMethodSmtp, database.NotificationMethodInbox},
+		},
+		{
+			name:     "FallbackToInbox",
+			expected: []database.NotificationMethod{database.NotificationMethodInbox},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.Context(t, testutil.WaitShort)
+			store := dbmock.NewMockStore(gomock.NewController(t))
+			userID := uuid.New()
+
+			// Given: a deployment which posts notifications to chat channels by default.
+			cfg := defaultNotificationsConfig(database.NotificationMethodSlack)
+			if tc.smtp {
+				cfg.SMTP.Smarthost = "localhost:1337"
+			}
+
+			store.EXPECT().FetchNewMessageMetadata(gomock.Any(), gomock.Any()).Return(database.FetchNewMessageMetadataRow{
+				NotificationName:       "Notification Target Verification",
+				NotificationTemplateID: notifications.TemplateNotificationTargetVerification,
+				Actions:                []byte("[]"),
+				UserID:                 userID,
+			}, nil)
+			store.EXPECT().GetVerifiedNotificationTargetsByTemplateID(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			// Then: the verification code is never posted to a chat channel.
+			var methods []database.NotificationMethod
+			store.EXPECT().EnqueueNotificationMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, arg database.EnqueueNotificationMessageParams) error {
+					methods = append(methods, arg.Method)
+					return nil
+				}).AnyTimes()
+
+			enq, err := notifications.NewStoreEnqueuer(cfg, store, defaultHelpers(), slogtest.Make(t, nil), quartz.NewMock(t))
+			require.NoError(t, err)
+
+			// When: a private notification is enqueued.
+			_, err = enq.Enqueue(ctx, userID, notifications.TemplateNotificationTargetVerification, map[string]string{"verification_code": "123456"}, "test")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, methods)
+		})
+	}
+}
+
+func TestEnqueueChatMethodsOnlyForOrganizationsWithWebhooks(t *testing.T) {
+	t.Parallel()
+
+	orgA, orgB := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		targets  []uuid.UUID
+		expected []database.NotificationMethod
+	}{
+		{
+			name:     "OrganizationWithWebhook",
+			targets:  []uuid.UUID{uuid.New(), orgA},
+			expected: []database.NotificationMethod{database.NotificationMethodSlack, database.NotificationMethodInbox},
+		},
+		{
+			name:     "OrganizationWithoutWebhook",
+			targets:  []uuid.UUID{uuid.New(), orgB},
+			expected: []database.NotificationMethod{database.NotificationMethodSmtp, database.NotificationMethodInbox},
+		},
+		{
+			name:     "NoOrganization",
+			expected: []database.NotificationMethod{database.NotificationMethodSmtp, database.NotificationMethodInbox},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := testutil.Context(t, testutil.WaitShort)
+			store := dbmock.NewMockStore(gomock.NewController(t))
+			userID := uuid.New()
+
+			// Given: a deployment which posts notifications to chat channels by default, and a user who is a member
+			// of organization A, which configured a Slack webhook, and of organization B, which did not.
+			cfg := defaultNotificationsConfig(database.NotificationMethodSlack)
+			cfg.SMTP.Smarthost = "localhost:1337"
+
+			store.EXPECT().FetchNewMessageMetadata(gomock.Any(), gomock.Any()).Return(database.FetchNewMessageMetadataRow{
+				NotificationName:       "Workspace Deleted",
+				NotificationTemplateID: notifications.TemplateWorkspaceDeleted,
+				Actions:                []byte("[]"),
+				UserID:                 userID,
+			}, nil)
+			store.EXPECT().GetVerifiedNotificationTargetsByTemplateID(gomock.Any(), gomock.Any()).Return(nil, nil)
+			store.EXPECT().GetOrganizationNotificationWebhooksByUserID(gomock.Any(), database.GetOrganizationNotificationWebhooksByUserIDParams{
+				UserID: userID,
+				Method: database.NotificationMethodSlack,
+			}).Return([]database.OrganizationNotificationWebhook{
+				{OrganizationID: orgA, Method: database.NotificationMethodSlack, Endpoint: "https://hooks.slack.com/services/a"},
+			}, nil).MaxTimes(1)
+
+			// Then: the notification is only posted to a chat channel if it concerns organization A.
+			var methods []database.NotificationMethod
+			store.EXPECT().EnqueueNotificationMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, arg database.EnqueueNotificationMessageParams) error {
+					methods = append(methods, arg.Method)
+					return nil
+				}).AnyTimes()
+
+			enq, err := notifications.NewStoreEnqueuer(cfg, store, defaultHelpers(), slogtest.Make(t, nil), quartz.NewMock(t))
+			require.NoError(t, err)
+
+			// When: a notification is enqueued.
+			_, err = enq.Enqueue(ctx, userID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "bobby-ws"}, "test", tc.targets...)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, methods)
+		})
+	}
+}
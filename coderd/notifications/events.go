@@ -18,6 +18,8 @@ var (
 	TemplateWorkspaceManualBuildFailed = uuid.MustParse("2faeee0f-26cb-4e96-821c-85ccb9f71513")
 	TemplateWorkspaceOutOfMemory       = uuid.MustParse("a9d027b4-ac49-4fb1-9f6d-45af15f64e7a")
 	TemplateWorkspaceOutOfDisk         = uuid.MustParse("f047f6a3-5713-40f7-85aa-0394cce9fa3a")
+
+	TemplateWorkspaceMaintenanceScheduled = uuid.MustParse("6d088baf-3132-4ca5-9915-0b85bbe69b67")
 )
 
 // Account-related events.
@@ -40,6 +42,7 @@ var (
 
 	TemplateWorkspaceBuildsFailedReport = uuid.MustParse("34a20db2-e9cc-4a93-b0e4-8569699d7a00")
 	TemplateWorkspaceResourceReplaced   = uuid.MustParse("89d9745a-816e-4695-a17f-3d0a229e2b8d")
+	TemplateMaintenanceWindowReport     = uuid.MustParse("aa9c27c5-abed-4782-90f7-9becc8722cc3")
 )
 
 // Prebuilds-related events.
@@ -49,8 +52,9 @@ var (
 
 // Notification-related events.
 var (
-	TemplateTestNotification   = uuid.MustParse("c425f63e-716a-4bf4-ae24-78348f706c3f")
-	TemplateCustomNotification = uuid.MustParse("39b1e189-c857-4b0c-877a-511144c18516")
+	TemplateTestNotification               = uuid.MustParse("c425f63e-716a-4bf4-ae24-78348f706c3f")
+	TemplateCustomNotification             = uuid.MustParse("39b1e189-c857-4b0c-877a-511144c18516")
+	TemplateNotificationTargetVerification = uuid.MustParse("e3f5d1a8-6b2c-4f0e-9d7a-1c4b8e2f6a93")
 )
 
 // Task-related events.
@@ -73,6 +73,13 @@ func WithTestClock(clock quartz.Clock) ManagerOption {
 	}
 }
 
+// WithWebpushDispatcher enables the delivery of notification messages as web push notifications.
+func WithWebpushDispatcher(dispatcher dispatch.WebpushDispatcher) ManagerOption {
+	return func(m *Manager) {
+		m.handlers[database.NotificationMethodWebpush] = dispatch.NewWebpushHandler(m.log.Named("dispatcher.webpush"), dispatcher)
+	}
+}
+
 // NewManager instantiates a new Manager instance which coordinates notification enqueuing and delivery.
 //
 // helpers is a map of template helpers which are used to customize notification messages to use global settings like
@@ -110,7 +117,7 @@ func NewManager(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub,
 		stop: make(chan any),
 		done: make(chan any),
 
-		handlers: defaultHandlers(cfg, log, store, ps),
+		handlers: make(map[database.NotificationMethod]Handler),
 		helpers:  helpers,
 
 		clock: quartz.NewReal(),
@@ -118,15 +125,24 @@ func NewManager(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub,
 	for _, o := range opts {
 		o(m)
 	}
+	// The default handlers are built once the options are applied so that they share the manager's clock, but they
+	// don't replace handlers which were set by options.
+	for method, handler := range defaultHandlers(cfg, log, store, ps, m.clock) {
+		if _, ok := m.handlers[method]; !ok {
+			m.handlers[method] = handler
+		}
+	}
 	return m, nil
 }
 
 // defaultHandlers builds a set of known handlers; panics if any error occurs as these handlers should be valid at compile time.
-func defaultHandlers(cfg codersdk.NotificationsConfig, log slog.Logger, store Store, ps pubsub.Pubsub) map[database.NotificationMethod]Handler {
+func defaultHandlers(cfg codersdk.NotificationsConfig, log slog.Logger, store Store, ps pubsub.Pubsub, clock quartz.Clock) map[database.NotificationMethod]Handler {
 	return map[database.NotificationMethod]Handler{
 		database.NotificationMethodSmtp:    dispatch.NewSMTPHandler(cfg.SMTP, log.Named("dispatcher.smtp")),
-		database.NotificationMethodWebhook: dispatch.NewWebhookHandler(cfg.Webhook, log.Named("dispatcher.webhook")),
+		database.NotificationMethodWebhook: dispatch.NewWebhookHandler(cfg.Webhook, log.Named("dispatcher.webhook"), clock),
 		database.NotificationMethodInbox:   dispatch.NewInboxHandler(log.Named("dispatcher.inbox"), store, ps),
+		database.NotificationMethodSlack:   dispatch.NewSlackHandler(log.Named("dispatcher.slack"), store),
+		database.NotificationMethodTeams:   dispatch.NewTeamsHandler(log.Named("dispatcher.teams"), store),
 	}
 }
 
@@ -278,8 +294,10 @@ func (m *Manager) syncUpdates(ctx context.Context) {
 		res := <-m.failure
 
 		var (
-			reason string
-			status database.NotificationMessageStatus
+			reason     string
+			status     database.NotificationMessageStatus
+			statusCode int32
+			respErr    *dispatch.UnsuccessfulResponseError
 		)
 
 		switch {
@@ -299,6 +317,11 @@ func (m *Manager) syncUpdates(ctx context.Context) {
 			reason = res.err.Error()
 		}
 		failureParams.StatusReasons = append(failureParams.StatusReasons, reason)
+		if xerrors.As(res.err, &respErr) {
+			// #nosec G115 - HTTP status codes are well within int32 range
+			statusCode = int32(respErr.StatusCode)
+		}
+		failureParams.ResponseStatusCodes = append(failureParams.ResponseStatusCodes, statusCode)
 	}
 
 	// Execute bulk updates for success/failure concurrently.
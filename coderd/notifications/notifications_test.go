@@ -3,6 +3,7 @@ package notifications_test
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	_ "embed"
 	"encoding/json"
 	"flag"
@@ -265,6 +266,124 @@ func TestWebhookDispatch(t *testing.T) {
 	require.NotEmpty(t, payload.Payload.NotificationName)
 }
 
+// TestWebhookDeliveryStatusCode validates that the status code of a failed webhook delivery is recorded on its message.
+func TestWebhookDeliveryStatusCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := dbauthz.AsNotifier(testutil.Context(t, testutil.WaitSuperLong))
+	store, pubsub := dbtestutil.NewDB(t)
+	logger := testutil.Logger(t)
+
+	// GIVEN: a webhook endpoint which is unavailable, and a single delivery attempt per message.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	cfg := defaultNotificationsConfig(database.NotificationMethodWebhook)
+	cfg.Webhook = codersdk.NotificationsWebhookConfig{
+		Endpoint: *serpent.URLOf(endpoint),
+	}
+	cfg.MaxSendAttempts = 1
+	cfg.StoreSyncInterval = serpent.Duration(time.Millisecond * 100)
+	cfg.FetchInterval = serpent.Duration(time.Millisecond * 100)
+
+	mgr, err := notifications.NewManager(cfg, store, pubsub, defaultHelpers(), createMetrics(), logger.Named("manager"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mgr.Stop(ctx))
+	})
+	enq, err := notifications.NewStoreEnqueuer(cfg, store, defaultHelpers(), logger.Named("enqueuer"), quartz.NewReal())
+	require.NoError(t, err)
+
+	user := createSampleUser(t, store)
+
+	// WHEN: a notification is enqueued and delivered.
+	msgIDs, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+	require.NoError(t, err)
+
+	mgr.Run(ctx)
+
+	// THEN: the message failed permanently with the status code of the response.
+	var delivery database.GetNotificationMessageDeliveriesRow
+	require.Eventually(t, func() bool {
+		deliveries, err := store.GetNotificationMessageDeliveries(ctx, database.GetNotificationMessageDeliveriesParams{
+			Method: string(database.NotificationMethodWebhook),
+			Limit:  10,
+		})
+		if !assert.NoError(t, err) || len(deliveries) != 1 {
+			return false
+		}
+		delivery = deliveries[0]
+		return delivery.Status == database.NotificationMessageStatusPermanentFailure
+	}, testutil.WaitLong, testutil.IntervalFast)
+	require.Contains(t, msgIDs, delivery.ID)
+	require.Equal(t, sql.NullInt32{Int32: http.StatusBadGateway, Valid: true}, delivery.ResponseStatusCode)
+	require.Equal(t, sql.NullInt32{Int32: 1, Valid: true}, delivery.AttemptCount)
+	require.Contains(t, delivery.StatusReason.String, "non-2xx response (502)")
+}
+
+// TestWebhookSignatureClock validates that webhook deliveries are signed using the manager's clock.
+func TestWebhookSignatureClock(t *testing.T) {
+	t.Parallel()
+
+	ctx := dbauthz.AsNotifier(testutil.Context(t, testutil.WaitLong))
+	store, pubsub := dbtestutil.NewDB(t)
+	logger := testutil.Logger(t)
+
+	// GIVEN: a manager whose clock lags behind the real time.
+	mClock := quartz.NewMock(t)
+	mClock.Set(time.Now().Add(-time.Hour).Truncate(time.Second))
+	fetchTrap := mClock.Trap().TickerFunc("notifier", "fetchInterval")
+	defer fetchTrap.Close()
+
+	signatures := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		header := r.Header.Get(codersdk.WebhookSignatureHeader)
+		assert.Equal(t, codersdk.SignWebhookPayload(body, mClock.Now(), "secret"), header)
+		w.WriteHeader(http.StatusOK)
+		signatures <- header
+	}))
+	defer server.Close()
+
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	cfg := defaultNotificationsConfig(database.NotificationMethodWebhook)
+	cfg.Webhook = codersdk.NotificationsWebhookConfig{
+		Endpoint:       *serpent.URLOf(endpoint),
+		SigningSecrets: serpent.StringArray{"secret"},
+	}
+	mgr, err := notifications.NewManager(cfg, store, pubsub, defaultHelpers(), createMetrics(), logger.Named("manager"),
+		notifications.WithTestClock(mClock))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mgr.Stop(ctx))
+	})
+	enq, err := notifications.NewStoreEnqueuer(cfg, store, defaultHelpers(), logger.Named("enqueuer"), quartz.NewReal())
+	require.NoError(t, err)
+
+	user := createSampleUser(t, store)
+
+	// WHEN: a notification is enqueued and delivered.
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+	require.NoError(t, err)
+
+	mgr.Run(ctx)
+	fetchTrap.MustWait(ctx).MustRelease(ctx)
+	mClock.Advance(cfg.FetchInterval.Value())
+
+	// THEN: the delivery is signed at the time of the manager's clock, rather than the real time.
+	header := testutil.TryReceive(ctx, t, signatures)
+	require.NotEmpty(t, header)
+	require.Contains(t, header, fmt.Sprintf("t=%d", mClock.Now().Unix()))
+}
+
 // TestBackpressure validates that delays in processing the buffered updates will result in slowed dequeue rates.
 // As a side-effect, this also tests the graceful shutdown and flushing of the buffers.
 func TestBackpressure(t *testing.T) {
@@ -442,7 +561,7 @@ func TestRetries(t *testing.T) {
 	cfg.RetryInterval = serpent.Duration(time.Second) // query uses second-precision
 	cfg.FetchInterval = serpent.Duration(time.Millisecond * 100)
 
-	handler := newDispatchInterceptor(dispatch.NewWebhookHandler(cfg.Webhook, logger.Named("webhook")))
+	handler := newDispatchInterceptor(dispatch.NewWebhookHandler(cfg.Webhook, logger.Named("webhook"), quartz.NewReal()))
 
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: store}
@@ -1246,6 +1365,21 @@ func TestNotificationTemplates_Golden(t *testing.T) {
 				Data: map[string]any{},
 			},
 		},
+		{
+			name: "TemplateNotificationTargetVerification",
+			id:   notifications.TemplateNotificationTargetVerification,
+			payload: types.MessagePayload{
+				UserName:     "Bobby",
+				UserEmail:    "bobby@coder.com",
+				UserUsername: "bobby",
+				Labels: map[string]string{
+					"target_name": "personal-alerts",
+					"code":        "123456",
+					"expires_in":  "1 hour",
+				},
+				Data: map[string]any{},
+			},
+		},
 		{
 			name: "TemplateTaskWorking",
 			id:   notifications.TemplateTaskWorking,
@@ -1302,6 +1436,64 @@ func TestNotificationTemplates_Golden(t *testing.T) {
 				Data: map[string]any{},
 			},
 		},
+		{
+			name: "TemplateWorkspaceMaintenanceScheduled",
+			id:   notifications.TemplateWorkspaceMaintenanceScheduled,
+			payload: types.MessagePayload{
+				UserName:     "Bobby",
+				UserEmail:    "bobby@coder.com",
+				UserUsername: "bobby",
+				Labels: map[string]string{
+					"name":                  "bobby-workspace",
+					"template":              "Bobby's Template",
+					"template_version_name": "v2",
+					"window_start":          "2024-10-12 02:00 UTC",
+				},
+				Data: map[string]any{},
+			},
+		},
+		{
+			name: "TemplateMaintenanceWindowReport",
+			id:   notifications.TemplateMaintenanceWindowReport,
+			payload: types.MessagePayload{
+				UserName:     "Bobby",
+				UserEmail:    "bobby@coder.com",
+				UserUsername: "bobby",
+				Labels: map[string]string{
+					"template_name":         "bobby-template",
+					"template_display_name": "Bobby's Template",
+					"template_version_name": "v2",
+				},
+				Data: map[string]any{
+					"updated_workspaces": []map[string]any{
+						{
+							"id":             "24f5bd8f-1566-4374-9734-c3efa0454dc7",
+							"name":           "workspace-1",
+							"owner_username": "mtojek",
+						},
+						{
+							"id":             "372a194b-dcde-43f1-b7cf-8a2f3d3114a0",
+							"name":           "my-workspace-3",
+							"owner_username": "johndoe",
+						},
+					},
+					"failed_workspaces": []map[string]any{
+						{
+							"id":             "1386d294-19c1-4351-89e2-6cae1afb9bfe",
+							"name":           "workwork",
+							"owner_username": "jack",
+						},
+					},
+					"outdated_workspaces": []map[string]any{
+						{
+							"id":             "86fd99b1-1b6e-4b7e-b58e-0aee6e35c159",
+							"name":           "cool-workspace",
+							"owner_username": "ben",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	// We must have a test case for every notification_template. This is enforced below:
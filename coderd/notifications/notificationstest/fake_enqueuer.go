@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
@@ -32,6 +33,8 @@ type FakeNotification struct {
 	Data               map[string]any
 	CreatedBy          string
 	Targets            []uuid.UUID
+	// NotificationTargetID is set for notifications enqueued for a single notification target.
+	NotificationTargetID uuid.NullUUID
 }
 
 // TODO: replace this with actual calls to dbauthz.
@@ -74,6 +77,24 @@ func (f *FakeEnqueuer) EnqueueWithData(ctx context.Context, userID, templateID u
 	return f.enqueueWithDataLock(ctx, userID, templateID, labels, data, createdBy, targets...)
 }
 
+func (f *FakeEnqueuer) EnqueueToTarget(ctx context.Context, target database.NotificationTarget, templateID uuid.UUID, labels map[string]string, data map[string]any, createdBy string) ([]uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.assertRBACNoLock(ctx)
+
+	f.sent = append(f.sent, &FakeNotification{
+		UserID:               target.UserID,
+		TemplateID:           templateID,
+		Labels:               labels,
+		Data:                 data,
+		CreatedBy:            createdBy,
+		NotificationTargetID: uuid.NullUUID{UUID: target.ID, Valid: true},
+	})
+
+	id := uuid.New()
+	return []uuid.UUID{id}, nil
+}
+
 func (f *FakeEnqueuer) enqueueWithDataLock(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string, data map[string]any, createdBy string, targets ...uuid.UUID) ([]uuid.UUID, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -257,6 +257,15 @@ func (n *notifier) prepare(ctx context.Context, msg database.AcquireNotification
 		return nil, xerrors.Errorf("render body: %w", err)
 	}
 
+	// Messages for one of the user's own notification targets are delivered to the target's address, if it has one.
+	if msg.NotificationTargetAddress != "" {
+		targetHandler, ok := handler.(TargetHandler)
+		if !ok {
+			return nil, xerrors.Errorf("handler %q does not support notification targets", msg.Method)
+		}
+		return targetHandler.TargetDispatcher(msg.NotificationTargetAddress, payload, title, body, helpers)
+	}
+
 	return handler.Dispatcher(payload, title, body, helpers)
 }
 
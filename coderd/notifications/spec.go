@@ -23,10 +23,12 @@ type Store interface {
 	FetchNewMessageMetadata(ctx context.Context, arg database.FetchNewMessageMetadataParams) (database.FetchNewMessageMetadataRow, error)
 	GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error)
 	GetNotificationsSettings(ctx context.Context) (string, error)
+	GetVerifiedNotificationTargetsByTemplateID(ctx context.Context, arg database.GetVerifiedNotificationTargetsByTemplateIDParams) ([]database.NotificationTarget, error)
 	GetApplicationName(ctx context.Context) (string, error)
 	GetLogoURL(ctx context.Context) (string, error)
 
 	InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) (database.InboxNotification, error)
+	GetOrganizationNotificationWebhooksByUserID(ctx context.Context, arg database.GetOrganizationNotificationWebhooksByUserIDParams) ([]database.OrganizationNotificationWebhook, error)
 }
 
 // Handler is responsible for preparing and delivering a notification by a given method.
@@ -35,8 +37,19 @@ type Handler interface {
 	Dispatcher(payload types.MessagePayload, title, body string, helpers template.FuncMap) (dispatch.DeliveryFunc, error)
 }
 
+// TargetHandler is a Handler which can also deliver a notification to the address of one of the recipient's own
+// notification targets, such as their personal webhook endpoint.
+type TargetHandler interface {
+	Handler
+	// TargetDispatcher constructs a DeliveryFunc to be used for delivering a notification to the given address.
+	TargetDispatcher(address string, payload types.MessagePayload, title, body string, helpers template.FuncMap) (dispatch.DeliveryFunc, error)
+}
+
 // Enqueuer enqueues a new notification message in the store and returns its ID, should it enqueue without failure.
 type Enqueuer interface {
 	Enqueue(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string, createdBy string, targets ...uuid.UUID) ([]uuid.UUID, error)
 	EnqueueWithData(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string, data map[string]any, createdBy string, targets ...uuid.UUID) ([]uuid.UUID, error)
+	// EnqueueToTarget enqueues a notification message for delivery to a single notification target of its owner only,
+	// whether or not the target is verified. It is used to verify notification targets.
+	EnqueueToTarget(ctx context.Context, target database.NotificationTarget, templateID uuid.UUID, labels map[string]string, data map[string]any, createdBy string) ([]uuid.UUID, error)
 }
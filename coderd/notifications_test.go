@@ -4,12 +4,16 @@ import (
 	"net/http"
 	"slices"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/notifications/notificationstest"
 	"github.com/coder/coder/v2/codersdk"
@@ -296,7 +300,7 @@ func TestNotificationDispatchMethods(t *testing.T) {
 
 	var allMethods []string
 	for _, nm := range database.AllNotificationMethodValues() {
-		if nm == database.NotificationMethodInbox {
+		if nm == database.NotificationMethodInbox || nm == database.NotificationMethodWebpush {
 			continue
 		}
 		allMethods = append(allMethods, string(nm))
@@ -486,3 +490,78 @@ func TestCustomNotification(t *testing.T) {
 		require.Equal(t, memberUser.ID.String(), sent[0].CreatedBy)
 	})
 }
+
+func TestNotificationDeliveries(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	ownerClient, db := coderdtest.NewWithDatabase(t, createOpts(t))
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	memberClient, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	// Given: one webhook message which failed with a 502 and one pending SMTP message.
+	failedID := uuid.New()
+	for _, msg := range []database.EnqueueNotificationMessageParams{
+		{ID: failedID, Method: database.NotificationMethodWebhook},
+		{ID: uuid.New(), Method: database.NotificationMethodSmtp},
+	} {
+		msg.NotificationTemplateID = notifications.TemplateTestNotification
+		msg.UserID = owner.UserID
+		msg.Payload = []byte(`{}`)
+		msg.CreatedBy = "test"
+		msg.CreatedAt = dbtime.Now()
+		require.NoError(t, db.EnqueueNotificationMessage(dbauthz.AsNotifier(ctx), msg))
+	}
+	_, err := db.BulkMarkNotificationMessagesFailed(dbauthz.AsNotifier(ctx), database.BulkMarkNotificationMessagesFailedParams{
+		MaxAttempts:         5,
+		RetryInterval:       60,
+		IDs:                 []uuid.UUID{failedID},
+		FailedAts:           []time.Time{dbtime.Now()},
+		Statuses:            []database.NotificationMessageStatus{database.NotificationMessageStatusTemporaryFailure},
+		StatusReasons:       []string{"non-2xx response (502)"},
+		ResponseStatusCodes: []int32{http.StatusBadGateway},
+	})
+	require.NoError(t, err)
+
+	t.Run("Owner", func(t *testing.T) {
+		t.Parallel()
+
+		deliveries, err := ownerClient.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+
+		deliveries, err = ownerClient.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{
+			Method: string(database.NotificationMethodWebhook),
+		})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, failedID, deliveries[0].MessageID)
+		require.Equal(t, notifications.TemplateTestNotification, deliveries[0].TemplateID)
+		require.Equal(t, string(database.NotificationMessageStatusTemporaryFailure), deliveries[0].Status)
+		require.Equal(t, http.StatusBadGateway, deliveries[0].ResponseStatusCode)
+		require.EqualValues(t, 1, deliveries[0].AttemptCount)
+	})
+
+	t.Run("MemberForbidden", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := memberClient.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+	})
+
+	t.Run("InvalidParams", func(t *testing.T) {
+		t.Parallel()
+
+		for _, req := range []codersdk.NotificationDeliveriesRequest{
+			{Method: "carrier-pigeon"},
+			{Limit: 101},
+		} {
+			_, err := ownerClient.NotificationDeliveries(ctx, req)
+			var sdkError *codersdk.Error
+			require.ErrorAs(t, err, &sdkError)
+			require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+		}
+	})
+}
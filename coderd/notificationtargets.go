@@ -0,0 +1,374 @@
+package coderd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/httprate"
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/userpassword"
+	"github.com/coder/coder/v2/coderd/util/slice"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/cryptorand"
+)
+
+const (
+	// notificationTargetVerificationCodeValidity is how long the verification code sent to a notification target is
+	// valid.
+	notificationTargetVerificationCodeValidity = time.Hour
+	// notificationTargetTestInterval is how long a user must wait before sending another test message to the same
+	// notification target.
+	notificationTargetTestInterval = time.Minute
+	// notificationTargetTestsPerHour is how many test messages a user may send per hour across all of their
+	// notification targets. Test messages are sent to addresses which have not been verified yet, so they must not be
+	// usable to send mail to arbitrary addresses in bulk.
+	notificationTargetTestsPerHour = 10
+)
+
+// notificationTargetTestRateLimit limits the test messages a user can send across all of their notification targets.
+func notificationTargetTestRateLimit() func(http.Handler) http.Handler {
+	return httprate.Limit(
+		notificationTargetTestsPerHour,
+		time.Hour,
+		httprate.WithKeyFuncs(func(r *http.Request) (string, error) {
+			return httpmw.APIKey(r).UserID.String(), nil
+		}),
+		httprate.WithLimitHandler(func(rw http.ResponseWriter, r *http.Request) {
+			httpapi.Write(r.Context(), rw, http.StatusTooManyRequests, codersdk.Response{
+				Message: fmt.Sprintf("You can send at most %d test messages to notification targets per hour.", notificationTargetTestsPerHour),
+			})
+		}),
+	)
+}
+
+// @Summary Get user notification targets
+// @ID get-user-notification-targets
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {array} codersdk.NotificationTarget
+// @Router /users/{user}/notifications/targets [get]
+func (api *API) userNotificationTargets(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	targets, err := api.Database.GetNotificationTargetsByUserID(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification targets.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, slice.List(targets, convertNotificationTarget))
+}
+
+// @Summary Create user notification target
+// @Description Notification targets are created unverified, and only receive notifications once verified
+// @Description with the code from their test message.
+// @ID create-user-notification-target
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.CreateNotificationTargetRequest true "Notification target"
+// @Success 201 {object} codersdk.NotificationTarget
+// @Router /users/{user}/notifications/targets [post]
+func (api *API) postUserNotificationTarget(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	var req codersdk.CreateNotificationTargetRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	method := database.NotificationMethod(req.Method)
+	if err := validateNotificationTargetAddress(ctx, method, req.Address); err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid notification target.",
+			Validations: []codersdk.ValidationError{
+				{Field: "address", Detail: err.Error()},
+			},
+		})
+		return
+	}
+
+	now := dbtime.Now()
+	target, err := api.Database.InsertNotificationTarget(ctx, database.InsertNotificationTargetParams{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Name:      req.Name,
+		Method:    method,
+		Address:   req.Address,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if database.IsUniqueViolation(err, database.UniqueNotificationTargetsUserIDNameKey) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "A notification target with this name already exists.",
+			Validations: []codersdk.ValidationError{
+				{Field: "name", Detail: "This value is already in use and should be unique."},
+			},
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to create notification target.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertNotificationTarget(target))
+}
+
+// @Summary Delete user notification target
+// @ID delete-user-notification-target
+// @Security CoderSessionToken
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param notification_target path string true "Notification target ID" format(uuid)
+// @Success 204
+// @Router /users/{user}/notifications/targets/{notification_target} [delete]
+func (api *API) deleteUserNotificationTarget(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		target = httpmw.NotificationTargetParam(r)
+	)
+
+	// Routes to the target and its pending messages are deleted along with it.
+	if err := api.Database.DeleteNotificationTargetByID(ctx, target.ID); err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to delete notification target.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Send a test message to user notification target
+// @Description The test message contains a code with which the notification target can be verified.
+// @Description Sending a new test message invalidates the code of the previous one.
+// @ID send-a-test-message-to-user-notification-target
+// @Security CoderSessionToken
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param notification_target path string true "Notification target ID" format(uuid)
+// @Success 204
+// @Router /users/{user}/notifications/targets/{notification_target}/test [post]
+func (api *API) postUserNotificationTargetTest(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		target = httpmw.NotificationTargetParam(r)
+		logger = api.Logger.Named("notifications.targets").With(slog.F("user_id", target.UserID), slog.F("target_id", target.ID))
+	)
+
+	now := dbtime.Now()
+	if target.VerificationCodeExpiresAt.Valid {
+		lastSent := target.VerificationCodeExpiresAt.Time.Add(-notificationTargetVerificationCodeValidity)
+		if wait := lastSent.Add(notificationTargetTestInterval).Sub(now); wait > 0 {
+			rw.Header().Set("Retry-After", strconv.Itoa(int(wait.Round(time.Second).Seconds())))
+			httpapi.Write(ctx, rw, http.StatusTooManyRequests, codersdk.Response{
+				Message: "A test message was sent to this notification target recently.",
+				Detail:  fmt.Sprintf("Wait %s before sending another test message.", wait.Round(time.Second)),
+			})
+			return
+		}
+	}
+
+	code, err := cryptorand.StringCharset(cryptorand.Upper+cryptorand.Numeric, 8)
+	if err != nil {
+		httpapi.InternalServerError(rw, xerrors.Errorf("generate verification code: %w", err))
+		return
+	}
+	hashedCode, err := userpassword.Hash(code)
+	if err != nil {
+		httpapi.InternalServerError(rw, xerrors.Errorf("hash verification code: %w", err))
+		return
+	}
+
+	target, err = api.Database.UpdateNotificationTargetVerificationCode(ctx, database.UpdateNotificationTargetVerificationCodeParams{
+		HashedVerificationCode:    []byte(hashedCode),
+		VerificationCodeExpiresAt: now.Add(notificationTargetVerificationCodeValidity),
+		UpdatedAt:                 now,
+		ID:                        target.ID,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update notification target.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	msgIDs, err := api.NotificationsEnqueuer.EnqueueToTarget(
+		//nolint:gocritic // We need to be notifier to send the notification.
+		dbauthz.AsNotifier(ctx),
+		target,
+		notifications.TemplateNotificationTargetVerification,
+		map[string]string{
+			"target_name": target.Name,
+			"code":        code,
+			"expires_in":  "1 hour",
+		},
+		map[string]any{},
+		"send-notification-target-test",
+	)
+	if err != nil {
+		logger.Error(ctx, "send notification target test message", slog.Error(err))
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to send test message to notification target.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if len(msgIDs) == 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Test message was not sent to notification target.",
+			Detail:  "Enable the \"Notification Target Verification\" notification to verify notification targets.",
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Verify user notification target
+// @ID verify-user-notification-target
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param notification_target path string true "Notification target ID" format(uuid)
+// @Param request body codersdk.VerifyNotificationTargetRequest true "Verification code"
+// @Success 200 {object} codersdk.NotificationTarget
+// @Router /users/{user}/notifications/targets/{notification_target}/verify [post]
+func (api *API) postUserNotificationTargetVerify(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		target = httpmw.NotificationTargetParam(r)
+	)
+
+	var req codersdk.VerifyNotificationTargetRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if len(target.HashedVerificationCode) == 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "No verification code was sent to this notification target.",
+			Detail:  "Send a test message to the notification target to receive a verification code.",
+		})
+		return
+	}
+
+	equal, err := userpassword.Compare(string(target.HashedVerificationCode), req.Code)
+	if err != nil {
+		httpapi.InternalServerError(rw, xerrors.Errorf("compare verification code: %w", err))
+		return
+	}
+	now := dbtime.Now()
+	if !equal || now.After(target.VerificationCodeExpiresAt.Time) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Incorrect or expired verification code.",
+		})
+		return
+	}
+
+	target, err = api.Database.UpdateNotificationTargetVerifiedAt(ctx, database.UpdateNotificationTargetVerifiedAtParams{
+		VerifiedAt: now,
+		ID:         target.ID,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to verify notification target.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationTarget(target))
+}
+
+// validateNotificationTargetAddress checks that the address can be delivered to by the method. Webhook endpoints
+// must not be on internal addresses, since the server sends requests to them on behalf of the user.
+func validateNotificationTargetAddress(ctx context.Context, method database.NotificationMethod, address string) error {
+	switch method {
+	case database.NotificationMethodWebhook:
+		if err := validateWebhookEndpoint(address); err != nil {
+			return err
+		}
+		u, _ := url.Parse(address)
+		if err := dispatch.ValidateTargetHost(ctx, net.DefaultResolver, u.Hostname()); err != nil {
+			return xerrors.New("webhook endpoint must not be a loopback, link-local, or private network address")
+		}
+	case database.NotificationMethodSmtp:
+		addr, err := mail.ParseAddress(address)
+		if err != nil || addr.Address != address {
+			return xerrors.Errorf("invalid email address %q", address)
+		}
+	case database.NotificationMethodInbox, database.NotificationMethodWebpush:
+		if address != "" {
+			return xerrors.Errorf("method %q does not take an address", method)
+		}
+	default:
+		return xerrors.Errorf("invalid notification method %q", method)
+	}
+	return nil
+}
+
+// validateWebhookEndpoint checks that the endpoint is an absolute HTTP or HTTPS URL.
+func validateWebhookEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return xerrors.Errorf("invalid webhook endpoint: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return xerrors.New("webhook endpoint must be an absolute HTTP or HTTPS URL")
+	}
+	return nil
+}
+
+func convertNotificationTarget(target database.NotificationTarget) codersdk.NotificationTarget {
+	out := codersdk.NotificationTarget{
+		ID:        target.ID,
+		UserID:    target.UserID,
+		Name:      target.Name,
+		Method:    string(target.Method),
+		Address:   target.Address,
+		CreatedAt: target.CreatedAt,
+		UpdatedAt: target.UpdatedAt,
+	}
+	if target.VerifiedAt.Valid {
+		out.VerifiedAt = &target.VerifiedAt.Time
+	}
+	return out
+}
@@ -0,0 +1,305 @@
+package coderd_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/notificationstest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestNotificationTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("CreateAndVerify", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		notifyEnq := &notificationstest.FakeEnqueuer{}
+		api := coderdtest.New(t, &coderdtest.Options{
+			DeploymentValues:      coderdtest.DeploymentValues(t),
+			NotificationsEnqueuer: notifyEnq,
+		})
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Given: a new webhook target.
+		target, err := memberClient.CreateNotificationTarget(ctx, member.ID, codersdk.CreateNotificationTargetRequest{
+			Name:    "alerts",
+			Method:  "webhook",
+			Address: "https://example.com/hooks/alerts",
+		})
+		require.NoError(t, err)
+		require.Nil(t, target.VerifiedAt)
+
+		// When: verifying the target before a test message was sent.
+		_, err = memberClient.VerifyNotificationTarget(ctx, member.ID, target.ID, codersdk.VerifyNotificationTargetRequest{Code: "ABCD1234"})
+
+		// Then: the request is rejected.
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+
+		// When: sending a test message to the target.
+		err = memberClient.TestNotificationTarget(ctx, member.ID, target.ID)
+		require.NoError(t, err)
+
+		// Then: a verification message is enqueued for the target only.
+		sent := notifyEnq.Sent(notificationstest.WithTemplateID(notifications.TemplateNotificationTargetVerification))
+		require.Len(t, sent, 1)
+		require.Equal(t, member.ID, sent[0].UserID)
+		require.Equal(t, uuid.NullUUID{UUID: target.ID, Valid: true}, sent[0].NotificationTargetID)
+		require.Equal(t, "alerts", sent[0].Labels["target_name"])
+		code := sent[0].Labels["code"]
+		require.NotEmpty(t, code)
+
+		// When: verifying the target with an incorrect code.
+		_, err = memberClient.VerifyNotificationTarget(ctx, member.ID, target.ID, codersdk.VerifyNotificationTargetRequest{Code: code + "X"})
+
+		// Then: the request is rejected.
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+
+		// When: verifying the target with the code from the test message.
+		target, err = memberClient.VerifyNotificationTarget(ctx, member.ID, target.ID, codersdk.VerifyNotificationTargetRequest{Code: code})
+
+		// Then: the target is verified, and the code cannot be reused.
+		require.NoError(t, err)
+		require.NotNil(t, target.VerifiedAt)
+		_, err = memberClient.VerifyNotificationTarget(ctx, member.ID, target.ID, codersdk.VerifyNotificationTargetRequest{Code: code})
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+
+		targets, err := memberClient.NotificationTargets(ctx, member.ID)
+		require.NoError(t, err)
+		require.Len(t, targets, 1)
+		require.Equal(t, target.ID, targets[0].ID)
+		require.NotNil(t, targets[0].VerifiedAt)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		t.Parallel()
+
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		for _, req := range []codersdk.CreateNotificationTargetRequest{
+			{Name: "relative-url", Method: "webhook", Address: "/hooks/alerts"},
+			{Name: "ftp-url", Method: "webhook", Address: "ftp://example.com/alerts"},
+			{Name: "bad-email", Method: "smtp", Address: "not an email"},
+			{Name: "inbox-address", Method: "inbox", Address: "bobby@coder.com"},
+			{Name: "unknown-method", Method: "carrier-pigeon"},
+			{Name: "Reset your password at https://example.com", Method: "smtp", Address: "bobby@example.com"},
+		} {
+			ctx := testutil.Context(t, testutil.WaitShort)
+			_, err := memberClient.CreateNotificationTarget(ctx, member.ID, req)
+			requireSDKErrorStatus(t, err, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("InternalAddress", func(t *testing.T) {
+		t.Parallel()
+
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Users must not be able to make the server send requests into its own network.
+		for _, address := range []string{
+			"http://127.0.0.1:8080/hooks",
+			"http://localhost/hooks",
+			"http://169.254.169.254/latest/meta-data/",
+			"http://10.0.0.1/hooks",
+			"http://[::1]/hooks",
+		} {
+			ctx := testutil.Context(t, testutil.WaitShort)
+			_, err := memberClient.CreateNotificationTarget(ctx, member.ID, codersdk.CreateNotificationTargetRequest{
+				Name:    "internal",
+				Method:  "webhook",
+				Address: address,
+			})
+			requireSDKErrorStatus(t, err, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("TestMessageRateLimit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		api := coderdtest.New(t, &coderdtest.Options{
+			DeploymentValues:      coderdtest.DeploymentValues(t),
+			NotificationsEnqueuer: &notificationstest.FakeEnqueuer{},
+		})
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Given: a target to which a test message was just sent.
+		createTarget := func(name string) codersdk.NotificationTarget {
+			target, err := memberClient.CreateNotificationTarget(ctx, member.ID, codersdk.CreateNotificationTargetRequest{
+				Name:    name,
+				Method:  "smtp",
+				Address: name + "@example.com",
+			})
+			require.NoError(t, err)
+			return target
+		}
+		target := createTarget("target-0")
+		require.NoError(t, memberClient.TestNotificationTarget(ctx, member.ID, target.ID))
+
+		// When: sending another test message to the same target right away.
+		err := memberClient.TestNotificationTarget(ctx, member.ID, target.ID)
+
+		// Then: the request is rejected.
+		requireSDKErrorStatus(t, err, http.StatusTooManyRequests)
+
+		// When: sending test messages to many different targets.
+		for i := 1; i < 9; i++ {
+			require.NoError(t, memberClient.TestNotificationTarget(ctx, member.ID, createTarget(fmt.Sprintf("target-%d", i)).ID))
+		}
+		err = memberClient.TestNotificationTarget(ctx, member.ID, createTarget("target-9").ID)
+
+		// Then: the user's hourly limit is reached, whichever targets the messages were sent to.
+		requireSDKErrorStatus(t, err, http.StatusTooManyRequests)
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		req := codersdk.CreateNotificationTargetRequest{Name: "email", Method: "smtp", Address: "bobby@example.com"}
+		_, err := memberClient.CreateNotificationTarget(ctx, member.ID, req)
+		require.NoError(t, err)
+		_, err = memberClient.CreateNotificationTarget(ctx, member.ID, req)
+		requireSDKErrorStatus(t, err, http.StatusConflict)
+	})
+
+	t.Run("OtherUsersTarget", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		api := coderdtest.New(t, createOpts(t))
+		firstUser := coderdtest.CreateFirstUser(t, api)
+
+		// Given: 2 members, one of which has a target.
+		member1Client, member1 := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+		member2Client, member2 := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+		target, err := member1Client.CreateNotificationTarget(ctx, member1.ID, codersdk.CreateNotificationTargetRequest{Name: "inbox", Method: "inbox"})
+		require.NoError(t, err)
+
+		// When: the other member attempts to delete the target, either as its owner or through their own targets.
+		err = member2Client.DeleteNotificationTarget(ctx, member1.ID, target.ID)
+		requireSDKErrorStatus(t, err, http.StatusNotFound)
+		err = member2Client.DeleteNotificationTarget(ctx, member2.ID, target.ID)
+		requireSDKErrorStatus(t, err, http.StatusNotFound)
+
+		// Then: the target still exists.
+		targets, err := member1Client.NotificationTargets(ctx, member1.ID)
+		require.NoError(t, err)
+		require.Len(t, targets, 1)
+
+		// When: the other member attempts to route their notifications to the target.
+		_, err = member2Client.UpdateUserNotificationPreferences(ctx, member2.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateTargetsMap: map[string][]uuid.UUID{
+				notifications.TemplateWorkspaceDeleted.String(): {target.ID},
+			},
+		})
+
+		// Then: the request is rejected.
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("RouteTemplates", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		notifyEnq := &notificationstest.FakeEnqueuer{}
+		api := coderdtest.New(t, &coderdtest.Options{
+			DeploymentValues:      coderdtest.DeploymentValues(t),
+			NotificationsEnqueuer: notifyEnq,
+		})
+		firstUser := coderdtest.CreateFirstUser(t, api)
+		memberClient, member := coderdtest.CreateAnotherUser(t, api, firstUser.OrganizationID)
+
+		// Given: an unverified target.
+		target, err := memberClient.CreateNotificationTarget(ctx, member.ID, codersdk.CreateNotificationTargetRequest{
+			Name:    "alerts",
+			Method:  "webhook",
+			Address: "https://example.com/hooks/alerts",
+		})
+		require.NoError(t, err)
+		route := codersdk.UpdateUserNotificationPreferences{
+			TemplateTargetsMap: map[string][]uuid.UUID{
+				notifications.TemplateWorkspaceDeleted.String(): {target.ID},
+			},
+		}
+
+		// When: routing notifications to the unverified target.
+		_, err = memberClient.UpdateUserNotificationPreferences(ctx, member.ID, route)
+
+		// Then: the request is rejected.
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+
+		// When: routing notifications to the target once verified.
+		require.NoError(t, memberClient.TestNotificationTarget(ctx, member.ID, target.ID))
+		sent := notifyEnq.Sent(notificationstest.WithTemplateID(notifications.TemplateNotificationTargetVerification))
+		require.Len(t, sent, 1)
+		_, err = memberClient.VerifyNotificationTarget(ctx, member.ID, target.ID, codersdk.VerifyNotificationTargetRequest{Code: sent[0].Labels["code"]})
+		require.NoError(t, err)
+		prefs, err := memberClient.UpdateUserNotificationPreferences(ctx, member.ID, route)
+
+		// Then: the template is routed to the target.
+		require.NoError(t, err)
+		require.Len(t, prefs, 1)
+		require.Equal(t, notifications.TemplateWorkspaceDeleted, prefs[0].NotificationTemplateID)
+		require.False(t, prefs[0].Disabled)
+		require.Equal(t, []uuid.UUID{target.ID}, prefs[0].TargetIDs)
+
+		// When: routing one-time passcodes to the target.
+		_, err = memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateTargetsMap: map[string][]uuid.UUID{
+				notifications.TemplateUserRequestedOneTimePasscode.String(): {target.ID},
+			},
+		})
+
+		// Then: the request is rejected, since passcodes are always delivered by the deployment's method.
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+
+		// When: clearing the route.
+		prefs, err = memberClient.UpdateUserNotificationPreferences(ctx, member.ID, codersdk.UpdateUserNotificationPreferences{
+			TemplateTargetsMap: map[string][]uuid.UUID{
+				notifications.TemplateWorkspaceDeleted.String(): {},
+			},
+		})
+
+		// Then: the template is no longer routed.
+		require.NoError(t, err)
+		require.Len(t, prefs, 0)
+
+		// When: routing the template again, and deleting the target.
+		_, err = memberClient.UpdateUserNotificationPreferences(ctx, member.ID, route)
+		require.NoError(t, err)
+		require.NoError(t, memberClient.DeleteNotificationTarget(ctx, member.ID, target.ID))
+
+		// Then: the route is deleted along with the target.
+		prefs, err = memberClient.GetUserNotificationPreferences(ctx, member.ID)
+		require.NoError(t, err)
+		require.Len(t, prefs, 0)
+	})
+}
+
+func requireSDKErrorStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var sdkError *codersdk.Error
+	require.Error(t, err)
+	require.ErrorAsf(t, err, &sdkError, "error should be of type *codersdk.Error")
+	require.Equal(t, status, sdkError.StatusCode())
+}
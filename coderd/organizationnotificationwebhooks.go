@@ -0,0 +1,238 @@
+package coderd
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/util/slice"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// organizationNotificationWebhookMethods are the notification methods which deliver to a webhook configured by the
+// organizations of the recipient.
+var organizationNotificationWebhookMethods = []database.NotificationMethod{
+	database.NotificationMethodSlack,
+	database.NotificationMethodTeams,
+}
+
+// @Summary Get organization notification webhooks
+// @ID get-organization-notification-webhooks
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param organization path string true "Organization ID" format(uuid)
+// @Success 200 {array} codersdk.OrganizationNotificationWebhook
+// @Router /organizations/{organization}/notifications/webhooks [get]
+func (api *API) organizationNotificationWebhooks(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		org = httpmw.OrganizationParam(r)
+	)
+
+	webhooks, err := api.Database.GetOrganizationNotificationWebhooks(ctx, org.ID)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve organization notification webhooks.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, slice.List(webhooks, convertOrganizationNotificationWebhook))
+}
+
+// @Summary Update organization notification webhook
+// @Description Notifications delivered by the method are sent to the webhook of every organization the recipient
+// @Description is a member of which configured one.
+// @ID update-organization-notification-webhook
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param organization path string true "Organization ID" format(uuid)
+// @Param method path string true "Notification method" Enums(slack,teams)
+// @Param request body codersdk.UpdateOrganizationNotificationWebhookRequest true "Webhook"
+// @Success 200 {object} codersdk.OrganizationNotificationWebhook
+// @Router /organizations/{organization}/notifications/webhooks/{method} [put]
+func (api *API) putOrganizationNotificationWebhook(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		org               = httpmw.OrganizationParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.OrganizationNotificationWebhook](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: org.ID,
+		})
+	)
+	defer commitAudit()
+
+	method, ok := parseOrganizationNotificationWebhookMethod(rw, r)
+	if !ok {
+		return
+	}
+
+	old, ok := api.organizationNotificationWebhook(rw, r, org.ID, method)
+	if !ok {
+		return
+	}
+	if old.Method == "" {
+		aReq.Action = database.AuditActionCreate
+	}
+	aReq.Old = old
+
+	var req codersdk.UpdateOrganizationNotificationWebhookRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	if err := validateWebhookEndpoint(req.Endpoint); err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid notification webhook.",
+			Validations: []codersdk.ValidationError{
+				{Field: "endpoint", Detail: err.Error()},
+			},
+		})
+		return
+	}
+
+	now := dbtime.Now()
+	webhook, err := api.Database.UpsertOrganizationNotificationWebhook(ctx, database.UpsertOrganizationNotificationWebhookParams{
+		OrganizationID: org.ID,
+		Method:         method,
+		Endpoint:       req.Endpoint,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update organization notification webhook.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	aReq.New = webhook
+	httpapi.Write(ctx, rw, http.StatusOK, convertOrganizationNotificationWebhook(webhook))
+}
+
+// @Summary Delete organization notification webhook
+// @ID delete-organization-notification-webhook
+// @Security CoderSessionToken
+// @Tags Notifications
+// @Param organization path string true "Organization ID" format(uuid)
+// @Param method path string true "Notification method" Enums(slack,teams)
+// @Success 204
+// @Router /organizations/{organization}/notifications/webhooks/{method} [delete]
+func (api *API) deleteOrganizationNotificationWebhook(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		org               = httpmw.OrganizationParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.OrganizationNotificationWebhook](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+			OrganizationID: org.ID,
+		})
+	)
+	defer commitAudit()
+
+	method, ok := parseOrganizationNotificationWebhookMethod(rw, r)
+	if !ok {
+		return
+	}
+
+	old, ok := api.organizationNotificationWebhook(rw, r, org.ID, method)
+	if !ok {
+		return
+	}
+	aReq.Old = old
+
+	err := api.Database.DeleteOrganizationNotificationWebhook(ctx, database.DeleteOrganizationNotificationWebhookParams{
+		OrganizationID: org.ID,
+		Method:         method,
+	})
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to delete organization notification webhook.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// organizationNotificationWebhook returns the organization's webhook for the method, or the zero value if there is
+// none, and writes an error response if it cannot be retrieved.
+func (api *API) organizationNotificationWebhook(rw http.ResponseWriter, r *http.Request, organizationID uuid.UUID, method database.NotificationMethod) (database.OrganizationNotificationWebhook, bool) {
+	ctx := r.Context()
+	webhooks, err := api.Database.GetOrganizationNotificationWebhooks(ctx, organizationID)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return database.OrganizationNotificationWebhook{}, false
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve organization notification webhooks.",
+			Detail:  err.Error(),
+		})
+		return database.OrganizationNotificationWebhook{}, false
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Method == method {
+			return webhook, true
+		}
+	}
+	return database.OrganizationNotificationWebhook{}, true
+}
+
+// parseOrganizationNotificationWebhookMethod reads the method path parameter, and writes an error response if it is
+// not a method which delivers to organization webhooks.
+func parseOrganizationNotificationWebhookMethod(rw http.ResponseWriter, r *http.Request) (database.NotificationMethod, bool) {
+	method := database.NotificationMethod(chi.URLParam(r, "method"))
+	if !slices.Contains(organizationNotificationWebhookMethods, method) {
+		httpapi.Write(r.Context(), rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("Invalid notification method %q.", method),
+			Detail:  fmt.Sprintf("Organization notification webhooks are supported by the methods %v.", organizationNotificationWebhookMethods),
+		})
+		return "", false
+	}
+	return method, true
+}
+
+func convertOrganizationNotificationWebhook(webhook database.OrganizationNotificationWebhook) codersdk.OrganizationNotificationWebhook {
+	return codersdk.OrganizationNotificationWebhook{
+		OrganizationID: webhook.OrganizationID,
+		Method:         string(webhook.Method),
+		Endpoint:       webhook.Endpoint,
+		CreatedAt:      webhook.CreatedAt,
+		UpdatedAt:      webhook.UpdatedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestOrganizationNotificationWebhooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateAndDelete", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		auditor := audit.NewMock()
+		client := coderdtest.New(t, &coderdtest.Options{Auditor: auditor})
+		owner := coderdtest.CreateFirstUser(t, client)
+		orgAdminClient, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID, rbac.ScopedRoleOrgAdmin(owner.OrganizationID))
+
+		// Given: an organization without webhooks.
+		webhooks, err := orgAdminClient.OrganizationNotificationWebhooks(ctx, owner.OrganizationID)
+		require.NoError(t, err)
+		require.Empty(t, webhooks)
+
+		// When: an organization admin configures Slack and Teams webhooks.
+		slack, err := orgAdminClient.UpdateOrganizationNotificationWebhook(ctx, owner.OrganizationID, "slack", codersdk.UpdateOrganizationNotificationWebhookRequest{
+			Endpoint: "https://hooks.slack.com/services/T000/B000/XXXX",
+		})
+		require.NoError(t, err)
+		require.Equal(t, owner.OrganizationID, slack.OrganizationID)
+		require.Equal(t, "slack", slack.Method)
+		_, err = orgAdminClient.UpdateOrganizationNotificationWebhook(ctx, owner.OrganizationID, "teams", codersdk.UpdateOrganizationNotificationWebhookRequest{
+			Endpoint: "https://example.webhook.office.com/workflows/1",
+		})
+		require.NoError(t, err)
+
+		// And: the Slack webhook is replaced.
+		slack, err = orgAdminClient.UpdateOrganizationNotificationWebhook(ctx, owner.OrganizationID, "slack", codersdk.UpdateOrganizationNotificationWebhookRequest{
+			Endpoint: "https://hooks.slack.com/services/T000/B000/YYYY",
+		})
+		require.NoError(t, err)
+
+		// Then: the organization has one webhook per method.
+		webhooks, err = orgAdminClient.OrganizationNotificationWebhooks(ctx, owner.OrganizationID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		require.Equal(t, "slack", webhooks[0].Method)
+		require.Equal(t, slack.Endpoint, webhooks[0].Endpoint)
+		require.Equal(t, "teams", webhooks[1].Method)
+
+		// And: the changes are audited.
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			OrganizationID: owner.OrganizationID,
+			ResourceType:   database.ResourceTypeOrganizationNotificationWebhook,
+			ResourceTarget: "slack",
+			Action:         database.AuditActionCreate,
+		}))
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			OrganizationID: owner.OrganizationID,
+			ResourceType:   database.ResourceTypeOrganizationNotificationWebhook,
+			ResourceTarget: "slack",
+			Action:         database.AuditActionWrite,
+		}))
+
+		// When: the Teams webhook is deleted.
+		err = orgAdminClient.DeleteOrganizationNotificationWebhook(ctx, owner.OrganizationID, "teams")
+		require.NoError(t, err)
+
+		// Then: only the Slack webhook remains.
+		webhooks, err = orgAdminClient.OrganizationNotificationWebhooks(ctx, owner.OrganizationID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, "slack", webhooks[0].Method)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			OrganizationID: owner.OrganizationID,
+			ResourceType:   database.ResourceTypeOrganizationNotificationWebhook,
+			ResourceTarget: "teams",
+			Action:         database.AuditActionDelete,
+		}))
+	})
+
+	t.Run("InvalidMethod", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+
+		// Webhooks of the generic webhook method are configured by the deployment, not by organizations.
+		_, err := client.UpdateOrganizationNotificationWebhook(ctx, owner.OrganizationID, "webhook", codersdk.UpdateOrganizationNotificationWebhookRequest{
+			Endpoint: "https://example.com/hooks",
+		})
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+
+		_, err := client.UpdateOrganizationNotificationWebhook(ctx, owner.OrganizationID, "slack", codersdk.UpdateOrganizationNotificationWebhookRequest{
+			Endpoint: "hooks.slack.com/services/T000/B000/XXXX",
+		})
+		requireSDKErrorStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("MemberForbidden", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		// Webhook endpoints embed their credentials, so members can neither read nor change them.
+		_, err := memberClient.OrganizationNotificationWebhooks(ctx, owner.OrganizationID)
+		requireSDKErrorStatus(t, err, http.StatusForbidden)
+		_, err = memberClient.UpdateOrganizationNotificationWebhook(ctx, owner.OrganizationID, "slack", codersdk.UpdateOrganizationNotificationWebhookRequest{
+			Endpoint: "https://hooks.slack.com/services/T000/B000/XXXX",
+		})
+		requireSDKErrorStatus(t, err, http.StatusForbidden)
+		err = memberClient.DeleteOrganizationNotificationWebhook(ctx, owner.OrganizationID, "slack")
+		requireSDKErrorStatus(t, err, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,63 @@
+package prebuilds
+
+import (
+	"math"
+	"time"
+)
+
+// demandBucketsPerWeek is the number of hourly time-of-week buckets claims are grouped in.
+const demandBucketsPerWeek = 7 * 24
+
+// DefaultAdaptiveLookbackWeeks is the number of weeks of claim history used to forecast claims
+// when a template has not configured adaptive prebuilds.
+const DefaultAdaptiveLookbackWeeks = 4
+
+// DemandForecast estimates how many prebuilt workspaces of a preset are claimed per hour.
+// Claims are grouped by hour of the week in the preset's scheduling timezone, so that daily and weekly
+// patterns such as a Monday-morning rush are forecasted from the same hour in previous weeks.
+type DemandForecast struct {
+	buckets [demandBucketsPerWeek]float64
+	loc     *time.Location
+}
+
+// NewDemandForecast builds a forecast from the claims made over the given number of weeks of history.
+// Each bucket holds the average number of claims made during that hour of the week.
+func NewDemandForecast(claims []time.Time, weeks int, loc *time.Location) DemandForecast {
+	if loc == nil {
+		loc = time.UTC
+	}
+	f := DemandForecast{loc: loc}
+	if weeks <= 0 {
+		return f
+	}
+
+	for _, claimedAt := range claims {
+		f.buckets[f.bucket(claimedAt)]++
+	}
+	for i := range f.buckets {
+		f.buckets[i] /= float64(weeks)
+	}
+	return f
+}
+
+func (f DemandForecast) bucket(at time.Time) int {
+	at = at.In(f.loc)
+	return int(at.Weekday())*24 + at.Hour()
+}
+
+// ClaimsPerHour returns the forecasted number of claims during the hour containing the given time.
+func (f DemandForecast) ClaimsPerHour(at time.Time) float64 {
+	return f.buckets[f.bucket(at)]
+}
+
+// DesiredInstances returns the number of prebuilt workspaces needed to absorb the forecasted claims,
+// bounded by minInstances and maxInstances.
+func (f DemandForecast) DesiredInstances(at time.Time, minInstances, maxInstances int32) int32 {
+	// Prebuilds take a while to provision, so the pool is sized for the busier of the current and the
+	// next hour. This fills the pool ahead of a rush rather than once it has already started.
+	claims := max(f.ClaimsPerHour(at), f.ClaimsPerHour(at.Add(time.Hour)))
+
+	// #nosec G115 - Safe conversion as the number of claims per hour is far below the int32 range
+	desired := int32(math.Ceil(claims))
+	return min(max(desired, minInstances), maxInstances)
+}
@@ -492,6 +492,10 @@ func (c *DevStoreReconciler) SnapshotState(ctx context.Context, store database.S
 			allPendingPrebuilds,
 			presetsBackoff,
 			hardLimitedPresets,
+			nil, // Adaptive prebuilds are not supported in development mode.
+			nil,
+			nil, // Readiness checks are not supported in development mode.
+			nil,
 			c.clock,
 			c.logger,
 		)
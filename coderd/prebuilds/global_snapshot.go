@@ -21,6 +21,10 @@ type GlobalSnapshot struct {
 	PendingPrebuilds      []database.CountPendingNonActivePrebuildsRow
 	Backoffs              []database.GetPresetsBackoffRow
 	HardLimitedPresetsMap map[uuid.UUID]database.GetPresetsAtFailureLimitRow
+	AdaptivePrebuildsMap  map[uuid.UUID]database.TemplateAdaptivePrebuild
+	ClaimHistory          []database.GetPrebuildClaimHistoryRow
+	ReadinessChecks       []database.TemplatePresetReadinessCheck
+	PrebuildReadinessMap  map[uuid.UUID]database.WorkspacePrebuildReadiness
 	clock                 quartz.Clock
 	logger                slog.Logger
 }
@@ -33,6 +37,10 @@ func NewGlobalSnapshot(
 	pendingPrebuilds []database.CountPendingNonActivePrebuildsRow,
 	backoffs []database.GetPresetsBackoffRow,
 	hardLimitedPresets []database.GetPresetsAtFailureLimitRow,
+	adaptivePrebuilds []database.TemplateAdaptivePrebuild,
+	claimHistory []database.GetPrebuildClaimHistoryRow,
+	readinessChecks []database.TemplatePresetReadinessCheck,
+	prebuildReadiness []database.WorkspacePrebuildReadiness,
 	clock quartz.Clock,
 	logger slog.Logger,
 ) GlobalSnapshot {
@@ -41,6 +49,16 @@ func NewGlobalSnapshot(
 		hardLimitedPresetsMap[preset.PresetID] = preset
 	}
 
+	adaptivePrebuildsMap := make(map[uuid.UUID]database.TemplateAdaptivePrebuild, len(adaptivePrebuilds))
+	for _, adaptive := range adaptivePrebuilds {
+		adaptivePrebuildsMap[adaptive.TemplateID] = adaptive
+	}
+
+	prebuildReadinessMap := make(map[uuid.UUID]database.WorkspacePrebuildReadiness, len(prebuildReadiness))
+	for _, readiness := range prebuildReadiness {
+		prebuildReadinessMap[readiness.WorkspaceID] = readiness
+	}
+
 	return GlobalSnapshot{
 		Presets:               presets,
 		PrebuildSchedules:     prebuildSchedules,
@@ -49,6 +67,10 @@ func NewGlobalSnapshot(
 		PendingPrebuilds:      pendingPrebuilds,
 		Backoffs:              backoffs,
 		HardLimitedPresetsMap: hardLimitedPresetsMap,
+		AdaptivePrebuildsMap:  adaptivePrebuildsMap,
+		ClaimHistory:          claimHistory,
+		ReadinessChecks:       readinessChecks,
+		PrebuildReadinessMap:  prebuildReadinessMap,
 		clock:                 clock,
 		logger:                logger,
 	}
@@ -77,6 +99,28 @@ func (s GlobalSnapshot) FilterByPreset(presetID uuid.UUID) (*PresetSnapshot, err
 	// Separate running workspaces into non-expired and expired based on the preset's TTL
 	nonExpired, expired := filterExpiredWorkspaces(preset, running)
 
+	// Separate non-expired workspaces that failed the preset's readiness checks, so they are replaced
+	readinessChecks := s.ReadinessChecksFor(preset.TemplateID, preset.Name)
+	readiness := make(map[uuid.UUID]database.WorkspacePrebuildReadiness)
+	var unhealthy []database.GetRunningPrebuiltWorkspacesRow
+	if readinessChecks != nil {
+		for _, prebuild := range running {
+			if result, ok := s.PrebuildReadinessMap[prebuild.ID]; ok {
+				readiness[prebuild.ID] = result
+			}
+		}
+
+		healthy := make([]database.GetRunningPrebuiltWorkspacesRow, 0, len(nonExpired))
+		for _, prebuild := range nonExpired {
+			if readiness[prebuild.ID].Status == database.PrebuildReadinessStatusFailed {
+				unhealthy = append(unhealthy, prebuild)
+			} else {
+				healthy = append(healthy, prebuild)
+			}
+		}
+		nonExpired = healthy
+	}
+
 	// Includes in-progress prebuilds only for active template versions.
 	// In-progress prebuilds correspond to workspace statuses: 'pending', 'starting', 'stopping', and 'deleting'
 	inProgress := slice.Filter(s.PrebuildsInProgress, func(prebuild database.CountInProgressPrebuildsRow) bool {
@@ -101,15 +145,42 @@ func (s GlobalSnapshot) FilterByPreset(presetID uuid.UUID) (*PresetSnapshot, err
 
 	_, isHardLimited := s.HardLimitedPresetsMap[preset.ID]
 
+	// Claims are matched by preset name, since each template version has its own presets
+	// but claim history should carry over to new versions of the template.
+	var (
+		adaptivePtr *database.TemplateAdaptivePrebuild
+		claims      []time.Time
+	)
+	lookbackWeeks := DefaultAdaptiveLookbackWeeks
+	if adaptive, ok := s.AdaptivePrebuildsMap[preset.TemplateID]; ok {
+		adaptivePtr = &adaptive
+		lookbackWeeks = int(adaptive.LookbackWeeks)
+	}
+	claimsSince := s.clock.Now().Truncate(time.Hour).Add(-time.Duration(lookbackWeeks) * 7 * 24 * time.Hour)
+	for _, claim := range s.ClaimHistory {
+		if claim.TemplateID != preset.TemplateID || claim.PresetName != preset.Name {
+			continue
+		}
+		if claim.ClaimedAt.Before(claimsSince) {
+			continue
+		}
+		claims = append(claims, claim.ClaimedAt)
+	}
+
 	presetSnapshot := NewPresetSnapshot(
 		preset,
 		prebuildSchedules,
 		nonExpired,
 		expired,
+		unhealthy,
 		inProgress,
 		pendingCount,
 		backoffPtr,
 		isHardLimited,
+		adaptivePtr,
+		claims,
+		readinessChecks,
+		readiness,
 		s.clock,
 		s.logger,
 	)
@@ -123,6 +194,18 @@ func (s GlobalSnapshot) IsHardLimited(presetID uuid.UUID) bool {
 	return isHardLimited
 }
 
+// ReadinessChecksFor returns the readiness checks of the preset with the given name in the given template,
+// or nil if the preset has none. Presets are matched by name, so that checks apply to all template versions.
+func (s GlobalSnapshot) ReadinessChecksFor(templateID uuid.UUID, presetName string) *database.TemplatePresetReadinessCheck {
+	checks, found := slice.Find(s.ReadinessChecks, func(checks database.TemplatePresetReadinessCheck) bool {
+		return checks.TemplateID == templateID && checks.PresetName == presetName
+	})
+	if !found {
+		return nil
+	}
+	return &checks
+}
+
 // filterExpiredWorkspaces splits running workspaces into expired and non-expired
 // based on the preset's TTL and last_invalidated_at timestamp.
 // A prebuild is considered expired if:
@@ -41,17 +41,27 @@ const (
 // including running prebuilds, in-progress builds, and backoff information.
 // - Running: prebuilds running and non-expired
 // - Expired: prebuilds running and expired due to the preset's TTL
+// - Unhealthy: prebuilds running, non-expired and failed the preset's readiness checks
 // - InProgress: prebuilds currently in progress
 // - Backoff: holds failure info to decide if prebuild creation should be backed off
+// - Adaptive: demand-driven sizing settings of the preset's template, if enabled
+// - Claims: when prebuilds of the preset were claimed, within the adaptive lookback period
+// - ReadinessChecks: checks prebuilds must pass before they can be claimed, if configured
+// - Readiness: readiness check results of the running prebuilds, by workspace ID
 type PresetSnapshot struct {
 	Preset            database.GetTemplatePresetsWithPrebuildsRow
 	PrebuildSchedules []database.TemplateVersionPresetPrebuildSchedule
 	Running           []database.GetRunningPrebuiltWorkspacesRow
 	Expired           []database.GetRunningPrebuiltWorkspacesRow
+	Unhealthy         []database.GetRunningPrebuiltWorkspacesRow
 	InProgress        []database.CountInProgressPrebuildsRow
 	PendingCount      int
 	Backoff           *database.GetPresetsBackoffRow
 	IsHardLimited     bool
+	Adaptive          *database.TemplateAdaptivePrebuild
+	Claims            []time.Time
+	ReadinessChecks   *database.TemplatePresetReadinessCheck
+	Readiness         map[uuid.UUID]database.WorkspacePrebuildReadiness
 	clock             quartz.Clock
 	logger            slog.Logger
 }
@@ -61,10 +71,15 @@ func NewPresetSnapshot(
 	prebuildSchedules []database.TemplateVersionPresetPrebuildSchedule,
 	running []database.GetRunningPrebuiltWorkspacesRow,
 	expired []database.GetRunningPrebuiltWorkspacesRow,
+	unhealthy []database.GetRunningPrebuiltWorkspacesRow,
 	inProgress []database.CountInProgressPrebuildsRow,
 	pendingCount int,
 	backoff *database.GetPresetsBackoffRow,
 	isHardLimited bool,
+	adaptive *database.TemplateAdaptivePrebuild,
+	claims []time.Time,
+	readinessChecks *database.TemplatePresetReadinessCheck,
+	readiness map[uuid.UUID]database.WorkspacePrebuildReadiness,
 	clock quartz.Clock,
 	logger slog.Logger,
 ) PresetSnapshot {
@@ -73,10 +88,15 @@ func NewPresetSnapshot(
 		PrebuildSchedules: prebuildSchedules,
 		Running:           running,
 		Expired:           expired,
+		Unhealthy:         unhealthy,
 		InProgress:        inProgress,
 		PendingCount:      pendingCount,
 		Backoff:           backoff,
 		IsHardLimited:     isHardLimited,
+		Adaptive:          adaptive,
+		Claims:            claims,
+		ReadinessChecks:   readinessChecks,
+		Readiness:         readiness,
 		clock:             clock,
 		logger:            logger,
 	}
@@ -106,6 +126,11 @@ func (p PresetSnapshot) CanSkipReconciliation() bool {
 		return false
 	}
 
+	// Inactive presets with unhealthy prebuilds means there are unhealthy prebuilds to delete.
+	if len(p.Unhealthy) > 0 {
+		return false
+	}
+
 	// Inactive presets with pending jobs means there are pending jobs to cancel.
 	if p.PendingCount > 0 {
 		return false
@@ -120,6 +145,8 @@ func (p PresetSnapshot) CanSkipReconciliation() bool {
 	// - PrebuildSchedules: Only affects desired instance calculation.
 	// - InProgress: Only populated for active template versions.
 	// - IsHardLimited: Only populated for active template versions.
+	// - Adaptive, Claims: Only affect desired instance calculation.
+	// - ReadinessChecks, Readiness: Only affect eligibility, which is calculated for active presets.
 
 	// Inactive preset with nothing to clean up: safe to skip.
 	return true
@@ -132,12 +159,17 @@ func (p PresetSnapshot) CanSkipReconciliation() bool {
 // For example, it calculates how many prebuilds are expired, eligible,
 // how many are extraneous, and how many are in various transition states.
 type ReconciliationState struct {
-	Actual     int32 // Number of currently running prebuilds, i.e., non-expired, expired and extraneous prebuilds
+	Actual     int32 // Number of currently running prebuilds, i.e., non-expired, expired, unhealthy and extraneous prebuilds
 	Expired    int32 // Number of currently running prebuilds that exceeded their allowed time-to-live (TTL)
+	Unhealthy  int32 // Number of currently running prebuilds that failed their preset's readiness checks
 	Desired    int32 // Number of prebuilds desired as defined in the preset
 	Eligible   int32 // Number of prebuilds that are ready to be claimed
 	Extraneous int32 // Number of extra running prebuilds beyond the desired count
 
+	// Claim rates of presets with adaptive prebuilds, used to derive Desired
+	ForecastClaims float64 // Number of claims forecasted for the current hour
+	RecentClaims   int32   // Number of prebuilds claimed during the last hour
+
 	// Counts of prebuilds in various transition states
 	Starting int32
 	Stopping int32
@@ -175,10 +207,66 @@ func MatchesCron(cronExpression string, at time.Time) (bool, error) {
 	return sched.IsWithinRange(at), nil
 }
 
+// IsAdaptive returns true if the desired instances of the preset are forecasted from its claim history.
+func (p PresetSnapshot) IsAdaptive() bool {
+	return p.Adaptive != nil && p.Adaptive.Enabled
+}
+
+// Location returns the timezone the preset's schedules and claim forecast are evaluated in.
+// It falls back to UTC if the preset has no valid scheduling timezone.
+func (p PresetSnapshot) Location() *time.Location {
+	if p.Preset.SchedulingTimezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.Preset.SchedulingTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Forecast returns the claim forecast of the preset, built from the claims within the adaptive
+// lookback period. Claims made during the current hour are left out, so that every hour of the
+// week is sampled over the same number of weeks.
+func (p PresetSnapshot) Forecast() DemandForecast {
+	weeks := DefaultAdaptiveLookbackWeeks
+	if p.Adaptive != nil {
+		weeks = int(p.Adaptive.LookbackWeeks)
+	}
+	currentHour := p.clock.Now().Truncate(time.Hour)
+	claims := slices.DeleteFunc(slices.Clone(p.Claims), func(claimedAt time.Time) bool {
+		return !claimedAt.Before(currentHour)
+	})
+	return NewDemandForecast(claims, weeks, p.Location())
+}
+
+// CountClaims returns the number of prebuilds of the preset that were claimed within [from, to).
+func (p PresetSnapshot) CountClaims(from, to time.Time) int {
+	var count int
+	for _, claimedAt := range p.Claims {
+		if !claimedAt.Before(from) && claimedAt.Before(to) {
+			count++
+		}
+	}
+	return count
+}
+
 // CalculateDesiredInstances returns the number of desired instances based on the provided time.
-// If the time matches any defined prebuild schedule, the corresponding number of instances is returned.
+// If the preset's template has adaptive prebuilds enabled, the number of instances is forecasted from the
+// preset's claim history and bounded by the template's minimum and maximum; prebuild schedules are ignored.
+// Otherwise, if the time matches any defined prebuild schedule, the corresponding number of instances is returned.
 // Otherwise, it falls back to the default number of instances specified in the prebuild configuration.
 func (p PresetSnapshot) CalculateDesiredInstances(at time.Time) int32 {
+	if p.IsAdaptive() {
+		desired := p.Forecast().DesiredInstances(at, p.Adaptive.MinInstances, p.Adaptive.MaxInstances)
+		p.logger.Debug(context.Background(), "forecasted desired instances from claim history",
+			slog.F("preset_id", p.Preset.ID),
+			slog.F("current_time", at.String()),
+			slog.F("desired_instances", desired),
+		)
+		return desired
+	}
+
 	if len(p.PrebuildSchedules) == 0 {
 		// If no schedules are defined, fall back to the default desired instance count
 		return p.Preset.DesiredInstances.Int32
@@ -251,11 +339,13 @@ func (p PresetSnapshot) CalculateDesiredInstances(at time.Time) int32 {
 }
 
 // CalculateState computes the current state of prebuilds for a preset, including:
-// - Actual: Number of currently running prebuilds, i.e., non-expired and expired prebuilds
+// - Actual: Number of currently running prebuilds, i.e., non-expired, expired and unhealthy prebuilds
 // - Expired: Number of currently running expired prebuilds
+// - Unhealthy: Number of currently running prebuilds that failed their preset's readiness checks
 // - Desired: Number of prebuilds desired as defined in the preset
 // - Eligible: Number of prebuilds that are ready to be claimed
 // - Extraneous: Number of extra running prebuilds beyond the desired count
+// - ForecastClaims/RecentClaims: Forecasted and actual claim rates, for presets with adaptive prebuilds
 // - Starting/Stopping/Deleting: Counts of prebuilds in various transition states
 //
 // The function takes into account whether the preset is active (using the active template version)
@@ -267,20 +357,34 @@ func (p PresetSnapshot) CalculateState() *ReconciliationState {
 		actual     int32
 		desired    int32
 		expired    int32
+		unhealthy  int32
 		eligible   int32
 		extraneous int32
+
+		forecastClaims float64
+		recentClaims   int32
 	)
 
-	// #nosec G115 - Safe conversion as p.Running and p.Expired slice length is expected to be within int32 range
-	actual = int32(len(p.Running) + len(p.Expired))
+	// #nosec G115 - Safe conversion as p.Running, p.Expired and p.Unhealthy slice length is expected to be within int32 range
+	actual = int32(len(p.Running) + len(p.Expired) + len(p.Unhealthy))
 
 	// #nosec G115 - Safe conversion as p.Expired slice length is expected to be within int32 range
 	expired = int32(len(p.Expired))
 
+	// #nosec G115 - Safe conversion as p.Unhealthy slice length is expected to be within int32 range
+	unhealthy = int32(len(p.Unhealthy))
+
 	if p.isActive() {
-		desired = p.CalculateDesiredInstances(p.clock.Now())
+		now := p.clock.Now()
+		desired = p.CalculateDesiredInstances(now)
 		eligible = p.countEligible()
-		extraneous = max(actual-expired-desired, 0)
+		extraneous = max(actual-expired-unhealthy-desired, 0)
+
+		if p.IsAdaptive() {
+			forecastClaims = p.Forecast().ClaimsPerHour(now)
+			// #nosec G115 - Safe conversion as p.Claims slice length is expected to be within int32 range
+			recentClaims = int32(p.CountClaims(now.Add(-time.Hour), now))
+		}
 	}
 
 	starting, stopping, deleting := p.countInProgress()
@@ -288,10 +392,14 @@ func (p PresetSnapshot) CalculateState() *ReconciliationState {
 	return &ReconciliationState{
 		Actual:     actual,
 		Expired:    expired,
+		Unhealthy:  unhealthy,
 		Desired:    desired,
 		Eligible:   eligible,
 		Extraneous: extraneous,
 
+		ForecastClaims: forecastClaims,
+		RecentClaims:   recentClaims,
+
 		Starting: starting,
 		Stopping: stopping,
 		Deleting: deleting,
@@ -343,9 +451,10 @@ func (p PresetSnapshot) isActive() bool {
 //
 // The reconciliation follows this order:
 //  1. Delete expired prebuilds: These are no longer valid and must be removed first.
-//  2. Delete extraneous prebuilds: After expired ones are removed, if the number of running non-expired prebuilds
-//     still exceeds the desired count, the oldest prebuilds are deleted to reduce excess.
-//  3. Create missing prebuilds: If the number of non-expired, non-starting prebuilds is still below the desired count,
+//  2. Delete unhealthy prebuilds: These failed their preset's readiness checks and can never be claimed.
+//  3. Delete extraneous prebuilds: After expired and unhealthy ones are removed, if the number of running healthy
+//     prebuilds still exceeds the desired count, the oldest prebuilds are deleted to reduce excess.
+//  4. Create missing prebuilds: If the number of healthy, non-starting prebuilds is still below the desired count,
 //     create the necessary number of prebuilds to reach the target.
 //
 // The function returns a list of actions to be executed to achieve the desired state.
@@ -365,6 +474,19 @@ func (p PresetSnapshot) handleActiveTemplateVersion() (actions []*Reconciliation
 			})
 	}
 
+	// If we have unhealthy prebuilds, delete them so that they are replaced
+	if state.Unhealthy > 0 {
+		var deleteIDs []uuid.UUID
+		for _, unhealthy := range p.Unhealthy {
+			deleteIDs = append(deleteIDs, unhealthy.ID)
+		}
+		actions = append(actions,
+			&ReconciliationActions{
+				ActionType: ActionTypeDelete,
+				DeleteIDs:  deleteIDs,
+			})
+	}
+
 	// If we still have more prebuilds than desired, delete the oldest ones
 	if state.Extraneous > 0 {
 		actions = append(actions,
@@ -374,8 +496,8 @@ func (p PresetSnapshot) handleActiveTemplateVersion() (actions []*Reconciliation
 			})
 	}
 
-	// Number of running prebuilds excluding the recently deleted Expired
-	runningValid := state.Actual - state.Expired
+	// Number of running prebuilds excluding the recently deleted Expired and Unhealthy
+	runningValid := state.Actual - state.Expired - state.Unhealthy
 
 	// Calculate how many new prebuilds we need to create
 	// We subtract starting prebuilds since they're already being created
@@ -395,7 +517,7 @@ func (p PresetSnapshot) handleActiveTemplateVersion() (actions []*Reconciliation
 //  1. If the preset has pending prebuild jobs from an inactive template version, create a cancel reconciliation action.
 //     This cancels all pending prebuild jobs for this preset's template version.
 //  2. If the preset has prebuilt workspaces currently running from an inactive template version,
-//     create a delete reconciliation action to remove all running prebuilt workspaces.
+//     create a delete reconciliation action to remove all running prebuilt workspaces, including unhealthy ones.
 func (p PresetSnapshot) handleInactiveTemplateVersion() (actions []*ReconciliationActions, err error) {
 	// Cancel pending initial prebuild jobs from inactive version
 	if p.PendingCount > 0 {
@@ -407,6 +529,9 @@ func (p PresetSnapshot) handleInactiveTemplateVersion() (actions []*Reconciliati
 
 	// Delete prebuilds running in inactive version
 	deleteIDs := p.getOldestPrebuildIDs(len(p.Running))
+	for _, unhealthy := range p.Unhealthy {
+		deleteIDs = append(deleteIDs, unhealthy.ID)
+	}
 	if len(deleteIDs) > 0 {
 		actions = append(actions,
 			&ReconciliationActions{
@@ -438,17 +563,30 @@ func (p PresetSnapshot) needsBackoffPeriod(clock quartz.Clock, backoffInterval t
 }
 
 // countEligible returns the number of prebuilds that are ready to be claimed.
-// A prebuild is eligible if it's running and its agents are in ready state.
 func (p PresetSnapshot) countEligible() int32 {
 	var count int32
 	for _, prebuild := range p.Running {
-		if prebuild.Ready {
+		if p.IsEligible(prebuild) {
 			count++
 		}
 	}
 	return count
 }
 
+// IsEligible returns true if the prebuild is ready to be claimed.
+// A prebuild is eligible if it's running, its agents are in ready state, and it passed
+// the preset's readiness checks, if any, for its latest build.
+func (p PresetSnapshot) IsEligible(prebuild database.GetRunningPrebuiltWorkspacesRow) bool {
+	if !prebuild.Ready {
+		return false
+	}
+	if p.ReadinessChecks == nil {
+		return true
+	}
+	readiness, ok := p.Readiness[prebuild.ID]
+	return ok && readiness.Status == database.PrebuildReadinessStatusPassed
+}
+
 // countInProgress returns counts of prebuilds in transition states (starting, stopping, deleting).
 // These counts are tracked at the template level, so all presets sharing the same template see the same values.
 func (p PresetSnapshot) countInProgress() (starting int32, stopping int32, deleting int32) {
@@ -457,7 +595,7 @@ func (p PresetSnapshot) countInProgress() (starting int32, stopping int32, delet
 		switch progress.Transition {
 		case database.WorkspaceTransitionStart:
 			starting += num
-		case database.WorkspaceTransitionStop:
+		case database.WorkspaceTransitionStop, database.WorkspaceTransitionHibernate:
 			stopping += num
 		case database.WorkspaceTransitionDelete:
 			deleting += num
@@ -84,7 +84,7 @@ func TestNoPrebuilds(t *testing.T) {
 		preset(true, 0, current),
 	}
 
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 	ps, err := snapshot.FilterByPreset(current.presetID)
 	require.NoError(t, err)
 
@@ -106,7 +106,7 @@ func TestNetNew(t *testing.T) {
 		preset(true, 1, current),
 	}
 
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 	ps, err := snapshot.FilterByPreset(current.presetID)
 	require.NoError(t, err)
 
@@ -148,7 +148,7 @@ func TestOutdatedPrebuilds(t *testing.T) {
 	var inProgress []database.CountInProgressPrebuildsRow
 
 	// WHEN: calculating the outdated preset's state.
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, nil, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
 	ps, err := snapshot.FilterByPreset(outdated.presetID)
 	require.NoError(t, err)
 
@@ -214,7 +214,7 @@ func TestDeleteOutdatedPrebuilds(t *testing.T) {
 	}
 
 	// WHEN: calculating the outdated preset's state.
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, nil, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
 	ps, err := snapshot.FilterByPreset(outdated.presetID)
 	require.NoError(t, err)
 
@@ -262,7 +262,7 @@ func TestCancelPendingPrebuilds(t *testing.T) {
 		}}
 
 		// When: calculating the current preset's state
-		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, pending, nil, nil, clock, testutil.Logger(t))
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, pending, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 		ps, err := snapshot.FilterByPreset(current.presetID)
 		require.NoError(t, err)
 
@@ -292,7 +292,7 @@ func TestCancelPendingPrebuilds(t *testing.T) {
 		}}
 
 		// When: calculating the current preset's state
-		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, pending, nil, nil, clock, testutil.Logger(t))
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, pending, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 		ps, err := snapshot.FilterByPreset(current.presetID)
 		require.NoError(t, err)
 
@@ -526,7 +526,7 @@ func TestInProgressActions(t *testing.T) {
 			}
 
 			// WHEN: calculating the current preset's state.
-			snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
+			snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, nil, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
 			ps, err := snapshot.FilterByPreset(current.presetID)
 			require.NoError(t, err)
 
@@ -569,7 +569,7 @@ func TestExtraneous(t *testing.T) {
 	var inProgress []database.CountInProgressPrebuildsRow
 
 	// WHEN: calculating the current preset's state.
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, nil, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
 	ps, err := snapshot.FilterByPreset(current.presetID)
 	require.NoError(t, err)
 
@@ -819,7 +819,7 @@ func TestExpiredPrebuilds(t *testing.T) {
 			}
 
 			// WHEN: calculating the current preset's state.
-			snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, nil, nil, clock, testutil.Logger(t))
+			snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 			ps, err := snapshot.FilterByPreset(current.presetID)
 			require.NoError(t, err)
 
@@ -855,7 +855,7 @@ func TestDeprecated(t *testing.T) {
 	var inProgress []database.CountInProgressPrebuildsRow
 
 	// WHEN: calculating the current preset's state.
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, nil, nil, nil, nil, nil, nil, quartz.NewMock(t), testutil.Logger(t))
 	ps, err := snapshot.FilterByPreset(current.presetID)
 	require.NoError(t, err)
 
@@ -908,7 +908,7 @@ func TestLatestBuildFailed(t *testing.T) {
 	}
 
 	// WHEN: calculating the current preset's state.
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, backoffs, nil, clock, testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, inProgress, nil, backoffs, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 	psCurrent, err := snapshot.FilterByPreset(current.presetID)
 	require.NoError(t, err)
 
@@ -1001,7 +1001,7 @@ func TestMultiplePresetsPerTemplateVersion(t *testing.T) {
 		},
 	}
 
-	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, inProgress, nil, nil, nil, clock, testutil.Logger(t))
+	snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, inProgress, nil, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 
 	// Nothing has to be created for preset 1.
 	{
@@ -1121,7 +1121,7 @@ func TestPrebuildScheduling(t *testing.T) {
 				schedule(presets[1].ID, "* 14-16 * * 1-5", 5),
 			}
 
-			snapshot := prebuilds.NewGlobalSnapshot(presets, schedules, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
+			snapshot := prebuilds.NewGlobalSnapshot(presets, schedules, nil, nil, nil, nil, nil, nil, nil, nil, nil, clock, testutil.Logger(t))
 
 			// Check 1st preset.
 			{
@@ -1229,9 +1229,14 @@ func TestCalculateDesiredInstances(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			0,
 			nil,
 			false,
+			nil,
+			nil,
+			nil,
+			nil,
 			quartz.NewMock(t),
 			testutil.Logger(t),
 		)
@@ -1527,6 +1532,314 @@ func TestCalculateDesiredInstances(t *testing.T) {
 	}
 }
 
+func TestAdaptiveDesiredInstances(t *testing.T) {
+	t.Parallel()
+
+	current := opts[optionSet0]
+	// Monday.
+	now := mustParseTime(t, time.RFC1123, "Mon, 02 Jun 2025 08:30:00 UTC")
+
+	// claimsEachWeek returns n claims during the hour starting at the given time, in each of the given number
+	// of weeks before it.
+	claimsEachWeek := func(presetName string, hourStart time.Time, weeks, n int) []database.GetPrebuildClaimHistoryRow {
+		var rows []database.GetPrebuildClaimHistoryRow
+		for week := 1; week <= weeks; week++ {
+			for i := range n {
+				rows = append(rows, database.GetPrebuildClaimHistoryRow{
+					TemplateID: current.templateID,
+					PresetName: presetName,
+					ClaimedAt:  hourStart.AddDate(0, 0, -7*week).Add(time.Duration(i) * time.Minute),
+				})
+			}
+		}
+		return rows
+	}
+
+	var history []database.GetPrebuildClaimHistoryRow
+	// A steady trickle before the Monday-morning rush, then the rush itself.
+	history = append(history, claimsEachWeek(current.presetName, now.Truncate(time.Hour), 4, 2)...)
+	history = append(history, claimsEachWeek(current.presetName, now.Truncate(time.Hour).Add(time.Hour), 4, 6)...)
+	// A claim during the current hour, which is only used for the recent claims.
+	history = append(history, database.GetPrebuildClaimHistoryRow{
+		TemplateID: current.templateID,
+		PresetName: current.presetName,
+		ClaimedAt:  now.Add(-10 * time.Minute),
+	})
+	// A single claim on Tuesday afternoon over the whole lookback period.
+	history = append(history, claimsEachWeek(current.presetName, now.Truncate(time.Hour).Add(30*time.Hour), 1, 1)...)
+	// Claims of another preset must not affect this preset.
+	history = append(history, claimsEachWeek("other-preset", now.Truncate(time.Hour).Add(48*time.Hour), 4, 10)...)
+	// Wednesday night claims that are older than the lookback period.
+	for _, row := range claimsEachWeek(current.presetName, now.Truncate(time.Hour).Add(67*time.Hour), 6, 10) {
+		if row.ClaimedAt.Before(now.AddDate(0, 0, -7*4)) {
+			history = append(history, row)
+		}
+	}
+
+	adaptive := func(enabled bool, minInstances, maxInstances int32) database.TemplateAdaptivePrebuild {
+		return database.TemplateAdaptivePrebuild{
+			TemplateID:    current.templateID,
+			Enabled:       enabled,
+			MinInstances:  minInstances,
+			MaxInstances:  maxInstances,
+			LookbackWeeks: 4,
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		adaptive database.TemplateAdaptivePrebuild
+		at       time.Time
+		expected int32
+	}{
+		{
+			name:     "pool is filled ahead of the rush",
+			adaptive: adaptive(true, 0, 10),
+			at:       now,
+			expected: 6,
+		},
+		{
+			name:     "bounded by the maximum",
+			adaptive: adaptive(true, 0, 4),
+			at:       now,
+			expected: 4,
+		},
+		{
+			name:     "trickle after the rush",
+			adaptive: adaptive(true, 0, 10),
+			at:       now.Add(2 * time.Hour),
+			expected: 0,
+		},
+		{
+			name:     "partial claims round up",
+			adaptive: adaptive(true, 0, 10),
+			at:       now.Add(30 * time.Hour),
+			expected: 1,
+		},
+		{
+			name:     "bounded by the minimum",
+			adaptive: adaptive(true, 2, 10),
+			at:       now.Add(48 * time.Hour),
+			expected: 2,
+		},
+		{
+			name:     "claims before the lookback period are ignored",
+			adaptive: adaptive(true, 0, 10),
+			at:       now.Add(67 * time.Hour),
+			expected: 0,
+		},
+		{
+			name:     "disabled uses the preset configuration",
+			adaptive: adaptive(false, 0, 10),
+			at:       now,
+			expected: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			clock := quartz.NewMock(t)
+			clock.Set(tc.at)
+
+			presets := []database.GetTemplatePresetsWithPrebuildsRow{
+				preset(true, 3, current),
+			}
+			snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, nil, nil, nil, []database.TemplateAdaptivePrebuild{tc.adaptive}, history, nil, nil, clock, testutil.Logger(t))
+			ps, err := snapshot.FilterByPreset(current.presetID)
+			require.NoError(t, err)
+
+			state := ps.CalculateState()
+			require.Equal(t, tc.expected, state.Desired)
+
+			actions, err := ps.CalculateActions(backoffInterval)
+			require.NoError(t, err)
+			var expectedActions []*prebuilds.ReconciliationActions
+			if tc.expected > 0 {
+				expectedActions = []*prebuilds.ReconciliationActions{
+					{ActionType: prebuilds.ActionTypeCreate, Create: tc.expected},
+				}
+			}
+			validateActions(t, expectedActions, actions)
+		})
+	}
+
+	t.Run("ForecastAndClaims", func(t *testing.T) {
+		t.Parallel()
+
+		clock := quartz.NewMock(t)
+		clock.Set(now)
+
+		presets := []database.GetTemplatePresetsWithPrebuildsRow{
+			preset(true, 3, current),
+		}
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, nil, nil, nil, nil, nil, []database.TemplateAdaptivePrebuild{adaptive(true, 0, 10)}, history, nil, nil, clock, testutil.Logger(t))
+		ps, err := snapshot.FilterByPreset(current.presetID)
+		require.NoError(t, err)
+
+		forecast := ps.Forecast()
+		require.InDelta(t, 2, forecast.ClaimsPerHour(now), 0.001)
+		require.InDelta(t, 6, forecast.ClaimsPerHour(now.Add(time.Hour)), 0.001)
+		require.InDelta(t, 0.25, forecast.ClaimsPerHour(now.Add(30*time.Hour)), 0.001)
+
+		state := ps.CalculateState()
+		require.InDelta(t, 2, state.ForecastClaims, 0.001)
+		require.EqualValues(t, 1, state.RecentClaims)
+
+		// Claims of the same hour last week.
+		lastWeek := now.Truncate(time.Hour).AddDate(0, 0, -7)
+		require.Equal(t, 2, ps.CountClaims(lastWeek, lastWeek.Add(time.Hour)))
+		require.Equal(t, 8, ps.CountClaims(lastWeek, lastWeek.Add(2*time.Hour)))
+	})
+}
+
+// Prebuilds of presets with readiness checks are only eligible once they passed them, and are replaced if they failed.
+func TestReadinessChecks(t *testing.T) {
+	t.Parallel()
+	current := opts[optionSet0]
+	clock := quartz.NewMock(t)
+
+	passedID, pendingID, failedID := uuid.New(), uuid.New(), uuid.New()
+	running := []database.GetRunningPrebuiltWorkspacesRow{
+		prebuiltWorkspace(current, clock, func(row database.GetRunningPrebuiltWorkspacesRow) database.GetRunningPrebuiltWorkspacesRow {
+			row.ID = passedID
+			return row
+		}),
+		prebuiltWorkspace(current, clock, func(row database.GetRunningPrebuiltWorkspacesRow) database.GetRunningPrebuiltWorkspacesRow {
+			row.ID = pendingID
+			return row
+		}),
+		prebuiltWorkspace(current, clock, func(row database.GetRunningPrebuiltWorkspacesRow) database.GetRunningPrebuiltWorkspacesRow {
+			row.ID = failedID
+			return row
+		}),
+	}
+	readiness := []database.WorkspacePrebuildReadiness{
+		{WorkspaceID: passedID, Status: database.PrebuildReadinessStatusPassed},
+		{WorkspaceID: pendingID, Status: database.PrebuildReadinessStatusPending},
+		{WorkspaceID: failedID, Status: database.PrebuildReadinessStatusFailed, FailureReasons: []string{`app "code-server" is unhealthy after 10m0s`}},
+	}
+	checks := []database.TemplatePresetReadinessCheck{{
+		TemplateID: current.templateID,
+		PresetName: current.presetName,
+		Checks:     database.PrebuildReadinessChecks{{Type: database.PrebuildReadinessCheckTypeApp, App: "code-server"}},
+		Timeout:    int64(10 * time.Minute),
+	}}
+
+	t.Run("ReplacesUnhealthy", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a preset with 3 desired prebuilds, of which one passed, one is pending and one failed its readiness checks.
+		presets := []database.GetTemplatePresetsWithPrebuildsRow{preset(true, 3, current)}
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, nil, nil, nil, nil, checks, readiness, clock, testutil.Logger(t))
+		ps, err := snapshot.FilterByPreset(current.presetID)
+		require.NoError(t, err)
+		require.NotNil(t, ps.ReadinessChecks)
+
+		// THEN: only the prebuild that passed is eligible, and the one that failed is replaced.
+		state := ps.CalculateState()
+		actions, err := ps.CalculateActions(backoffInterval)
+		require.NoError(t, err)
+		validateState(t, prebuilds.ReconciliationState{
+			Actual: 3, Unhealthy: 1, Desired: 3, Eligible: 1,
+		}, *state)
+		validateActions(t, []*prebuilds.ReconciliationActions{
+			{
+				ActionType: prebuilds.ActionTypeDelete,
+				DeleteIDs:  []uuid.UUID{failedID},
+			},
+			{
+				ActionType: prebuilds.ActionTypeCreate,
+				Create:     1,
+			},
+		}, actions)
+	})
+
+	t.Run("BacksOffRepeatedFailures", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: prebuilds of the preset failed their readiness checks repeatedly, which the store reports as
+		// failed builds.
+		presets := []database.GetTemplatePresetsWithPrebuildsRow{preset(true, 3, current)}
+		lastBuildTime := clock.Now()
+		numFailed := 3
+		backoffs := []database.GetPresetsBackoffRow{{
+			TemplateVersionID: current.templateVersionID,
+			PresetID:          current.presetID,
+			NumFailed:         int32(numFailed),
+			LastBuildAt:       lastBuildTime,
+		}}
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, backoffs, nil, nil, nil, checks, readiness, clock, testutil.Logger(t))
+		ps, err := snapshot.FilterByPreset(current.presetID)
+		require.NoError(t, err)
+
+		// THEN: the unhealthy prebuild is not replaced until the backoff elapsed.
+		actions, err := ps.CalculateActions(backoffInterval)
+		require.NoError(t, err)
+		validateActions(t, []*prebuilds.ReconciliationActions{
+			{
+				ActionType:   prebuilds.ActionTypeBackoff,
+				BackoffUntil: lastBuildTime.Add(time.Duration(numFailed) * backoffInterval),
+			},
+		}, actions)
+	})
+
+	t.Run("HardLimited", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: the latest prebuilds of the preset all failed their readiness checks, so that it hit the failure limit.
+		presets := []database.GetTemplatePresetsWithPrebuildsRow{preset(true, 3, current)}
+		hardLimited := []database.GetPresetsAtFailureLimitRow{{
+			TemplateVersionID: current.templateVersionID,
+			PresetID:          current.presetID,
+		}}
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, nil, hardLimited, nil, nil, checks, readiness, clock, testutil.Logger(t))
+		ps, err := snapshot.FilterByPreset(current.presetID)
+		require.NoError(t, err)
+
+		// THEN: the preset is hard limited, so the reconciler deletes the unhealthy prebuild without replacing it.
+		require.True(t, ps.IsHardLimited)
+	})
+
+	t.Run("InactiveVersion", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: the same prebuilds on an inactive template version.
+		presets := []database.GetTemplatePresetsWithPrebuildsRow{preset(false, 3, current)}
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, nil, nil, nil, nil, checks, readiness, clock, testutil.Logger(t))
+		ps, err := snapshot.FilterByPreset(current.presetID)
+		require.NoError(t, err)
+
+		// THEN: all prebuilds are deleted, including the unhealthy one.
+		require.False(t, ps.CanSkipReconciliation())
+		actions, err := ps.CalculateActions(backoffInterval)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.ElementsMatch(t, []uuid.UUID{passedID, pendingID, failedID}, actions[0].DeleteIDs)
+	})
+
+	t.Run("NoChecks", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: readiness results of a preset that no longer has readiness checks.
+		presets := []database.GetTemplatePresetsWithPrebuildsRow{preset(true, 3, current)}
+		snapshot := prebuilds.NewGlobalSnapshot(presets, nil, running, nil, nil, nil, nil, nil, nil, nil, readiness, clock, testutil.Logger(t))
+		ps, err := snapshot.FilterByPreset(current.presetID)
+		require.NoError(t, err)
+		require.Nil(t, ps.ReadinessChecks)
+
+		// THEN: the results are ignored and all ready prebuilds are eligible.
+		state := ps.CalculateState()
+		actions, err := ps.CalculateActions(backoffInterval)
+		require.NoError(t, err)
+		validateState(t, prebuilds.ReconciliationState{
+			Actual: 3, Desired: 3, Eligible: 3,
+		}, *state)
+		validateActions(t, nil, actions)
+	})
+}
+
 // TestCanSkipReconciliation ensures that CanSkipReconciliation only returns true
 // when CalculateActions would return no actions.
 func TestCanSkipReconciliation(t *testing.T) {
@@ -1751,10 +2064,15 @@ func TestCanSkipReconciliation(t *testing.T) {
 				[]database.TemplateVersionPresetPrebuildSchedule{},
 				tt.running,
 				tt.expired,
+				nil,
 				tt.inProgress,
 				tt.pendingCount,
 				tt.backoff,
 				tt.isHardLimited,
+				nil,
+				nil,
+				nil,
+				nil,
 				clock,
 				logger,
 			)
@@ -0,0 +1,183 @@
+package prebuilds
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/database"
+)
+
+// ReadinessInput is the state of a prebuilt workspace's latest build that readiness checks are evaluated against.
+type ReadinessInput struct {
+	Agents        []database.WorkspaceAgent
+	Scripts       []database.WorkspaceAgentScript
+	ScriptTimings []database.GetWorkspaceAgentScriptTimingsByBuildIDRow
+	Metadata      []database.WorkspaceAgentMetadatum
+	Apps          []database.WorkspaceApp
+	// BuildCompletedAt is when the latest build's provisioner job completed. The checks' timeout starts then if
+	// the agents did not report when they became ready, or if there are no agents.
+	BuildCompletedAt time.Time
+}
+
+// EvaluateReadiness evaluates the readiness checks of a preset against one of its prebuilt workspaces.
+//
+// Checks are only evaluated once all agents are ready. A check that can no longer pass, such as a script
+// that exited with an error or an app without a healthcheck, fails the prebuilt workspace right away.
+// Checks that have not passed yet, such as an app that is still initializing, remain pending until the
+// checks' timeout elapses after the agents became ready (or the build completed, if they did not report when),
+// after which they fail as well.
+//
+// The returned reasons explain why the checks are pending or failed, and are empty if the checks passed.
+func EvaluateReadiness(checks database.TemplatePresetReadinessCheck, in ReadinessInput, now time.Time) (database.PrebuildReadinessStatus, []string) {
+	var (
+		failed  []string
+		pending []string
+		readyAt time.Time
+	)
+	for _, agent := range in.Agents {
+		switch agent.LifecycleState {
+		case database.WorkspaceAgentLifecycleStateReady:
+			if agent.ReadyAt.Valid && agent.ReadyAt.Time.After(readyAt) {
+				readyAt = agent.ReadyAt.Time
+			}
+		case database.WorkspaceAgentLifecycleStateStartError, database.WorkspaceAgentLifecycleStateStartTimeout:
+			failed = append(failed, fmt.Sprintf("agent %q failed to start: %s", agent.Name, agent.LifecycleState))
+		default:
+			pending = append(pending, fmt.Sprintf("agent %q is not ready: %s", agent.Name, agent.LifecycleState))
+		}
+	}
+	if len(failed) > 0 {
+		return database.PrebuildReadinessStatusFailed, failed
+	}
+	if len(pending) > 0 {
+		// The agents' startup timeout applies until they are ready.
+		return database.PrebuildReadinessStatusPending, pending
+	}
+
+	for _, check := range checks.Checks {
+		var (
+			reason string
+			ok     bool
+		)
+		switch check.Type {
+		case database.PrebuildReadinessCheckTypeScript:
+			reason, ok = evaluateScriptCheck(check, in)
+		case database.PrebuildReadinessCheckTypeMetadata:
+			reason, ok = evaluateMetadataCheck(check, in)
+		case database.PrebuildReadinessCheckTypeApp:
+			reason, ok = evaluateAppCheck(check, in)
+		default:
+			reason, ok = fmt.Sprintf("unknown readiness check type %q", check.Type), false
+		}
+		if reason == "" {
+			continue
+		}
+		if ok {
+			pending = append(pending, reason)
+		} else {
+			failed = append(failed, reason)
+		}
+	}
+	if len(failed) > 0 {
+		return database.PrebuildReadinessStatusFailed, failed
+	}
+	if len(pending) == 0 {
+		return database.PrebuildReadinessStatusPassed, nil
+	}
+
+	if readyAt.IsZero() {
+		readyAt = in.BuildCompletedAt
+	}
+	timeout := time.Duration(checks.Timeout)
+	if !readyAt.IsZero() && now.Sub(readyAt) > timeout {
+		reasons := make([]string, 0, len(pending))
+		for _, reason := range pending {
+			reasons = append(reasons, fmt.Sprintf("%s after %s", reason, timeout))
+		}
+		return database.PrebuildReadinessStatusFailed, reasons
+	}
+	return database.PrebuildReadinessStatusPending, pending
+}
+
+// The evaluate*Check functions return an empty reason if the check passed. Otherwise, ok reports
+// whether the check may still pass, in which case the reason explains why it is pending.
+
+func evaluateScriptCheck(check database.PrebuildReadinessCheck, in ReadinessInput) (reason string, ok bool) {
+	scriptIDs := make(map[uuid.UUID]struct{})
+	for _, script := range in.Scripts {
+		if script.DisplayName == check.Script && script.RunOnStart {
+			scriptIDs[script.ID] = struct{}{}
+		}
+	}
+	if len(scriptIDs) == 0 {
+		return fmt.Sprintf("script %q does not exist or does not run on start", check.Script), false
+	}
+
+	var timing *database.GetWorkspaceAgentScriptTimingsByBuildIDRow
+	for i, t := range in.ScriptTimings {
+		if _, found := scriptIDs[t.ScriptID]; !found || t.Stage != database.WorkspaceAgentScriptTimingStageStart {
+			continue
+		}
+		if timing == nil || t.StartedAt.After(timing.StartedAt) {
+			timing = &in.ScriptTimings[i]
+		}
+	}
+	if timing == nil {
+		return fmt.Sprintf("script %q has not completed", check.Script), true
+	}
+	if timing.Status != database.WorkspaceAgentScriptTimingStatusOk {
+		return fmt.Sprintf("script %q failed with exit code %d: %s", check.Script, timing.ExitCode, timing.Status), false
+	}
+	return "", true
+}
+
+func evaluateMetadataCheck(check database.PrebuildReadinessCheck, in ReadinessInput) (reason string, ok bool) {
+	re, err := regexp.Compile(check.MetadataValue)
+	if err != nil {
+		return fmt.Sprintf("metadata %q has an invalid value pattern: %s", check.MetadataKey, err), false
+	}
+
+	var found bool
+	for _, md := range in.Metadata {
+		if md.Key != check.MetadataKey {
+			continue
+		}
+		found = true
+		switch {
+		case md.CollectedAt.IsZero():
+			return fmt.Sprintf("metadata %q has not been collected", check.MetadataKey), true
+		case md.Error != "":
+			return fmt.Sprintf("metadata %q failed to collect: %s", check.MetadataKey, md.Error), true
+		case !re.MatchString(md.Value):
+			return fmt.Sprintf("metadata %q value %q does not match %q", check.MetadataKey, md.Value, check.MetadataValue), true
+		}
+	}
+	if !found {
+		return fmt.Sprintf("metadata %q does not exist", check.MetadataKey), false
+	}
+	return "", true
+}
+
+func evaluateAppCheck(check database.PrebuildReadinessCheck, in ReadinessInput) (reason string, ok bool) {
+	var found bool
+	for _, app := range in.Apps {
+		if app.Slug != check.App {
+			continue
+		}
+		found = true
+		switch app.Health {
+		case database.WorkspaceAppHealthHealthy:
+		case database.WorkspaceAppHealthDisabled:
+			return fmt.Sprintf("app %q has no healthcheck", check.App), false
+		default:
+			return fmt.Sprintf("app %q is %s", check.App, app.Health), true
+		}
+	}
+	if !found {
+		return fmt.Sprintf("app %q does not exist", check.App), false
+	}
+	return "", true
+}
@@ -0,0 +1,186 @@
+package prebuilds_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/prebuilds"
+)
+
+func TestEvaluateReadiness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	agentID := uuid.New()
+	scriptID := uuid.New()
+
+	readyAgent := func(readyAt time.Time) database.WorkspaceAgent {
+		return database.WorkspaceAgent{
+			ID:             agentID,
+			Name:           "main",
+			LifecycleState: database.WorkspaceAgentLifecycleStateReady,
+			ReadyAt:        sql.NullTime{Time: readyAt, Valid: true},
+		}
+	}
+	script := database.WorkspaceAgentScript{
+		ID:               scriptID,
+		WorkspaceAgentID: agentID,
+		DisplayName:      "Clone repository",
+		RunOnStart:       true,
+	}
+	scriptTiming := func(status database.WorkspaceAgentScriptTimingStatus, exitCode int32) database.GetWorkspaceAgentScriptTimingsByBuildIDRow {
+		return database.GetWorkspaceAgentScriptTimingsByBuildIDRow{
+			ScriptID:  scriptID,
+			StartedAt: now.Add(-time.Minute),
+			ExitCode:  exitCode,
+			Stage:     database.WorkspaceAgentScriptTimingStageStart,
+			Status:    status,
+		}
+	}
+	metadata := func(value, errMsg string) database.WorkspaceAgentMetadatum {
+		return database.WorkspaceAgentMetadatum{
+			WorkspaceAgentID: agentID,
+			Key:              "cache",
+			Value:            value,
+			Error:            errMsg,
+			CollectedAt:      now,
+		}
+	}
+	app := func(health database.WorkspaceAppHealth) database.WorkspaceApp {
+		return database.WorkspaceApp{AgentID: agentID, Slug: "code-server", Health: health}
+	}
+
+	scriptCheck := database.PrebuildReadinessCheck{Type: database.PrebuildReadinessCheckTypeScript, Script: "Clone repository"}
+	metadataCheck := database.PrebuildReadinessCheck{Type: database.PrebuildReadinessCheckTypeMetadata, MetadataKey: "cache", MetadataValue: "^warm$"}
+	appCheck := database.PrebuildReadinessCheck{Type: database.PrebuildReadinessCheckTypeApp, App: "code-server"}
+
+	testCases := []struct {
+		name            string
+		checks          database.PrebuildReadinessChecks
+		input           prebuilds.ReadinessInput
+		expectedStatus  database.PrebuildReadinessStatus
+		expectedReasons []string
+	}{
+		{
+			name:   "AllPassed",
+			checks: database.PrebuildReadinessChecks{scriptCheck, metadataCheck, appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents:        []database.WorkspaceAgent{readyAgent(now.Add(-time.Minute))},
+				Scripts:       []database.WorkspaceAgentScript{script},
+				ScriptTimings: []database.GetWorkspaceAgentScriptTimingsByBuildIDRow{scriptTiming(database.WorkspaceAgentScriptTimingStatusOk, 0)},
+				Metadata:      []database.WorkspaceAgentMetadatum{metadata("warm", "")},
+				Apps:          []database.WorkspaceApp{app(database.WorkspaceAppHealthHealthy)},
+			},
+			expectedStatus: database.PrebuildReadinessStatusPassed,
+		},
+		{
+			name:   "AgentNotReady",
+			checks: database.PrebuildReadinessChecks{appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents: []database.WorkspaceAgent{{Name: "main", LifecycleState: database.WorkspaceAgentLifecycleStateStarting}},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusPending,
+			expectedReasons: []string{`agent "main" is not ready: starting`},
+		},
+		{
+			name:   "AgentStartError",
+			checks: database.PrebuildReadinessChecks{appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents: []database.WorkspaceAgent{{Name: "main", LifecycleState: database.WorkspaceAgentLifecycleStateStartError}},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusFailed,
+			expectedReasons: []string{`agent "main" failed to start: start_error`},
+		},
+		{
+			name:   "ScriptFailed",
+			checks: database.PrebuildReadinessChecks{scriptCheck},
+			input: prebuilds.ReadinessInput{
+				Agents:        []database.WorkspaceAgent{readyAgent(now.Add(-time.Minute))},
+				Scripts:       []database.WorkspaceAgentScript{script},
+				ScriptTimings: []database.GetWorkspaceAgentScriptTimingsByBuildIDRow{scriptTiming(database.WorkspaceAgentScriptTimingStatusExitFailure, 128)},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusFailed,
+			expectedReasons: []string{`script "Clone repository" failed with exit code 128: exit_failure`},
+		},
+		{
+			name:   "ScriptMissing",
+			checks: database.PrebuildReadinessChecks{scriptCheck},
+			input: prebuilds.ReadinessInput{
+				Agents: []database.WorkspaceAgent{readyAgent(now.Add(-time.Minute))},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusFailed,
+			expectedReasons: []string{`script "Clone repository" does not exist or does not run on start`},
+		},
+		{
+			name:   "MetadataMismatchPending",
+			checks: database.PrebuildReadinessChecks{metadataCheck},
+			input: prebuilds.ReadinessInput{
+				Agents:   []database.WorkspaceAgent{readyAgent(now.Add(-time.Minute))},
+				Metadata: []database.WorkspaceAgentMetadatum{metadata("cold", "")},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusPending,
+			expectedReasons: []string{`metadata "cache" value "cold" does not match "^warm$"`},
+		},
+		{
+			name:   "AppUnhealthyTimedOut",
+			checks: database.PrebuildReadinessChecks{appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents: []database.WorkspaceAgent{readyAgent(now.Add(-time.Hour))},
+				Apps:   []database.WorkspaceApp{app(database.WorkspaceAppHealthUnhealthy)},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusFailed,
+			expectedReasons: []string{`app "code-server" is unhealthy after 10m0s`},
+		},
+		{
+			name:   "ReadyWithoutReadyAtTimedOut",
+			checks: database.PrebuildReadinessChecks{appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents:           []database.WorkspaceAgent{{Name: "main", LifecycleState: database.WorkspaceAgentLifecycleStateReady}},
+				Apps:             []database.WorkspaceApp{app(database.WorkspaceAppHealthInitializing)},
+				BuildCompletedAt: now.Add(-time.Hour),
+			},
+			expectedStatus:  database.PrebuildReadinessStatusFailed,
+			expectedReasons: []string{`app "code-server" is initializing after 10m0s`},
+		},
+		{
+			name:   "ReadyWithoutReadyAtPending",
+			checks: database.PrebuildReadinessChecks{appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents:           []database.WorkspaceAgent{{Name: "main", LifecycleState: database.WorkspaceAgentLifecycleStateReady}},
+				Apps:             []database.WorkspaceApp{app(database.WorkspaceAppHealthInitializing)},
+				BuildCompletedAt: now.Add(-time.Minute),
+			},
+			expectedStatus:  database.PrebuildReadinessStatusPending,
+			expectedReasons: []string{`app "code-server" is initializing`},
+		},
+		{
+			name:   "AppWithoutHealthcheck",
+			checks: database.PrebuildReadinessChecks{appCheck},
+			input: prebuilds.ReadinessInput{
+				Agents: []database.WorkspaceAgent{readyAgent(now.Add(-time.Minute))},
+				Apps:   []database.WorkspaceApp{app(database.WorkspaceAppHealthDisabled)},
+			},
+			expectedStatus:  database.PrebuildReadinessStatusFailed,
+			expectedReasons: []string{`app "code-server" has no healthcheck`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checks := database.TemplatePresetReadinessCheck{
+				Checks:  tc.checks,
+				Timeout: int64(10 * time.Minute),
+			}
+			status, reasons := prebuilds.EvaluateReadiness(checks, tc.input, now)
+			require.Equal(t, tc.expectedStatus, status)
+			require.Equal(t, tc.expectedReasons, reasons)
+		})
+	}
+}
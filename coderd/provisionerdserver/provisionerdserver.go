@@ -577,7 +577,7 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 			if err != nil {
 				return nil, failJob(fmt.Sprintf("regenerate session token: %s", err))
 			}
-		case database.WorkspaceTransitionStop, database.WorkspaceTransitionDelete:
+		case database.WorkspaceTransitionStop, database.WorkspaceTransitionHibernate, database.WorkspaceTransitionDelete:
 			err = deleteSessionToken(ctx, s.Database, workspace)
 			if err != nil {
 				return nil, failJob(fmt.Sprintf("delete session token: %s", err))
@@ -681,24 +681,9 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 			})
 		}
 
-		allUserRoles, err := s.Database.GetAuthorizationUserRoles(ctx, owner.ID)
+		ownerRbacRoles, err := s.ownerRBACRoles(ctx, owner.ID)
 		if err != nil {
-			return nil, failJob(fmt.Sprintf("get owner authorization roles: %s", err))
-		}
-		ownerRbacRoles := []*sdkproto.Role{}
-		roles, err := allUserRoles.RoleNames()
-		if err == nil {
-			for _, role := range roles {
-				if role.OrganizationID != uuid.Nil && role.OrganizationID != s.OrganizationID {
-					continue // Only include site wide and org specific roles
-				}
-
-				orgID := role.OrganizationID.String()
-				if role.OrganizationID == uuid.Nil {
-					orgID = ""
-				}
-				ownerRbacRoles = append(ownerRbacRoles, &sdkproto.Role{Name: role.Name, OrgId: orgID})
-			}
+			return nil, failJob(err.Error())
 		}
 
 		runningAgentAuthTokens := []*sdkproto.RunningAgentAuthToken{}
@@ -780,19 +765,28 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 			return nil, failJob(fmt.Sprintf("get template version variables: %s", err))
 		}
 
-		protoJob.Type = &proto.AcquiredJob_TemplateDryRun_{
-			TemplateDryRun: &proto.AcquiredJob_TemplateDryRun{
-				RichParameterValues: convertRichParameterValues(input.RichParameterValues),
-				VariableValues:      asVariableValues(templateVariables),
-				Metadata: &sdkproto.Metadata{
-					CoderUrl:      s.AccessURL.String(),
-					WorkspaceName: input.WorkspaceName,
-					// There is no owner for a template import, but we can assume
-					// the "Everyone" group as a placeholder.
-					WorkspaceOwnerGroups: []string{database.EveryoneGroup},
-				},
+		dryRun := &proto.AcquiredJob_TemplateDryRun{
+			RichParameterValues: convertRichParameterValues(input.RichParameterValues),
+			VariableValues:      asVariableValues(templateVariables),
+			Metadata: &sdkproto.Metadata{
+				CoderUrl:      s.AccessURL.String(),
+				WorkspaceName: input.WorkspaceName,
+				// There is no owner for a template import, but we can assume
+				// the "Everyone" group as a placeholder.
+				WorkspaceOwnerGroups: []string{database.EveryoneGroup},
 			},
 		}
+		if input.WorkspaceID.Valid {
+			// The dry-run previews a build of an existing workspace, so plan
+			// against its current state with the same metadata a build gets.
+			dryRun.Metadata, dryRun.State, err = s.workspaceDryRunMetadata(ctx, input.WorkspaceID.UUID, templateVersion)
+			if err != nil {
+				return nil, failJob(err.Error())
+			}
+		}
+		protoJob.Type = &proto.AcquiredJob_TemplateDryRun_{
+			TemplateDryRun: dryRun,
+		}
 	case database.ProvisionerJobTypeTemplateVersionImport:
 		var input TemplateVersionImportJob
 		err = json.Unmarshal(job.Input, &input)
@@ -847,6 +841,105 @@ func (s *server) acquireProtoJob(ctx context.Context, job database.ProvisionerJo
 	return protoJob, err
 }
 
+// ownerRBACRoles returns the site wide and organization roles of the
+// workspace owner.
+func (s *server) ownerRBACRoles(ctx context.Context, ownerID uuid.UUID) ([]*sdkproto.Role, error) {
+	allUserRoles, err := s.Database.GetAuthorizationUserRoles(ctx, ownerID)
+	if err != nil {
+		return nil, xerrors.Errorf("get owner authorization roles: %w", err)
+	}
+	ownerRbacRoles := []*sdkproto.Role{}
+	roles, err := allUserRoles.RoleNames()
+	if err == nil {
+		for _, role := range roles {
+			if role.OrganizationID != uuid.Nil && role.OrganizationID != s.OrganizationID {
+				continue // Only include site wide and org specific roles
+			}
+
+			orgID := role.OrganizationID.String()
+			if role.OrganizationID == uuid.Nil {
+				orgID = ""
+			}
+			ownerRbacRoles = append(ownerRbacRoles, &sdkproto.Role{Name: role.Name, OrgId: orgID})
+		}
+	}
+	return ownerRbacRoles, nil
+}
+
+// workspaceDryRunMetadata returns the metadata and the current state of an
+// existing workspace, so a dry-run of the given template version plans the
+// changes a build of that workspace would make. Credentials that are minted
+// for each build, such as the owner session token, are omitted.
+func (s *server) workspaceDryRunMetadata(ctx context.Context, workspaceID uuid.UUID, templateVersion database.TemplateVersion) (*sdkproto.Metadata, []byte, error) {
+	workspace, err := s.Database.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("get workspace: %w", err)
+	}
+	latestBuild, err := s.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("get latest workspace build: %w", err)
+	}
+	template, err := s.Database.GetTemplateByID(ctx, workspace.TemplateID)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("get template: %w", err)
+	}
+	owner, err := s.Database.GetUserByID(ctx, workspace.OwnerID)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("get owner: %w", err)
+	}
+
+	var ownerSSHPublicKey, ownerSSHPrivateKey string
+	if ownerSSHKey, err := s.Database.GetGitSSHKey(ctx, owner.ID); err != nil {
+		if !xerrors.Is(err, sql.ErrNoRows) {
+			return nil, nil, xerrors.Errorf("get owner ssh key: %w", err)
+		}
+	} else {
+		ownerSSHPublicKey = ownerSSHKey.PublicKey
+		ownerSSHPrivateKey = ownerSSHKey.PrivateKey
+	}
+	ownerGroups, err := s.Database.GetGroups(ctx, database.GetGroupsParams{
+		HasMemberID:    owner.ID,
+		OrganizationID: s.OrganizationID,
+	})
+	if err != nil {
+		return nil, nil, xerrors.Errorf("get owner group names: %w", err)
+	}
+	ownerGroupNames := []string{}
+	for _, group := range ownerGroups {
+		ownerGroupNames = append(ownerGroupNames, group.Group.Name)
+	}
+	ownerRbacRoles, err := s.ownerRBACRoles(ctx, owner.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := s.Database.GetTaskByWorkspaceID(ctx, workspace.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, xerrors.Errorf("get task by workspace id: %w", err)
+	}
+
+	return &sdkproto.Metadata{
+		CoderUrl:                    s.AccessURL.String(),
+		WorkspaceTransition:         sdkproto.WorkspaceTransition_START,
+		WorkspaceName:               workspace.Name,
+		WorkspaceOwner:              owner.Username,
+		WorkspaceOwnerEmail:         owner.Email,
+		WorkspaceOwnerName:          owner.Name,
+		WorkspaceOwnerGroups:        ownerGroupNames,
+		WorkspaceId:                 workspace.ID.String(),
+		WorkspaceOwnerId:            owner.ID.String(),
+		TemplateId:                  template.ID.String(),
+		TemplateName:                template.Name,
+		TemplateVersionId:           templateVersion.ID.String(),
+		TemplateVersion:             templateVersion.Name,
+		WorkspaceOwnerSshPublicKey:  ownerSSHPublicKey,
+		WorkspaceOwnerSshPrivateKey: ownerSSHPrivateKey,
+		WorkspaceOwnerLoginType:     string(owner.LoginType),
+		WorkspaceOwnerRbacRoles:     ownerRbacRoles,
+		TaskId:                      task.ID.String(),
+		TaskPrompt:                  task.Prompt,
+	}, latestBuild.ProvisionerState, nil
+}
+
 func (s *server) includeLastVariableValues(ctx context.Context, templateVersionID uuid.UUID, userVariableValues []codersdk.VariableValue) ([]codersdk.VariableValue, error) {
 	var values []codersdk.VariableValue
 	values = append(values, userVariableValues...)
@@ -1978,7 +2071,7 @@ func (s *server) completeWorkspaceBuildJob(ctx context.Context, job database.Pro
 			}
 
 			if workspace.AutostartSchedule.Valid {
-				templateScheduleOptions, err := templateScheduleStore.Get(ctx, db, workspace.TemplateID)
+				templateScheduleOptions, err := templateScheduleStore.GetForUser(ctx, db, workspace.TemplateID, workspace.OwnerID)
 				if err != nil {
 					return xerrors.Errorf("get template schedule options: %w", err)
 				}
@@ -2523,6 +2616,34 @@ func (s *server) completeTemplateDryRunJob(ctx context.Context, job database.Pro
 			}
 		}
 
+		// Process resource changes
+		for _, change := range jobType.TemplateDryRun.ResourceChanges {
+			action := database.ResourceChangeAction(change.Action)
+			if !action.Valid() {
+				s.Logger.Warn(ctx, "skipping template dry-run resource change with unknown action",
+					slog.F("job_id", job.ID.String()),
+					slog.F("address", change.Address),
+					slog.F("action", change.Action),
+				)
+				continue
+			}
+			replacePaths := change.ReplacePaths
+			if replacePaths == nil {
+				replacePaths = []string{}
+			}
+			_, err := db.InsertProvisionerJobResourceChange(ctx, database.InsertProvisionerJobResourceChangeParams{
+				JobID:        jobID,
+				Address:      change.Address,
+				Type:         change.Type,
+				Name:         change.Name,
+				Action:       action,
+				ReplacePaths: replacePaths,
+			})
+			if err != nil {
+				return xerrors.Errorf("insert resource change: %w", err)
+			}
+		}
+
 		// Mark job as complete
 		err := db.UpdateProvisionerJobWithCompleteByID(ctx, database.UpdateProvisionerJobWithCompleteByIDParams{
 			ID:        jobID,
@@ -2947,6 +3068,8 @@ func InsertWorkspaceResource(ctx context.Context, db database.Store, jobID uuid.
 				// been enabled.
 				scriptsParams.ScriptRunOnStart = append(scriptsParams.ScriptRunOnStart, true)
 				scriptsParams.ScriptRunOnStop = append(scriptsParams.ScriptRunOnStop, false)
+				scriptsParams.ScriptUnitNames = append(scriptsParams.ScriptUnitNames, "")
+				scriptsParams.ScriptDependsOn = append(scriptsParams.ScriptDependsOn, nil)
 			}
 
 			_, err = db.InsertWorkspaceAgentDevcontainers(ctx, database.InsertWorkspaceAgentDevcontainersParams{
@@ -3173,6 +3296,8 @@ func convertWorkspaceTransition(transition database.WorkspaceTransition) (sdkpro
 		return sdkproto.WorkspaceTransition_STOP, nil
 	case database.WorkspaceTransitionDelete:
 		return sdkproto.WorkspaceTransition_DESTROY, nil
+	case database.WorkspaceTransitionHibernate:
+		return sdkproto.WorkspaceTransition_HIBERNATE, nil
 	default:
 		return 0, xerrors.Errorf("unrecognized transition: %q", transition)
 	}
@@ -3182,7 +3307,7 @@ func auditActionFromTransition(transition database.WorkspaceTransition) database
 	switch transition {
 	case database.WorkspaceTransitionStart:
 		return database.AuditActionStart
-	case database.WorkspaceTransitionStop:
+	case database.WorkspaceTransitionStop, database.WorkspaceTransitionHibernate:
 		return database.AuditActionStop
 	case database.WorkspaceTransitionDelete:
 		return database.AuditActionDelete
@@ -3213,6 +3338,9 @@ type TemplateVersionDryRunJob struct {
 	TemplateVersionID   uuid.UUID                          `json:"template_version_id"`
 	WorkspaceName       string                             `json:"workspace_name"`
 	RichParameterValues []database.WorkspaceBuildParameter `json:"rich_parameter_values"`
+	// WorkspaceID is set when the dry-run previews a build of an existing
+	// workspace. The plan is then computed against the workspace's state.
+	WorkspaceID uuid.NullUUID `json:"workspace_id,omitempty"`
 }
 
 func asVariableValues(templateVariables []database.TemplateVersionVariable) []*sdkproto.VariableValue {
@@ -3383,6 +3511,8 @@ type agentScriptsParams struct {
 	ScriptStartBlocksLogin []bool
 	ScriptRunOnStart       []bool
 	ScriptRunOnStop        []bool
+	ScriptUnitNames        []string
+	ScriptDependsOn        [][]string
 }
 
 // agentScriptsFromProto converts a slice of proto scripts into the
@@ -3402,6 +3532,8 @@ func agentScriptsFromProto(scripts []*sdkproto.Script) agentScriptsParams {
 		ScriptStartBlocksLogin: make([]bool, 0, len(scripts)),
 		ScriptRunOnStart:       make([]bool, 0, len(scripts)),
 		ScriptRunOnStop:        make([]bool, 0, len(scripts)),
+		ScriptUnitNames:        make([]string, 0, len(scripts)),
+		ScriptDependsOn:        make([][]string, 0, len(scripts)),
 	}
 
 	for _, script := range scripts {
@@ -3418,6 +3550,8 @@ func agentScriptsFromProto(scripts []*sdkproto.Script) agentScriptsParams {
 		params.ScriptStartBlocksLogin = append(params.ScriptStartBlocksLogin, script.GetStartBlocksLogin())
 		params.ScriptRunOnStart = append(params.ScriptRunOnStart, script.GetRunOnStart())
 		params.ScriptRunOnStop = append(params.ScriptRunOnStop, script.GetRunOnStop())
+		params.ScriptUnitNames = append(params.ScriptUnitNames, script.GetUnitName())
+		params.ScriptDependsOn = append(params.ScriptDependsOn, script.GetDependsOn())
 	}
 
 	return params
@@ -3443,6 +3577,18 @@ func insertAgentScriptsAndLogSources(ctx context.Context, db database.Store, age
 		return xerrors.Errorf("insert log sources: %w", err)
 	}
 
+	dependsOn := make([][]string, 0, len(params.ScriptDependsOn))
+	for _, deps := range params.ScriptDependsOn {
+		if deps == nil {
+			deps = []string{}
+		}
+		dependsOn = append(dependsOn, deps)
+	}
+	dependsOnJSON, err := json.Marshal(dependsOn)
+	if err != nil {
+		return xerrors.Errorf("marshal script dependencies: %w", err)
+	}
+
 	_, err = db.InsertWorkspaceAgentScripts(ctx, database.InsertWorkspaceAgentScriptsParams{
 		WorkspaceAgentID: agentID,
 		LogSourceID:      params.LogSourceIDs,
@@ -3456,6 +3602,8 @@ func insertAgentScriptsAndLogSources(ctx context.Context, db database.Store, age
 		RunOnStart:       params.ScriptRunOnStart,
 		RunOnStop:        params.ScriptRunOnStop,
 		DisplayName:      params.ScriptDisplayNames,
+		UnitName:         params.ScriptUnitNames,
+		DependsOn:        dependsOnJSON,
 	})
 	if err != nil {
 		return xerrors.Errorf("insert scripts: %w", err)
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
@@ -197,12 +198,17 @@ func (api *API) provisionerJobLogs(rw http.ResponseWriter, r *http.Request, job
 		}
 	}
 
+	filter, ok := parseProvisionerJobLogsFilter(rw, r)
+	if !ok {
+		return
+	}
+
 	if !follow {
-		fetchAndWriteLogs(ctx, api.Database, job.ID, after, rw, format)
+		fetchAndWriteLogs(ctx, api.Database, job.ID, after, filter, rw, format)
 		return
 	}
 
-	follower := newLogFollower(ctx, logger, api.Database, api.Pubsub, rw, r, job, after)
+	follower := newLogFollower(ctx, logger, api.Database, api.Pubsub, rw, r, job, after, filter)
 	api.WebsocketWaitMutex.Lock()
 	api.WebsocketWaitGroup.Add(1)
 	api.WebsocketWaitMutex.Unlock()
@@ -210,6 +216,53 @@ func (api *API) provisionerJobLogs(rw http.ResponseWriter, r *http.Request, job
 	follower.follow()
 }
 
+// provisionerJobLogsFilter narrows down the logs returned by the provisioner
+// job logs endpoints, so large logs don't have to be downloaded in full.
+type provisionerJobLogsFilter struct {
+	Stages []string
+	Levels []database.LogLevel
+	Search string
+	Since  time.Time
+}
+
+// parseProvisionerJobLogsFilter parses the filter from the query parameters of
+// the request. It writes an error response and returns false if any are
+// invalid.
+func parseProvisionerJobLogsFilter(rw http.ResponseWriter, r *http.Request) (provisionerJobLogsFilter, bool) {
+	var (
+		ctx    = r.Context()
+		values = r.URL.Query()
+		parser = httpapi.NewQueryParamParser()
+	)
+	filter := provisionerJobLogsFilter{
+		Stages: parser.Strings(values, []string{}, "stage"),
+		Levels: httpapi.ParseCustomList(parser, values, []database.LogLevel{}, "level", httpapi.ParseEnum[database.LogLevel]),
+		Search: parser.String(values, "", "q"),
+		Since:  parser.Time3339Nano(values, time.Time{}, "since"),
+	}
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query parameters.",
+			Validations: parser.Errors,
+		})
+		return provisionerJobLogsFilter{}, false
+	}
+	return filter, true
+}
+
+// params returns the query parameters to fetch the logs of the job created
+// after the given log ID that match the filter.
+func (f provisionerJobLogsFilter) params(jobID uuid.UUID, after int64) database.GetProvisionerLogsAfterIDParams {
+	return database.GetProvisionerLogsAfterIDParams{
+		JobID:          jobID,
+		CreatedAfter:   after,
+		Stages:         f.Stages,
+		Levels:         f.Levels,
+		Search:         f.Search,
+		CreatedAtSince: f.Since,
+	}
+}
+
 func (api *API) provisionerJobResources(rw http.ResponseWriter, r *http.Request, job database.ProvisionerJob) {
 	ctx := r.Context()
 	if !job.CompletedAt.Valid {
@@ -438,11 +491,8 @@ func convertProvisionerJobWithQueuePosition(pj database.GetProvisionerJobsByOrga
 	return job
 }
 
-func fetchAndWriteLogs(ctx context.Context, db database.Store, jobID uuid.UUID, after int64, rw http.ResponseWriter, format string) {
-	logs, err := db.GetProvisionerLogsAfterID(ctx, database.GetProvisionerLogsAfterIDParams{
-		JobID:        jobID,
-		CreatedAfter: after,
-	})
+func fetchAndWriteLogs(ctx context.Context, db database.Store, jobID uuid.UUID, after int64, filter provisionerJobLogsFilter, rw http.ResponseWriter, format string) {
+	logs, err := db.GetProvisionerLogsAfterID(ctx, filter.params(jobID, after))
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
 			Message: "Internal error fetching provisioner logs.",
@@ -501,6 +551,7 @@ type logFollower struct {
 
 	jobID         uuid.UUID
 	after         int64
+	filter        provisionerJobLogsFilter
 	complete      bool
 	notifications chan provisionersdk.ProvisionerJobLogsNotifyMessage
 	errors        chan error
@@ -509,6 +560,7 @@ type logFollower struct {
 func newLogFollower(
 	ctx context.Context, logger slog.Logger, db database.Store, ps pubsub.Pubsub,
 	rw http.ResponseWriter, r *http.Request, job database.ProvisionerJob, after int64,
+	filter provisionerJobLogsFilter,
 ) *logFollower {
 	return &logFollower{
 		ctx:           ctx,
@@ -519,6 +571,7 @@ func newLogFollower(
 		rw:            rw,
 		jobID:         job.ID,
 		after:         after,
+		filter:        filter,
 		complete:      jobIsComplete(logger, job),
 		notifications: make(chan provisionersdk.ProvisionerJobLogsNotifyMessage),
 		errors:        make(chan error),
@@ -672,10 +725,7 @@ func (f *logFollower) listener(_ context.Context, message []byte, err error) {
 // connection.
 func (f *logFollower) query() error {
 	f.logger.Debug(f.ctx, "querying logs", slog.F("after", f.after))
-	logs, err := f.db.GetProvisionerLogsAfterID(f.ctx, database.GetProvisionerLogsAfterIDParams{
-		JobID:        f.jobID,
-		CreatedAfter: f.after,
-	})
+	logs, err := f.db.GetProvisionerLogsAfterID(f.ctx, f.filter.params(f.jobID, f.after))
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return xerrors.Errorf("error fetching logs: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
@@ -169,13 +170,27 @@ func Test_logFollower_completeBeforeFollow(t *testing.T) {
 
 	// we need an HTTP server to get a websocket
 	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		uut := newLogFollower(ctx, logger, mDB, ps, rw, r, job, 10)
+		uut := newLogFollower(ctx, logger, mDB, ps, rw, r, job, 10, provisionerJobLogsFilter{
+			Stages: []string{"One"},
+			Levels: []database.LogLevel{database.LogLevelInfo},
+			Search: "o",
+			Since:  job.CreatedAt,
+		})
 		uut.follow()
 	}))
 	defer srv.Close()
 
-	// return some historical logs
-	mDB.EXPECT().GetProvisionerLogsAfterID(gomock.Any(), matchesJobAfter(job.ID, 10)).
+	// return some historical logs, which are fetched with the filter
+	mDB.EXPECT().GetProvisionerLogsAfterID(gomock.Any(), &logsAfterMatcher{
+		params: database.GetProvisionerLogsAfterIDParams{
+			JobID:          job.ID,
+			CreatedAfter:   10,
+			Stages:         []string{"One"},
+			Levels:         []database.LogLevel{database.LogLevelInfo},
+			Search:         "o",
+			CreatedAtSince: job.CreatedAt,
+		},
+	}).
 		Times(1).
 		Return(
 			[]database.ProvisionerJobLog{
@@ -230,7 +245,7 @@ func Test_logFollower_completeBeforeSubscribe(t *testing.T) {
 
 	// we need an HTTP server to get a websocket
 	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		uut := newLogFollower(ctx, logger, mDB, ps, rw, r, job, 0)
+		uut := newLogFollower(ctx, logger, mDB, ps, rw, r, job, 0, provisionerJobLogsFilter{})
 		uut.follow()
 	}))
 	defer srv.Close()
@@ -312,7 +327,7 @@ func Test_logFollower_EndOfLogs(t *testing.T) {
 
 	// we need an HTTP server to get a websocket
 	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		uut := newLogFollower(ctx, logger, mDB, ps, rw, r, job, 0)
+		uut := newLogFollower(ctx, logger, mDB, ps, rw, r, job, 0, provisionerJobLogsFilter{})
 		uut.follow()
 	}))
 
@@ -409,7 +424,12 @@ func (m *logsAfterMatcher) Matches(x interface{}) bool {
 	if !ok {
 		return false
 	}
-	return m.params == p
+	return m.params.JobID == p.JobID &&
+		m.params.CreatedAfter == p.CreatedAfter &&
+		slices.Equal(m.params.Stages, p.Stages) &&
+		slices.Equal(m.params.Levels, p.Levels) &&
+		m.params.Search == p.Search &&
+		m.params.CreatedAtSince.Equal(p.CreatedAtSince)
 }
 
 func (m *logsAfterMatcher) String() string {
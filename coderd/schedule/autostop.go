@@ -118,7 +118,7 @@ func CalculateAutostop(ctx context.Context, params CalculateAutostopParams) (Aut
 		autostop AutostopTime
 	)
 
-	templateSchedule, err := params.TemplateScheduleStore.Get(ctx, db, workspace.TemplateID)
+	templateSchedule, err := params.TemplateScheduleStore.GetForUser(ctx, db, workspace.TemplateID, workspace.OwnerID)
 	if err != nil {
 		return autostop, xerrors.Errorf("get template schedule options: %w", err)
 	}
@@ -236,6 +236,15 @@ func CalculateAutostop(ctx context.Context, params CalculateAutostopParams) (Aut
 		}
 	}
 
+	// A max TTL caps how long the build can run in total, so that activity
+	// bumps cannot extend the deadline past it.
+	if templateSchedule.MaxTTL > 0 {
+		maxDeadline := buildCompletedAt.Add(templateSchedule.MaxTTL)
+		if autostop.MaxDeadline.IsZero() || maxDeadline.Before(autostop.MaxDeadline) {
+			autostop.MaxDeadline = maxDeadline
+		}
+	}
+
 	// If the workspace doesn't have a deadline or the max deadline is sooner
 	// than the workspace deadline, use the max deadline as the actual deadline.
 	if !autostop.MaxDeadline.IsZero() && (autostop.Deadline.IsZero() || autostop.MaxDeadline.Before(autostop.Deadline)) {
@@ -256,19 +265,23 @@ func CalculateAutostop(ctx context.Context, params CalculateAutostopParams) (Aut
 // If the template forbids custom workspace TTLs, then we always use the
 // template's configured TTL (or 0 if the template has no TTL configured).
 func workspaceTTL(workspace database.WorkspaceTable, templateSchedule TemplateScheduleOptions) time.Duration {
+	ttl := time.Duration(0)
+	switch {
 	// If the template forbids custom workspace TTLs, then we always use the
 	// template's configured TTL (or 0 if the template has no TTL configured).
-	if !templateSchedule.UserAutostopEnabled {
-		// This is intentionally a nested if statement because of the else if.
+	case !templateSchedule.UserAutostopEnabled:
 		if templateSchedule.DefaultTTL > 0 {
-			return templateSchedule.DefaultTTL
+			ttl = templateSchedule.DefaultTTL
 		}
-		return 0
+	case workspace.Ttl.Valid:
+		ttl = time.Duration(workspace.Ttl.Int64)
 	}
-	if workspace.Ttl.Valid {
-		return time.Duration(workspace.Ttl.Int64)
+
+	// A max TTL also applies to workspaces that would otherwise never stop.
+	if templateSchedule.MaxTTL > 0 && (ttl <= 0 || ttl > templateSchedule.MaxTTL) {
+		return templateSchedule.MaxTTL
 	}
-	return 0
+	return ttl
 }
 
 // truncateMidnight truncates a time to midnight in the time object's timezone.
@@ -85,6 +85,7 @@ func TestCalculateAutoStop(t *testing.T) {
 		templateAllowAutostop       bool
 		templateDefaultTTL          time.Duration
 		templateAutostopRequirement schedule.TemplateAutostopRequirement
+		templateMaxTTL              time.Duration
 		userQuietHoursSchedule      string
 		// workspaceTTL is usually copied from the template's TTL when the
 		// workspace is made, so it takes precedence unless
@@ -156,6 +157,43 @@ func TestCalculateAutoStop(t *testing.T) {
 			expectedDeadline:            now.Add(3 * time.Hour),
 			expectedMaxDeadline:         time.Time{},
 		},
+		{
+			// Activity bumps cannot extend the deadline past the max TTL.
+			name:                  "MaxTTL",
+			buildCompletedAt:      now,
+			templateAllowAutostop: true,
+			templateMaxTTL:        4 * time.Hour,
+			workspaceTTL:          time.Hour,
+			expectedDeadline:      now.Add(time.Hour),
+			expectedMaxDeadline:   now.Add(4 * time.Hour),
+		},
+		{
+			// The deadline would be extended to 2 hours after the 9am
+			// autostart, but the build may only run for 150 minutes.
+			name:                  "MaxTTLCapsAutostartBorder",
+			buildCompletedAt:      time.Date(pastDateNight.Year(), pastDateNight.Month(), pastDateNight.Day(), 8, 0, 0, 0, chicago),
+			wsAutostart:           "CRON_TZ=America/Chicago 0 9 * * *",
+			templateAllowAutostop: true,
+			templateAutoStart: schedule.TemplateAutostartRequirement{
+				DaysOfWeek: 0b01111111,
+			},
+			templateMaxTTL:      150 * time.Minute,
+			workspaceTTL:        2 * time.Hour,
+			expectedMaxDeadline: time.Date(pastDateNight.Year(), pastDateNight.Month(), pastDateNight.Day(), 10, 30, 0, 0, chicago),
+		},
+		{
+			// The max TTL is sooner than the autostop requirement.
+			name:                   "MaxTTLBeforeAutostopRequirement",
+			buildCompletedAt:       pastDateNight,
+			templateAllowAutostop:  true,
+			templateMaxTTL:         4 * time.Hour,
+			userQuietHoursSchedule: "CRON_TZ=America/Chicago 0 11 * * *",
+			templateAutostopRequirement: schedule.TemplateAutostopRequirement{
+				DaysOfWeek: 0b01111111,
+				Weeks:      1,
+			},
+			expectedMaxDeadline: pastDateNight.Add(4 * time.Hour),
+		},
 		{
 			name:                   "TemplateAutostopRequirement",
 			buildCompletedAt:       wednesdayMidnightUTC,
@@ -498,6 +536,7 @@ func TestCalculateAutoStop(t *testing.T) {
 						UserAutostartEnabled: false,
 						UserAutostopEnabled:  c.templateAllowAutostop,
 						DefaultTTL:           c.templateDefaultTTL,
+						MaxTTL:               c.templateMaxTTL,
 						AutostopRequirement:  c.templateAutostopRequirement,
 						AutostartRequirement: c.templateAutoStart,
 					}, nil
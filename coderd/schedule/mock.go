@@ -9,8 +9,9 @@ import (
 )
 
 type MockTemplateScheduleStore struct {
-	GetFn func(ctx context.Context, db database.Store, templateID uuid.UUID) (TemplateScheduleOptions, error)
-	SetFn func(ctx context.Context, db database.Store, template database.Template, options TemplateScheduleOptions) (database.Template, error)
+	GetFn        func(ctx context.Context, db database.Store, templateID uuid.UUID) (TemplateScheduleOptions, error)
+	GetForUserFn func(ctx context.Context, db database.Store, templateID uuid.UUID, userID uuid.UUID) (TemplateScheduleOptions, error)
+	SetFn        func(ctx context.Context, db database.Store, template database.Template, options TemplateScheduleOptions) (database.Template, error)
 }
 
 var _ TemplateScheduleStore = MockTemplateScheduleStore{}
@@ -23,6 +24,14 @@ func (m MockTemplateScheduleStore) Get(ctx context.Context, db database.Store, t
 	return NewAGPLTemplateScheduleStore().Get(ctx, db, templateID)
 }
 
+func (m MockTemplateScheduleStore) GetForUser(ctx context.Context, db database.Store, templateID uuid.UUID, userID uuid.UUID) (TemplateScheduleOptions, error) {
+	if m.GetForUserFn != nil {
+		return m.GetForUserFn(ctx, db, templateID, userID)
+	}
+
+	return m.Get(ctx, db, templateID)
+}
+
 func (m MockTemplateScheduleStore) Set(ctx context.Context, db database.Store, template database.Template, options TemplateScheduleOptions) (database.Template, error) {
 	if m.SetFn != nil {
 		return m.SetFn(ctx, db, template, options)
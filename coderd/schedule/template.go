@@ -120,6 +120,10 @@ type TemplateScheduleOptions struct {
 	UserAutostartEnabled bool
 	UserAutostopEnabled  bool
 	DefaultTTL           time.Duration
+	// MaxTTL caps the TTL of workspaces, including TTLs set by users. A value
+	// of 0 means no cap. It is only set by group schedule overrides, so Set
+	// ignores it.
+	MaxTTL time.Duration
 	// ActivityBump dictates the duration to bump the workspace's deadline by if
 	// Coder detects activity from the user. A value of 0 means no bumping.
 	ActivityBump time.Duration
@@ -137,6 +141,12 @@ type TemplateScheduleOptions struct {
 	// TimeTilDormantAutoDelete dictates the duration after which dormant workspaces will be
 	// permanently deleted.
 	TimeTilDormantAutoDelete time.Duration
+	// HibernateIdleThreshold dictates whether workspaces reaching their
+	// autostop deadline are hibernated rather than stopped. Workspaces that
+	// have been inactive for less than this duration are hibernated. A value
+	// of 0 means workspaces are always stopped. It is updated alongside the
+	// template metadata, so Set ignores it.
+	HibernateIdleThreshold time.Duration
 	// UpdateWorkspaceLastUsedAt updates the template's workspaces'
 	// last_used_at field. This is useful for preventing updates to the
 	// templates inactivity_ttl immediately triggering a dormant action against
@@ -155,6 +165,11 @@ type TemplateScheduleOptions struct {
 // scheduling options set by the template/site admin.
 type TemplateScheduleStore interface {
 	Get(ctx context.Context, db database.Store, templateID uuid.UUID) (TemplateScheduleOptions, error)
+	// GetForUser returns the scheduling options that apply to workspaces of
+	// the template owned by the given user. These can be stricter than the
+	// template's own options when the user belongs to groups with schedule
+	// overrides.
+	GetForUser(ctx context.Context, db database.Store, templateID uuid.UUID, userID uuid.UUID) (TemplateScheduleOptions, error)
 	Set(ctx context.Context, db database.Store, template database.Template, opts TemplateScheduleOptions) (database.Template, error)
 }
 
@@ -197,9 +212,15 @@ func (*agplTemplateScheduleStore) Get(ctx context.Context, db database.Store, te
 		FailureTTL:               0,
 		TimeTilDormant:           0,
 		TimeTilDormantAutoDelete: 0,
+		HibernateIdleThreshold:   time.Duration(tpl.HibernateIdleThreshold),
 	}, nil
 }
 
+func (s *agplTemplateScheduleStore) GetForUser(ctx context.Context, db database.Store, templateID uuid.UUID, _ uuid.UUID) (TemplateScheduleOptions, error) {
+	// Group schedule overrides are an enterprise feature.
+	return s.Get(ctx, db, templateID)
+}
+
 func (*agplTemplateScheduleStore) Set(ctx context.Context, db database.Store, tpl database.Template, opts TemplateScheduleOptions) (database.Template, error) {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.End()
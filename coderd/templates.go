@@ -26,6 +26,7 @@ import (
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/schedule"
+	"github.com/coder/coder/v2/coderd/schedule/cron"
 	"github.com/coder/coder/v2/coderd/searchquery"
 	"github.com/coder/coder/v2/coderd/telemetry"
 	"github.com/coder/coder/v2/coderd/util/ptr"
@@ -762,6 +763,21 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	maintenanceWindowSchedule := ptr.NilToDefault(req.MaintenanceWindowSchedule, template.MaintenanceWindowSchedule)
+	if maintenanceWindowSchedule != "" && maintenanceWindowSchedule != template.MaintenanceWindowSchedule {
+		if _, err := cron.TimeRange(maintenanceWindowSchedule); err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "maintenance_window_schedule", Detail: err.Error()})
+		}
+	}
+
+	hibernateIdleThreshold := template.HibernateIdleThreshold
+	if req.HibernateIdleThresholdMillis != nil {
+		if *req.HibernateIdleThresholdMillis < 0 {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "hibernate_idle_threshold_ms", Detail: "Value must not be negative."})
+		}
+		hibernateIdleThreshold = int64(time.Duration(*req.HibernateIdleThresholdMillis) * time.Millisecond)
+	}
+
 	if len(validErrs) > 0 {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message:     "Invalid request to update template metadata!",
@@ -779,6 +795,8 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 	if req.DisableModuleCache != nil {
 		disableModuleCache = *req.DisableModuleCache
 	}
+	sessionRecordingEnabled := ptr.NilToDefault(req.SessionRecordingEnabled, template.SessionRecordingEnabled)
+	sessionRecordingIncludeInput := ptr.NilToDefault(req.SessionRecordingIncludeInput, template.SessionRecordingIncludeInput)
 
 	displayName := ptr.NilToDefault(req.DisplayName, template.DisplayName)
 	description := ptr.NilToDefault(req.Description, template.Description)
@@ -805,6 +823,10 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			(deprecationMessage == template.Deprecated) &&
 			(classicTemplateFlow == template.UseClassicParameterFlow) &&
 			(disableModuleCache == template.DisableModuleCache) &&
+			sessionRecordingEnabled == template.SessionRecordingEnabled &&
+			sessionRecordingIncludeInput == template.SessionRecordingIncludeInput &&
+			maintenanceWindowSchedule == template.MaintenanceWindowSchedule &&
+			hibernateIdleThreshold == template.HibernateIdleThreshold &&
 			maxPortShareLevel == template.MaxPortSharingLevel &&
 			corsBehavior == template.CorsBehavior {
 			return nil
@@ -850,6 +872,10 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			UseClassicParameterFlow:      classicTemplateFlow,
 			CorsBehavior:                 corsBehavior,
 			DisableModuleCache:           disableModuleCache,
+			SessionRecordingEnabled:      sessionRecordingEnabled,
+			SessionRecordingIncludeInput: sessionRecordingIncludeInput,
+			MaintenanceWindowSchedule:    maintenanceWindowSchedule,
+			HibernateIdleThreshold:       hibernateIdleThreshold,
 		})
 		if err != nil {
 			return xerrors.Errorf("update template metadata: %w", err)
@@ -1136,6 +1162,11 @@ func (api *API) convertTemplate(
 		UseClassicParameterFlow: template.UseClassicParameterFlow,
 		CORSBehavior:            codersdk.CORSBehavior(template.CorsBehavior),
 		DisableModuleCache:      template.DisableModuleCache,
+
+		SessionRecordingEnabled:      template.SessionRecordingEnabled,
+		SessionRecordingIncludeInput: template.SessionRecordingIncludeInput,
+		MaintenanceWindowSchedule:    template.MaintenanceWindowSchedule,
+		HibernateIdleThresholdMillis: time.Duration(template.HibernateIdleThreshold).Milliseconds(),
 	}
 }
 
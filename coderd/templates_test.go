@@ -1649,6 +1649,47 @@ func TestPatchTemplateMeta(t *testing.T) {
 		assert.False(t, updated.DisableModuleCache, "expected false")
 	})
 
+	t.Run("MaintenanceWindow", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		require.Empty(t, template.MaintenanceWindowSchedule, "default is unset")
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// Minutes must be a wildcard for a time range.
+		_, err := client.UpdateTemplateMeta(ctx, template.ID, codersdk.UpdateTemplateMeta{
+			MaintenanceWindowSchedule: ptr.Ref("30 2 * * 6"),
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Len(t, apiErr.Validations, 1)
+		require.Equal(t, "maintenance_window_schedule", apiErr.Validations[0].Field)
+
+		req := codersdk.UpdateTemplateMeta{
+			MaintenanceWindowSchedule: ptr.Ref("CRON_TZ=Europe/Berlin * 2-4 * * 6"),
+		}
+		updated, err := client.UpdateTemplateMeta(ctx, template.ID, req)
+		require.NoError(t, err)
+		assert.Equal(t, "CRON_TZ=Europe/Berlin * 2-4 * * 6", updated.MaintenanceWindowSchedule)
+
+		// noop - should stay set when not specified
+		req.MaintenanceWindowSchedule = nil
+		updated, err = client.UpdateTemplateMeta(ctx, template.ID, req)
+		require.NoError(t, err)
+		assert.Equal(t, "CRON_TZ=Europe/Berlin * 2-4 * * 6", updated.MaintenanceWindowSchedule)
+
+		// unset
+		req.MaintenanceWindowSchedule = ptr.Ref("")
+		updated, err = client.UpdateTemplateMeta(ctx, template.ID, req)
+		require.NoError(t, err)
+		assert.Empty(t, updated.MaintenanceWindowSchedule)
+	})
+
 	t.Run("SupportEmptyOrDefaultFields", func(t *testing.T) {
 		t.Parallel()
 
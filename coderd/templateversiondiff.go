@@ -0,0 +1,326 @@
+package coderd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pkg/diff"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// templateVersionDiffMaxFileSize is the size above which source files are
+// reported as binary instead of being diffed.
+const templateVersionDiffMaxFileSize = 1 << 20
+
+// templateVersionDiffSource is what is compared of a template version.
+type templateVersionDiffSource struct {
+	id         uuid.UUID
+	files      map[string][]byte
+	parameters []codersdk.TemplateVersionParameter
+	variables  []codersdk.TemplateVersionVariable
+	// resources maps resource addresses to their fields.
+	resources map[string]map[string]string
+}
+
+// readTemplateVersionFiles reads all regular files of a template source
+// archive.
+func readTemplateVersionFiles(fsys fs.FS) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return xerrors.Errorf("read %q: %w", path, err)
+		}
+		files[path] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// templateVersionDiffResources keys the resources of a template version
+// import job by their address. Resources planned for both the start and stop
+// transitions are merged, preferring the values of the start transition.
+func templateVersionDiffResources(resources []database.WorkspaceResource, metadata []database.WorkspaceResourceMetadatum, agents []database.WorkspaceAgent) map[string]map[string]string {
+	metadataByResource := make(map[uuid.UUID][]database.WorkspaceResourceMetadatum)
+	for _, m := range metadata {
+		metadataByResource[m.WorkspaceResourceID] = append(metadataByResource[m.WorkspaceResourceID], m)
+	}
+	agentsByResource := make(map[uuid.UUID][]string)
+	for _, agent := range agents {
+		agentsByResource[agent.ResourceID] = append(agentsByResource[agent.ResourceID], agent.Name)
+	}
+
+	// Stop transitions sort first, so start transitions overwrite them.
+	resources = slices.Clone(resources)
+	slices.SortStableFunc(resources, func(a, b database.WorkspaceResource) int {
+		return strings.Compare(string(b.Transition), string(a.Transition))
+	})
+
+	transitions := make(map[string][]string)
+	fields := make(map[string]map[string]string)
+	for _, resource := range resources {
+		address := resource.Type + "." + resource.Name
+		if resource.ModulePath.Valid && resource.ModulePath.String != "" {
+			address = resource.ModulePath.String + "." + address
+		}
+		f, ok := fields[address]
+		if !ok {
+			f = make(map[string]string)
+			fields[address] = f
+		}
+		if !slices.Contains(transitions[address], string(resource.Transition)) {
+			transitions[address] = append(transitions[address], string(resource.Transition))
+		}
+		f["icon"] = resource.Icon
+		f["hide"] = strconv.FormatBool(resource.Hide)
+		f["instance_type"] = resource.InstanceType.String
+		f["daily_cost"] = strconv.FormatInt(int64(resource.DailyCost), 10)
+		resourceAgents := slices.Clone(agentsByResource[resource.ID])
+		slices.Sort(resourceAgents)
+		f["agents"] = strings.Join(resourceAgents, ", ")
+		for _, m := range metadataByResource[resource.ID] {
+			value := m.Value.String
+			if m.Sensitive {
+				value = redacted
+			}
+			f["metadata."+m.Key] = value
+		}
+	}
+	for address, t := range transitions {
+		slices.Sort(t)
+		fields[address]["transitions"] = strings.Join(t, ", ")
+	}
+	return fields
+}
+
+// diffTemplateVersions compares two template versions.
+func diffTemplateVersions(from, to templateVersionDiffSource) codersdk.TemplateVersionDiff {
+	return codersdk.TemplateVersionDiff{
+		FromID:     from.id,
+		ToID:       to.id,
+		Files:      diffTemplateVersionFiles(from.files, to.files),
+		Parameters: diffTemplateVersionParameters(from.parameters, to.parameters),
+		Variables:  diffTemplateVersionVariables(from.variables, to.variables),
+		Resources:  diffTemplateVersionItems(from.resources, to.resources),
+	}
+}
+
+func diffTemplateVersionFiles(from, to map[string][]byte) []codersdk.TemplateVersionFileDiff {
+	diffs := make([]codersdk.TemplateVersionFileDiff, 0)
+	for _, path := range diffNames(from, to) {
+		fromData, inFrom := from[path]
+		toData, inTo := to[path]
+		fileDiff := codersdk.TemplateVersionFileDiff{
+			Path:   path,
+			Change: codersdk.TemplateVersionDiffChangeModified,
+		}
+		fromName, toName := "a/"+path, "b/"+path
+		switch {
+		case !inFrom:
+			fileDiff.Change = codersdk.TemplateVersionDiffChangeAdded
+			fromName = "/dev/null"
+		case !inTo:
+			fileDiff.Change = codersdk.TemplateVersionDiffChangeRemoved
+			toName = "/dev/null"
+		case bytes.Equal(fromData, toData):
+			continue
+		}
+		if !isDiffableText(fromData) || !isDiffableText(toData) {
+			fileDiff.Binary = true
+			diffs = append(diffs, fileDiff)
+			continue
+		}
+		var buf bytes.Buffer
+		// Writing to a bytes.Buffer cannot fail.
+		_ = diff.Text(fromName, toName, fromData, toData, &buf)
+		fileDiff.Diff = buf.String()
+		diffs = append(diffs, fileDiff)
+	}
+	return diffs
+}
+
+func isDiffableText(data []byte) bool {
+	return len(data) <= templateVersionDiffMaxFileSize &&
+		utf8.Valid(data) &&
+		!bytes.Contains(data, []byte{0})
+}
+
+func diffTemplateVersionParameters(from, to []codersdk.TemplateVersionParameter) []codersdk.TemplateVersionParameterDiff {
+	fromByName := make(map[string]codersdk.TemplateVersionParameter, len(from))
+	for _, p := range from {
+		fromByName[p.Name] = p
+	}
+	toByName := make(map[string]codersdk.TemplateVersionParameter, len(to))
+	for _, p := range to {
+		toByName[p.Name] = p
+	}
+
+	diffs := make([]codersdk.TemplateVersionParameterDiff, 0)
+	for _, name := range diffNames(fromByName, toByName) {
+		fromParam, inFrom := fromByName[name]
+		toParam, inTo := toByName[name]
+		switch {
+		case !inFrom:
+			diffs = append(diffs, codersdk.TemplateVersionParameterDiff{Name: name, Change: codersdk.TemplateVersionDiffChangeAdded})
+			continue
+		case !inTo:
+			diffs = append(diffs, codersdk.TemplateVersionParameterDiff{Name: name, Change: codersdk.TemplateVersionDiffChangeRemoved})
+			continue
+		}
+
+		fromOptions := make(map[string]codersdk.TemplateVersionParameterOption, len(fromParam.Options))
+		for _, o := range fromParam.Options {
+			fromOptions[o.Value] = o
+		}
+		toOptions := make(map[string]codersdk.TemplateVersionParameterOption, len(toParam.Options))
+		for _, o := range toParam.Options {
+			toOptions[o.Value] = o
+		}
+		paramDiff := codersdk.TemplateVersionParameterDiff{
+			Name:   name,
+			Change: codersdk.TemplateVersionDiffChangeModified,
+			Fields: diffFields(parameterDiffFields(fromParam, toOptions), parameterDiffFields(toParam, fromOptions)),
+		}
+		for _, value := range diffNames(fromOptions, toOptions) {
+			_, inFrom := fromOptions[value]
+			_, inTo := toOptions[value]
+			switch {
+			case !inFrom:
+				paramDiff.OptionsAdded = append(paramDiff.OptionsAdded, value)
+			case !inTo:
+				paramDiff.OptionsRemoved = append(paramDiff.OptionsRemoved, value)
+			}
+		}
+		if len(paramDiff.Fields) > 0 || len(paramDiff.OptionsAdded) > 0 || len(paramDiff.OptionsRemoved) > 0 {
+			diffs = append(diffs, paramDiff)
+		}
+	}
+	return diffs
+}
+
+// parameterDiffFields returns the fields of a parameter, including those of
+// its options that also exist in the other version.
+func parameterDiffFields(p codersdk.TemplateVersionParameter, otherOptions map[string]codersdk.TemplateVersionParameterOption) map[string]string {
+	formatInt := func(v *int32) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatInt(int64(*v), 10)
+	}
+	fields := map[string]string{
+		"display_name":         p.DisplayName,
+		"description":          p.Description,
+		"type":                 p.Type,
+		"form_type":            p.FormType,
+		"mutable":              strconv.FormatBool(p.Mutable),
+		"default_value":        p.DefaultValue,
+		"icon":                 p.Icon,
+		"required":             strconv.FormatBool(p.Required),
+		"ephemeral":            strconv.FormatBool(p.Ephemeral),
+		"validation_error":     p.ValidationError,
+		"validation_regex":     p.ValidationRegex,
+		"validation_min":       formatInt(p.ValidationMin),
+		"validation_max":       formatInt(p.ValidationMax),
+		"validation_monotonic": string(p.ValidationMonotonic),
+	}
+	for _, o := range p.Options {
+		if _, ok := otherOptions[o.Value]; !ok {
+			continue
+		}
+		prefix := fmt.Sprintf("options[%q].", o.Value)
+		fields[prefix+"name"] = o.Name
+		fields[prefix+"description"] = o.Description
+		fields[prefix+"icon"] = o.Icon
+	}
+	return fields
+}
+
+func diffTemplateVersionVariables(from, to []codersdk.TemplateVersionVariable) []codersdk.TemplateVersionItemDiff {
+	variableFields := func(variables []codersdk.TemplateVersionVariable) map[string]map[string]string {
+		fields := make(map[string]map[string]string, len(variables))
+		for _, v := range variables {
+			fields[v.Name] = map[string]string{
+				"description":   v.Description,
+				"type":          v.Type,
+				"value":         v.Value,
+				"default_value": v.DefaultValue,
+				"required":      strconv.FormatBool(v.Required),
+				"sensitive":     strconv.FormatBool(v.Sensitive),
+			}
+		}
+		return fields
+	}
+	return diffTemplateVersionItems(variableFields(from), variableFields(to))
+}
+
+// diffTemplateVersionItems compares named items by their fields.
+func diffTemplateVersionItems(from, to map[string]map[string]string) []codersdk.TemplateVersionItemDiff {
+	diffs := make([]codersdk.TemplateVersionItemDiff, 0)
+	for _, name := range diffNames(from, to) {
+		fromFields, inFrom := from[name]
+		toFields, inTo := to[name]
+		switch {
+		case !inFrom:
+			diffs = append(diffs, codersdk.TemplateVersionItemDiff{Name: name, Change: codersdk.TemplateVersionDiffChangeAdded})
+		case !inTo:
+			diffs = append(diffs, codersdk.TemplateVersionItemDiff{Name: name, Change: codersdk.TemplateVersionDiffChangeRemoved})
+		default:
+			fields := diffFields(fromFields, toFields)
+			if len(fields) > 0 {
+				diffs = append(diffs, codersdk.TemplateVersionItemDiff{
+					Name:   name,
+					Change: codersdk.TemplateVersionDiffChangeModified,
+					Fields: fields,
+				})
+			}
+		}
+	}
+	return diffs
+}
+
+// diffFields returns the fields whose values differ, sorted by field name. A
+// field missing on one side is compared as empty.
+func diffFields(from, to map[string]string) []codersdk.TemplateVersionFieldDiff {
+	var fields []codersdk.TemplateVersionFieldDiff
+	for _, field := range diffNames(from, to) {
+		if from[field] != to[field] {
+			fields = append(fields, codersdk.TemplateVersionFieldDiff{
+				Field: field,
+				From:  from[field],
+				To:    to[field],
+			})
+		}
+	}
+	return fields
+}
+
+// diffNames returns the sorted union of the keys of both maps.
+func diffNames[V any](from, to map[string]V) []string {
+	names := slices.Collect(maps.Keys(from))
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,165 @@
+package coderd
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func TestDiffTemplateVersions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Files", func(t *testing.T) {
+		t.Parallel()
+
+		diffs := diffTemplateVersionFiles(map[string][]byte{
+			"main.tf":    []byte("a\nb\n"),
+			"same.txt":   []byte("same"),
+			"removed.tf": []byte("gone\n"),
+			"image.png":  {0x89, 0x50, 0x00},
+		}, map[string][]byte{
+			"main.tf":   []byte("a\nc\n"),
+			"same.txt":  []byte("same"),
+			"added.tf":  []byte("new\n"),
+			"image.png": {0x89, 0x50, 0x01},
+		})
+		require.Equal(t, []codersdk.TemplateVersionFileDiff{
+			{
+				Path:   "added.tf",
+				Change: codersdk.TemplateVersionDiffChangeAdded,
+				Diff:   "--- /dev/null\n+++ b/added.tf\n@@ -0,0 +1,1 @@\n+new\n",
+			},
+			{
+				Path:   "image.png",
+				Change: codersdk.TemplateVersionDiffChangeModified,
+				Binary: true,
+			},
+			{
+				Path:   "main.tf",
+				Change: codersdk.TemplateVersionDiffChangeModified,
+				Diff:   "--- a/main.tf\n+++ b/main.tf\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+			},
+			{
+				Path:   "removed.tf",
+				Change: codersdk.TemplateVersionDiffChangeRemoved,
+				Diff:   "--- a/removed.tf\n+++ /dev/null\n@@ -1,1 +0,0 @@\n-gone\n",
+			},
+		}, diffs)
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		t.Parallel()
+
+		diffs := diffTemplateVersionParameters([]codersdk.TemplateVersionParameter{
+			{Name: "same", Type: "string"},
+			{Name: "removed", Type: "string"},
+			{
+				Name:          "region",
+				Type:          "string",
+				DefaultValue:  "us",
+				ValidationMin: ptr.Ref(int32(1)),
+				Options: []codersdk.TemplateVersionParameterOption{
+					{Name: "US", Value: "us"},
+					{Name: "Asia", Value: "ap"},
+				},
+			},
+		}, []codersdk.TemplateVersionParameter{
+			{Name: "same", Type: "string"},
+			{Name: "added", Type: "number"},
+			{
+				Name:         "region",
+				Type:         "string",
+				DefaultValue: "eu",
+				Options: []codersdk.TemplateVersionParameterOption{
+					{Name: "United States", Value: "us"},
+					{Name: "Europe", Value: "eu"},
+				},
+			},
+		})
+		require.Equal(t, []codersdk.TemplateVersionParameterDiff{
+			{Name: "added", Change: codersdk.TemplateVersionDiffChangeAdded},
+			{
+				Name:   "region",
+				Change: codersdk.TemplateVersionDiffChangeModified,
+				Fields: []codersdk.TemplateVersionFieldDiff{
+					{Field: "default_value", From: "us", To: "eu"},
+					{Field: `options["us"].name`, From: "US", To: "United States"},
+					{Field: "validation_min", From: "1", To: ""},
+				},
+				OptionsAdded:   []string{"eu"},
+				OptionsRemoved: []string{"ap"},
+			},
+			{Name: "removed", Change: codersdk.TemplateVersionDiffChangeRemoved},
+		}, diffs)
+	})
+
+	t.Run("Variables", func(t *testing.T) {
+		t.Parallel()
+
+		diffs := diffTemplateVersionVariables([]codersdk.TemplateVersionVariable{
+			{Name: "token", Type: "string", Value: redacted, Sensitive: true},
+			{Name: "size", Type: "number", DefaultValue: "1"},
+		}, []codersdk.TemplateVersionVariable{
+			{Name: "token", Type: "string", Value: redacted, Sensitive: true},
+			{Name: "size", Type: "number", DefaultValue: "2"},
+			{Name: "image", Type: "string"},
+		})
+		require.Equal(t, []codersdk.TemplateVersionItemDiff{
+			{Name: "image", Change: codersdk.TemplateVersionDiffChangeAdded},
+			{
+				Name:   "size",
+				Change: codersdk.TemplateVersionDiffChangeModified,
+				Fields: []codersdk.TemplateVersionFieldDiff{{Field: "default_value", From: "1", To: "2"}},
+			},
+		}, diffs)
+	})
+
+	t.Run("Resources", func(t *testing.T) {
+		t.Parallel()
+
+		containerStart, containerStop, volume := uuid.New(), uuid.New(), uuid.New()
+		from := templateVersionDiffResources([]database.WorkspaceResource{
+			{ID: containerStart, Transition: database.WorkspaceTransitionStart, Type: "docker_container", Name: "workspace"},
+			{ID: volume, Transition: database.WorkspaceTransitionStart, Type: "docker_volume", Name: "home", ModulePath: sql.NullString{String: "module.home", Valid: true}},
+		}, []database.WorkspaceResourceMetadatum{
+			{WorkspaceResourceID: containerStart, Key: "image", Value: sql.NullString{String: "ubuntu:22.04", Valid: true}},
+			{WorkspaceResourceID: containerStart, Key: "password", Value: sql.NullString{String: "secret", Valid: true}, Sensitive: true},
+		}, []database.WorkspaceAgent{
+			{ResourceID: containerStart, Name: "main"},
+		})
+
+		containerStart = uuid.New()
+		to := templateVersionDiffResources([]database.WorkspaceResource{
+			{ID: containerStart, Transition: database.WorkspaceTransitionStart, Type: "docker_container", Name: "workspace"},
+			{ID: containerStop, Transition: database.WorkspaceTransitionStop, Type: "docker_container", Name: "workspace"},
+			{ID: uuid.New(), Transition: database.WorkspaceTransitionStart, Type: "docker_image", Name: "main"},
+		}, []database.WorkspaceResourceMetadatum{
+			{WorkspaceResourceID: containerStart, Key: "image", Value: sql.NullString{String: "ubuntu:24.04", Valid: true}},
+			{WorkspaceResourceID: containerStart, Key: "password", Value: sql.NullString{String: "changed", Valid: true}, Sensitive: true},
+			{WorkspaceResourceID: containerStop, Key: "image", Value: sql.NullString{String: "stopped", Valid: true}},
+		}, []database.WorkspaceAgent{
+			{ResourceID: containerStart, Name: "main"},
+			{ResourceID: containerStart, Name: "sidecar"},
+		})
+
+		require.Equal(t, []codersdk.TemplateVersionItemDiff{
+			{
+				Name:   "docker_container.workspace",
+				Change: codersdk.TemplateVersionDiffChangeModified,
+				Fields: []codersdk.TemplateVersionFieldDiff{
+					{Field: "agents", From: "main", To: "main, sidecar"},
+					{Field: "metadata.image", From: "ubuntu:22.04", To: "ubuntu:24.04"},
+					{Field: "transitions", From: "start", To: "start, stop"},
+				},
+			},
+			{Name: "docker_image.main", Change: codersdk.TemplateVersionDiffChangeAdded},
+			{Name: "module.home.docker_volume.home", Change: codersdk.TemplateVersionDiffChangeRemoved},
+		}, diffTemplateVersionItems(from, to))
+	})
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	tfaddr "github.com/hashicorp/go-terraform-address"
 	"github.com/sqlc-dev/pqtype"
 	"github.com/zclconf/go-cty/cty"
 	"golang.org/x/xerrors"
@@ -484,21 +485,6 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	job, err := api.Database.GetProvisionerJobByID(ctx, templateVersion.JobID)
-	if err != nil {
-		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
-			Message: "Internal error updating provisioner job.",
-			Detail:  err.Error(),
-		})
-		return
-	}
-	if !job.CompletedAt.Valid {
-		httpapi.Write(ctx, rw, http.StatusTooEarly, codersdk.Response{
-			Message: "Template version import job hasn't completed!",
-		})
-		return
-	}
-
 	richParameterValues := make([]database.WorkspaceBuildParameter, len(req.RichParameterValues))
 	for i, v := range req.RichParameterValues {
 		richParameterValues[i] = database.WorkspaceBuildParameter{
@@ -510,17 +496,52 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 
 	// Marshal template version dry-run job with the parameters from the
 	// request.
-	input, err := json.Marshal(provisionerdserver.TemplateVersionDryRunJob{
+	provisionerJob, ok := api.createTemplateVersionDryRunJob(rw, r, templateVersion, provisionerdserver.TemplateVersionDryRunJob{
 		TemplateVersionID:   templateVersion.ID,
 		WorkspaceName:       req.WorkspaceName,
 		RichParameterValues: richParameterValues,
 	})
+	if !ok {
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertProvisionerJob(database.GetProvisionerJobsByIDsWithQueuePositionRow{
+		ProvisionerJob: provisionerJob,
+		QueuePosition:  0,
+	}))
+}
+
+// createTemplateVersionDryRunJob inserts a dry-run job for the template
+// version and notifies provisioners about it. It writes an error response
+// and returns false on failure.
+func (api *API) createTemplateVersionDryRunJob(rw http.ResponseWriter, r *http.Request, templateVersion database.TemplateVersion, dryRun provisionerdserver.TemplateVersionDryRunJob) (database.ProvisionerJob, bool) {
+	var (
+		ctx    = r.Context()
+		apiKey = httpmw.APIKey(r)
+	)
+
+	job, err := api.Database.GetProvisionerJobByID(ctx, templateVersion.JobID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating provisioner job.",
+			Detail:  err.Error(),
+		})
+		return database.ProvisionerJob{}, false
+	}
+	if !job.CompletedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusTooEarly, codersdk.Response{
+			Message: "Template version import job hasn't completed!",
+		})
+		return database.ProvisionerJob{}, false
+	}
+
+	input, err := json.Marshal(dryRun)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
 			Message: "Internal error unmarshalling provisioner job.",
 			Detail:  err.Error(),
 		})
-		return
+		return database.ProvisionerJob{}, false
 	}
 
 	metadataRaw, err := json.Marshal(tracing.MetadataFromContext(ctx))
@@ -529,7 +550,7 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 			Message: "Internal error unmarshalling metadata.",
 			Detail:  err.Error(),
 		})
-		return
+		return database.ProvisionerJob{}, false
 	}
 
 	// Create a dry-run job
@@ -558,7 +579,7 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 			Message: "Internal error inserting provisioner job.",
 			Detail:  err.Error(),
 		})
-		return
+		return database.ProvisionerJob{}, false
 	}
 	err = provisionerjobs.PostJob(api.Pubsub, provisionerJob)
 	if err != nil {
@@ -566,10 +587,7 @@ func (api *API) postTemplateVersionDryRun(rw http.ResponseWriter, r *http.Reques
 		api.Logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
 	}
 
-	httpapi.Write(ctx, rw, http.StatusCreated, convertProvisionerJob(database.GetProvisionerJobsByIDsWithQueuePositionRow{
-		ProvisionerJob: provisionerJob,
-		QueuePosition:  0,
-	}))
+	return provisionerJob, true
 }
 
 // @Summary Get template version dry-run by job ID
@@ -646,6 +664,107 @@ func (api *API) templateVersionDryRunResources(rw http.ResponseWriter, r *http.R
 	api.provisionerJobResources(rw, r, job.ProvisionerJob)
 }
 
+// @Summary Get template version dry-run resource changes by job ID
+// @ID get-template-version-dry-run-resource-changes-by-job-id
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param templateversion path string true "Template version ID" format(uuid)
+// @Param jobID path string true "Job ID" format(uuid)
+// @Success 200 {array} codersdk.WorkspaceResourceChange
+// @Router /templateversions/{templateversion}/dry-run/{jobID}/resource-changes [get]
+func (api *API) templateVersionDryRunResourceChanges(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx             = r.Context()
+		templateVersion = httpmw.TemplateVersionParam(r)
+	)
+	job, ok := api.fetchTemplateVersionDryRunJob(rw, r)
+	if !ok {
+		return
+	}
+	if !job.ProvisionerJob.CompletedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Job hasn't completed!",
+		})
+		return
+	}
+
+	changes, err := api.Database.GetProvisionerJobResourceChangesByJobID(ctx, job.ProvisionerJob.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching resource changes.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Resources that exist while a workspace is stopped are persistent. A
+	// resource is checked against the version being planned and, for
+	// previews of an existing workspace, against the version it runs now,
+	// since deleted resources only exist in the latter.
+	versionJobIDs := []uuid.UUID{templateVersion.JobID}
+	var input provisionerdserver.TemplateVersionDryRunJob
+	if err := json.Unmarshal(job.ProvisionerJob.Input, &input); err == nil && input.WorkspaceID.Valid {
+		build, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, input.WorkspaceID.UUID)
+		if err == nil {
+			currentVersion, err := api.Database.GetTemplateVersionByID(ctx, build.TemplateVersionID)
+			if err == nil {
+				versionJobIDs = append(versionJobIDs, currentVersion.JobID)
+			}
+		}
+	}
+	persistent := map[string]struct{}{}
+	for _, jobID := range versionJobIDs {
+		resources, err := api.Database.GetWorkspaceResourcesByJobID(ctx, jobID)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching template version resources.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		for _, resource := range resources {
+			if resource.Transition == database.WorkspaceTransitionStop {
+				persistent[resourceAddress(resource.ModulePath.String, resource.Type, resource.Name)] = struct{}{}
+			}
+		}
+	}
+
+	apiChanges := make([]codersdk.WorkspaceResourceChange, 0, len(changes))
+	for _, change := range changes {
+		_, isPersistent := persistent[resourceChangeAddress(change)]
+		apiChanges = append(apiChanges, codersdk.WorkspaceResourceChange{
+			Address:      change.Address,
+			Type:         change.Type,
+			Name:         change.Name,
+			Action:       codersdk.ResourceChangeAction(change.Action),
+			ReplacePaths: change.ReplacePaths,
+			Persistent:   isPersistent,
+		})
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, apiChanges)
+}
+
+// resourceAddress returns the Terraform address of a resource without its
+// instance index, eg. "module.home.docker_volume.home".
+func resourceAddress(modulePath, typ, name string) string {
+	if modulePath == "" {
+		return typ + "." + name
+	}
+	return modulePath + "." + typ + "." + name
+}
+
+// resourceChangeAddress returns the address of a planned change in the same
+// form as resourceAddress, so both can be compared.
+func resourceChangeAddress(change database.ProvisionerJobResourceChange) string {
+	addr, err := tfaddr.NewAddress(change.Address)
+	if err != nil {
+		return resourceAddress("", change.Type, change.Name)
+	}
+	return resourceAddress(addr.ModulePath.String(), change.Type, change.Name)
+}
+
 // @Summary Get template version dry-run logs by job ID
 // @ID get-template-version-dry-run-logs-by-job-id
 // @Security CoderSessionToken
@@ -1915,6 +2034,166 @@ func (api *API) templateVersionResources(rw http.ResponseWriter, r *http.Request
 	api.provisionerJobResources(rw, r, job)
 }
 
+// templateVersionDiff compares the source files, parameters, variables and
+// planned resources of a template version with those of another one.
+//
+// @Summary Get template version diff
+// @ID get-template-version-diff
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param templateversion path string true "Template version ID" format(uuid)
+// @Param from query string true "Template version ID to compare against" format(uuid)
+// @Success 200 {object} codersdk.TemplateVersionDiff
+// @Router /templateversions/{templateversion}/diff [get]
+func (api *API) templateVersionDiff(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx             = r.Context()
+		templateVersion = httpmw.TemplateVersionParam(r)
+	)
+
+	p := httpapi.NewQueryParamParser().RequiredNotEmpty("from")
+	fromID := p.UUID(r.URL.Query(), uuid.Nil, "from")
+	p.ErrorExcessParams(r.URL.Query())
+	if len(p.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query parameters.",
+			Validations: p.Errors,
+		})
+		return
+	}
+	fromVersion, err := api.Database.GetTemplateVersionByID(ctx, fromID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     fmt.Sprintf("Template version %q does not exist.", fromID),
+			Validations: []codersdk.ValidationError{{Field: "from", Detail: "Template version not found."}},
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	from, ok := api.templateVersionDiffSource(rw, r, fromVersion)
+	if !ok {
+		return
+	}
+	to, ok := api.templateVersionDiffSource(rw, r, templateVersion)
+	if !ok {
+		return
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, diffTemplateVersions(from, to))
+}
+
+// templateVersionDiffSource loads what templateVersionDiff compares of a
+// template version, writing an error response if it cannot.
+func (api *API) templateVersionDiffSource(rw http.ResponseWriter, r *http.Request, templateVersion database.TemplateVersion) (templateVersionDiffSource, bool) {
+	ctx := r.Context()
+
+	job, err := api.Database.GetProvisionerJobByID(ctx, templateVersion.JobID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching provisioner job.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+	if !job.CompletedAt.Valid {
+		httpapi.Write(ctx, rw, http.StatusTooEarly, codersdk.Response{
+			Message: fmt.Sprintf("Template version %q job has not finished.", templateVersion.Name),
+		})
+		return templateVersionDiffSource{}, false
+	}
+
+	fsys, err := api.FileCache.Acquire(ctx, api.Database, job.FileID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version source.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+	defer fsys.Close()
+	files, err := readTemplateVersionFiles(fsys)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error reading template version source.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+
+	dbParameters, err := api.Database.GetTemplateVersionParameters(ctx, templateVersion.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version parameters.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+	parameters, err := db2sdk.TemplateVersionParameters(dbParameters)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error converting template version parameter.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+
+	variables, err := api.Database.GetTemplateVersionVariables(ctx, templateVersion.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version variables.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+
+	// nolint:gocritic // GetWorkspaceResourcesByJobID is a system function.
+	resources, err := api.Database.GetWorkspaceResourcesByJobID(dbauthz.AsSystemRestricted(ctx), job.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching job resources.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+	resourceIDs := make([]uuid.UUID, 0, len(resources))
+	for _, resource := range resources {
+		resourceIDs = append(resourceIDs, resource.ID)
+	}
+	// nolint:gocritic // GetWorkspaceResourceMetadataByResourceIDs is a system function.
+	metadata, err := api.Database.GetWorkspaceResourceMetadataByResourceIDs(dbauthz.AsSystemRestricted(ctx), resourceIDs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching job resource metadata.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+	// nolint:gocritic // GetWorkspaceAgentsByResourceIDs is a system function.
+	agents, err := api.Database.GetWorkspaceAgentsByResourceIDs(dbauthz.AsSystemRestricted(ctx), resourceIDs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace agents.",
+			Detail:  err.Error(),
+		})
+		return templateVersionDiffSource{}, false
+	}
+
+	return templateVersionDiffSource{
+		id:         templateVersion.ID,
+		files:      files,
+		parameters: parameters,
+		variables:  convertTemplateVersionVariables(variables),
+		resources:  templateVersionDiffResources(resources, metadata, agents),
+	}, true
+}
+
 // templateVersionLogs returns the logs returned by the provisioner for the given
 // template version. These logs are only associated with the template version,
 // and not any build logs for a workspace.
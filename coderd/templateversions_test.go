@@ -953,6 +953,74 @@ func TestTemplateVersionResources(t *testing.T) {
 	})
 }
 
+func TestTemplateVersionDiff(t *testing.T) {
+	t.Parallel()
+
+	responses := func(parameters []*proto.RichParameter, resources []*proto.Resource) *echo.Responses {
+		return &echo.Responses{
+			Parse:         echo.ParseComplete,
+			ProvisionPlan: echo.PlanComplete,
+			ProvisionGraph: []*proto.Response{{
+				Type: &proto.Response_Graph{
+					Graph: &proto.GraphComplete{
+						Parameters: parameters,
+						Resources:  resources,
+					},
+				},
+			}},
+			ProvisionApply: echo.ApplyComplete,
+		}
+	}
+
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	user := coderdtest.CreateFirstUser(t, client)
+	from := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, responses(
+		[]*proto.RichParameter{{Name: "region", Type: "string", DefaultValue: "us"}},
+		[]*proto.Resource{{Name: "workspace", Type: "docker_container"}},
+	))
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, from.ID)
+	template := coderdtest.CreateTemplate(t, client, user.OrganizationID, from.ID)
+	to := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, responses(
+		[]*proto.RichParameter{{Name: "region", Type: "string", DefaultValue: "eu"}, {Name: "size", Type: "number", DefaultValue: "1"}},
+		[]*proto.Resource{{Name: "workspace", Type: "docker_container"}, {Name: "home", Type: "docker_volume"}},
+	), func(req *codersdk.CreateTemplateVersionRequest) {
+		req.TemplateID = template.ID
+	})
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, to.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	diff, err := client.TemplateVersionDiff(ctx, from.ID, to.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.ID, diff.FromID)
+	require.Equal(t, to.ID, diff.ToID)
+	require.NotEmpty(t, diff.Files)
+	require.Equal(t, []codersdk.TemplateVersionParameterDiff{
+		{
+			Name:   "region",
+			Change: codersdk.TemplateVersionDiffChangeModified,
+			Fields: []codersdk.TemplateVersionFieldDiff{{Field: "default_value", From: "us", To: "eu"}},
+		},
+		{Name: "size", Change: codersdk.TemplateVersionDiffChangeAdded},
+	}, diff.Parameters)
+	require.Empty(t, diff.Variables)
+	require.Equal(t, []codersdk.TemplateVersionItemDiff{
+		{Name: "docker_volume.home", Change: codersdk.TemplateVersionDiffChangeAdded},
+	}, diff.Resources)
+
+	// A version compared with itself has no changes.
+	diff, err = client.TemplateVersionDiff(ctx, to.ID, to.ID)
+	require.NoError(t, err)
+	require.Empty(t, diff.Files)
+	require.Empty(t, diff.Parameters)
+	require.Empty(t, diff.Resources)
+
+	_, err = client.TemplateVersionDiff(ctx, uuid.New(), to.ID)
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+}
+
 func TestTemplateVersionLogs(t *testing.T) {
 	t.Parallel()
 	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
@@ -535,6 +535,10 @@ func (api *API) enqueueAITaskStateNotification(
 // @Param follow query bool false "Follow log stream"
 // @Param no_compression query bool false "Disable compression for WebSocket connection"
 // @Param format query string false "Log output format. Accepted: 'json' (default), 'text' (plain text with RFC3339 timestamps and ANSI colors). Not supported with follow=true." Enums(json,text)
+// @Param source query []string false "Only return logs from these log source IDs" collectionFormat(csv)
+// @Param level query []string false "Only return logs with these levels: trace, debug, info, warn or error" collectionFormat(csv)
+// @Param q query string false "Only return logs whose output contains this text, case-insensitive"
+// @Param since query string false "Only return logs created at or after this time" format(date-time)
 // @Success 200 {array} codersdk.WorkspaceAgentLog
 // @Router /workspaceagents/{workspaceagent}/logs [get]
 func (api *API) workspaceAgentLogs(rw http.ResponseWriter, r *http.Request) {
@@ -586,10 +590,15 @@ func (api *API) workspaceAgentLogs(rw http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	logs, err := api.Database.GetWorkspaceAgentLogsAfter(ctx, database.GetWorkspaceAgentLogsAfterParams{
-		AgentID:      waws.WorkspaceAgent.ID,
-		CreatedAfter: after,
-	})
+	// Filtering on the server means large logs aren't sent in full to
+	// clients that only want to tail some of them.
+	params, ok := parseWorkspaceAgentLogsFilter(rw, r, waws.WorkspaceAgent.ID)
+	if !ok {
+		return
+	}
+	params.CreatedAfter = after
+
+	logs, err := api.Database.GetWorkspaceAgentLogsAfter(ctx, params)
 	if errors.Is(err, sql.ErrNoRows) {
 		err = nil
 	}
@@ -783,10 +792,8 @@ func (api *API) workspaceAgentLogs(rw http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
-			logs, err := api.Database.GetWorkspaceAgentLogsAfter(ctx, database.GetWorkspaceAgentLogsAfterParams{
-				AgentID:      waws.WorkspaceAgent.ID,
-				CreatedAfter: lastSentLogID,
-			})
+			params.CreatedAfter = lastSentLogID
+			logs, err := api.Database.GetWorkspaceAgentLogsAfter(ctx, params)
 			if err != nil {
 				if xerrors.Is(err, context.Canceled) {
 					return
@@ -837,6 +844,32 @@ func (api *API) workspaceAgentLogs(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// parseWorkspaceAgentLogsFilter parses the log filter from the query
+// parameters of the request into the parameters to fetch the logs of the
+// agent. It writes an error response and returns false if any are invalid.
+func parseWorkspaceAgentLogsFilter(rw http.ResponseWriter, r *http.Request, agentID uuid.UUID) (database.GetWorkspaceAgentLogsAfterParams, bool) {
+	var (
+		ctx    = r.Context()
+		values = r.URL.Query()
+		parser = httpapi.NewQueryParamParser()
+	)
+	params := database.GetWorkspaceAgentLogsAfterParams{
+		AgentID:        agentID,
+		LogSourceIds:   parser.UUIDs(values, []uuid.UUID{}, "source"),
+		Levels:         httpapi.ParseCustomList(parser, values, []database.LogLevel{}, "level", httpapi.ParseEnum[database.LogLevel]),
+		Search:         parser.String(values, "", "q"),
+		CreatedAtSince: parser.Time3339Nano(values, time.Time{}, "since"),
+	}
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query parameters.",
+			Validations: parser.Errors,
+		})
+		return database.GetWorkspaceAgentLogsAfterParams{}, false
+	}
+	return params, true
+}
+
 // @Summary Get listening ports for workspace agent
 // @ID get-listening-ports-for-workspace-agent
 // @Security CoderSessionToken
@@ -1709,6 +1742,8 @@ func convertScripts(dbScripts []database.WorkspaceAgentScript) []codersdk.Worksp
 			StartBlocksLogin: dbScript.StartBlocksLogin,
 			Timeout:          time.Duration(dbScript.TimeoutSeconds) * time.Second,
 			DisplayName:      dbScript.DisplayName,
+			UnitName:         dbScript.UnitName,
+			DependsOn:        dbScript.DependsOnUnits(),
 		})
 	}
 	return scripts
@@ -300,6 +300,103 @@ func TestWorkspaceAgentLogs(t *testing.T) {
 		case <-logs:
 		}
 	})
+	t.Run("Filter", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		client, db := coderdtest.NewWithDatabase(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		r := dbfake.WorkspaceBuild(t, db, database.WorkspaceTable{
+			OrganizationID: user.OrganizationID,
+			OwnerID:        user.UserID,
+		}).WithAgent().Do()
+		agentID := r.Agents[0].ID
+
+		startupSourceID, dotfilesSourceID := uuid.New(), uuid.New()
+		for _, log := range []database.WorkspaceAgentLog{
+			{LogSourceID: startupSourceID, Level: database.LogLevelInfo, Output: "cloning repository"},
+			{LogSourceID: startupSourceID, Level: database.LogLevelError, Output: "clone failed: permission denied"},
+			{LogSourceID: dotfilesSourceID, Level: database.LogLevelInfo, Output: "installing dotfiles"},
+		} {
+			log.AgentID = agentID
+			_ = dbgen.WorkspaceAgentLog(t, db, log)
+		}
+
+		outputs := func(logs []codersdk.WorkspaceAgentLog) []string {
+			out := make([]string, 0, len(logs))
+			for _, log := range logs {
+				out = append(out, log.Output)
+			}
+			return out
+		}
+		for _, tc := range []struct {
+			name     string
+			filter   codersdk.WorkspaceAgentLogsFilter
+			expected []string
+		}{
+			{
+				name:     "Source",
+				filter:   codersdk.WorkspaceAgentLogsFilter{SourceIDs: []uuid.UUID{startupSourceID}},
+				expected: []string{"cloning repository", "clone failed: permission denied"},
+			},
+			{
+				name:     "Level",
+				filter:   codersdk.WorkspaceAgentLogsFilter{Levels: []codersdk.LogLevel{codersdk.LogLevelError}},
+				expected: []string{"clone failed: permission denied"},
+			},
+			{
+				name:     "Search",
+				filter:   codersdk.WorkspaceAgentLogsFilter{Search: "DOTFILES"},
+				expected: []string{"installing dotfiles"},
+			},
+		} {
+			logs, closer, err := client.WorkspaceAgentLogsAfterWithFilter(ctx, agentID, 0, false, tc.filter)
+			require.NoError(t, err, tc.name)
+			require.Equal(t, tc.expected, outputs(<-logs), tc.name)
+			_ = closer.Close()
+		}
+
+		// Followed logs are filtered as well.
+		logs, closer, err := client.WorkspaceAgentLogsAfterWithFilter(ctx, agentID, 0, true, codersdk.WorkspaceAgentLogsFilter{
+			SourceIDs: []uuid.UUID{dotfilesSourceID},
+		})
+		require.NoError(t, err)
+		defer func() {
+			_ = closer.Close()
+		}()
+		var logChunk []codersdk.WorkspaceAgentLog
+		select {
+		case <-ctx.Done():
+		case logChunk = <-logs:
+		}
+		require.NoError(t, ctx.Err())
+		require.Equal(t, []string{"installing dotfiles"}, outputs(logChunk))
+
+		agentClient := agentsdk.New(client.URL, agentsdk.WithFixedToken(r.AgentToken))
+		for sourceID, output := range map[uuid.UUID]string{
+			startupSourceID:  "retrying clone",
+			dotfilesSourceID: "dotfiles installed",
+		} {
+			err = agentClient.PatchLogs(ctx, agentsdk.PatchLogs{
+				LogSourceID: sourceID,
+				Logs:        []agentsdk.Log{{CreatedAt: dbtime.Now(), Output: output}},
+			})
+			require.NoError(t, err)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				require.FailNow(t, "context done while waiting for followed logs")
+			case logChunk = <-logs:
+			}
+			// Each patch notifies the stream, but only matching logs are sent.
+			for _, log := range logChunk {
+				require.Equal(t, "dotfiles installed", log.Output)
+			}
+			if len(logChunk) > 0 {
+				break
+			}
+		}
+	})
 	t.Run("PublishesOnOverflow", func(t *testing.T) {
 		t.Parallel()
 		ctx := testutil.Context(t, testutil.WaitMedium)
@@ -3096,7 +3193,7 @@ func requireGetManifest(ctx context.Context, t testing.TB, aAPI agentproto.DRPCA
 }
 
 func postStartup(ctx context.Context, t testing.TB, client agent.Client, startup *agentproto.Startup) error {
-	aAPI, _, err := client.ConnectRPC28(ctx)
+	aAPI, _, err := client.ConnectRPC210(ctx)
 	require.NoError(t, err)
 	defer func() {
 		cErr := aAPI.DRPCConn().Close()
@@ -741,6 +741,7 @@ func (s *Server) workspaceAgentPTY(rw http.ResponseWriter, r *http.Request) {
 	container := parser.String(values, "", "container")
 	containerUser := parser.String(values, "", "container_user")
 	backendType := parser.String(values, "", "backend_type")
+	readOnly := parser.Boolean(values, false, "read_only")
 	if len(parser.Errors) > 0 {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message:     "Invalid query parameters.",
@@ -783,6 +784,7 @@ func (s *Server) workspaceAgentPTY(rw http.ResponseWriter, r *http.Request) {
 		arp.Container = container
 		arp.ContainerUser = containerUser
 		arp.BackendType = backendType
+		arp.ReadOnly = readOnly
 	})
 	if err != nil {
 		log.Debug(ctx, "dial reconnecting pty server in workspace agent", slog.Error(err))
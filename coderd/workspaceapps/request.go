@@ -268,7 +268,7 @@ func (r Request) getDatabase(ctx context.Context, db database.Store) (*databaseR
 	if err != nil {
 		return nil, xerrors.Errorf("get latest workspace build: %w", err)
 	}
-	if build.Transition == database.WorkspaceTransitionStop {
+	if build.Transition == database.WorkspaceTransitionStop || build.Transition == database.WorkspaceTransitionHibernate {
 		return nil, errWorkspaceStopped
 	}
 	if len(agents) == 0 {
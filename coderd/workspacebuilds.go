@@ -361,6 +361,128 @@ func (api *API) postWorkspaceBuilds(rw http.ResponseWriter, r *http.Request) {
 	httpapi.Write(ctx, rw, http.StatusCreated, apiBuild)
 }
 
+// @Summary Create workspace dry-run
+// @Description Plans a start of the workspace without applying it, to preview
+// @Description the changes to its resources. The returned job is a template
+// @Description version dry-run; its logs and resource changes are read with the
+// @Description template version dry-run endpoints.
+// @ID create-workspace-dry-run
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Builds
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.CreateWorkspaceDryRunRequest true "Dry-run request"
+// @Success 201 {object} codersdk.ProvisionerJob
+// @Router /workspaces/{workspace}/dry-run [post]
+func (api *API) postWorkspaceDryRun(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspace := httpmw.WorkspaceParam(r)
+
+	// Previewing a build is allowed for anyone who may start the workspace.
+	if !api.Authorize(r, policy.ActionWorkspaceStart, workspace) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	var req codersdk.CreateWorkspaceDryRunRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if workspace.Deleted {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "Cannot preview a build of a deleted workspace!",
+		})
+		return
+	}
+
+	versionID := req.TemplateVersionID
+	if versionID == uuid.Nil {
+		template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching template.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		versionID = template.ActiveVersionID
+	}
+	templateVersion, err := api.Database.GetTemplateVersionByID(ctx, versionID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("Template version %q not found.", versionID),
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if templateVersion.TemplateID.UUID != workspace.TemplateID {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Template version must belong to the template of the workspace.",
+		})
+		return
+	}
+
+	// Plan with the parameters of the latest build, overridden by the ones
+	// from the request, like a build would.
+	latestBuild, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching latest workspace build.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	lastParameters, err := api.Database.GetWorkspaceBuildParameters(ctx, latestBuild.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace build parameters.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	richParameterValues := make([]database.WorkspaceBuildParameter, 0, len(lastParameters)+len(req.RichParameterValues))
+	for _, p := range lastParameters {
+		if slices.ContainsFunc(req.RichParameterValues, func(v codersdk.WorkspaceBuildParameter) bool {
+			return v.Name == p.Name
+		}) {
+			continue
+		}
+		richParameterValues = append(richParameterValues, database.WorkspaceBuildParameter{
+			Name:  p.Name,
+			Value: p.Value,
+		})
+	}
+	for _, v := range req.RichParameterValues {
+		richParameterValues = append(richParameterValues, database.WorkspaceBuildParameter{
+			Name:  v.Name,
+			Value: v.Value,
+		})
+	}
+
+	provisionerJob, ok := api.createTemplateVersionDryRunJob(rw, r, templateVersion, provisionerdserver.TemplateVersionDryRunJob{
+		TemplateVersionID:   templateVersion.ID,
+		WorkspaceName:       workspace.Name,
+		RichParameterValues: richParameterValues,
+		WorkspaceID:         uuid.NullUUID{UUID: workspace.ID, Valid: true},
+	})
+	if !ok {
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertProvisionerJob(database.GetProvisionerJobsByIDsWithQueuePositionRow{
+		ProvisionerJob: provisionerJob,
+		QueuePosition:  0,
+	}))
+}
+
 // postWorkspaceBuildsInternal handles the internal logic for creating
 // workspace builds, can be called by other handlers and must not
 // reference httpmw.
@@ -385,7 +507,7 @@ func (api *API) postWorkspaceBuildsInternal(
 		TemplateVersionPresetID(createBuild.TemplateVersionPresetID).
 		BuildMetrics(api.WorkspaceBuilderMetrics)
 
-	if (transition == database.WorkspaceTransitionStart || transition == database.WorkspaceTransitionStop) && createBuild.Reason != "" {
+	if (transition == database.WorkspaceTransitionStart || transition == database.WorkspaceTransitionStop || transition == database.WorkspaceTransitionHibernate) && createBuild.Reason != "" {
 		builder = builder.Reason(database.BuildReason(createBuild.Reason))
 	}
 
@@ -828,6 +950,10 @@ func (api *API) workspaceBuildParameters(rw http.ResponseWriter, r *http.Request
 // @Param after query int false "After log id"
 // @Param follow query bool false "Follow log stream"
 // @Param format query string false "Log output format. Accepted: 'json' (default), 'text' (plain text with RFC3339 timestamps and ANSI colors). Not supported with follow=true." Enums(json,text)
+// @Param stage query []string false "Only return logs from these stages" collectionFormat(csv)
+// @Param level query []string false "Only return logs with these levels: trace, debug, info, warn or error" collectionFormat(csv)
+// @Param q query string false "Only return logs whose output contains this text, case-insensitive"
+// @Param since query string false "Only return logs created at or after this time" format(date-time)
 // @Success 200 {array} codersdk.ProvisionerJobLog
 // @Router /workspacebuilds/{workspacebuild}/logs [get]
 func (api *API) workspaceBuildLogs(rw http.ResponseWriter, r *http.Request) {
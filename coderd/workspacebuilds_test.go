@@ -1184,6 +1184,100 @@ func TestWorkspaceBuildLogsFormat(t *testing.T) {
 	}
 }
 
+func TestWorkspaceBuildLogsFilter(t *testing.T) {
+	t.Parallel()
+
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	user := coderdtest.CreateFirstUser(t, client)
+
+	r := dbfake.WorkspaceBuild(t, db, database.WorkspaceTable{
+		OrganizationID: user.OrganizationID,
+		OwnerID:        user.UserID,
+	}).Do()
+
+	now := dbtime.Now()
+	for _, log := range []database.ProvisionerJobLog{
+		{Stage: "Planning", Level: database.LogLevelInfo, Output: "planning docker_volume.home", CreatedAt: now.Add(-time.Hour)},
+		{Stage: "Planning", Level: database.LogLevelWarn, Output: "deprecated attribute", CreatedAt: now.Add(-time.Hour)},
+		{Stage: "Applying", Level: database.LogLevelInfo, Output: "Creating docker_volume.home", CreatedAt: now},
+		{Stage: "Applying", Level: database.LogLevelError, Output: "failed to pull image", CreatedAt: now},
+	} {
+		log.JobID = r.Build.JobID
+		log.Source = database.LogSourceProvisioner
+		_ = dbgen.ProvisionerJobLog(t, db, log)
+	}
+
+	outputs := func(logs []codersdk.ProvisionerJobLog) []string {
+		out := make([]string, 0, len(logs))
+		for _, log := range logs {
+			out = append(out, log.Output)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		filter   codersdk.ProvisionerJobLogsFilter
+		expected []string
+	}{
+		{
+			name:     "None",
+			expected: []string{"planning docker_volume.home", "deprecated attribute", "Creating docker_volume.home", "failed to pull image"},
+		},
+		{
+			name:     "Stage",
+			filter:   codersdk.ProvisionerJobLogsFilter{Stages: []string{"Applying"}},
+			expected: []string{"Creating docker_volume.home", "failed to pull image"},
+		},
+		{
+			name:     "Levels",
+			filter:   codersdk.ProvisionerJobLogsFilter{Levels: []codersdk.LogLevel{codersdk.LogLevelWarn, codersdk.LogLevelError}},
+			expected: []string{"deprecated attribute", "failed to pull image"},
+		},
+		{
+			name:     "Search",
+			filter:   codersdk.ProvisionerJobLogsFilter{Search: "DOCKER_VOLUME"},
+			expected: []string{"planning docker_volume.home", "Creating docker_volume.home"},
+		},
+		{
+			name:     "Since",
+			filter:   codersdk.ProvisionerJobLogsFilter{Since: now.Add(-time.Minute)},
+			expected: []string{"Creating docker_volume.home", "failed to pull image"},
+		},
+		{
+			name: "Combined",
+			filter: codersdk.ProvisionerJobLogsFilter{
+				Stages: []string{"Planning"},
+				Search: "docker",
+			},
+			expected: []string{"planning docker_volume.home"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.Context(t, testutil.WaitLong)
+
+			logs, err := client.WorkspaceBuildLogs(ctx, r.Build.ID, tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, outputs(logs))
+		})
+	}
+
+	t.Run("InvalidLevel", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.WorkspaceBuildLogs(ctx, r.Build.ID, codersdk.ProvisionerJobLogsFilter{
+			Levels: []codersdk.LogLevel{"loud"},
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
+
 func TestWorkspaceBuildState(t *testing.T) {
 	t.Parallel()
 	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
@@ -2016,6 +2110,125 @@ func TestPostWorkspaceBuild(t *testing.T) {
 	})
 }
 
+func TestPostWorkspaceDryRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+			Parse: echo.ParseComplete,
+			ProvisionGraph: []*proto.Response{{
+				Type: &proto.Response_Graph{
+					Graph: &proto.GraphComplete{
+						Resources: []*proto.Resource{{
+							Name: "home",
+							Type: "docker_volume",
+						}, {
+							Name:       "cache",
+							Type:       "docker_volume",
+							ModulePath: "module.data",
+						}},
+					},
+				},
+			}},
+			ProvisionPlan: []*proto.Response{{
+				Type: &proto.Response_Plan{
+					Plan: &proto.PlanComplete{
+						ResourceChanges: []*proto.ResourceChange{{
+							Address:      "docker_volume.home",
+							Type:         "docker_volume",
+							Name:         "home",
+							Action:       "replace",
+							ReplacePaths: []string{"name"},
+						}, {
+							Address: "docker_container.workspace",
+							Type:    "docker_container",
+							Name:    "workspace",
+							Action:  "create",
+						}, {
+							// Shares its type and name with a persistent
+							// resource, but lives in a module.
+							Address: "module.scratch.docker_volume.home",
+							Type:    "docker_volume",
+							Name:    "home",
+							Action:  "delete",
+						}, {
+							Address: "module.data.docker_volume.cache[0]",
+							Type:    "docker_volume",
+							Name:    "cache",
+							Action:  "update",
+						}},
+					},
+				},
+			}},
+			ProvisionApply: echo.ApplyComplete,
+		})
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		job, err := client.CreateWorkspaceDryRun(ctx, workspace.ID, codersdk.CreateWorkspaceDryRunRequest{})
+		require.NoError(t, err)
+		require.Equal(t, codersdk.ProvisionerJobTypeTemplateVersionDryRun, job.Type)
+		require.NotNil(t, job.Input.TemplateVersionID)
+		require.Equal(t, version.ID, *job.Input.TemplateVersionID)
+
+		require.Eventually(t, func() bool {
+			job, err = client.TemplateVersionDryRun(ctx, version.ID, job.ID)
+			return assert.NoError(t, err) && job.Status == codersdk.ProvisionerJobSucceeded
+		}, testutil.WaitLong, testutil.IntervalFast)
+
+		changes, err := client.TemplateVersionDryRunResourceChanges(ctx, version.ID, job.ID)
+		require.NoError(t, err)
+		require.Len(t, changes, 4)
+		require.Equal(t, "docker_container.workspace", changes[0].Address)
+		require.Equal(t, codersdk.ResourceChangeActionCreate, changes[0].Action)
+		require.False(t, changes[0].Persistent)
+		require.Equal(t, "docker_volume.home", changes[1].Address)
+		require.Equal(t, codersdk.ResourceChangeActionReplace, changes[1].Action)
+		require.Equal(t, []string{"name"}, changes[1].ReplacePaths)
+		require.True(t, changes[1].Persistent)
+		require.Equal(t, "module.data.docker_volume.cache[0]", changes[2].Address)
+		require.True(t, changes[2].Persistent)
+		require.Equal(t, "module.scratch.docker_volume.home", changes[3].Address)
+		require.False(t, changes[3].Persistent)
+
+		// The dry-run must not create a build.
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), workspace.LatestBuild.BuildNumber)
+	})
+
+	t.Run("VersionFromOtherTemplate", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+		otherVersion := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJobCompleted(t, client, otherVersion.ID)
+		coderdtest.CreateTemplate(t, client, user.OrganizationID, otherVersion.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.CreateWorkspaceDryRun(ctx, workspace.ID, codersdk.CreateWorkspaceDryRunRequest{
+			TemplateVersionID: otherVersion.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
+
 func TestWorkspaceBuildTimings(t *testing.T) {
 	t.Parallel()
 
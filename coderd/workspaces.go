@@ -597,7 +597,7 @@ func createWorkspace(
 		})
 	}
 
-	templateSchedule, err := (*api.TemplateScheduleStore.Load()).Get(ctx, api.Database, template.ID)
+	templateSchedule, err := (*api.TemplateScheduleStore.Load()).GetForUser(ctx, api.Database, template.ID, owner.ID)
 	if err != nil {
 		return codersdk.Workspace{}, httperror.NewResponseError(http.StatusInternalServerError, codersdk.Response{
 			Message: "Internal error fetching template schedule.",
@@ -614,6 +614,9 @@ func createWorkspace(
 	}
 
 	dbTTL, err := validWorkspaceTTLMillis(req.TTLMillis, templateSchedule.DefaultTTL)
+	if err == nil {
+		err = validWorkspaceTTLMax(dbTTL, templateSchedule.MaxTTL)
+	}
 	if err != nil {
 		return codersdk.Workspace{}, httperror.NewResponseError(http.StatusBadRequest, codersdk.Response{
 			Message:     "Invalid Workspace Time to Shutdown.",
@@ -633,6 +636,31 @@ func createWorkspace(
 		}
 	}
 
+	var sourceWorkspaceID uuid.NullUUID
+	if req.SourceWorkspaceID != uuid.Nil {
+		// The caller must be able to read the workspace they are cloning.
+		source, err := api.Database.GetWorkspaceByID(ctx, req.SourceWorkspaceID)
+		if httpapi.Is404Error(err) {
+			return codersdk.Workspace{}, httperror.NewResponseError(http.StatusBadRequest, codersdk.Response{
+				Message:     fmt.Sprintf("Source workspace %q does not exist.", req.SourceWorkspaceID),
+				Validations: []codersdk.ValidationError{{Field: "source_workspace_id", Detail: "Workspace not found."}},
+			})
+		}
+		if err != nil {
+			return codersdk.Workspace{}, httperror.NewResponseError(http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching source workspace.",
+				Detail:  err.Error(),
+			})
+		}
+		if source.TemplateID != template.ID {
+			return codersdk.Workspace{}, httperror.NewResponseError(http.StatusBadRequest, codersdk.Response{
+				Message:     "Source workspace uses a different template.",
+				Validations: []codersdk.ValidationError{{Field: "source_workspace_id", Detail: fmt.Sprintf("Workspace %q does not use template %q.", source.Name, template.Name)}},
+			})
+		}
+		sourceWorkspaceID = uuid.NullUUID{UUID: source.ID, Valid: true}
+	}
+
 	// TODO: This should be a system call as the actor might not be able to
 	// read other workspaces. Ideally we check the error on create and look for
 	// a postgres conflict error.
@@ -703,8 +731,9 @@ func createWorkspace(
 			}
 		}
 
-		// Try to claim a prebuilt workspace.
-		if templateVersionPresetID != uuid.Nil {
+		// Try to claim a prebuilt workspace. Clones are always created from
+		// scratch so that their lineage is recorded.
+		if templateVersionPresetID != uuid.Nil && !sourceWorkspaceID.Valid {
 			// Try and claim an eligible prebuild, if available.
 			// On successful claim, initialize all lifecycle fields from template and workspace-level config
 			// so the newly claimed workspace is properly managed by the lifecycle executor.
@@ -752,8 +781,9 @@ func createWorkspace(
 				Ttl:               dbTTL,
 				// The workspaces page will sort by last used at, and it's useful to
 				// have the newly created workspace at the top of the list!
-				LastUsedAt:       now,
-				AutomaticUpdates: dbAU,
+				LastUsedAt:        now,
+				AutomaticUpdates:  dbAU,
+				SourceWorkspaceID: sourceWorkspaceID,
 			})
 			if err != nil {
 				return xerrors.Errorf("insert workspace: %w", err)
@@ -1180,7 +1210,7 @@ func (api *API) putWorkspaceAutostart(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if the template allows users to configure autostart.
-	templateSchedule, err := (*api.TemplateScheduleStore.Load()).Get(ctx, api.Database, workspace.TemplateID)
+	templateSchedule, err := (*api.TemplateScheduleStore.Load()).GetForUser(ctx, api.Database, workspace.TemplateID, workspace.OwnerID)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
 			Message: "Internal error getting template schedule options.",
@@ -1276,7 +1306,7 @@ func (api *API) putWorkspaceTTL(rw http.ResponseWriter, r *http.Request) {
 	var dbTTL sql.NullInt64
 
 	err := api.Database.InTx(func(s database.Store) error {
-		templateSchedule, err := (*api.TemplateScheduleStore.Load()).Get(ctx, s, workspace.TemplateID)
+		templateSchedule, err := (*api.TemplateScheduleStore.Load()).GetForUser(ctx, s, workspace.TemplateID, workspace.OwnerID)
 		if err != nil {
 			return xerrors.Errorf("get template schedule: %w", err)
 		}
@@ -1291,6 +1321,9 @@ func (api *API) putWorkspaceTTL(rw http.ResponseWriter, r *http.Request) {
 		if validityErr != nil {
 			return codersdk.ValidationError{Field: "ttl_ms", Detail: validityErr.Error()}
 		}
+		if err := validWorkspaceTTLMax(dbTTL, templateSchedule.MaxTTL); err != nil {
+			return codersdk.ValidationError{Field: "ttl_ms", Detail: err.Error()}
+		}
 		if err := s.UpdateWorkspaceTTL(ctx, database.UpdateWorkspaceTTLParams{
 			ID:  workspace.ID,
 			Ttl: dbTTL,
@@ -2746,13 +2779,14 @@ func convertWorkspace(
 			Healthy:       len(failingAgents) == 0,
 			FailingAgents: failingAgents,
 		},
-		AutomaticUpdates: codersdk.AutomaticUpdates(workspace.AutomaticUpdates),
-		AllowRenames:     allowRenames,
-		Favorite:         requesterFavorite,
-		NextStartAt:      nextStartAt,
-		IsPrebuild:       workspace.IsPrebuild(),
-		TaskID:           workspace.TaskID,
-		SharedWith:       sharedWorkspaceActors(ctx, experiments, logger, workspace),
+		AutomaticUpdates:  codersdk.AutomaticUpdates(workspace.AutomaticUpdates),
+		AllowRenames:      allowRenames,
+		Favorite:          requesterFavorite,
+		NextStartAt:       nextStartAt,
+		IsPrebuild:        workspace.IsPrebuild(),
+		TaskID:            workspace.TaskID,
+		SharedWith:        sharedWorkspaceActors(ctx, experiments, logger, workspace),
+		SourceWorkspaceID: workspace.SourceWorkspaceID,
 	}, nil
 }
 
@@ -2842,6 +2876,19 @@ func validWorkspaceTTLMillis(millis *int64, templateDefault time.Duration) (sql.
 	}, nil
 }
 
+// validWorkspaceTTLMax returns an error if the TTL exceeds the max TTL that
+// group schedule overrides impose on the workspace owner. A TTL that disables
+// autostop exceeds any max TTL.
+func validWorkspaceTTLMax(ttl sql.NullInt64, maxTTL time.Duration) error {
+	if maxTTL <= 0 {
+		return nil
+	}
+	if !ttl.Valid || time.Duration(ttl.Int64) > maxTTL {
+		return xerrors.Errorf("time until shutdown must be at most %s", maxTTL)
+	}
+	return nil
+}
+
 func validWorkspaceAutomaticUpdates(updates codersdk.AutomaticUpdates) (database.AutomaticUpdates, error) {
 	if updates == "" {
 		return database.AutomaticUpdatesNever, nil
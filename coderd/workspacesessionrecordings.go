@@ -0,0 +1,127 @@
+package coderd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/asciicast"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get workspace session recordings
+// @ID get-workspace-session-recordings
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param limit query int false "Page limit"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} codersdk.WorkspaceSessionRecording
+// @Router /workspaces/{workspace}/session-recordings [get]
+func (api *API) workspaceSessionRecordings(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspace := httpmw.WorkspaceParam(r)
+
+	page, ok := ParsePagination(rw, r)
+	if !ok {
+		return
+	}
+
+	// Recordings are filtered by the permission to read connection logs, so
+	// users that may not see them get an empty list.
+	recordings, err := api.Database.GetWorkspaceSessionRecordingsByWorkspaceID(ctx, database.GetWorkspaceSessionRecordingsByWorkspaceIDParams{
+		WorkspaceID: workspace.ID,
+		OffsetOpt:   int32(page.Offset), //nolint:gosec // Validated by ParsePagination.
+		LimitOpt:    int32(page.Limit),  //nolint:gosec // Validated by ParsePagination.
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session recordings.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	res := make([]codersdk.WorkspaceSessionRecording, 0, len(recordings))
+	for _, rec := range recordings {
+		res = append(res, codersdk.WorkspaceSessionRecording{
+			ID:           rec.ID,
+			WorkspaceID:  rec.WorkspaceID,
+			AgentID:      rec.AgentID,
+			ConnectionID: rec.ConnectionID,
+			Type:         codersdk.ConnectionType(rec.Type),
+			StartedAt:    rec.StartedAt,
+			EndedAt:      rec.EndedAt,
+			CreatedAt:    rec.CreatedAt,
+			IncludeInput: rec.IncludeInput,
+			Truncated:    rec.Truncated,
+			Size:         rec.Size,
+		})
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, res)
+}
+
+// @Summary Download workspace session recording
+// @ID download-workspace-session-recording
+// @Security CoderSessionToken
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param recording path string true "Recording ID" format(uuid)
+// @Success 200
+// @Router /workspaces/{workspace}/session-recordings/{recording} [get]
+func (api *API) workspaceSessionRecording(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspace := httpmw.WorkspaceParam(r)
+
+	recordingID, err := uuid.Parse(chi.URLParam(r, "recording"))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid recording ID.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	recording, err := api.Database.GetWorkspaceSessionRecordingByID(ctx, recordingID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session recording.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if recording.WorkspaceID != workspace.ID {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	chunks, err := api.Database.GetWorkspaceSessionRecordingChunks(ctx, recording.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching session recording data.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.Header().Set("Content-Type", asciicast.MediaType)
+	rw.Header().Set("Content-Length", strconv.FormatInt(recording.Size, 10))
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", recording.ID.String()+".cast"))
+	rw.WriteHeader(http.StatusOK)
+	for _, chunk := range chunks {
+		if _, err := rw.Write(chunk.Data); err != nil {
+			return
+		}
+	}
+}
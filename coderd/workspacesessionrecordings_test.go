@@ -0,0 +1,118 @@
+package coderd_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceSessionRecordings(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	client := coderdtest.New(t, &coderdtest.Options{
+		Database: db,
+		Pubsub:   pubsub,
+	})
+	owner := coderdtest.CreateFirstUser(t, client)
+	memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+	r := dbfake.WorkspaceBuild(t, db, database.WorkspaceTable{
+		OrganizationID: owner.OrganizationID,
+		OwnerID:        member.ID,
+	}).WithAgent().Do()
+
+	now := dbtime.Now()
+	// The older recording was uploaded in two parts.
+	olderParts := [][]byte{
+		[]byte("{\"version\":2,\"width\":80,\"height\":24}\n"),
+		[]byte("[0.1,\"o\",\"hello\\r\\n\"]\n"),
+	}
+	older := dbgen.WorkspaceSessionRecording(t, db, database.WorkspaceSessionRecording{
+		OrganizationID: owner.OrganizationID,
+		WorkspaceID:    r.Workspace.ID,
+		AgentID:        r.Agents[0].ID,
+		StartedAt:      now.Add(-time.Hour),
+	}, olderParts...)
+	newer := dbgen.WorkspaceSessionRecording(t, db, database.WorkspaceSessionRecording{
+		OrganizationID: owner.OrganizationID,
+		WorkspaceID:    r.Workspace.ID,
+		AgentID:        r.Agents[0].ID,
+		Type:           database.ConnectionTypeReconnectingPty,
+		StartedAt:      now.Add(-time.Minute),
+		IncludeInput:   true,
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		recordings, err := client.WorkspaceSessionRecordings(ctx, r.Workspace.ID, codersdk.Pagination{})
+		require.NoError(t, err)
+		require.Len(t, recordings, 2)
+		require.Equal(t, newer.ID, recordings[0].ID)
+		require.Equal(t, codersdk.ConnectionTypeReconnectingPTY, recordings[0].Type)
+		require.True(t, recordings[0].IncludeInput)
+		require.Equal(t, newer.Size, recordings[0].Size)
+		require.Equal(t, older.ID, recordings[1].ID)
+		require.Equal(t, older.ConnectionID, recordings[1].ConnectionID)
+
+		recordings, err = client.WorkspaceSessionRecordings(ctx, r.Workspace.ID, codersdk.Pagination{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, recordings, 1)
+		require.Equal(t, older.ID, recordings[0].ID)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		rc, err := client.WorkspaceSessionRecording(ctx, r.Workspace.ID, older.ID)
+		require.NoError(t, err)
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, bytes.Join(olderParts, nil), data)
+		require.EqualValues(t, older.Size, len(data))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		_, err := client.WorkspaceSessionRecording(ctx, r.Workspace.ID, uuid.New())
+		var sdkErr *codersdk.Error
+		require.ErrorAs(t, err, &sdkErr)
+		require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+	})
+
+	t.Run("MemberCannotRead", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// Recordings are audit data, so the workspace owner may not read
+		// them unless they may read connection logs.
+		recordings, err := memberClient.WorkspaceSessionRecordings(ctx, r.Workspace.ID, codersdk.Pagination{})
+		require.NoError(t, err)
+		require.Empty(t, recordings)
+
+		_, err = memberClient.WorkspaceSessionRecording(ctx, r.Workspace.ID, older.ID)
+		var sdkErr *codersdk.Error
+		require.ErrorAs(t, err, &sdkErr)
+		require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+	})
+}
@@ -171,7 +171,7 @@ func (r *Reporter) ReportAgentStats(ctx context.Context, now time.Time, workspac
 		// check next autostart
 		var nextAutostart time.Time
 		if workspace.AutostartSchedule.String != "" {
-			templateSchedule, err := (*(r.opts.TemplateScheduleStore.Load())).Get(ctx, r.opts.Database, workspace.TemplateID)
+			templateSchedule, err := (*(r.opts.TemplateScheduleStore.Load())).GetForUser(ctx, r.opts.Database, workspace.TemplateID, workspace.OwnerID)
 			// If the template schedule fails to load, just default to bumping
 			// without the next transition and log it.
 			switch {
@@ -1239,7 +1239,7 @@ func (b *Builder) authorize(authFunc func(action policy.Action, object rbac.Obje
 			// workspace.start permission apply.
 			action = policy.ActionUpdate
 		}
-	case database.WorkspaceTransitionStop:
+	case database.WorkspaceTransitionStop, database.WorkspaceTransitionHibernate:
 		action = policy.ActionWorkspaceStop
 	default:
 		msg := fmt.Sprintf("Transition %q not supported.", b.trans)
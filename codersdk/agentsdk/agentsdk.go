@@ -126,6 +126,10 @@ type Manifest struct {
 	Metadata                 []codersdk.WorkspaceAgentMetadataDescription `json:"metadata"`
 	Scripts                  []codersdk.WorkspaceAgentScript              `json:"scripts"`
 	Devcontainers            []codersdk.WorkspaceAgentDevcontainer        `json:"devcontainers"`
+	// SessionRecordingEnabled records interactive terminal sessions and
+	// uploads them to coderd when they end.
+	SessionRecordingEnabled      bool `json:"session_recording_enabled"`
+	SessionRecordingIncludeInput bool `json:"session_recording_include_input"`
 }
 
 type LogSource struct {
@@ -279,6 +283,30 @@ func (c *Client) ConnectRPC28(ctx context.Context) (
 	return proto.NewDRPCAgentClient(conn), tailnetproto.NewDRPCTailnetClient(conn), nil
 }
 
+// ConnectRPC29 returns a dRPC client to the Agent API v2.9.  It is useful when you want to be
+// maximally compatible with Coderd Release Versions from 2.32+
+func (c *Client) ConnectRPC29(ctx context.Context) (
+	proto.DRPCAgentClient29, tailnetproto.DRPCTailnetClient29, error,
+) {
+	conn, err := c.connectRPCVersion(ctx, apiversion.New(2, 9))
+	if err != nil {
+		return nil, nil, err
+	}
+	return proto.NewDRPCAgentClient(conn), tailnetproto.NewDRPCTailnetClient(conn), nil
+}
+
+// ConnectRPC210 returns a dRPC client to the Agent API v2.10.  It is useful when you want to be
+// maximally compatible with Coderd Release Versions from 2.32+
+func (c *Client) ConnectRPC210(ctx context.Context) (
+	proto.DRPCAgentClient210, tailnetproto.DRPCTailnetClient210, error,
+) {
+	conn, err := c.connectRPCVersion(ctx, apiversion.New(2, 10))
+	if err != nil {
+		return nil, nil, err
+	}
+	return proto.NewDRPCAgentClient(conn), tailnetproto.NewDRPCTailnetClient(conn), nil
+}
+
 // ConnectRPC connects to the workspace agent API and tailnet API
 func (c *Client) ConnectRPC(ctx context.Context) (drpc.Conn, error) {
 	return c.connectRPCVersion(ctx, proto.CurrentVersion)
@@ -62,6 +62,9 @@ func ManifestFromProto(manifest *proto.Manifest) (Manifest, error) {
 		DisableDirectConnections: manifest.DisableDirectConnections,
 		Metadata:                 MetadataDescriptionsFromProto(manifest.Metadata),
 		Devcontainers:            devcontainers,
+
+		SessionRecordingEnabled:      manifest.SessionRecordingEnabled,
+		SessionRecordingIncludeInput: manifest.SessionRecordingIncludeInput,
 	}, nil
 }
 
@@ -90,6 +93,9 @@ func ProtoFromManifest(manifest Manifest) (*proto.Manifest, error) {
 		Apps:                     apps,
 		Metadata:                 ProtoFromMetadataDescriptions(manifest.Metadata),
 		Devcontainers:            ProtoFromDevcontainers(manifest.Devcontainers),
+
+		SessionRecordingEnabled:      manifest.SessionRecordingEnabled,
+		SessionRecordingIncludeInput: manifest.SessionRecordingIncludeInput,
 	}, nil
 }
 
@@ -196,6 +202,8 @@ func AgentScriptFromProto(protoScript *proto.WorkspaceAgentScript) (codersdk.Wor
 		StartBlocksLogin: protoScript.StartBlocksLogin,
 		Timeout:          protoScript.Timeout.AsDuration(),
 		DisplayName:      protoScript.DisplayName,
+		UnitName:         protoScript.UnitName,
+		DependsOn:        protoScript.DependsOn,
 	}, nil
 }
 
@@ -211,6 +219,8 @@ func ProtoFromScript(s codersdk.WorkspaceAgentScript) *proto.WorkspaceAgentScrip
 		StartBlocksLogin: s.StartBlocksLogin,
 		Timeout:          durationpb.New(s.Timeout),
 		DisplayName:      s.DisplayName,
+		UnitName:         s.UnitName,
+		DependsOn:        s.DependsOn,
 	}
 }
 
@@ -139,6 +139,8 @@ func TestManifest(t *testing.T) {
 				SubagentID:      uuid.NullUUID{Valid: true, UUID: uuid.New()},
 			},
 		},
+		SessionRecordingEnabled:      true,
+		SessionRecordingIncludeInput: true,
 	}
 	p, err := agentsdk.ProtoFromManifest(manifest)
 	require.NoError(t, err)
@@ -163,6 +165,8 @@ func TestManifest(t *testing.T) {
 	require.Equal(t, manifest.Metadata, back.Metadata)
 	require.Equal(t, manifest.Scripts, back.Scripts)
 	require.Equal(t, manifest.Devcontainers, back.Devcontainers)
+	require.Equal(t, manifest.SessionRecordingEnabled, back.SessionRecordingEnabled)
+	require.Equal(t, manifest.SessionRecordingIncludeInput, back.SessionRecordingIncludeInput)
 }
 
 func TestSubsystems(t *testing.T) {
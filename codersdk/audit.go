@@ -43,8 +43,12 @@ const (
 	ResourceTypeWorkspaceAgent ResourceType = "workspace_agent"
 	// Deprecated: Workspace App connections are now included in the
 	// connection log.
-	ResourceTypeWorkspaceApp ResourceType = "workspace_app"
-	ResourceTypeTask         ResourceType = "task"
+	ResourceTypeWorkspaceApp                    ResourceType = "workspace_app"
+	ResourceTypeTask                            ResourceType = "task"
+	ResourceTypeOrganizationNotificationWebhook ResourceType = "organization_notification_webhook"
+	ResourceTypeTemplatePresetReadinessChecks   ResourceType = "template_preset_readiness_checks"
+	ResourceTypeTemplateGroupScheduleOverride   ResourceType = "template_group_schedule_override"
+	ResourceTypeTemplateAdaptivePrebuilds       ResourceType = "template_adaptive_prebuilds"
 )
 
 func (r ResourceType) FriendlyString() string {
@@ -103,6 +107,14 @@ func (r ResourceType) FriendlyString() string {
 		return "workspace app"
 	case ResourceTypeTask:
 		return "task"
+	case ResourceTypeOrganizationNotificationWebhook:
+		return "organization notification webhook"
+	case ResourceTypeTemplatePresetReadinessChecks:
+		return "template preset readiness checks"
+	case ResourceTypeTemplateGroupScheduleOverride:
+		return "template group schedule override"
+	case ResourceTypeTemplateAdaptivePrebuilds:
+		return "template adaptive prebuilds"
 	default:
 		return "unknown"
 	}
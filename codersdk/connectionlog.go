@@ -86,6 +86,9 @@ type ConnectionLogSSHInfo struct {
 	// ExitCode is the exit code of the SSH session. It is omitted if a
 	// disconnect event with the same connection ID has not yet been seen.
 	ExitCode *int32 `json:"exit_code,omitempty"`
+	// SessionRecordingID is the ID of the recording of the session, if the
+	// template records sessions and the recording has been uploaded.
+	SessionRecordingID *uuid.UUID `json:"session_recording_id,omitempty" format:"uuid"`
 }
 
 type ConnectionLogsRequest struct {
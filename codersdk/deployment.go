@@ -1084,6 +1084,8 @@ func (c *NotificationsEmailTLSConfig) Empty() bool {
 type NotificationsWebhookConfig struct {
 	// The URL to which the payload will be sent with an HTTP POST request.
 	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// The secrets with which request bodies are signed, see WebhookSignatureHeader.
+	SigningSecrets serpent.StringArray `json:"signing_secrets,omitempty" typescript:",notnull"`
 }
 
 type PrebuildsConfig struct {
@@ -3319,6 +3321,15 @@ Write out the current server config as YAML to stdout.`,
 			Group:       &deploymentGroupNotificationsWebhook,
 			YAML:        "endpoint",
 		},
+		{
+			Name:        "Notifications: Webhook: Signing Secrets",
+			Description: "Sign the bodies of webhook requests with HMAC-SHA256, so that receivers can verify they were sent by this deployment. The value must be a comma-separated list of secrets, and requests carry a signature for each of them in the X-Coder-Signature header. To rotate secrets, add the new secret, update receivers to use it, and then remove the old secret.",
+			Flag:        "notifications-webhook-signing-secrets",
+			Env:         "CODER_NOTIFICATIONS_WEBHOOK_SIGNING_SECRETS",
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.Notifications.Webhook.SigningSecrets,
+			Group:       &deploymentGroupNotificationsWebhook,
+		},
 		{
 			Name:        "Notifications: Inbox: Enabled",
 			Description: "Enable Coder Inbox.",
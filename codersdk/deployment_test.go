@@ -83,6 +83,9 @@ func TestDeploymentValues_HighlyConfigurable(t *testing.T) {
 		"Notifications: Email Auth: Password": {
 			yaml: true,
 		},
+		"Notifications: Webhook: Signing Secrets": {
+			yaml: true,
+		},
 		// We don't want these to be configurable via YAML because they are secrets.
 		// However, we do want to allow them to be shown in documentation.
 		"AI Bridge OpenAI Key": {
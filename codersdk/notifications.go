@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,6 +39,75 @@ type NotificationPreference struct {
 	NotificationTemplateID uuid.UUID `json:"id" format:"uuid"`
 	Disabled               bool      `json:"disabled"`
 	UpdatedAt              time.Time `json:"updated_at" format:"date-time"`
+	// TargetIDs are the notification targets to which notifications of this template are delivered, instead of by
+	// the template's method. Only verified targets receive notifications.
+	TargetIDs []uuid.UUID `json:"target_ids" format:"uuid"`
+}
+
+// NotificationTarget is a personal delivery target for a user's notifications, such as their own webhook endpoint.
+// Targets must be verified before they receive notifications.
+type NotificationTarget struct {
+	ID     uuid.UUID `json:"id" format:"uuid"`
+	UserID uuid.UUID `json:"user_id" format:"uuid"`
+	Name   string    `json:"name"`
+	Method string    `json:"method" enums:"smtp,webhook,inbox,webpush"`
+	// Address is the webhook endpoint or email address notifications are delivered to. It is empty for methods which
+	// deliver to the user directly, such as inbox and webpush.
+	Address    string     `json:"address"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty" format:"date-time"`
+	CreatedAt  time.Time  `json:"created_at" format:"date-time"`
+	UpdatedAt  time.Time  `json:"updated_at" format:"date-time"`
+}
+
+type CreateNotificationTargetRequest struct {
+	Name    string `json:"name" validate:"required,notification_target_name"`
+	Method  string `json:"method" validate:"required" enums:"smtp,webhook,inbox,webpush"`
+	Address string `json:"address,omitempty"`
+}
+
+type VerifyNotificationTargetRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// NotificationDelivery is a notification message along with the outcome of its most recent delivery attempt.
+type NotificationDelivery struct {
+	MessageID    uuid.UUID  `json:"message_id" format:"uuid"`
+	TemplateID   uuid.UUID  `json:"template_id" format:"uuid"`
+	TemplateName string     `json:"template_name"`
+	UserID       uuid.UUID  `json:"user_id" format:"uuid"`
+	Method       string     `json:"method" enums:"smtp,webhook,inbox,webpush,slack,teams"`
+	TargetID     *uuid.UUID `json:"target_id,omitempty" format:"uuid"`
+	Status       string     `json:"status" enums:"pending,leased,sent,permanent_failure,temporary_failure,unknown,inhibited"`
+	StatusReason string     `json:"status_reason,omitempty"`
+	// ResponseStatusCode is the HTTP status code with which the last delivery attempt was rejected, for methods which
+	// deliver over HTTP.
+	ResponseStatusCode int `json:"response_status_code,omitempty"`
+	// AttemptCount is the number of delivery attempts made so far; every attempt after the first is a retry.
+	AttemptCount   int        `json:"attempt_count"`
+	CreatedAt      time.Time  `json:"created_at" format:"date-time"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty" format:"date-time"`
+	NextRetryAfter *time.Time `json:"next_retry_after,omitempty" format:"date-time"`
+}
+
+type NotificationDeliveriesRequest struct {
+	// Method filters deliveries by their method, such as "webhook". Deliveries of all methods are returned if empty.
+	Method string `json:"method,omitempty"`
+	// Limit is the maximum number of deliveries to return.
+	Limit int `json:"limit,omitempty"`
+}
+
+// OrganizationNotificationWebhook is the incoming webhook of a chat service, such as Slack or Microsoft Teams, to
+// which notifications of the organization's members are delivered by the method of the same name.
+type OrganizationNotificationWebhook struct {
+	OrganizationID uuid.UUID `json:"organization_id" format:"uuid"`
+	Method         string    `json:"method" enums:"slack,teams"`
+	Endpoint       string    `json:"endpoint"`
+	CreatedAt      time.Time `json:"created_at" format:"date-time"`
+	UpdatedAt      time.Time `json:"updated_at" format:"date-time"`
+}
+
+type UpdateOrganizationNotificationWebhookRequest struct {
+	Endpoint string `json:"endpoint" validate:"required"`
 }
 
 // GetNotificationsSettings retrieves the notifications settings, which currently just describes whether all
@@ -194,6 +264,147 @@ func (c *Client) GetNotificationDispatchMethods(ctx context.Context) (Notificati
 	return resp, nil
 }
 
+// NotificationTargets retrieves the notification targets of a given user.
+func (c *Client) NotificationTargets(ctx context.Context, userID uuid.UUID) ([]NotificationTarget, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/targets", userID.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var targets []NotificationTarget
+	return targets, json.NewDecoder(res.Body).Decode(&targets)
+}
+
+// CreateNotificationTarget creates an unverified notification target for a given user.
+func (c *Client) CreateNotificationTarget(ctx context.Context, userID uuid.UUID, req CreateNotificationTargetRequest) (NotificationTarget, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/users/%s/notifications/targets", userID.String()), req)
+	if err != nil {
+		return NotificationTarget{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return NotificationTarget{}, ReadBodyAsError(res)
+	}
+	var target NotificationTarget
+	return target, json.NewDecoder(res.Body).Decode(&target)
+}
+
+// DeleteNotificationTarget deletes a notification target of a given user, along with its routes.
+func (c *Client) DeleteNotificationTarget(ctx context.Context, userID, targetID uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/users/%s/notifications/targets/%s", userID.String(), targetID.String()), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// TestNotificationTarget sends a test message containing a verification code to a notification target.
+func (c *Client) TestNotificationTarget(ctx context.Context, userID, targetID uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/users/%s/notifications/targets/%s/test", userID.String(), targetID.String()), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// VerifyNotificationTarget verifies a notification target with the code from its test message.
+func (c *Client) VerifyNotificationTarget(ctx context.Context, userID, targetID uuid.UUID, req VerifyNotificationTargetRequest) (NotificationTarget, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/users/%s/notifications/targets/%s/verify", userID.String(), targetID.String()), req)
+	if err != nil {
+		return NotificationTarget{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return NotificationTarget{}, ReadBodyAsError(res)
+	}
+	var target NotificationTarget
+	return target, json.NewDecoder(res.Body).Decode(&target)
+}
+
+// NotificationDeliveries retrieves the most recently enqueued notification messages, along with the outcome of their
+// most recent delivery attempt.
+func (c *Client) NotificationDeliveries(ctx context.Context, req NotificationDeliveriesRequest) ([]NotificationDelivery, error) {
+	var opts []RequestOption
+	if req.Method != "" {
+		opts = append(opts, WithQueryParam("method", req.Method))
+	}
+	if req.Limit > 0 {
+		opts = append(opts, WithQueryParam("limit", strconv.Itoa(req.Limit)))
+	}
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/notifications/deliveries", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var deliveries []NotificationDelivery
+	return deliveries, json.NewDecoder(res.Body).Decode(&deliveries)
+}
+
+// OrganizationNotificationWebhooks retrieves the chat service webhooks configured by an organization.
+func (c *Client) OrganizationNotificationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]OrganizationNotificationWebhook, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/organizations/%s/notifications/webhooks", organizationID.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var webhooks []OrganizationNotificationWebhook
+	return webhooks, json.NewDecoder(res.Body).Decode(&webhooks)
+}
+
+// UpdateOrganizationNotificationWebhook sets the webhook to which notifications are delivered by the given chat
+// method, such as "slack" or "teams", for the members of an organization.
+func (c *Client) UpdateOrganizationNotificationWebhook(ctx context.Context, organizationID uuid.UUID, method string, req UpdateOrganizationNotificationWebhookRequest) (OrganizationNotificationWebhook, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/organizations/%s/notifications/webhooks/%s", organizationID.String(), method), req)
+	if err != nil {
+		return OrganizationNotificationWebhook{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return OrganizationNotificationWebhook{}, ReadBodyAsError(res)
+	}
+	var webhook OrganizationNotificationWebhook
+	return webhook, json.NewDecoder(res.Body).Decode(&webhook)
+}
+
+// DeleteOrganizationNotificationWebhook removes the webhook of the given chat method from an organization.
+func (c *Client) DeleteOrganizationNotificationWebhook(ctx context.Context, organizationID uuid.UUID, method string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/organizations/%s/notifications/webhooks/%s", organizationID.String(), method), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 func (c *Client) PostTestNotification(ctx context.Context) error {
 	res, err := c.Request(ctx, http.MethodPost, "/api/v2/notifications/test", nil)
 	if err != nil {
@@ -213,6 +424,9 @@ type UpdateNotificationTemplateMethod struct {
 
 type UpdateUserNotificationPreferences struct {
 	TemplateDisabledMap map[string]bool `json:"template_disabled_map"`
+	// TemplateTargetsMap routes notifications of the given templates to the given notification targets, replacing
+	// their current routes. An empty list of targets restores delivery by the template's method.
+	TemplateTargetsMap map[string][]uuid.UUID `json:"template_targets_map,omitempty"`
 }
 
 type WebpushMessageAction struct {
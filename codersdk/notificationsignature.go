@@ -0,0 +1,96 @@
+package codersdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// WebhookSignatureHeader is the header of webhook notification requests which carries their signatures, if
+	// CODER_NOTIFICATIONS_WEBHOOK_SIGNING_SECRETS is set. Its value has the form "t=<timestamp>,v1=<signature>", with
+	// one v1 signature for each secret. Each signature is the hex-encoded HMAC-SHA256 of the Unix timestamp, a dot, and
+	// the request body.
+	WebhookSignatureHeader = "X-Coder-Signature"
+	// DefaultWebhookSignatureTolerance is how old a webhook signature may be before it is rejected as a possible replay.
+	// Every delivery attempt is signed anew, so retried requests carry a fresh timestamp.
+	DefaultWebhookSignatureTolerance = 5 * time.Minute
+)
+
+var (
+	ErrWebhookSignatureMismatch = xerrors.New("webhook signature does not match any secret")
+	ErrWebhookSignatureExpired  = xerrors.New("webhook signature timestamp is outside of the tolerance")
+)
+
+// SignWebhookPayload returns the value of the WebhookSignatureHeader of a webhook request with the given body, sent at
+// the given time. The body is signed with each of the secrets, so receivers can verify it with any one of them.
+func SignWebhookPayload(body []byte, timestamp time.Time, secrets ...string) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	parts := []string{"t=" + ts}
+	for _, secret := range secrets {
+		parts = append(parts, "v1="+hex.EncodeToString(webhookSignature(secret, ts, body)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifyWebhookSignature checks that the WebhookSignatureHeader value of a webhook request was signed for its body with
+// one of the secrets, no longer than tolerance ago. While rotating secrets, pass both the old and the new one.
+//
+// Requests within the tolerance can still be replayed; receivers which need to guard against that should also discard
+// requests whose msg_id they have already processed.
+func VerifyWebhookSignature(body []byte, header string, tolerance time.Duration, secrets ...string) error {
+	var (
+		timestamp  string
+		signatures [][]byte
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			// Signatures of unknown schemes are ignored, as are malformed ones.
+			if signature, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return xerrors.New("webhook signature header must contain a timestamp and at least one v1 signature")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return xerrors.Errorf("parse webhook signature timestamp: %w", err)
+	}
+	age := time.Since(time.Unix(unix, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrWebhookSignatureExpired
+	}
+
+	for _, secret := range secrets {
+		expected := webhookSignature(secret, timestamp, body)
+		for _, signature := range signatures {
+			if hmac.Equal(expected, signature) {
+				return nil
+			}
+		}
+	}
+	return ErrWebhookSignatureMismatch
+}
+
+func webhookSignature(secret, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
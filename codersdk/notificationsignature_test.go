@@ -0,0 +1,104 @@
+package codersdk_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"_version":"1.1","msg_id":"a7c3f6f0-0d1e-4b5e-9c1d-2b3c4d5e6f70"}`)
+
+	tests := []struct {
+		name        string
+		header      string
+		body        []byte
+		secrets     []string
+		expectedErr error
+		errContains string
+	}{
+		{
+			name:    "OK",
+			header:  codersdk.SignWebhookPayload(body, time.Now(), "secret"),
+			body:    body,
+			secrets: []string{"secret"},
+		},
+		{
+			// During rotation the deployment signs with both secrets, and receivers accept either.
+			name:    "Rotation",
+			header:  codersdk.SignWebhookPayload(body, time.Now(), "new-secret", "old-secret"),
+			body:    body,
+			secrets: []string{"old-secret"},
+		},
+		{
+			name:    "ReceiverRotation",
+			header:  codersdk.SignWebhookPayload(body, time.Now(), "new-secret"),
+			body:    body,
+			secrets: []string{"old-secret", "new-secret"},
+		},
+		{
+			name:        "WrongSecret",
+			header:      codersdk.SignWebhookPayload(body, time.Now(), "secret"),
+			body:        body,
+			secrets:     []string{"other-secret"},
+			expectedErr: codersdk.ErrWebhookSignatureMismatch,
+		},
+		{
+			name:        "TamperedBody",
+			header:      codersdk.SignWebhookPayload(body, time.Now(), "secret"),
+			body:        []byte(strings.Replace(string(body), "1.1", "1.2", 1)),
+			secrets:     []string{"secret"},
+			expectedErr: codersdk.ErrWebhookSignatureMismatch,
+		},
+		{
+			name:        "Expired",
+			header:      codersdk.SignWebhookPayload(body, time.Now().Add(-time.Hour), "secret"),
+			body:        body,
+			secrets:     []string{"secret"},
+			expectedErr: codersdk.ErrWebhookSignatureExpired,
+		},
+		{
+			name:        "FromTheFuture",
+			header:      codersdk.SignWebhookPayload(body, time.Now().Add(time.Hour), "secret"),
+			body:        body,
+			secrets:     []string{"secret"},
+			expectedErr: codersdk.ErrWebhookSignatureExpired,
+		},
+		{
+			name:        "MissingSignature",
+			header:      "t=1700000000",
+			body:        body,
+			secrets:     []string{"secret"},
+			errContains: "at least one v1 signature",
+		},
+		{
+			name:        "MalformedTimestamp",
+			header:      "t=yesterday,v1=00",
+			body:        body,
+			secrets:     []string{"secret"},
+			errContains: "parse webhook signature timestamp",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := codersdk.VerifyWebhookSignature(tc.body, tc.header, codersdk.DefaultWebhookSignatureTolerance, tc.secrets...)
+			switch {
+			case tc.expectedErr != nil:
+				require.ErrorIs(t, err, tc.expectedErr)
+			case tc.errContains != "":
+				require.ErrorContains(t, err, tc.errContains)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
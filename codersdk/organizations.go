@@ -236,6 +236,10 @@ type CreateWorkspaceRequest struct {
 	RichParameterValues     []WorkspaceBuildParameter `json:"rich_parameter_values,omitempty"`
 	AutomaticUpdates        AutomaticUpdates          `json:"automatic_updates,omitempty"`
 	TemplateVersionPresetID uuid.UUID                 `json:"template_version_preset_id,omitempty" format:"uuid"`
+	// SourceWorkspaceID records the workspace this workspace is cloned from.
+	// The source workspace must use the same template and be readable by the
+	// caller.
+	SourceWorkspaceID uuid.UUID `json:"source_workspace_id,omitempty" format:"uuid"`
 }
 
 func (c *Client) OrganizationByName(ctx context.Context, name string) (Organization, error) {
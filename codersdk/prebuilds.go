@@ -3,7 +3,12 @@ package codersdk
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type PrebuildsSettings struct {
@@ -42,3 +47,206 @@ func (c *Client) PutPrebuildsSettings(ctx context.Context, settings PrebuildsSet
 	}
 	return nil
 }
+
+// TemplateAdaptivePrebuilds configures adaptive pool sizing for the prebuilds of a template.
+// When enabled, the desired instances of each preset are forecasted from the number of prebuilds
+// claimed during the same hour of the week over the lookback period, instead of being taken from
+// the preset's static prebuild schedules.
+type TemplateAdaptivePrebuilds struct {
+	Enabled       bool  `json:"enabled"`
+	MinInstances  int32 `json:"min_instances"`
+	MaxInstances  int32 `json:"max_instances"`
+	LookbackWeeks int32 `json:"lookback_weeks"`
+}
+
+// TemplatePrebuildsForecast describes the forecasted and actual claims of the prebuilds of
+// each preset of a template's active version.
+type TemplatePrebuildsForecast struct {
+	Presets []PrebuildPresetForecast `json:"presets"`
+}
+
+type PrebuildPresetForecast struct {
+	PresetID   uuid.UUID `json:"preset_id" format:"uuid"`
+	PresetName string    `json:"preset_name"`
+	// Adaptive is true if the desired instances of the preset are forecasted from its claim history.
+	Adaptive         bool                   `json:"adaptive"`
+	DesiredInstances int32                  `json:"desired_instances"`
+	Hours            []PrebuildForecastHour `json:"hours"`
+}
+
+// PrebuildForecastHour describes a single hour of a preset's prebuild forecast.
+type PrebuildForecastHour struct {
+	StartTime      time.Time `json:"start_time" format:"date-time"`
+	ForecastClaims float64   `json:"forecast_claims"`
+	// ActualClaims is the number of prebuilds claimed during the hour. It is zero for hours in the future.
+	ActualClaims     int64 `json:"actual_claims"`
+	DesiredInstances int32 `json:"desired_instances"`
+}
+
+// TemplateAdaptivePrebuilds returns the adaptive prebuilds settings of a template.
+func (c *Client) TemplateAdaptivePrebuilds(ctx context.Context, templateID uuid.UUID) (TemplateAdaptivePrebuilds, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/prebuilds/adaptive", templateID), nil)
+	if err != nil {
+		return TemplateAdaptivePrebuilds{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplateAdaptivePrebuilds{}, ReadBodyAsError(res)
+	}
+	var settings TemplateAdaptivePrebuilds
+	return settings, json.NewDecoder(res.Body).Decode(&settings)
+}
+
+// UpdateTemplateAdaptivePrebuilds modifies the adaptive prebuilds settings of a template.
+func (c *Client) UpdateTemplateAdaptivePrebuilds(ctx context.Context, templateID uuid.UUID, req TemplateAdaptivePrebuilds) (TemplateAdaptivePrebuilds, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/templates/%s/prebuilds/adaptive", templateID), req)
+	if err != nil {
+		return TemplateAdaptivePrebuilds{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplateAdaptivePrebuilds{}, ReadBodyAsError(res)
+	}
+	var settings TemplateAdaptivePrebuilds
+	return settings, json.NewDecoder(res.Body).Decode(&settings)
+}
+
+// TemplatePrebuildsForecast returns the forecasted and actual prebuild claims of a template's
+// presets, for the past and the next 24 hours.
+func (c *Client) TemplatePrebuildsForecast(ctx context.Context, templateID uuid.UUID) (TemplatePrebuildsForecast, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/prebuilds/forecast", templateID), nil)
+	if err != nil {
+		return TemplatePrebuildsForecast{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplatePrebuildsForecast{}, ReadBodyAsError(res)
+	}
+	var forecast TemplatePrebuildsForecast
+	return forecast, json.NewDecoder(res.Body).Decode(&forecast)
+}
+
+type PrebuildReadinessCheckType string
+
+const (
+	PrebuildReadinessCheckTypeScript   PrebuildReadinessCheckType = "script"
+	PrebuildReadinessCheckTypeMetadata PrebuildReadinessCheckType = "metadata"
+	PrebuildReadinessCheckTypeApp      PrebuildReadinessCheckType = "app"
+)
+
+// PrebuildReadinessCheck is a check that a prebuilt workspace must pass once its agents are
+// ready, before it can be claimed.
+type PrebuildReadinessCheck struct {
+	Type PrebuildReadinessCheckType `json:"type" enums:"script,metadata,app"`
+	// Script is the display name of an agent script that must exit successfully. Required for script checks.
+	Script string `json:"script,omitempty"`
+	// MetadataKey is the key of the agent metadata item to check. Required for metadata checks.
+	MetadataKey string `json:"metadata_key,omitempty"`
+	// MetadataValue is a regular expression the agent metadata value must match. Required for metadata checks.
+	MetadataValue string `json:"metadata_value,omitempty"`
+	// App is the slug of an app that must be healthy. Required for app checks.
+	App string `json:"app,omitempty"`
+}
+
+// TemplatePresetReadinessChecks are the readiness checks of the prebuilt workspaces of a template
+// preset. Presets are matched by name, so that the checks apply to all versions of the template.
+type TemplatePresetReadinessChecks struct {
+	PresetName string                   `json:"preset_name"`
+	Checks     []PrebuildReadinessCheck `json:"checks"`
+	// TimeoutMillis is how long checks may remain pending after the agents are ready
+	// before the prebuilt workspace is considered unhealthy and replaced.
+	TimeoutMillis int64     `json:"timeout_ms"`
+	UpdatedAt     time.Time `json:"updated_at" format:"date-time"`
+}
+
+type UpdateTemplatePresetReadinessChecksRequest struct {
+	Checks        []PrebuildReadinessCheck `json:"checks"`
+	TimeoutMillis int64                    `json:"timeout_ms"`
+}
+
+type PrebuildReadinessStatus string
+
+const (
+	PrebuildReadinessStatusPending PrebuildReadinessStatus = "pending"
+	PrebuildReadinessStatusPassed  PrebuildReadinessStatus = "passed"
+	PrebuildReadinessStatusFailed  PrebuildReadinessStatus = "failed"
+)
+
+// TemplatePrebuildsStatus describes the running prebuilt workspaces of a template.
+type TemplatePrebuildsStatus struct {
+	Prebuilds []PrebuiltWorkspaceStatus `json:"prebuilds"`
+}
+
+type PrebuiltWorkspaceStatus struct {
+	WorkspaceID   uuid.UUID `json:"workspace_id" format:"uuid"`
+	WorkspaceName string    `json:"workspace_name"`
+	PresetID      uuid.UUID `json:"preset_id" format:"uuid"`
+	PresetName    string    `json:"preset_name"`
+	// AgentsReady is true if all agents of the prebuilt workspace are ready.
+	AgentsReady bool `json:"agents_ready"`
+	// ReadinessStatus is the result of the preset's readiness checks for the latest build.
+	// It is empty if the preset has no readiness checks.
+	ReadinessStatus PrebuildReadinessStatus `json:"readiness_status,omitempty" enums:"pending,passed,failed"`
+	// FailureReasons explains why the readiness checks are pending or failed.
+	FailureReasons []string   `json:"failure_reasons"`
+	CheckedAt      *time.Time `json:"checked_at,omitempty" format:"date-time"`
+	// Eligible is true if the prebuilt workspace can be claimed.
+	Eligible  bool      `json:"eligible"`
+	CreatedAt time.Time `json:"created_at" format:"date-time"`
+}
+
+// TemplatePresetReadinessChecks returns the readiness checks of the presets of a template.
+func (c *Client) TemplatePresetReadinessChecks(ctx context.Context, templateID uuid.UUID) ([]TemplatePresetReadinessChecks, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/prebuilds/readiness", templateID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var checks []TemplatePresetReadinessChecks
+	return checks, json.NewDecoder(res.Body).Decode(&checks)
+}
+
+// UpdateTemplatePresetReadinessChecks creates or replaces the readiness checks of a template preset.
+func (c *Client) UpdateTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.UUID, presetName string, req UpdateTemplatePresetReadinessChecksRequest) (TemplatePresetReadinessChecks, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/templates/%s/prebuilds/readiness/%s", templateID, url.PathEscape(presetName)), req)
+	if err != nil {
+		return TemplatePresetReadinessChecks{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplatePresetReadinessChecks{}, ReadBodyAsError(res)
+	}
+	var checks TemplatePresetReadinessChecks
+	return checks, json.NewDecoder(res.Body).Decode(&checks)
+}
+
+// DeleteTemplatePresetReadinessChecks removes the readiness checks of a template preset.
+func (c *Client) DeleteTemplatePresetReadinessChecks(ctx context.Context, templateID uuid.UUID, presetName string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/templates/%s/prebuilds/readiness/%s", templateID, url.PathEscape(presetName)), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// TemplatePrebuildsStatus returns the running prebuilt workspaces of a template along with
+// the results of their readiness checks.
+func (c *Client) TemplatePrebuildsStatus(ctx context.Context, templateID uuid.UUID) (TemplatePrebuildsStatus, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/prebuilds/status", templateID), nil)
+	if err != nil {
+		return TemplatePrebuildsStatus{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplatePrebuildsStatus{}, ReadBodyAsError(res)
+	}
+	var status TemplatePrebuildsStatus
+	return status, json.NewDecoder(res.Body).Decode(&status)
+}
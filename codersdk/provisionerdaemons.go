@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -229,16 +231,70 @@ func (l ProvisionerJobLog) Text() string {
 	return sb.String()
 }
 
-// provisionerJobLogsAfter streams logs that occurred after a specific time.
-func (c *Client) provisionerJobLogsAfter(ctx context.Context, path string, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
-	afterQuery := ""
-	if after != 0 {
-		afterQuery = fmt.Sprintf("&after=%d", after)
+// ProvisionerJobLogsFilter narrows down the logs of a provisioner job to the
+// ones matching all of the set fields. The zero value matches every log.
+// @typescript-ignore ProvisionerJobLogsFilter
+type ProvisionerJobLogsFilter struct {
+	Stages []string
+	Levels []LogLevel
+	// Search matches logs whose output contains it, case-insensitive.
+	Search string
+	// Since matches logs created at or after it.
+	Since time.Time
+}
+
+func (f ProvisionerJobLogsFilter) encode(q url.Values) {
+	if len(f.Stages) > 0 {
+		q.Set("stage", strings.Join(f.Stages, ","))
+	}
+	if len(f.Levels) > 0 {
+		levels := make([]string, 0, len(f.Levels))
+		for _, level := range f.Levels {
+			levels = append(levels, string(level))
+		}
+		q.Set("level", strings.Join(levels, ","))
+	}
+	if f.Search != "" {
+		q.Set("q", f.Search)
+	}
+	if !f.Since.IsZero() {
+		q.Set("since", f.Since.Format(time.RFC3339Nano))
+	}
+}
+
+// provisionerJobLogs returns the logs of a provisioner job matching the
+// filter.
+func (c *Client) provisionerJobLogs(ctx context.Context, path string, filter ProvisionerJobLogsFilter) ([]ProvisionerJobLog, error) {
+	res, err := c.Request(ctx, http.MethodGet, path, nil, func(r *http.Request) {
+		q := r.URL.Query()
+		filter.encode(q)
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
 	}
-	followURL, err := c.URL.Parse(fmt.Sprintf("%s?follow%s", path, afterQuery))
+	var logs []ProvisionerJobLog
+	return logs, json.NewDecoder(res.Body).Decode(&logs)
+}
+
+// provisionerJobLogsAfter streams logs matching the filter that occurred
+// after a specific log ID.
+func (c *Client) provisionerJobLogsAfter(ctx context.Context, path string, after int64, filter ProvisionerJobLogsFilter) (<-chan ProvisionerJobLog, io.Closer, error) {
+	followURL, err := c.URL.Parse(path)
 	if err != nil {
 		return nil, nil, err
 	}
+	q := url.Values{}
+	if after != 0 {
+		q.Set("after", strconv.FormatInt(after, 10))
+	}
+	filter.encode(q)
+	// The follow parameter is a flag without a value.
+	followURL.RawQuery = strings.TrimPrefix(q.Encode()+"&follow", "&")
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, nil, xerrors.Errorf("create cookie jar: %w", err)
@@ -525,7 +581,7 @@ func ConvertWorkspaceStatus(jobStatus ProvisionerJobStatus, transition Workspace
 		switch transition {
 		case WorkspaceTransitionStart:
 			return WorkspaceStatusStarting
-		case WorkspaceTransitionStop:
+		case WorkspaceTransitionStop, WorkspaceTransitionHibernate:
 			return WorkspaceStatusStopping
 		case WorkspaceTransitionDelete:
 			return WorkspaceStatusDeleting
@@ -534,7 +590,7 @@ func ConvertWorkspaceStatus(jobStatus ProvisionerJobStatus, transition Workspace
 		switch transition {
 		case WorkspaceTransitionStart:
 			return WorkspaceStatusRunning
-		case WorkspaceTransitionStop:
+		case WorkspaceTransitionStop, WorkspaceTransitionHibernate:
 			return WorkspaceStatusStopped
 		case WorkspaceTransitionDelete:
 			return WorkspaceStatusDeleted
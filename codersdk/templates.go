@@ -69,6 +69,23 @@ type Template struct {
 	// DisableModuleCache disables the use of cached Terraform modules during
 	// provisioning.
 	DisableModuleCache bool `json:"disable_module_cache"`
+
+	// SessionRecordingEnabled records interactive terminal sessions, such as
+	// SSH and reconnecting PTY sessions, in workspaces of this template.
+	SessionRecordingEnabled bool `json:"session_recording_enabled"`
+	// SessionRecordingIncludeInput also records the input typed during a
+	// session. Only used when SessionRecordingEnabled is set.
+	SessionRecordingIncludeInput bool `json:"session_recording_include_input"`
+
+	// MaintenanceWindowSchedule is a cron time range, e.g. "* 2-4 * * 6",
+	// during which idle workspaces running an outdated template version are
+	// restarted onto the active version. Empty when unset.
+	MaintenanceWindowSchedule string `json:"maintenance_window_schedule"`
+
+	// HibernateIdleThresholdMillis makes autostop hibernate workspaces that
+	// have been inactive for less than this duration instead of stopping
+	// them. 0 means workspaces are always stopped.
+	HibernateIdleThresholdMillis int64 `json:"hibernate_idle_threshold_ms"`
 }
 
 // WeekdaysToBitmap converts a list of weekdays to a bitmap in accordance with
@@ -271,6 +288,21 @@ type UpdateTemplateMeta struct {
 	// DisableModuleCache disables the using of cached Terraform modules during
 	// provisioning. It is recommended not to disable this.
 	DisableModuleCache *bool `json:"disable_module_cache,omitempty"`
+	// SessionRecordingEnabled records interactive terminal sessions in
+	// workspaces of this template. Sessions that are already running are not
+	// affected.
+	SessionRecordingEnabled *bool `json:"session_recording_enabled,omitempty"`
+	// SessionRecordingIncludeInput also records the input typed during a
+	// session, which may contain secrets such as passwords.
+	SessionRecordingIncludeInput *bool `json:"session_recording_include_input,omitempty"`
+	// MaintenanceWindowSchedule is a cron time range, e.g. "* 2-4 * * 6",
+	// during which idle outdated workspaces are restarted onto the active
+	// version. An empty string disables the maintenance window.
+	MaintenanceWindowSchedule *string `json:"maintenance_window_schedule,omitempty"`
+	// HibernateIdleThresholdMillis makes autostop hibernate workspaces that
+	// have been inactive for less than this duration instead of stopping
+	// them. 0 disables hibernation on autostop.
+	HibernateIdleThresholdMillis *int64 `json:"hibernate_idle_threshold_ms,omitempty"`
 }
 
 type TemplateExample struct {
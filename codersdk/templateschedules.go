@@ -0,0 +1,117 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateGroupScheduleOverride restricts the schedule of a template's
+// workspaces that are owned by members of a group. Overrides can only make
+// the template schedule stricter. Users in several groups with overrides get
+// the strictest value of each setting.
+type TemplateGroupScheduleOverride struct {
+	TemplateID uuid.UUID `json:"template_id" format:"uuid"`
+	GroupID    uuid.UUID `json:"group_id" format:"uuid"`
+	// MaxTTLMillis caps the time until shutdown of workspaces, including
+	// workspaces that would otherwise never stop. 0 means no cap.
+	MaxTTLMillis int64 `json:"max_ttl_ms"`
+	// AutostartBlockedDays are days of the week on which autostart is not
+	// allowed, in addition to the days the template does not allow.
+	AutostartBlockedDays []string `json:"autostart_blocked_days" enums:"monday,tuesday,wednesday,thursday,friday,saturday,sunday"`
+	// AutostopRequirement adds days on which restarts are required to the
+	// template's autostop requirement. A Weeks value of 0 keeps the template's
+	// interval.
+	AutostopRequirement TemplateAutostopRequirement `json:"autostop_requirement"`
+	CreatedAt           time.Time                   `json:"created_at" format:"date-time"`
+	UpdatedAt           time.Time                   `json:"updated_at" format:"date-time"`
+}
+
+type UpsertTemplateGroupScheduleOverrideRequest struct {
+	MaxTTLMillis         int64                        `json:"max_ttl_ms,omitempty"`
+	AutostartBlockedDays []string                     `json:"autostart_blocked_days,omitempty" enums:"monday,tuesday,wednesday,thursday,friday,saturday,sunday"`
+	AutostopRequirement  *TemplateAutostopRequirement `json:"autostop_requirement,omitempty"`
+}
+
+// UserTemplateSchedule is the schedule policy that applies to a user's
+// workspaces of a template once the user's group overrides are applied.
+type UserTemplateSchedule struct {
+	TemplateID uuid.UUID `json:"template_id" format:"uuid"`
+	UserID     uuid.UUID `json:"user_id" format:"uuid"`
+	// GroupIDs are the groups of the user that have schedule overrides for
+	// the template.
+	GroupIDs             []uuid.UUID                  `json:"group_ids" format:"uuid"`
+	AllowUserAutostart   bool                         `json:"allow_user_autostart"`
+	AllowUserAutostop    bool                         `json:"allow_user_autostop"`
+	DefaultTTLMillis     int64                        `json:"default_ttl_ms"`
+	MaxTTLMillis         int64                        `json:"max_ttl_ms"`
+	AutostartRequirement TemplateAutostartRequirement `json:"autostart_requirement"`
+	AutostopRequirement  TemplateAutostopRequirement  `json:"autostop_requirement"`
+	// QuietHoursSchedule is the user's quiet hours schedule, during which
+	// restarts required by the autostop requirement happen. It is empty if
+	// quiet hours are not enabled on the deployment.
+	QuietHoursSchedule string `json:"quiet_hours_schedule"`
+}
+
+// TemplateGroupScheduleOverrides returns the group schedule overrides of a
+// template.
+func (c *Client) TemplateGroupScheduleOverrides(ctx context.Context, templateID uuid.UUID) ([]TemplateGroupScheduleOverride, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/schedule/groups", templateID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var overrides []TemplateGroupScheduleOverride
+	return overrides, json.NewDecoder(res.Body).Decode(&overrides)
+}
+
+// UpsertTemplateGroupScheduleOverride creates or replaces the schedule
+// override of a group for a template.
+func (c *Client) UpsertTemplateGroupScheduleOverride(ctx context.Context, templateID, groupID uuid.UUID, req UpsertTemplateGroupScheduleOverrideRequest) (TemplateGroupScheduleOverride, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/templates/%s/schedule/groups/%s", templateID, groupID), req)
+	if err != nil {
+		return TemplateGroupScheduleOverride{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplateGroupScheduleOverride{}, ReadBodyAsError(res)
+	}
+	var override TemplateGroupScheduleOverride
+	return override, json.NewDecoder(res.Body).Decode(&override)
+}
+
+// DeleteTemplateGroupScheduleOverride removes the schedule override of a
+// group for a template.
+func (c *Client) DeleteTemplateGroupScheduleOverride(ctx context.Context, templateID, groupID uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/templates/%s/schedule/groups/%s", templateID, groupID), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// UserTemplateSchedule previews the schedule policy that applies to a user's
+// workspaces of a template.
+func (c *Client) UserTemplateSchedule(ctx context.Context, templateID uuid.UUID, user string) (UserTemplateSchedule, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/schedule/users/%s", templateID, user), nil)
+	if err != nil {
+		return UserTemplateSchedule{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return UserTemplateSchedule{}, ReadBodyAsError(res)
+	}
+	var sched UserTemplateSchedule
+	return sched, json.NewDecoder(res.Body).Decode(&sched)
+}
@@ -186,7 +186,7 @@ func (c *Client) TemplateVersionVariables(ctx context.Context, version uuid.UUID
 
 // TemplateVersionLogsAfter streams logs for a template version that occurred after a specific log ID.
 func (c *Client) TemplateVersionLogsAfter(ctx context.Context, version uuid.UUID, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
-	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/templateversions/%s/logs", version), after)
+	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/templateversions/%s/logs", version), after, ProvisionerJobLogsFilter{})
 }
 
 // CreateTemplateVersionDryRunRequest defines the request parameters for
@@ -261,10 +261,26 @@ func (c *Client) TemplateVersionDryRunResources(ctx context.Context, version, jo
 	return resources, json.NewDecoder(res.Body).Decode(&resources)
 }
 
+// TemplateVersionDryRunResourceChanges returns the changes that a finished
+// template version dry-run plans to make to resources.
+func (c *Client) TemplateVersionDryRunResourceChanges(ctx context.Context, version, job uuid.UUID) ([]WorkspaceResourceChange, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templateversions/%s/dry-run/%s/resource-changes", version, job), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+
+	var changes []WorkspaceResourceChange
+	return changes, json.NewDecoder(res.Body).Decode(&changes)
+}
+
 // TemplateVersionDryRunLogsAfter streams logs for a template version dry-run
 // that occurred after a specific log ID.
 func (c *Client) TemplateVersionDryRunLogsAfter(ctx context.Context, version, job uuid.UUID, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
-	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/templateversions/%s/dry-run/%s/logs", version, job), after)
+	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/templateversions/%s/dry-run/%s/logs", version, job), after, ProvisionerJobLogsFilter{})
 }
 
 // CancelTemplateVersionDryRun marks a template version dry-run job as canceled.
@@ -305,3 +321,84 @@ func (c *Client) UpdateTemplateVersion(ctx context.Context, versionID uuid.UUID,
 	var version TemplateVersion
 	return version, json.NewDecoder(res.Body).Decode(&version)
 }
+
+// TemplateVersionDiffChange describes how an item differs between two
+// template versions.
+type TemplateVersionDiffChange string
+
+const (
+	TemplateVersionDiffChangeAdded    TemplateVersionDiffChange = "added"
+	TemplateVersionDiffChangeRemoved  TemplateVersionDiffChange = "removed"
+	TemplateVersionDiffChangeModified TemplateVersionDiffChange = "modified"
+)
+
+// TemplateVersionDiff lists the changes from one template version to another.
+// Items that did not change are omitted.
+type TemplateVersionDiff struct {
+	FromID     uuid.UUID                      `json:"from_id" format:"uuid"`
+	ToID       uuid.UUID                      `json:"to_id" format:"uuid"`
+	Files      []TemplateVersionFileDiff      `json:"files"`
+	Parameters []TemplateVersionParameterDiff `json:"parameters"`
+	Variables  []TemplateVersionItemDiff      `json:"variables"`
+	// Resources are the resources declared by the Terraform plan of the
+	// template version import, keyed by their address.
+	Resources []TemplateVersionItemDiff `json:"resources"`
+}
+
+// TemplateVersionFileDiff is a file of the template source archive that
+// differs between the template versions.
+type TemplateVersionFileDiff struct {
+	Path   string                    `json:"path"`
+	Change TemplateVersionDiffChange `json:"change" enums:"added,removed,modified"`
+	// Binary is true for files that are not text or too large to diff. Diff
+	// is empty for those.
+	Binary bool `json:"binary"`
+	// Diff is the unified diff of the file.
+	Diff string `json:"diff"`
+}
+
+// TemplateVersionItemDiff is a variable or resource that differs between the
+// template versions.
+type TemplateVersionItemDiff struct {
+	Name   string                    `json:"name"`
+	Change TemplateVersionDiffChange `json:"change" enums:"added,removed,modified"`
+	// Fields lists the changed fields of modified items.
+	Fields []TemplateVersionFieldDiff `json:"fields,omitempty"`
+}
+
+// TemplateVersionParameterDiff is a parameter that differs between the
+// template versions.
+type TemplateVersionParameterDiff struct {
+	Name   string                    `json:"name"`
+	Change TemplateVersionDiffChange `json:"change" enums:"added,removed,modified"`
+	// Fields lists the changed fields of modified parameters, including
+	// changes to options that exist in both versions.
+	Fields []TemplateVersionFieldDiff `json:"fields,omitempty"`
+	// OptionsAdded and OptionsRemoved list the values of options only present
+	// in one of the versions.
+	OptionsAdded   []string `json:"options_added,omitempty"`
+	OptionsRemoved []string `json:"options_removed,omitempty"`
+}
+
+// TemplateVersionFieldDiff is a field whose value differs between the
+// template versions.
+type TemplateVersionFieldDiff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// TemplateVersionDiff returns the changes from the template version from to
+// the template version to.
+func (c *Client) TemplateVersionDiff(ctx context.Context, from, to uuid.UUID) (TemplateVersionDiff, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templateversions/%s/diff?from=%s", to, from), nil)
+	if err != nil {
+		return TemplateVersionDiff{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return TemplateVersionDiff{}, ReadBodyAsError(res)
+	}
+	var diff TemplateVersionDiff
+	return diff, json.NewDecoder(res.Body).Decode(&diff)
+}
@@ -48,6 +48,13 @@ const (
 	ToolNameWorkspaceWriteFile          = "coder_workspace_write_file"
 	ToolNameWorkspaceEditFile           = "coder_workspace_edit_file"
 	ToolNameWorkspaceEditFiles          = "coder_workspace_edit_files"
+	ToolNameWorkspaceStat               = "coder_workspace_stat"
+	ToolNameWorkspaceMkdir              = "coder_workspace_mkdir"
+	ToolNameWorkspaceRename             = "coder_workspace_rename"
+	ToolNameWorkspaceDelete             = "coder_workspace_delete"
+	ToolNameWorkspaceChmod              = "coder_workspace_chmod"
+	ToolNameWorkspaceWalk               = "coder_workspace_walk"
+	ToolNameWorkspaceSearch             = "coder_workspace_search"
 	ToolNameWorkspacePortForward        = "coder_workspace_port_forward"
 	ToolNameWorkspaceListApps           = "coder_workspace_list_apps"
 	ToolNameCreateTask                  = "coder_create_task"
@@ -228,6 +235,13 @@ var All = []GenericTool{
 	WorkspaceWriteFile.Generic(),
 	WorkspaceEditFile.Generic(),
 	WorkspaceEditFiles.Generic(),
+	WorkspaceStat.Generic(),
+	WorkspaceMkdir.Generic(),
+	WorkspaceRename.Generic(),
+	WorkspaceDelete.Generic(),
+	WorkspaceChmod.Generic(),
+	WorkspaceWalk.Generic(),
+	WorkspaceSearch.Generic(),
 	WorkspacePortForward.Generic(),
 	WorkspaceListApps.Generic(),
 	CreateTask.Generic(),
@@ -556,8 +570,8 @@ connect to the workspace.
 				},
 				"transition": map[string]any{
 					"type":        "string",
-					"description": "The transition to perform. Must be one of: start, stop, delete",
-					"enum":        []string{"start", "stop", "delete"},
+					"description": "The transition to perform. Must be one of: start, stop, delete, hibernate",
+					"enum":        []string{"start", "stop", "delete", "hibernate"},
 				},
 				"template_version_id": map[string]any{
 					"type":        "string",
@@ -1650,7 +1664,7 @@ var WorkspaceEditFile = Tool[WorkspaceEditFileArgs, codersdk.Response]{
 		}
 		defer conn.Close()
 
-		err = conn.EditFiles(ctx, workspacesdk.FileEditRequest{
+		_, err = conn.EditFiles(ctx, workspacesdk.FileEditRequest{
 			Files: []workspacesdk.FileEdits{
 				{
 					Path:  args.Path,
@@ -1671,12 +1685,19 @@ var WorkspaceEditFile = Tool[WorkspaceEditFileArgs, codersdk.Response]{
 type WorkspaceEditFilesArgs struct {
 	Workspace string                   `json:"workspace"`
 	Files     []workspacesdk.FileEdits `json:"files"`
+	Atomic    bool                     `json:"atomic"`
+	DryRun    bool                     `json:"dry_run"`
 }
 
-var WorkspaceEditFiles = Tool[WorkspaceEditFilesArgs, codersdk.Response]{
+var WorkspaceEditFiles = Tool[WorkspaceEditFilesArgs, workspacesdk.FileEditResponse]{
 	Tool: aisdk.Tool{
-		Name:        ToolNameWorkspaceEditFiles,
-		Description: `Edit one or more files in a workspace.`,
+		Name: ToolNameWorkspaceEditFiles,
+		Description: `Edit one or more files in a workspace.
+
+The response contains the SHA-256 hash of each edited file. Pass it as
+"expected_hash" in a later edit of the same file to fail with a conflict if the
+file was changed in the meantime. Use "dry_run" to preview the edits as unified
+diffs, and "atomic" to make sure either all files or none are edited.`,
 		Schema: aisdk.Schema{
 			Properties: map[string]any{
 				"workspace": map[string]any{
@@ -1711,33 +1732,435 @@ var WorkspaceEditFiles = Tool[WorkspaceEditFilesArgs, codersdk.Response]{
 									"required": []string{"search", "replace"},
 								},
 							},
+							"expected_hash": map[string]any{
+								"type":        "string",
+								"description": "The hex-encoded SHA-256 hash of the file contents the edits are based on. The edit fails if the file no longer matches.",
+							},
 						},
 						"required": []string{"path", "edits"},
 					},
 				},
+				"atomic": map[string]any{
+					"type":        "boolean",
+					"description": "Apply either all edits or none of them. Without this, files edited before a failing file stay edited.",
+				},
+				"dry_run": map[string]any{
+					"type":        "boolean",
+					"description": "Validate the edits and return a unified diff for each file without changing anything.",
+				},
 			},
 			Required: []string{"workspace", "files"},
 		},
 	},
 	UserClientOptional: true,
-	Handler: func(ctx context.Context, deps Deps, args WorkspaceEditFilesArgs) (codersdk.Response, error) {
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceEditFilesArgs) (workspacesdk.FileEditResponse, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return workspacesdk.FileEditResponse{}, err
+		}
+		defer conn.Close()
+
+		return conn.EditFiles(ctx, workspacesdk.FileEditRequest{
+			Files:  args.Files,
+			Atomic: args.Atomic,
+			DryRun: args.DryRun,
+		})
+	},
+}
+
+type WorkspaceStatArgs struct {
+	Workspace string `json:"workspace"`
+	Path      string `json:"path"`
+}
+
+var WorkspaceStat = Tool[WorkspaceStatArgs, workspacesdk.FileInfo]{
+	Tool: aisdk.Tool{
+		Name:        ToolNameWorkspaceStat,
+		Description: `Get metadata (type, size, permissions and modification time) about a file or directory in a workspace.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the file or directory in the workspace.",
+				},
+			},
+			Required: []string{"path", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceStatArgs) (workspacesdk.FileInfo, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return workspacesdk.FileInfo{}, err
+		}
+		defer conn.Close()
+
+		return conn.Stat(ctx, args.Path)
+	},
+}
+
+type WorkspaceMkdirArgs struct {
+	Workspace string `json:"workspace"`
+	Path      string `json:"path"`
+	Parents   bool   `json:"parents"`
+	Mode      string `json:"mode"`
+}
+
+var WorkspaceMkdir = Tool[WorkspaceMkdirArgs, codersdk.Response]{
+	Tool: aisdk.Tool{
+		Name:        ToolNameWorkspaceMkdir,
+		Description: `Create a directory in a workspace.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the directory to create in the workspace.",
+				},
+				"parents": map[string]any{
+					"type":        "boolean",
+					"description": "Create missing parent directories and do not fail if the directory already exists.",
+				},
+				"mode": map[string]any{
+					"type":        "string",
+					"description": "The octal permissions of the new directory, e.g. \"0755\". Defaults to \"0755\".",
+				},
+			},
+			Required: []string{"path", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceMkdirArgs) (codersdk.Response, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return codersdk.Response{}, err
+		}
+		defer conn.Close()
+
+		err = conn.Mkdir(ctx, workspacesdk.MkdirRequest{
+			Path:    args.Path,
+			Parents: args.Parents,
+			Mode:    args.Mode,
+		})
+		if err != nil {
+			return codersdk.Response{}, err
+		}
+
+		return codersdk.Response{
+			Message: "Directory created successfully.",
+		}, nil
+	},
+}
+
+type WorkspaceRenameArgs struct {
+	Workspace   string `json:"workspace"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Overwrite   bool   `json:"overwrite"`
+}
+
+var WorkspaceRename = Tool[WorkspaceRenameArgs, codersdk.Response]{
+	Tool: aisdk.Tool{
+		Name:        ToolNameWorkspaceRename,
+		Description: `Move or rename a file or directory in a workspace. Missing parent directories of the destination are created.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"source": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the file or directory to move.",
+				},
+				"destination": map[string]any{
+					"type":        "string",
+					"description": "The absolute path to move the file or directory to.",
+				},
+				"overwrite": map[string]any{
+					"type":        "boolean",
+					"description": "Replace the destination if it already exists.",
+				},
+			},
+			Required: []string{"source", "destination", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceRenameArgs) (codersdk.Response, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return codersdk.Response{}, err
+		}
+		defer conn.Close()
+
+		err = conn.Rename(ctx, workspacesdk.RenameRequest{
+			Source:      args.Source,
+			Destination: args.Destination,
+			Overwrite:   args.Overwrite,
+		})
+		if err != nil {
+			return codersdk.Response{}, err
+		}
+
+		return codersdk.Response{
+			Message: "File moved successfully.",
+		}, nil
+	},
+}
+
+type WorkspaceDeleteArgs struct {
+	Workspace string `json:"workspace"`
+	Path      string `json:"path"`
+	Recursive bool   `json:"recursive"`
+}
+
+var WorkspaceDelete = Tool[WorkspaceDeleteArgs, codersdk.Response]{
+	Tool: aisdk.Tool{
+		Name:        ToolNameWorkspaceDelete,
+		Description: `Delete a file or directory in a workspace.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the file or directory to delete.",
+				},
+				"recursive": map[string]any{
+					"type":        "boolean",
+					"description": "Delete a directory and everything inside it. Required to delete a directory that is not empty.",
+				},
+			},
+			Required: []string{"path", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceDeleteArgs) (codersdk.Response, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return codersdk.Response{}, err
+		}
+		defer conn.Close()
+
+		err = conn.Delete(ctx, workspacesdk.DeleteRequest{
+			Path:      args.Path,
+			Recursive: args.Recursive,
+		})
+		if err != nil {
+			return codersdk.Response{}, err
+		}
+
+		return codersdk.Response{
+			Message: "File deleted successfully.",
+		}, nil
+	},
+}
+
+type WorkspaceChmodArgs struct {
+	Workspace string `json:"workspace"`
+	Path      string `json:"path"`
+	Mode      string `json:"mode"`
+}
+
+var WorkspaceChmod = Tool[WorkspaceChmodArgs, codersdk.Response]{
+	Tool: aisdk.Tool{
+		Name:        ToolNameWorkspaceChmod,
+		Description: `Change the permissions of a file or directory in a workspace.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the file or directory.",
+				},
+				"mode": map[string]any{
+					"type":        "string",
+					"description": "The octal permissions to set, e.g. \"0755\".",
+				},
+			},
+			Required: []string{"path", "mode", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceChmodArgs) (codersdk.Response, error) {
 		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
 		if err != nil {
 			return codersdk.Response{}, err
 		}
 		defer conn.Close()
 
-		err = conn.EditFiles(ctx, workspacesdk.FileEditRequest{Files: args.Files})
+		err = conn.Chmod(ctx, workspacesdk.ChmodRequest{
+			Path: args.Path,
+			Mode: args.Mode,
+		})
 		if err != nil {
 			return codersdk.Response{}, err
 		}
 
 		return codersdk.Response{
-			Message: "File(s) edited successfully.",
+			Message: "Permissions changed successfully.",
 		}, nil
 	},
 }
 
+type WorkspaceWalkArgs struct {
+	Workspace   string `json:"workspace"`
+	Path        string `json:"path"`
+	Pattern     string `json:"pattern"`
+	MaxDepth    int    `json:"max_depth"`
+	MaxEntries  int    `json:"max_entries"`
+	MaxFileSize int64  `json:"max_file_size"`
+}
+
+var WorkspaceWalk = Tool[WorkspaceWalkArgs, workspacesdk.WalkResponse]{
+	Tool: aisdk.Tool{
+		Name: ToolNameWorkspaceWalk,
+		Description: `Recursively list files and directories in a workspace, optionally
+filtered by a glob pattern.
+
+Results are returned in lexical order. If "truncated" is true in the response,
+more entries matched than were returned; narrow the search with a pattern or a
+deeper path rather than raising the limit.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the directory to walk.",
+				},
+				"pattern": map[string]any{
+					"type":        "string",
+					"description": "A glob pattern, e.g. \"*.go\". Patterns without a \"/\" match file names, otherwise they match the path relative to the walked directory. \"**\" is not supported.",
+				},
+				"max_depth": map[string]any{
+					"type":        "integer",
+					"description": "How many directories deep to descend. Defaults to no limit.",
+				},
+				"max_entries": map[string]any{
+					"type":        "integer",
+					"description": fmt.Sprintf("The maximum number of entries to return. Defaults to %d and cannot exceed %d.", workspacesdk.WalkDefaultMaxEntries, workspacesdk.WalkMaxEntries),
+				},
+				"max_file_size": map[string]any{
+					"type":        "integer",
+					"description": "Exclude files larger than this many bytes. Defaults to no limit.",
+				},
+			},
+			Required: []string{"path", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceWalkArgs) (workspacesdk.WalkResponse, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return workspacesdk.WalkResponse{}, err
+		}
+		defer conn.Close()
+
+		return conn.Walk(ctx, workspacesdk.WalkRequest{
+			Path:        args.Path,
+			Pattern:     args.Pattern,
+			MaxDepth:    args.MaxDepth,
+			MaxEntries:  args.MaxEntries,
+			MaxFileSize: args.MaxFileSize,
+		})
+	},
+}
+
+type WorkspaceSearchArgs struct {
+	Workspace       string `json:"workspace"`
+	Path            string `json:"path"`
+	Query           string `json:"query"`
+	CaseInsensitive bool   `json:"case_insensitive"`
+	Include         string `json:"include"`
+	ContextLines    int    `json:"context_lines"`
+	MaxResults      int    `json:"max_results"`
+	NoIgnore        bool   `json:"no_ignore"`
+}
+
+var WorkspaceSearch = Tool[WorkspaceSearchArgs, workspacesdk.SearchResponse]{
+	Tool: aisdk.Tool{
+		Name: ToolNameWorkspaceSearch,
+		Description: `Search the contents of files in a workspace directory for a regular
+expression, similar to grep.
+
+Files ignored by .gitignore, binary files and files over 1 MiB are skipped. Each
+match includes the file path, the 1-based line and column, and optionally the
+surrounding lines. If "truncated" is true in the response, more lines matched
+than were returned; narrow the search with a more specific query, an include
+pattern or a deeper path.`,
+		Schema: aisdk.Schema{
+			Properties: map[string]any{
+				"workspace": map[string]any{
+					"type":        "string",
+					"description": workspaceAgentDescription,
+				},
+				"path": map[string]any{
+					"type":        "string",
+					"description": "The absolute path of the directory to search.",
+				},
+				"query": map[string]any{
+					"type":        "string",
+					"description": "A regular expression in RE2 syntax matched against each line.",
+				},
+				"case_insensitive": map[string]any{
+					"type":        "boolean",
+					"description": "Whether to match the query case-insensitively.",
+				},
+				"include": map[string]any{
+					"type":        "string",
+					"description": "A glob pattern limiting which files are searched, e.g. \"*.go\". Patterns without a \"/\" match file names, otherwise they match the path relative to the searched directory.",
+				},
+				"context_lines": map[string]any{
+					"type":        "integer",
+					"description": fmt.Sprintf("The number of lines to return before and after each match. Cannot exceed %d.", workspacesdk.SearchMaxContextLines),
+				},
+				"max_results": map[string]any{
+					"type":        "integer",
+					"description": fmt.Sprintf("The maximum number of matches to return. Defaults to %d and cannot exceed %d.", workspacesdk.SearchDefaultMaxResults, workspacesdk.SearchMaxResults),
+				},
+				"no_ignore": map[string]any{
+					"type":        "boolean",
+					"description": "Also search files ignored by .gitignore.",
+				},
+			},
+			Required: []string{"path", "query", "workspace"},
+		},
+	},
+	UserClientOptional: true,
+	Handler: func(ctx context.Context, deps Deps, args WorkspaceSearchArgs) (workspacesdk.SearchResponse, error) {
+		conn, err := newAgentConn(ctx, deps.coderClient, args.Workspace)
+		if err != nil {
+			return workspacesdk.SearchResponse{}, err
+		}
+		defer conn.Close()
+
+		return conn.Search(ctx, workspacesdk.SearchRequest{
+			Path:            args.Path,
+			Query:           args.Query,
+			CaseInsensitive: args.CaseInsensitive,
+			Include:         args.Include,
+			ContextLines:    args.ContextLines,
+			MaxResults:      args.MaxResults,
+			NoIgnore:        args.NoIgnore,
+		})
+	},
+}
+
 type WorkspacePortForwardArgs struct {
 	Workspace string `json:"workspace"`
 	Port      int    `json:"port"`
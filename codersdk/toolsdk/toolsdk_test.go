@@ -725,7 +725,7 @@ func TestTools(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "must specify at least one file")
 
-		_, err = testTool(t, toolsdk.WorkspaceEditFiles, tb, toolsdk.WorkspaceEditFilesArgs{
+		res, err := testTool(t, toolsdk.WorkspaceEditFiles, tb, toolsdk.WorkspaceEditFilesArgs{
 			Workspace: workspace.Name,
 			Files: []workspacesdk.FileEdits{
 				{
@@ -749,6 +749,8 @@ func TestTools(t *testing.T) {
 			},
 		})
 		require.NoError(t, err)
+		require.Len(t, res.Files, 2)
+		require.Equal(t, workspacesdk.FileHash([]byte("bar1 bar1")), res.Files[0].Hash)
 
 		b, err := afero.ReadFile(fs, filePath1)
 		require.NoError(t, err)
@@ -757,6 +759,249 @@ func TestTools(t *testing.T) {
 		b, err = afero.ReadFile(fs, filePath2)
 		require.NoError(t, err)
 		require.Equal(t, "bar2 bar2", string(b))
+
+		// A stale hash fails the whole atomic edit.
+		_, err = testTool(t, toolsdk.WorkspaceEditFiles, tb, toolsdk.WorkspaceEditFilesArgs{
+			Workspace: workspace.Name,
+			Atomic:    true,
+			Files: []workspacesdk.FileEdits{
+				{
+					Path:         filePath1,
+					ExpectedHash: res.Files[0].Hash,
+					Edits:        []workspacesdk.FileEdit{{Search: "bar1", Replace: "baz1"}},
+				},
+				{
+					Path:         filePath2,
+					ExpectedHash: workspacesdk.FileHash([]byte("foo2 bar2")),
+					Edits:        []workspacesdk.FileEdit{{Search: "bar2", Replace: "baz2"}},
+				},
+			},
+		})
+		require.ErrorContains(t, err, "was modified")
+
+		b, err = afero.ReadFile(fs, filePath1)
+		require.NoError(t, err)
+		require.Equal(t, "bar1 bar1", string(b))
+	})
+
+	t.Run("WorkspaceStat", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		filePath := filepath.Join(tmpdir, "stat")
+		err = afero.WriteFile(fs, filePath, []byte("content"), 0o600)
+		require.NoError(t, err)
+
+		res, err := testTool(t, toolsdk.WorkspaceStat, tb, toolsdk.WorkspaceStatArgs{
+			Workspace: workspace.Name,
+			Path:      filePath,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "stat", res.Name)
+		require.False(t, res.IsDir)
+		require.EqualValues(t, 7, res.Size)
+		require.Equal(t, "0600", res.Mode)
+	})
+
+	t.Run("WorkspaceMkdir", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		dirPath := filepath.Join(tmpdir, "mkdir", "nested")
+
+		_, err = testTool(t, toolsdk.WorkspaceMkdir, tb, toolsdk.WorkspaceMkdirArgs{
+			Workspace: workspace.Name,
+			Path:      dirPath,
+			Parents:   true,
+		})
+		require.NoError(t, err)
+
+		stat, err := fs.Stat(dirPath)
+		require.NoError(t, err)
+		require.True(t, stat.IsDir())
+	})
+
+	t.Run("WorkspaceRename", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		srcPath := filepath.Join(tmpdir, "rename-src")
+		dstPath := filepath.Join(tmpdir, "rename-dst")
+		err = afero.WriteFile(fs, srcPath, []byte("content"), 0o644)
+		require.NoError(t, err)
+
+		_, err = testTool(t, toolsdk.WorkspaceRename, tb, toolsdk.WorkspaceRenameArgs{
+			Workspace:   workspace.Name,
+			Source:      srcPath,
+			Destination: dstPath,
+		})
+		require.NoError(t, err)
+
+		_, err = fs.Stat(srcPath)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		b, err := afero.ReadFile(fs, dstPath)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(b))
+	})
+
+	t.Run("WorkspaceDelete", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		dirPath := filepath.Join(tmpdir, "delete")
+		err = afero.WriteFile(fs, filepath.Join(dirPath, "file"), []byte("content"), 0o644)
+		require.NoError(t, err)
+
+		_, err = testTool(t, toolsdk.WorkspaceDelete, tb, toolsdk.WorkspaceDeleteArgs{
+			Workspace: workspace.Name,
+			Path:      dirPath,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not empty")
+
+		_, err = testTool(t, toolsdk.WorkspaceDelete, tb, toolsdk.WorkspaceDeleteArgs{
+			Workspace: workspace.Name,
+			Path:      dirPath,
+			Recursive: true,
+		})
+		require.NoError(t, err)
+
+		_, err = fs.Stat(dirPath)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("WorkspaceChmod", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		filePath := filepath.Join(tmpdir, "chmod")
+		err = afero.WriteFile(fs, filePath, []byte("content"), 0o644)
+		require.NoError(t, err)
+
+		_, err = testTool(t, toolsdk.WorkspaceChmod, tb, toolsdk.WorkspaceChmodArgs{
+			Workspace: workspace.Name,
+			Path:      filePath,
+			Mode:      "0755",
+		})
+		require.NoError(t, err)
+
+		stat, err := fs.Stat(filePath)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o755), stat.Mode().Perm())
+	})
+
+	t.Run("WorkspaceWalk", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		dirPath := filepath.Join(tmpdir, "walk")
+		err = afero.WriteFile(fs, filepath.Join(dirPath, "a.go"), []byte("package a"), 0o644)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, filepath.Join(dirPath, "sub", "b.go"), []byte("package b"), 0o644)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, filepath.Join(dirPath, "sub", "c.txt"), []byte("c"), 0o644)
+		require.NoError(t, err)
+
+		res, err := testTool(t, toolsdk.WorkspaceWalk, tb, toolsdk.WorkspaceWalkArgs{
+			Workspace: workspace.Name,
+			Path:      dirPath,
+			Pattern:   "*.go",
+		})
+		require.NoError(t, err)
+		require.False(t, res.Truncated)
+		require.Len(t, res.Entries, 2)
+		require.Equal(t, filepath.Join(dirPath, "a.go"), res.Entries[0].AbsolutePathString)
+		require.Equal(t, filepath.Join(dirPath, "sub", "b.go"), res.Entries[1].AbsolutePathString)
+	})
+
+	t.Run("WorkspaceSearch", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		fs := afero.NewMemMapFs()
+		_ = agenttest.New(t, client.URL, agentToken, func(opts *agent.Options) {
+			opts.Filesystem = fs
+		})
+		coderdtest.NewWorkspaceAgentWaiter(t, client, workspace.ID).Wait()
+		tb, err := toolsdk.NewDeps(client)
+		require.NoError(t, err)
+
+		tmpdir := os.TempDir()
+		dirPath := filepath.Join(tmpdir, "search")
+		err = afero.WriteFile(fs, filepath.Join(dirPath, ".gitignore"), []byte("ignored.go\n"), 0o644)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, filepath.Join(dirPath, "a.go"), []byte("package a\n// TODO: fix\n"), 0o644)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, filepath.Join(dirPath, "ignored.go"), []byte("// TODO: ignored\n"), 0o644)
+		require.NoError(t, err)
+
+		res, err := testTool(t, toolsdk.WorkspaceSearch, tb, toolsdk.WorkspaceSearchArgs{
+			Workspace:    workspace.Name,
+			Path:         dirPath,
+			Query:        "TODO",
+			ContextLines: 1,
+		})
+		require.NoError(t, err)
+		require.False(t, res.Truncated)
+		require.Len(t, res.Matches, 1)
+		require.Equal(t, filepath.Join(dirPath, "a.go"), res.Matches[0].Path)
+		require.Equal(t, 2, res.Matches[0].Line)
+		require.Equal(t, 4, res.Matches[0].Column)
+		require.Equal(t, []string{"package a"}, res.Matches[0].Before)
 	})
 
 	t.Run("WorkspacePortForward", func(t *testing.T) {
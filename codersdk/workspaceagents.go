@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -197,6 +199,8 @@ type WorkspaceAgentScript struct {
 	StartBlocksLogin bool          `json:"start_blocks_login"`
 	Timeout          time.Duration `json:"timeout"`
 	DisplayName      string        `json:"display_name"`
+	UnitName         string        `json:"unit_name,omitempty"`
+	DependsOn        []string      `json:"depends_on,omitempty"`
 }
 
 type WorkspaceAgentHealth struct {
@@ -646,23 +650,67 @@ func (c *Client) WorkspaceAgentRecreateDevcontainer(ctx context.Context, agentID
 	return m, nil
 }
 
-//nolint:revive // Follow is a control flag on the server as well.
-func (c *Client) WorkspaceAgentLogsAfter(ctx context.Context, agentID uuid.UUID, after int64, follow bool) (<-chan []WorkspaceAgentLog, io.Closer, error) {
-	var queryParams []string
-	if after != 0 {
-		queryParams = append(queryParams, fmt.Sprintf("after=%d", after))
+// WorkspaceAgentLogsFilter narrows down the logs of a workspace agent to the
+// ones matching all of the set fields. The zero value matches every log.
+// @typescript-ignore WorkspaceAgentLogsFilter
+type WorkspaceAgentLogsFilter struct {
+	// SourceIDs are the IDs of the log sources, such as startup scripts.
+	SourceIDs []uuid.UUID
+	Levels    []LogLevel
+	// Search matches logs whose output contains it, case-insensitive.
+	Search string
+	// Since matches logs created at or after it.
+	Since time.Time
+}
+
+func (f WorkspaceAgentLogsFilter) encode(q url.Values) {
+	if len(f.SourceIDs) > 0 {
+		ids := make([]string, 0, len(f.SourceIDs))
+		for _, id := range f.SourceIDs {
+			ids = append(ids, id.String())
+		}
+		q.Set("source", strings.Join(ids, ","))
 	}
-	if follow {
-		queryParams = append(queryParams, "follow")
+	if len(f.Levels) > 0 {
+		levels := make([]string, 0, len(f.Levels))
+		for _, level := range f.Levels {
+			levels = append(levels, string(level))
+		}
+		q.Set("level", strings.Join(levels, ","))
+	}
+	if f.Search != "" {
+		q.Set("q", f.Search)
 	}
-	var query string
-	if len(queryParams) > 0 {
-		query = "?" + strings.Join(queryParams, "&")
+	if !f.Since.IsZero() {
+		q.Set("since", f.Since.Format(time.RFC3339Nano))
 	}
-	reqURL, err := c.URL.Parse(fmt.Sprintf("/api/v2/workspaceagents/%s/logs%s", agentID, query))
+}
+
+//nolint:revive // Follow is a control flag on the server as well.
+func (c *Client) WorkspaceAgentLogsAfter(ctx context.Context, agentID uuid.UUID, after int64, follow bool) (<-chan []WorkspaceAgentLog, io.Closer, error) {
+	return c.WorkspaceAgentLogsAfterWithFilter(ctx, agentID, after, follow, WorkspaceAgentLogsFilter{})
+}
+
+// WorkspaceAgentLogsAfterWithFilter returns the logs of a workspace agent that
+// occurred after a specific log ID and match the filter. The filter is applied
+// by the server, so followed logs only include matching logs as well.
+//
+//nolint:revive // Follow is a control flag on the server as well.
+func (c *Client) WorkspaceAgentLogsAfterWithFilter(ctx context.Context, agentID uuid.UUID, after int64, follow bool, filter WorkspaceAgentLogsFilter) (<-chan []WorkspaceAgentLog, io.Closer, error) {
+	reqURL, err := c.URL.Parse(fmt.Sprintf("/api/v2/workspaceagents/%s/logs", agentID))
 	if err != nil {
 		return nil, nil, err
 	}
+	q := url.Values{}
+	if after != 0 {
+		q.Set("after", strconv.FormatInt(after, 10))
+	}
+	filter.encode(q)
+	reqURL.RawQuery = q.Encode()
+	if follow {
+		// The follow parameter is a flag without a value.
+		reqURL.RawQuery = strings.TrimPrefix(reqURL.RawQuery+"&follow", "&")
+	}
 
 	if !follow {
 		resp, err := c.Request(ctx, http.MethodGet, reqURL.String(), nil)
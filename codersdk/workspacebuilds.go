@@ -17,6 +17,10 @@ const (
 	WorkspaceTransitionStart  WorkspaceTransition = "start"
 	WorkspaceTransitionStop   WorkspaceTransition = "stop"
 	WorkspaceTransitionDelete WorkspaceTransition = "delete"
+	// WorkspaceTransitionHibernate releases the workspace's compute while
+	// retaining persistent resources. Templates can tell it apart from a stop
+	// through the `transition` attribute of the `coder_workspace` data source.
+	WorkspaceTransitionHibernate WorkspaceTransition = "hibernate"
 )
 
 type WorkspaceStatus string
@@ -68,6 +72,10 @@ const (
 	// BuildReasonTaskResume "task_resume" is used when a build to
 	// start a task workspace is triggered by a user.
 	BuildReasonTaskResume BuildReason = "task_resume"
+	// BuildReasonMaintenance "maintenance" is used when a build to restart an
+	// outdated workspace onto the active template version is triggered by the
+	// template's maintenance window.
+	BuildReasonMaintenance BuildReason = "maintenance"
 )
 
 // WorkspaceBuild is an at-point representation of a workspace state.
@@ -85,7 +93,7 @@ type WorkspaceBuild struct {
 	TemplateVersionID       uuid.UUID            `json:"template_version_id" format:"uuid"`
 	TemplateVersionName     string               `json:"template_version_name"`
 	BuildNumber             int32                `json:"build_number"`
-	Transition              WorkspaceTransition  `json:"transition" enums:"start,stop,delete"`
+	Transition              WorkspaceTransition  `json:"transition" enums:"start,stop,delete,hibernate"`
 	InitiatorID             uuid.UUID            `json:"initiator_id" format:"uuid"`
 	InitiatorUsername       string               `json:"initiator_name"`
 	Job                     ProvisionerJob       `json:"job"`
@@ -108,7 +116,7 @@ type WorkspaceResource struct {
 	ID         uuid.UUID                   `json:"id" format:"uuid"`
 	CreatedAt  time.Time                   `json:"created_at" format:"date-time"`
 	JobID      uuid.UUID                   `json:"job_id" format:"uuid"`
-	Transition WorkspaceTransition         `json:"workspace_transition" enums:"start,stop,delete"`
+	Transition WorkspaceTransition         `json:"workspace_transition" enums:"start,stop,delete,hibernate"`
 	Type       string                      `json:"type"`
 	Name       string                      `json:"name"`
 	Hide       bool                        `json:"hide"`
@@ -125,6 +133,33 @@ type WorkspaceResourceMetadata struct {
 	Sensitive bool   `json:"sensitive"`
 }
 
+// ResourceChangeAction is the change that a planned build would make to a
+// resource.
+type ResourceChangeAction string
+
+const (
+	ResourceChangeActionCreate  ResourceChangeAction = "create"
+	ResourceChangeActionUpdate  ResourceChangeAction = "update"
+	ResourceChangeActionReplace ResourceChangeAction = "replace"
+	ResourceChangeActionDelete  ResourceChangeAction = "delete"
+)
+
+// WorkspaceResourceChange describes a change that a dry-run plan would make
+// to a resource of a workspace.
+type WorkspaceResourceChange struct {
+	Address string               `json:"address"`
+	Type    string               `json:"type"`
+	Name    string               `json:"name"`
+	Action  ResourceChangeAction `json:"action" enums:"create,update,replace,delete"`
+	// ReplacePaths are the attribute paths which force the resource to be
+	// replaced.
+	ReplacePaths []string `json:"replace_paths"`
+	// Persistent is true if the resource is kept while the workspace is
+	// stopped, such as a home volume. Replacing or deleting a persistent
+	// resource loses its data.
+	Persistent bool `json:"persistent"`
+}
+
 // WorkspaceBuildParameter represents a parameter specific for a workspace build.
 type WorkspaceBuildParameter struct {
 	Name  string `json:"name"`
@@ -181,7 +216,20 @@ func (c *Client) CancelWorkspaceBuild(ctx context.Context, id uuid.UUID, req Can
 
 // WorkspaceBuildLogsAfter streams logs for a workspace build that occurred after a specific log ID.
 func (c *Client) WorkspaceBuildLogsAfter(ctx context.Context, build uuid.UUID, after int64) (<-chan ProvisionerJobLog, io.Closer, error) {
-	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/workspacebuilds/%s/logs", build), after)
+	return c.WorkspaceBuildLogsAfterWithFilter(ctx, build, after, ProvisionerJobLogsFilter{})
+}
+
+// WorkspaceBuildLogsAfterWithFilter streams logs for a workspace build that
+// occurred after a specific log ID and match the filter. The filter is
+// applied by the server.
+func (c *Client) WorkspaceBuildLogsAfterWithFilter(ctx context.Context, build uuid.UUID, after int64, filter ProvisionerJobLogsFilter) (<-chan ProvisionerJobLog, io.Closer, error) {
+	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/workspacebuilds/%s/logs", build), after, filter)
+}
+
+// WorkspaceBuildLogs returns the logs of a workspace build that match the
+// filter. The filter is applied by the server.
+func (c *Client) WorkspaceBuildLogs(ctx context.Context, build uuid.UUID, filter ProvisionerJobLogsFilter) ([]ProvisionerJobLog, error) {
+	return c.provisionerJobLogs(ctx, fmt.Sprintf("/api/v2/workspacebuilds/%s/logs", build), filter)
 }
 
 // WorkspaceBuildState returns the provisioner state of the build.
@@ -74,6 +74,9 @@ type Workspace struct {
 	// TaskID, if set, indicates that the workspace is relevant to the given codersdk.Task.
 	TaskID     uuid.NullUUID          `json:"task_id,omitempty"`
 	SharedWith []SharedWorkspaceActor `json:"shared_with,omitempty"`
+	// SourceWorkspaceID, if set, is the workspace this workspace was cloned
+	// from.
+	SourceWorkspaceID uuid.NullUUID `json:"source_workspace_id,omitempty"`
 }
 
 func (w Workspace) FullName() string {
@@ -116,7 +119,7 @@ const (
 // CreateWorkspaceBuildRequest provides options to update the latest workspace build.
 type CreateWorkspaceBuildRequest struct {
 	TemplateVersionID uuid.UUID           `json:"template_version_id,omitempty" format:"uuid"`
-	Transition        WorkspaceTransition `json:"transition" validate:"oneof=start stop delete,required"`
+	Transition        WorkspaceTransition `json:"transition" validate:"oneof=start stop delete hibernate,required"`
 	DryRun            bool                `json:"dry_run,omitempty"`
 	ProvisionerState  []byte              `json:"state,omitempty"`
 	// Orphan may be set for the Destroy transition.
@@ -213,6 +216,34 @@ func (c *Client) CreateWorkspaceBuild(ctx context.Context, workspace uuid.UUID,
 	return workspaceBuild, json.NewDecoder(res.Body).Decode(&workspaceBuild)
 }
 
+// CreateWorkspaceDryRunRequest defines the request parameters for
+// CreateWorkspaceDryRun.
+type CreateWorkspaceDryRunRequest struct {
+	// TemplateVersionID is the version to plan the build with. The active
+	// version of the workspace's template is used if unset.
+	TemplateVersionID uuid.UUID `json:"template_version_id,omitempty" format:"uuid"`
+	// RichParameterValues override the parameters of the latest build.
+	RichParameterValues []WorkspaceBuildParameter `json:"rich_parameter_values,omitempty"`
+}
+
+// CreateWorkspaceDryRun begins a plan-only build of a workspace to preview
+// the changes a start or update would make to its resources. The returned
+// job is a template version dry-run and is followed with the template
+// version dry-run endpoints.
+func (c *Client) CreateWorkspaceDryRun(ctx context.Context, workspace uuid.UUID, req CreateWorkspaceDryRunRequest) (ProvisionerJob, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/workspaces/%s/dry-run", workspace), req)
+	if err != nil {
+		return ProvisionerJob{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return ProvisionerJob{}, ReadBodyAsError(res)
+	}
+
+	var job ProvisionerJob
+	return job, json.NewDecoder(res.Body).Decode(&job)
+}
+
 func (c *Client) WatchWorkspace(ctx context.Context, id uuid.UUID) (<-chan Workspace, error) {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.End()
@@ -3,13 +3,16 @@ package workspacesdk
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/netip"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -59,12 +62,24 @@ type AgentConn interface {
 	Ping(ctx context.Context) (time.Duration, bool, *ipnstate.PingResult, error)
 	PrometheusMetrics(ctx context.Context) ([]byte, error)
 	ReconnectingPTY(ctx context.Context, id uuid.UUID, height uint16, width uint16, command string, initOpts ...AgentReconnectingPTYInitOption) (net.Conn, error)
+	ReconnectingPTYSessions(ctx context.Context) (ReconnectingPTYSessionsResponse, error)
+	KillReconnectingPTY(ctx context.Context, id uuid.UUID) error
 	DeleteDevcontainer(ctx context.Context, devcontainerID string) error
 	RecreateDevcontainer(ctx context.Context, devcontainerID string) (codersdk.Response, error)
 	LS(ctx context.Context, path string, req LSRequest) (LSResponse, error)
 	ReadFile(ctx context.Context, path string, offset, limit int64) (io.ReadCloser, string, error)
 	WriteFile(ctx context.Context, path string, reader io.Reader) error
-	EditFiles(ctx context.Context, edits FileEditRequest) error
+	EditFiles(ctx context.Context, edits FileEditRequest) (FileEditResponse, error)
+	Stat(ctx context.Context, path string) (FileInfo, error)
+	Mkdir(ctx context.Context, req MkdirRequest) error
+	Rename(ctx context.Context, req RenameRequest) error
+	Delete(ctx context.Context, req DeleteRequest) error
+	Chmod(ctx context.Context, req ChmodRequest) error
+	Walk(ctx context.Context, req WalkRequest) (WalkResponse, error)
+	DownloadArchive(ctx context.Context, req ArchiveRequest) (io.ReadCloser, error)
+	UploadArchive(ctx context.Context, req ArchiveRequest, reader io.Reader) error
+	Search(ctx context.Context, req SearchRequest) (SearchResponse, error)
+	Watch(ctx context.Context, logger slog.Logger, req WatchRequest) (<-chan WatchEvent, io.Closer, error)
 	SSH(ctx context.Context) (*gonet.TCPConn, error)
 	SSHClient(ctx context.Context) (*ssh.Client, error)
 	SSHClientOnPort(ctx context.Context, port uint16) (*ssh.Client, error)
@@ -142,6 +157,10 @@ type AgentReconnectingPTYInit struct {
 	ContainerUser string
 
 	BackendType string
+	// ReadOnly attaches to an existing session without forwarding input or
+	// resize requests. Attaching read-only to a session that does not exist
+	// fails.
+	ReadOnly bool
 }
 
 // AgentReconnectingPTYInitOption is a functional option for AgentReconnectingPTYInit.
@@ -155,6 +174,14 @@ func AgentReconnectingPTYInitWithContainer(container, containerUser string) Agen
 	}
 }
 
+// AgentReconnectingPTYInitWithReadOnly attaches to the reconnecting PTY
+// session as a read-only viewer.
+func AgentReconnectingPTYInitWithReadOnly() AgentReconnectingPTYInitOption {
+	return func(init *AgentReconnectingPTYInit) {
+		init.ReadOnly = true
+	}
+}
+
 // ReconnectingPTYRequest is sent from the client to the server
 // to pipe data to a PTY.
 // @typescript-ignore ReconnectingPTYRequest
@@ -205,6 +232,74 @@ func (c *agentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, w
 	return conn, nil
 }
 
+// ReconnectingPTYConnection is a connection attached to a reconnecting PTY
+// session.
+type ReconnectingPTYConnection struct {
+	ID string `json:"id"`
+	// RemoteAddr is the address the connection was made from. For web
+	// terminals this is the address of the proxying Coder server.
+	RemoteAddr string    `json:"remote_addr"`
+	ReadOnly   bool      `json:"read_only"`
+	AttachedAt time.Time `json:"attached_at" format:"date-time"`
+}
+
+// ReconnectingPTYSession describes a running reconnecting PTY session.
+type ReconnectingPTYSession struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+	// Command is the command the session was started with. An empty command
+	// runs the user's shell.
+	Command   string    `json:"command"`
+	Container string    `json:"container,omitempty"`
+	StartedAt time.Time `json:"started_at" format:"date-time"`
+	// LastActivityAt is the last time input or output passed through any of
+	// the attached connections.
+	LastActivityAt time.Time                   `json:"last_activity_at" format:"date-time"`
+	Connections    []ReconnectingPTYConnection `json:"connections"`
+}
+
+type ReconnectingPTYSessionsResponse struct {
+	Sessions []ReconnectingPTYSession `json:"sessions"`
+}
+
+// ReconnectingPTYSessions lists the reconnecting PTY sessions running in the
+// workspace agent.
+func (c *agentConn) ReconnectingPTYSessions(ctx context.Context) (ReconnectingPTYSessionsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	res, err := c.apiRequest(ctx, http.MethodGet, "/api/v0/reconnecting-pty", nil)
+	if err != nil {
+		return ReconnectingPTYSessionsResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ReconnectingPTYSessionsResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var m ReconnectingPTYSessionsResponse
+	if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+		return ReconnectingPTYSessionsResponse{}, xerrors.Errorf("decode response body: %w", err)
+	}
+	return m, nil
+}
+
+// KillReconnectingPTY closes a reconnecting PTY session, killing its process
+// and disconnecting every attached connection.
+func (c *agentConn) KillReconnectingPTY(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	res, err := c.apiRequest(ctx, http.MethodDelete, "/api/v0/reconnecting-pty/"+id.String(), nil)
+	if err != nil {
+		return xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return codersdk.ReadBodyAsError(res)
+	}
+	return nil
+}
+
 // SSH pipes the SSH protocol over the returned net.Conn.
 // This connects to the built-in SSH server in the workspace agent.
 func (c *agentConn) SSH(ctx context.Context) (*gonet.TCPConn, error) {
@@ -604,18 +699,478 @@ type FileEdit struct {
 type FileEdits struct {
 	Path  string     `json:"path"`
 	Edits []FileEdit `json:"edits"`
+	// ExpectedHash is the hex-encoded SHA-256 of the file contents the edits
+	// were made against, see FileHash. If the file no longer matches, the
+	// edit fails with a conflict.
+	ExpectedHash string `json:"expected_hash,omitempty"`
 }
 
 type FileEditRequest struct {
 	Files []FileEdits `json:"files"`
+	// Atomic applies either all of the edits or none of them. Edited files
+	// are staged next to the originals and only moved into place once every
+	// file was edited successfully.
+	Atomic bool `json:"atomic,omitempty"`
+	// DryRun validates the edits and returns a unified diff for each file
+	// without changing anything. A dry run is all-or-nothing like Atomic.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+type FileEditResult struct {
+	Path string `json:"path"`
+	// Hash is the hex-encoded SHA-256 of the edited contents, which can be
+	// used as the ExpectedHash of a subsequent edit.
+	Hash string `json:"hash"`
+	// Diff is the unified diff of the edit. Only set for dry runs, and empty
+	// if the edits did not change the file.
+	Diff string `json:"diff,omitempty"`
+}
+
+type FileEditResponse struct {
+	Message string           `json:"message"`
+	Files   []FileEditResult `json:"files"`
+}
+
+// FileHash returns the hash of file contents in the format used by
+// FileEdits.ExpectedHash.
+func FileHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
 }
 
 // EditFiles performs search and replace edits on one or more files.
-func (c *agentConn) EditFiles(ctx context.Context, edits FileEditRequest) error {
+func (c *agentConn) EditFiles(ctx context.Context, edits FileEditRequest) (FileEditResponse, error) {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.End()
 
 	res, err := c.apiRequest(ctx, http.MethodPost, "/api/v0/edit-files", edits)
+	if err != nil {
+		return FileEditResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return FileEditResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var m FileEditResponse
+	if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+		return FileEditResponse{}, xerrors.Errorf("decode response body: %w", err)
+	}
+	return m, nil
+}
+
+type FileInfo struct {
+	Name string `json:"name"`
+	// e.g. "C:\\Users\\coder\\hello.txt"
+	//      "/home/coder/hello.txt"
+	AbsolutePathString string `json:"absolute_path_string"`
+	IsDir              bool   `json:"is_dir"`
+	Size               int64  `json:"size"`
+	// Mode is the octal permission bits of the file, e.g. "0644".
+	Mode    string    `json:"mode"`
+	ModTime time.Time `json:"mod_time" format:"date-time"`
+	// IsSymlink is set for symbolic links listed by Walk, which does not
+	// follow them.
+	IsSymlink bool `json:"is_symlink,omitempty"`
+	// Hash is the hex-encoded SHA-256 of the file contents, see FileHash.
+	// Only set for regular files listed by a Walk that requested hashes.
+	Hash string `json:"hash,omitempty"`
+}
+
+// Stat returns metadata about a file or directory in the workspace.
+func (c *agentConn) Stat(ctx context.Context, path string) (FileInfo, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	res, err := c.apiRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v0/stat?path=%s", url.QueryEscape(path)), nil)
+	if err != nil {
+		return FileInfo{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return FileInfo{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var m FileInfo
+	if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+		return FileInfo{}, xerrors.Errorf("decode response body: %w", err)
+	}
+	return m, nil
+}
+
+type MkdirRequest struct {
+	Path string `json:"path"`
+	// Parents creates any missing parent directories and does not fail if the
+	// directory already exists.
+	Parents bool `json:"parents,omitempty"`
+	// Mode is the octal permission bits for the new directory. Defaults to
+	// "0755".
+	Mode string `json:"mode,omitempty"`
+}
+
+// Mkdir creates a directory in the workspace.
+func (c *agentConn) Mkdir(ctx context.Context, req MkdirRequest) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	return c.fileOperation(ctx, "/api/v0/mkdir", req)
+}
+
+type RenameRequest struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	// Overwrite replaces the destination if it already exists.
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+// Rename moves a file or directory in the workspace.
+func (c *agentConn) Rename(ctx context.Context, req RenameRequest) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	return c.fileOperation(ctx, "/api/v0/rename", req)
+}
+
+type DeleteRequest struct {
+	Path string `json:"path"`
+	// Recursive must be set to delete a directory that is not empty.
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// Delete removes a file or directory from the workspace.
+func (c *agentConn) Delete(ctx context.Context, req DeleteRequest) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	return c.fileOperation(ctx, "/api/v0/delete", req)
+}
+
+type ChmodRequest struct {
+	Path string `json:"path"`
+	// Mode is the octal permission bits to set, e.g. "0755".
+	Mode string `json:"mode"`
+}
+
+// Chmod changes the permissions of a file or directory in the workspace.
+func (c *agentConn) Chmod(ctx context.Context, req ChmodRequest) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	return c.fileOperation(ctx, "/api/v0/chmod", req)
+}
+
+type WalkRequest struct {
+	Path string `json:"path"`
+	// Pattern is a glob as understood by filepath.Match. Patterns without a
+	// path separator match the base name of each entry, otherwise they match
+	// the path relative to Path.
+	Pattern string `json:"pattern,omitempty"`
+	// MaxDepth limits how many directories deep the walk descends. Zero means
+	// no limit.
+	MaxDepth int `json:"max_depth,omitempty"`
+	// MaxEntries limits the number of entries returned. Defaults to
+	// WalkDefaultMaxEntries and cannot exceed WalkMaxEntries.
+	MaxEntries int `json:"max_entries,omitempty"`
+	// MaxFileSize excludes files larger than this many bytes. Zero means no
+	// limit.
+	MaxFileSize int64 `json:"max_file_size,omitempty"`
+	// Exclude skips entries matching these glob patterns, see archive.Filter
+	// for the matching rules. Excluded directories are not descended into.
+	Exclude []string `json:"exclude,omitempty"`
+	// IgnoreFile is the name of files containing gitignore-style patterns,
+	// e.g. ".gitignore". Patterns in such a file exclude entries in its
+	// directory and below. Empty disables ignore files.
+	IgnoreFile string `json:"ignore_file,omitempty"`
+	// Hash sets FileInfo.Hash for the regular files in the response.
+	Hash bool `json:"hash,omitempty"`
+}
+
+const (
+	WalkDefaultMaxEntries = 1000
+	WalkMaxEntries        = 10000
+)
+
+type WalkResponse struct {
+	Entries []FileInfo `json:"entries"`
+	// Truncated is true if the walk stopped after reaching MaxEntries.
+	Truncated bool `json:"truncated"`
+}
+
+// Walk recursively lists a directory in the workspace.
+func (c *agentConn) Walk(ctx context.Context, req WalkRequest) (WalkResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	res, err := c.apiRequest(ctx, http.MethodPost, "/api/v0/walk", req)
+	if err != nil {
+		return WalkResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WalkResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var m WalkResponse
+	if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+		return WalkResponse{}, xerrors.Errorf("decode response body: %w", err)
+	}
+	return m, nil
+}
+
+type ArchiveFormat string
+
+const (
+	ArchiveFormatTar ArchiveFormat = "tar"
+	ArchiveFormatZip ArchiveFormat = "zip"
+)
+
+func (f ArchiveFormat) Valid() bool {
+	switch f {
+	case ArchiveFormatTar, ArchiveFormatZip:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentType returns the MIME type of the archive format.
+func (f ArchiveFormat) ContentType() string {
+	if f == ArchiveFormatZip {
+		return "application/zip"
+	}
+	return "application/x-tar"
+}
+
+type ArchiveRequest struct {
+	// Path is the absolute path of the directory to archive or extract into.
+	Path string `json:"path"`
+	// Format defaults to ArchiveFormatTar.
+	Format ArchiveFormat `json:"format,omitempty"`
+	// Include and Exclude are glob patterns selecting entries, see
+	// archive.Filter for the matching rules.
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+func (r ArchiveRequest) query() string {
+	q := url.Values{}
+	q.Set("path", r.Path)
+	if r.Format != "" {
+		q.Set("format", string(r.Format))
+	}
+	for _, pattern := range r.Include {
+		q.Add("include", pattern)
+	}
+	for _, pattern := range r.Exclude {
+		q.Add("exclude", pattern)
+	}
+	return q.Encode()
+}
+
+// DownloadArchive streams a directory in the workspace as an archive. The
+// caller must close the returned reader.
+func (c *agentConn) DownloadArchive(ctx context.Context, req ArchiveRequest) (io.ReadCloser, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	//nolint:bodyclose // we want to return the body so the caller can stream.
+	res, err := c.apiRequest(ctx, http.MethodGet, "/api/v0/download-archive?"+req.query(), nil)
+	if err != nil {
+		return nil, xerrors.Errorf("do request: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		// codersdk.ReadBodyAsError will close the body.
+		return nil, codersdk.ReadBodyAsError(res)
+	}
+
+	return res.Body, nil
+}
+
+// UploadArchive extracts an archive read from reader into a directory in the
+// workspace, creating the directory if it does not exist.
+func (c *agentConn) UploadArchive(ctx context.Context, req ArchiveRequest, reader io.Reader) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	return c.fileOperation(ctx, "/api/v0/upload-archive?"+req.query(), reader)
+}
+
+type SearchRequest struct {
+	// Path is the absolute path of the directory to search.
+	Path string `json:"path"`
+	// Query is a regular expression in RE2 syntax matched against each line.
+	Query           string `json:"query"`
+	CaseInsensitive bool   `json:"case_insensitive,omitempty"`
+	// Include is a glob limiting which files are searched, with the same
+	// matching rules as WalkRequest.Pattern.
+	Include string `json:"include,omitempty"`
+	// ContextLines is the number of lines to return before and after each
+	// match. Cannot exceed SearchMaxContextLines.
+	ContextLines int `json:"context_lines,omitempty"`
+	// MaxResults limits the number of matches returned. Defaults to
+	// SearchDefaultMaxResults and cannot exceed SearchMaxResults.
+	MaxResults int `json:"max_results,omitempty"`
+	// MaxFileSize skips files larger than this many bytes. Defaults to
+	// SearchDefaultMaxFileSize.
+	MaxFileSize int64 `json:"max_file_size,omitempty"`
+	// NoIgnore searches files that are ignored by .gitignore files. The .git
+	// directory is always skipped.
+	NoIgnore bool `json:"no_ignore,omitempty"`
+}
+
+const (
+	SearchDefaultMaxResults  = 100
+	SearchMaxResults         = 1000
+	SearchMaxContextLines    = 10
+	SearchDefaultMaxFileSize = 1 << 20
+	// SearchMaxLineLength is the number of bytes of a line returned in a
+	// match, longer lines are truncated.
+	SearchMaxLineLength = 512
+)
+
+type SearchMatch struct {
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+	// Line and Column are the 1-based position of the start of the match.
+	// Column is measured in bytes.
+	Line   int      `json:"line"`
+	Column int      `json:"column"`
+	Text   string   `json:"text"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+type SearchResponse struct {
+	Matches []SearchMatch `json:"matches"`
+	// FilesSearched is the number of files that were read. Binary files and
+	// files over the size limit are not counted.
+	FilesSearched int `json:"files_searched"`
+	// Truncated is true if the search stopped after reaching MaxResults.
+	Truncated bool `json:"truncated"`
+}
+
+// Search searches the files in a workspace directory for lines matching a
+// regular expression.
+func (c *agentConn) Search(ctx context.Context, req SearchRequest) (SearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	res, err := c.apiRequest(ctx, http.MethodPost, "/api/v0/search", req)
+	if err != nil {
+		return SearchResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return SearchResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var m SearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&m); err != nil {
+		return SearchResponse{}, xerrors.Errorf("decode response body: %w", err)
+	}
+	return m, nil
+}
+
+type WatchOp string
+
+const (
+	WatchOpCreate WatchOp = "create"
+	WatchOpModify WatchOp = "modify"
+	WatchOpDelete WatchOp = "delete"
+	// WatchOpRename is sent for the old path of a renamed entry. The new path
+	// is sent as a create event if it is within a watched path.
+	WatchOpRename WatchOp = "rename"
+	// WatchOpOverflow is sent without a path when the agent dropped events
+	// because the kernel event queue overflowed. Clients should rescan the
+	// watched paths.
+	WatchOpOverflow WatchOp = "overflow"
+)
+
+type WatchRequest struct {
+	// Paths are the absolute paths of the files and directories to watch.
+	Paths []string `json:"paths"`
+	// Recursive watches subdirectories of the watched directories, including
+	// ones created after the watch started.
+	Recursive bool `json:"recursive,omitempty"`
+	// Exclude skips entries matching these glob patterns, see archive.Filter
+	// for the matching rules. Patterns are matched against the path relative
+	// to the watched directory.
+	Exclude []string `json:"exclude,omitempty"`
+	// Debounce is how long events are collected and coalesced before they are
+	// sent. Defaults to WatchDefaultDebounce and cannot exceed
+	// WatchMaxDebounce.
+	Debounce time.Duration `json:"debounce,omitempty"`
+}
+
+const (
+	WatchDefaultDebounce = 100 * time.Millisecond
+	WatchMaxDebounce     = 10 * time.Second
+	// WatchMaxDirectories limits how many directories a single watch may
+	// observe, since each one consumes an inotify watch on Linux.
+	WatchMaxDirectories = 8192
+)
+
+func (r WatchRequest) query() string {
+	q := url.Values{}
+	for _, p := range r.Paths {
+		q.Add("path", p)
+	}
+	if r.Recursive {
+		q.Set("recursive", "true")
+	}
+	for _, pattern := range r.Exclude {
+		q.Add("exclude", pattern)
+	}
+	if r.Debounce != 0 {
+		q.Set("debounce", r.Debounce.String())
+	}
+	return q.Encode()
+}
+
+type WatchEvent struct {
+	Path string  `json:"path"`
+	Op   WatchOp `json:"op"`
+	// IsDir is best effort for delete and rename events, as the entry no
+	// longer exists when the event is observed.
+	IsDir bool `json:"is_dir"`
+}
+
+// Watch streams change events for files and directories in the workspace.
+// Events for the same path within the debounce window are coalesced, for
+// example a create followed by writes is sent as a single create event. A file
+// replaced by renaming another file over it is reported as created.
+func (c *agentConn) Watch(ctx context.Context, logger slog.Logger, req WatchRequest) (<-chan WatchEvent, io.Closer, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	host := net.JoinHostPort(c.agentAddress().String(), strconv.Itoa(AgentHTTPAPIServerPort))
+	url := fmt.Sprintf("http://%s%s?%s", host, "/api/v0/watch", req.query())
+
+	conn, res, err := websocket.Dial(ctx, url, &websocket.DialOptions{
+		HTTPClient: c.apiClient(),
+
+		// We want `NoContextTakeover` compression to balance improving
+		// bandwidth cost/latency with minimal memory usage overhead.
+		CompressionMode: websocket.CompressionNoContextTakeover,
+	})
+	if err != nil {
+		if res == nil {
+			return nil, nil, err
+		}
+		return nil, nil, codersdk.ReadBodyAsError(res)
+	}
+	if res != nil && res.Body != nil {
+		defer res.Body.Close()
+	}
+
+	d := wsjson.NewDecoder[WatchEvent](conn, websocket.MessageText, logger)
+	return d.Chan(), d, nil
+}
+
+// fileOperation posts a request to a file endpoint that responds with a
+// codersdk.Response on success.
+func (c *agentConn) fileOperation(ctx context.Context, path string, req any) error {
+	res, err := c.apiRequest(ctx, http.MethodPost, path, req)
 	if err != nil {
 		return xerrors.Errorf("do request: %w", err)
 	}
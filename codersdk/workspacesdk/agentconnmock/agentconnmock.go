@@ -67,6 +67,20 @@ func (mr *MockAgentConnMockRecorder) AwaitReachable(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwaitReachable", reflect.TypeOf((*MockAgentConn)(nil).AwaitReachable), ctx)
 }
 
+// Chmod mocks base method.
+func (m *MockAgentConn) Chmod(ctx context.Context, req workspacesdk.ChmodRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chmod", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Chmod indicates an expected call of Chmod.
+func (mr *MockAgentConnMockRecorder) Chmod(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chmod", reflect.TypeOf((*MockAgentConn)(nil).Chmod), ctx, req)
+}
+
 // Close mocks base method.
 func (m *MockAgentConn) Close() error {
 	m.ctrl.T.Helper()
@@ -126,6 +140,20 @@ func (mr *MockAgentConnMockRecorder) DebugManifest(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugManifest", reflect.TypeOf((*MockAgentConn)(nil).DebugManifest), ctx)
 }
 
+// Delete mocks base method.
+func (m *MockAgentConn) Delete(ctx context.Context, req workspacesdk.DeleteRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAgentConnMockRecorder) Delete(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAgentConn)(nil).Delete), ctx, req)
+}
+
 // DeleteDevcontainer mocks base method.
 func (m *MockAgentConn) DeleteDevcontainer(ctx context.Context, devcontainerID string) error {
 	m.ctrl.T.Helper()
@@ -155,12 +183,28 @@ func (mr *MockAgentConnMockRecorder) DialContext(ctx, network, addr any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialContext", reflect.TypeOf((*MockAgentConn)(nil).DialContext), ctx, network, addr)
 }
 
+// DownloadArchive mocks base method.
+func (m *MockAgentConn) DownloadArchive(ctx context.Context, req workspacesdk.ArchiveRequest) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadArchive", ctx, req)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadArchive indicates an expected call of DownloadArchive.
+func (mr *MockAgentConnMockRecorder) DownloadArchive(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadArchive", reflect.TypeOf((*MockAgentConn)(nil).DownloadArchive), ctx, req)
+}
+
 // EditFiles mocks base method.
-func (m *MockAgentConn) EditFiles(ctx context.Context, edits workspacesdk.FileEditRequest) error {
+func (m *MockAgentConn) EditFiles(ctx context.Context, edits workspacesdk.FileEditRequest) (workspacesdk.FileEditResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "EditFiles", ctx, edits)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(workspacesdk.FileEditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // EditFiles indicates an expected call of EditFiles.
@@ -183,6 +227,20 @@ func (mr *MockAgentConnMockRecorder) GetPeerDiagnostics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeerDiagnostics", reflect.TypeOf((*MockAgentConn)(nil).GetPeerDiagnostics))
 }
 
+// KillReconnectingPTY mocks base method.
+func (m *MockAgentConn) KillReconnectingPTY(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillReconnectingPTY", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KillReconnectingPTY indicates an expected call of KillReconnectingPTY.
+func (mr *MockAgentConnMockRecorder) KillReconnectingPTY(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillReconnectingPTY", reflect.TypeOf((*MockAgentConn)(nil).KillReconnectingPTY), ctx, id)
+}
+
 // LS mocks base method.
 func (m *MockAgentConn) LS(ctx context.Context, path string, req workspacesdk.LSRequest) (workspacesdk.LSResponse, error) {
 	m.ctrl.T.Helper()
@@ -228,6 +286,20 @@ func (mr *MockAgentConnMockRecorder) ListeningPorts(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListeningPorts", reflect.TypeOf((*MockAgentConn)(nil).ListeningPorts), ctx)
 }
 
+// Mkdir mocks base method.
+func (m *MockAgentConn) Mkdir(ctx context.Context, req workspacesdk.MkdirRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mkdir", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mkdir indicates an expected call of Mkdir.
+func (mr *MockAgentConnMockRecorder) Mkdir(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mkdir", reflect.TypeOf((*MockAgentConn)(nil).Mkdir), ctx, req)
+}
+
 // Netcheck mocks base method.
 func (m *MockAgentConn) Netcheck(ctx context.Context) (healthsdk.AgentNetcheckReport, error) {
 	m.ctrl.T.Helper()
@@ -311,6 +383,21 @@ func (mr *MockAgentConnMockRecorder) ReconnectingPTY(ctx, id, height, width, com
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconnectingPTY", reflect.TypeOf((*MockAgentConn)(nil).ReconnectingPTY), varargs...)
 }
 
+// ReconnectingPTYSessions mocks base method.
+func (m *MockAgentConn) ReconnectingPTYSessions(ctx context.Context) (workspacesdk.ReconnectingPTYSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconnectingPTYSessions", ctx)
+	ret0, _ := ret[0].(workspacesdk.ReconnectingPTYSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconnectingPTYSessions indicates an expected call of ReconnectingPTYSessions.
+func (mr *MockAgentConnMockRecorder) ReconnectingPTYSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconnectingPTYSessions", reflect.TypeOf((*MockAgentConn)(nil).ReconnectingPTYSessions), ctx)
+}
+
 // RecreateDevcontainer mocks base method.
 func (m *MockAgentConn) RecreateDevcontainer(ctx context.Context, devcontainerID string) (codersdk.Response, error) {
 	m.ctrl.T.Helper()
@@ -326,6 +413,20 @@ func (mr *MockAgentConnMockRecorder) RecreateDevcontainer(ctx, devcontainerID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecreateDevcontainer", reflect.TypeOf((*MockAgentConn)(nil).RecreateDevcontainer), ctx, devcontainerID)
 }
 
+// Rename mocks base method.
+func (m *MockAgentConn) Rename(ctx context.Context, req workspacesdk.RenameRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockAgentConnMockRecorder) Rename(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockAgentConn)(nil).Rename), ctx, req)
+}
+
 // SSH mocks base method.
 func (m *MockAgentConn) SSH(ctx context.Context) (*gonet.TCPConn, error) {
 	m.ctrl.T.Helper()
@@ -386,6 +487,21 @@ func (mr *MockAgentConnMockRecorder) SSHOnPort(ctx, port any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSHOnPort", reflect.TypeOf((*MockAgentConn)(nil).SSHOnPort), ctx, port)
 }
 
+// Search mocks base method.
+func (m *MockAgentConn) Search(ctx context.Context, req workspacesdk.SearchRequest) (workspacesdk.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, req)
+	ret0, _ := ret[0].(workspacesdk.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAgentConnMockRecorder) Search(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAgentConn)(nil).Search), ctx, req)
+}
+
 // Speedtest mocks base method.
 func (m *MockAgentConn) Speedtest(ctx context.Context, direction speedtest.Direction, duration time.Duration) ([]speedtest.Result, error) {
 	m.ctrl.T.Helper()
@@ -401,6 +517,21 @@ func (mr *MockAgentConnMockRecorder) Speedtest(ctx, direction, duration any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Speedtest", reflect.TypeOf((*MockAgentConn)(nil).Speedtest), ctx, direction, duration)
 }
 
+// Stat mocks base method.
+func (m *MockAgentConn) Stat(ctx context.Context, path string) (workspacesdk.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stat", ctx, path)
+	ret0, _ := ret[0].(workspacesdk.FileInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stat indicates an expected call of Stat.
+func (mr *MockAgentConnMockRecorder) Stat(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stat", reflect.TypeOf((*MockAgentConn)(nil).Stat), ctx, path)
+}
+
 // TailnetConn mocks base method.
 func (m *MockAgentConn) TailnetConn() *tailnet.Conn {
 	m.ctrl.T.Helper()
@@ -415,6 +546,51 @@ func (mr *MockAgentConnMockRecorder) TailnetConn() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailnetConn", reflect.TypeOf((*MockAgentConn)(nil).TailnetConn))
 }
 
+// UploadArchive mocks base method.
+func (m *MockAgentConn) UploadArchive(ctx context.Context, req workspacesdk.ArchiveRequest, reader io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadArchive", ctx, req, reader)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadArchive indicates an expected call of UploadArchive.
+func (mr *MockAgentConnMockRecorder) UploadArchive(ctx, req, reader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadArchive", reflect.TypeOf((*MockAgentConn)(nil).UploadArchive), ctx, req, reader)
+}
+
+// Walk mocks base method.
+func (m *MockAgentConn) Walk(ctx context.Context, req workspacesdk.WalkRequest) (workspacesdk.WalkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Walk", ctx, req)
+	ret0, _ := ret[0].(workspacesdk.WalkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Walk indicates an expected call of Walk.
+func (mr *MockAgentConnMockRecorder) Walk(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Walk", reflect.TypeOf((*MockAgentConn)(nil).Walk), ctx, req)
+}
+
+// Watch mocks base method.
+func (m *MockAgentConn) Watch(ctx context.Context, logger slog.Logger, req workspacesdk.WatchRequest) (<-chan workspacesdk.WatchEvent, io.Closer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, logger, req)
+	ret0, _ := ret[0].(<-chan workspacesdk.WatchEvent)
+	ret1, _ := ret[1].(io.Closer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockAgentConnMockRecorder) Watch(ctx, logger, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockAgentConn)(nil).Watch), ctx, logger, req)
+}
+
 // WatchContainers mocks base method.
 func (m *MockAgentConn) WatchContainers(ctx context.Context, logger slog.Logger) (<-chan codersdk.WorkspaceAgentListContainersResponse, io.Closer, error) {
 	m.ctrl.T.Helper()
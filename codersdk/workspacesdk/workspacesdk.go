@@ -333,6 +333,10 @@ type WorkspaceAgentReconnectingPTYOpts struct {
 	// workspace agent will attempt to determine the preferred backend type.
 	// Supported values are "screen" and "buffered".
 	BackendType string
+
+	// ReadOnly attaches to an existing session without forwarding input, so
+	// that others can watch without interfering.
+	ReadOnly bool
 }
 
 // AgentReconnectingPTY spawns a PTY that reconnects using the token provided.
@@ -357,6 +361,9 @@ func (c *Client) AgentReconnectingPTY(ctx context.Context, opts WorkspaceAgentRe
 	if opts.BackendType != "" {
 		q.Set("backend_type", opts.BackendType)
 	}
+	if opts.ReadOnly {
+		q.Set("read_only", "true")
+	}
 	// If we're using a signed token, set the query parameter.
 	if opts.SignedToken != "" {
 		q.Set(codersdk.SignedAppTokenQueryParameter, opts.SignedToken)
@@ -0,0 +1,62 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceSessionRecording is a recording of an interactive terminal session
+// in a workspace, stored in the asciicast v2 format.
+type WorkspaceSessionRecording struct {
+	ID          uuid.UUID `json:"id" format:"uuid"`
+	WorkspaceID uuid.UUID `json:"workspace_id" format:"uuid"`
+	AgentID     uuid.UUID `json:"agent_id" format:"uuid"`
+	// ConnectionID is the ID of the connection log entry of the session.
+	ConnectionID uuid.UUID      `json:"connection_id" format:"uuid"`
+	Type         ConnectionType `json:"type"`
+	StartedAt    time.Time      `json:"started_at" format:"date-time"`
+	EndedAt      time.Time      `json:"ended_at" format:"date-time"`
+	CreatedAt    time.Time      `json:"created_at" format:"date-time"`
+	// IncludeInput is true if the recording contains what the user typed.
+	IncludeInput bool `json:"include_input"`
+	// Truncated is true if the session exceeded the maximum recording size
+	// and its end is missing.
+	Truncated bool `json:"truncated"`
+	// Size is the size of the recording in bytes.
+	Size int64 `json:"size"`
+}
+
+// WorkspaceSessionRecordings returns the session recordings of a workspace,
+// most recent first.
+func (c *Client) WorkspaceSessionRecordings(ctx context.Context, workspaceID uuid.UUID, pagination Pagination) ([]WorkspaceSessionRecording, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/session-recordings", workspaceID), nil, pagination.asRequestOption())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var recordings []WorkspaceSessionRecording
+	return recordings, json.NewDecoder(res.Body).Decode(&recordings)
+}
+
+// WorkspaceSessionRecording downloads a session recording as an asciicast v2
+// file. The caller must close the returned reader.
+func (c *Client) WorkspaceSessionRecording(ctx context.Context, workspaceID, recordingID uuid.UUID) (io.ReadCloser, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/session-recordings/%s", workspaceID, recordingID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, ReadBodyAsError(res)
+	}
+	return res.Body, nil
+}
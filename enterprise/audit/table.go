@@ -120,6 +120,10 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"use_classic_parameter_flow":        ActionTrack,
 		"cors_behavior":                     ActionTrack,
 		"disable_module_cache":              ActionTrack,
+		"session_recording_enabled":         ActionTrack,
+		"session_recording_include_input":   ActionTrack,
+		"maintenance_window_schedule":       ActionTrack,
+		"hibernate_idle_threshold":          ActionTrack,
 	},
 	&database.TemplateVersion{}: {
 		"id":                      ActionTrack,
@@ -162,24 +166,25 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"is_system":                    ActionTrack, // Should never change, but track it anyway.
 	},
 	&database.WorkspaceTable{}: {
-		"id":                 ActionTrack,
-		"created_at":         ActionIgnore, // Never changes.
-		"updated_at":         ActionIgnore, // Changes, but is implicit and not helpful in a diff.
-		"owner_id":           ActionTrack,
-		"organization_id":    ActionIgnore, // Never changes.
-		"template_id":        ActionTrack,
-		"deleted":            ActionIgnore, // Changes, but is implicit when a delete event is fired.
-		"name":               ActionTrack,
-		"autostart_schedule": ActionTrack,
-		"ttl":                ActionTrack,
-		"last_used_at":       ActionIgnore,
-		"dormant_at":         ActionTrack,
-		"deleting_at":        ActionTrack,
-		"automatic_updates":  ActionTrack,
-		"favorite":           ActionTrack,
-		"next_start_at":      ActionTrack,
-		"group_acl":          ActionTrack,
-		"user_acl":           ActionTrack,
+		"id":                  ActionTrack,
+		"created_at":          ActionIgnore, // Never changes.
+		"updated_at":          ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+		"owner_id":            ActionTrack,
+		"organization_id":     ActionIgnore, // Never changes.
+		"template_id":         ActionTrack,
+		"deleted":             ActionIgnore, // Changes, but is implicit when a delete event is fired.
+		"name":                ActionTrack,
+		"autostart_schedule":  ActionTrack,
+		"ttl":                 ActionTrack,
+		"last_used_at":        ActionIgnore,
+		"dormant_at":          ActionTrack,
+		"deleting_at":         ActionTrack,
+		"automatic_updates":   ActionTrack,
+		"favorite":            ActionTrack,
+		"next_start_at":       ActionTrack,
+		"group_acl":           ActionTrack,
+		"user_acl":            ActionTrack,
+		"source_workspace_id": ActionTrack,
 	},
 	&database.WorkspaceBuild{}: {
 		"id":                         ActionIgnore,
@@ -334,6 +339,40 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"kind":               ActionTrack,
 		"enabled_by_default": ActionTrack,
 	},
+	&database.OrganizationNotificationWebhook{}: {
+		"organization_id": ActionIgnore, // Never changes.
+		"method":          ActionIgnore, // Never changes, and is the audit target.
+		"endpoint":        ActionSecret, // Contains the webhook's credentials.
+		"created_at":      ActionIgnore, // Never changes.
+		"updated_at":      ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
+	&database.TemplatePresetReadinessCheck{}: {
+		"template_id": ActionIgnore, // Never changes.
+		"preset_name": ActionIgnore, // Never changes, and is the audit target.
+		"checks":      ActionTrack,
+		"timeout":     ActionTrack,
+		"created_at":  ActionIgnore, // Never changes.
+		"updated_at":  ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
+	&database.TemplateGroupScheduleOverride{}: {
+		"template_id":                       ActionIgnore, // Never changes.
+		"group_id":                          ActionIgnore, // Never changes, and is the audit target.
+		"max_ttl":                           ActionTrack,
+		"autostart_block_days_of_week":      ActionTrack,
+		"autostop_requirement_days_of_week": ActionTrack,
+		"autostop_requirement_weeks":        ActionTrack,
+		"created_at":                        ActionIgnore, // Never changes.
+		"updated_at":                        ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
+	&database.TemplateAdaptivePrebuild{}: {
+		"template_id":    ActionIgnore, // Never changes.
+		"enabled":        ActionTrack,
+		"min_instances":  ActionTrack,
+		"max_instances":  ActionTrack,
+		"lookback_weeks": ActionTrack,
+		"created_at":     ActionIgnore, // Never changes.
+		"updated_at":     ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
 	&idpsync.OrganizationSyncSettings{}: {
 		"field":          ActionTrack,
 		"mapping":        ActionTrack,
@@ -462,6 +462,25 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 			r.Get("/", api.templateACL)
 			r.Patch("/", api.patchTemplateACL)
 		})
+		r.Route("/templates/{template}/schedule", func(r chi.Router) {
+			r.Use(
+				api.RequireFeatureMW(codersdk.FeatureAdvancedTemplateScheduling),
+				apiKeyMiddleware,
+				httpmw.ExtractTemplateParam(api.Database),
+			)
+			r.Route("/groups", func(r chi.Router) {
+				r.Get("/", api.templateGroupScheduleOverrides)
+				r.Route("/{group}", func(r chi.Router) {
+					r.Use(httpmw.ExtractGroupParam(api.Database))
+					r.Put("/", api.putTemplateGroupScheduleOverride)
+					r.Delete("/", api.deleteTemplateGroupScheduleOverride)
+				})
+			})
+			r.Route("/users/{user}", func(r chi.Router) {
+				r.Use(httpmw.ExtractUserParam(api.Database))
+				r.Get("/", api.userTemplateSchedule)
+			})
+		})
 		r.Route("/templates/{template}/prebuilds", func(r chi.Router) {
 			r.Use(
 				api.RequireFeatureMW(codersdk.FeatureWorkspacePrebuilds),
@@ -469,6 +488,15 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 				httpmw.ExtractTemplateParam(api.Database),
 			)
 			r.Post("/invalidate", api.postInvalidateTemplatePresets)
+			r.Get("/adaptive", api.templateAdaptivePrebuilds)
+			r.Put("/adaptive", api.putTemplateAdaptivePrebuilds)
+			r.Get("/forecast", api.templatePrebuildsForecast)
+			r.Get("/status", api.templatePrebuildsStatus)
+			r.Route("/readiness", func(r chi.Router) {
+				r.Get("/", api.templatePresetReadinessChecks)
+				r.Put("/{preset}", api.putTemplatePresetReadinessChecks)
+				r.Delete("/{preset}", api.deleteTemplatePresetReadinessChecks)
+			})
 		})
 
 		r.Route("/groups", func(r chi.Router) {
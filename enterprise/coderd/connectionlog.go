@@ -149,6 +149,9 @@ func convertConnectionLog(dblog database.GetConnectionLogsOffsetRow) codersdk.Co
 		if dblog.ConnectionLog.Code.Valid {
 			sshInfo.ExitCode = &dblog.ConnectionLog.Code.Int32
 		}
+		if dblog.SessionRecordingID.Valid {
+			sshInfo.SessionRecordingID = &dblog.SessionRecordingID.UUID
+		}
 	}
 
 	return codersdk.ConnectionLog{
@@ -2,19 +2,38 @@ package coderd
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	agplprebuilds "github.com/coder/coder/v2/coderd/prebuilds"
 	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/enterprise/coderd/prebuilds"
 )
 
+// maxAdaptiveLookbackWeeks bounds how much claim history is scanned to forecast prebuild claims.
+const maxAdaptiveLookbackWeeks = 12
+
+// prebuildsForecastWindow is how far into the past and the future the prebuilds forecast of a template spans.
+const prebuildsForecastWindow = 24 * time.Hour
+
 // @Summary Get prebuilds settings
 // @ID get-prebuilds-settings
 // @Security CoderSessionToken
@@ -118,3 +137,581 @@ func (api *API) putPrebuildsSettings(rw http.ResponseWriter, r *http.Request) {
 
 	httpapi.Write(r.Context(), rw, http.StatusOK, settings)
 }
+
+// @Summary Get adaptive prebuilds settings for template
+// @ID get-adaptive-prebuilds-settings-for-template
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {object} codersdk.TemplateAdaptivePrebuilds
+// @Router /templates/{template}/prebuilds/adaptive [get]
+func (api *API) templateAdaptivePrebuilds(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	template := httpmw.TemplateParam(r)
+
+	settings, err := templateAdaptivePrebuildsSettings(ctx, api.Database, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch adaptive prebuilds settings.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateAdaptivePrebuilds(settings))
+}
+
+// @Summary Update adaptive prebuilds settings for template
+// @ID update-adaptive-prebuilds-settings-for-template
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Param request body codersdk.TemplateAdaptivePrebuilds true "Adaptive prebuilds settings request"
+// @Success 200 {object} codersdk.TemplateAdaptivePrebuilds
+// @Router /templates/{template}/prebuilds/adaptive [put]
+func (api *API) putTemplateAdaptivePrebuilds(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplateAdaptivePrebuild](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, template) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	var req codersdk.TemplateAdaptivePrebuilds
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	var validErrs []codersdk.ValidationError
+	if req.MinInstances < 0 {
+		validErrs = append(validErrs, codersdk.ValidationError{Field: "min_instances", Detail: "Must be greater than or equal to 0."})
+	}
+	if req.MaxInstances < req.MinInstances {
+		validErrs = append(validErrs, codersdk.ValidationError{Field: "max_instances", Detail: "Must be greater than or equal to min_instances."})
+	}
+	if req.LookbackWeeks < 1 || req.LookbackWeeks > maxAdaptiveLookbackWeeks {
+		validErrs = append(validErrs, codersdk.ValidationError{
+			Field:  "lookback_weeks",
+			Detail: fmt.Sprintf("Must be between 1 and %d.", maxAdaptiveLookbackWeeks),
+		})
+	}
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid adaptive prebuilds settings.",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	existing, err := templateAdaptivePrebuildsSettings(ctx, api.Database, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch adaptive prebuilds settings.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.Old = existing
+
+	now := dbtime.Time(api.Clock.Now())
+	settings, err := api.Database.UpsertTemplateAdaptivePrebuilds(ctx, database.UpsertTemplateAdaptivePrebuildsParams{
+		TemplateID:    template.ID,
+		Enabled:       req.Enabled,
+		MinInstances:  req.MinInstances,
+		MaxInstances:  req.MaxInstances,
+		LookbackWeeks: req.LookbackWeeks,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update adaptive prebuilds settings.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = settings
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateAdaptivePrebuilds(settings))
+}
+
+// @Summary Get prebuilds forecast for template
+// @Description Returns the forecasted and actual prebuild claims of each preset of the template's
+// @Description active version, per hour, for the past and the next 24 hours.
+// @ID get-prebuilds-forecast-for-template
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {object} codersdk.TemplatePrebuildsForecast
+// @Router /templates/{template}/prebuilds/forecast [get]
+func (api *API) templatePrebuildsForecast(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	template := httpmw.TemplateParam(r)
+
+	if !api.Authorize(r, policy.ActionViewInsights, template) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	// The claim history spans the workspaces of all users of the template, which the caller
+	// is not necessarily allowed to read. Only aggregated counts are returned.
+	//nolint:gocritic // Reading claim history requires reading all workspaces of the template.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	snapshot, err := api.templatePrebuildsSnapshot(sysCtx, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch prebuilds claim history.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	now := api.Clock.Now()
+	from := now.Truncate(time.Hour).Add(-prebuildsForecastWindow)
+	to := now.Add(prebuildsForecastWindow)
+
+	forecast := codersdk.TemplatePrebuildsForecast{
+		Presets: []codersdk.PrebuildPresetForecast{},
+	}
+	for _, preset := range snapshot.Presets {
+		if !preset.UsingActiveVersion || preset.Deleted || preset.Deprecated {
+			continue
+		}
+		presetSnapshot, err := snapshot.FilterByPreset(preset.ID)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to forecast prebuild claims.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		demand := presetSnapshot.Forecast()
+		presetForecast := codersdk.PrebuildPresetForecast{
+			PresetID:         preset.ID,
+			PresetName:       preset.Name,
+			Adaptive:         presetSnapshot.IsAdaptive(),
+			DesiredInstances: presetSnapshot.CalculateDesiredInstances(now),
+			Hours:            []codersdk.PrebuildForecastHour{},
+		}
+		for hour := from; hour.Before(to); hour = hour.Add(time.Hour) {
+			presetForecast.Hours = append(presetForecast.Hours, codersdk.PrebuildForecastHour{
+				StartTime:        hour,
+				ForecastClaims:   demand.ClaimsPerHour(hour),
+				ActualClaims:     int64(presetSnapshot.CountClaims(hour, hour.Add(time.Hour))),
+				DesiredInstances: presetSnapshot.CalculateDesiredInstances(hour),
+			})
+		}
+		forecast.Presets = append(forecast.Presets, presetForecast)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, forecast)
+}
+
+// @Summary Get prebuild readiness checks for template
+// @ID get-prebuild-readiness-checks-for-template
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {array} codersdk.TemplatePresetReadinessChecks
+// @Router /templates/{template}/prebuilds/readiness [get]
+func (api *API) templatePresetReadinessChecks(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	template := httpmw.TemplateParam(r)
+
+	checks, err := api.Database.GetTemplatePresetReadinessChecks(ctx, uuid.NullUUID{UUID: template.ID, Valid: true})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch prebuild readiness checks.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	resp := make([]codersdk.TemplatePresetReadinessChecks, 0, len(checks))
+	for _, c := range checks {
+		resp = append(resp, convertTemplatePresetReadinessChecks(c))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+// @Summary Update prebuild readiness checks for template preset
+// @Description Prebuilt workspaces of the preset must pass the readiness checks once their agents are
+// @Description ready, before they can be claimed. Prebuilt workspaces that fail the checks are replaced.
+// @ID update-prebuild-readiness-checks-for-template-preset
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Param preset path string true "Preset name"
+// @Param request body codersdk.UpdateTemplatePresetReadinessChecksRequest true "Readiness checks request"
+// @Success 200 {object} codersdk.TemplatePresetReadinessChecks
+// @Router /templates/{template}/prebuilds/readiness/{preset} [put]
+func (api *API) putTemplatePresetReadinessChecks(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		presetName        = chi.URLParam(r, "preset")
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplatePresetReadinessCheck](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, template) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	var req codersdk.UpdateTemplatePresetReadinessChecksRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	validErrs := validatePrebuildReadinessChecks(req)
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid prebuild readiness checks.",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	existing, err := api.Database.GetTemplatePresetReadinessChecks(ctx, uuid.NullUUID{UUID: template.ID, Valid: true})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch prebuild readiness checks.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	for _, c := range existing {
+		if c.PresetName == presetName {
+			aReq.Old = c
+		}
+	}
+
+	checks := make(database.PrebuildReadinessChecks, 0, len(req.Checks))
+	for _, c := range req.Checks {
+		checks = append(checks, database.PrebuildReadinessCheck{
+			Type:          database.PrebuildReadinessCheckType(c.Type),
+			Script:        c.Script,
+			MetadataKey:   c.MetadataKey,
+			MetadataValue: c.MetadataValue,
+			App:           c.App,
+		})
+	}
+
+	now := dbtime.Time(api.Clock.Now())
+	updated, err := api.Database.UpsertTemplatePresetReadinessChecks(ctx, database.UpsertTemplatePresetReadinessChecksParams{
+		TemplateID: template.ID,
+		PresetName: presetName,
+		Checks:     checks,
+		Timeout:    int64(time.Duration(req.TimeoutMillis) * time.Millisecond),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update prebuild readiness checks.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = updated
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplatePresetReadinessChecks(updated))
+}
+
+// @Summary Delete prebuild readiness checks for template preset
+// @ID delete-prebuild-readiness-checks-for-template-preset
+// @Security CoderSessionToken
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Param preset path string true "Preset name"
+// @Success 204
+// @Router /templates/{template}/prebuilds/readiness/{preset} [delete]
+func (api *API) deleteTemplatePresetReadinessChecks(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplatePresetReadinessCheck](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	deleted, err := api.Database.DeleteTemplatePresetReadinessChecks(ctx, database.DeleteTemplatePresetReadinessChecksParams{
+		TemplateID: template.ID,
+		PresetName: chi.URLParam(r, "preset"),
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.Old = deleted
+
+	httpapi.Write(ctx, rw, http.StatusNoContent, nil)
+}
+
+// @Summary Get prebuilds status for template
+// @Description Returns the running prebuilt workspaces of the template, along with the results of
+// @Description their preset's readiness checks and whether they can be claimed.
+// @ID get-prebuilds-status-for-template
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Prebuilds
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {object} codersdk.TemplatePrebuildsStatus
+// @Router /templates/{template}/prebuilds/status [get]
+func (api *API) templatePrebuildsStatus(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	template := httpmw.TemplateParam(r)
+
+	if !api.Authorize(r, policy.ActionUpdate, template) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	//nolint:gocritic // Prebuilt workspaces are owned by the prebuilds system user.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	snapshot, err := api.templatePrebuildsReadinessSnapshot(sysCtx, template.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to fetch prebuilt workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	status := codersdk.TemplatePrebuildsStatus{
+		Prebuilds: []codersdk.PrebuiltWorkspaceStatus{},
+	}
+	for _, preset := range snapshot.Presets {
+		presetSnapshot, err := snapshot.FilterByPreset(preset.ID)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to fetch prebuilt workspaces.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		for _, prebuild := range slices.Concat(presetSnapshot.Running, presetSnapshot.Expired, presetSnapshot.Unhealthy) {
+			prebuildStatus := codersdk.PrebuiltWorkspaceStatus{
+				WorkspaceID:    prebuild.ID,
+				WorkspaceName:  prebuild.Name,
+				PresetID:       preset.ID,
+				PresetName:     preset.Name,
+				AgentsReady:    prebuild.Ready,
+				FailureReasons: []string{},
+				Eligible:       presetSnapshot.IsEligible(prebuild),
+				CreatedAt:      prebuild.CreatedAt,
+			}
+			if presetSnapshot.ReadinessChecks != nil {
+				prebuildStatus.ReadinessStatus = codersdk.PrebuildReadinessStatusPending
+			}
+			if readiness, ok := presetSnapshot.Readiness[prebuild.ID]; ok {
+				prebuildStatus.ReadinessStatus = codersdk.PrebuildReadinessStatus(readiness.Status)
+				prebuildStatus.FailureReasons = append(prebuildStatus.FailureReasons, readiness.FailureReasons...)
+				prebuildStatus.CheckedAt = &readiness.CheckedAt
+			}
+			status.Prebuilds = append(status.Prebuilds, prebuildStatus)
+		}
+	}
+	slices.SortFunc(status.Prebuilds, func(a, b codersdk.PrebuiltWorkspaceStatus) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	httpapi.Write(ctx, rw, http.StatusOK, status)
+}
+
+// templatePrebuildsReadinessSnapshot builds a snapshot of the running prebuilt workspaces of a template
+// along with the readiness checks of their presets and the results of these checks.
+func (api *API) templatePrebuildsReadinessSnapshot(ctx context.Context, templateID uuid.UUID) (agplprebuilds.GlobalSnapshot, error) {
+	templateFilter := uuid.NullUUID{UUID: templateID, Valid: true}
+	presets, err := api.Database.GetTemplatePresetsWithPrebuilds(ctx, templateFilter)
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get template presets: %w", err)
+	}
+
+	running, err := api.Database.GetRunningPrebuiltWorkspaces(ctx)
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get running prebuilt workspaces: %w", err)
+	}
+	running = slices.DeleteFunc(running, func(prebuild database.GetRunningPrebuiltWorkspacesRow) bool {
+		return prebuild.TemplateID != templateID
+	})
+
+	readinessChecks, err := api.Database.GetTemplatePresetReadinessChecks(ctx, templateFilter)
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get preset readiness checks: %w", err)
+	}
+
+	readiness, err := api.Database.GetPrebuildReadiness(ctx, templateFilter)
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get prebuild readiness: %w", err)
+	}
+
+	return agplprebuilds.NewGlobalSnapshot(
+		presets,
+		nil,
+		running,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		readinessChecks,
+		readiness,
+		api.Clock,
+		api.Logger.Named("prebuilds"),
+	), nil
+}
+
+// templatePrebuildsSnapshot builds a snapshot of the presets of a template along with their claim history.
+// The forecast of presets without adaptive prebuilds enabled is built from the default lookback period.
+func (api *API) templatePrebuildsSnapshot(ctx context.Context, templateID uuid.UUID) (agplprebuilds.GlobalSnapshot, error) {
+	presets, err := api.Database.GetTemplatePresetsWithPrebuilds(ctx, uuid.NullUUID{UUID: templateID, Valid: true})
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get template presets: %w", err)
+	}
+
+	schedules, err := api.Database.GetActivePresetPrebuildSchedules(ctx)
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get preset prebuild schedules: %w", err)
+	}
+
+	settings, err := templateAdaptivePrebuildsSettings(ctx, api.Database, templateID)
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get adaptive prebuilds settings: %w", err)
+	}
+
+	claimHistory, err := api.Database.GetPrebuildClaimHistory(ctx, database.GetPrebuildClaimHistoryParams{
+		TemplateIDs: []uuid.UUID{templateID},
+		Since:       api.Clock.Now().Truncate(time.Hour).Add(-time.Duration(settings.LookbackWeeks) * 7 * 24 * time.Hour),
+	})
+	if err != nil {
+		return agplprebuilds.GlobalSnapshot{}, xerrors.Errorf("get prebuild claim history: %w", err)
+	}
+
+	return agplprebuilds.NewGlobalSnapshot(
+		presets,
+		schedules,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		[]database.TemplateAdaptivePrebuild{settings},
+		claimHistory,
+		nil,
+		nil,
+		api.Clock,
+		api.Logger.Named("prebuilds"),
+	), nil
+}
+
+// templateAdaptivePrebuildsSettings returns the adaptive prebuilds settings of a template, or the
+// defaults if the template has never configured them.
+func templateAdaptivePrebuildsSettings(ctx context.Context, db database.Store, templateID uuid.UUID) (database.TemplateAdaptivePrebuild, error) {
+	settings, err := db.GetTemplateAdaptivePrebuildsByTemplateID(ctx, templateID)
+	if xerrors.Is(err, sql.ErrNoRows) {
+		return database.TemplateAdaptivePrebuild{
+			TemplateID:    templateID,
+			LookbackWeeks: agplprebuilds.DefaultAdaptiveLookbackWeeks,
+		}, nil
+	}
+	return settings, err
+}
+
+func convertTemplateAdaptivePrebuilds(settings database.TemplateAdaptivePrebuild) codersdk.TemplateAdaptivePrebuilds {
+	return codersdk.TemplateAdaptivePrebuilds{
+		Enabled:       settings.Enabled,
+		MinInstances:  settings.MinInstances,
+		MaxInstances:  settings.MaxInstances,
+		LookbackWeeks: settings.LookbackWeeks,
+	}
+}
+
+func validatePrebuildReadinessChecks(req codersdk.UpdateTemplatePresetReadinessChecksRequest) []codersdk.ValidationError {
+	var validErrs []codersdk.ValidationError
+	if req.TimeoutMillis <= 0 {
+		validErrs = append(validErrs, codersdk.ValidationError{Field: "timeout_ms", Detail: "Must be greater than 0."})
+	}
+	for i, check := range req.Checks {
+		field := fmt.Sprintf("checks[%d]", i)
+		switch check.Type {
+		case codersdk.PrebuildReadinessCheckTypeScript:
+			if check.Script == "" {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".script", Detail: "Required for script checks."})
+			}
+		case codersdk.PrebuildReadinessCheckTypeMetadata:
+			if check.MetadataKey == "" {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".metadata_key", Detail: "Required for metadata checks."})
+			}
+			if _, err := regexp.Compile(check.MetadataValue); err != nil {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".metadata_value", Detail: fmt.Sprintf("Invalid regular expression: %s.", err)})
+			}
+		case codersdk.PrebuildReadinessCheckTypeApp:
+			if check.App == "" {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".app", Detail: "Required for app checks."})
+			}
+		default:
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field + ".type", Detail: fmt.Sprintf("Unknown readiness check type %q.", check.Type)})
+		}
+	}
+	return validErrs
+}
+
+func convertTemplatePresetReadinessChecks(checks database.TemplatePresetReadinessCheck) codersdk.TemplatePresetReadinessChecks {
+	converted := codersdk.TemplatePresetReadinessChecks{
+		PresetName:    checks.PresetName,
+		Checks:        make([]codersdk.PrebuildReadinessCheck, 0, len(checks.Checks)),
+		TimeoutMillis: time.Duration(checks.Timeout).Milliseconds(),
+		UpdatedAt:     checks.UpdatedAt,
+	}
+	for _, c := range checks.Checks {
+		converted.Checks = append(converted.Checks, codersdk.PrebuildReadinessCheck{
+			Type:          codersdk.PrebuildReadinessCheckType(c.Type),
+			Script:        c.Script,
+			MetadataKey:   c.MetadataKey,
+			MetadataValue: c.MetadataValue,
+			App:           c.App,
+		})
+	}
+	return converted
+}
@@ -26,6 +26,8 @@ const (
 	MetricDesiredGauge              = namespace + "desired"
 	MetricRunningGauge              = namespace + "running"
 	MetricEligibleGauge             = namespace + "eligible"
+	MetricClaimsForecastGauge       = namespace + "claims_forecast"
+	MetricRecentClaimsGauge         = namespace + "claims_last_hour"
 	MetricPresetHardLimitedGauge    = namespace + "preset_hard_limited"
 	MetricLastUpdatedGauge          = namespace + "metrics_last_updated"
 	MetricReconciliationPausedGauge = namespace + "reconciliation_paused"
@@ -83,6 +85,20 @@ var (
 		labels,
 		nil,
 	)
+	claimsForecastDesc = prometheus.NewDesc(
+		MetricClaimsForecastGauge,
+		"Forecasted number of prebuilt workspaces claimed during the current hour, for template presets with adaptive "+
+			"pool sizing. The forecast is the average number of claims during the same hour of the week in the template's "+
+			"lookback period.",
+		labels,
+		nil,
+	)
+	recentClaimsDesc = prometheus.NewDesc(
+		MetricRecentClaimsGauge,
+		"Number of prebuilt workspaces claimed during the last hour, for template presets with adaptive pool sizing.",
+		labels,
+		nil,
+	)
 	presetHardLimitedDesc = prometheus.NewDesc(
 		MetricPresetHardLimitedGauge,
 		"Indicates whether a given preset has reached the hard failure limit (1 = hard-limited). Metric is omitted otherwise.",
@@ -147,6 +163,8 @@ func (*MetricsCollector) Describe(descCh chan<- *prometheus.Desc) {
 	descCh <- desiredPrebuildsDesc
 	descCh <- runningPrebuildsDesc
 	descCh <- eligiblePrebuildsDesc
+	descCh <- claimsForecastDesc
+	descCh <- recentClaimsDesc
 	descCh <- presetHardLimitedDesc
 	descCh <- lastUpdateDesc
 	descCh <- reconciliationPausedDesc
@@ -203,6 +221,11 @@ func (mc *MetricsCollector) Collect(metricsCh chan<- prometheus.Metric) {
 		metricsCh <- prometheus.MustNewConstMetric(desiredPrebuildsDesc, prometheus.GaugeValue, float64(state.Desired), preset.TemplateName, preset.Name, preset.OrganizationName)
 		metricsCh <- prometheus.MustNewConstMetric(runningPrebuildsDesc, prometheus.GaugeValue, float64(state.Actual), preset.TemplateName, preset.Name, preset.OrganizationName)
 		metricsCh <- prometheus.MustNewConstMetric(eligiblePrebuildsDesc, prometheus.GaugeValue, float64(state.Eligible), preset.TemplateName, preset.Name, preset.OrganizationName)
+
+		if presetSnapshot.IsAdaptive() {
+			metricsCh <- prometheus.MustNewConstMetric(claimsForecastDesc, prometheus.GaugeValue, state.ForecastClaims, preset.TemplateName, preset.Name, preset.OrganizationName)
+			metricsCh <- prometheus.MustNewConstMetric(recentClaimsDesc, prometheus.GaugeValue, float64(state.RecentClaims), preset.TemplateName, preset.Name, preset.OrganizationName)
+		}
 	}
 
 	mc.isPresetHardLimitedMu.Lock()
@@ -0,0 +1,140 @@
+package prebuilds
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/v3"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/prebuilds"
+)
+
+// checkPrebuildReadiness evaluates the readiness checks of running prebuilt workspaces whose latest build has
+// no final result yet, stores the results, and updates the snapshot so that the presets are reconciled accordingly.
+// A result is final once the checks passed or failed, unless the checks were changed since.
+//
+// Errors are logged rather than returned, so that a prebuilt workspace that cannot be checked does not block the
+// reconciliation of other presets. Such a prebuilt workspace cannot be claimed until it is checked.
+//
+// NOTE: Read operations must use db (the lock transaction) while write operations must use c.store.
+func (c *StoreReconciler) checkPrebuildReadiness(ctx context.Context, db database.Store, snapshot *prebuilds.GlobalSnapshot) {
+	if len(snapshot.ReadinessChecks) > 0 {
+		presets := make(map[uuid.UUID]database.GetTemplatePresetsWithPrebuildsRow, len(snapshot.Presets))
+		for _, preset := range snapshot.Presets {
+			presets[preset.ID] = preset
+		}
+
+		for _, prebuild := range snapshot.RunningPrebuilds {
+			if !prebuild.CurrentPresetID.Valid {
+				continue
+			}
+			preset, ok := presets[prebuild.CurrentPresetID.UUID]
+			if !ok {
+				continue
+			}
+			checks := snapshot.ReadinessChecksFor(preset.TemplateID, preset.Name)
+			if checks == nil {
+				continue
+			}
+			if result, ok := snapshot.PrebuildReadinessMap[prebuild.ID]; ok &&
+				result.Status != database.PrebuildReadinessStatusPending &&
+				!result.CheckedAt.Before(checks.UpdatedAt) {
+				continue
+			}
+
+			result, err := c.evaluatePrebuildReadiness(ctx, db, prebuild, *checks)
+			if err != nil {
+				c.logger.Error(ctx, "failed to evaluate prebuild readiness",
+					slog.F("workspace_id", prebuild.ID), slog.F("preset_id", preset.ID), slog.Error(err))
+				continue
+			}
+			if err := c.store.UpsertPrebuildReadiness(ctx, database.UpsertPrebuildReadinessParams{
+				WorkspaceID:    result.WorkspaceID,
+				BuildID:        result.BuildID,
+				Status:         result.Status,
+				FailureReasons: result.FailureReasons,
+				CheckedAt:      result.CheckedAt,
+			}); err != nil {
+				c.logger.Error(ctx, "failed to store prebuild readiness",
+					slog.F("workspace_id", prebuild.ID), slog.F("preset_id", preset.ID), slog.Error(err))
+				continue
+			}
+			if result.Status == database.PrebuildReadinessStatusFailed {
+				c.logger.Warn(ctx, "prebuilt workspace failed its readiness checks and will be replaced",
+					slog.F("workspace_id", prebuild.ID), slog.F("preset_id", preset.ID),
+					slog.F("failure_reasons", result.FailureReasons))
+			}
+			snapshot.PrebuildReadinessMap[prebuild.ID] = result
+		}
+	}
+
+	// Results of claimed and deleted prebuilt workspaces are no longer needed.
+	if err := c.store.DeleteStalePrebuildReadiness(ctx); err != nil {
+		c.logger.Error(ctx, "failed to delete stale prebuild readiness", slog.Error(err))
+	}
+}
+
+// evaluatePrebuildReadiness evaluates the readiness checks against the latest build of a prebuilt workspace.
+func (c *StoreReconciler) evaluatePrebuildReadiness(ctx context.Context, db database.Store, prebuild database.GetRunningPrebuiltWorkspacesRow, checks database.TemplatePresetReadinessCheck) (database.WorkspacePrebuildReadiness, error) {
+	// nolint:gocritic // Necessary to query the agents, scripts and apps of prebuilt workspaces.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+
+	build, err := db.GetLatestWorkspaceBuildByWorkspaceID(ctx, prebuild.ID)
+	if err != nil {
+		return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get latest build: %w", err)
+	}
+
+	job, err := db.GetProvisionerJobByID(ctx, build.JobID)
+	if err != nil {
+		return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get build job: %w", err)
+	}
+
+	var in prebuilds.ReadinessInput
+	if job.CompletedAt.Valid {
+		in.BuildCompletedAt = job.CompletedAt.Time
+	}
+	in.Agents, err = db.GetWorkspaceAgentsInLatestBuildByWorkspaceID(ctx, prebuild.ID)
+	if err != nil {
+		return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get agents: %w", err)
+	}
+	agentIDs := make([]uuid.UUID, 0, len(in.Agents))
+	for _, agent := range in.Agents {
+		agentIDs = append(agentIDs, agent.ID)
+
+		metadata, err := db.GetWorkspaceAgentMetadata(ctx, database.GetWorkspaceAgentMetadataParams{
+			WorkspaceAgentID: agent.ID,
+		})
+		if err != nil {
+			return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get metadata of agent %q: %w", agent.Name, err)
+		}
+		in.Metadata = append(in.Metadata, metadata...)
+	}
+	in.Scripts, err = db.GetWorkspaceAgentScriptsByAgentIDs(ctx, agentIDs)
+	if err != nil {
+		return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get scripts: %w", err)
+	}
+	in.ScriptTimings, err = db.GetWorkspaceAgentScriptTimingsByBuildID(ctx, build.ID)
+	if err != nil {
+		return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get script timings: %w", err)
+	}
+	in.Apps, err = db.GetWorkspaceAppsByAgentIDs(ctx, agentIDs)
+	if err != nil {
+		return database.WorkspacePrebuildReadiness{}, xerrors.Errorf("get apps: %w", err)
+	}
+
+	now := c.clock.Now()
+	status, reasons := prebuilds.EvaluateReadiness(checks, in, now)
+	if reasons == nil {
+		reasons = []string{}
+	}
+	return database.WorkspacePrebuildReadiness{
+		WorkspaceID:    prebuild.ID,
+		BuildID:        build.ID,
+		Status:         status,
+		FailureReasons: reasons,
+		CheckedAt:      now,
+	}, nil
+}
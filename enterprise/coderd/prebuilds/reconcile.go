@@ -414,6 +414,10 @@ func (c *StoreReconciler) ReconcileAll(ctx context.Context) (stats prebuilds.Rec
 
 		c.reportHardLimitedPresets(snapshot)
 
+		// Readiness checks are evaluated before presets are reconciled, so that prebuilt workspaces
+		// which failed them are replaced within the same reconciliation cycle.
+		c.checkPrebuildReadiness(ctx, db, snapshot)
+
 		if len(snapshot.Presets) == 0 {
 			logger.Debug(ctx, "no templates found with prebuilds configured")
 			return nil
@@ -568,6 +572,26 @@ func (c *StoreReconciler) SnapshotState(ctx context.Context, store database.Stor
 			return xerrors.Errorf("failed to get hard limited presets: %w", err)
 		}
 
+		adaptivePrebuilds, err := db.GetEnabledTemplateAdaptivePrebuilds(ctx)
+		if err != nil {
+			return xerrors.Errorf("failed to get adaptive prebuilds settings: %w", err)
+		}
+
+		claimHistory, err := c.claimHistory(ctx, db, adaptivePrebuilds)
+		if err != nil {
+			return xerrors.Errorf("failed to get prebuild claim history: %w", err)
+		}
+
+		readinessChecks, err := db.GetTemplatePresetReadinessChecks(ctx, uuid.NullUUID{})
+		if err != nil {
+			return xerrors.Errorf("failed to get preset readiness checks: %w", err)
+		}
+
+		prebuildReadiness, err := db.GetPrebuildReadiness(ctx, uuid.NullUUID{})
+		if err != nil {
+			return xerrors.Errorf("failed to get prebuild readiness: %w", err)
+		}
+
 		state = prebuilds.NewGlobalSnapshot(
 			presetsWithPrebuilds,
 			presetPrebuildSchedules,
@@ -576,6 +600,10 @@ func (c *StoreReconciler) SnapshotState(ctx context.Context, store database.Stor
 			allPendingPrebuilds,
 			presetsBackoff,
 			hardLimitedPresets,
+			adaptivePrebuilds,
+			claimHistory,
+			readinessChecks,
+			prebuildReadiness,
 			c.clock,
 			c.logger,
 		)
@@ -589,6 +617,26 @@ func (c *StoreReconciler) SnapshotState(ctx context.Context, store database.Stor
 	return &state, err
 }
 
+// claimHistory fetches the claims needed to forecast the desired instances of templates with adaptive
+// prebuilds enabled. History is only fetched as far back as the longest lookback period.
+func (c *StoreReconciler) claimHistory(ctx context.Context, db database.Store, adaptivePrebuilds []database.TemplateAdaptivePrebuild) ([]database.GetPrebuildClaimHistoryRow, error) {
+	if len(adaptivePrebuilds) == 0 {
+		return nil, nil
+	}
+
+	var lookbackWeeks int32
+	templateIDs := make([]uuid.UUID, 0, len(adaptivePrebuilds))
+	for _, adaptive := range adaptivePrebuilds {
+		templateIDs = append(templateIDs, adaptive.TemplateID)
+		lookbackWeeks = max(lookbackWeeks, adaptive.LookbackWeeks)
+	}
+
+	return db.GetPrebuildClaimHistory(ctx, database.GetPrebuildClaimHistoryParams{
+		TemplateIDs: templateIDs,
+		Since:       c.clock.Now().Truncate(time.Hour).Add(-time.Duration(lookbackWeeks) * 7 * 24 * time.Hour),
+	})
+}
+
 func (c *StoreReconciler) ReconcilePreset(ctx context.Context, ps prebuilds.PresetSnapshot) error {
 	ctx, span := c.tracer.Start(ctx, "prebuilds.ReconcilePreset", trace.WithAttributes(
 		attribute.String("preset_id", ps.Preset.ID.String()),
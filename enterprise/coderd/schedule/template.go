@@ -99,9 +99,94 @@ func (*EnterpriseTemplateScheduleStore) Get(ctx context.Context, db database.Sto
 		FailureTTL:               time.Duration(tpl.FailureTTL),
 		TimeTilDormant:           time.Duration(tpl.TimeTilDormant),
 		TimeTilDormantAutoDelete: time.Duration(tpl.TimeTilDormantAutoDelete),
+		HibernateIdleThreshold:   time.Duration(tpl.HibernateIdleThreshold),
 	}, nil
 }
 
+// GetForUser implements agpl.TemplateScheduleStore.
+func (s *EnterpriseTemplateScheduleStore) GetForUser(ctx context.Context, db database.Store, templateID uuid.UUID, userID uuid.UUID) (agpl.TemplateScheduleOptions, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	opts, err := s.Get(ctx, db, templateID)
+	if err != nil {
+		return agpl.TemplateScheduleOptions{}, err
+	}
+
+	overrides, err := db.GetTemplateGroupScheduleOverridesByUserID(ctx, database.GetTemplateGroupScheduleOverridesByUserIDParams{
+		TemplateID: templateID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return agpl.TemplateScheduleOptions{}, xerrors.Errorf("get group schedule overrides: %w", err)
+	}
+
+	return ApplyGroupScheduleOverrides(opts, overrides)
+}
+
+// ApplyGroupScheduleOverrides restricts the template scheduling options with
+// the schedule overrides of the groups a user belongs to. Overrides can only
+// make the schedule stricter, and when several overrides apply the strictest
+// value of each setting is used:
+//   - the smallest max TTL, which also caps the default TTL,
+//   - the union of the days autostart is blocked on,
+//   - the union of the autostop requirement days, with the smallest week
+//     interval.
+func ApplyGroupScheduleOverrides(opts agpl.TemplateScheduleOptions, overrides []database.TemplateGroupScheduleOverride) (agpl.TemplateScheduleOptions, error) {
+	var (
+		blockedDays  uint8
+		autostopDays uint8
+		weeks        int64
+	)
+	for _, o := range overrides {
+		// These checks have to be done before the conversion because we lose
+		// precision and signs when converting from the database types.
+		if o.AutostartBlockDaysOfWeek < 0 || o.AutostartBlockDaysOfWeek > 0b01111111 {
+			return agpl.TemplateScheduleOptions{}, xerrors.Errorf("invalid autostart block days %d in override for group %s", o.AutostartBlockDaysOfWeek, o.GroupID)
+		}
+		if o.AutostopRequirementDaysOfWeek < 0 || o.AutostopRequirementDaysOfWeek > 0b01111111 {
+			return agpl.TemplateScheduleOptions{}, xerrors.Errorf("invalid autostop requirement days %d in override for group %s", o.AutostopRequirementDaysOfWeek, o.GroupID)
+		}
+
+		if o.MaxTTL > 0 && (opts.MaxTTL == 0 || time.Duration(o.MaxTTL) < opts.MaxTTL) {
+			opts.MaxTTL = time.Duration(o.MaxTTL)
+		}
+		// #nosec G115 - Safe conversion as we've verified the value is <= 127
+		blockedDays |= uint8(o.AutostartBlockDaysOfWeek)
+		// #nosec G115 - Safe conversion as we've verified the value is <= 127
+		autostopDays |= uint8(o.AutostopRequirementDaysOfWeek)
+		if o.AutostopRequirementWeeks > 0 && (weeks == 0 || o.AutostopRequirementWeeks < weeks) {
+			weeks = o.AutostopRequirementWeeks
+		}
+	}
+
+	if opts.MaxTTL > 0 && (opts.DefaultTTL == 0 || opts.DefaultTTL > opts.MaxTTL) {
+		opts.DefaultTTL = opts.MaxTTL
+	}
+
+	opts.AutostartRequirement.DaysOfWeek &^= blockedDays
+
+	if autostopDays != 0 || opts.AutostopRequirement.DaysOfWeek != 0 {
+		// The template's week interval only matters if the template has an
+		// autostop requirement of its own.
+		if opts.AutostopRequirement.DaysOfWeek == 0 {
+			opts.AutostopRequirement.Weeks = 1
+			if weeks > 0 {
+				opts.AutostopRequirement.Weeks = weeks
+			}
+		} else if weeks > 0 && weeks < opts.AutostopRequirement.Weeks {
+			opts.AutostopRequirement.Weeks = weeks
+		}
+		opts.AutostopRequirement.DaysOfWeek |= autostopDays
+	}
+
+	err := agpl.VerifyTemplateAutostopRequirement(opts.AutostopRequirement.DaysOfWeek, opts.AutostopRequirement.Weeks)
+	if err != nil {
+		return agpl.TemplateScheduleOptions{}, err
+	}
+	return opts, nil
+}
+
 // Set implements agpl.TemplateScheduleStore.
 func (s *EnterpriseTemplateScheduleStore) Set(ctx context.Context, db database.Store, tpl database.Template, opts agpl.TemplateScheduleOptions) (database.Template, error) {
 	ctx, span := tracing.StartSpan(ctx)
@@ -296,6 +381,16 @@ func (s *EnterpriseTemplateScheduleStore) Set(ctx context.Context, db database.S
 	return template, nil
 }
 
+// UpdateWorkspaceBuilds recalculates the max_deadline and deadline of all
+// running workspace builds on the template. It must be called after a change
+// that affects the effective schedule of the template's workspaces, such as a
+// group schedule override.
+func (s *EnterpriseTemplateScheduleStore) UpdateWorkspaceBuilds(ctx context.Context, db database.Store, template database.Template) error {
+	return db.InTx(func(tx database.Store) error {
+		return s.updateWorkspaceBuilds(ctx, tx, template)
+	}, nil)
+}
+
 func (s *EnterpriseTemplateScheduleStore) updateWorkspaceBuilds(ctx context.Context, db database.Store, template database.Template) error {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.End()
@@ -383,7 +478,7 @@ func (s *EnterpriseTemplateScheduleStore) updateWorkspaceBuild(ctx context.Conte
 	}
 
 	if workspace.AutostartSchedule.Valid {
-		templateScheduleOptions, err := s.Get(ctx, db, workspace.TemplateID)
+		templateScheduleOptions, err := s.GetForUser(ctx, db, workspace.TemplateID, workspace.OwnerID)
 		if err != nil {
 			return xerrors.Errorf("get template schedule options: %w", err)
 		}
@@ -1378,3 +1378,116 @@ func must[V any](v V, err error) V {
 	}
 	return v
 }
+
+func TestApplyGroupScheduleOverrides(t *testing.T) {
+	t.Parallel()
+
+	groupA := uuid.New()
+	groupB := uuid.New()
+	base := agplschedule.TemplateScheduleOptions{
+		UserAutostartEnabled: true,
+		UserAutostopEnabled:  true,
+		DefaultTTL:           8 * time.Hour,
+		AutostartRequirement: agplschedule.TemplateAutostartRequirement{
+			DaysOfWeek: 0b01111111,
+		},
+		AutostopRequirement: agplschedule.TemplateAutostopRequirement{
+			DaysOfWeek: 0,
+			Weeks:      1,
+		},
+	}
+
+	cases := []struct {
+		name      string
+		base      agplschedule.TemplateScheduleOptions
+		overrides []database.TemplateGroupScheduleOverride
+		expected  agplschedule.TemplateScheduleOptions
+		errorMsg  string
+	}{
+		{
+			name:     "NoOverrides",
+			base:     base,
+			expected: base,
+		},
+		{
+			name: "MaxTTLCapsDefaultTTL",
+			base: base,
+			overrides: []database.TemplateGroupScheduleOverride{
+				{GroupID: groupA, MaxTTL: int64(4 * time.Hour)},
+			},
+			expected: func(o agplschedule.TemplateScheduleOptions) agplschedule.TemplateScheduleOptions {
+				o.MaxTTL = 4 * time.Hour
+				o.DefaultTTL = 4 * time.Hour
+				return o
+			}(base),
+		},
+		{
+			name: "MaxTTLAppliesWithoutDefaultTTL",
+			base: func(o agplschedule.TemplateScheduleOptions) agplschedule.TemplateScheduleOptions {
+				o.DefaultTTL = 0
+				return o
+			}(base),
+			overrides: []database.TemplateGroupScheduleOverride{
+				{GroupID: groupA, MaxTTL: int64(12 * time.Hour)},
+			},
+			expected: func(o agplschedule.TemplateScheduleOptions) agplschedule.TemplateScheduleOptions {
+				o.MaxTTL = 12 * time.Hour
+				o.DefaultTTL = 12 * time.Hour
+				return o
+			}(base),
+		},
+		{
+			name: "StrictestOfSeveralGroups",
+			base: func(o agplschedule.TemplateScheduleOptions) agplschedule.TemplateScheduleOptions {
+				o.AutostopRequirement = agplschedule.TemplateAutostopRequirement{DaysOfWeek: 0b01000000, Weeks: 2}
+				return o
+			}(base),
+			overrides: []database.TemplateGroupScheduleOverride{
+				// Block weekends, restart on Fridays.
+				{GroupID: groupA, MaxTTL: int64(6 * time.Hour), AutostartBlockDaysOfWeek: 0b01100000, AutostopRequirementDaysOfWeek: 0b00010000},
+				// Block Mondays, restart weekly.
+				{GroupID: groupB, MaxTTL: int64(2 * time.Hour), AutostartBlockDaysOfWeek: 0b00000001, AutostopRequirementWeeks: 1},
+			},
+			expected: func(o agplschedule.TemplateScheduleOptions) agplschedule.TemplateScheduleOptions {
+				o.MaxTTL = 2 * time.Hour
+				o.DefaultTTL = 2 * time.Hour
+				o.AutostartRequirement.DaysOfWeek = 0b00011110
+				o.AutostopRequirement = agplschedule.TemplateAutostopRequirement{DaysOfWeek: 0b01010000, Weeks: 1}
+				return o
+			}(base),
+		},
+		{
+			name: "AutostopRequirementWithoutTemplateRequirement",
+			base: base,
+			overrides: []database.TemplateGroupScheduleOverride{
+				{GroupID: groupA, AutostopRequirementDaysOfWeek: 0b00000001, AutostopRequirementWeeks: 3},
+			},
+			expected: func(o agplschedule.TemplateScheduleOptions) agplschedule.TemplateScheduleOptions {
+				o.AutostopRequirement = agplschedule.TemplateAutostopRequirement{DaysOfWeek: 0b00000001, Weeks: 3}
+				return o
+			}(base),
+		},
+		{
+			name: "InvalidDays",
+			base: base,
+			overrides: []database.TemplateGroupScheduleOverride{
+				{GroupID: groupA, AutostartBlockDaysOfWeek: 0b10000000},
+			},
+			errorMsg: "invalid autostart block days",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := schedule.ApplyGroupScheduleOverrides(c.base, c.overrides)
+			if c.errorMsg != "" {
+				require.ErrorContains(t, err, c.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, opts)
+		})
+	}
+}
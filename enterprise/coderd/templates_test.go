@@ -2326,3 +2326,191 @@ func TestInvalidateTemplatePrebuilds_LicenseFeatureDisabled(t *testing.T) {
 	require.ErrorAs(t, err, &sdkError)
 	require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
 }
+
+func TestTemplateAdaptivePrebuilds(t *testing.T) {
+	t.Parallel()
+
+	// Given the template versions and template...
+	auditor := audit.NewMock()
+	ownerClient, owner := coderdenttest.New(t, &coderdenttest.Options{
+		AuditLogging: true,
+		Options: &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			Auditor:                  auditor,
+		},
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureWorkspacePrebuilds: 1,
+				codersdk.FeatureAuditLog:           1,
+			},
+		},
+	})
+	templateAdminClient, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID, rbac.RoleTemplateAdmin())
+	regularUserClient, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	version := coderdtest.CreateTemplateVersion(t, templateAdminClient, owner.OrganizationID, &echo.Responses{
+		Parse: echo.ParseComplete, ProvisionPlan: echo.PlanComplete, ProvisionApply: echo.ApplyComplete,
+	})
+	coderdtest.AwaitTemplateVersionJobCompleted(t, templateAdminClient, version.ID)
+	template := coderdtest.CreateTemplate(t, templateAdminClient, owner.OrganizationID, version.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// Then the defaults are returned for a template that has not configured adaptive prebuilds.
+	settings, err := templateAdminClient.TemplateAdaptivePrebuilds(ctx, template.ID)
+	require.NoError(t, err)
+	require.Equal(t, codersdk.TemplateAdaptivePrebuilds{LookbackWeeks: 4}, settings)
+
+	// When the settings are updated
+	want := codersdk.TemplateAdaptivePrebuilds{
+		Enabled:       true,
+		MinInstances:  1,
+		MaxInstances:  5,
+		LookbackWeeks: 2,
+	}
+	settings, err = templateAdminClient.UpdateTemplateAdaptivePrebuilds(ctx, template.ID, want)
+	require.NoError(t, err)
+	require.Equal(t, want, settings)
+	require.True(t, auditor.Contains(t, database.AuditLog{
+		Action:         database.AuditActionWrite,
+		ResourceType:   database.ResourceTypeTemplateAdaptivePrebuilds,
+		ResourceID:     template.ID,
+		OrganizationID: owner.OrganizationID,
+	}))
+
+	// Then they are persisted.
+	settings, err = templateAdminClient.TemplateAdaptivePrebuilds(ctx, template.ID)
+	require.NoError(t, err)
+	require.Equal(t, want, settings)
+
+	// Invalid bounds are rejected.
+	_, err = templateAdminClient.UpdateTemplateAdaptivePrebuilds(ctx, template.ID, codersdk.TemplateAdaptivePrebuilds{
+		Enabled:       true,
+		MinInstances:  5,
+		MaxInstances:  1,
+		LookbackWeeks: 0,
+	})
+	var sdkError *codersdk.Error
+	require.ErrorAs(t, err, &sdkError)
+	require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	require.Len(t, sdkError.Validations, 2)
+
+	// Regular users cannot update the settings.
+	_, err = regularUserClient.UpdateTemplateAdaptivePrebuilds(ctx, template.ID, want)
+	require.ErrorAs(t, err, &sdkError)
+	require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+
+	// The forecast covers the past and the next 24 hours of each preset. The template
+	// has no presets, so there is nothing to forecast.
+	forecast, err := templateAdminClient.TemplatePrebuildsForecast(ctx, template.ID)
+	require.NoError(t, err)
+	require.NotNil(t, forecast.Presets)
+	require.Empty(t, forecast.Presets)
+}
+
+func TestTemplatePresetReadinessChecks(t *testing.T) {
+	t.Parallel()
+
+	auditor := audit.NewMock()
+	ownerClient, owner := coderdenttest.New(t, &coderdenttest.Options{
+		AuditLogging: true,
+		Options: &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			Auditor:                  auditor,
+		},
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureWorkspacePrebuilds: 1,
+				codersdk.FeatureAuditLog:           1,
+			},
+		},
+	})
+	templateAdminClient, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID, rbac.RoleTemplateAdmin())
+	regularUserClient, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	version := coderdtest.CreateTemplateVersion(t, templateAdminClient, owner.OrganizationID, &echo.Responses{
+		Parse: echo.ParseComplete, ProvisionPlan: echo.PlanComplete, ProvisionApply: echo.ApplyComplete,
+	})
+	coderdtest.AwaitTemplateVersionJobCompleted(t, templateAdminClient, version.ID)
+	template := coderdtest.CreateTemplate(t, templateAdminClient, owner.OrganizationID, version.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// No readiness checks are configured by default.
+	checks, err := templateAdminClient.TemplatePresetReadinessChecks(ctx, template.ID)
+	require.NoError(t, err)
+	require.Empty(t, checks)
+
+	// When readiness checks are configured for a preset
+	req := codersdk.UpdateTemplatePresetReadinessChecksRequest{
+		Checks: []codersdk.PrebuildReadinessCheck{
+			{Type: codersdk.PrebuildReadinessCheckTypeScript, Script: "Clone repository"},
+			{Type: codersdk.PrebuildReadinessCheckTypeMetadata, MetadataKey: "cache", MetadataValue: "^warm$"},
+			{Type: codersdk.PrebuildReadinessCheckTypeApp, App: "code-server"},
+		},
+		TimeoutMillis: (5 * time.Minute).Milliseconds(),
+	}
+	updated, err := templateAdminClient.UpdateTemplatePresetReadinessChecks(ctx, template.ID, "default", req)
+	require.NoError(t, err)
+	require.Equal(t, "default", updated.PresetName)
+	require.Equal(t, req.Checks, updated.Checks)
+	require.Equal(t, req.TimeoutMillis, updated.TimeoutMillis)
+	require.True(t, auditor.Contains(t, database.AuditLog{
+		Action:         database.AuditActionWrite,
+		ResourceType:   database.ResourceTypeTemplatePresetReadinessChecks,
+		ResourceID:     template.ID,
+		ResourceTarget: "default",
+		OrganizationID: owner.OrganizationID,
+	}))
+
+	// Then they are persisted.
+	checks, err = templateAdminClient.TemplatePresetReadinessChecks(ctx, template.ID)
+	require.NoError(t, err)
+	require.Len(t, checks, 1)
+	require.Equal(t, req.Checks, checks[0].Checks)
+
+	// Invalid checks are rejected.
+	_, err = templateAdminClient.UpdateTemplatePresetReadinessChecks(ctx, template.ID, "default", codersdk.UpdateTemplatePresetReadinessChecksRequest{
+		Checks: []codersdk.PrebuildReadinessCheck{
+			{Type: codersdk.PrebuildReadinessCheckTypeMetadata, MetadataKey: "cache", MetadataValue: "("},
+			{Type: "unknown"},
+		},
+	})
+	var sdkError *codersdk.Error
+	require.ErrorAs(t, err, &sdkError)
+	require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	require.Len(t, sdkError.Validations, 3)
+
+	// Regular users cannot update the checks or see the prebuilds status.
+	_, err = regularUserClient.UpdateTemplatePresetReadinessChecks(ctx, template.ID, "default", req)
+	require.ErrorAs(t, err, &sdkError)
+	require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+	_, err = regularUserClient.TemplatePrebuildsStatus(ctx, template.ID)
+	require.ErrorAs(t, err, &sdkError)
+	require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+
+	// The template has no prebuilt workspaces.
+	status, err := templateAdminClient.TemplatePrebuildsStatus(ctx, template.ID)
+	require.NoError(t, err)
+	require.NotNil(t, status.Prebuilds)
+	require.Empty(t, status.Prebuilds)
+
+	// When the checks are deleted, they are no longer returned.
+	err = templateAdminClient.DeleteTemplatePresetReadinessChecks(ctx, template.ID, "default")
+	require.NoError(t, err)
+	require.True(t, auditor.Contains(t, database.AuditLog{
+		Action:         database.AuditActionDelete,
+		ResourceType:   database.ResourceTypeTemplatePresetReadinessChecks,
+		ResourceID:     template.ID,
+		ResourceTarget: "default",
+		OrganizationID: owner.OrganizationID,
+	}))
+	checks, err = templateAdminClient.TemplatePresetReadinessChecks(ctx, template.ID)
+	require.NoError(t, err)
+	require.Empty(t, checks)
+
+	// Deleting checks that do not exist fails.
+	err = templateAdminClient.DeleteTemplatePresetReadinessChecks(ctx, template.ID, "default")
+	require.ErrorAs(t, err, &sdkError)
+	require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+}
@@ -0,0 +1,303 @@
+package coderd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	agplschedule "github.com/coder/coder/v2/coderd/schedule"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get template group schedule overrides
+// @ID get-template-group-schedule-overrides
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {array} codersdk.TemplateGroupScheduleOverride
+// @Router /templates/{template}/schedule/groups [get]
+func (api *API) templateGroupScheduleOverrides(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	overrides, err := api.Database.GetTemplateGroupScheduleOverrides(ctx, template.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	res := make([]codersdk.TemplateGroupScheduleOverride, 0, len(overrides))
+	for _, override := range overrides {
+		res = append(res, convertTemplateGroupScheduleOverride(override))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, res)
+}
+
+// @Summary Upsert template group schedule override
+// @ID upsert-template-group-schedule-override
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param template path string true "Template ID" format(uuid)
+// @Param group path string true "Group ID" format(uuid)
+// @Param request body codersdk.UpsertTemplateGroupScheduleOverrideRequest true "Schedule override"
+// @Success 200 {object} codersdk.TemplateGroupScheduleOverride
+// @Router /templates/{template}/schedule/groups/{group} [put]
+func (api *API) putTemplateGroupScheduleOverride(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		group             = httpmw.GroupParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplateGroupScheduleOverride](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	var req codersdk.UpsertTemplateGroupScheduleOverrideRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if group.OrganizationID != template.OrganizationID {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Group must belong to the same organization as the template.",
+		})
+		return
+	}
+
+	var validErrs []codersdk.ValidationError
+	if req.MaxTTLMillis < 0 {
+		validErrs = append(validErrs, codersdk.ValidationError{Field: "max_ttl_ms", Detail: "Must be a positive integer."})
+	} else if req.MaxTTLMillis > maxTemplateGroupScheduleOverrideTTL.Milliseconds() {
+		validErrs = append(validErrs, codersdk.ValidationError{Field: "max_ttl_ms", Detail: "Must be at most 30 days."})
+	}
+	blockedDays, err := codersdk.WeekdaysToBitmap(req.AutostartBlockedDays)
+	if err != nil {
+		validErrs = append(validErrs, codersdk.ValidationError{Field: "autostart_blocked_days", Detail: err.Error()})
+	}
+	var (
+		autostopDays  uint8
+		autostopWeeks int64
+	)
+	if req.AutostopRequirement != nil {
+		autostopDays, err = codersdk.WeekdaysToBitmap(req.AutostopRequirement.DaysOfWeek)
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "autostop_requirement.days_of_week", Detail: err.Error()})
+		}
+		autostopWeeks = req.AutostopRequirement.Weeks
+		if autostopWeeks < 0 || autostopWeeks > agplschedule.MaxTemplateAutostopRequirementWeeks {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "autostop_requirement.weeks", Detail: "Must be between 0 and 16."})
+		}
+	}
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid request to update template group schedule override!",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	overrides, err := api.Database.GetTemplateGroupScheduleOverrides(ctx, template.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	for _, override := range overrides {
+		if override.GroupID == group.ID {
+			aReq.Old = override
+			break
+		}
+	}
+
+	now := dbtime.Now()
+	override, err := api.Database.UpsertTemplateGroupScheduleOverride(ctx, database.UpsertTemplateGroupScheduleOverrideParams{
+		TemplateID:                    template.ID,
+		GroupID:                       group.ID,
+		MaxTTL:                        int64(time.Duration(req.MaxTTLMillis) * time.Millisecond),
+		AutostartBlockDaysOfWeek:      int16(blockedDays),
+		AutostopRequirementDaysOfWeek: int16(autostopDays),
+		AutostopRequirementWeeks:      autostopWeeks,
+		CreatedAt:                     now,
+		UpdatedAt:                     now,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.New = override
+
+	if err := api.updateTemplateWorkspaceBuilds(ctx, template); err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateGroupScheduleOverride(override))
+}
+
+// @Summary Delete template group schedule override
+// @ID delete-template-group-schedule-override
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param template path string true "Template ID" format(uuid)
+// @Param group path string true "Group ID" format(uuid)
+// @Success 204
+// @Router /templates/{template}/schedule/groups/{group} [delete]
+func (api *API) deleteTemplateGroupScheduleOverride(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		group             = httpmw.GroupParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplateGroupScheduleOverride](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	override, err := api.Database.DeleteTemplateGroupScheduleOverride(ctx, database.DeleteTemplateGroupScheduleOverrideParams{
+		TemplateID: template.ID,
+		GroupID:    group.ID,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.Old = override
+
+	if err := api.updateTemplateWorkspaceBuilds(ctx, template); err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusNoContent, nil)
+}
+
+// @Summary Get effective template schedule for user
+// @ID get-effective-template-schedule-for-user
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param template path string true "Template ID" format(uuid)
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.UserTemplateSchedule
+// @Router /templates/{template}/schedule/users/{user} [get]
+func (api *API) userTemplateSchedule(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+		user     = httpmw.UserParam(r)
+	)
+
+	opts, err := (*api.AGPL.TemplateScheduleStore.Load()).GetForUser(ctx, api.Database, template.ID, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	overrides, err := api.Database.GetTemplateGroupScheduleOverridesByUserID(ctx, database.GetTemplateGroupScheduleOverridesByUserIDParams{
+		TemplateID: template.ID,
+		UserID:     user.ID,
+	})
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	groupIDs := make([]uuid.UUID, 0, len(overrides))
+	for _, override := range overrides {
+		groupIDs = append(groupIDs, override.GroupID)
+	}
+
+	quietHours, err := (*api.AGPL.UserQuietHoursScheduleStore.Load()).Get(ctx, api.Database, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	var quietHoursSchedule string
+	if quietHours.Schedule != nil {
+		quietHoursSchedule = quietHours.Schedule.String()
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.UserTemplateSchedule{
+		TemplateID:         template.ID,
+		UserID:             user.ID,
+		GroupIDs:           groupIDs,
+		AllowUserAutostart: opts.UserAutostartEnabled,
+		AllowUserAutostop:  opts.UserAutostopEnabled,
+		DefaultTTLMillis:   opts.DefaultTTL.Milliseconds(),
+		MaxTTLMillis:       opts.MaxTTL.Milliseconds(),
+		AutostartRequirement: codersdk.TemplateAutostartRequirement{
+			DaysOfWeek: codersdk.BitmapToWeekdays(opts.AutostartRequirement.DaysOfWeek),
+		},
+		AutostopRequirement: codersdk.TemplateAutostopRequirement{
+			DaysOfWeek: codersdk.BitmapToWeekdays(opts.AutostopRequirement.DaysOfWeek),
+			Weeks:      opts.AutostopRequirement.Weeks,
+		},
+		QuietHoursSchedule: quietHoursSchedule,
+	})
+}
+
+// maxTemplateGroupScheduleOverrideTTL matches the maximum workspace TTL.
+const maxTemplateGroupScheduleOverrideTTL = 30 * 24 * time.Hour
+
+// updateTemplateWorkspaceBuilds recalculates the deadlines of running builds
+// on the template after one of its group schedule overrides changed, so that
+// the new limits apply without waiting for a rebuild.
+func (api *API) updateTemplateWorkspaceBuilds(ctx context.Context, template database.Template) error {
+	store, ok := (*api.AGPL.TemplateScheduleStore.Load()).(interface {
+		UpdateWorkspaceBuilds(ctx context.Context, db database.Store, template database.Template) error
+	})
+	if !ok {
+		return nil
+	}
+	if err := store.UpdateWorkspaceBuilds(ctx, api.Database, template); err != nil {
+		return xerrors.Errorf("update workspace builds: %w", err)
+	}
+	return nil
+}
+
+func convertTemplateGroupScheduleOverride(override database.TemplateGroupScheduleOverride) codersdk.TemplateGroupScheduleOverride {
+	return codersdk.TemplateGroupScheduleOverride{
+		TemplateID:   override.TemplateID,
+		GroupID:      override.GroupID,
+		MaxTTLMillis: time.Duration(override.MaxTTL).Milliseconds(),
+		// #nosec G115 - Safe conversion as the API only stores 7-bit bitmaps
+		AutostartBlockedDays: codersdk.BitmapToWeekdays(uint8(override.AutostartBlockDaysOfWeek)),
+		AutostopRequirement: codersdk.TemplateAutostopRequirement{
+			// #nosec G115 - Safe conversion as the API only stores 7-bit bitmaps
+			DaysOfWeek: codersdk.BitmapToWeekdays(uint8(override.AutostopRequirementDaysOfWeek)),
+			Weeks:      override.AutostopRequirementWeeks,
+		},
+		CreatedAt: override.CreatedAt,
+		UpdatedAt: override.UpdatedAt,
+	}
+}
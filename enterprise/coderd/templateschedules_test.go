@@ -0,0 +1,188 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
+	"github.com/coder/coder/v2/enterprise/coderd/license"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTemplateGroupScheduleOverrides(t *testing.T) {
+	t.Parallel()
+
+	auditor := audit.NewMock()
+	client, owner := coderdenttest.New(t, &coderdenttest.Options{
+		AuditLogging: true,
+		Options: &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			Auditor:                  auditor,
+		},
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureAdvancedTemplateScheduling: 1,
+				codersdk.FeatureTemplateRBAC:               1,
+				codersdk.FeatureAuditLog:                   1,
+			},
+		},
+	})
+	memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+	version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	group, err := client.CreateGroup(ctx, owner.OrganizationID, codersdk.CreateGroupRequest{
+		Name: "contractors",
+	})
+	require.NoError(t, err)
+	group, err = client.PatchGroup(ctx, group.ID, codersdk.PatchGroupRequest{
+		AddUsers: []string{member.ID.String()},
+	})
+	require.NoError(t, err)
+
+	override, err := client.UpsertTemplateGroupScheduleOverride(ctx, template.ID, group.ID, codersdk.UpsertTemplateGroupScheduleOverrideRequest{
+		MaxTTLMillis:         (2 * time.Hour).Milliseconds(),
+		AutostartBlockedDays: []string{"saturday", "sunday"},
+		AutostopRequirement: &codersdk.TemplateAutostopRequirement{
+			DaysOfWeek: []string{"friday"},
+			Weeks:      1,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, group.ID, override.GroupID)
+	require.Equal(t, (2 * time.Hour).Milliseconds(), override.MaxTTLMillis)
+	require.Equal(t, []string{"saturday", "sunday"}, override.AutostartBlockedDays)
+	require.True(t, auditor.Contains(t, database.AuditLog{
+		Action:         database.AuditActionWrite,
+		ResourceType:   database.ResourceTypeTemplateGroupScheduleOverride,
+		ResourceID:     template.ID,
+		ResourceTarget: group.ID.String(),
+		OrganizationID: owner.OrganizationID,
+	}))
+
+	overrides, err := client.TemplateGroupScheduleOverrides(ctx, template.ID)
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+
+	t.Run("Preview", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		sched, err := client.UserTemplateSchedule(ctx, template.ID, member.ID.String())
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{group.ID}, sched.GroupIDs)
+		require.Equal(t, (2 * time.Hour).Milliseconds(), sched.MaxTTLMillis)
+		require.Equal(t, []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, sched.AutostartRequirement.DaysOfWeek)
+		require.Equal(t, []string{"friday"}, sched.AutostopRequirement.DaysOfWeek)
+
+		// The owner isn't in the group, so the template schedule applies.
+		sched, err = client.UserTemplateSchedule(ctx, template.ID, codersdk.Me)
+		require.NoError(t, err)
+		require.Empty(t, sched.GroupIDs)
+		require.Zero(t, sched.MaxTTLMillis)
+		require.Len(t, sched.AutostartRequirement.DaysOfWeek, 7)
+	})
+
+	t.Run("MaxTTLEnforced", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := memberClient.CreateUserWorkspace(ctx, codersdk.Me, codersdk.CreateWorkspaceRequest{
+			TemplateID: template.ID,
+			Name:       coderdtest.RandomUsername(t),
+			TTLMillis:  ptr.Ref((8 * time.Hour).Milliseconds()),
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		workspace := coderdtest.CreateWorkspace(t, memberClient, template.ID, func(req *codersdk.CreateWorkspaceRequest) {
+			req.TTLMillis = ptr.Ref(time.Hour.Milliseconds())
+		})
+		build := coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, workspace.LatestBuild.ID)
+
+		// The max TTL bounds the build's max deadline, so activity bumps
+		// cannot keep the workspace running past it.
+		require.NotNil(t, build.Job.CompletedAt)
+		require.True(t, build.MaxDeadline.Valid)
+		require.WithinDuration(t, build.Job.CompletedAt.Add(2*time.Hour), build.MaxDeadline.Time, time.Minute)
+
+		err = memberClient.UpdateWorkspaceTTL(ctx, workspace.ID, codersdk.UpdateWorkspaceTTLRequest{
+			TTLMillis: nil,
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("InvalidMaxTTL", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.UpsertTemplateGroupScheduleOverride(ctx, template.ID, group.ID, codersdk.UpsertTemplateGroupScheduleOverrideRequest{
+			MaxTTLMillis: (31 * 24 * time.Hour).Milliseconds(),
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Len(t, apiErr.Validations, 1)
+		require.Equal(t, "max_ttl_ms", apiErr.Validations[0].Field)
+	})
+
+	t.Run("UnknownGroup", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.UpsertTemplateGroupScheduleOverride(ctx, template.ID, uuid.New(), codersdk.UpsertTemplateGroupScheduleOverrideRequest{})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		other, err := client.CreateGroup(ctx, owner.OrganizationID, codersdk.CreateGroupRequest{
+			Name: "interns",
+		})
+		require.NoError(t, err)
+		_, err = client.UpsertTemplateGroupScheduleOverride(ctx, template.ID, other.ID, codersdk.UpsertTemplateGroupScheduleOverrideRequest{
+			MaxTTLMillis: time.Hour.Milliseconds(),
+		})
+		require.NoError(t, err)
+
+		err = client.DeleteTemplateGroupScheduleOverride(ctx, template.ID, other.ID)
+		require.NoError(t, err)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			Action:         database.AuditActionDelete,
+			ResourceType:   database.ResourceTypeTemplateGroupScheduleOverride,
+			ResourceID:     template.ID,
+			ResourceTarget: other.ID.String(),
+			OrganizationID: owner.OrganizationID,
+		}))
+
+		// Deleting an override that no longer exists is a 404.
+		err = client.DeleteTemplateGroupScheduleOverride(ctx, template.ID, other.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		overrides, err := client.TemplateGroupScheduleOverrides(ctx, template.ID)
+		require.NoError(t, err)
+		for _, o := range overrides {
+			require.NotEqual(t, other.ID, o.GroupID)
+		}
+	})
+}
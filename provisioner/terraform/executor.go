@@ -374,6 +374,7 @@ func (e *executor) plan(ctx, killCtx context.Context, env, vars []string, logr l
 		DailyCost:            state.DailyCost,
 		ResourceReplacements: resReps,
 		AiTaskCount:          state.AITaskCount,
+		ResourceChanges:      findResourceChanges(plan),
 	}
 
 	return msg, nil
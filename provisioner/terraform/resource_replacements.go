@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/coder/coder/v2/provisionersdk/proto"
 )
 
 type resourceReplacements map[string][]string
@@ -84,3 +86,52 @@ func findResourceReplacements(plan *tfjson.Plan) resourceReplacements {
 
 	return replacements
 }
+
+// findResourceChanges summarizes the changes the current plan would make to non-coder resources, so a build can be
+// previewed before it is applied. Replacements carry the attribute paths found by findResourceReplacements.
+func findResourceChanges(plan *tfjson.Plan) []*proto.ResourceChange {
+	if plan == nil || len(plan.ResourceChanges) == 0 {
+		return nil
+	}
+
+	replacements := findResourceReplacements(plan)
+	changes := make([]*proto.ResourceChange, 0, len(plan.ResourceChanges))
+	for _, ch := range plan.ResourceChanges {
+		if ch.Change == nil {
+			continue
+		}
+		// See findResourceReplacements for why our own resources are ignored.
+		if strings.Index(ch.Type, "coder_") == 0 {
+			continue
+		}
+
+		var action string
+		switch {
+		case ch.Change.Actions.Replace():
+			action = "replace"
+		case ch.Change.Actions.Create():
+			action = "create"
+		case ch.Change.Actions.Update():
+			action = "update"
+		case ch.Change.Actions.Delete():
+			action = "delete"
+		default:
+			// No-op, read and forget actions do not change any infrastructure.
+			continue
+		}
+
+		changes = append(changes, &proto.ResourceChange{
+			Address:      ch.Address,
+			Type:         ch.Type,
+			Name:         ch.Name,
+			Action:       action,
+			ReplacePaths: replacements[ch.Address],
+		})
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return changes
+}
@@ -5,6 +5,8 @@ import (
 
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/provisionersdk/proto"
 )
 
 func TestFindResourceReplacements(t *testing.T) {
@@ -174,3 +176,104 @@ func TestFindResourceReplacements(t *testing.T) {
 		})
 	}
 }
+
+func TestFindResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		plan     *tfjson.Plan
+		expected []*proto.ResourceChange
+	}{
+		{
+			name: "nil plan",
+		},
+		{
+			name: "no resource changes",
+			plan: &tfjson.Plan{},
+		},
+		{
+			name: "no-op and read actions are ignored",
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "example_resource.noop",
+						Type:    "example_resource",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionNoop},
+						},
+					},
+					{
+						Address: "data.example_data.read",
+						Type:    "example_data",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionRead},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "all actions",
+			plan: &tfjson.Plan{
+				ResourceChanges: []*tfjson.ResourceChange{
+					{
+						Address: "example_resource.created",
+						Type:    "example_resource",
+						Name:    "created",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionCreate},
+						},
+					},
+					{
+						Address: "example_resource.updated",
+						Type:    "example_resource",
+						Name:    "updated",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionUpdate},
+						},
+					},
+					{
+						Address: "example_resource.replaced",
+						Type:    "example_resource",
+						Name:    "replaced",
+						Change: &tfjson.Change{
+							Actions:      tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+							ReplacePaths: []interface{}{[]interface{}{"image", 0}},
+						},
+					},
+					{
+						Address: "example_resource.deleted",
+						Type:    "example_resource",
+						Name:    "deleted",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionDelete},
+						},
+					},
+					{
+						Address: "coder_agent.main",
+						Type:    "coder_agent",
+						Name:    "main",
+						Change: &tfjson.Change{
+							Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+						},
+					},
+				},
+			},
+			expected: []*proto.ResourceChange{
+				{Address: "example_resource.created", Type: "example_resource", Name: "created", Action: "create"},
+				{Address: "example_resource.updated", Type: "example_resource", Name: "updated", Action: "update"},
+				{Address: "example_resource.replaced", Type: "example_resource", Name: "replaced", Action: "replace", ReplacePaths: []string{"image.0"}},
+				{Address: "example_resource.deleted", Type: "example_resource", Name: "deleted", Action: "delete"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, findResourceChanges(tc.plan))
+		})
+	}
+}
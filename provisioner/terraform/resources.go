@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/awalterschulze/gographviz"
@@ -692,6 +693,13 @@ func ConvertState(ctx context.Context, modules []*tfjson.StateModule, rawGraph s
 				RunOnStart:       attrs.RunOnStart,
 				RunOnStop:        attrs.RunOnStop,
 				TimeoutSeconds:   attrs.TimeoutSeconds,
+				UnitName:         resource.Address,
+			}
+			// Only scripts that block login are ordered after the coder_script
+			// resources they depend on, so that the scripts of existing
+			// templates keep starting in parallel.
+			if attrs.StartBlocksLogin {
+				script.DependsOn = findScriptDependencies(graph, tfResourcesByLabel, resource)
 			}
 
 		scriptAgentLoop:
@@ -1289,6 +1297,66 @@ func applyAutomaticInstanceID(resource *tfjson.StateResource, agents []*proto.Ag
 	}
 }
 
+// findScriptDependencies returns the addresses of the coder_script resources
+// that the given coder_script depends on, either through a reference or an
+// explicit depends_on. The graph is traversed through intermediate nodes, such
+// as locals and modules, until a resource is found.
+func findScriptDependencies(graph *gographviz.Graph, tfResourcesByLabel map[string]map[string]*tfjson.StateResource, script *tfjson.StateResource) []string {
+	scriptLabel := convertAddressToLabel(script.Address)
+	queue := make([]string, 0)
+	for _, node := range graph.Nodes.Lookup {
+		// The node attributes surround the label with quotes.
+		if strings.Trim(node.Attrs["label"], `"`) == scriptLabel {
+			queue = append(queue, node.Name)
+		}
+	}
+
+	visited := map[string]bool{}
+	dependencies := map[string]struct{}{}
+	for len(queue) > 0 {
+		nodeName := queue[0]
+		queue = queue[1:]
+		for destination := range graph.Edges.SrcToDsts[nodeName] {
+			if visited[destination] {
+				continue
+			}
+			visited[destination] = true
+
+			destinationNode, ok := graph.Nodes.Lookup[destination]
+			if !ok {
+				continue
+			}
+			destinationLabel := strings.Trim(destinationNode.Attrs["label"], `"`)
+			resources, isResource := tfResourcesByLabel[destinationLabel]
+			if !isResource {
+				// Not a resource, so keep looking for resources this node
+				// depends on.
+				queue = append(queue, destination)
+				continue
+			}
+			for address, resource := range resources {
+				if resource.Mode != tfjson.ManagedResourceMode || resource.Type != "coder_script" {
+					continue
+				}
+				if address == script.Address {
+					continue
+				}
+				dependencies[address] = struct{}{}
+			}
+		}
+	}
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(dependencies))
+	for address := range dependencies {
+		addresses = append(addresses, address)
+	}
+	slices.Sort(addresses)
+	return addresses
+}
+
 // findResourcesInGraph traverses directionally in a graph until a resource is found,
 // then it stores the depth it was found at, and continues working up the tree.
 // nolint:revive
@@ -5,9 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -452,11 +454,13 @@ func TestConvertResources(t *testing.T) {
 							DisplayName: "Foobar Script 1",
 							Script:      "echo foobar 1",
 							RunOnStart:  true,
+							UnitName:    "coder_script.script1",
 						},
 						{
 							DisplayName: "Foobar Script 2",
 							Script:      "echo foobar 2",
 							RunOnStart:  true,
+							UnitName:    "coder_script.script2",
 						},
 					},
 					Auth:                     &proto.Agent_Token{},
@@ -477,6 +481,7 @@ func TestConvertResources(t *testing.T) {
 							DisplayName: "Foobar Script 3",
 							Script:      "echo foobar 3",
 							RunOnStart:  true,
+							UnitName:    "coder_script.script3",
 						},
 					},
 					Auth:                     &proto.Agent_Token{},
@@ -962,6 +967,7 @@ func TestConvertResources(t *testing.T) {
 										Script:      "echo devcontainer",
 										RunOnStart:  true,
 										RunOnStop:   false,
+										UnitName:    "coder_script.devcontainer-script",
 									},
 								},
 								Envs: []*proto.Env{
@@ -1200,6 +1206,73 @@ func TestInvalidTerraformAddress(t *testing.T) {
 	require.Equal(t, state.Resources[0].ModulePath, "invalid terraform address")
 }
 
+func TestScriptDependencies(t *testing.T) {
+	t.Parallel()
+	ctx, logger := ctxAndLogger(t)
+
+	// nolint:dogsled
+	_, filename, _, _ := runtime.Caller(0)
+
+	dir := filepath.Join(filepath.Dir(filename), "testdata", "resources", "multiple-agents-multiple-scripts")
+	tfPlanRaw, err := os.ReadFile(filepath.Join(dir, "multiple-agents-multiple-scripts.tfplan.json"))
+	require.NoError(t, err)
+	var tfPlan tfjson.Plan
+	err = json.Unmarshal(tfPlanRaw, &tfPlan)
+	require.NoError(t, err)
+	tfPlanGraph, err := os.ReadFile(filepath.Join(dir, "multiple-agents-multiple-scripts.tfplan.dot"))
+	require.NoError(t, err)
+
+	// script2 depends on script1 directly, and script3 depends on script1
+	// through a local value.
+	graph := strings.Replace(string(tfPlanGraph), `"[root] root" ->`, `"[root] local.dep (expand)" [label = "local.dep", shape = "note"]
+		"[root] coder_script.script2 (expand)" -> "[root] coder_script.script1 (expand)"
+		"[root] coder_script.script3 (expand)" -> "[root] local.dep (expand)"
+		"[root] local.dep (expand)" -> "[root] coder_script.script1 (expand)"
+		"[root] root" ->`, 1)
+
+	convert := func(blocksLogin ...string) map[string][]string {
+		t.Helper()
+
+		module := *tfPlan.PlannedValues.RootModule
+		module.Resources = nil
+		for _, resource := range tfPlan.PlannedValues.RootModule.Resources {
+			resource := *resource
+			resource.AttributeValues = maps.Clone(resource.AttributeValues)
+			if slices.Contains(blocksLogin, resource.Address) {
+				resource.AttributeValues["start_blocks_login"] = true
+			}
+			module.Resources = append(module.Resources, &resource)
+		}
+
+		state, err := terraform.ConvertState(ctx, []*tfjson.StateModule{&module}, graph, logger)
+		require.NoError(t, err)
+
+		dependsOn := map[string][]string{}
+		for _, resource := range state.Resources {
+			for _, agent := range resource.Agents {
+				for _, script := range agent.Scripts {
+					dependsOn[script.UnitName] = script.DependsOn
+				}
+			}
+		}
+		return dependsOn
+	}
+
+	require.Equal(t, map[string][]string{
+		"coder_script.script1": nil,
+		"coder_script.script2": {"coder_script.script1"},
+		"coder_script.script3": {"coder_script.script1"},
+	}, convert("coder_script.script2", "coder_script.script3"))
+
+	// Dependencies are only inferred for scripts that block login, so that
+	// the scripts of existing templates keep starting in parallel.
+	require.Equal(t, map[string][]string{
+		"coder_script.script1": nil,
+		"coder_script.script2": {"coder_script.script1"},
+		"coder_script.script3": nil,
+	}, convert("coder_script.script2"))
+}
+
 //nolint:tparallel
 func TestAppSlugValidation(t *testing.T) {
 	t.Parallel()
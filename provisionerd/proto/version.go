@@ -75,9 +75,19 @@ import "github.com/coder/coder/v2/apiversion"
 // API v1.15:
 //   - Removed `stop_modules` from CompleteJob. Was a duplicate of start_modules
 //   - Add `id`, `subagent_id`, `apps`, `scripts` and `envs` to `provisioner.Devcontainer`
+//
+// API v1.16:
+//   - Added new fields `unit_name` and `depends_on` to `provisioner.Script`
+//
+// API v1.17:
+//   - Added `state` to `provisionerd.AcquiredJob.TemplateDryRun` to preview builds of existing workspaces
+//   - Added `resource_changes` to `provisioner.PlanComplete` and `provisionerd.CompletedJob.TemplateDryRun`
+//
+// API v1.18:
+//   - Added `HIBERNATE` to `provisioner.WorkspaceTransition`
 const (
 	CurrentMajor = 1
-	CurrentMinor = 15
+	CurrentMinor = 18
 )
 
 // CurrentVersion is the current provisionerd API version.
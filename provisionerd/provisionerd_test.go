@@ -449,6 +449,92 @@ func TestProvisionerd(t *testing.T) {
 		assert.True(t, didComplete.Load(), "should complete the job")
 	})
 
+	t.Run("TemplateDryRunWithState", func(t *testing.T) {
+		t.Parallel()
+		done := make(chan struct{})
+		t.Cleanup(func() {
+			close(done)
+		})
+		var (
+			didComplete atomic.Bool
+			state       = []byte("state")
+			changes     = []*sdkproto.ResourceChange{{
+				Address:      "docker_volume.home",
+				Type:         "docker_volume",
+				Name:         "home",
+				Action:       "replace",
+				ReplacePaths: []string{"name"},
+			}}
+			acq = newAcquireOne(t, &proto.AcquiredJob{
+				JobId:       "test",
+				Provisioner: "someprovisioner",
+				TemplateSourceArchive: testutil.CreateTar(t, map[string]string{
+					"test.txt": "content",
+				}),
+				Type: &proto.AcquiredJob_TemplateDryRun_{
+					TemplateDryRun: &proto.AcquiredJob_TemplateDryRun{
+						Metadata: &sdkproto.Metadata{
+							WorkspaceOwner: "owner",
+						},
+						State: state,
+					},
+				},
+			})
+		)
+
+		closer := createProvisionerd(t, func(ctx context.Context) (proto.DRPCProvisionerDaemonClient, error) {
+			return createProvisionerDaemonClient(t, done, provisionerDaemonTestServer{
+				acquireJobWithCancel: acq.acquireWithCancel,
+				updateJob:            noopUpdateJob,
+				completeJob: func(ctx context.Context, job *proto.CompletedJob) (*proto.Empty, error) {
+					got := job.GetTemplateDryRun().GetResourceChanges()
+					if assert.Len(t, got, 1) {
+						assert.Equal(t, "replace", got[0].Action)
+						assert.Equal(t, []string{"name"}, got[0].ReplacePaths)
+					}
+					didComplete.Store(true)
+					return &proto.Empty{}, nil
+				},
+			}), nil
+		}, provisionerd.LocalProvisioners{
+			"someprovisioner": createProvisionerClient(t, done, provisionerTestServer{
+				init: func(s *provisionersdk.Session, r *provisionersdk.InitRequest, canceledOrComplete <-chan struct{}) *sdkproto.InitComplete {
+					return &sdkproto.InitComplete{}
+				},
+				plan: func(
+					_ *provisionersdk.Session,
+					r *sdkproto.PlanRequest,
+					_ <-chan struct{},
+				) *sdkproto.PlanComplete {
+					// The plan must be computed against the workspace's state
+					// and metadata.
+					assert.Equal(t, state, r.State)
+					assert.Equal(t, "owner", r.Metadata.WorkspaceOwner)
+					return &sdkproto.PlanComplete{
+						ResourceChanges: changes,
+					}
+				},
+				apply: func(
+					_ *provisionersdk.Session,
+					_ *sdkproto.ApplyRequest,
+					_ <-chan struct{},
+				) *sdkproto.ApplyComplete {
+					t.Error("dry run should not apply")
+					return &sdkproto.ApplyComplete{}
+				},
+				graph: func(s *provisionersdk.Session, r *sdkproto.GraphRequest, canceledOrComplete <-chan struct{}) *sdkproto.GraphComplete {
+					return &sdkproto.GraphComplete{
+						Resources: []*sdkproto.Resource{},
+					}
+				},
+			}),
+		})
+
+		require.Condition(t, closedWithin(acq.complete, testutil.WaitShort))
+		require.NoError(t, closer.Close())
+		assert.True(t, didComplete.Load(), "should complete the job")
+	})
+
 	t.Run("WorkspaceBuild", func(t *testing.T) {
 		t.Parallel()
 		done := make(chan struct{})
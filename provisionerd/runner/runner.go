@@ -681,6 +681,7 @@ type templateImportProvision struct {
 	ExternalAuthProviders []*sdkproto.ExternalAuthProviderResource
 	Presets               []*sdkproto.Preset
 	Plan                  json.RawMessage
+	ResourceChanges       []*sdkproto.ResourceChange
 	HasAITasks            bool
 	HasExternalAgents     bool
 }
@@ -689,16 +690,18 @@ type templateImportProvision struct {
 // This is used to detect resources that would be provisioned for a workspace in various states.
 // It doesn't define values for rich parameters as they're unknown during template import.
 func (r *Runner) runTemplateImportProvision(ctx context.Context, variableValues []*sdkproto.VariableValue, metadata *sdkproto.Metadata) (*templateImportProvision, error) {
-	return r.runTemplateImportProvisionWithRichParameters(ctx, variableValues, nil, metadata)
+	return r.runTemplateImportProvisionWithRichParameters(ctx, variableValues, nil, metadata, nil)
 }
 
 // Performs a dry-run provision with provided rich parameters.
 // This is used to detect resources that would be provisioned for a workspace in various states.
+// When state is set, the plan is computed against the resources of an existing workspace.
 func (r *Runner) runTemplateImportProvisionWithRichParameters(
 	ctx context.Context,
 	variableValues []*sdkproto.VariableValue,
 	richParameterValues []*sdkproto.RichParameterValue,
 	metadata *sdkproto.Metadata,
+	state []byte,
 ) (*templateImportProvision, error) {
 	ctx, span := r.startTrace(ctx, tracing.FuncName())
 	defer span.End()
@@ -717,7 +720,7 @@ func (r *Runner) runTemplateImportProvisionWithRichParameters(
 		VariableValues:          variableValues,
 		ExternalAuthProviders:   nil,
 		PreviousParameterValues: nil,
-		State:                   nil,
+		State:                   state,
 	})
 	if failed != nil {
 		return nil, xerrors.Errorf("plan during template import provision: %w", failed)
@@ -749,6 +752,7 @@ func (r *Runner) runTemplateImportProvisionWithRichParameters(
 		ExternalAuthProviders: graphComplete.ExternalAuthProviders,
 		Presets:               graphComplete.Presets,
 		Plan:                  planComplete.Plan,
+		ResourceChanges:       planComplete.ResourceChanges,
 		HasAITasks:            graphComplete.HasAiTasks,
 		HasExternalAgents:     graphComplete.HasExternalAgents,
 	}, nil
@@ -767,7 +771,9 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 	if metadata.WorkspaceName == "" {
 		metadata.WorkspaceName = "dryrun"
 	}
-	metadata.WorkspaceOwner = r.job.UserName
+	if metadata.WorkspaceOwner == "" {
+		metadata.WorkspaceOwner = r.job.UserName
+	}
 	if metadata.WorkspaceOwner == "" {
 		metadata.WorkspaceOwner = "dryrunner"
 	}
@@ -808,6 +814,7 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 		r.job.GetTemplateDryRun().GetVariableValues(),
 		r.job.GetTemplateDryRun().GetRichParameterValues(),
 		metadata,
+		r.job.GetTemplateDryRun().GetState(),
 	)
 	if err != nil {
 		return nil, r.failedJobf("run dry-run provision job: %s", err)
@@ -817,8 +824,9 @@ func (r *Runner) runTemplateDryRun(ctx context.Context) (*proto.CompletedJob, *p
 		JobId: r.job.JobId,
 		Type: &proto.CompletedJob_TemplateDryRun_{
 			TemplateDryRun: &proto.CompletedJob_TemplateDryRun{
-				Resources: provision.Resources,
-				Modules:   initResp.Modules,
+				Resources:       provision.Resources,
+				Modules:         initResp.Modules,
+				ResourceChanges: provision.ResourceChanges,
 			},
 		},
 	}, nil
@@ -890,6 +898,9 @@ func (r *Runner) runWorkspaceBuild(ctx context.Context) (*proto.CompletedJob, *p
 	case sdkproto.WorkspaceTransition_STOP:
 		applyStage = "Stopping workspace"
 		commitQuota = true
+	case sdkproto.WorkspaceTransition_HIBERNATE:
+		applyStage = "Hibernating workspace"
+		commitQuota = true
 	case sdkproto.WorkspaceTransition_DESTROY:
 		applyStage = "Destroying workspace"
 	}
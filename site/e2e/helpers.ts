@@ -668,6 +668,7 @@ const createTemplateVersionTar = async (
 			timings: [],
 			plan: emptyPlan,
 			resourceReplacements: [],
+			resourceChanges: [],
 			...response.plan,
 		} as PlanComplete;
 		tar.addFile(
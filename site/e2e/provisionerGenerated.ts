@@ -56,6 +56,11 @@ export enum WorkspaceTransition {
   START = 0,
   STOP = 1,
   DESTROY = 2,
+  /**
+   * HIBERNATE - HIBERNATE releases compute but retains persistent, snapshot-able
+   * resources. Templates that don't distinguish it behave as for STOP.
+   */
+  HIBERNATE = 3,
   UNRECOGNIZED = -1,
 }
 
@@ -189,6 +194,17 @@ export interface ResourceReplacement {
   paths: string[];
 }
 
+/** ResourceChange describes a change that a plan would make to a resource. */
+export interface ResourceChange {
+  address: string;
+  type: string;
+  name: string;
+  /** action is one of "create", "update", "replace" or "delete". */
+  action: string;
+  /** replace_paths are the attribute paths which force a replacement. */
+  replacePaths: string[];
+}
+
 /** VariableValue holds the key/value mapping of a Terraform variable. */
 export interface VariableValue {
   name: string;
@@ -300,6 +316,8 @@ export interface Script {
   runOnStop: boolean;
   timeoutSeconds: number;
   logPath: string;
+  unitName: string;
+  dependsOn: string[];
 }
 
 export interface Devcontainer {
@@ -490,6 +508,7 @@ export interface PlanComplete {
   dailyCost: number;
   resourceReplacements: ResourceReplacement[];
   aiTaskCount: number;
+  resourceChanges: ResourceChange[];
 }
 
 /**
@@ -822,6 +841,27 @@ export const ResourceReplacement = {
   },
 };
 
+export const ResourceChange = {
+  encode(message: ResourceChange, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.address !== "") {
+      writer.uint32(10).string(message.address);
+    }
+    if (message.type !== "") {
+      writer.uint32(18).string(message.type);
+    }
+    if (message.name !== "") {
+      writer.uint32(26).string(message.name);
+    }
+    if (message.action !== "") {
+      writer.uint32(34).string(message.action);
+    }
+    for (const v of message.replacePaths) {
+      writer.uint32(42).string(v!);
+    }
+    return writer;
+  },
+};
+
 export const VariableValue = {
   encode(message: VariableValue, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
     if (message.name !== "") {
@@ -1085,6 +1125,12 @@ export const Script = {
     if (message.logPath !== "") {
       writer.uint32(74).string(message.logPath);
     }
+    if (message.unitName !== "") {
+      writer.uint32(82).string(message.unitName);
+    }
+    for (const v of message.dependsOn) {
+      writer.uint32(90).string(v!);
+    }
     return writer;
   },
 };
@@ -1514,6 +1560,9 @@ export const PlanComplete = {
     if (message.aiTaskCount !== 0) {
       writer.uint32(48).int32(message.aiTaskCount);
     }
+    for (const v of message.resourceChanges) {
+      ResourceChange.encode(v!, writer.uint32(58).fork()).ldelim();
+    }
     return writer;
   },
 };
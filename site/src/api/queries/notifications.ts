@@ -30,6 +30,9 @@ export const updateUserNotificationPreferences = (
 			return API.putUserNotificationPreferences(userId, req);
 		},
 		onMutate: (data) => {
+			const previous = queryClient.getQueryData<NotificationPreference[]>(
+				userNotificationPreferencesKey(userId),
+			);
 			queryClient.setQueryData(
 				userNotificationPreferencesKey(userId),
 				Object.entries(data.template_disabled_map).map(
@@ -38,6 +41,7 @@ export const updateUserNotificationPreferences = (
 							id,
 							disabled,
 							updated_at: new Date().toISOString(),
+							target_ids: previous?.find((p) => p.id === id)?.target_ids ?? [],
 						}) satisfies NotificationPreference,
 				),
 			);
@@ -962,6 +962,7 @@ export type BuildReason =
 	| "dormancy"
 	| "initiator"
 	| "jetbrains_connection"
+	| "maintenance"
 	| "ssh_connection"
 	| "task_auto_pause"
 	| "task_manual_pause"
@@ -976,6 +977,7 @@ export const BuildReasons: BuildReason[] = [
 	"dormancy",
 	"initiator",
 	"jetbrains_connection",
+	"maintenance",
 	"ssh_connection",
 	"task_auto_pause",
 	"task_manual_pause",
@@ -1104,6 +1106,11 @@ export interface ConnectionLogSSHInfo {
 	 * disconnect event with the same connection ID has not yet been seen.
 	 */
 	readonly exit_code?: number;
+	/**
+	 * SessionRecordingID is the ID of the recording of the session, if the
+	 * template records sessions and the recording has been uploaded.
+	 */
+	readonly session_recording_id?: string;
 }
 
 // From codersdk/connectionlog.go
@@ -1204,6 +1211,13 @@ export interface CreateGroupRequest {
 	readonly quota_allowance: number;
 }
 
+// From codersdk/notifications.go
+export interface CreateNotificationTargetRequest {
+	readonly name: string;
+	readonly method: string;
+	readonly address?: string;
+}
+
 // From codersdk/organizations.go
 export interface CreateOrganizationRequest {
 	readonly name: string;
@@ -1479,6 +1493,23 @@ export interface CreateWorkspaceBuildRequest {
 	readonly reason?: CreateWorkspaceBuildReason;
 }
 
+// From codersdk/workspaces.go
+/**
+ * CreateWorkspaceDryRunRequest defines the request parameters for
+ * CreateWorkspaceDryRun.
+ */
+export interface CreateWorkspaceDryRunRequest {
+	/**
+	 * TemplateVersionID is the version to plan the build with. The active
+	 * version of the workspace's template is used if unset.
+	 */
+	readonly template_version_id?: string;
+	/**
+	 * RichParameterValues override the parameters of the latest build.
+	 */
+	readonly rich_parameter_values?: readonly WorkspaceBuildParameter[];
+}
+
 // From codersdk/workspaceproxy.go
 export interface CreateWorkspaceProxyRequest {
 	readonly name: string;
@@ -1520,6 +1551,12 @@ export interface CreateWorkspaceRequest {
 	readonly rich_parameter_values?: readonly WorkspaceBuildParameter[];
 	readonly automatic_updates?: AutomaticUpdates;
 	readonly template_version_preset_id?: string;
+	/**
+	 * SourceWorkspaceID records the workspace this workspace is cloned from.
+	 * The source workspace must use the same template and be readable by the
+	 * caller.
+	 */
+	readonly source_workspace_id?: string;
 }
 
 // From codersdk/deployment.go
@@ -2775,6 +2812,48 @@ export interface NetcheckReport {
 	readonly CaptivePortal: boolean | null;
 }
 
+// From codersdk/notifications.go
+export interface NotificationDeliveriesRequest {
+	/**
+	 * Method filters deliveries by their method, such as "webhook". Deliveries of
+	 * all methods are returned if empty.
+	 */
+	readonly method?: string;
+	/**
+	 * Limit is the maximum number of deliveries to return.
+	 */
+	readonly limit?: number;
+}
+
+// From codersdk/notifications.go
+/**
+ * NotificationDelivery is a notification message along with the outcome of its
+ * most recent delivery attempt.
+ */
+export interface NotificationDelivery {
+	readonly message_id: string;
+	readonly template_id: string;
+	readonly template_name: string;
+	readonly user_id: string;
+	readonly method: string;
+	readonly target_id?: string;
+	readonly status: string;
+	readonly status_reason?: string;
+	/**
+	 * ResponseStatusCode is the HTTP status code with which the last delivery
+	 * attempt was rejected, for methods which deliver over HTTP.
+	 */
+	readonly response_status_code?: number;
+	/**
+	 * AttemptCount is the number of delivery attempts made so far; every attempt
+	 * after the first is a retry.
+	 */
+	readonly attempt_count: number;
+	readonly created_at: string;
+	readonly updated_at?: string;
+	readonly next_retry_after?: string;
+}
+
 // From codersdk/notifications.go
 export interface NotificationMethodsResponse {
 	readonly available: readonly string[];
@@ -2786,6 +2865,34 @@ export interface NotificationPreference {
 	readonly id: string;
 	readonly disabled: boolean;
 	readonly updated_at: string;
+	/**
+	 * TargetIDs are the notification targets to which notifications of this
+	 * template are delivered, instead of by the template's method. Only verified
+	 * targets receive notifications.
+	 */
+	readonly target_ids: readonly string[];
+}
+
+// From codersdk/notifications.go
+/**
+ * NotificationTarget is a personal delivery target for a user's notifications,
+ * such as their own webhook endpoint. Targets must be verified before they
+ * receive notifications.
+ */
+export interface NotificationTarget {
+	readonly id: string;
+	readonly user_id: string;
+	readonly name: string;
+	readonly method: string;
+	/**
+	 * Address is the webhook endpoint or email address notifications are delivered
+	 * to. It is empty for methods which deliver to the user directly, such as
+	 * inbox and webpush.
+	 */
+	readonly address: string;
+	readonly verified_at?: string;
+	readonly created_at: string;
+	readonly updated_at: string;
 }
 
 // From codersdk/notifications.go
@@ -2955,6 +3062,11 @@ export interface NotificationsWebhookConfig {
 	 * The URL to which the payload will be sent with an HTTP POST request.
 	 */
 	readonly endpoint: string;
+	/**
+	 * The secrets with which request bodies are signed, see
+	 * WebhookSignatureHeader.
+	 */
+	readonly signing_secrets?: string;
 }
 
 // From codersdk/parameters.go
@@ -3410,6 +3522,20 @@ export interface OrganizationMembersQuery {
 	readonly GithubUserID: number;
 }
 
+// From codersdk/notifications.go
+/**
+ * OrganizationNotificationWebhook is the incoming webhook of a chat service,
+ * such as Slack or Microsoft Teams, to which notifications of the
+ * organization's members are delivered by the method of the same name.
+ */
+export interface OrganizationNotificationWebhook {
+	readonly organization_id: string;
+	readonly method: string;
+	readonly endpoint: string;
+	readonly created_at: string;
+	readonly updated_at: string;
+}
+
 // From codersdk/organizations.go
 export interface OrganizationProvisionerDaemonsOptions {
 	readonly Limit: number;
@@ -3646,6 +3772,75 @@ export interface PprofConfig {
 	readonly address: string;
 }
 
+// From codersdk/prebuilds.go
+/**
+ * PrebuildForecastHour describes a single hour of a preset's prebuild forecast.
+ */
+export interface PrebuildForecastHour {
+	readonly start_time: string;
+	readonly forecast_claims: number;
+	/**
+	 * ActualClaims is the number of prebuilds claimed during the hour. It is zero for hours in the future.
+	 */
+	readonly actual_claims: number;
+	readonly desired_instances: number;
+}
+
+// From codersdk/prebuilds.go
+export interface PrebuildPresetForecast {
+	readonly preset_id: string;
+	readonly preset_name: string;
+	/**
+	 * Adaptive is true if the desired instances of the preset are forecasted from its claim history.
+	 */
+	readonly adaptive: boolean;
+	readonly desired_instances: number;
+	readonly hours: readonly PrebuildForecastHour[];
+}
+
+// From codersdk/prebuilds.go
+/**
+ * PrebuildReadinessCheck is a check that a prebuilt workspace must pass once its agents are
+ * ready, before it can be claimed.
+ */
+export interface PrebuildReadinessCheck {
+	readonly type: PrebuildReadinessCheckType;
+	/**
+	 * Script is the display name of an agent script that must exit successfully. Required for script checks.
+	 */
+	readonly script?: string;
+	/**
+	 * MetadataKey is the key of the agent metadata item to check. Required for metadata checks.
+	 */
+	readonly metadata_key?: string;
+	/**
+	 * MetadataValue is a regular expression the agent metadata value must match. Required for metadata checks.
+	 */
+	readonly metadata_value?: string;
+	/**
+	 * App is the slug of an app that must be healthy. Required for app checks.
+	 */
+	readonly app?: string;
+}
+
+// From codersdk/prebuilds.go
+export type PrebuildReadinessCheckType = "app" | "metadata" | "script";
+
+export const PrebuildReadinessCheckTypes: PrebuildReadinessCheckType[] = [
+	"app",
+	"metadata",
+	"script",
+];
+
+// From codersdk/prebuilds.go
+export type PrebuildReadinessStatus = "failed" | "passed" | "pending";
+
+export const PrebuildReadinessStatuses: PrebuildReadinessStatus[] = [
+	"failed",
+	"passed",
+	"pending",
+];
+
 // From codersdk/deployment.go
 export interface PrebuildsConfig {
 	/**
@@ -3676,6 +3871,33 @@ export interface PrebuildsSettings {
 	readonly reconciliation_paused: boolean;
 }
 
+// From codersdk/prebuilds.go
+export interface PrebuiltWorkspaceStatus {
+	readonly workspace_id: string;
+	readonly workspace_name: string;
+	readonly preset_id: string;
+	readonly preset_name: string;
+	/**
+	 * AgentsReady is true if all agents of the prebuilt workspace are ready.
+	 */
+	readonly agents_ready: boolean;
+	/**
+	 * ReadinessStatus is the result of the preset's readiness checks for the latest build.
+	 * It is empty if the preset has no readiness checks.
+	 */
+	readonly readiness_status?: PrebuildReadinessStatus;
+	/**
+	 * FailureReasons explains why the readiness checks are pending or failed.
+	 */
+	readonly failure_reasons: readonly string[];
+	readonly checked_at?: string;
+	/**
+	 * Eligible is true if the prebuilt workspace can be claimed.
+	 */
+	readonly eligible: boolean;
+	readonly created_at: string;
+}
+
 // From codersdk/presets.go
 export interface Preset {
 	readonly ID: string;
@@ -4277,6 +4499,11 @@ export interface ResolveAutostartResponse {
 	readonly parameter_mismatch: boolean;
 }
 
+// From codersdk/workspacebuilds.go
+export type ResourceChangeAction = "create" | "delete" | "replace" | "update";
+
+export const ResourceChangeActions: ResourceChangeAction[] = ["create", "delete", "replace", "update"];
+
 // From codersdk/audit.go
 export type ResourceType =
 	| "api_key"
@@ -4295,9 +4522,13 @@ export type ResourceType =
 	| "oauth2_provider_app_secret"
 	| "organization"
 	| "organization_member"
+	| "organization_notification_webhook"
 	| "prebuilds_settings"
 	| "task"
 	| "template"
+	| "template_adaptive_prebuilds"
+	| "template_group_schedule_override"
+	| "template_preset_readiness_checks"
 	| "template_version"
 	| "user"
 	| "workspace"
@@ -4323,9 +4554,13 @@ export const ResourceTypes: ResourceType[] = [
 	"oauth2_provider_app_secret",
 	"organization",
 	"organization_member",
+	"organization_notification_webhook",
 	"prebuilds_settings",
 	"task",
 	"template",
+	"template_adaptive_prebuilds",
+	"template_group_schedule_override",
+	"template_preset_readiness_checks",
 	"template_version",
 	"user",
 	"workspace",
@@ -5204,6 +5439,28 @@ export interface Template {
 	 * provisioning.
 	 */
 	readonly disable_module_cache: boolean;
+	/**
+	 * SessionRecordingEnabled records interactive terminal sessions, such as
+	 * SSH and reconnecting PTY sessions, in workspaces of this template.
+	 */
+	readonly session_recording_enabled: boolean;
+	/**
+	 * SessionRecordingIncludeInput also records the input typed during a
+	 * session. Only used when SessionRecordingEnabled is set.
+	 */
+	readonly session_recording_include_input: boolean;
+	/**
+	 * MaintenanceWindowSchedule is a cron time range, e.g. "* 2-4 * * 6",
+	 * during which idle workspaces running an outdated template version are
+	 * restarted onto the active version. Empty when unset.
+	 */
+	readonly maintenance_window_schedule: string;
+	/**
+	 * HibernateIdleThresholdMillis makes autostop hibernate workspaces that
+	 * have been inactive for less than this duration instead of stopping
+	 * them. 0 means workspaces are always stopped.
+	 */
+	readonly hibernate_idle_threshold_ms: number;
 }
 
 // From codersdk/templates.go
@@ -5212,6 +5469,20 @@ export interface TemplateACL {
 	readonly group: readonly TemplateGroup[];
 }
 
+// From codersdk/prebuilds.go
+/**
+ * TemplateAdaptivePrebuilds configures adaptive pool sizing for the prebuilds of a template.
+ * When enabled, the desired instances of each preset are forecasted from the number of prebuilds
+ * claimed during the same hour of the week over the lookback period, instead of being taken from
+ * the preset's static prebuild schedules.
+ */
+export interface TemplateAdaptivePrebuilds {
+	readonly enabled: boolean;
+	readonly min_instances: number;
+	readonly max_instances: number;
+	readonly lookback_weeks: number;
+}
+
 // From codersdk/insights.go
 /**
  * TemplateAppUsage shows the usage of an app for one or more templates.
@@ -5319,6 +5590,36 @@ export interface TemplateGroup extends Group {
 	readonly role: TemplateRole;
 }
 
+// From codersdk/templateschedules.go
+/**
+ * TemplateGroupScheduleOverride restricts the schedule of a template's
+ * workspaces that are owned by members of a group. Overrides can only make
+ * the template schedule stricter. Users in several groups with overrides get
+ * the strictest value of each setting.
+ */
+export interface TemplateGroupScheduleOverride {
+	readonly template_id: string;
+	readonly group_id: string;
+	/**
+	 * MaxTTLMillis caps the time until shutdown of workspaces, including
+	 * workspaces that would otherwise never stop. 0 means no cap.
+	 */
+	readonly max_ttl_ms: number;
+	/**
+	 * AutostartBlockedDays are days of the week on which autostart is not
+	 * allowed, in addition to the days the template does not allow.
+	 */
+	readonly autostart_blocked_days: readonly string[];
+	/**
+	 * AutostopRequirement adds days on which restarts are required to the
+	 * template's autostop requirement. A Weeks value of 0 keeps the template's
+	 * interval.
+	 */
+	readonly autostop_requirement: TemplateAutostopRequirement;
+	readonly created_at: string;
+	readonly updated_at: string;
+}
+
 // From codersdk/insights.go
 /**
  * TemplateInsightsIntervalReport is the report from the template insights
@@ -5396,6 +5697,39 @@ export interface TemplateParameterValue {
 	readonly count: number;
 }
 
+// From codersdk/prebuilds.go
+/**
+ * TemplatePrebuildsForecast describes the forecasted and actual claims of the prebuilds of
+ * each preset of a template's active version.
+ */
+export interface TemplatePrebuildsForecast {
+	readonly presets: readonly PrebuildPresetForecast[];
+}
+
+// From codersdk/prebuilds.go
+/**
+ * TemplatePrebuildsStatus describes the running prebuilt workspaces of a template.
+ */
+export interface TemplatePrebuildsStatus {
+	readonly prebuilds: readonly PrebuiltWorkspaceStatus[];
+}
+
+// From codersdk/prebuilds.go
+/**
+ * TemplatePresetReadinessChecks are the readiness checks of the prebuilt workspaces of a template
+ * preset. Presets are matched by name, so that the checks apply to all versions of the template.
+ */
+export interface TemplatePresetReadinessChecks {
+	readonly preset_name: string;
+	readonly checks: readonly PrebuildReadinessCheck[];
+	/**
+	 * TimeoutMillis is how long checks may remain pending after the agents are ready
+	 * before the prebuilt workspace is considered unhealthy and replaced.
+	 */
+	readonly timeout_ms: number;
+	readonly updated_at: string;
+}
+
 // From codersdk/templates.go
 export type TemplateRole = "admin" | "" | "use";
 
@@ -5427,6 +5761,29 @@ export interface TemplateVersion {
 	readonly has_external_agent: boolean;
 }
 
+// From codersdk/templateversions.go
+/**
+ * TemplateVersionDiff lists the changes from one template version to another.
+ * Items that did not change are omitted.
+ */
+export interface TemplateVersionDiff {
+	readonly from_id: string;
+	readonly to_id: string;
+	readonly files: readonly TemplateVersionFileDiff[];
+	readonly parameters: readonly TemplateVersionParameterDiff[];
+	readonly variables: readonly TemplateVersionItemDiff[];
+	/**
+	 * Resources are the resources declared by the Terraform plan of the
+	 * template version import, keyed by their address.
+	 */
+	readonly resources: readonly TemplateVersionItemDiff[];
+}
+
+// From codersdk/templateversions.go
+export type TemplateVersionDiffChange = "added" | "modified" | "removed";
+
+export const TemplateVersionDiffChanges: TemplateVersionDiffChange[] = ["added", "modified", "removed"];
+
 // From codersdk/templateversions.go
 export interface TemplateVersionExternalAuth {
 	readonly id: string;
@@ -5438,6 +5795,50 @@ export interface TemplateVersionExternalAuth {
 	readonly optional?: boolean;
 }
 
+// From codersdk/templateversions.go
+/**
+ * TemplateVersionFieldDiff is a field whose value differs between the
+ * template versions.
+ */
+export interface TemplateVersionFieldDiff {
+	readonly field: string;
+	readonly from: string;
+	readonly to: string;
+}
+
+// From codersdk/templateversions.go
+/**
+ * TemplateVersionFileDiff is a file of the template source archive that
+ * differs between the template versions.
+ */
+export interface TemplateVersionFileDiff {
+	readonly path: string;
+	readonly change: TemplateVersionDiffChange;
+	/**
+	 * Binary is true for files that are not text or too large to diff. Diff
+	 * is empty for those.
+	 */
+	readonly binary: boolean;
+	/**
+	 * Diff is the unified diff of the file.
+	 */
+	readonly diff: string;
+}
+
+// From codersdk/templateversions.go
+/**
+ * TemplateVersionItemDiff is a variable or resource that differs between the
+ * template versions.
+ */
+export interface TemplateVersionItemDiff {
+	readonly name: string;
+	readonly change: TemplateVersionDiffChange;
+	/**
+	 * Fields lists the changed fields of modified items.
+	 */
+	readonly fields?: readonly TemplateVersionFieldDiff[];
+}
+
 // From codersdk/templateversions.go
 /**
  * TemplateVersionParameter represents a parameter for a template version.
@@ -5466,6 +5867,27 @@ export interface TemplateVersionParameter {
 	readonly ephemeral: boolean;
 }
 
+// From codersdk/templateversions.go
+/**
+ * TemplateVersionParameterDiff is a parameter that differs between the
+ * template versions.
+ */
+export interface TemplateVersionParameterDiff {
+	readonly name: string;
+	readonly change: TemplateVersionDiffChange;
+	/**
+	 * Fields lists the changed fields of modified parameters, including
+	 * changes to options that exist in both versions.
+	 */
+	readonly fields?: readonly TemplateVersionFieldDiff[];
+	/**
+	 * OptionsAdded and OptionsRemoved list the values of options only present
+	 * in one of the versions.
+	 */
+	readonly options_added?: readonly string[];
+	readonly options_removed?: readonly string[];
+}
+
 // From codersdk/templateversions.go
 /**
  * TemplateVersionParameterOption represents a selectable option for a template parameter.
@@ -5626,6 +6048,11 @@ export interface UpdateNotificationTemplateMethod {
 	readonly method?: string;
 }
 
+// From codersdk/notifications.go
+export interface UpdateOrganizationNotificationWebhookRequest {
+	readonly endpoint: string;
+}
+
 // From codersdk/organizations.go
 export interface UpdateOrganizationRequest {
 	readonly name?: string;
@@ -5738,6 +6165,35 @@ export interface UpdateTemplateMeta {
 	 * provisioning. It is recommended not to disable this.
 	 */
 	readonly disable_module_cache?: boolean;
+	/**
+	 * SessionRecordingEnabled records interactive terminal sessions in
+	 * workspaces of this template. Sessions that are already running are not
+	 * affected.
+	 */
+	readonly session_recording_enabled?: boolean;
+	/**
+	 * SessionRecordingIncludeInput also records the input typed during a
+	 * session, which may contain secrets such as passwords.
+	 */
+	readonly session_recording_include_input?: boolean;
+	/**
+	 * MaintenanceWindowSchedule is a cron time range, e.g. "* 2-4 * * 6",
+	 * during which idle outdated workspaces are restarted onto the active
+	 * version. An empty string disables the maintenance window.
+	 */
+	readonly maintenance_window_schedule?: string;
+	/**
+	 * HibernateIdleThresholdMillis makes autostop hibernate workspaces that
+	 * have been inactive for less than this duration instead of stopping
+	 * them. 0 disables hibernation on autostop.
+	 */
+	readonly hibernate_idle_threshold_ms?: number;
+}
+
+// From codersdk/prebuilds.go
+export interface UpdateTemplatePresetReadinessChecksRequest {
+	readonly checks: readonly PrebuildReadinessCheck[];
+	readonly timeout_ms: number;
 }
 
 // From codersdk/users.go
@@ -5749,6 +6205,12 @@ export interface UpdateUserAppearanceSettingsRequest {
 // From codersdk/notifications.go
 export interface UpdateUserNotificationPreferences {
 	readonly template_disabled_map: Record<string, boolean>;
+	/**
+	 * TemplateTargetsMap routes notifications of the given templates to the given
+	 * notification targets, replacing their current routes. An empty list of
+	 * targets restores delivery by the template's method.
+	 */
+	readonly template_targets_map?: Record<string, string[]>;
 }
 
 // From codersdk/users.go
@@ -5868,6 +6330,13 @@ export interface UploadResponse {
 	readonly hash: string;
 }
 
+// From codersdk/templateschedules.go
+export interface UpsertTemplateGroupScheduleOverrideRequest {
+	readonly max_ttl_ms?: number;
+	readonly autostart_blocked_days?: readonly string[];
+	readonly autostop_requirement?: TemplateAutostopRequirement;
+}
+
 // From codersdk/workspaceagentportshare.go
 export interface UpsertWorkspaceAgentPortShareRequest {
 	readonly agent_name: string;
@@ -6058,6 +6527,33 @@ export interface UserStatusChangeCount {
 
 export const UserStatuses: UserStatus[] = ["active", "dormant", "suspended"];
 
+// From codersdk/templateschedules.go
+/**
+ * UserTemplateSchedule is the schedule policy that applies to a user's
+ * workspaces of a template once the user's group overrides are applied.
+ */
+export interface UserTemplateSchedule {
+	readonly template_id: string;
+	readonly user_id: string;
+	/**
+	 * GroupIDs are the groups of the user that have schedule overrides for
+	 * the template.
+	 */
+	readonly group_ids: readonly string[];
+	readonly allow_user_autostart: boolean;
+	readonly allow_user_autostop: boolean;
+	readonly default_ttl_ms: number;
+	readonly max_ttl_ms: number;
+	readonly autostart_requirement: TemplateAutostartRequirement;
+	readonly autostop_requirement: TemplateAutostopRequirement;
+	/**
+	 * QuietHoursSchedule is the user's quiet hours schedule, during which
+	 * restarts required by the autostop requirement happen. It is empty if
+	 * quiet hours are not enabled on the deployment.
+	 */
+	readonly quiet_hours_schedule: string;
+}
+
 // From codersdk/users.go
 export interface UsersRequest extends Pagination {
 	readonly q?: string;
@@ -6097,6 +6593,21 @@ export interface VariableValue {
 	readonly value: string;
 }
 
+// From codersdk/notifications.go
+export interface VerifyNotificationTargetRequest {
+	readonly code: string;
+}
+
+// From codersdk/notificationsignature.go
+/**
+ * WebhookSignatureHeader is the header of webhook notification requests which
+ * carries their signatures, if CODER_NOTIFICATIONS_WEBHOOK_SIGNING_SECRETS is
+ * set. Its value has the form "t=<timestamp>,v1=<signature>", with one v1
+ * signature for each secret. Each signature is the hex-encoded HMAC-SHA256 of
+ * the Unix timestamp, a dot, and the request body.
+ */
+export const WebhookSignatureHeader = "X-Coder-Signature";
+
 // From codersdk/notifications.go
 export interface WebpushMessage {
 	readonly icon: string;
@@ -6198,6 +6709,11 @@ export interface Workspace {
 	 */
 	readonly task_id?: string;
 	readonly shared_with?: readonly SharedWorkspaceActor[];
+	/**
+	 * SourceWorkspaceID, if set, is the workspace this workspace was cloned
+	 * from.
+	 */
+	readonly source_workspace_id?: string;
 }
 
 // From codersdk/workspaces.go
@@ -6533,6 +7049,8 @@ export interface WorkspaceAgentScript {
 	readonly start_blocks_login: boolean;
 	readonly timeout: number;
 	readonly display_name: string;
+	readonly unit_name?: string;
+	readonly depends_on?: readonly string[];
 }
 
 // From codersdk/workspaceagents.go
@@ -6868,6 +7386,29 @@ export interface WorkspaceResource {
 	readonly daily_cost: number;
 }
 
+// From codersdk/workspacebuilds.go
+/**
+ * WorkspaceResourceChange describes a change that a dry-run plan would make
+ * to a resource of a workspace.
+ */
+export interface WorkspaceResourceChange {
+	readonly address: string;
+	readonly type: string;
+	readonly name: string;
+	readonly action: ResourceChangeAction;
+	/**
+	 * ReplacePaths are the attribute paths which force the resource to be
+	 * replaced.
+	 */
+	readonly replace_paths: readonly string[];
+	/**
+	 * Persistent is true if the resource is kept while the workspace is
+	 * stopped, such as a home volume. Replacing or deleting a persistent
+	 * resource loses its data.
+	 */
+	readonly persistent: boolean;
+}
+
 // From codersdk/workspacebuilds.go
 /**
  * WorkspaceResourceMetadata annotates the workspace resource with custom key-value pairs.
@@ -6883,6 +7424,38 @@ export type WorkspaceRole = "admin" | "" | "use";
 
 export const WorkspaceRoles: WorkspaceRole[] = ["admin", "", "use"];
 
+// From codersdk/workspacesessionrecordings.go
+/**
+ * WorkspaceSessionRecording is a recording of an interactive terminal session
+ * in a workspace, stored in the asciicast v2 format.
+ */
+export interface WorkspaceSessionRecording {
+	readonly id: string;
+	readonly workspace_id: string;
+	readonly agent_id: string;
+	/**
+	 * ConnectionID is the ID of the connection log entry of the session.
+	 */
+	readonly connection_id: string;
+	readonly type: ConnectionType;
+	readonly started_at: string;
+	readonly ended_at: string;
+	readonly created_at: string;
+	/**
+	 * IncludeInput is true if the recording contains what the user typed.
+	 */
+	readonly include_input: boolean;
+	/**
+	 * Truncated is true if the session exceeded the maximum recording size
+	 * and its end is missing.
+	 */
+	readonly truncated: boolean;
+	/**
+	 * Size is the size of the recording in bytes.
+	 */
+	readonly size: number;
+}
+
 // From codersdk/workspacesharing.go
 /**
  * WorkspaceSharingSettings represents workspace sharing settings for an organization.
@@ -6918,10 +7491,11 @@ export const WorkspaceStatuses: WorkspaceStatus[] = [
 ];
 
 // From codersdk/workspacebuilds.go
-export type WorkspaceTransition = "delete" | "start" | "stop";
+export type WorkspaceTransition = "delete" | "hibernate" | "start" | "stop";
 
 export const WorkspaceTransitions: WorkspaceTransition[] = [
 	"delete",
+	"hibernate",
 	"start",
 	"stop",
 ];
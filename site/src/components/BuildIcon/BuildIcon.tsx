@@ -1,5 +1,5 @@
 import type { WorkspaceTransition } from "api/typesGenerated";
-import { PlayIcon, SquareIcon, TrashIcon } from "lucide-react";
+import { MoonIcon, PlayIcon, SquareIcon, TrashIcon } from "lucide-react";
 import type { ComponentProps } from "react";
 
 type SVGIcon = typeof PlayIcon;
@@ -10,6 +10,7 @@ const iconByTransition: Record<WorkspaceTransition, SVGIcon> = {
 	start: PlayIcon,
 	stop: SquareIcon,
 	delete: TrashIcon,
+	hibernate: MoonIcon,
 };
 
 export const BuildIcon = (
@@ -2,21 +2,30 @@ import type {
 	NotificationPreference,
 	NotificationTemplate,
 } from "api/typesGenerated";
-import { MailIcon, WebhookIcon } from "lucide-react";
+import {
+	MailIcon,
+	MessageSquareIcon,
+	UsersIcon,
+	WebhookIcon,
+} from "lucide-react";
 
 // TODO: This should be provided by the auto generated types from codersdk
-const notificationMethods = ["smtp", "webhook"] as const;
+const notificationMethods = ["smtp", "webhook", "slack", "teams"] as const;
 
 export type NotificationMethod = (typeof notificationMethods)[number];
 
 export const methodIcons: Record<NotificationMethod, typeof MailIcon> = {
 	smtp: MailIcon,
 	webhook: WebhookIcon,
+	slack: MessageSquareIcon,
+	teams: UsersIcon,
 };
 
 export const methodLabels: Record<NotificationMethod, string> = {
 	smtp: "SMTP",
 	webhook: "Webhook",
+	slack: "Slack",
+	teams: "Microsoft Teams",
 };
 
 export const castNotificationMethod = (value: string) => {
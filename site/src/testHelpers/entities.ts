@@ -4559,36 +4559,43 @@ export const MockNotificationPreferences: TypesGen.NotificationPreference[] = [
 		id: "f44d9314-ad03-4bc8-95d0-5cad491da6b6",
 		disabled: false,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 	{
 		id: "381df2a9-c0c0-4749-420f-80a9280c66f9",
 		disabled: true,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 	{
 		id: "f517da0b-cdc9-410f-ab89-a86107c420ed",
 		disabled: false,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 	{
 		id: "c34a0c09-0704-4cac-bd1c-0c0146811c2b",
 		disabled: false,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 	{
 		id: "0ea69165-ec14-4314-91f1-69566ac3c5a0",
 		disabled: false,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 	{
 		id: "51ce2fdf-c9ca-4be1-8d70-628674f9bc42",
 		disabled: false,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 	{
 		id: "4e19c0ac-94e1-4532-9515-d1801aa283b2",
 		disabled: true,
 		updated_at: "2024-08-06T11:58:37.755053Z",
+		target_ids: [],
 	},
 ];
 
@@ -8,6 +8,7 @@ import utc from "dayjs/plugin/utc";
 import {
 	CircleAlertIcon,
 	HourglassIcon,
+	MoonIcon,
 	PlayIcon,
 	SquareIcon,
 } from "lucide-react";
@@ -94,6 +95,7 @@ export const getDisplayWorkspaceBuildInitiatedBy = (
 		case "autostop":
 		case "dormancy":
 		case "task_auto_pause":
+		case "maintenance":
 			return "Coder";
 	}
 	return undefined;
@@ -103,6 +105,7 @@ export const systemBuildReasons = [
 	"autostart",
 	"autostop",
 	"dormancy",
+	"maintenance",
 	"task_auto_pause",
 	"task_manual_pause",
 	"task_resume",
@@ -121,6 +124,7 @@ export const buildReasonLabels: Record<TypesGen.BuildReason, string> = {
 	autostart: "Autostart",
 	autostop: "Autostop",
 	dormancy: "Dormancy",
+	maintenance: "Maintenance",
 	task_auto_pause: "Task Auto-Pause",
 	task_manual_pause: "Task Manual Pause",
 	task_resume: "Task Resume",
@@ -224,7 +228,12 @@ type DisplayWorkspaceStatus = {
 export const getDisplayWorkspaceStatus = (
 	workspaceStatus: TypesGen.WorkspaceStatus,
 	provisionerJob?: TypesGen.ProvisionerJob,
+	transition?: TypesGen.WorkspaceTransition,
 ): DisplayWorkspaceStatus => {
+	// Hibernated workspaces are reported as stopped, but are displayed as
+	// hibernated since their persistent resources are retained.
+	const hibernate = transition === "hibernate";
+
 	switch (workspaceStatus) {
 		case undefined:
 			return {
@@ -247,14 +256,14 @@ export const getDisplayWorkspaceStatus = (
 		case "stopping":
 			return {
 				type: "inactive",
-				text: "Stopping",
+				text: hibernate ? "Hibernating" : "Stopping",
 				icon: <PillSpinner />,
 			} as const;
 		case "stopped":
 			return {
 				type: "inactive",
-				text: "Stopped",
-				icon: <SquareIcon />,
+				text: hibernate ? "Hibernated" : "Stopped",
+				icon: hibernate ? <MoonIcon /> : <SquareIcon />,
 			} as const;
 		case "deleting":
 			return {
@@ -60,3 +60,13 @@ type DRPCTailnetClient27 interface {
 type DRPCTailnetClient28 interface {
 	DRPCTailnetClient27
 }
+
+// DRPCTailnetClient29 is the Tailnet API at v2.9.
+type DRPCTailnetClient29 interface {
+	DRPCTailnetClient28
+}
+
+// DRPCTailnetClient210 is the Tailnet API at v2.10.
+type DRPCTailnetClient210 interface {
+	DRPCTailnetClient29
+}
@@ -63,9 +63,18 @@ import (
 //
 // API v2.8:
 //   - Added support for pre-created sub agents on the Agent API.
+//
+// API v2.9:
+//   - Added `UnitName` and `DependsOn` to `WorkspaceAgentScript` in the agent
+//     manifest.
+//
+// API v2.10:
+//   - Added `SessionRecordingEnabled` and `SessionRecordingIncludeInput` to the
+//     agent manifest.
+//   - Added support for UploadSessionRecording RPC on the Agent API.
 const (
 	CurrentMajor = 2
-	CurrentMinor = 8
+	CurrentMinor = 10
 )
 
 var CurrentVersion = apiversion.New(CurrentMajor, CurrentMinor)